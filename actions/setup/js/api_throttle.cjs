@@ -0,0 +1,145 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * API throttling for safe output write operations
+ *
+ * Installs an Octokit request hook that:
+ * - waits GH_AW_SAFE_OUTPUTS_THROTTLE_MS between successive write API calls (opt-in)
+ * - retries requests rejected by GitHub rate limits (429, or 403 rate limit responses)
+ *   with exponential backoff, honoring the retry-after header when present (always on)
+ */
+
+const { sleep } = require("./error_recovery.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/**
+ * Default backoff configuration for rate-limited requests
+ */
+const RATE_LIMIT_BACKOFF = {
+  maxRetries: 3,
+  initialDelayMs: 2000,
+  maxDelayMs: 60000,
+  backoffMultiplier: 2,
+};
+
+/**
+ * Read the delay between successive write API calls
+ * Reads the delay from GH_AW_SAFE_OUTPUTS_THROTTLE_MS environment variable
+ * @returns {number} Delay in milliseconds, or 0 when throttling is disabled
+ */
+function getThrottleMs() {
+  const value = parseInt(process.env.GH_AW_SAFE_OUTPUTS_THROTTLE_MS || "", 10);
+  return Number.isInteger(value) && value > 0 ? value : 0;
+}
+
+/**
+ * Determine if a request writes to GitHub
+ * GraphQL requests only count as writes when they contain a mutation.
+ * @param {any} options - Octokit request options
+ * @returns {boolean}
+ */
+function isWriteRequest(options) {
+  const method = String(options?.method || "GET").toUpperCase();
+  if (method === "GET" || method === "HEAD") {
+    return false;
+  }
+  if (String(options?.url || "").endsWith("/graphql")) {
+    return /^\s*mutation\b/.test(String(options?.query || ""));
+  }
+  return true;
+}
+
+/**
+ * Determine if an error is a GitHub rate limit rejection
+ * @param {any} error - The error thrown by Octokit
+ * @returns {boolean}
+ */
+function isRateLimitError(error) {
+  const status = error?.status;
+  if (status === 429) {
+    return true;
+  }
+  if (status !== 403) {
+    return false;
+  }
+  const headers = error?.response?.headers || {};
+  if (headers["retry-after"] !== undefined || headers["x-ratelimit-remaining"] === "0") {
+    return true;
+  }
+  const message = getErrorMessage(error).toLowerCase();
+  return message.includes("rate limit") || message.includes("abuse detection");
+}
+
+/**
+ * Compute the delay before retrying a rate-limited request
+ * @param {any} error - The rate limit error
+ * @param {number} attempt - Zero-based retry attempt
+ * @returns {number} Delay in milliseconds
+ */
+function getRateLimitDelayMs(error, attempt) {
+  const retryAfter = parseInt(String(error?.response?.headers?.["retry-after"] ?? ""), 10);
+  if (Number.isInteger(retryAfter) && retryAfter > 0) {
+    return Math.min(retryAfter * 1000, RATE_LIMIT_BACKOFF.maxDelayMs);
+  }
+  return Math.min(RATE_LIMIT_BACKOFF.initialDelayMs * Math.pow(RATE_LIMIT_BACKOFF.backoffMultiplier, attempt), RATE_LIMIT_BACKOFF.maxDelayMs);
+}
+
+/**
+ * Install the throttling and rate limit backoff hook on an Octokit client
+ * @param {any} client - Octokit client (e.g., the github-script `github` global)
+ * @param {{throttleMs?: number, sleepFn?: (ms: number) => Promise<void>}} [options]
+ * @returns {boolean} True when the hook was installed
+ */
+function installApiThrottle(client, options = {}) {
+  if (!client?.hook || typeof client.hook.wrap !== "function") {
+    core.debug("GitHub client does not support request hooks - API throttling disabled");
+    return false;
+  }
+
+  const throttleMs = options.throttleMs ?? getThrottleMs();
+  const sleepFn = options.sleepFn ?? sleep;
+  let lastWriteAt = 0;
+
+  if (throttleMs > 0) {
+    core.info(`Throttling safe output write API calls: ${throttleMs}ms between calls`);
+  }
+
+  client.hook.wrap("request", async (request, requestOptions) => {
+    const isWrite = isWriteRequest(requestOptions);
+
+    for (let attempt = 0; ; attempt++) {
+      if (isWrite && throttleMs > 0 && lastWriteAt > 0) {
+        const waitMs = lastWriteAt + throttleMs - Date.now();
+        if (waitMs > 0) {
+          await sleepFn(waitMs);
+        }
+      }
+
+      try {
+        return await request(requestOptions);
+      } catch (error) {
+        if (!isRateLimitError(error) || attempt >= RATE_LIMIT_BACKOFF.maxRetries) {
+          throw error;
+        }
+        const delayMs = getRateLimitDelayMs(error, attempt);
+        core.warning(`GitHub API rate limit hit for ${requestOptions.method} ${requestOptions.url}; retrying in ${delayMs}ms (attempt ${attempt + 1}/${RATE_LIMIT_BACKOFF.maxRetries})`);
+        await sleepFn(delayMs);
+      } finally {
+        if (isWrite) {
+          lastWriteAt = Date.now();
+        }
+      }
+    }
+  });
+
+  return true;
+}
+
+module.exports = {
+  getThrottleMs,
+  isWriteRequest,
+  isRateLimitError,
+  getRateLimitDelayMs,
+  installApiThrottle,
+};
@@ -0,0 +1,163 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import * as throttle from "./api_throttle.cjs";
+
+describe("api_throttle.cjs", () => {
+  let mockCore;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      debug: vi.fn(),
+      warning: vi.fn(),
+    };
+    global.core = mockCore;
+  });
+
+  afterEach(() => {
+    delete global.core;
+    delete process.env.GH_AW_SAFE_OUTPUTS_THROTTLE_MS;
+  });
+
+  /**
+   * Build a fake Octokit client whose hook.wrap stores the wrapper
+   * @param {(options: any) => Promise<any>} request
+   */
+  const createClient = request => {
+    const client = { hook: { wrap: vi.fn() }, request: undefined };
+    client.hook.wrap.mockImplementation((name, wrapper) => {
+      client.request = options => wrapper(request, options);
+    });
+    return client;
+  };
+
+  const rateLimitError = (status, headers = {}, message = "API rate limit exceeded") => Object.assign(new Error(message), { status, response: { headers } });
+
+  describe("getThrottleMs", () => {
+    it("should be disabled by default", () => {
+      expect(throttle.getThrottleMs()).toBe(0);
+    });
+
+    it("should read the delay from the environment", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_THROTTLE_MS = "250";
+      expect(throttle.getThrottleMs()).toBe(250);
+    });
+
+    it("should ignore invalid values", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_THROTTLE_MS = "-5";
+      expect(throttle.getThrottleMs()).toBe(0);
+    });
+  });
+
+  describe("isWriteRequest", () => {
+    it("should treat reads as non-writes", () => {
+      expect(throttle.isWriteRequest({ method: "GET", url: "/repos/{owner}/{repo}/issues" })).toBe(false);
+    });
+
+    it("should treat REST mutations as writes", () => {
+      expect(throttle.isWriteRequest({ method: "POST", url: "/repos/{owner}/{repo}/issues/{issue_number}/comments" })).toBe(true);
+      expect(throttle.isWriteRequest({ method: "DELETE", url: "/repos/{owner}/{repo}/issues/{issue_number}/labels/{name}" })).toBe(true);
+    });
+
+    it("should only treat GraphQL mutations as writes", () => {
+      expect(throttle.isWriteRequest({ method: "POST", url: "/graphql", query: "query { viewer { login } }" })).toBe(false);
+      expect(throttle.isWriteRequest({ method: "POST", url: "/graphql", query: "mutation { addComment { clientMutationId } }" })).toBe(true);
+    });
+  });
+
+  describe("isRateLimitError", () => {
+    it("should detect 429 responses", () => {
+      expect(throttle.isRateLimitError(rateLimitError(429))).toBe(true);
+    });
+
+    it("should detect secondary rate limit 403 responses", () => {
+      expect(throttle.isRateLimitError(rateLimitError(403, {}, "You have exceeded a secondary rate limit"))).toBe(true);
+      expect(throttle.isRateLimitError(rateLimitError(403, { "retry-after": "1" }, "Forbidden"))).toBe(true);
+    });
+
+    it("should not retry permission errors", () => {
+      expect(throttle.isRateLimitError(rateLimitError(403, {}, "Resource not accessible by integration"))).toBe(false);
+      expect(throttle.isRateLimitError(rateLimitError(404, {}, "Not Found"))).toBe(false);
+    });
+  });
+
+  describe("getRateLimitDelayMs", () => {
+    it("should honor the retry-after header", () => {
+      expect(throttle.getRateLimitDelayMs(rateLimitError(429, { "retry-after": "3" }), 0)).toBe(3000);
+    });
+
+    it("should back off exponentially", () => {
+      const error = rateLimitError(429);
+      expect(throttle.getRateLimitDelayMs(error, 0)).toBe(2000);
+      expect(throttle.getRateLimitDelayMs(error, 1)).toBe(4000);
+      expect(throttle.getRateLimitDelayMs(error, 2)).toBe(8000);
+    });
+  });
+
+  describe("installApiThrottle", () => {
+    it("should skip clients without request hooks", () => {
+      expect(throttle.installApiThrottle({})).toBe(false);
+    });
+
+    it("should retry rate-limited requests with backoff", async () => {
+      const request = vi.fn().mockRejectedValueOnce(rateLimitError(429)).mockResolvedValueOnce({ status: 201 });
+      const sleepFn = vi.fn().mockResolvedValue(undefined);
+      const client = createClient(request);
+
+      expect(throttle.installApiThrottle(client, { sleepFn })).toBe(true);
+      const response = await client.request({ method: "POST", url: "/repos/{owner}/{repo}/issues" });
+
+      expect(response).toEqual({ status: 201 });
+      expect(request).toHaveBeenCalledTimes(2);
+      expect(sleepFn).toHaveBeenCalledWith(2000);
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("rate limit"));
+    });
+
+    it("should give up after the maximum number of retries", async () => {
+      const request = vi.fn().mockRejectedValue(rateLimitError(429));
+      const sleepFn = vi.fn().mockResolvedValue(undefined);
+      const client = createClient(request);
+      throttle.installApiThrottle(client, { sleepFn });
+
+      await expect(client.request({ method: "POST", url: "/repos/{owner}/{repo}/issues" })).rejects.toThrow("API rate limit exceeded");
+      expect(request).toHaveBeenCalledTimes(4);
+    });
+
+    it("should not retry other errors", async () => {
+      const request = vi.fn().mockRejectedValue(rateLimitError(404, {}, "Not Found"));
+      const sleepFn = vi.fn();
+      const client = createClient(request);
+      throttle.installApiThrottle(client, { sleepFn });
+
+      await expect(client.request({ method: "POST", url: "/repos/{owner}/{repo}/issues" })).rejects.toThrow("Not Found");
+      expect(request).toHaveBeenCalledTimes(1);
+      expect(sleepFn).not.toHaveBeenCalled();
+    });
+
+    it("should delay successive write calls when throttling is enabled", async () => {
+      const request = vi.fn().mockResolvedValue({ status: 200 });
+      const sleepFn = vi.fn().mockResolvedValue(undefined);
+      const client = createClient(request);
+      throttle.installApiThrottle(client, { throttleMs: 500, sleepFn });
+
+      await client.request({ method: "POST", url: "/repos/{owner}/{repo}/issues/1/comments" });
+      await client.request({ method: "GET", url: "/repos/{owner}/{repo}/issues/1" });
+      await client.request({ method: "POST", url: "/repos/{owner}/{repo}/issues/1/labels" });
+
+      expect(sleepFn).toHaveBeenCalledTimes(1);
+      expect(sleepFn.mock.calls[0][0]).toBeGreaterThan(0);
+      expect(sleepFn.mock.calls[0][0]).toBeLessThanOrEqual(500);
+    });
+
+    it("should not delay write calls when throttling is disabled", async () => {
+      const request = vi.fn().mockResolvedValue({ status: 200 });
+      const sleepFn = vi.fn();
+      const client = createClient(request);
+      throttle.installApiThrottle(client, { sleepFn });
+
+      await client.request({ method: "POST", url: "/repos/{owner}/{repo}/issues/1/comments" });
+      await client.request({ method: "POST", url: "/repos/{owner}/{repo}/issues/1/comments" });
+
+      expect(sleepFn).not.toHaveBeenCalled();
+    });
+  });
+});
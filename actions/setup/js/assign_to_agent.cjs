@@ -10,6 +10,7 @@ const { loadTemporaryIdMap, resolveRepoIssueTarget } = require("./temporary_id.c
 const { sleep } = require("./error_recovery.cjs");
 const { parseAllowedRepos, validateRepo, resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
 const { resolvePullRequestRepo } = require("./pr_helpers.cjs");
+const { limitToMaxTotal, recordMaxTotalUsage, failMaxTotalExceeded } = require("./max_total_budget.cjs");
 
 async function main() {
   const result = loadAgentOutput();
@@ -123,11 +124,18 @@ async function main() {
   core.info(`Max count: ${maxCount}`);
 
   // Limit items to max count
-  const itemsToProcess = assignItems.slice(0, maxCount);
   if (assignItems.length > maxCount) {
     core.warning(`Found ${assignItems.length} agent assignments, but max is ${maxCount}. Processing first ${maxCount}.`);
   }
 
+  // Limit to the budget left by earlier safe output steps under the global max-total cap
+  const { items: itemsToProcess, exceeded: maxTotalExceeded } = limitToMaxTotal(assignItems.slice(0, maxCount), "assign_to_agent");
+  recordMaxTotalUsage(itemsToProcess.length);
+  if (itemsToProcess.length === 0) {
+    failMaxTotalExceeded(maxTotalExceeded, "assign_to_agent");
+    return;
+  }
+
   // Get default target repository and allowed repos using standardized helpers
   const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig({
     allowed_repos: process.env.GH_AW_ALLOWED_REPOS,
@@ -607,6 +615,10 @@ async function main() {
   if (failureCount > 0) {
     core.warning(`Failed to assign ${failureCount} agent(s) - errors will be reported in conclusion job`);
   }
+
+  if (maxTotalExceeded > 0) {
+    failMaxTotalExceeded(maxTotalExceeded, "assign_to_agent");
+  }
 }
 
 module.exports = { main };
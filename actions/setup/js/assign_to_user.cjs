@@ -15,6 +15,47 @@ const { parseBoolTemplatable } = require("./templatable.cjs");
 /** @type {string} Safe output type handled by this module */
 const HANDLER_TYPE = "assign_to_user";
 
+/**
+ * Get the client used to read team membership.
+ * A token configured on assign-to-user itself is passed as GH_AW_ASSIGN_TO_USER_GITHUB_TOKEN
+ * and takes precedence over the client of the handler manager step.
+ * @returns {Promise<any>} Octokit client
+ */
+async function getTeamsClient() {
+  const token = process.env.GH_AW_ASSIGN_TO_USER_GITHUB_TOKEN;
+  if (!token) {
+    return github;
+  }
+  core.info("Reading team membership with the assign-to-user github-token");
+  const { getOctokit } = await import("@actions/github");
+  return getOctokit(token);
+}
+
+/**
+ * Expand "org/team-slug" entries into the logins of the team members
+ * @param {string[]} allowedUsers - Literal usernames from the allowlist
+ * @param {string[]} allowedTeams - Team references in "org/team-slug" form
+ * @returns {Promise<string[]>} Deduplicated allowlist of users and team members
+ */
+async function expandAllowedTeams(allowedUsers, allowedTeams) {
+  const allowed = new Set(allowedUsers);
+  const client = await getTeamsClient();
+  for (const team of allowedTeams) {
+    const [org, teamSlug] = team.split("/");
+    const members = await client.paginate(client.rest.teams.listMembersInOrg, {
+      org,
+      team_slug: teamSlug,
+      per_page: 100,
+    });
+    const logins = members.map(member => member.login).filter(Boolean);
+    core.info(`Resolved ${logins.length} member(s) of @${team}`);
+    for (const login of logins) {
+      allowed.add(login);
+    }
+  }
+  return Array.from(allowed);
+}
+
 /**
  * Main handler factory for assign_to_user
  * Returns a message handler function that processes individual assign_to_user messages
@@ -23,6 +64,7 @@ const HANDLER_TYPE = "assign_to_user";
 async function main(config = {}) {
   // Extract configuration
   const allowedAssignees = config.allowed || [];
+  const allowedTeams = config.allowed_teams || [];
   const blockedAssignees = config.blocked || [];
   const maxCount = config.max || 10;
   const unassignFirst = parseBoolTemplatable(config.unassign_first, false);
@@ -35,6 +77,9 @@ async function main(config = {}) {
   if (allowedAssignees.length > 0) {
     core.info(`Allowed assignees: ${allowedAssignees.join(", ")}`);
   }
+  if (allowedTeams.length > 0) {
+    core.info(`Allowed teams: ${allowedTeams.map(team => `@${team}`).join(", ")}`);
+  }
   if (blockedAssignees.length > 0) {
     core.info(`Blocked assignees: ${blockedAssignees.join(", ")}`);
   }
@@ -46,6 +91,16 @@ async function main(config = {}) {
   // Track how many items we've processed for max limit
   let processedCount = 0;
 
+  // Team members are resolved once, on the first message that needs them
+  /** @type {Promise<string[]> | null} */
+  let effectiveAllowedPromise = null;
+  const getEffectiveAllowed = () => {
+    if (!effectiveAllowedPromise) {
+      effectiveAllowedPromise = expandAllowedTeams(allowedAssignees, allowedTeams);
+    }
+    return effectiveAllowedPromise;
+  };
+
   /**
    * Message handler function that processes a single assign_to_user message
    * @param {Object} message - The assign_to_user message to process
@@ -94,8 +149,34 @@ async function main(config = {}) {
 
     core.info(`Requested assignees: ${JSON.stringify(requestedAssignees)}`);
 
+    // Expand team entries so the allowlist covers literal users plus current team members
+    let effectiveAllowed = allowedAssignees;
+    if (allowedTeams.length > 0) {
+      try {
+        effectiveAllowed = await getEffectiveAllowed();
+      } catch (error) {
+        const errorMessage = `Failed to resolve allowed teams: ${getErrorMessage(error)}`;
+        core.error(errorMessage);
+        return {
+          success: false,
+          error: errorMessage,
+        };
+      }
+    }
+
+    // An allowlist made only of teams with no members must not fall back to allowing anyone
+    if (allowedTeams.length > 0 && effectiveAllowed.length === 0) {
+      core.warning("Allowed teams have no members; no assignees can be added");
+      return {
+        success: true,
+        issueNumber: issueNumber,
+        assigneesAdded: [],
+        message: "No valid assignees found",
+      };
+    }
+
     // Use shared helper to filter, sanitize, dedupe, and limit
-    const uniqueAssignees = processItems(requestedAssignees, allowedAssignees, maxCount, blockedAssignees);
+    const uniqueAssignees = processItems(requestedAssignees, effectiveAllowed, maxCount, blockedAssignees);
 
     if (uniqueAssignees.length === 0) {
       core.info("No assignees to add");
@@ -178,4 +259,4 @@ async function main(config = {}) {
   };
 }
 
-module.exports = { main };
+module.exports = { main, expandAllowedTeams };
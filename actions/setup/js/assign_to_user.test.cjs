@@ -1,4 +1,13 @@
-import { describe, it, expect, beforeEach, vi } from "vitest";
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+const mockTokenClient = {
+  paginate: vi.fn(),
+  rest: { teams: { listMembersInOrg: vi.fn() } },
+};
+
+vi.mock("@actions/github", () => ({
+  getOctokit: vi.fn(() => mockTokenClient),
+}));
 
 const mockCore = {
   debug: vi.fn(),
@@ -585,4 +594,102 @@ describe("assign_to_user (Handler Factory Architecture)", () => {
       expect(mockGithub.rest.issues.addAssignees).not.toHaveBeenCalled();
     });
   });
+
+  describe("allowed teams", () => {
+    beforeEach(() => {
+      mockGithub.rest.teams = { listMembersInOrg: vi.fn() };
+      mockGithub.paginate = vi.fn();
+    });
+
+    it("should allow members of allowed teams alongside literal users", async () => {
+      mockGithub.paginate.mockResolvedValue([{ login: "team-member" }, { login: "other-member" }]);
+      mockGithub.rest.issues.addAssignees.mockResolvedValue({});
+
+      const { main } = require("./assign_to_user.cjs");
+      const handler = await main({
+        max: 10,
+        allowed: ["user1"],
+        allowed_teams: ["my-org/triage"],
+      });
+
+      const result = await handler({ type: "assign_to_user", assignees: ["user1", "team-member", "outsider"] }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.assigneesAdded).toEqual(["user1", "team-member"]);
+      expect(mockGithub.paginate).toHaveBeenCalledWith(mockGithub.rest.teams.listMembersInOrg, {
+        org: "my-org",
+        team_slug: "triage",
+        per_page: 100,
+      });
+    });
+
+    it("should resolve team members only once across messages", async () => {
+      mockGithub.paginate.mockResolvedValue([{ login: "team-member" }]);
+      mockGithub.rest.issues.addAssignees.mockResolvedValue({});
+
+      const { main } = require("./assign_to_user.cjs");
+      const handler = await main({ max: 10, allowed_teams: ["my-org/triage"] });
+
+      await handler({ type: "assign_to_user", assignees: ["team-member"] }, {});
+      await handler({ type: "assign_to_user", assignees: ["team-member"] }, {});
+
+      expect(mockGithub.paginate).toHaveBeenCalledTimes(1);
+    });
+
+    it("should not allow anyone when allowed teams have no members", async () => {
+      mockGithub.paginate.mockResolvedValue([]);
+
+      const { main } = require("./assign_to_user.cjs");
+      const handler = await main({ max: 10, allowed_teams: ["my-org/empty"] });
+
+      const result = await handler({ type: "assign_to_user", assignees: ["anyone"] }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.assigneesAdded).toEqual([]);
+      expect(mockGithub.rest.issues.addAssignees).not.toHaveBeenCalled();
+    });
+
+    it("should fail when team members cannot be resolved", async () => {
+      mockGithub.paginate.mockRejectedValue(new Error("Resource not accessible by integration"));
+
+      const { main } = require("./assign_to_user.cjs");
+      const handler = await main({ max: 10, allowed: ["user1"], allowed_teams: ["my-org/triage"] });
+
+      const result = await handler({ type: "assign_to_user", assignees: ["user1"] }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("Failed to resolve allowed teams");
+      expect(mockGithub.rest.issues.addAssignees).not.toHaveBeenCalled();
+    });
+
+    describe("with an assign-to-user github-token", () => {
+      beforeEach(() => {
+        process.env.GH_AW_ASSIGN_TO_USER_GITHUB_TOKEN = "per-output-token";
+        mockTokenClient.paginate.mockReset();
+      });
+
+      afterEach(() => {
+        delete process.env.GH_AW_ASSIGN_TO_USER_GITHUB_TOKEN;
+      });
+
+      it("should read team membership with the configured token", async () => {
+        mockTokenClient.paginate.mockResolvedValue([{ login: "team-member" }]);
+        mockGithub.rest.issues.addAssignees.mockResolvedValue({});
+
+        const { main } = require("./assign_to_user.cjs");
+        const handler = await main({ max: 10, allowed_teams: ["my-org/triage"] });
+
+        const result = await handler({ type: "assign_to_user", assignees: ["team-member"] }, {});
+
+        expect(result.success).toBe(true);
+        expect(result.assigneesAdded).toEqual(["team-member"]);
+        expect(mockTokenClient.paginate).toHaveBeenCalledWith(mockTokenClient.rest.teams.listMembersInOrg, {
+          org: "my-org",
+          team_slug: "triage",
+          per_page: 100,
+        });
+        expect(mockGithub.paginate).not.toHaveBeenCalled();
+      });
+    });
+  });
 });
@@ -0,0 +1,75 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { globPatternToRegex } = require("./glob_pattern_helpers.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_CONFIG } = require("./error_codes.cjs");
+
+/**
+ * Lists the files changed by the triggering push or pull request
+ * @returns {Promise<string[] | null>} Changed file paths, or null when the event has no change set
+ */
+async function getChangedFiles() {
+  const { eventName, payload } = context;
+
+  if ((eventName === "pull_request" || eventName === "pull_request_target") && payload.pull_request) {
+    const files = await github.paginate(github.rest.pulls.listFiles, {
+      owner: context.repo.owner,
+      repo: context.repo.repo,
+      pull_number: payload.pull_request.number,
+      per_page: 100,
+    });
+    return files.map(file => file.filename);
+  }
+
+  if (eventName === "push" && Array.isArray(payload.commits)) {
+    const changed = new Set();
+    for (const commit of payload.commits) {
+      for (const file of [...(commit.added || []), ...(commit.modified || []), ...(commit.removed || [])]) {
+        changed.add(file);
+      }
+    }
+    return [...changed];
+  }
+
+  return null;
+}
+
+/**
+ * Check the changed files of the triggering push or pull request against the path globs of the
+ * workflow condition. GH_AW_CHANGED_FILES_PATTERNS is a JSON array of glob groups; every group
+ * must match at least one changed file for the workflow to proceed. Events without a change set
+ * (e.g. workflow_dispatch) are not filtered, matching the behavior of on.<event>.paths.
+ */
+async function main() {
+  let groups;
+  try {
+    groups = JSON.parse(process.env.GH_AW_CHANGED_FILES_PATTERNS || "[]");
+  } catch (error) {
+    core.setFailed(`${ERR_CONFIG}: Failed to parse GH_AW_CHANGED_FILES_PATTERNS: ${getErrorMessage(error)}`);
+    return;
+  }
+
+  const changedFiles = await getChangedFiles();
+  if (changedFiles === null) {
+    core.info(`✅ Event '${context.eventName}' has no changed files; path conditions do not apply`);
+    core.setOutput("changed_files_ok", "true");
+    return;
+  }
+  core.info(`Found ${changedFiles.length} changed file(s)`);
+
+  for (const group of groups) {
+    const regexes = group.map(pattern => globPatternToRegex(pattern));
+    const match = changedFiles.find(file => regexes.some(regex => regex.test(file)));
+    if (!match) {
+      core.info(`❌ No changed file matches ${group.join(" or ")}. Workflow will be skipped.`);
+      core.setOutput("changed_files_ok", "false");
+      return;
+    }
+    core.info(`✅ ${match} matches ${group.join(" or ")}`);
+  }
+
+  core.setOutput("changed_files_ok", "true");
+}
+
+module.exports = { main };
@@ -0,0 +1,96 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+describe("check_changed_files.cjs", () => {
+  let mockCore;
+  let mockContext;
+  let mockGithub;
+
+  beforeEach(() => {
+    // Mock core actions methods
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn(),
+    };
+
+    mockContext = {
+      eventName: "push",
+      payload: {
+        commits: [
+          { added: ["docs/intro.md"], modified: [], removed: [] },
+          { added: [], modified: ["src/main.go"], removed: ["old.txt"] },
+        ],
+      },
+      repo: {
+        owner: "test-owner",
+        repo: "test-repo",
+      },
+    };
+
+    mockGithub = {
+      paginate: vi.fn(),
+      rest: { pulls: { listFiles: vi.fn() } },
+    };
+
+    // Set up global mocks
+    global.core = mockCore;
+    global.context = mockContext;
+    global.github = mockGithub;
+
+    delete process.env.GH_AW_CHANGED_FILES_PATTERNS;
+
+    // Clear module cache to ensure fresh import
+    vi.resetModules();
+  });
+
+  afterEach(() => {
+    vi.clearAllMocks();
+    delete global.core;
+    delete global.context;
+    delete global.github;
+  });
+
+  it("should proceed when every group matches a pushed file", async () => {
+    process.env.GH_AW_CHANGED_FILES_PATTERNS = JSON.stringify([["src/**", "lib/**"], ["docs/*.md"]]);
+
+    const { main } = await import("./check_changed_files.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("changed_files_ok", "true");
+  });
+
+  it("should skip when a group matches no changed file", async () => {
+    process.env.GH_AW_CHANGED_FILES_PATTERNS = JSON.stringify([["src/**"], ["*.yml"]]);
+
+    const { main } = await import("./check_changed_files.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("changed_files_ok", "false");
+  });
+
+  it("should list pull request files through the API", async () => {
+    process.env.GH_AW_CHANGED_FILES_PATTERNS = JSON.stringify([["pkg/**"]]);
+    mockContext.eventName = "pull_request";
+    mockContext.payload = { pull_request: { number: 42 } };
+    mockGithub.paginate.mockResolvedValue([{ filename: "pkg/workflow/condition.go" }]);
+
+    const { main } = await import("./check_changed_files.cjs");
+    await main();
+
+    expect(mockGithub.paginate).toHaveBeenCalledWith(mockGithub.rest.pulls.listFiles, expect.objectContaining({ pull_number: 42 }));
+    expect(mockCore.setOutput).toHaveBeenCalledWith("changed_files_ok", "true");
+  });
+
+  it("should not filter events without changed files", async () => {
+    process.env.GH_AW_CHANGED_FILES_PATTERNS = JSON.stringify([["src/**"]]);
+    mockContext.eventName = "workflow_dispatch";
+    mockContext.payload = {};
+
+    const { main } = await import("./check_changed_files.cjs");
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("changed_files_ok", "true");
+  });
+});
@@ -83,12 +83,13 @@ async function main(config = {}) {
   const requiredTitlePrefix = config.required_title_prefix || "";
   const maxCount = config.max || 10;
   const comment = config.comment || "";
+  const requireComment = config.require_comment !== false;
   const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
 
   // Check if we're in staged mode
   const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
 
-  core.info(`Close issue configuration: max=${maxCount}`);
+  core.info(`Close issue configuration: max=${maxCount}, require_comment=${requireComment}`);
   if (requiredLabels.length > 0) {
     core.info(`Required labels: ${requiredLabels.join(", ")}`);
   }
@@ -135,9 +136,9 @@ async function main(config = {}) {
 
     // Determine comment body - prefer non-empty item.body over non-empty config.comment
     /** @type {string} */
-    let commentToPost;
+    let commentToPost = "";
     /** @type {string} */
-    let commentSource = "unknown";
+    let commentSource = "none";
 
     if (typeof item.body === "string" && item.body.trim() !== "") {
       commentToPost = item.body;
@@ -145,7 +146,7 @@ async function main(config = {}) {
     } else if (typeof comment === "string" && comment.trim() !== "") {
       commentToPost = comment;
       commentSource = "config.comment";
-    } else {
+    } else if (requireComment) {
       core.warning("No comment body provided in message and no default comment configured");
       return {
         success: false,
@@ -153,10 +154,13 @@ async function main(config = {}) {
       };
     }
 
-    core.info(`Comment body determined: length=${commentToPost.length}, source=${commentSource}`);
-
-    // Sanitize content to prevent injection attacks
-    commentToPost = sanitizeContent(commentToPost);
+    if (commentToPost) {
+      core.info(`Comment body determined: length=${commentToPost.length}, source=${commentSource}`);
+      // Sanitize content to prevent injection attacks
+      commentToPost = sanitizeContent(commentToPost);
+    } else {
+      core.info("No comment body provided, issue will be closed without a comment");
+    }
 
     // Resolve and validate target repository
     const repoResult = resolveAndValidateRepo(item, defaultTargetRepo, allowedRepos, "issue");
@@ -200,10 +204,17 @@ async function main(config = {}) {
       const issue = await getIssueDetails(github, repoParts.owner, repoParts.repo, issueNumber);
       core.info(`Issue #${issueNumber} fetched: state=${issue.state}, title="${issue.title}", labels=[${issue.labels.map(l => l.name || l).join(", ")}]`);
 
-      // Check if already closed - but still add comment
-      const wasAlreadyClosed = issue.state === "closed";
-      if (wasAlreadyClosed) {
-        core.info(`Issue #${issueNumber} is already closed, but will still add comment`);
+      // Skip issues that are already closed so repeated runs do not comment or close twice
+      if (issue.state === "closed") {
+        core.info(`Issue #${issueNumber} is already closed, skipping`);
+        return {
+          success: true,
+          skipped: true,
+          number: issueNumber,
+          url: issue.html_url,
+          title: issue.title,
+          alreadyClosed: true,
+        };
       }
 
       // Validate required labels if configured
@@ -241,29 +252,23 @@ async function main(config = {}) {
           previewInfo: {
             number: issueNumber,
             repo: itemRepo,
-            alreadyClosed: wasAlreadyClosed,
             hasComment: !!commentToPost,
           },
         };
       }
 
       // Add comment with the body from the message
-      core.info(`Adding comment to issue #${issueNumber}: length=${commentToPost.length}`);
-      const commentResult = await addIssueComment(github, repoParts.owner, repoParts.repo, issueNumber, commentToPost);
-      core.info(`✓ Comment posted to issue #${issueNumber}: ${commentResult.html_url}`);
-      core.info(`Comment details: id=${commentResult.id}, body_length=${commentToPost.length}`);
-
-      // Close the issue if not already closed
-      let closedIssue;
-      if (wasAlreadyClosed) {
-        core.info(`Issue #${issueNumber} was already closed, comment added successfully`);
-        closedIssue = issue;
-      } else {
-        core.info(`Closing issue #${issueNumber} in ${itemRepo}`);
-        closedIssue = await closeIssue(github, repoParts.owner, repoParts.repo, issueNumber);
-        core.info(`✓ Issue #${issueNumber} closed successfully: ${closedIssue.html_url}`);
+      if (commentToPost) {
+        core.info(`Adding comment to issue #${issueNumber}: length=${commentToPost.length}`);
+        const commentResult = await addIssueComment(github, repoParts.owner, repoParts.repo, issueNumber, commentToPost);
+        core.info(`✓ Comment posted to issue #${issueNumber}: ${commentResult.html_url}`);
+        core.info(`Comment details: id=${commentResult.id}, body_length=${commentToPost.length}`);
       }
 
+      core.info(`Closing issue #${issueNumber} in ${itemRepo}`);
+      const closedIssue = await closeIssue(github, repoParts.owner, repoParts.repo, issueNumber);
+      core.info(`✓ Issue #${issueNumber} closed successfully: ${closedIssue.html_url}`);
+
       core.info(`close_issue completed successfully for issue #${issueNumber}`);
 
       return {
@@ -271,7 +276,8 @@ async function main(config = {}) {
         number: issueNumber,
         url: closedIssue.html_url,
         title: closedIssue.title,
-        alreadyClosed: wasAlreadyClosed,
+        alreadyClosed: false,
+        commentPosted: !!commentToPost,
       };
     } catch (error) {
       const errorMessage = getErrorMessage(error);
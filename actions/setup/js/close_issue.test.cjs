@@ -204,7 +204,7 @@ describe("close_issue", () => {
       expect(result3.error.includes("Max count")).toBe(true);
     });
 
-    it("should still add comment to already closed issues", async () => {
+    it("should skip already closed issues without commenting", async () => {
       const handler = await main({ max: 10, comment: "Test comment" });
 
       let commentAdded = false;
@@ -244,8 +244,9 @@ describe("close_issue", () => {
       const result = await handler({ issue_number: 100, body: "Test comment" }, {});
 
       expect(result.success).toBe(true);
+      expect(result.skipped).toBe(true);
       expect(result.alreadyClosed).toBe(true);
-      expect(commentAdded).toBe(true);
+      expect(commentAdded).toBe(false); // Should not comment on an already closed issue
       expect(issueUpdateCalled).toBe(false); // Should not call update for already closed issue
     });
 
@@ -399,30 +400,15 @@ describe("close_issue", () => {
       expect(result.error).toContain("No comment body provided");
     });
 
-    it("should use body field from message for already closed issues", async () => {
-      const handler = await main({ max: 10 });
+    it("should close without a comment when require_comment is false and no body is provided", async () => {
+      const handler = await main({ max: 10, require_comment: false });
 
-      let commentBody = "";
+      let commentAdded = false;
       let issueUpdateCalled = false;
 
-      mockGithub.rest.issues.get = async () => ({
-        data: {
-          number: 100,
-          title: "Test Issue",
-          labels: [],
-          html_url: "https://github.com/test-owner/test-repo/issues/100",
-          state: "closed",
-        },
-      });
-
-      mockGithub.rest.issues.createComment = async params => {
-        commentBody = params.body;
-        return {
-          data: {
-            id: 456,
-            html_url: "https://github.com/test-owner/test-repo/issues/100#issuecomment-456",
-          },
-        };
+      mockGithub.rest.issues.createComment = async () => {
+        commentAdded = true;
+        return { data: { id: 456, html_url: "https://github.com/test-owner/test-repo/issues/100#issuecomment-456" } };
       };
 
       mockGithub.rest.issues.update = async () => {
@@ -436,12 +422,28 @@ describe("close_issue", () => {
         };
       };
 
+      const result = await handler({ issue_number: 100 }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.commentPosted).toBe(false);
+      expect(commentAdded).toBe(false);
+      expect(issueUpdateCalled).toBe(true);
+    });
+
+    it("should still post the body when require_comment is false and a body is provided", async () => {
+      const handler = await main({ max: 10, require_comment: false });
+
+      let commentBody = "";
+      mockGithub.rest.issues.createComment = async params => {
+        commentBody = params.body;
+        return { data: { id: 456, html_url: "https://github.com/test-owner/test-repo/issues/100#issuecomment-456" } };
+      };
+
       const result = await handler({ issue_number: 100, body: "Closing comment with details" }, {});
 
       expect(result.success).toBe(true);
-      expect(result.alreadyClosed).toBe(true);
+      expect(result.commentPosted).toBe(true);
       expect(commentBody).toBe("Closing comment with details");
-      expect(issueUpdateCalled).toBe(false); // Should not call update for already closed issue
     });
 
     it("should handle API errors gracefully", async () => {
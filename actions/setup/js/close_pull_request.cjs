@@ -85,11 +85,12 @@ async function main(config = {}) {
   const requiredTitlePrefix = config.required_title_prefix || "";
   const maxCount = config.max || 10;
   const comment = config.comment || "";
+  const requireComment = config.require_comment !== false;
 
   // Check if we're in staged mode
   const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
 
-  core.info(`Close pull request configuration: max=${maxCount}`);
+  core.info(`Close pull request configuration: max=${maxCount}, require_comment=${requireComment}`);
   if (requiredLabels.length > 0) {
     core.info(`Required labels: ${requiredLabels.join(", ")}`);
   }
@@ -131,9 +132,9 @@ async function main(config = {}) {
 
     // Determine comment body - prefer non-empty item.body over non-empty config.comment
     /** @type {string} */
-    let commentToPost;
+    let commentToPost = "";
     /** @type {string} */
-    let commentSource = "unknown";
+    let commentSource = "none";
 
     if (typeof item.body === "string" && item.body.trim() !== "") {
       commentToPost = item.body;
@@ -141,7 +142,7 @@ async function main(config = {}) {
     } else if (typeof comment === "string" && comment.trim() !== "") {
       commentToPost = comment;
       commentSource = "config.comment";
-    } else {
+    } else if (requireComment) {
       core.warning("No comment body provided in message and no default comment configured");
       return {
         success: false,
@@ -149,10 +150,13 @@ async function main(config = {}) {
       };
     }
 
-    core.info(`Comment body determined: length=${commentToPost.length}, source=${commentSource}`);
-
-    // Sanitize content to prevent injection attacks
-    commentToPost = sanitizeContent(commentToPost);
+    if (commentToPost) {
+      core.info(`Comment body determined: length=${commentToPost.length}, source=${commentSource}`);
+      // Sanitize content to prevent injection attacks
+      commentToPost = sanitizeContent(commentToPost);
+    } else {
+      core.info("No comment body provided, pull request will be closed without a comment");
+    }
 
     // Determine PR number
     let prNumber;
@@ -196,10 +200,17 @@ async function main(config = {}) {
       };
     }
 
-    // Check if already closed - but still add comment
-    const wasAlreadyClosed = pr.state === "closed";
-    if (wasAlreadyClosed) {
-      core.info(`PR #${prNumber} is already closed, but will still add comment`);
+    // Skip PRs that are already closed so repeated runs do not comment or close twice
+    if (pr.state === "closed") {
+      core.info(`PR #${prNumber} is already closed, skipping`);
+      return {
+        success: true,
+        skipped: true,
+        pull_request_number: pr.number,
+        pull_request_url: pr.html_url,
+        alreadyClosed: true,
+        commentPosted: false,
+      };
     }
 
     // Check label filter
@@ -234,7 +245,6 @@ async function main(config = {}) {
         staged: true,
         previewInfo: {
           number: prNumber,
-          alreadyClosed: wasAlreadyClosed,
           hasComment: !!commentToPost,
         },
       };
@@ -242,42 +252,19 @@ async function main(config = {}) {
 
     // Add comment with the body from the message
     let commentPosted = false;
-    try {
-      const triggeringPRNumber = context.payload?.pull_request?.number;
-      const triggeringIssueNumber = context.payload?.issue?.number;
-      const commentBody = buildCommentBody(commentToPost, triggeringIssueNumber, triggeringPRNumber);
-      core.info(`Adding comment to PR #${prNumber}: length=${commentBody.length}`);
-      await addPullRequestComment(github, owner, repo, prNumber, commentBody);
-      commentPosted = true;
-      core.info(`✓ Comment posted to PR #${prNumber}`);
-      core.info(`Comment details: body_length=${commentBody.length}`);
-    } catch (error) {
-      const errorMsg = getErrorMessage(error);
-      core.error(`Failed to add comment to PR #${prNumber}: ${errorMsg}`);
-      core.error(
-        `Error details: ${JSON.stringify({
-          prNumber,
-          hasBody: !!item.body,
-          bodyLength: item.body ? item.body.length : 0,
-          errorMessage: errorMsg,
-        })}`
-      );
-      // Continue with closing even if comment fails - but log the error
-    }
-
-    // Close the PR if not already closed
-    let closedPR;
-    if (wasAlreadyClosed) {
-      core.info(`PR #${prNumber} was already closed, comment ${commentPosted ? "posted successfully" : "posting attempted"}`);
-      closedPR = pr;
-    } else {
+    if (commentToPost) {
       try {
-        core.info(`Closing PR #${prNumber}`);
-        closedPR = await closePullRequest(github, owner, repo, prNumber);
-        core.info(`✓ PR #${prNumber} closed successfully: ${closedPR.title}`);
+        const triggeringPRNumber = context.payload?.pull_request?.number;
+        const triggeringIssueNumber = context.payload?.issue?.number;
+        const commentBody = buildCommentBody(commentToPost, triggeringIssueNumber, triggeringPRNumber);
+        core.info(`Adding comment to PR #${prNumber}: length=${commentBody.length}`);
+        await addPullRequestComment(github, owner, repo, prNumber, commentBody);
+        commentPosted = true;
+        core.info(`✓ Comment posted to PR #${prNumber}`);
+        core.info(`Comment details: body_length=${commentBody.length}`);
       } catch (error) {
         const errorMsg = getErrorMessage(error);
-        core.error(`Failed to close PR #${prNumber}: ${errorMsg}`);
+        core.error(`Failed to add comment to PR #${prNumber}: ${errorMsg}`);
         core.error(
           `Error details: ${JSON.stringify({
             prNumber,
@@ -286,20 +273,47 @@ async function main(config = {}) {
             errorMessage: errorMsg,
           })}`
         );
-        return {
-          success: false,
-          error: `Failed to close PR #${prNumber}: ${errorMsg}`,
-        };
+        // A required comment must be posted before the PR is closed; otherwise
+        // continue with closing and keep the logged error
+        if (requireComment) {
+          return {
+            success: false,
+            error: `Failed to add required comment to PR #${prNumber}, pull request was not closed: ${errorMsg}`,
+          };
+        }
       }
     }
 
-    core.info(`close_pull_request completed for PR #${prNumber}: ${commentPosted ? "comment posted and " : ""}PR ${wasAlreadyClosed ? "was already closed" : "closed successfully"}`);
+    // Close the PR
+    let closedPR;
+    try {
+      core.info(`Closing PR #${prNumber}`);
+      closedPR = await closePullRequest(github, owner, repo, prNumber);
+      core.info(`✓ PR #${prNumber} closed successfully: ${closedPR.title}`);
+    } catch (error) {
+      const errorMsg = getErrorMessage(error);
+      core.error(`Failed to close PR #${prNumber}: ${errorMsg}`);
+      core.error(
+        `Error details: ${JSON.stringify({
+          prNumber,
+          hasBody: !!item.body,
+          bodyLength: item.body ? item.body.length : 0,
+          errorMessage: errorMsg,
+        })}`
+      );
+      return {
+        success: false,
+        error: `Failed to close PR #${prNumber}: ${errorMsg}`,
+      };
+    }
+
+    core.info(`close_pull_request completed for PR #${prNumber}: ${commentPosted ? "comment posted and " : ""}PR closed successfully`);
 
     return {
       success: true,
       pull_request_number: closedPR.number,
       pull_request_url: closedPR.html_url,
-      alreadyClosed: wasAlreadyClosed,
+      alreadyClosed: false,
       commentPosted,
     };
   };
@@ -275,7 +275,7 @@ describe("close_pull_request", () => {
       expect(result.error).toContain("No comment body provided");
     });
 
-    it("should still add comment to already closed PRs", async () => {
+    it("should skip already closed PRs without commenting", async () => {
       const handler = await main({ max: 10 });
 
       let commentAdded = false;
@@ -315,11 +315,42 @@ describe("close_pull_request", () => {
       const result = await handler({ pull_request_number: 100, body: "Test comment" }, {});
 
       expect(result.success).toBe(true);
+      expect(result.skipped).toBe(true);
       expect(result.alreadyClosed).toBe(true);
-      expect(commentAdded).toBe(true);
+      expect(commentAdded).toBe(false); // Should not comment on an already closed PR
       expect(prUpdateCalled).toBe(false); // Should not call update for already closed PR
     });
 
+    it("should close without a comment when require_comment is false and no body is provided", async () => {
+      const handler = await main({ max: 10, require_comment: false });
+
+      let commentAdded = false;
+      let prUpdateCalled = false;
+
+      mockGithub.rest.issues.createComment = async () => {
+        commentAdded = true;
+        return { data: { id: 789, html_url: "https://github.com/test-owner/test-repo/pull/100#issuecomment-789" } };
+      };
+
+      mockGithub.rest.pulls.update = async () => {
+        prUpdateCalled = true;
+        return {
+          data: {
+            number: 100,
+            title: "Test PR",
+            html_url: "https://github.com/test-owner/test-repo/pull/100",
+          },
+        };
+      };
+
+      const result = await handler({ pull_request_number: 100 }, {});
+
+      expect(result.success).toBe(true);
+      expect(result.commentPosted).toBe(false);
+      expect(commentAdded).toBe(false);
+      expect(prUpdateCalled).toBe(true);
+    });
+
     it("should track comment posting status", async () => {
       const handler = await main({ max: 10 });
 
@@ -329,9 +360,29 @@ describe("close_pull_request", () => {
       expect(result.commentPosted).toBe(true);
     });
 
-    it("should continue closing even if comment fails", async () => {
+    it("should not close the PR when a required comment fails", async () => {
       const handler = await main({ max: 10 });
 
+      mockGithub.rest.issues.createComment = async () => {
+        throw new Error("Comment API error");
+      };
+      let prUpdateCalled = false;
+      mockGithub.rest.pulls.update = async () => {
+        prUpdateCalled = true;
+        return { data: {} };
+      };
+
+      const result = await handler({ pull_request_number: 100, body: "Comment that fails" }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toContain("pull request was not closed");
+      expect(prUpdateCalled).toBe(false);
+      expect(mockCore.errors.some(msg => msg.includes("Failed to add comment"))).toBe(true);
+    });
+
+    it("should continue closing if comment fails when require_comment is false", async () => {
+      const handler = await main({ max: 10, require_comment: false });
+
       mockGithub.rest.issues.createComment = async () => {
         throw new Error("Comment API error");
       };
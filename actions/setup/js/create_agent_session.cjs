@@ -3,6 +3,7 @@
 
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
+const { limitToMaxTotal, recordMaxTotalUsage, failMaxTotalExceeded } = require("./max_total_budget.cjs");
 
 const fs = require("fs");
 const path = require("path");
@@ -85,11 +86,19 @@ async function main() {
   // Get base branch from environment or use current branch
   const baseBranch = process.env.GITHUB_AW_AGENT_SESSION_BASE || process.env.GITHUB_REF_NAME || "main";
 
+  // Limit to the budget left by earlier safe output steps under the global max-total cap
+  const { items: itemsToProcess, exceeded: maxTotalExceeded } = limitToMaxTotal(createAgentSessionItems, "create_agent_session");
+  recordMaxTotalUsage(itemsToProcess.length);
+  if (itemsToProcess.length === 0) {
+    failMaxTotalExceeded(maxTotalExceeded, "create_agent_session");
+    return;
+  }
+
   // Process all agent session items
   const createdTasks = [];
   let summaryContent = "## ✅ Agent Sessions Created\n\n";
 
-  for (const [index, taskItem] of createAgentSessionItems.entries()) {
+  for (const [index, taskItem] of itemsToProcess.entries()) {
     const taskDescription = taskItem.body;
 
     if (!taskDescription || taskDescription.trim() === "") {
@@ -192,6 +201,10 @@ async function main() {
   core.info(summaryContent);
   core.summary.addRaw(summaryContent);
   await core.summary.write();
+
+  if (maxTotalExceeded > 0) {
+    failMaxTotalExceeded(maxTotalExceeded, "create_agent_session");
+  }
 }
 
 module.exports = { main };
@@ -0,0 +1,139 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { loadAgentOutput } = require("./load_agent_output.cjs");
+const { generateStagedPreview } = require("./staged_preview.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_API, ERR_CONFIG } = require("./error_codes.cjs");
+const { limitToMaxTotal, recordMaxTotalUsage, failMaxTotalExceeded } = require("./max_total_budget.cjs");
+
+/**
+ * Build a gist filename from the configured prefix and the item's filename.
+ * Path separators are stripped so the name is always a single file.
+ * @param {string} prefix - Filename prefix from GH_AW_GIST_FILENAME_PREFIX
+ * @param {string | undefined} filename - Filename requested by the agent
+ * @param {number} index - Index of the item (used for the default filename)
+ * @returns {string}
+ */
+function buildGistFilename(prefix, filename, index) {
+  const base = (filename || "").replace(/[\\/]/g, "-").trim() || `report-${index + 1}.md`;
+  return `${prefix}${base}`;
+}
+
+async function main() {
+  // Initialize outputs to empty strings to ensure they're always set
+  core.setOutput("gist_id", "");
+  core.setOutput("gist_url", "");
+
+  const result = loadAgentOutput();
+  if (!result.success) {
+    return;
+  }
+
+  const gistItems = result.items.filter(item => item.type === "create_gist");
+  if (gistItems.length === 0) {
+    core.info("No create_gist items found in agent output");
+    return;
+  }
+
+  core.info(`Found ${gistItems.length} create_gist item(s)`);
+
+  const maxCountEnv = process.env.GH_AW_GIST_MAX_COUNT;
+  const maxCount = maxCountEnv ? parseInt(maxCountEnv, 10) : 1;
+  if (isNaN(maxCount) || maxCount < 1) {
+    core.setFailed(`${ERR_CONFIG}: Invalid max value: ${maxCountEnv}. Must be a positive integer`);
+    return;
+  }
+
+  if (gistItems.length > maxCount) {
+    core.warning(`Found ${gistItems.length} gists to create, but max is ${maxCount}. Processing first ${maxCount}.`);
+  }
+
+  // Limit to the budget left by earlier safe output steps under the global max-total cap
+  const { items: itemsToProcess, exceeded: maxTotalExceeded } = limitToMaxTotal(gistItems.slice(0, maxCount), "create_gist");
+  recordMaxTotalUsage(itemsToProcess.length);
+  if (itemsToProcess.length === 0) {
+    failMaxTotalExceeded(maxTotalExceeded, "create_gist");
+    return;
+  }
+
+  const isPublic = process.env.GH_AW_GIST_PUBLIC === "true";
+  const defaultDescription = process.env.GH_AW_GIST_DESCRIPTION || "";
+  const filenamePrefix = process.env.GH_AW_GIST_FILENAME_PREFIX || "";
+
+  if (process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true") {
+    await generateStagedPreview({
+      title: "Create Gists",
+      description: "The following gists would be created if staged mode was disabled:",
+      items: itemsToProcess,
+      renderItem: (item, index) => {
+        let content = `### ${buildGistFilename(filenamePrefix, item.filename, index)}\n\n`;
+        content += `**Visibility:** ${isPublic ? "public" : "secret"}\n\n`;
+        const description = item.description || defaultDescription;
+        if (description) {
+          content += `**Description:** ${description}\n\n`;
+        }
+        content += `**Content:**\n${item.content || ""}\n\n`;
+        return content;
+      },
+    });
+    if (maxTotalExceeded > 0) {
+      failMaxTotalExceeded(maxTotalExceeded, "create_gist");
+    }
+    return;
+  }
+
+  const createdGists = [];
+  let summaryContent = "## ✅ Gists Created\n\n";
+
+  for (const [index, item] of itemsToProcess.entries()) {
+    const content = sanitizeContent(item.content || "");
+    if (content.trim() === "") {
+      core.warning(`Gist ${index + 1}: content is empty, skipping`);
+      continue;
+    }
+
+    const filename = buildGistFilename(filenamePrefix, item.filename, index);
+    const description = sanitizeContent(item.description || defaultDescription);
+
+    try {
+      const { data: gist } = await github.rest.gists.create({
+        description,
+        public: isPublic,
+        files: {
+          [filename]: { content },
+        },
+      });
+
+      createdGists.push({ id: gist.id, url: gist.html_url });
+      summaryContent += `- [${filename}](${gist.html_url})\n`;
+      core.info(`✅ Created gist ${gist.id}: ${gist.html_url}`);
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      if (errorMessage.includes("404") || errorMessage.includes("403") || errorMessage.includes("Resource not accessible")) {
+        core.error(`Gist ${index + 1}: the token does not have the 'gist' scope.`);
+        core.error("The default GITHUB_TOKEN cannot create gists. Configure GH_AW_GIST_TOKEN or safe-outputs.create-gist.github-token with a token that has the 'gist' scope.");
+      }
+      core.error(`${ERR_API}: Gist ${index + 1}: Failed to create gist: ${errorMessage}`);
+    }
+  }
+
+  if (createdGists.length === 0) {
+    core.setFailed(`${ERR_API}: No gists were created`);
+    return;
+  }
+
+  // Set outputs for the first created gist
+  core.setOutput("gist_id", createdGists[0].id);
+  core.setOutput("gist_url", createdGists[0].url);
+
+  core.summary.addRaw(summaryContent);
+  await core.summary.write();
+
+  if (maxTotalExceeded > 0) {
+    failMaxTotalExceeded(maxTotalExceeded, "create_gist");
+  }
+}
+
+module.exports = { main, buildGistFilename };
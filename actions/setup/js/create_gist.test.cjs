@@ -0,0 +1,137 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import path from "path";
+import { MAX_TOTAL_USAGE_FILE } from "./max_total_budget.cjs";
+
+describe("create_gist.cjs", () => {
+  let mockCore, mockGithub, testOutputFile;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      debug: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn(),
+      summary: { addRaw: vi.fn().mockReturnThis(), write: vi.fn().mockResolvedValue() },
+    };
+    mockGithub = {
+      rest: {
+        gists: {
+          create: vi.fn().mockResolvedValue({ data: { id: "abc123", html_url: "https://gist.github.com/abc123" } }),
+        },
+      },
+    };
+    global.core = mockCore;
+    global.github = mockGithub;
+    testOutputFile = `/tmp/test_gist_output_${Date.now()}.json`;
+  });
+
+  afterEach(() => {
+    delete global.core;
+    delete global.github;
+    delete process.env.GH_AW_AGENT_OUTPUT;
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    delete process.env.GH_AW_GIST_MAX_COUNT;
+    delete process.env.GH_AW_GIST_PUBLIC;
+    delete process.env.GH_AW_GIST_DESCRIPTION;
+    delete process.env.GH_AW_GIST_FILENAME_PREFIX;
+    delete process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL;
+    if (fs.existsSync(testOutputFile)) {
+      fs.unlinkSync(testOutputFile);
+    }
+    fs.rmSync(MAX_TOTAL_USAGE_FILE, { force: true });
+  });
+
+  const createAgentOutput = items => {
+    fs.writeFileSync(testOutputFile, JSON.stringify({ items }));
+    process.env.GH_AW_AGENT_OUTPUT = testOutputFile;
+  };
+
+  const runScript = async () => {
+    const scriptPath = path.join(process.cwd(), "create_gist.cjs");
+    delete require.cache[require.resolve(scriptPath)];
+    const { main } = require(scriptPath);
+    await main();
+  };
+
+  it("should initialize outputs and skip when there are no gist items", async () => {
+    createAgentOutput([{ type: "create_issue", title: "Test", body: "Content" }]);
+    await runScript();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("gist_id", "");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("gist_url", "");
+    expect(mockCore.info).toHaveBeenCalledWith("No create_gist items found in agent output");
+    expect(mockGithub.rest.gists.create).not.toHaveBeenCalled();
+  });
+
+  it("should create a secret gist with the configured prefix and description", async () => {
+    process.env.GH_AW_GIST_FILENAME_PREFIX = "weekly-";
+    process.env.GH_AW_GIST_DESCRIPTION = "Weekly report";
+    createAgentOutput([{ type: "create_gist", filename: "summary.md", content: "# Summary" }]);
+    await runScript();
+
+    expect(mockGithub.rest.gists.create).toHaveBeenCalledWith({
+      description: "Weekly report",
+      public: false,
+      files: { "weekly-summary.md": { content: "# Summary" } },
+    });
+    expect(mockCore.setOutput).toHaveBeenCalledWith("gist_id", "abc123");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("gist_url", "https://gist.github.com/abc123");
+  });
+
+  it("should create public gists when configured", async () => {
+    process.env.GH_AW_GIST_PUBLIC = "true";
+    createAgentOutput([{ type: "create_gist", content: "report" }]);
+    await runScript();
+
+    expect(mockGithub.rest.gists.create).toHaveBeenCalledWith(expect.objectContaining({ public: true, files: { "report-1.md": { content: "report" } } }));
+  });
+
+  it("should respect the max count", async () => {
+    process.env.GH_AW_GIST_MAX_COUNT = "1";
+    createAgentOutput([
+      { type: "create_gist", content: "first" },
+      { type: "create_gist", content: "second" },
+    ]);
+    await runScript();
+
+    expect(mockGithub.rest.gists.create).toHaveBeenCalledTimes(1);
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("max is 1"));
+  });
+
+  it("should stop at the budget left under the global max-total cap", async () => {
+    process.env.GH_AW_GIST_MAX_COUNT = "3";
+    process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "3";
+    fs.mkdirSync(path.dirname(MAX_TOTAL_USAGE_FILE), { recursive: true });
+    fs.writeFileSync(MAX_TOTAL_USAGE_FILE, "2");
+    createAgentOutput([
+      { type: "create_gist", content: "first" },
+      { type: "create_gist", content: "second" },
+    ]);
+    await runScript();
+
+    expect(mockGithub.rest.gists.create).toHaveBeenCalledTimes(1);
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("1 create_gist operation(s) exceeded the max-total limit of 3"));
+    expect(fs.readFileSync(MAX_TOTAL_USAGE_FILE, "utf8")).toBe("3");
+  });
+
+  it("should preview gists in staged mode without calling the API", async () => {
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+    createAgentOutput([{ type: "create_gist", filename: "notes.md", content: "Staged content" }]);
+    await runScript();
+
+    expect(mockGithub.rest.gists.create).not.toHaveBeenCalled();
+    const summary = mockCore.summary.addRaw.mock.calls[0][0];
+    expect(summary).toContain("🎭 Staged Mode: Create Gists Preview");
+    expect(summary).toContain("notes.md");
+    expect(summary).toContain("Staged content");
+  });
+
+  it("should strip path separators from filenames", async () => {
+    const { buildGistFilename } = require(path.join(process.cwd(), "create_gist.cjs"));
+    expect(buildGistFilename("", "../etc/passwd", 0)).toBe("..-etc-passwd");
+    expect(buildGistFilename("p-", "", 2)).toBe("p-report-3.md");
+  });
+});
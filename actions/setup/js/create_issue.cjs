@@ -35,6 +35,7 @@ const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_help
 const { removeDuplicateTitleFromDescription } = require("./remove_duplicate_title.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { renderTemplate } = require("./messages_core.cjs");
+const { applySafeOutputTemplate } = require("./safe_output_template.cjs");
 const { createExpirationLine, addExpirationToFooter } = require("./ephemerals.cjs");
 const { MAX_SUB_ISSUES, getSubIssueCount } = require("./sub_issue_helpers.cjs");
 const { closeOlderIssues } = require("./close_older_issues.cjs");
@@ -207,6 +208,8 @@ async function main(config = {}) {
   const envLabels = config.labels ? (Array.isArray(config.labels) ? config.labels : config.labels.split(",")).map(label => String(label).trim()).filter(Boolean) : [];
   const envAssignees = config.assignees ? (Array.isArray(config.assignees) ? config.assignees : config.assignees.split(",")).map(assignee => String(assignee).trim()).filter(Boolean) : [];
   const titlePrefix = config.title_prefix ?? "";
+  const titleTemplate = config.title_template || "";
+  const bodyTemplate = config.body_template || "";
   const expiresHours = config.expires ? parseInt(String(config.expires), 10) : 0;
   const maxCount = config.max ?? 10;
   const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
@@ -233,6 +236,12 @@ async function main(config = {}) {
   if (titlePrefix) {
     core.info(`Title prefix: ${titlePrefix}`);
   }
+  if (titleTemplate) {
+    core.info(`Title template: ${titleTemplate}`);
+  }
+  if (bodyTemplate) {
+    core.info(`Body template: ${bodyTemplate}`);
+  }
   if (expiresHours > 0) {
     core.info(`Issues expire after: ${expiresHours} hours`);
   }
@@ -398,6 +407,9 @@ async function main(config = {}) {
     // Remove duplicate title from description if it starts with a header matching the title
     processedBody = removeDuplicateTitleFromDescription(title, processedBody);
 
+    // Apply the configured body template (run context placeholders resolved at runtime)
+    processedBody = applySafeOutputTemplate(bodyTemplate, "body", processedBody);
+
     const bodyLines = processedBody.split("\n");
 
     if (!title) {
@@ -407,6 +419,9 @@ async function main(config = {}) {
     // Sanitize title for Unicode security and remove any duplicate prefixes
     title = sanitizeTitle(title, titlePrefix);
 
+    // Apply the configured title template before the prefix so the prefix stays first
+    title = applySafeOutputTemplate(titleTemplate, "title", title);
+
     // Apply title prefix (only if it doesn't already exist)
     title = applyTitlePrefix(title, titlePrefix);
 
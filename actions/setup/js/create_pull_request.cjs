@@ -16,6 +16,7 @@ const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_help
 const { addExpirationToFooter } = require("./ephemerals.cjs");
 const { generateWorkflowIdMarker } = require("./generate_footer.cjs");
 const { parseBoolTemplatable } = require("./templatable.cjs");
+const { applySafeOutputTemplate } = require("./safe_output_template.cjs");
 const { generateFooterWithMessages } = require("./messages_footer.cjs");
 const { normalizeBranchName } = require("./normalize_branch_name.cjs");
 const { pushExtraEmptyCommit } = require("./extra_empty_commit.cjs");
@@ -93,6 +94,8 @@ function generatePatchPreview(patchContent) {
 async function main(config = {}) {
   // Extract configuration
   const titlePrefix = config.title_prefix || "";
+  const titleTemplate = config.title_template || "";
+  const bodyTemplate = config.body_template || "";
   const envLabels = config.labels ? (Array.isArray(config.labels) ? config.labels : config.labels.split(",")).map(label => String(label).trim()).filter(label => label) : [];
   const draftDefault = parseBoolTemplatable(config.draft, true);
   const ifNoChanges = config.if_no_changes || "warn";
@@ -145,6 +148,12 @@ async function main(config = {}) {
   if (titlePrefix) {
     core.info(`Title prefix: ${titlePrefix}`);
   }
+  if (titleTemplate) {
+    core.info(`Title template: ${titleTemplate}`);
+  }
+  if (bodyTemplate) {
+    core.info(`Body template: ${bodyTemplate}`);
+  }
   core.info(`Draft default: ${draftDefault}`);
   core.info(`If no changes: ${ifNoChanges}`);
   core.info(`Allow empty: ${allowEmpty}`);
@@ -417,6 +426,9 @@ async function main(config = {}) {
     // Remove duplicate title from description if it starts with a header matching the title
     processedBody = removeDuplicateTitleFromDescription(title, processedBody);
 
+    // Apply the configured body template (run context placeholders resolved at runtime)
+    processedBody = applySafeOutputTemplate(bodyTemplate, "body", processedBody);
+
     // Auto-add "Fixes #N" closing keyword if triggered from an issue and not already present.
     // This ensures the triggering issue is auto-closed when the PR is merged.
     // Agents are instructed to include this but don't reliably do so.
@@ -455,6 +467,9 @@ async function main(config = {}) {
     // Sanitize title for Unicode security and remove any duplicate prefixes
     title = sanitizeTitle(title, titlePrefix);
 
+    // Apply the configured title template before the prefix so the prefix stays first
+    title = applySafeOutputTemplate(titleTemplate, "title", title);
+
     // Apply title prefix (only if it doesn't already exist)
     title = applyTitlePrefix(title, titlePrefix);
 
@@ -0,0 +1,228 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { loadAgentOutput } = require("./load_agent_output.cjs");
+const { generateStagedPreview } = require("./staged_preview.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { resolveTarget } = require("./safe_output_helpers.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_API, ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
+const { limitToMaxTotal, recordMaxTotalUsage, failMaxTotalExceeded } = require("./max_total_budget.cjs");
+
+/** Review events accepted by the GitHub API */
+const REVIEW_EVENTS = ["COMMENT", "REQUEST_CHANGES", "APPROVE"];
+
+/**
+ * Resolve the review event for an item. APPROVE is downgraded to COMMENT unless
+ * the workflow explicitly allows approvals.
+ * @param {string | undefined} requested - Event requested by the agent
+ * @param {string} defaultEvent - Event from GH_AW_PULL_REQUEST_REVIEW_EVENT
+ * @param {boolean} allowApprove - Whether GH_AW_PULL_REQUEST_REVIEW_ALLOW_APPROVE is set
+ * @returns {{event: string, downgraded: boolean} | {error: string}}
+ */
+function resolveReviewEvent(requested, defaultEvent, allowApprove) {
+  const event = (requested || defaultEvent || "COMMENT").trim().toUpperCase();
+  if (!REVIEW_EVENTS.includes(event)) {
+    return { error: `Invalid review event '${event}'. Must be one of: ${REVIEW_EVENTS.join(", ")}` };
+  }
+  if (event === "APPROVE" && !allowApprove) {
+    return { event: "COMMENT", downgraded: true };
+  }
+  return { event, downgraded: false };
+}
+
+/**
+ * Convert the agent's inline comments into the createReview comments payload,
+ * skipping entries without a path, a positive line number, or a body.
+ * @param {any} comments - Inline comments from the agent output
+ * @returns {Array<{path: string, line: number, side: string, body: string, start_line?: number, start_side?: string}>}
+ */
+function buildReviewComments(comments) {
+  if (!Array.isArray(comments)) {
+    return [];
+  }
+
+  const result = [];
+  for (const [index, comment] of comments.entries()) {
+    const line = typeof comment?.line === "number" ? comment.line : parseInt(String(comment?.line), 10);
+    if (typeof comment?.path !== "string" || comment.path === "" || !Number.isInteger(line) || line <= 0 || typeof comment.body !== "string" || comment.body === "") {
+      core.warning(`Inline comment ${index + 1}: path, a positive line and body are required, skipping`);
+      continue;
+    }
+
+    const side = comment.side === "LEFT" ? "LEFT" : "RIGHT";
+    /** @type {{path: string, line: number, side: string, body: string, start_line?: number, start_side?: string}} */
+    const reviewComment = { path: comment.path, line, side, body: sanitizeContent(comment.body) };
+    const startLine = typeof comment.start_line === "number" ? comment.start_line : parseInt(String(comment.start_line), 10);
+    if (Number.isInteger(startLine) && startLine > 0 && startLine < line) {
+      reviewComment.start_line = startLine;
+      reviewComment.start_side = side;
+    }
+    result.push(reviewComment);
+  }
+  return result;
+}
+
+/**
+ * Resolve the pull request number for a review item
+ * @param {any} item - Safe output item
+ * @param {string} targetConfig - Target from GH_AW_PULL_REQUEST_REVIEW_TARGET
+ * @returns {{success: true, number: number} | {success: false, error: string, shouldFail: boolean}}
+ */
+function resolvePullRequestNumber(item, targetConfig) {
+  // Comments on a pull request arrive as issue_comment events
+  if (!targetConfig && context.eventName === "issue_comment" && context.payload.issue?.pull_request) {
+    return { success: true, number: context.payload.issue.number };
+  }
+  return resolveTarget({ targetConfig, item, context, itemType: "pull request review", supportsPR: false });
+}
+
+async function main() {
+  // Initialize outputs to empty strings to ensure they're always set
+  core.setOutput("review_id", "");
+  core.setOutput("review_url", "");
+
+  const result = loadAgentOutput();
+  if (!result.success) {
+    return;
+  }
+
+  const reviewItems = result.items.filter(item => item.type === "create_pull_request_review");
+  if (reviewItems.length === 0) {
+    core.info("No create_pull_request_review items found in agent output");
+    return;
+  }
+
+  core.info(`Found ${reviewItems.length} create_pull_request_review item(s)`);
+
+  const maxCountEnv = process.env.GH_AW_PULL_REQUEST_REVIEW_MAX_COUNT;
+  const maxCount = maxCountEnv ? parseInt(maxCountEnv, 10) : 1;
+  if (isNaN(maxCount) || maxCount < 1) {
+    core.setFailed(`${ERR_CONFIG}: Invalid max value: ${maxCountEnv}. Must be a positive integer`);
+    return;
+  }
+
+  if (reviewItems.length > maxCount) {
+    core.warning(`Found ${reviewItems.length} reviews to submit, but max is ${maxCount}. Processing first ${maxCount}.`);
+  }
+
+  // Limit to the budget left by earlier safe output steps under the global max-total cap
+  const { items: itemsToProcess, exceeded: maxTotalExceeded } = limitToMaxTotal(reviewItems.slice(0, maxCount), "create_pull_request_review");
+  recordMaxTotalUsage(itemsToProcess.length);
+  if (itemsToProcess.length === 0) {
+    failMaxTotalExceeded(maxTotalExceeded, "create_pull_request_review");
+    return;
+  }
+
+  const defaultEvent = process.env.GH_AW_PULL_REQUEST_REVIEW_EVENT || "COMMENT";
+  const defaultBody = process.env.GH_AW_PULL_REQUEST_REVIEW_BODY || "";
+  const allowApprove = process.env.GH_AW_PULL_REQUEST_REVIEW_ALLOW_APPROVE === "true";
+  const targetConfig = process.env.GH_AW_PULL_REQUEST_REVIEW_TARGET || "";
+
+  if (process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true") {
+    await generateStagedPreview({
+      title: "Create Pull Request Reviews",
+      description: "The following pull request reviews would be submitted if staged mode was disabled:",
+      items: itemsToProcess,
+      renderItem: item => {
+        const resolved = resolveReviewEvent(item.event, defaultEvent, allowApprove);
+        let content = `### ${"event" in resolved ? resolved.event : "Invalid"} review\n\n`;
+        content += `**Body:**\n${item.body || defaultBody}\n\n`;
+        const comments = buildReviewComments(item.comments);
+        if (comments.length > 0) {
+          content += `**Inline comments:** ${comments.length}\n\n`;
+          for (const comment of comments) {
+            content += `- \`${comment.path}:${comment.line}\`: ${comment.body}\n`;
+          }
+          content += "\n";
+        }
+        return content;
+      },
+    });
+    if (maxTotalExceeded > 0) {
+      failMaxTotalExceeded(maxTotalExceeded, "create_pull_request_review");
+    }
+    return;
+  }
+
+  const submittedReviews = [];
+  let hasErrors = false;
+  let summaryContent = "## ✅ Pull Request Reviews Submitted\n\n";
+
+  for (const [index, item] of itemsToProcess.entries()) {
+    const resolved = resolveReviewEvent(item.event, defaultEvent, allowApprove);
+    if ("error" in resolved) {
+      core.error(`${ERR_VALIDATION}: Review ${index + 1}: ${resolved.error}, skipping`);
+      hasErrors = true;
+      continue;
+    }
+    if (resolved.downgraded) {
+      core.warning(`Review ${index + 1}: APPROVE is not allowed by this workflow (set allow-approve: true to enable it). Submitting as COMMENT instead.`);
+    }
+
+    const body = sanitizeContent(item.body || defaultBody);
+    const comments = buildReviewComments(item.comments);
+    if (resolved.event === "REQUEST_CHANGES" && body === "") {
+      core.error(`${ERR_VALIDATION}: Review ${index + 1}: a body is required for REQUEST_CHANGES reviews, skipping`);
+      hasErrors = true;
+      continue;
+    }
+    if (resolved.event === "COMMENT" && body === "" && comments.length === 0) {
+      core.error(`${ERR_VALIDATION}: Review ${index + 1}: a COMMENT review needs a body or inline comments, skipping`);
+      hasErrors = true;
+      continue;
+    }
+
+    const target = resolvePullRequestNumber(item, targetConfig);
+    if (!target.success) {
+      if (target.shouldFail) {
+        core.error(`${ERR_VALIDATION}: Review ${index + 1}: ${target.error}`);
+        hasErrors = true;
+      } else {
+        core.info(target.error);
+      }
+      continue;
+    }
+
+    try {
+      const { data: review } = await github.rest.pulls.createReview({
+        owner: context.repo.owner,
+        repo: context.repo.repo,
+        pull_number: target.number,
+        event: resolved.event,
+        ...(body ? { body } : {}),
+        ...(comments.length > 0 ? { comments } : {}),
+      });
+
+      submittedReviews.push({ id: review.id, url: review.html_url });
+      summaryContent += `- [${resolved.event} review on #${target.number}](${review.html_url})\n`;
+      core.info(`✅ Submitted ${resolved.event} review on pull request #${target.number}: ${review.html_url}`);
+    } catch (error) {
+      core.error(`${ERR_API}: Review ${index + 1}: Failed to submit review on pull request #${target.number}: ${getErrorMessage(error)}`);
+      hasErrors = true;
+    }
+  }
+
+  if (submittedReviews.length === 0) {
+    // Items skipped outside a pull request context are not failures
+    if (hasErrors) {
+      core.setFailed(`${ERR_API}: No pull request reviews were submitted`);
+    } else if (maxTotalExceeded > 0) {
+      failMaxTotalExceeded(maxTotalExceeded, "create_pull_request_review");
+    }
+    return;
+  }
+
+  // Set outputs for the first submitted review
+  core.setOutput("review_id", String(submittedReviews[0].id));
+  core.setOutput("review_url", submittedReviews[0].url);
+
+  core.summary.addRaw(summaryContent);
+  await core.summary.write();
+
+  if (maxTotalExceeded > 0) {
+    failMaxTotalExceeded(maxTotalExceeded, "create_pull_request_review");
+  }
+}
+
+module.exports = { main, resolveReviewEvent, buildReviewComments };
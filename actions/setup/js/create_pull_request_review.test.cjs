@@ -0,0 +1,184 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import path from "path";
+import { MAX_TOTAL_USAGE_FILE } from "./max_total_budget.cjs";
+
+describe("create_pull_request_review.cjs", () => {
+  let mockCore, mockGithub, testOutputFile;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      debug: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn(),
+      summary: { addRaw: vi.fn().mockReturnThis(), write: vi.fn().mockResolvedValue() },
+    };
+    mockGithub = {
+      rest: {
+        pulls: {
+          createReview: vi.fn().mockResolvedValue({ data: { id: 99, html_url: "https://github.com/owner/repo/pull/7#pullrequestreview-99" } }),
+        },
+      },
+    };
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = {
+      eventName: "pull_request",
+      repo: { owner: "owner", repo: "repo" },
+      payload: { pull_request: { number: 7 } },
+    };
+    testOutputFile = `/tmp/test_pr_review_output_${Date.now()}.json`;
+  });
+
+  afterEach(() => {
+    delete global.core;
+    delete global.github;
+    delete global.context;
+    delete process.env.GH_AW_AGENT_OUTPUT;
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    delete process.env.GH_AW_PULL_REQUEST_REVIEW_MAX_COUNT;
+    delete process.env.GH_AW_PULL_REQUEST_REVIEW_EVENT;
+    delete process.env.GH_AW_PULL_REQUEST_REVIEW_BODY;
+    delete process.env.GH_AW_PULL_REQUEST_REVIEW_ALLOW_APPROVE;
+    delete process.env.GH_AW_PULL_REQUEST_REVIEW_TARGET;
+    delete process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL;
+    if (fs.existsSync(testOutputFile)) {
+      fs.unlinkSync(testOutputFile);
+    }
+    fs.rmSync(MAX_TOTAL_USAGE_FILE, { force: true });
+  });
+
+  const createAgentOutput = items => {
+    fs.writeFileSync(testOutputFile, JSON.stringify({ items }));
+    process.env.GH_AW_AGENT_OUTPUT = testOutputFile;
+  };
+
+  const runScript = async () => {
+    const scriptPath = path.join(process.cwd(), "create_pull_request_review.cjs");
+    delete require.cache[require.resolve(scriptPath)];
+    const { main } = require(scriptPath);
+    await main();
+  };
+
+  it("should initialize outputs and skip when there are no review items", async () => {
+    createAgentOutput([{ type: "create_issue", title: "Test", body: "Content" }]);
+    await runScript();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("review_id", "");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("review_url", "");
+    expect(mockGithub.rest.pulls.createReview).not.toHaveBeenCalled();
+  });
+
+  it("should submit a COMMENT review with inline comments by default", async () => {
+    createAgentOutput([
+      {
+        type: "create_pull_request_review",
+        body: "Looks reasonable overall",
+        comments: [
+          { path: "src/main.go", line: 12, body: "Consider handling the error" },
+          { path: "src/util.go", start_line: 3, line: 5, side: "LEFT", body: "This block was removed" },
+        ],
+      },
+    ]);
+    await runScript();
+
+    expect(mockGithub.rest.pulls.createReview).toHaveBeenCalledWith({
+      owner: "owner",
+      repo: "repo",
+      pull_number: 7,
+      event: "COMMENT",
+      body: "Looks reasonable overall",
+      comments: [
+        { path: "src/main.go", line: 12, side: "RIGHT", body: "Consider handling the error" },
+        { path: "src/util.go", line: 5, side: "LEFT", body: "This block was removed", start_line: 3, start_side: "LEFT" },
+      ],
+    });
+    expect(mockCore.setOutput).toHaveBeenCalledWith("review_id", "99");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("review_url", "https://github.com/owner/repo/pull/7#pullrequestreview-99");
+  });
+
+  it("should use the configured default event and body", async () => {
+    process.env.GH_AW_PULL_REQUEST_REVIEW_EVENT = "REQUEST_CHANGES";
+    process.env.GH_AW_PULL_REQUEST_REVIEW_BODY = "Automated review";
+    createAgentOutput([{ type: "create_pull_request_review" }]);
+    await runScript();
+
+    expect(mockGithub.rest.pulls.createReview).toHaveBeenCalledWith(expect.objectContaining({ event: "REQUEST_CHANGES", body: "Automated review" }));
+  });
+
+  it("should downgrade APPROVE to COMMENT unless approvals are allowed", async () => {
+    createAgentOutput([{ type: "create_pull_request_review", event: "APPROVE", body: "LGTM" }]);
+    await runScript();
+
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("APPROVE is not allowed"));
+    expect(mockGithub.rest.pulls.createReview).toHaveBeenCalledWith(expect.objectContaining({ event: "COMMENT", body: "LGTM" }));
+  });
+
+  it("should approve when approvals are allowed", async () => {
+    process.env.GH_AW_PULL_REQUEST_REVIEW_ALLOW_APPROVE = "true";
+    createAgentOutput([{ type: "create_pull_request_review", event: "APPROVE", body: "LGTM" }]);
+    await runScript();
+
+    expect(mockGithub.rest.pulls.createReview).toHaveBeenCalledWith(expect.objectContaining({ event: "APPROVE" }));
+  });
+
+  it("should require a body for REQUEST_CHANGES reviews", async () => {
+    createAgentOutput([{ type: "create_pull_request_review", event: "REQUEST_CHANGES" }]);
+    await runScript();
+
+    expect(mockGithub.rest.pulls.createReview).not.toHaveBeenCalled();
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("No pull request reviews were submitted"));
+  });
+
+  it("should skip without failing outside a pull request context", async () => {
+    global.context = { eventName: "push", repo: { owner: "owner", repo: "repo" }, payload: {} };
+    createAgentOutput([{ type: "create_pull_request_review", body: "Review" }]);
+    await runScript();
+
+    expect(mockGithub.rest.pulls.createReview).not.toHaveBeenCalled();
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("should use pull_request_number when target is *", async () => {
+    process.env.GH_AW_PULL_REQUEST_REVIEW_TARGET = "*";
+    global.context = { eventName: "workflow_dispatch", repo: { owner: "owner", repo: "repo" }, payload: {} };
+    createAgentOutput([{ type: "create_pull_request_review", body: "Review", pull_request_number: 42 }]);
+    await runScript();
+
+    expect(mockGithub.rest.pulls.createReview).toHaveBeenCalledWith(expect.objectContaining({ pull_number: 42 }));
+  });
+
+  it("should generate a staged preview instead of submitting", async () => {
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+    createAgentOutput([{ type: "create_pull_request_review", body: "Staged review" }]);
+    await runScript();
+
+    expect(mockGithub.rest.pulls.createReview).not.toHaveBeenCalled();
+    expect(mockCore.summary.addRaw).toHaveBeenCalled();
+  });
+
+  it("should not submit reviews once the global max-total cap is used up", async () => {
+    process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "2";
+    fs.mkdirSync(path.dirname(MAX_TOTAL_USAGE_FILE), { recursive: true });
+    fs.writeFileSync(MAX_TOTAL_USAGE_FILE, "2");
+    createAgentOutput([{ type: "create_pull_request_review", body: "Over budget" }]);
+    await runScript();
+
+    expect(mockGithub.rest.pulls.createReview).not.toHaveBeenCalled();
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("1 create_pull_request_review operation(s) exceeded the max-total limit of 2"));
+    expect(fs.readFileSync(MAX_TOTAL_USAGE_FILE, "utf8")).toBe("2");
+  });
+
+  it("should drop invalid inline comments", async () => {
+    global.core = mockCore;
+    const { buildReviewComments } = require(path.join(process.cwd(), "create_pull_request_review.cjs"));
+
+    const comments = buildReviewComments([{ path: "a.go", line: 0, body: "zero line" }, { line: 3, body: "no path" }, { path: "b.go", line: "4", body: "string line" }]);
+
+    expect(comments).toEqual([{ path: "b.go", line: 4, side: "RIGHT", body: "string line" }]);
+    expect(mockCore.warning).toHaveBeenCalledTimes(2);
+  });
+});
@@ -0,0 +1,147 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { loadAgentOutput } = require("./load_agent_output.cjs");
+const { generateStagedPreview } = require("./staged_preview.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_API, ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
+const { limitToMaxTotal, recordMaxTotalUsage, failMaxTotalExceeded } = require("./max_total_budget.cjs");
+
+/**
+ * Apply the configured tag prefix unless the tag already starts with it.
+ * @param {string} prefix - Tag prefix from GH_AW_RELEASE_TAG_PREFIX
+ * @param {string | undefined} tag - Tag requested by the agent
+ * @returns {string}
+ */
+function buildReleaseTag(prefix, tag) {
+  const trimmed = (tag || "").trim();
+  if (trimmed === "" || prefix === "" || trimmed.startsWith(prefix)) {
+    return trimmed;
+  }
+  return `${prefix}${trimmed}`;
+}
+
+async function main() {
+  // Initialize outputs to empty strings to ensure they're always set
+  core.setOutput("release_id", "");
+  core.setOutput("release_url", "");
+  core.setOutput("release_tag", "");
+
+  const result = loadAgentOutput();
+  if (!result.success) {
+    return;
+  }
+
+  const releaseItems = result.items.filter(item => item.type === "create_release");
+  if (releaseItems.length === 0) {
+    core.info("No create_release items found in agent output");
+    return;
+  }
+
+  core.info(`Found ${releaseItems.length} create_release item(s)`);
+
+  const maxCountEnv = process.env.GH_AW_RELEASE_MAX_COUNT;
+  const maxCount = maxCountEnv ? parseInt(maxCountEnv, 10) : 1;
+  if (isNaN(maxCount) || maxCount < 1) {
+    core.setFailed(`${ERR_CONFIG}: Invalid max value: ${maxCountEnv}. Must be a positive integer`);
+    return;
+  }
+
+  if (releaseItems.length > maxCount) {
+    core.warning(`Found ${releaseItems.length} releases to create, but max is ${maxCount}. Processing first ${maxCount}.`);
+  }
+
+  // Limit to the budget left by earlier safe output steps under the global max-total cap
+  const { items: itemsToProcess, exceeded: maxTotalExceeded } = limitToMaxTotal(releaseItems.slice(0, maxCount), "create_release");
+  recordMaxTotalUsage(itemsToProcess.length);
+  if (itemsToProcess.length === 0) {
+    failMaxTotalExceeded(maxTotalExceeded, "create_release");
+    return;
+  }
+
+  // Releases are only published when the workflow explicitly sets draft: false
+  const isDraft = process.env.GH_AW_RELEASE_DRAFT !== "false";
+  const isPrerelease = process.env.GH_AW_RELEASE_PRERELEASE === "true";
+  const tagPrefix = process.env.GH_AW_RELEASE_TAG_PREFIX || "";
+  const targetCommitish = process.env.GH_AW_RELEASE_TARGET_COMMITISH || "";
+
+  if (process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true") {
+    await generateStagedPreview({
+      title: "Create Releases",
+      description: "The following releases would be created if staged mode was disabled:",
+      items: itemsToProcess,
+      renderItem: item => {
+        const tag = buildReleaseTag(tagPrefix, item.tag);
+        let content = `### ${item.name || tag}\n\n`;
+        content += `**Tag:** ${tag}\n\n`;
+        content += `**Draft:** ${isDraft ? "yes" : "no"}\n\n`;
+        if (isPrerelease) {
+          content += `**Prerelease:** yes\n\n`;
+        }
+        content += `**Release Notes:**\n${item.body || ""}\n\n`;
+        return content;
+      },
+    });
+    if (maxTotalExceeded > 0) {
+      failMaxTotalExceeded(maxTotalExceeded, "create_release");
+    }
+    return;
+  }
+
+  const createdReleases = [];
+  let summaryContent = `## ✅ ${isDraft ? "Draft Releases" : "Releases"} Created\n\n`;
+
+  for (const [index, item] of itemsToProcess.entries()) {
+    const tag = buildReleaseTag(tagPrefix, item.tag);
+    if (tag === "") {
+      core.error(`${ERR_VALIDATION}: Release ${index + 1}: tag is required, skipping`);
+      continue;
+    }
+
+    const body = sanitizeContent(item.body || "");
+    const name = sanitizeContent(item.name || tag);
+
+    try {
+      const { data: release } = await github.rest.repos.createRelease({
+        owner: context.repo.owner,
+        repo: context.repo.repo,
+        tag_name: tag,
+        name,
+        body,
+        draft: isDraft,
+        prerelease: isPrerelease,
+        ...(targetCommitish ? { target_commitish: targetCommitish } : {}),
+      });
+
+      createdReleases.push({ id: release.id, url: release.html_url, tag: release.tag_name });
+      summaryContent += `- [${name}](${release.html_url})${isDraft ? " (draft)" : ""}\n`;
+      core.info(`✅ Created ${isDraft ? "draft " : ""}release ${release.tag_name}: ${release.html_url}`);
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      if (errorMessage.includes("already_exists")) {
+        core.error(`Release ${index + 1}: a release for tag ${tag} already exists. Use update-release to edit existing releases.`);
+      }
+      core.error(`${ERR_API}: Release ${index + 1}: Failed to create release: ${errorMessage}`);
+    }
+  }
+
+  if (createdReleases.length === 0) {
+    core.setFailed(`${ERR_API}: No releases were created`);
+    return;
+  }
+
+  // Set outputs for the first created release
+  core.setOutput("release_id", String(createdReleases[0].id));
+  core.setOutput("release_url", createdReleases[0].url);
+  core.setOutput("release_tag", createdReleases[0].tag);
+
+  core.summary.addRaw(summaryContent);
+  await core.summary.write();
+
+  if (maxTotalExceeded > 0) {
+    failMaxTotalExceeded(maxTotalExceeded, "create_release");
+  }
+}
+
+module.exports = { main, buildReleaseTag };
@@ -0,0 +1,138 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import path from "path";
+
+describe("create_release.cjs", () => {
+  let mockCore, mockGithub, testOutputFile;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      debug: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn(),
+      summary: { addRaw: vi.fn().mockReturnThis(), write: vi.fn().mockResolvedValue() },
+    };
+    mockGithub = {
+      rest: {
+        repos: {
+          createRelease: vi.fn().mockResolvedValue({ data: { id: 42, html_url: "https://github.com/owner/repo/releases/tag/v1.0.0", tag_name: "v1.0.0" } }),
+        },
+      },
+    };
+    global.core = mockCore;
+    global.github = mockGithub;
+    global.context = { repo: { owner: "owner", repo: "repo" } };
+    testOutputFile = `/tmp/test_release_output_${Date.now()}.json`;
+  });
+
+  afterEach(() => {
+    delete global.core;
+    delete global.github;
+    delete global.context;
+    delete process.env.GH_AW_AGENT_OUTPUT;
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    delete process.env.GH_AW_RELEASE_MAX_COUNT;
+    delete process.env.GH_AW_RELEASE_DRAFT;
+    delete process.env.GH_AW_RELEASE_PRERELEASE;
+    delete process.env.GH_AW_RELEASE_TAG_PREFIX;
+    delete process.env.GH_AW_RELEASE_TARGET_COMMITISH;
+    if (fs.existsSync(testOutputFile)) {
+      fs.unlinkSync(testOutputFile);
+    }
+  });
+
+  const createAgentOutput = items => {
+    fs.writeFileSync(testOutputFile, JSON.stringify({ items }));
+    process.env.GH_AW_AGENT_OUTPUT = testOutputFile;
+  };
+
+  const runScript = async () => {
+    const scriptPath = path.join(process.cwd(), "create_release.cjs");
+    delete require.cache[require.resolve(scriptPath)];
+    const { main } = require(scriptPath);
+    await main();
+  };
+
+  it("should initialize outputs and skip when there are no release items", async () => {
+    createAgentOutput([{ type: "create_issue", title: "Test", body: "Content" }]);
+    await runScript();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("release_id", "");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("release_url", "");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("release_tag", "");
+    expect(mockGithub.rest.repos.createRelease).not.toHaveBeenCalled();
+  });
+
+  it("should create a draft release by default", async () => {
+    createAgentOutput([{ type: "create_release", tag: "v1.0.0", body: "Release notes" }]);
+    await runScript();
+
+    expect(mockGithub.rest.repos.createRelease).toHaveBeenCalledWith({
+      owner: "owner",
+      repo: "repo",
+      tag_name: "v1.0.0",
+      name: "v1.0.0",
+      body: "Release notes",
+      draft: true,
+      prerelease: false,
+    });
+    expect(mockCore.setOutput).toHaveBeenCalledWith("release_id", "42");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("release_tag", "v1.0.0");
+  });
+
+  it("should only publish when draft is explicitly disabled", async () => {
+    process.env.GH_AW_RELEASE_DRAFT = "false";
+    process.env.GH_AW_RELEASE_PRERELEASE = "true";
+    process.env.GH_AW_RELEASE_TARGET_COMMITISH = "main";
+    createAgentOutput([{ type: "create_release", tag: "v1.0.0", name: "Version 1", body: "Notes" }]);
+    await runScript();
+
+    expect(mockGithub.rest.repos.createRelease).toHaveBeenCalledWith(expect.objectContaining({ draft: false, prerelease: true, name: "Version 1", target_commitish: "main" }));
+  });
+
+  it("should apply the tag prefix", async () => {
+    process.env.GH_AW_RELEASE_TAG_PREFIX = "v";
+    createAgentOutput([{ type: "create_release", tag: "2.0.0", body: "Notes" }]);
+    await runScript();
+
+    expect(mockGithub.rest.repos.createRelease).toHaveBeenCalledWith(expect.objectContaining({ tag_name: "v2.0.0" }));
+  });
+
+  it("should sanitize the release notes", async () => {
+    createAgentOutput([{ type: "create_release", tag: "v1.0.0", body: "Thanks @octocat" }]);
+    await runScript();
+
+    const { body } = mockGithub.rest.repos.createRelease.mock.calls[0][0];
+    expect(body).toContain("`@octocat`");
+  });
+
+  it("should fail when no release could be created", async () => {
+    mockGithub.rest.repos.createRelease.mockRejectedValue(new Error("Validation Failed: already_exists"));
+    createAgentOutput([{ type: "create_release", tag: "v1.0.0", body: "Notes" }]);
+    await runScript();
+
+    expect(mockCore.error).toHaveBeenCalledWith(expect.stringContaining("already exists"));
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("No releases were created"));
+  });
+
+  it("should preview releases in staged mode without calling the API", async () => {
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+    createAgentOutput([{ type: "create_release", tag: "v1.0.0", body: "Staged notes" }]);
+    await runScript();
+
+    expect(mockGithub.rest.repos.createRelease).not.toHaveBeenCalled();
+    const summary = mockCore.summary.addRaw.mock.calls[0][0];
+    expect(summary).toContain("🎭 Staged Mode: Create Releases Preview");
+    expect(summary).toContain("Staged notes");
+  });
+
+  it("should not duplicate an existing tag prefix", () => {
+    const { buildReleaseTag } = require(path.join(process.cwd(), "create_release.cjs"));
+    expect(buildReleaseTag("v", "v1.2.3")).toBe("v1.2.3");
+    expect(buildReleaseTag("v", " 1.2.3 ")).toBe("v1.2.3");
+    expect(buildReleaseTag("v", "")).toBe("");
+  });
+});
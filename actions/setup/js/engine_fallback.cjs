@@ -0,0 +1,112 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const fs = require("fs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_API } = require("./error_codes.cjs");
+
+/**
+ * Marker appended to the agent log before the next engine runs. Only log content after
+ * the last marker belongs to the attempt being classified.
+ */
+const ENGINE_FALLBACK_MARKER = "::gh-aw-engine-fallback::";
+
+/**
+ * Number of trailing log lines inspected. Provider errors terminate the CLI, so they appear
+ * at the end of the log; earlier lines may contain tool output that mentions HTTP errors.
+ */
+const CLASSIFY_TAIL_LINES = 200;
+
+/**
+ * Provider-level error signatures that make a failed attempt eligible for fallback.
+ * Any failure that matches none of these patterns is a hard failure.
+ * @type {Array<{category: string, pattern: RegExp}>}
+ */
+const FALLBACK_ERROR_PATTERNS = [
+  // Authentication
+  { category: "authentication", pattern: /authentication_error/i },
+  { category: "authentication", pattern: /invalid[ _-]?(?:x-)?api[ _-]?key/i },
+  { category: "authentication", pattern: /incorrect api key/i },
+  { category: "authentication", pattern: /API_KEY_INVALID/ },
+  { category: "authentication", pattern: /\b401\b[^\n]*unauthori[sz]ed/i },
+  // Quota and rate limits
+  { category: "quota", pattern: /insufficient_quota/i },
+  { category: "quota", pattern: /quota[ _-]?(?:exceeded|exhausted)/i },
+  { category: "quota", pattern: /RESOURCE_EXHAUSTED/ },
+  { category: "quota", pattern: /rate[ _-]?limit[ _-]?(?:error|exceeded|reached)/i },
+  { category: "quota", pattern: /\b429\b[^\n]*too many requests/i },
+  // Provider server errors
+  { category: "server", pattern: /overloaded_error/i },
+  { category: "server", pattern: /API Error:?\s*5\d\d\b/i },
+  { category: "server", pattern: /\b5\d\d\b[^\n]*(?:internal server error|bad gateway|service unavailable|gateway time-?out|overloaded)/i },
+];
+
+/**
+ * Return the portion of the agent log written by the most recent engine attempt.
+ * @param {string} logContent - Full agent log
+ * @returns {string} Trailing lines of the latest attempt
+ */
+function getLatestAttemptLog(logContent) {
+  const markerIndex = logContent.lastIndexOf(ENGINE_FALLBACK_MARKER);
+  const attemptLog = markerIndex >= 0 ? logContent.slice(logContent.indexOf("\n", markerIndex) + 1) : logContent;
+  return attemptLog.split("\n").slice(-CLASSIFY_TAIL_LINES).join("\n");
+}
+
+/**
+ * Classify a failed engine attempt from its log output.
+ * @param {string} attemptLog - Log output of the failed attempt
+ * @returns {{eligible: boolean, category: string, match: string}} Classification result
+ */
+function classifyEngineFailure(attemptLog) {
+  for (const { category, pattern } of FALLBACK_ERROR_PATTERNS) {
+    const match = attemptLog.match(pattern);
+    if (match) {
+      return { eligible: true, category, match: match[0] };
+    }
+  }
+  return { eligible: false, category: "", match: "" };
+}
+
+/**
+ * Decide whether the next engine in the fallback chain should run after a failed attempt.
+ * Sets the "fallback" output to "true" for provider errors when another engine is available
+ * and fails the step otherwise.
+ * @returns {Promise<void>}
+ */
+async function main() {
+  const logPath = process.env.GH_AW_AGENT_LOG || "";
+  const engineID = process.env.GH_AW_ENGINE_ID || "engine";
+  const nextEngine = process.env.GH_AW_FALLBACK_ENGINE || "";
+
+  core.setOutput("fallback", "false");
+
+  let logContent = "";
+  try {
+    if (logPath && fs.existsSync(logPath)) {
+      logContent = fs.readFileSync(logPath, "utf8");
+    }
+  } catch (error) {
+    core.warning(`Failed to read agent log ${logPath}: ${getErrorMessage(error)}`);
+  }
+
+  const result = classifyEngineFailure(getLatestAttemptLog(logContent));
+  core.setOutput("category", result.category);
+
+  if (!result.eligible) {
+    core.setFailed(`${ERR_API}: Engine '${engineID}' failed with an error that is not eligible for fallback. Fallback is only attempted for provider authentication, quota and server (5xx) errors.`);
+    return;
+  }
+
+  core.info(`Engine '${engineID}' failed with a ${result.category} error: ${result.match}`);
+
+  if (!nextEngine) {
+    core.setFailed(`${ERR_API}: Engine '${engineID}' failed with a ${result.category} error and no fallback engines remain`);
+    return;
+  }
+
+  core.warning(`Engine '${engineID}' is unavailable (${result.category} error), falling back to '${nextEngine}'`);
+  fs.appendFileSync(logPath, `\n${ENGINE_FALLBACK_MARKER} ${engineID} -> ${nextEngine} (${result.category})\n`);
+  core.setOutput("fallback", "true");
+}
+
+module.exports = { main, classifyEngineFailure, getLatestAttemptLog, ENGINE_FALLBACK_MARKER, FALLBACK_ERROR_PATTERNS };
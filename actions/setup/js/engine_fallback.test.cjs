@@ -0,0 +1,104 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+
+const { main, classifyEngineFailure, getLatestAttemptLog, ENGINE_FALLBACK_MARKER } = require("./engine_fallback.cjs");
+
+describe("engine_fallback.cjs", () => {
+  let mockCore, logFile;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn(),
+    };
+    global.core = mockCore;
+    logFile = `/tmp/test_engine_fallback_${Date.now()}.log`;
+    process.env.GH_AW_AGENT_LOG = logFile;
+    process.env.GH_AW_ENGINE_ID = "copilot";
+  });
+
+  afterEach(() => {
+    delete global.core;
+    delete process.env.GH_AW_AGENT_LOG;
+    delete process.env.GH_AW_ENGINE_ID;
+    delete process.env.GH_AW_FALLBACK_ENGINE;
+    if (fs.existsSync(logFile)) {
+      fs.unlinkSync(logFile);
+    }
+  });
+
+  describe("classifyEngineFailure", () => {
+    it.each([
+      ["authentication", 'API Error: 401 {"type":"error","error":{"type":"authentication_error","message":"invalid x-api-key"}}'],
+      ["authentication", "Error: Incorrect API key provided"],
+      ["quota", "Error: You exceeded your current quota (insufficient_quota)"],
+      ["quota", "[API Error: RESOURCE_EXHAUSTED]"],
+      ["quota", "HTTP 429 Too Many Requests"],
+      ["server", 'API Error: 529 {"type":"error","error":{"type":"overloaded_error"}}'],
+      ["server", "Request failed: 503 Service Unavailable"],
+    ])("should classify %s errors as fallback-eligible", (category, log) => {
+      const result = classifyEngineFailure(log);
+      expect(result.eligible).toBe(true);
+      expect(result.category).toBe(category);
+    });
+
+    it.each([
+      ["tool error", "Tool 'bash' failed: command exited with code 1"],
+      ["agent error", "Error: Agent exceeded maximum turns"],
+      ["empty log", ""],
+    ])("should classify a %s as a hard failure", (_name, log) => {
+      expect(classifyEngineFailure(log).eligible).toBe(false);
+    });
+  });
+
+  describe("getLatestAttemptLog", () => {
+    it("should only return content after the last fallback marker", () => {
+      const log = `API Error: 503 Service Unavailable\n${ENGINE_FALLBACK_MARKER} copilot -> claude (server)\nTool 'bash' failed\n`;
+      expect(getLatestAttemptLog(log)).toBe("Tool 'bash' failed\n");
+    });
+  });
+
+  describe("main", () => {
+    it("should request fallback for a provider error when another engine remains", async () => {
+      process.env.GH_AW_FALLBACK_ENGINE = "claude";
+      fs.writeFileSync(logFile, "API Error: 503 Service Unavailable\n");
+
+      await main();
+
+      expect(mockCore.setOutput).toHaveBeenCalledWith("fallback", "true");
+      expect(mockCore.setFailed).not.toHaveBeenCalled();
+      expect(fs.readFileSync(logFile, "utf8")).toContain(`${ENGINE_FALLBACK_MARKER} copilot -> claude (server)`);
+    });
+
+    it("should fail without fallback for a hard failure", async () => {
+      process.env.GH_AW_FALLBACK_ENGINE = "claude";
+      fs.writeFileSync(logFile, "Tool 'bash' failed: command exited with code 1\n");
+
+      await main();
+
+      expect(mockCore.setOutput).not.toHaveBeenCalledWith("fallback", "true");
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("not eligible for fallback"));
+    });
+
+    it("should fail when the last engine in the chain has a provider error", async () => {
+      fs.writeFileSync(logFile, "insufficient_quota\n");
+
+      await main();
+
+      expect(mockCore.setOutput).not.toHaveBeenCalledWith("fallback", "true");
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("no fallback engines remain"));
+    });
+
+    it("should ignore provider errors from a previous attempt", async () => {
+      process.env.GH_AW_ENGINE_ID = "claude";
+      process.env.GH_AW_FALLBACK_ENGINE = "codex";
+      fs.writeFileSync(logFile, `API Error: 503 Service Unavailable\n${ENGINE_FALLBACK_MARKER} copilot -> claude (server)\nTool 'bash' failed\n`);
+
+      await main();
+
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("not eligible for fallback"));
+    });
+  });
+});
@@ -0,0 +1,104 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const fs = require("fs");
+const path = require("path");
+const { parseFirewallLogLine, isRequestAllowed } = require("./parse_firewall_logs.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/**
+ * Exports the firewall proxy logs to a single network log with one line per connection:
+ *   <ISO timestamp> <ALLOW|DENY> <host> <method> <status> <destination>
+ *
+ * In audit mode the hosts the firewall blocked are also listed in the step summary, as the
+ * hosts to add to network.allowed.
+ */
+
+const FIREWALL_LOGS_DIR = "/tmp/gh-aw/sandbox/firewall/logs/";
+
+/**
+ * Strips the port from a "host:port" log field
+ * @param {string} domainWithPort - Domain field from the firewall log
+ * @returns {string} Host without port
+ */
+function getHost(domainWithPort) {
+  if (!domainWithPort || domainWithPort === "-") {
+    return "-";
+  }
+  const lastColonIndex = domainWithPort.lastIndexOf(":");
+  return (lastColonIndex > 0 ? domainWithPort.substring(0, lastColonIndex) : domainWithPort).toLowerCase();
+}
+
+/**
+ * Determines the verdict for a logged connection from the proxy decision
+ * @param {any} entry - Parsed firewall log entry
+ * @returns {string} ALLOW or DENY
+ */
+function getVerdict(entry) {
+  return isRequestAllowed(entry.decision, entry.status) ? "ALLOW" : "DENY";
+}
+
+/**
+ * Formats a parsed firewall log entry as a network log line
+ * @param {any} entry - Parsed firewall log entry
+ * @param {string} verdict - Connection verdict
+ * @returns {string} Network log line
+ */
+function formatNetworkLogLine(entry, verdict) {
+  const time = new Date(parseFloat(entry.timestamp) * 1000).toISOString();
+  return [time, verdict, getHost(entry.domain), entry.method, entry.status, entry.destIpPort].join(" ");
+}
+
+/**
+ * Writes the network log and, in audit mode, a summary of the blocked hosts
+ * @returns {Promise<void>}
+ */
+async function main() {
+  const networkLogPath = process.env.GH_AW_NETWORK_LOG || "/tmp/gh-aw/network.log";
+  const mode = process.env.GH_AW_NETWORK_MODE || "enforce";
+
+  const lines = [`# gh-aw network log (mode: ${mode})`];
+  const deniedHosts = new Set();
+
+  try {
+    const files = fs.existsSync(FIREWALL_LOGS_DIR) ? fs.readdirSync(FIREWALL_LOGS_DIR).filter(file => file.endsWith(".log")) : [];
+    if (files.length === 0) {
+      core.info(`No firewall log files found in: ${FIREWALL_LOGS_DIR}`);
+    }
+
+    for (const file of files) {
+      const content = fs.readFileSync(path.join(FIREWALL_LOGS_DIR, file), "utf8");
+      for (const line of content.split("\n")) {
+        const entry = parseFirewallLogLine(line);
+        if (!entry) {
+          continue;
+        }
+        const verdict = getVerdict(entry);
+        if (verdict === "DENY") {
+          deniedHosts.add(getHost(entry.domain));
+        }
+        lines.push(formatNetworkLogLine(entry, verdict));
+      }
+    }
+  } catch (error) {
+    core.warning(`Failed to read firewall logs: ${getErrorMessage(error)}`);
+  }
+
+  fs.mkdirSync(path.dirname(networkLogPath), { recursive: true });
+  fs.writeFileSync(networkLogPath, lines.join("\n") + "\n");
+  core.info(`Wrote ${lines.length - 1} connection(s) to ${networkLogPath}`);
+
+  if (mode === "audit") {
+    const hosts = Array.from(deniedHosts).sort();
+    let summary = "### Network audit\n\n";
+    if (hosts.length === 0) {
+      summary += "All connections were within the network allowlist.\n";
+    } else {
+      summary += `${hosts.length} host${hosts.length !== 1 ? "s" : ""} outside the network allowlist ${hosts.length !== 1 ? "were" : "was"} blocked. Add them to \`network.allowed\` if they are required:\n\n`;
+      summary += hosts.map(host => `- \`${host}\``).join("\n") + "\n";
+    }
+    await core.summary.addRaw(summary).write();
+  }
+}
+
+module.exports = { main, getHost, getVerdict, formatNetworkLogLine };
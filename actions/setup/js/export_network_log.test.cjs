@@ -0,0 +1,80 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+
+const { main, getHost, getVerdict } = require("./export_network_log.cjs");
+
+const FIREWALL_LOGS_DIR = "/tmp/gh-aw/sandbox/firewall/logs/";
+
+describe("export_network_log.cjs", () => {
+  describe("getHost", () => {
+    it("should strip the port from the log domain field", () => {
+      expect(getHost("api.github.com:443")).toBe("api.github.com");
+      expect(getHost("-")).toBe("-");
+    });
+  });
+
+  describe("getVerdict", () => {
+    it("should report the proxy decision", () => {
+      expect(getVerdict({ decision: "TCP_TUNNEL:HIER_DIRECT", status: "200", domain: "example.com:443" })).toBe("ALLOW");
+      expect(getVerdict({ decision: "NONE_NONE:HIER_NONE", status: "403", domain: "example.com:443" })).toBe("DENY");
+    });
+  });
+
+  describe("main", () => {
+    let mockCore, networkLog, firewallLog;
+
+    beforeEach(() => {
+      mockCore = {
+        info: vi.fn(),
+        warning: vi.fn(),
+        summary: { addRaw: vi.fn().mockReturnThis(), write: vi.fn().mockResolvedValue(undefined) },
+      };
+      global.core = mockCore;
+      networkLog = `/tmp/test_network_${Date.now()}.log`;
+      firewallLog = `${FIREWALL_LOGS_DIR}test_access_${Date.now()}.log`;
+      fs.mkdirSync(FIREWALL_LOGS_DIR, { recursive: true });
+      fs.writeFileSync(
+        firewallLog,
+        [
+          '1761332530.474 172.30.0.20:35288 api.github.com:443 140.82.112.22:443 1.1 CONNECT 200 TCP_TUNNEL:HIER_DIRECT api.github.com:443 "-"',
+          '1761332531.123 172.30.0.20:35289 example.com:443 93.184.216.34:443 1.1 CONNECT 403 NONE_NONE:HIER_NONE example.com:443 "-"',
+        ].join("\n")
+      );
+      process.env.GH_AW_NETWORK_LOG = networkLog;
+    });
+
+    afterEach(() => {
+      delete global.core;
+      delete process.env.GH_AW_NETWORK_LOG;
+      delete process.env.GH_AW_NETWORK_MODE;
+      for (const file of [networkLog, firewallLog]) {
+        if (fs.existsSync(file)) {
+          fs.unlinkSync(file);
+        }
+      }
+    });
+
+    it("should write every connection in enforce mode", async () => {
+      process.env.GH_AW_NETWORK_MODE = "enforce";
+
+      await main();
+
+      const content = fs.readFileSync(networkLog, "utf8");
+      expect(content).toContain("# gh-aw network log (mode: enforce)");
+      expect(content).toContain("ALLOW api.github.com CONNECT 200 140.82.112.22:443");
+      expect(content).toContain("DENY example.com CONNECT 403 93.184.216.34:443");
+      expect(mockCore.summary.addRaw).not.toHaveBeenCalled();
+    });
+
+    it("should list blocked hosts in audit mode", async () => {
+      process.env.GH_AW_NETWORK_MODE = "audit";
+
+      await main();
+
+      const content = fs.readFileSync(networkLog, "utf8");
+      expect(content).toContain("ALLOW api.github.com");
+      expect(content).toContain("DENY example.com");
+      expect(mockCore.summary.addRaw).toHaveBeenCalledWith(expect.stringContaining("- `example.com`"));
+    });
+  });
+});
@@ -33,16 +33,116 @@ function getPatchPath(branchName) {
   return `/tmp/gh-aw/aw-${sanitized}.patch`;
 }
 
+/**
+ * Build the pathspec that leaves the given globs out of the patch
+ * @param {string} excludePaths - Comma-separated globs to exclude
+ * @returns {string[]} Pathspec arguments (including the leading "--"), or an empty array when nothing is excluded
+ */
+function buildPatchPathspec(excludePaths) {
+  const excludes = (excludePaths || "")
+    .split(",")
+    .map(p => p.trim())
+    .filter(Boolean)
+    .map(p => `:(glob,exclude)${p}`);
+  if (excludes.length === 0) {
+    return [];
+  }
+  // Exclusions need a positive pathspec: start from the repository root
+  return ["--", ":/", ...excludes];
+}
+
+/**
+ * Resolve an explicit patch base to a commit SHA and check that it is an ancestor of the target ref
+ * @param {string} base - The base ref from GH_AW_PATCH_BASE
+ * @param {string} target - The ref the patch is generated for
+ * @param {string} cwd - The repository directory
+ * @returns {string} The commit SHA of the base
+ */
+function resolvePatchBase(base, target, cwd) {
+  let baseSha;
+  try {
+    baseSha = execGitSync(["rev-parse", "--verify", "--quiet", `${base}^{commit}`], { cwd }).trim();
+  } catch {
+    baseSha = "";
+  }
+  if (!baseSha) {
+    throw new Error(`Patch base "${base}" (GH_AW_PATCH_BASE) does not exist`);
+  }
+  try {
+    execGitSync(["merge-base", "--is-ancestor", baseSha, target], { cwd });
+  } catch {
+    throw new Error(`Patch base "${base}" (GH_AW_PATCH_BASE) is not an ancestor of ${target}`);
+  }
+  return baseSha;
+}
+
+/**
+ * Read the create_pull_request patch options from the environment.
+ * These come from safe-outputs.create-pull-request and must not be applied to other patches,
+ * such as those generated for push_to_pull_request_branch.
+ * @returns {{maxPatchBytes: number, base: string, excludePaths: string}} Options for generateGitPatch
+ */
+function getCreatePullRequestPatchOptions() {
+  return {
+    maxPatchBytes: parseInt(process.env.GH_AW_MAX_PATCH_BYTES || "0", 10),
+    base: process.env.GH_AW_PATCH_BASE || "",
+    excludePaths: process.env.GH_AW_PATCH_EXCLUDE_PATHS || "",
+  };
+}
+
+/**
+ * Lists the binary files changed in a revision range. Binary changes are included in the
+ * patch (format-patch --binary), but reviewers cannot read them in the patch preview.
+ * @param {string} range - Revision range, e.g. "base..HEAD"
+ * @param {string[]} pathspec - Pathspec arguments from buildPatchPathspec
+ * @param {string} cwd - Repository directory
+ * @returns {string[]} Paths of binary files in the range
+ */
+function listBinaryFiles(range, pathspec, cwd) {
+  try {
+    const numstat = execGitSync(["diff", "--numstat", range, ...pathspec], { cwd });
+    return numstat
+      .split("\n")
+      .map(line => line.split("\t"))
+      .filter(fields => fields.length >= 3 && fields[0] === "-" && fields[1] === "-")
+      .map(fields => fields.slice(2).join("\t"));
+  } catch {
+    return [];
+  }
+}
+
+/**
+ * Appends a warning about binary files to the step summary, when one is available
+ * @param {string[]} binaryFiles - Paths of binary files included in the patch
+ */
+function warnBinaryFiles(binaryFiles) {
+  const summaryFile = process.env.GITHUB_STEP_SUMMARY;
+  if (binaryFiles.length === 0 || !summaryFile) {
+    return;
+  }
+  try {
+    fs.appendFileSync(summaryFile, `> [!WARNING]\n> The git patch includes binary files: ${binaryFiles.join(" ")}\n\n`);
+  } catch {
+    // The summary is informational; a write failure must not fail patch generation
+  }
+}
+
 /**
  * Generates a git patch file for the current changes
  * @param {string} branchName - The branch name to generate patch for
+ * @param {Object} [options] - Patch options
+ * @param {number} [options.maxPatchBytes] - Reject patches larger than this many bytes (0 disables the limit)
+ * @param {string} [options.base] - Explicit base ref for the patch, overriding the branch and HEAD strategies
+ * @param {string} [options.excludePaths] - Comma-separated globs to leave out of the patch
  * @returns {Object} Object with patch info or error
  */
-function generateGitPatch(branchName) {
+function generateGitPatch(branchName, options = {}) {
   const patchPath = getPatchPath(branchName);
   const cwd = process.env.GITHUB_WORKSPACE || process.cwd();
   const defaultBranch = process.env.DEFAULT_BRANCH || getBaseBranch();
   const githubSha = process.env.GITHUB_SHA;
+  const patchBase = options.base || "";
+  const pathspec = buildPatchPathspec(options.excludePaths || "");
 
   // Ensure /tmp/gh-aw directory exists
   const patchDir = path.dirname(patchPath);
@@ -52,10 +152,36 @@ function generateGitPatch(branchName) {
 
   let patchGenerated = false;
   let errorMessage = null;
+  /** @type {string[]} */
+  let binaryFiles = [];
 
   try {
+    // Explicit base: takes precedence over the branch and HEAD strategies
+    if (patchBase) {
+      let target = "HEAD";
+      if (branchName) {
+        try {
+          execGitSync(["show-ref", "--verify", "--quiet", `refs/heads/${branchName}`], { cwd });
+          target = branchName;
+        } catch {
+          // Branch does not exist locally, use the current HEAD
+        }
+      }
+
+      const baseSha = resolvePatchBase(patchBase, target, cwd);
+      const commitCount = parseInt(execGitSync(["rev-list", "--count", `${baseSha}..${target}`, ...pathspec], { cwd }).trim(), 10);
+      if (commitCount > 0) {
+        const patchContent = execGitSync(["format-patch", "--binary", `${baseSha}..${target}`, "--stdout", ...pathspec], { cwd });
+        if (patchContent && patchContent.trim()) {
+          fs.writeFileSync(patchPath, patchContent, "utf8");
+          patchGenerated = true;
+          binaryFiles = listBinaryFiles(`${baseSha}..${target}`, pathspec, cwd);
+        }
+      }
+    }
+
     // Strategy 1: If we have a branch name, check if that branch exists and get its diff
-    if (branchName) {
+    if (!patchBase && branchName) {
       // Check if the branch exists locally
       try {
         execGitSync(["show-ref", "--verify", "--quiet", `refs/heads/${branchName}`], { cwd });
@@ -73,15 +199,16 @@ function generateGitPatch(branchName) {
         }
 
         // Count commits to be included
-        const commitCount = parseInt(execGitSync(["rev-list", "--count", `${baseRef}..${branchName}`], { cwd }).trim(), 10);
+        const commitCount = parseInt(execGitSync(["rev-list", "--count", `${baseRef}..${branchName}`, ...pathspec], { cwd }).trim(), 10);
 
         if (commitCount > 0) {
-          // Generate patch from the determined base to the branch
-          const patchContent = execGitSync(["format-patch", `${baseRef}..${branchName}`, "--stdout"], { cwd });
+          // Generate patch from the determined base to the branch (--binary keeps binary files applicable)
+          const patchContent = execGitSync(["format-patch", "--binary", `${baseRef}..${branchName}`, "--stdout", ...pathspec], { cwd });
 
           if (patchContent && patchContent.trim()) {
             fs.writeFileSync(patchPath, patchContent, "utf8");
             patchGenerated = true;
+            binaryFiles = listBinaryFiles(`${baseRef}..${branchName}`, pathspec, cwd);
           }
         }
       } catch (branchError) {
@@ -90,7 +217,7 @@ function generateGitPatch(branchName) {
     }
 
     // Strategy 2: Check if commits were made to current HEAD since checkout
-    if (!patchGenerated) {
+    if (!patchBase && !patchGenerated) {
       const currentHead = execGitSync(["rev-parse", "HEAD"], { cwd }).trim();
 
       if (!githubSha) {
@@ -103,15 +230,16 @@ function generateGitPatch(branchName) {
           execGitSync(["merge-base", "--is-ancestor", githubSha, "HEAD"], { cwd });
 
           // Count commits between GITHUB_SHA and HEAD
-          const commitCount = parseInt(execGitSync(["rev-list", "--count", `${githubSha}..HEAD`], { cwd }).trim(), 10);
+          const commitCount = parseInt(execGitSync(["rev-list", "--count", `${githubSha}..HEAD`, ...pathspec], { cwd }).trim(), 10);
 
           if (commitCount > 0) {
             // Generate patch from GITHUB_SHA to HEAD
-            const patchContent = execGitSync(["format-patch", `${githubSha}..HEAD`, "--stdout"], { cwd });
+            const patchContent = execGitSync(["format-patch", "--binary", `${githubSha}..HEAD`, "--stdout", ...pathspec], { cwd });
 
             if (patchContent && patchContent.trim()) {
               fs.writeFileSync(patchPath, patchContent, "utf8");
               patchGenerated = true;
+              binaryFiles = listBinaryFiles(`${githubSha}..HEAD`, pathspec, cwd);
             }
           }
         } catch {
@@ -140,11 +268,27 @@ function generateGitPatch(branchName) {
       };
     }
 
+    // Enforce the patch size limit from safe-outputs.create-pull-request.max-patch-size
+    const maxPatchBytes = options.maxPatchBytes || 0;
+    if (maxPatchBytes > 0 && patchSize > maxPatchBytes) {
+      fs.unlinkSync(patchPath);
+      return {
+        success: false,
+        error: `Patch is ${patchSize} bytes, which exceeds the maximum of ${maxPatchBytes} bytes (GH_AW_MAX_PATCH_BYTES). Reduce the size of the changes.`,
+        patchPath: patchPath,
+        patchSize: patchSize,
+        patchLines: patchLines,
+      };
+    }
+
+    warnBinaryFiles(binaryFiles);
+
     return {
       success: true,
       patchPath: patchPath,
       patchSize: patchSize,
       patchLines: patchLines,
+      binaryFiles: binaryFiles,
     };
   }
 
@@ -157,7 +301,11 @@ function generateGitPatch(branchName) {
 }
 
 module.exports = {
+  buildPatchPathspec,
   generateGitPatch,
+  getCreatePullRequestPatchOptions,
   getPatchPath,
+  listBinaryFiles,
+  resolvePatchBase,
   sanitizeBranchNameForPatch,
 };
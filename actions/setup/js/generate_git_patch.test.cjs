@@ -10,6 +10,10 @@ describe("generateGitPatch", () => {
       GITHUB_WORKSPACE: process.env.GITHUB_WORKSPACE,
       DEFAULT_BRANCH: process.env.DEFAULT_BRANCH,
       GH_AW_BASE_BRANCH: process.env.GH_AW_BASE_BRANCH,
+      GH_AW_MAX_PATCH_BYTES: process.env.GH_AW_MAX_PATCH_BYTES,
+      GH_AW_PATCH_BASE: process.env.GH_AW_PATCH_BASE,
+      GH_AW_PATCH_EXCLUDE_PATHS: process.env.GH_AW_PATCH_EXCLUDE_PATHS,
+      GITHUB_STEP_SUMMARY: process.env.GITHUB_STEP_SUMMARY,
     };
   });
 
@@ -161,4 +165,220 @@ describe("generateGitPatch", () => {
     expect(result).toHaveProperty("success");
     expect(result.success).toBe(false);
   });
+
+  it("should reject patches larger than maxPatchBytes", async () => {
+    const { execFileSync } = await import("child_process");
+    const fs = await import("fs");
+    const path = await import("path");
+    const os = await import("os");
+    const { generateGitPatch, getCreatePullRequestPatchOptions } = await import("./generate_git_patch.cjs");
+
+    const repoDir = fs.mkdtempSync(path.join(os.tmpdir(), "patch-max-bytes-"));
+    const git = (...args) => execFileSync("git", args, { cwd: repoDir, encoding: "utf8" });
+    git("init", "-q");
+    git("config", "user.email", "test@example.com");
+    git("config", "user.name", "Test User");
+    fs.writeFileSync(path.join(repoDir, "initial.txt"), "initial content\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "Initial commit");
+    const initialSha = git("rev-parse", "HEAD").trim();
+    fs.writeFileSync(path.join(repoDir, "large.txt"), "generated line of content\n".repeat(2000));
+    git("add", ".");
+    git("commit", "-q", "-m", "Add large file");
+
+    process.env.GITHUB_WORKSPACE = repoDir;
+    process.env.GITHUB_SHA = initialSha;
+    process.env.GH_AW_MAX_PATCH_BYTES = "1024";
+
+    // The limit only applies when the caller passes the create_pull_request options
+    const unlimited = generateGitPatch("max-bytes-test");
+    expect(unlimited.success).toBe(true);
+
+    const result = generateGitPatch("max-bytes-test", getCreatePullRequestPatchOptions());
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("exceeds the maximum of 1024 bytes");
+    expect(fs.existsSync(result.patchPath)).toBe(false);
+
+    fs.rmSync(repoDir, { recursive: true, force: true });
+  });
+
+  it("should use the create_pull_request patch base when set", async () => {
+    const { execFileSync } = await import("child_process");
+    const fs = await import("fs");
+    const path = await import("path");
+    const os = await import("os");
+    const { generateGitPatch, getCreatePullRequestPatchOptions } = await import("./generate_git_patch.cjs");
+
+    const repoDir = fs.mkdtempSync(path.join(os.tmpdir(), "patch-base-"));
+    const git = (...args) => execFileSync("git", args, { cwd: repoDir, encoding: "utf8" });
+    git("init", "-q");
+    git("config", "user.email", "test@example.com");
+    git("config", "user.name", "Test User");
+    fs.writeFileSync(path.join(repoDir, "initial.txt"), "initial content\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "Initial commit");
+    const initialSha = git("rev-parse", "HEAD").trim();
+    fs.writeFileSync(path.join(repoDir, "first.txt"), "first\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "First change");
+    const baseSha = git("rev-parse", "HEAD").trim();
+    fs.writeFileSync(path.join(repoDir, "second.txt"), "second\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "Second change");
+
+    process.env.GITHUB_WORKSPACE = repoDir;
+    process.env.GITHUB_SHA = initialSha;
+    process.env.GH_AW_PATCH_BASE = baseSha;
+
+    const result = generateGitPatch("patch-base-test", getCreatePullRequestPatchOptions());
+
+    expect(result.success).toBe(true);
+    const patchContent = fs.readFileSync(result.patchPath, "utf8");
+    expect(patchContent).toContain("Second change");
+    expect(patchContent).not.toContain("First change");
+
+    // Without the create_pull_request options the base is not applied
+    const defaultBase = generateGitPatch("patch-base-test");
+    expect(defaultBase.success).toBe(true);
+    expect(fs.readFileSync(defaultBase.patchPath, "utf8")).toContain("First change");
+
+    process.env.GH_AW_PATCH_BASE = "does-not-exist";
+    const invalid = generateGitPatch("patch-base-test", getCreatePullRequestPatchOptions());
+    expect(invalid.success).toBe(false);
+    expect(invalid.error).toContain("does not exist");
+
+    fs.rmSync(repoDir, { recursive: true, force: true });
+  });
+
+  it("should reject a create_pull_request patch base that is not an ancestor", async () => {
+    const { execFileSync } = await import("child_process");
+    const fs = await import("fs");
+    const path = await import("path");
+    const os = await import("os");
+    const { generateGitPatch, getCreatePullRequestPatchOptions } = await import("./generate_git_patch.cjs");
+
+    const repoDir = fs.mkdtempSync(path.join(os.tmpdir(), "patch-base-side-"));
+    const git = (...args) => execFileSync("git", args, { cwd: repoDir, encoding: "utf8" });
+    git("init", "-q", "-b", "main");
+    git("config", "user.email", "test@example.com");
+    git("config", "user.name", "Test User");
+    fs.writeFileSync(path.join(repoDir, "initial.txt"), "initial content\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "Initial commit");
+    const initialSha = git("rev-parse", "HEAD").trim();
+    git("checkout", "-q", "-b", "side");
+    fs.writeFileSync(path.join(repoDir, "side.txt"), "side\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "Side change");
+    const sideSha = git("rev-parse", "HEAD").trim();
+    git("checkout", "-q", "main");
+    fs.writeFileSync(path.join(repoDir, "first.txt"), "first\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "First change");
+
+    process.env.GITHUB_WORKSPACE = repoDir;
+    process.env.GITHUB_SHA = initialSha;
+    process.env.GH_AW_PATCH_BASE = sideSha;
+
+    const result = generateGitPatch("patch-base-side-test", getCreatePullRequestPatchOptions());
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("is not an ancestor of HEAD");
+    expect(fs.existsSync(result.patchPath)).toBe(false);
+
+    fs.rmSync(repoDir, { recursive: true, force: true });
+  });
+
+  it("should leave the create_pull_request exclude paths out of the patch", async () => {
+    const { execFileSync } = await import("child_process");
+    const fs = await import("fs");
+    const path = await import("path");
+    const os = await import("os");
+    const { generateGitPatch, getCreatePullRequestPatchOptions } = await import("./generate_git_patch.cjs");
+
+    const repoDir = fs.mkdtempSync(path.join(os.tmpdir(), "patch-exclude-"));
+    const git = (...args) => execFileSync("git", args, { cwd: repoDir, encoding: "utf8" });
+    git("init", "-q");
+    git("config", "user.email", "test@example.com");
+    git("config", "user.name", "Test User");
+    fs.writeFileSync(path.join(repoDir, "initial.txt"), "initial content\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "Initial commit");
+    const initialSha = git("rev-parse", "HEAD").trim();
+    fs.mkdirSync(path.join(repoDir, "dist"));
+    fs.writeFileSync(path.join(repoDir, "dist", "bundle.js"), "generated\n");
+    fs.writeFileSync(path.join(repoDir, "feature.txt"), "feature\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "Add feature");
+    // A commit that only touches excluded paths
+    fs.mkdirSync(path.join(repoDir, "web"));
+    fs.writeFileSync(path.join(repoDir, "web", "package-lock.json"), "{}\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "Update lock file");
+
+    process.env.GITHUB_WORKSPACE = repoDir;
+    process.env.GITHUB_SHA = initialSha;
+    process.env.GH_AW_PATCH_EXCLUDE_PATHS = "dist/**,**/package-lock.json";
+
+    const result = generateGitPatch("patch-exclude-test", getCreatePullRequestPatchOptions());
+
+    expect(result.success).toBe(true);
+    const patchContent = fs.readFileSync(result.patchPath, "utf8");
+    expect(patchContent).toContain("feature.txt");
+    expect(patchContent).not.toContain("dist/bundle.js");
+    expect(patchContent).not.toContain("package-lock.json");
+    expect(patchContent).not.toContain("Update lock file");
+
+    // Without the create_pull_request options no paths are excluded
+    const unfiltered = generateGitPatch("patch-exclude-test");
+    expect(unfiltered.success).toBe(true);
+    expect(fs.readFileSync(unfiltered.patchPath, "utf8")).toContain("dist/bundle.js");
+
+    fs.rmSync(repoDir, { recursive: true, force: true });
+  });
+
+  it("should report binary files included in the patch", async () => {
+    const { execFileSync } = await import("child_process");
+    const fs = await import("fs");
+    const path = await import("path");
+    const os = await import("os");
+    const { generateGitPatch } = await import("./generate_git_patch.cjs");
+
+    const repoDir = fs.mkdtempSync(path.join(os.tmpdir(), "patch-binary-"));
+    const git = (...args) => execFileSync("git", args, { cwd: repoDir, encoding: "utf8" });
+    git("init", "-q");
+    git("config", "user.email", "test@example.com");
+    git("config", "user.name", "Test User");
+    fs.writeFileSync(path.join(repoDir, "initial.txt"), "initial content\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "Initial commit");
+    const initialSha = git("rev-parse", "HEAD").trim();
+    fs.writeFileSync(path.join(repoDir, "image.png"), Buffer.from([0x89, 0x50, 0x4e, 0x47, 0x00, 0x01, 0x02]));
+    fs.writeFileSync(path.join(repoDir, "notes.txt"), "text change\n");
+    git("add", ".");
+    git("commit", "-q", "-m", "Add image");
+
+    const summaryFile = path.join(repoDir, "summary.md");
+    process.env.GITHUB_WORKSPACE = repoDir;
+    process.env.GITHUB_SHA = initialSha;
+    process.env.GITHUB_STEP_SUMMARY = summaryFile;
+
+    const result = generateGitPatch("patch-binary-test");
+
+    expect(result.success).toBe(true);
+    expect(result.binaryFiles).toEqual(["image.png"]);
+    expect(fs.readFileSync(result.patchPath, "utf8")).toContain("GIT binary patch");
+    expect(fs.readFileSync(summaryFile, "utf8")).toContain("The git patch includes binary files: image.png");
+
+    fs.rmSync(repoDir, { recursive: true, force: true });
+  });
+
+  it("should build pathspec exclusions from comma-separated globs", async () => {
+    const { buildPatchPathspec } = await import("./generate_git_patch.cjs");
+
+    expect(buildPatchPathspec("")).toEqual([]);
+    expect(buildPatchPathspec(undefined)).toEqual([]);
+    expect(buildPatchPathspec("dist/**, *.lock")).toEqual(["--", ":/", ":(glob,exclude)dist/**", ":(glob,exclude)*.lock"]);
+  });
 });
@@ -0,0 +1,91 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Global max-total budget for safe output operations
+ *
+ * The cap from GH_AW_SAFE_OUTPUTS_MAX_TOTAL is shared by the steps of the safe_outputs job.
+ * The handler manager runs first and records how many operations it accepted; the dedicated
+ * steps (assign_to_agent, create_agent_session, create_gist, create_release,
+ * create_pull_request_review) then run in their canonical order, each limiting its items to
+ * the remaining budget and recording what it used. The steps share the budget through a file,
+ * since they run in the same job.
+ */
+
+const fs = require("fs");
+const path = require("path");
+const { ERR_VALIDATION } = require("./error_codes.cjs");
+
+/** File holding the number of operations counted towards max-total by earlier steps */
+const MAX_TOTAL_USAGE_FILE = "/tmp/gh-aw/safe-outputs-max-total-used.txt";
+
+/**
+ * Read the global cap on safe output operations across all types
+ * Reads the limit from GH_AW_SAFE_OUTPUTS_MAX_TOTAL environment variable
+ * @returns {number} Maximum number of operations, or 0 when unlimited
+ */
+function getMaxTotal() {
+  const value = parseInt(process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL || "", 10);
+  return Number.isInteger(value) && value > 0 ? value : 0;
+}
+
+/**
+ * Read the number of operations counted towards max-total by earlier steps
+ * @returns {number}
+ */
+function readMaxTotalUsage() {
+  try {
+    const value = parseInt(fs.readFileSync(MAX_TOTAL_USAGE_FILE, "utf8"), 10);
+    return Number.isInteger(value) && value > 0 ? value : 0;
+  } catch {
+    return 0;
+  }
+}
+
+/**
+ * Add operations to the max-total usage shared with later steps
+ * @param {number} count - Number of operations used by the current step
+ */
+function recordMaxTotalUsage(count) {
+  if (getMaxTotal() === 0 || count <= 0) {
+    return;
+  }
+  fs.mkdirSync(path.dirname(MAX_TOTAL_USAGE_FILE), { recursive: true });
+  fs.writeFileSync(MAX_TOTAL_USAGE_FILE, String(readMaxTotalUsage() + count), "utf8");
+}
+
+/**
+ * Limit the items of a dedicated step to the remaining max-total budget.
+ * Items are expected to be limited by the per-type max first.
+ * @template T
+ * @param {T[]} items - Items the step would process
+ * @param {string} type - Safe output type, used in log messages
+ * @returns {{items: T[], exceeded: number}} Items within the budget and the number of items dropped
+ */
+function limitToMaxTotal(items, type) {
+  const maxTotal = getMaxTotal();
+  if (maxTotal === 0) {
+    return { items, exceeded: 0 };
+  }
+
+  const remaining = Math.max(0, maxTotal - readMaxTotalUsage());
+  core.info(`Global max-total cap: ${remaining} of ${maxTotal} safe output operation(s) remaining`);
+  if (items.length <= remaining) {
+    return { items, exceeded: 0 };
+  }
+
+  const exceeded = items.length - remaining;
+  core.warning(`Skipping ${exceeded} ${type} operation(s): max-total of ${maxTotal} safe output operation(s) reached`);
+  return { items: items.slice(0, remaining), exceeded };
+}
+
+/**
+ * Fail the step for operations dropped by the max-total cap
+ * @param {number} exceeded - Number of operations dropped
+ * @param {string} type - Safe output type
+ */
+function failMaxTotalExceeded(exceeded, type) {
+  core.setFailed(`${ERR_VALIDATION}: ${exceeded} ${type} operation(s) exceeded the max-total limit of ${getMaxTotal()}`);
+}
+
+module.exports = { MAX_TOTAL_USAGE_FILE, getMaxTotal, readMaxTotalUsage, recordMaxTotalUsage, limitToMaxTotal, failMaxTotalExceeded };
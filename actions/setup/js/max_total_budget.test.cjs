@@ -0,0 +1,82 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import * as budget from "./max_total_budget.cjs";
+
+describe("max_total_budget.cjs", () => {
+  let mockCore;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      setFailed: vi.fn(),
+    };
+    global.core = mockCore;
+    fs.rmSync(budget.MAX_TOTAL_USAGE_FILE, { force: true });
+  });
+
+  afterEach(() => {
+    delete global.core;
+    delete process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL;
+    fs.rmSync(budget.MAX_TOTAL_USAGE_FILE, { force: true });
+  });
+
+  describe("getMaxTotal", () => {
+    it("should be unlimited by default", () => {
+      expect(budget.getMaxTotal()).toBe(0);
+    });
+
+    it("should read the cap from the environment", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "5";
+      expect(budget.getMaxTotal()).toBe(5);
+    });
+
+    it("should ignore invalid values", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "-1";
+      expect(budget.getMaxTotal()).toBe(0);
+    });
+  });
+
+  describe("recordMaxTotalUsage", () => {
+    it("should accumulate usage across steps", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "5";
+      budget.recordMaxTotalUsage(2);
+      budget.recordMaxTotalUsage(1);
+      expect(budget.readMaxTotalUsage()).toBe(3);
+    });
+
+    it("should not record usage without a cap", () => {
+      budget.recordMaxTotalUsage(2);
+      expect(fs.existsSync(budget.MAX_TOTAL_USAGE_FILE)).toBe(false);
+    });
+  });
+
+  describe("limitToMaxTotal", () => {
+    it("should keep all items without a cap", () => {
+      expect(budget.limitToMaxTotal(["a", "b"], "create_gist")).toEqual({ items: ["a", "b"], exceeded: 0 });
+    });
+
+    it("should limit items to the remaining budget", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "3";
+      budget.recordMaxTotalUsage(2);
+
+      expect(budget.limitToMaxTotal(["a", "b", "c"], "create_release")).toEqual({ items: ["a"], exceeded: 2 });
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Skipping 2 create_release operation(s)"));
+    });
+
+    it("should drop all items once the budget is used up", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "2";
+      budget.recordMaxTotalUsage(2);
+
+      expect(budget.limitToMaxTotal(["a"], "assign_to_agent")).toEqual({ items: [], exceeded: 1 });
+    });
+  });
+
+  describe("failMaxTotalExceeded", () => {
+    it("should fail the step with the dropped operations", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "2";
+      budget.failMaxTotalExceeded(1, "create_gist");
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("1 create_gist operation(s) exceeded the max-total limit of 2"));
+    });
+  });
+});
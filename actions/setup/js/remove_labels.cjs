@@ -104,6 +104,14 @@ async function main(config = {}) {
       };
     }
 
+    // Labels outside the allowlist are ignored so an agent cannot strip protected labels
+    if (allowedLabels.length > 0) {
+      const ignoredLabels = requestedLabels.filter(label => !allowedLabels.includes(label));
+      if (ignoredLabels.length > 0) {
+        core.info(`Ignoring ${ignoredLabels.length} label(s) not in the allowed list: ${ignoredLabels.join(", ")}`);
+      }
+    }
+
     // Use validation helper to sanitize and validate labels
     const labelsResult = validateLabels(requestedLabels, allowedLabels, maxCount, blockedPatterns);
     if (!labelsResult.valid) {
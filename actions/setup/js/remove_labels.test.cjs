@@ -246,6 +246,32 @@ describe("remove_labels", () => {
       expect(result.labelsRemoved).toEqual(["bug", "enhancement"]);
     });
 
+    it("should ignore attempts to remove labels outside the allowed list", async () => {
+      const handler = await main({
+        allowed: ["needs-triage"],
+        max: 10,
+      });
+
+      const removeLabelCalls = [];
+      mockGithub.rest.issues.removeLabel = async params => {
+        removeLabelCalls.push(params);
+        return {};
+      };
+
+      const result = await handler(
+        {
+          item_number: 100,
+          labels: ["security", "do-not-merge"],
+        },
+        {}
+      );
+
+      expect(result.success).toBe(true);
+      expect(result.labelsRemoved).toEqual([]);
+      expect(removeLabelCalls).toHaveLength(0);
+      expect(mockCore.infos).toContain("Ignoring 2 label(s) not in the allowed list: security, do-not-merge");
+    });
+
     it("should handle empty labels array", async () => {
       const handler = await main({ max: 10 });
 
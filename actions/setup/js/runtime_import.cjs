@@ -155,6 +155,7 @@ function isSafeExpression(expr) {
   // - github.aw.inputs.* (shared workflow inputs)
   // - inputs.* (workflow_call inputs)
   // - env.* (environment variables)
+  // - matrix.* (matrix values of the agent job)
   // Limit nesting depth to max 5 levels to prevent deep traversal attacks
   const dynamicPatterns = [
     /^(needs|steps)\.[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+){0,2}$/, // Max depth: needs.job.outputs.foo.bar (5 levels)
@@ -162,6 +163,7 @@ function isSafeExpression(expr) {
     /^github\.aw\.inputs\.[a-zA-Z0-9_-]+$/,
     /^inputs\.[a-zA-Z0-9_-]+$/,
     /^env\.[a-zA-Z0-9_-]+$/,
+    /^matrix\.[a-zA-Z0-9_-]+$/,
   ];
 
   for (const pattern of dynamicPatterns) {
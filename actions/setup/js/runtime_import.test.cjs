@@ -94,6 +94,7 @@ describe("runtime_import", () => {
         expect(isSafeExpression("github.event.inputs.repo")).toBe(!0);
         expect(isSafeExpression("inputs.repository")).toBe(!0);
         expect(isSafeExpression("env.MY_VAR")).toBe(!0);
+        expect(isSafeExpression("matrix.language")).toBe(!0);
       });
       it("should reject unsafe expressions", () => {
         expect(isSafeExpression("secrets.TOKEN")).toBe(!1);
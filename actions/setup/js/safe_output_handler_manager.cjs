@@ -20,6 +20,8 @@ const { getIssuesToAssignCopilot } = require("./create_issue.cjs");
 const { createReviewBuffer } = require("./pr_review_buffer.cjs");
 const { sanitizeContent } = require("./sanitize_content.cjs");
 const { createManifestLogger, ensureManifestExists, extractCreatedItemFromResult } = require("./safe_output_manifest.cjs");
+const { installApiThrottle } = require("./api_throttle.cjs");
+const { getMaxTotal, recordMaxTotalUsage } = require("./max_total_budget.cjs");
 
 /**
  * Handler map configuration
@@ -68,10 +70,10 @@ const HANDLER_MAP = {
  * Message types handled by standalone steps (not through the handler manager)
  * These types should not trigger warnings when skipped by the handler manager
  *
- * Standalone types: assign_to_agent, create_agent_session, upload_asset, noop
+ * Standalone types: assign_to_agent, create_agent_session, create_gist, create_release, create_pull_request_review, upload_asset, noop
  *   - Have dedicated processing steps with specialized logic
  */
-const STANDALONE_STEP_TYPES = new Set(["assign_to_agent", "create_agent_session", "upload_asset", "noop"]);
+const STANDALONE_STEP_TYPES = new Set(["assign_to_agent", "create_agent_session", "create_gist", "create_release", "create_pull_request_review", "upload_asset", "noop"]);
 
 /**
  * Code-push safe output types that must succeed before remaining outputs are processed.
@@ -79,6 +81,48 @@ const STANDALONE_STEP_TYPES = new Set(["assign_to_agent", "create_agent_session"
  */
 const CODE_PUSH_TYPES = new Set(["push_to_pull_request_branch", "create_pull_request"]);
 
+/**
+ * Informational safe output types that never write to GitHub.
+ * These types do not count towards the global max-total cap.
+ */
+const INFORMATIONAL_TYPES = new Set(["noop", "missing_tool", "missing_data"]);
+
+/**
+ * Export the number and URL of the first issue and pull request created while processing
+ * messages as step outputs, so downstream jobs can reference them through the job outputs.
+ * An issue created as a create_pull_request fallback is exported as the created issue when
+ * no create_issue message created one.
+ * @param {Array<any>} results - Processing results returned by processMessages
+ */
+function exportCreatedItemOutputs(results) {
+  const successful = results.filter(r => r.success && r.result);
+  const createdIssue = successful.find(r => r.type === "create_issue" && r.result.number);
+  const createdPullRequest = successful.find(r => r.type === "create_pull_request" && r.result.pull_request_number);
+  const fallbackIssue = successful.find(r => r.type === "create_pull_request" && r.result.fallback_used && r.result.issue_number);
+
+  let issueNumber = "";
+  let issueUrl = "";
+  if (createdIssue) {
+    issueNumber = String(createdIssue.result.number);
+    issueUrl = createdIssue.result.url || "";
+  } else if (fallbackIssue) {
+    issueNumber = String(fallbackIssue.result.issue_number);
+    issueUrl = fallbackIssue.result.issue_url || "";
+  }
+
+  core.setOutput("issue_number", issueNumber);
+  core.setOutput("issue_url", issueUrl);
+  core.setOutput("pr_number", createdPullRequest ? String(createdPullRequest.result.pull_request_number) : "");
+  core.setOutput("pr_url", createdPullRequest ? createdPullRequest.result.pull_request_url || "" : "");
+
+  if (issueNumber) {
+    core.info(`Exported created issue #${issueNumber}`);
+  }
+  if (createdPullRequest) {
+    core.info(`Exported created pull request #${createdPullRequest.result.pull_request_number}`);
+  }
+}
+
 /**
  * Load configuration for safe outputs
  * Reads configuration from GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG environment variable
@@ -214,7 +258,7 @@ function collectMissingMessages(messages) {
  * @param {Map<string, Function>} messageHandlers - Map of message handler functions
  * @param {Array<Object>} messages - Array of safe output messages
  * @param {((item: {type: string, url?: string, number?: number, repo?: string, temporaryId?: string}) => void)|null} [onItemCreated] - Optional callback invoked after each successful create operation (for manifest logging)
- * @returns {Promise<{success: boolean, results: Array<any>, temporaryIdMap: Object, outputsWithUnresolvedIds: Array<any>, missings: Object, codePushFailures: Array<{type: string, error: string}>}>}
+ * @returns {Promise<{success: boolean, results: Array<any>, temporaryIdMap: Object, outputsWithUnresolvedIds: Array<any>, missings: Object, codePushFailures: Array<{type: string, error: string}>, maxTotalCount: number, maxTotalExceeded: number}>}
  */
 async function processMessages(messageHandlers, messages, onItemCreated = null) {
   const results = [];
@@ -243,6 +287,15 @@ async function processMessages(messageHandlers, messages, onItemCreated = null)
   /** @type {Array<{type: string, error: string}>} */
   const codePushFailures = [];
 
+  // Track the global max-total cap. Per-type max limits are enforced by each handler first;
+  // only operations accepted by a handler (or forwarded to a standalone step) count towards the cap.
+  const maxTotal = getMaxTotal();
+  let totalCount = 0;
+  let maxTotalExceeded = 0;
+  if (maxTotal > 0) {
+    core.info(`Global max-total cap: ${maxTotal} safe output operation(s)`);
+  }
+
   core.info(`Processing ${messages.length} message(s) in order of appearance...`);
 
   // Process messages in order of appearance
@@ -269,6 +322,23 @@ async function processMessages(messageHandlers, messages, onItemCreated = null)
       continue;
     }
 
+    // Global cap: cancel write operations once max-total operations have been accepted.
+    // Types handled by standalone steps are counted by those steps against the remaining budget.
+    const countsTowardsMaxTotal = maxTotal > 0 && !INFORMATIONAL_TYPES.has(messageType) && !STANDALONE_STEP_TYPES.has(messageType);
+    if (countsTowardsMaxTotal && totalCount >= maxTotal) {
+      const cancelReason = `Cancelled: max-total of ${maxTotal} safe output operation(s) reached`;
+      core.warning(`⏭ Message ${i + 1} (${messageType}) cancelled — ${cancelReason}`);
+      results.push({
+        type: messageType,
+        messageIndex: i,
+        success: false,
+        cancelled: true,
+        reason: cancelReason,
+      });
+      maxTotalExceeded++;
+      continue;
+    }
+
     const messageHandler = messageHandlers.get(messageType);
 
     if (!messageHandler) {
@@ -332,6 +402,9 @@ async function processMessages(messageHandlers, messages, onItemCreated = null)
       // Check if the operation was deferred due to unresolved temporary IDs
       if (result && result.deferred === true) {
         core.info(`⏸ Message ${i + 1} (${messageType}) deferred - will retry after first pass`);
+        if (countsTowardsMaxTotal) {
+          totalCount++;
+        }
         deferredMessages.push({
           type: messageType,
           message: message,
@@ -403,6 +476,9 @@ async function processMessages(messageHandlers, messages, onItemCreated = null)
         success: true,
         result,
       });
+      if (countsTowardsMaxTotal) {
+        totalCount++;
+      }
 
       // Log to manifest if this was a create operation
       if (onItemCreated) {
@@ -553,6 +629,8 @@ async function processMessages(messageHandlers, messages, onItemCreated = null)
     outputsWithUnresolvedIds,
     missings,
     codePushFailures,
+    maxTotalCount: totalCount,
+    maxTotalExceeded,
   };
 }
 
@@ -795,6 +873,9 @@ async function main() {
     const { resetIssuesToAssignCopilot } = require("./create_issue.cjs");
     resetIssuesToAssignCopilot();
 
+    // Space out write API calls (opt-in) and back off on rate limit responses
+    installApiThrottle(github);
+
     // Load configuration
     const config = loadConfig();
     core.debug(`Configuration: ${JSON.stringify(Object.keys(config))}`);
@@ -896,6 +977,7 @@ async function main() {
     const successCount = processingResult.results.filter(r => r.success).length;
     const failureCount = processingResult.results.filter(r => !r.success && !r.deferred && !r.skipped && !r.cancelled).length;
     const cancelledCount = processingResult.results.filter(r => r.cancelled).length;
+    const maxTotalExceeded = processingResult.maxTotalExceeded || 0;
     const deferredCount = processingResult.results.filter(r => r.deferred).length;
     const skippedStandaloneResults = processingResult.results.filter(r => r.skipped && r.reason === "Handled by standalone step");
     const skippedNoHandlerResults = processingResult.results.filter(r => !r.success && !r.skipped && r.error?.includes("No handler loaded"));
@@ -905,7 +987,7 @@ async function main() {
     core.info(`Successful: ${successCount}`);
     core.info(`Failed: ${failureCount}`);
     if (cancelledCount > 0) {
-      core.info(`Cancelled (code push failed): ${cancelledCount}`);
+      core.info(`Cancelled: ${cancelledCount}`);
     }
     if (deferredCount > 0) {
       core.info(`Deferred: ${deferredCount}`);
@@ -926,8 +1008,8 @@ async function main() {
     if (failureCount > 0) {
       core.warning(`${failureCount} message(s) failed to process`);
     }
-    if (cancelledCount > 0) {
-      core.warning(`${cancelledCount} message(s) were cancelled because a code push operation failed`);
+    if (cancelledCount - maxTotalExceeded > 0) {
+      core.warning(`${cancelledCount - maxTotalExceeded} message(s) were cancelled because a code push operation failed`);
     }
     if (skippedNoHandlerResults.length > 0) {
       core.warning(`${skippedNoHandlerResults.length} message(s) were skipped because no handler was loaded. Check your workflow's safe-outputs configuration.`);
@@ -941,6 +1023,9 @@ async function main() {
     // Export processed count for consistency with project handler
     core.setOutput("processed_count", successCount);
 
+    // Export the created issue and pull request for downstream jobs
+    exportCreatedItemOutputs(processingResult.results);
+
     // Export issues that need copilot assignment (if any)
     const issuesToAssignCopilot = getIssuesToAssignCopilot();
     if (issuesToAssignCopilot.length > 0) {
@@ -989,6 +1074,16 @@ async function main() {
     // so this is a safety net for cases where we never reached the logger creation.
     if (!isStaged) ensureManifestExists();
 
+    // Share the operations counted towards max-total with the dedicated steps that run next
+    recordMaxTotalUsage(processingResult.maxTotalCount || 0);
+
+    // Fail the step when the agent requested more operations than the global cap allows.
+    // The dedicated steps still run, limited to the remaining budget.
+    if (maxTotalExceeded > 0) {
+      core.setFailed(`${ERR_VALIDATION}: ${maxTotalExceeded} safe output operation(s) exceeded the max-total limit of ${getMaxTotal()}`);
+      return;
+    }
+
     core.info("Safe Output Handler Manager completed");
   } catch (error) {
     core.setFailed(`${ERR_VALIDATION}: Handler manager failed: ${getErrorMessage(error)}`);
@@ -1005,4 +1100,4 @@ async function main() {
   }
 }
 
-module.exports = { main, loadConfig, loadHandlers, processMessages };
+module.exports = { main, loadConfig, loadHandlers, processMessages, getMaxTotal, exportCreatedItemOutputs };
@@ -1,7 +1,7 @@
 // @ts-check
 
 import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
-import { loadConfig, loadHandlers, processMessages } from "./safe_output_handler_manager.cjs";
+import { loadConfig, loadHandlers, processMessages, getMaxTotal, exportCreatedItemOutputs } from "./safe_output_handler_manager.cjs";
 
 describe("Safe Output Handler Manager", () => {
   beforeEach(() => {
@@ -20,6 +20,7 @@ describe("Safe Output Handler Manager", () => {
     // Clean up environment variables
     delete process.env.GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG;
     delete process.env.GH_AW_TRACKER_LABEL;
+    delete process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL;
   });
 
   describe("loadConfig", () => {
@@ -887,4 +888,143 @@ describe("Safe Output Handler Manager", () => {
       expect(result.codePushFailures).toHaveLength(0);
     });
   });
+
+  describe("max-total cap", () => {
+    it("should read the cap from the environment", () => {
+      expect(getMaxTotal()).toBe(0);
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "3";
+      expect(getMaxTotal()).toBe(3);
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "invalid";
+      expect(getMaxTotal()).toBe(0);
+    });
+
+    it("should cancel operations across types once the cap is reached", async () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "2";
+      const messages = [
+        { type: "create_issue", title: "Issue" },
+        { type: "add_comment", body: "Comment" },
+        { type: "create_issue", title: "Another issue" },
+        { type: "add_comment", body: "Another comment" },
+      ];
+
+      const issueHandler = vi.fn().mockResolvedValue({ repo: "owner/repo", number: 1 });
+      const commentHandler = vi.fn().mockResolvedValue([{ _tracking: null }]);
+      const handlers = new Map([
+        ["create_issue", issueHandler],
+        ["add_comment", commentHandler],
+      ]);
+
+      const result = await processMessages(handlers, messages);
+
+      expect(result.maxTotalExceeded).toBe(2);
+      expect(result.maxTotalCount).toBe(2);
+      expect(result.results[0].success).toBe(true);
+      expect(result.results[1].success).toBe(true);
+      expect(result.results[2].cancelled).toBe(true);
+      expect(result.results[2].reason).toContain("max-total of 2");
+      expect(result.results[3].cancelled).toBe(true);
+      expect(issueHandler).toHaveBeenCalledTimes(1);
+    });
+
+    it("should leave standalone step types to their dedicated steps", async () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "1";
+      const messages = [
+        { type: "create_issue", title: "Issue" },
+        { type: "create_release", tag: "v1.0.0" },
+      ];
+
+      const issueHandler = vi.fn().mockResolvedValue({ repo: "owner/repo", number: 1 });
+      const handlers = new Map([["create_issue", issueHandler]]);
+
+      const result = await processMessages(handlers, messages);
+
+      // The dedicated create_release step enforces the remaining budget itself
+      expect(result.maxTotalExceeded).toBe(0);
+      expect(result.maxTotalCount).toBe(1);
+      expect(result.results[1].skipped).toBe(true);
+      expect(result.results[1].cancelled).toBeUndefined();
+    });
+
+    it("should apply per-type max before the global cap", async () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "2";
+      const messages = [
+        { type: "create_issue", title: "Issue" },
+        { type: "create_issue", title: "Rejected by per-type max" },
+        { type: "add_comment", body: "Comment" },
+      ];
+
+      const issueHandler = vi
+        .fn()
+        .mockResolvedValueOnce({ repo: "owner/repo", number: 1 })
+        .mockResolvedValueOnce({ success: false, error: "Max count of 1 reached" });
+      const commentHandler = vi.fn().mockResolvedValue([{ _tracking: null }]);
+      const handlers = new Map([
+        ["create_issue", issueHandler],
+        ["add_comment", commentHandler],
+      ]);
+
+      const result = await processMessages(handlers, messages);
+
+      // The rejected issue does not consume the global cap, so the comment is still processed
+      expect(result.maxTotalExceeded).toBe(0);
+      expect(result.results[1].success).toBe(false);
+      expect(result.results[1].cancelled).toBeUndefined();
+      expect(result.results[2].success).toBe(true);
+      expect(commentHandler).toHaveBeenCalled();
+    });
+
+    it("should not count informational outputs towards the cap", async () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_TOTAL = "1";
+      const messages = [
+        { type: "noop", message: "Nothing to do" },
+        { type: "missing_tool", tool: "docker", reason: "Not available" },
+        { type: "create_issue", title: "Issue" },
+      ];
+
+      const issueHandler = vi.fn().mockResolvedValue({ repo: "owner/repo", number: 1 });
+      const handlers = new Map([["create_issue", issueHandler]]);
+
+      const result = await processMessages(handlers, messages);
+
+      expect(result.maxTotalExceeded).toBe(0);
+      expect(result.results.find(r => r.type === "create_issue").success).toBe(true);
+    });
+  });
+
+  describe("exportCreatedItemOutputs", () => {
+    it("should export the first created issue and pull request", () => {
+      exportCreatedItemOutputs([
+        { type: "add_comment", success: true, result: [{ id: 1 }] },
+        { type: "create_issue", success: false, error: "failed" },
+        { type: "create_issue", success: true, result: { repo: "owner/repo", number: 42, url: "https://github.com/owner/repo/issues/42" } },
+        { type: "create_issue", success: true, result: { repo: "owner/repo", number: 43, url: "https://github.com/owner/repo/issues/43" } },
+        { type: "create_pull_request", success: true, result: { pull_request_number: 7, pull_request_url: "https://github.com/owner/repo/pull/7" } },
+      ]);
+
+      expect(core.setOutput).toHaveBeenCalledWith("issue_number", "42");
+      expect(core.setOutput).toHaveBeenCalledWith("issue_url", "https://github.com/owner/repo/issues/42");
+      expect(core.setOutput).toHaveBeenCalledWith("pr_number", "7");
+      expect(core.setOutput).toHaveBeenCalledWith("pr_url", "https://github.com/owner/repo/pull/7");
+    });
+
+    it("should export a pull request fallback issue as the created issue", () => {
+      exportCreatedItemOutputs([
+        { type: "create_pull_request", success: true, result: { fallback_used: true, issue_number: 9, issue_url: "https://github.com/owner/repo/issues/9" } },
+      ]);
+
+      expect(core.setOutput).toHaveBeenCalledWith("issue_number", "9");
+      expect(core.setOutput).toHaveBeenCalledWith("issue_url", "https://github.com/owner/repo/issues/9");
+      expect(core.setOutput).toHaveBeenCalledWith("pr_number", "");
+      expect(core.setOutput).toHaveBeenCalledWith("pr_url", "");
+    });
+
+    it("should export empty outputs when nothing was created", () => {
+      exportCreatedItemOutputs([{ type: "create_issue", success: false, error: "failed" }]);
+
+      expect(core.setOutput).toHaveBeenCalledWith("issue_number", "");
+      expect(core.setOutput).toHaveBeenCalledWith("issue_url", "");
+      expect(core.setOutput).toHaveBeenCalledWith("pr_number", "");
+      expect(core.setOutput).toHaveBeenCalledWith("pr_url", "");
+    });
+  });
 });
@@ -0,0 +1,42 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { renderTemplate } = require("./messages_core.cjs");
+
+/**
+ * Build the run context placeholders available to title-template and body-template.
+ * The compiler only allows these placeholders (plus the field's own value), so
+ * templates cannot reference arbitrary expressions.
+ * @returns {Record<string, string>}
+ */
+function buildSafeOutputTemplateContext() {
+  return {
+    workflow: process.env.GH_AW_WORKFLOW_NAME || context.workflow || "",
+    run_id: String(context.runId ?? ""),
+    actor: context.actor || "",
+    event: context.eventName || "",
+  };
+}
+
+/**
+ * Apply a title-template or body-template to an agent-provided value.
+ * The value is substituted for the {title} or {body} placeholder; when the template
+ * does not contain it, the value is appended after the rendered template.
+ * @param {string | undefined} template - Normalized template from the handler config
+ * @param {"title" | "body"} field - Field the template applies to
+ * @param {string} value - Agent-provided title or body
+ * @returns {string} The templated value, or the original value when no template is configured
+ */
+function applySafeOutputTemplate(template, field, value) {
+  if (!template) {
+    return value;
+  }
+
+  const rendered = renderTemplate(template, { ...buildSafeOutputTemplateContext(), [field]: value });
+  if (template.includes(`{${field}}`)) {
+    return rendered;
+  }
+  return field === "title" ? `${rendered} ${value}`.trim() : `${rendered}\n\n${value}`;
+}
+
+module.exports = { applySafeOutputTemplate, buildSafeOutputTemplateContext };
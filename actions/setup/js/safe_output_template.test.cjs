@@ -0,0 +1,55 @@
+// @ts-check
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+
+const { applySafeOutputTemplate, buildSafeOutputTemplateContext } = require("./safe_output_template.cjs");
+
+describe("safe_output_template", () => {
+  beforeEach(() => {
+    global.context = { runId: 12345, actor: "octocat", eventName: "issues", workflow: "Triage" };
+    process.env.GH_AW_WORKFLOW_NAME = "Issue Triage";
+  });
+
+  afterEach(() => {
+    delete global.context;
+    delete process.env.GH_AW_WORKFLOW_NAME;
+  });
+
+  describe("buildSafeOutputTemplateContext", () => {
+    it("should expose only the run context placeholders", () => {
+      expect(buildSafeOutputTemplateContext()).toEqual({
+        workflow: "Issue Triage",
+        run_id: "12345",
+        actor: "octocat",
+        event: "issues",
+      });
+    });
+
+    it("should fall back to the context workflow name", () => {
+      delete process.env.GH_AW_WORKFLOW_NAME;
+      expect(buildSafeOutputTemplateContext().workflow).toBe("Triage");
+    });
+  });
+
+  describe("applySafeOutputTemplate", () => {
+    it("should return the value unchanged without a template", () => {
+      expect(applySafeOutputTemplate("", "title", "Bug")).toBe("Bug");
+      expect(applySafeOutputTemplate(undefined, "body", "Details")).toBe("Details");
+    });
+
+    it("should substitute the field placeholder", () => {
+      expect(applySafeOutputTemplate("[{event}] {title} (run {run_id})", "title", "Bug")).toBe("[issues] Bug (run 12345)");
+    });
+
+    it("should append the title when the template has no title placeholder", () => {
+      expect(applySafeOutputTemplate("[{workflow}]", "title", "Bug")).toBe("[Issue Triage] Bug");
+    });
+
+    it("should append the body after the rendered template", () => {
+      expect(applySafeOutputTemplate("Triggered by @{actor}", "body", "Details")).toBe("Triggered by @octocat\n\nDetails");
+    });
+
+    it("should not expand placeholders inside the agent-provided value", () => {
+      expect(applySafeOutputTemplate("{body}", "body", "literal {run_id}")).toBe("literal {run_id}");
+    });
+  });
+});
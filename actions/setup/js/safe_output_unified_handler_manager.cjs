@@ -27,6 +27,7 @@ const { sortSafeOutputMessages } = require("./safe_output_topological_sort.cjs")
 const { loadCustomSafeOutputJobTypes } = require("./safe_output_helpers.cjs");
 const { createReviewBuffer } = require("./pr_review_buffer.cjs");
 const { createManifestLogger, ensureManifestExists, extractCreatedItemFromResult } = require("./safe_output_manifest.cjs");
+const { installApiThrottle } = require("./api_throttle.cjs");
 
 /**
  * Handler map configuration for regular handlers
@@ -84,10 +85,10 @@ const PROJECT_HANDLER_MAP = {
  * Message types handled by standalone steps (not through the handler manager)
  * These types should not trigger warnings when skipped by the handler manager
  *
- * Other standalone types: assign_to_agent, create_agent_session, upload_asset, noop
+ * Other standalone types: assign_to_agent, create_agent_session, create_gist, create_release, create_pull_request_review, upload_asset, noop
  *   - Have dedicated processing steps with specialized logic
  */
-const STANDALONE_STEP_TYPES = new Set(["assign_to_agent", "create_agent_session", "upload_asset", "noop"]);
+const STANDALONE_STEP_TYPES = new Set(["assign_to_agent", "create_agent_session", "create_gist", "create_release", "create_pull_request_review", "upload_asset", "noop"]);
 
 /**
  * Project-related message types that are handled by project handlers
@@ -985,6 +986,9 @@ async function main() {
     const { resetIssuesToAssignCopilot } = require("./create_issue.cjs");
     resetIssuesToAssignCopilot();
 
+    // Space out write API calls (opt-in) and back off on rate limit responses
+    installApiThrottle(github);
+
     // Load configuration
     const configs = loadConfig();
     core.debug(`Configuration: regular=${JSON.stringify(Object.keys(configs.regular))}, project=${JSON.stringify(Object.keys(configs.project))}`);
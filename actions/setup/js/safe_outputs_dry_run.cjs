@@ -0,0 +1,93 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const fs = require("fs");
+const path = require("path");
+const { loadAgentOutput } = require("./load_agent_output.cjs");
+const { sanitizeContent } = require("./sanitize_content.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_SYSTEM } = require("./error_codes.cjs");
+
+/**
+ * Recursively sanitize every string value in a safe output item.
+ * @param {any} value - Value to sanitize
+ * @returns {any} Sanitized copy of the value
+ */
+function sanitizePayload(value) {
+  if (typeof value === "string") {
+    return sanitizeContent(value);
+  }
+  if (Array.isArray(value)) {
+    return value.map(sanitizePayload);
+  }
+  if (value && typeof value === "object") {
+    /** @type {Record<string, any>} */
+    const result = {};
+    for (const [key, entry] of Object.entries(value)) {
+      result[key] = sanitizePayload(entry);
+    }
+    return result;
+  }
+  return value;
+}
+
+/**
+ * Parse a comma-separated type filter (GH_AW_DRY_RUN_TYPES or GH_AW_DRY_RUN_EXCLUDE_TYPES).
+ * @param {string | undefined} typesEnv - Value of the filter environment variable
+ * @returns {Set<string> | null} Set of types, or null when the filter is empty
+ */
+function parseDryRunTypes(typesEnv) {
+  const types = (typesEnv || "")
+    .split(",")
+    .map(type => type.trim())
+    .filter(Boolean);
+  return types.length > 0 ? new Set(types) : null;
+}
+
+/**
+ * Log the sanitized payload of every safe output item instead of executing it.
+ * The payloads are written to the step summary and to GH_AW_DRY_RUN_PAYLOAD_PATH
+ * (one JSON object per line) so they can be uploaded as an artifact.
+ * @returns {Promise<void>}
+ */
+async function main() {
+  core.setOutput("item_count", "0");
+
+  const result = loadAgentOutput();
+  if (!result.success) {
+    return;
+  }
+
+  const types = parseDryRunTypes(process.env.GH_AW_DRY_RUN_TYPES);
+  // Types logged by their own safe output job, such as upload_asset
+  const excludedTypes = parseDryRunTypes(process.env.GH_AW_DRY_RUN_EXCLUDE_TYPES);
+  const items = result.items.filter(item => (!types || types.has(item.type)) && !(excludedTypes && excludedTypes.has(item.type))).map(sanitizePayload);
+
+  core.setOutput("item_count", String(items.length));
+  if (items.length === 0) {
+    core.info("🧪 Dry run: no safe output items to log");
+    return;
+  }
+
+  const payloadPath = process.env.GH_AW_DRY_RUN_PAYLOAD_PATH || "/tmp/gh-aw/safe-outputs-dry-run.jsonl";
+  try {
+    fs.mkdirSync(path.dirname(payloadPath), { recursive: true });
+    fs.appendFileSync(payloadPath, items.map(item => JSON.stringify(item)).join("\n") + "\n");
+  } catch (error) {
+    core.setFailed(`${ERR_SYSTEM}: Failed to write dry-run payloads: ${getErrorMessage(error)}`);
+    return;
+  }
+
+  let summaryContent = "## 🧪 Dry Run: Safe Outputs\n\n";
+  summaryContent += `The following ${items.length} safe output item(s) would have been processed if dry-run mode was disabled:\n\n`;
+  for (const [index, item] of items.entries()) {
+    summaryContent += `### ${index + 1}. \`${item.type}\`\n\n`;
+    summaryContent += "```json\n" + JSON.stringify(item, null, 2) + "\n```\n\n";
+    core.info(`🧪 Dry run: would process ${item.type}: ${JSON.stringify(item)}`);
+  }
+
+  await core.summary.addRaw(summaryContent).write();
+  core.info(`📝 Logged ${items.length} safe output item(s) to ${payloadPath}`);
+}
+
+module.exports = { main, sanitizePayload, parseDryRunTypes };
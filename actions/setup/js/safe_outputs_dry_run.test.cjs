@@ -0,0 +1,120 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import path from "path";
+
+describe("safe_outputs_dry_run.cjs", () => {
+  let mockCore, testOutputFile, payloadFile;
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      debug: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setFailed: vi.fn(),
+      setOutput: vi.fn(),
+      summary: { addRaw: vi.fn().mockReturnThis(), write: vi.fn().mockResolvedValue() },
+    };
+    global.core = mockCore;
+    global.github = { rest: {} };
+    testOutputFile = `/tmp/test_dry_run_output_${Date.now()}.json`;
+    payloadFile = `/tmp/test_dry_run_payload_${Date.now()}.jsonl`;
+    process.env.GH_AW_DRY_RUN_PAYLOAD_PATH = payloadFile;
+  });
+
+  afterEach(() => {
+    delete global.core;
+    delete global.github;
+    delete process.env.GH_AW_AGENT_OUTPUT;
+    delete process.env.GH_AW_DRY_RUN_TYPES;
+    delete process.env.GH_AW_DRY_RUN_EXCLUDE_TYPES;
+    delete process.env.GH_AW_DRY_RUN_PAYLOAD_PATH;
+    for (const file of [testOutputFile, payloadFile]) {
+      if (fs.existsSync(file)) {
+        fs.unlinkSync(file);
+      }
+    }
+  });
+
+  const createAgentOutput = items => {
+    fs.writeFileSync(testOutputFile, JSON.stringify({ items }));
+    process.env.GH_AW_AGENT_OUTPUT = testOutputFile;
+  };
+
+  const runScript = async () => {
+    const scriptPath = path.join(process.cwd(), "safe_outputs_dry_run.cjs");
+    delete require.cache[require.resolve(scriptPath)];
+    const { main } = require(scriptPath);
+    await main();
+  };
+
+  it("should log every item to the step summary and payload file", async () => {
+    createAgentOutput([
+      { type: "create_issue", title: "Bug", body: "Details" },
+      { type: "add_comment", body: "Hello" },
+    ]);
+    await runScript();
+
+    const summary = mockCore.summary.addRaw.mock.calls[0][0];
+    expect(summary).toContain("## 🧪 Dry Run: Safe Outputs");
+    expect(summary).toContain("`create_issue`");
+    expect(summary).toContain("`add_comment`");
+
+    const lines = fs.readFileSync(payloadFile, "utf8").trim().split("\n");
+    expect(lines).toHaveLength(2);
+    expect(JSON.parse(lines[0])).toMatchObject({ type: "create_issue", title: "Bug" });
+    expect(mockCore.setOutput).toHaveBeenCalledWith("item_count", "2");
+  });
+
+  it("should only log items matching GH_AW_DRY_RUN_TYPES", async () => {
+    process.env.GH_AW_DRY_RUN_TYPES = "add_comment";
+    createAgentOutput([
+      { type: "create_issue", title: "Bug", body: "Details" },
+      { type: "add_comment", body: "Hello" },
+    ]);
+    await runScript();
+
+    const lines = fs.readFileSync(payloadFile, "utf8").trim().split("\n");
+    expect(lines).toHaveLength(1);
+    expect(JSON.parse(lines[0]).type).toBe("add_comment");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("item_count", "1");
+  });
+
+  it("should not log items excluded by GH_AW_DRY_RUN_EXCLUDE_TYPES", async () => {
+    process.env.GH_AW_DRY_RUN_EXCLUDE_TYPES = "upload_asset";
+    createAgentOutput([
+      { type: "add_comment", body: "Hello" },
+      { type: "upload_asset", path: "/tmp/gh-aw/chart.png" },
+    ]);
+    await runScript();
+
+    const lines = fs.readFileSync(payloadFile, "utf8").trim().split("\n");
+    expect(lines).toHaveLength(1);
+    expect(JSON.parse(lines[0]).type).toBe("add_comment");
+    expect(mockCore.summary.addRaw.mock.calls[0][0]).not.toContain("`upload_asset`");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("item_count", "1");
+  });
+
+  it("should skip writing when there are no matching items", async () => {
+    process.env.GH_AW_DRY_RUN_TYPES = "create_discussion";
+    createAgentOutput([{ type: "create_issue", title: "Bug", body: "Details" }]);
+    await runScript();
+
+    expect(fs.existsSync(payloadFile)).toBe(false);
+    expect(mockCore.summary.addRaw).not.toHaveBeenCalled();
+    expect(mockCore.setOutput).toHaveBeenCalledWith("item_count", "0");
+  });
+
+  it("should parse the type filter", () => {
+    const { parseDryRunTypes } = require(path.join(process.cwd(), "safe_outputs_dry_run.cjs"));
+    expect(parseDryRunTypes(undefined)).toBeNull();
+    expect(parseDryRunTypes(" ,")).toBeNull();
+    expect([...parseDryRunTypes("create_issue, add_comment")]).toEqual(["create_issue", "add_comment"]);
+  });
+
+  it("should sanitize nested string values", () => {
+    const { sanitizePayload } = require(path.join(process.cwd(), "safe_outputs_dry_run.cjs"));
+    const result = sanitizePayload({ type: "create_issue", labels: ["bug"], count: 3, nested: { body: "text" } });
+    expect(result).toEqual({ type: "create_issue", labels: ["bug"], count: 3, nested: { body: "text" } });
+  });
+});
@@ -9,7 +9,7 @@ const { estimateTokens } = require("./estimate_tokens.cjs");
 const { writeLargeContentToFile } = require("./write_large_content_to_file.cjs");
 const { getCurrentBranch } = require("./get_current_branch.cjs");
 const { getBaseBranch } = require("./get_base_branch.cjs");
-const { generateGitPatch } = require("./generate_git_patch.cjs");
+const { generateGitPatch, getCreatePullRequestPatchOptions } = require("./generate_git_patch.cjs");
 const { enforceCommentLimits } = require("./comment_limit_helpers.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { ERR_CONFIG, ERR_SYSTEM, ERR_VALIDATION } = require("./error_codes.cjs");
@@ -229,7 +229,7 @@ function createHandlers(server, appendSafeOutput, config = {}) {
 
     // Generate git patch
     server.debug(`Generating patch for create_pull_request with branch: ${entry.branch}`);
-    const patchResult = generateGitPatch(entry.branch);
+    const patchResult = generateGitPatch(entry.branch, getCreatePullRequestPatchOptions());
 
     if (!patchResult.success) {
       // Patch generation failed or patch is empty
@@ -256,6 +256,12 @@ function createHandlers(server, appendSafeOutput, config = {}) {
     // prettier-ignore
     server.debug(`Patch generated successfully: ${patchResult.patchPath} (${patchResult.patchSize} bytes, ${patchResult.patchLines} lines)`);
 
+    // Binary files are applied from the patch but cannot be reviewed in its preview
+    const binaryFiles = patchResult.binaryFiles || [];
+    if (binaryFiles.length > 0) {
+      server.debug(`WARNING: Patch includes binary files: ${binaryFiles.join(", ")}`);
+    }
+
     // Store the patch path in the entry so consumers know which file to use
     entry.patch_path = patchResult.patchPath;
 
@@ -271,6 +277,7 @@ function createHandlers(server, appendSafeOutput, config = {}) {
               size: patchResult.patchSize,
               lines: patchResult.patchLines,
             },
+            ...(binaryFiles.length > 0 && { warning: `The patch includes binary files: ${binaryFiles.join(", ")}` }),
           }),
         },
       ],
@@ -329,6 +336,12 @@ function createHandlers(server, appendSafeOutput, config = {}) {
     // prettier-ignore
     server.debug(`Patch generated successfully: ${patchResult.patchPath} (${patchResult.patchSize} bytes, ${patchResult.patchLines} lines)`);
 
+    // Binary files are applied from the patch but cannot be reviewed in its preview
+    const binaryFiles = patchResult.binaryFiles || [];
+    if (binaryFiles.length > 0) {
+      server.debug(`WARNING: Patch includes binary files: ${binaryFiles.join(", ")}`);
+    }
+
     // Store the patch path in the entry so consumers know which file to use
     entry.patch_path = patchResult.patchPath;
 
@@ -344,6 +357,7 @@ function createHandlers(server, appendSafeOutput, config = {}) {
               size: patchResult.patchSize,
               lines: patchResult.patchLines,
             },
+            ...(binaryFiles.length > 0 && { warning: `The patch includes binary files: ${binaryFiles.join(", ")}` }),
           }),
         },
       ],
@@ -63,6 +63,7 @@ describe("safe_outputs_handlers", () => {
     delete process.env.GH_AW_ASSETS_BRANCH;
     delete process.env.GH_AW_ASSETS_MAX_SIZE_KB;
     delete process.env.GH_AW_ASSETS_ALLOWED_EXTS;
+    delete process.env.GH_AW_PATCH_BASE;
   });
 
   describe("defaultHandler", () => {
@@ -345,6 +346,18 @@ describe("safe_outputs_handlers", () => {
     });
   });
 
+  describe("create_pull_request patch options", () => {
+    it("should apply the patch base to create_pull_request only", () => {
+      process.env.GH_AW_PATCH_BASE = "does-not-exist";
+
+      const createResult = handlers.createPullRequestHandler({ branch: "feature-branch", title: "Test PR", body: "Test description" });
+      expect(JSON.parse(createResult.content[0].text).error).toContain("GH_AW_PATCH_BASE");
+
+      const pushResult = handlers.pushToPullRequestBranchHandler({ branch: "feature-branch" });
+      expect(JSON.parse(pushResult.content[0].text).error).not.toContain("GH_AW_PATCH_BASE");
+    });
+  });
+
   describe("handler structure", () => {
     it("should export all required handlers", () => {
       expect(handlers.defaultHandler).toBeDefined();
@@ -0,0 +1,61 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+// substitute_matrix_values.cjs
+// Replaces ${{ matrix.<key> }} placeholders in the prompt file with the values of the current matrix leg.
+// The prompt is built in the activation job, which does not run as part of the matrix, so matrix
+// expressions are left untouched there and resolved here in each agent job leg.
+
+const fs = require("fs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { ERR_CONFIG, ERR_VALIDATION } = require("./error_codes.cjs");
+
+/**
+ * Replaces simple ${{ matrix.<key> }} expressions with values from the matrix object.
+ * Expressions that reference keys not present in the matrix are left unchanged.
+ * @param {string} content - The prompt content
+ * @param {Record<string, any>} matrix - The matrix values for the current leg
+ * @returns {string} - The content with matrix values substituted
+ */
+function substituteMatrixValues(content, matrix) {
+  return content.replace(/\$\{\{\s*matrix\.([a-zA-Z0-9_-]+)\s*\}\}/g, (match, key) => {
+    if (!Object.prototype.hasOwnProperty.call(matrix, key)) {
+      core.warning(`Matrix value '${key}' is not defined for this leg; leaving ${match} unchanged`);
+      return match;
+    }
+    const value = matrix[key];
+    if (value === null || value === undefined) {
+      return "";
+    }
+    return typeof value === "object" ? JSON.stringify(value) : String(value);
+  });
+}
+
+async function main() {
+  const promptPath = process.env.GH_AW_PROMPT;
+  if (!promptPath) {
+    core.setFailed(`${ERR_CONFIG}: GH_AW_PROMPT environment variable is not set`);
+    return;
+  }
+
+  /** @type {Record<string, any>} */
+  let matrix;
+  try {
+    matrix = JSON.parse(process.env.GH_AW_MATRIX || "{}") || {};
+  } catch (error) {
+    core.setFailed(`${ERR_VALIDATION}: Failed to parse GH_AW_MATRIX: ${getErrorMessage(error)}`);
+    return;
+  }
+
+  const content = fs.readFileSync(promptPath, "utf8");
+  const result = substituteMatrixValues(content, matrix);
+  if (result === content) {
+    core.info("No matrix values to substitute in prompt");
+    return;
+  }
+
+  fs.writeFileSync(promptPath, result, "utf8");
+  core.info(`Substituted matrix values in prompt: ${JSON.stringify(matrix)}`);
+}
+
+module.exports = { main, substituteMatrixValues };
@@ -0,0 +1,63 @@
+import { describe, it, expect, vi, beforeEach, afterEach } from "vitest";
+import fs from "fs";
+import path from "path";
+import os from "os";
+const core = { info: vi.fn(), warning: vi.fn(), setFailed: vi.fn() };
+global.core = core;
+const { main, substituteMatrixValues } = require("./substitute_matrix_values.cjs");
+describe("substitute_matrix_values.cjs", () => {
+  describe("substituteMatrixValues", () => {
+    beforeEach(() => {
+      vi.clearAllMocks();
+    });
+    it("should replace matrix expressions with leg values", () => {
+      const result = substituteMatrixValues("Review the ${{ matrix.language }} code on ${{matrix.os}}.", { language: "go", os: "ubuntu-latest" });
+      expect(result).toBe("Review the go code on ubuntu-latest.");
+    });
+    it("should serialize object values as JSON", () => {
+      const result = substituteMatrixValues("Config: ${{ matrix.config }}", { config: { level: 2 } });
+      expect(result).toBe('Config: {"level":2}');
+    });
+    it("should leave unknown keys unchanged and warn", () => {
+      const result = substituteMatrixValues("Value: ${{ matrix.missing }}", { language: "go" });
+      expect(result).toBe("Value: ${{ matrix.missing }}");
+      expect(core.warning).toHaveBeenCalled();
+    });
+    it("should not touch other expressions", () => {
+      const result = substituteMatrixValues("Actor: ${{ github.actor }}", { language: "go" });
+      expect(result).toBe("Actor: ${{ github.actor }}");
+    });
+  });
+  describe("main", () => {
+    let tmpDir, promptPath;
+    beforeEach(() => {
+      vi.clearAllMocks();
+      tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), "matrix-test-"));
+      promptPath = path.join(tmpDir, "prompt.txt");
+    });
+    afterEach(() => {
+      fs.rmSync(tmpDir, { recursive: true, force: true });
+      delete process.env.GH_AW_PROMPT;
+      delete process.env.GH_AW_MATRIX;
+    });
+    it("should substitute matrix values in the prompt file", async () => {
+      fs.writeFileSync(promptPath, "Triage ${{ matrix.area }} issues");
+      process.env.GH_AW_PROMPT = promptPath;
+      process.env.GH_AW_MATRIX = JSON.stringify({ area: "docs" });
+      await main();
+      expect(fs.readFileSync(promptPath, "utf8")).toBe("Triage docs issues");
+      expect(core.setFailed).not.toHaveBeenCalled();
+    });
+    it("should fail when GH_AW_PROMPT is not set", async () => {
+      await main();
+      expect(core.setFailed).toHaveBeenCalledWith(expect.stringContaining("GH_AW_PROMPT"));
+    });
+    it("should fail when GH_AW_MATRIX is not valid JSON", async () => {
+      fs.writeFileSync(promptPath, "Triage ${{ matrix.area }} issues");
+      process.env.GH_AW_PROMPT = promptPath;
+      process.env.GH_AW_MATRIX = "{not json";
+      await main();
+      expect(core.setFailed).toHaveBeenCalledWith(expect.stringContaining("GH_AW_MATRIX"));
+    });
+  });
+});
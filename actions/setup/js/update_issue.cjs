@@ -115,6 +115,40 @@ const resolveIssueNumber = createStandardResolveNumber({
   supportsIssue: true, // update_issue only supports issues, not PRs
 });
 
+/**
+ * Maps the fields of an update_issue message to the names used in the allowed-fields configuration.
+ * The message may use either "status" or "state" for the open/closed state.
+ * @type {Record<string, string>}
+ */
+const UPDATE_FIELD_NAMES = {
+  title: "title",
+  body: "body",
+  status: "status",
+  state: "status",
+  labels: "labels",
+  assignees: "assignees",
+  milestone: "milestone",
+};
+
+/**
+ * Find the fields of a message that the allowed-fields configuration does not permit
+ * @param {Object} item - The message item
+ * @param {string[] | undefined} allowedFields - Configured allowed fields, or undefined when unrestricted
+ * @returns {string[]} Disallowed field names, empty when the update is permitted
+ */
+function findDisallowedFields(item, allowedFields) {
+  if (!Array.isArray(allowedFields) || allowedFields.length === 0) {
+    return [];
+  }
+  const disallowed = new Set();
+  for (const [messageField, fieldName] of Object.entries(UPDATE_FIELD_NAMES)) {
+    if (item[messageField] !== undefined && !allowedFields.includes(fieldName)) {
+      disallowed.add(fieldName);
+    }
+  }
+  return [...disallowed];
+}
+
 /**
  * Build update data from message
  * @param {Object} item - The message item
@@ -122,6 +156,14 @@ const resolveIssueNumber = createStandardResolveNumber({
  * @returns {{success: true, data: Object} | {success: false, error: string}} Update data result
  */
 function buildIssueUpdateData(item, config) {
+  // Reject the whole update if it touches a field outside the configured allowlist
+  const disallowedFields = findDisallowedFields(item, config.allowed_fields);
+  if (disallowedFields.length > 0) {
+    const error = `${ERR_VALIDATION}: Update to ${disallowedFields.join(", ")} is not allowed by safe-outputs configuration (allowed fields: ${config.allowed_fields.join(", ")})`;
+    core.warning(error);
+    return { success: false, error };
+  }
+
   const updateData = {};
 
   if (item.title !== undefined) {
@@ -205,4 +247,4 @@ const main = createUpdateHandlerFactory({
   formatSuccessResult: formatIssueSuccessResult,
 });
 
-module.exports = { main, buildIssueUpdateData };
+module.exports = { main, buildIssueUpdateData, findDisallowedFields };
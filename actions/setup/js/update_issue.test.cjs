@@ -741,3 +741,64 @@ describe("update_issue.cjs - title_prefix configuration", () => {
     expect(mockGithub.rest.issues.update).not.toHaveBeenCalled();
   });
 });
+
+describe("update_issue.cjs - allowed_fields configuration", () => {
+  beforeEach(async () => {
+    vi.resetAllMocks();
+    vi.resetModules();
+  });
+
+  it("should allow body edits with a body-only allowlist", async () => {
+    const { buildIssueUpdateData } = await import("./update_issue.cjs");
+
+    const result = buildIssueUpdateData({ body: "New body content" }, { allowed_fields: ["body"] });
+
+    expect(result.success).toBe(true);
+    expect(result.data._rawBody).toBe("New body content");
+  });
+
+  it("should block a state change with a body-only allowlist", async () => {
+    const { buildIssueUpdateData } = await import("./update_issue.cjs");
+
+    const result = buildIssueUpdateData({ body: "Closing", status: "closed" }, { allowed_fields: ["body"] });
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Update to status is not allowed");
+    expect(result.error).toContain("allowed fields: body");
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Update to status is not allowed"));
+  });
+
+  it("should treat state the same as status", async () => {
+    const { findDisallowedFields } = await import("./update_issue.cjs");
+
+    expect(findDisallowedFields({ state: "closed" }, ["body"])).toEqual(["status"]);
+    expect(findDisallowedFields({ state: "closed", status: "closed" }, ["body"])).toEqual(["status"]);
+    expect(findDisallowedFields({ state: "closed" }, ["status"])).toEqual([]);
+  });
+
+  it("should report every disallowed field", async () => {
+    const { findDisallowedFields } = await import("./update_issue.cjs");
+
+    expect(findDisallowedFields({ title: "T", labels: ["bug"], milestone: 1, body: "B" }, ["body"])).toEqual(["title", "labels", "milestone"]);
+  });
+
+  it("should not restrict fields when allowed_fields is not configured", async () => {
+    const { buildIssueUpdateData } = await import("./update_issue.cjs");
+
+    const result = buildIssueUpdateData({ title: "New Title", status: "closed" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.data.title).toBe("New Title");
+    expect(result.data.state).toBe("closed");
+  });
+
+  it("should not call the API when the update touches a disallowed field", async () => {
+    const { main } = await import("./update_issue.cjs");
+    const handler = await main({ allowed_fields: ["body"], target: "*" });
+
+    const result = await handler({ type: "update_issue", issue_number: 100, status: "closed" }, {});
+
+    expect(result.success).toBe(false);
+    expect(mockGithub.rest.issues.update).not.toHaveBeenCalled();
+  });
+});
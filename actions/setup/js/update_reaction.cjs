@@ -0,0 +1,141 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/** GraphQL ReactionContent values for the reaction names used by the REST API */
+const GRAPHQL_REACTIONS = {
+  "+1": "THUMBS_UP",
+  "-1": "THUMBS_DOWN",
+  laugh: "LAUGH",
+  confused: "CONFUSED",
+  heart: "HEART",
+  hooray: "HOORAY",
+  rocket: "ROCKET",
+  eyes: "EYES",
+};
+
+/**
+ * Update the reaction added on activation once the workflow run completes.
+ * Removes the activation reaction when GH_AW_REACTION_REMOVE is "true" and adds +1 or -1
+ * based on the agent job result when GH_AW_REACTION_OUTCOME is "true".
+ * Failures are reported as warnings so they never fail the workflow.
+ */
+async function main() {
+  const reaction = process.env.GH_AW_REACTION || "eyes";
+  const reactionId = process.env.GH_AW_REACTION_ID || "";
+  const removeReaction = process.env.GH_AW_REACTION_REMOVE === "true";
+  const outcomeReaction = process.env.GH_AW_REACTION_OUTCOME === "true";
+  const agentConclusion = process.env.GH_AW_AGENT_CONCLUSION || "";
+
+  const target = getReactionTarget();
+  if (!target) {
+    core.info(`Event ${context.eventName} has no reaction to update`);
+    return;
+  }
+
+  if (removeReaction) {
+    if (!reactionId) {
+      core.info("No reaction id from the activation step, nothing to remove");
+    } else {
+      try {
+        await removeActivationReaction(target, reactionId, reaction);
+        core.info(`Removed ${reaction} reaction (id: ${reactionId})`);
+      } catch (error) {
+        core.warning(`Failed to remove ${reaction} reaction: ${getErrorMessage(error)}`);
+      }
+    }
+  }
+
+  if (outcomeReaction) {
+    const outcome = agentConclusion === "success" ? "+1" : agentConclusion === "failure" ? "-1" : "";
+    if (!outcome) {
+      core.info(`Agent job result is '${agentConclusion}', no outcome reaction added`);
+      return;
+    }
+    try {
+      await addReaction(target, outcome);
+      core.info(`Added ${outcome} reaction for agent result '${agentConclusion}'`);
+    } catch (error) {
+      core.warning(`Failed to add ${outcome} reaction: ${getErrorMessage(error)}`);
+    }
+  }
+}
+
+/**
+ * Determine the item the activation reaction was added to, using the same rules as add_reaction.cjs
+ * @returns {{endpoint: string} | {subjectId: string} | null} REST endpoint or GraphQL node id, or null if unsupported
+ */
+function getReactionTarget() {
+  const owner = context.repo.owner;
+  const repo = context.repo.repo;
+  const payload = context.payload || {};
+
+  switch (context.eventName) {
+    case "issues":
+      return payload.issue?.number ? { endpoint: `/repos/${owner}/${repo}/issues/${payload.issue.number}/reactions` } : null;
+    case "issue_comment":
+      return payload.comment?.id ? { endpoint: `/repos/${owner}/${repo}/issues/comments/${payload.comment.id}/reactions` } : null;
+    case "pull_request":
+      // PRs are "issues" for the reactions endpoint
+      return payload.pull_request?.number ? { endpoint: `/repos/${owner}/${repo}/issues/${payload.pull_request.number}/reactions` } : null;
+    case "pull_request_review_comment":
+      return payload.comment?.id ? { endpoint: `/repos/${owner}/${repo}/pulls/comments/${payload.comment.id}/reactions` } : null;
+    case "discussion":
+      return payload.discussion?.node_id ? { subjectId: payload.discussion.node_id } : null;
+    case "discussion_comment":
+      return payload.comment?.node_id ? { subjectId: payload.comment.node_id } : null;
+    default:
+      return null;
+  }
+}
+
+/**
+ * Remove the reaction added on activation
+ * @param {{endpoint: string} | {subjectId: string}} target - Item the reaction was added to
+ * @param {string} reactionId - REST reaction id (unused for discussions, which remove by content)
+ * @param {string} reaction - Reaction content
+ */
+async function removeActivationReaction(target, reactionId, reaction) {
+  if ("endpoint" in target) {
+    await github.request(`DELETE ${target.endpoint}/${reactionId}`, {
+      headers: { Accept: "application/vnd.github+json" },
+    });
+    return;
+  }
+  await github.graphql(
+    `
+    mutation($subjectId: ID!, $content: ReactionContent!) {
+      removeReaction(input: { subjectId: $subjectId, content: $content }) {
+        reaction { content }
+      }
+    }`,
+    { subjectId: target.subjectId, content: GRAPHQL_REACTIONS[reaction] }
+  );
+}
+
+/**
+ * Add a reaction to the item
+ * @param {{endpoint: string} | {subjectId: string}} target - Item to react to
+ * @param {string} reaction - Reaction content
+ */
+async function addReaction(target, reaction) {
+  if ("endpoint" in target) {
+    await github.request(`POST ${target.endpoint}`, {
+      content: reaction,
+      headers: { Accept: "application/vnd.github+json" },
+    });
+    return;
+  }
+  await github.graphql(
+    `
+    mutation($subjectId: ID!, $content: ReactionContent!) {
+      addReaction(input: { subjectId: $subjectId, content: $content }) {
+        reaction { content }
+      }
+    }`,
+    { subjectId: target.subjectId, content: GRAPHQL_REACTIONS[reaction] }
+  );
+}
+
+module.exports = { main, getReactionTarget };
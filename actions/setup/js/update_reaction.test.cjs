@@ -0,0 +1,132 @@
+// @ts-check
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+// Mock the global objects that GitHub Actions provides
+const mockCore = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+};
+
+const mockGithub = {
+  request: vi.fn(),
+  graphql: vi.fn(),
+};
+
+// Set up global mocks before importing the module
+global.core = mockCore;
+global.github = mockGithub;
+
+describe("update_reaction", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    vi.resetModules();
+
+    process.env.GH_AW_REACTION = "eyes";
+    process.env.GH_AW_REACTION_ID = "42";
+    process.env.GH_AW_REACTION_REMOVE = "true";
+    process.env.GH_AW_REACTION_OUTCOME = "false";
+    process.env.GH_AW_AGENT_CONCLUSION = "success";
+
+    global.context = {
+      eventName: "issues",
+      repo: { owner: "testowner", repo: "testrepo" },
+      payload: { issue: { number: 123 } },
+    };
+
+    mockGithub.request.mockResolvedValue({ data: {} });
+    mockGithub.graphql.mockResolvedValue({});
+  });
+
+  it("should remove the activation reaction from an issue", async () => {
+    const { main } = await import("./update_reaction.cjs");
+    await main();
+
+    expect(mockGithub.request).toHaveBeenCalledTimes(1);
+    expect(mockGithub.request).toHaveBeenCalledWith("DELETE /repos/testowner/testrepo/issues/123/reactions/42", expect.any(Object));
+  });
+
+  it("should remove the activation reaction from an issue comment", async () => {
+    global.context.eventName = "issue_comment";
+    global.context.payload = { issue: { number: 123 }, comment: { id: 456 } };
+
+    const { main } = await import("./update_reaction.cjs");
+    await main();
+
+    expect(mockGithub.request).toHaveBeenCalledWith("DELETE /repos/testowner/testrepo/issues/comments/456/reactions/42", expect.any(Object));
+  });
+
+  it("should remove discussion reactions by content with GraphQL", async () => {
+    global.context.eventName = "discussion";
+    global.context.payload = { discussion: { number: 7, node_id: "D_kwDO123" } };
+
+    const { main } = await import("./update_reaction.cjs");
+    await main();
+
+    expect(mockGithub.graphql).toHaveBeenCalledWith(expect.stringContaining("removeReaction"), { subjectId: "D_kwDO123", content: "EYES" });
+  });
+
+  it("should skip removal when no reaction id is available", async () => {
+    process.env.GH_AW_REACTION_ID = "";
+
+    const { main } = await import("./update_reaction.cjs");
+    await main();
+
+    expect(mockGithub.request).not.toHaveBeenCalled();
+  });
+
+  it("should add +1 when the agent succeeds", async () => {
+    process.env.GH_AW_REACTION_REMOVE = "false";
+    process.env.GH_AW_REACTION_OUTCOME = "true";
+
+    const { main } = await import("./update_reaction.cjs");
+    await main();
+
+    expect(mockGithub.request).toHaveBeenCalledTimes(1);
+    expect(mockGithub.request).toHaveBeenCalledWith("POST /repos/testowner/testrepo/issues/123/reactions", expect.objectContaining({ content: "+1" }));
+  });
+
+  it("should remove the reaction and add -1 when the agent fails", async () => {
+    process.env.GH_AW_REACTION_OUTCOME = "true";
+    process.env.GH_AW_AGENT_CONCLUSION = "failure";
+
+    const { main } = await import("./update_reaction.cjs");
+    await main();
+
+    expect(mockGithub.request).toHaveBeenNthCalledWith(1, "DELETE /repos/testowner/testrepo/issues/123/reactions/42", expect.any(Object));
+    expect(mockGithub.request).toHaveBeenNthCalledWith(2, "POST /repos/testowner/testrepo/issues/123/reactions", expect.objectContaining({ content: "-1" }));
+  });
+
+  it("should not add an outcome reaction when the agent was cancelled", async () => {
+    process.env.GH_AW_REACTION_REMOVE = "false";
+    process.env.GH_AW_REACTION_OUTCOME = "true";
+    process.env.GH_AW_AGENT_CONCLUSION = "cancelled";
+
+    const { main } = await import("./update_reaction.cjs");
+    await main();
+
+    expect(mockGithub.request).not.toHaveBeenCalled();
+  });
+
+  it("should warn instead of failing when the API call fails", async () => {
+    mockGithub.request.mockRejectedValue(new Error("Not Found"));
+
+    const { main } = await import("./update_reaction.cjs");
+    await main();
+
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Failed to remove eyes reaction"));
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("should do nothing for events without a reaction target", async () => {
+    global.context.eventName = "workflow_dispatch";
+    global.context.payload = {};
+
+    const { main } = await import("./update_reaction.cjs");
+    await main();
+
+    expect(mockGithub.request).not.toHaveBeenCalled();
+    expect(mockGithub.graphql).not.toHaveBeenCalled();
+  });
+});
@@ -8,6 +8,9 @@
 # - Progressive delay: 2s, 4s between retry attempts
 # - Up to 3 retry attempts per server
 # - Accommodates slow-starting MCP servers (gateway may take 40-50 seconds to start)
+# - Servers listed in GH_AW_MCP_HEALTH_CHECKS (JSON object of server name to startup
+#   timeout in seconds, from `health-check: true` / `startup-timeout`) are polled with
+#   tools/list until they answer or their startup timeout elapses
 # Protocol: ping verifies basic connectivity; initialize establishes the session (capturing
 # Mcp-Session-Id); tools/list confirms the backend container is truly ready (must be forwarded
 # by the gateway, unlike ping which may be handled at the proxy layer).
@@ -30,6 +33,9 @@ print_timing() {
 #   GATEWAY_URL         : The HTTP URL of the MCP gateway (e.g., http://localhost:8080)
 #   GATEWAY_API_KEY     : API key for gateway authentication
 #
+# Environment:
+#   GH_AW_MCP_HEALTH_CHECKS : Optional JSON object mapping server names to startup timeouts in seconds
+#
 # Exit codes:
 #   0 - All HTTP servers successfully checked (skipped servers logged as warnings)
 #   1 - Invalid arguments, configuration file issues, or server connection failures
@@ -89,6 +95,13 @@ SERVERS_SKIPPED=0
 # Gateway may take 40-50 seconds to start all MCP servers (per start_mcp_gateway.sh)
 MAX_RETRIES=3
 
+# Per-server startup health checks (server name -> startup timeout in seconds)
+HEALTH_CHECKS="${GH_AW_MCP_HEALTH_CHECKS:-}"
+if [ -n "$HEALTH_CHECKS" ] && ! echo "$HEALTH_CHECKS" | jq -e 'type == "object"' >/dev/null 2>&1; then
+  echo "ERROR: GH_AW_MCP_HEALTH_CHECKS must be a JSON object of server names to startup timeouts" >&2
+  exit 1
+fi
+
 # Iterate through each server
 while IFS= read -r SERVER_NAME; do
   SERVERS_CHECKED=$((SERVERS_CHECKED + 1))
@@ -127,22 +140,53 @@ while IFS= read -r SERVER_NAME; do
   INIT_PAYLOAD='{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"capabilities":{},"clientInfo":{"name":"check-mcp-servers","version":"1.0.0"},"protocolVersion":"2024-11-05"}}'
   TOOLS_LIST_PAYLOAD='{"jsonrpc":"2.0","id":3,"method":"tools/list"}'
   
+  # Servers with a startup health check are polled until their startup timeout elapses
+  STARTUP_TIMEOUT=""
+  if [ -n "$HEALTH_CHECKS" ]; then
+    STARTUP_TIMEOUT=$(echo "$HEALTH_CHECKS" | jq -r --arg name "$SERVER_NAME" '.[$name] // empty' 2>/dev/null)
+  fi
+  DEADLINE=""
+  if [ -n "$STARTUP_TIMEOUT" ]; then
+    echo "  Health check enabled (startup timeout: ${STARTUP_TIMEOUT}s)"
+    DEADLINE=$(($(date +%s) + STARTUP_TIMEOUT))
+  fi
+  
   # Retry logic for slow-starting servers
   RETRY_COUNT=0
   CHECK_SUCCESS=false
   LAST_ERROR=""
   
-  while [ $RETRY_COUNT -lt $MAX_RETRIES ]; do
-    # Calculate timeout based on retry attempt (10s, 20s, 30s)
-    TIMEOUT=$((10 + RETRY_COUNT * 10))
-    
-    if [ $RETRY_COUNT -gt 0 ]; then
-      # Progressive delay between retries (2s, 4s)
-      DELAY=$((2 * RETRY_COUNT))
-      echo "  Retry $RETRY_COUNT/$MAX_RETRIES after ${DELAY}s delay (timeout: ${TIMEOUT}s)..."
-      sleep $DELAY
+  while true; do
+    if [ -n "$DEADLINE" ]; then
+      # Poll every 2s with a 10s request timeout, capped by the time left before the deadline
+      REMAINING=$((DEADLINE - $(date +%s)))
+      if [ $RETRY_COUNT -gt 0 ] && [ $REMAINING -le 2 ]; then
+        break
+      fi
+      if [ $RETRY_COUNT -gt 0 ]; then
+        sleep 2
+        REMAINING=$((REMAINING - 2))
+        echo "  Retry $RETRY_COUNT (${REMAINING}s left before startup timeout)..."
+      else
+        echo "  Attempting connection (startup timeout: ${STARTUP_TIMEOUT}s)..."
+      fi
+      TIMEOUT=$((REMAINING < 10 ? REMAINING : 10))
+      [ $TIMEOUT -lt 1 ] && TIMEOUT=1
     else
-      echo "  Attempting connection (timeout: ${TIMEOUT}s)..."
+      if [ $RETRY_COUNT -ge $MAX_RETRIES ]; then
+        break
+      fi
+      # Calculate timeout based on retry attempt (10s, 20s, 30s)
+      TIMEOUT=$((10 + RETRY_COUNT * 10))
+      
+      if [ $RETRY_COUNT -gt 0 ]; then
+        # Progressive delay between retries (2s, 4s)
+        DELAY=$((2 * RETRY_COUNT))
+        echo "  Retry $RETRY_COUNT/$MAX_RETRIES after ${DELAY}s delay (timeout: ${TIMEOUT}s)..."
+        sleep $DELAY
+      else
+        echo "  Attempting connection (timeout: ${TIMEOUT}s)..."
+      fi
     fi
     
     # Step 1: Send ping to verify basic connectivity
@@ -217,6 +261,13 @@ while IFS= read -r SERVER_NAME; do
   if [ "$CHECK_SUCCESS" = true ]; then
     echo "✓ $SERVER_NAME: connected"
     SERVERS_SUCCEEDED=$((SERVERS_SUCCEEDED + 1))
+  elif [ -n "$DEADLINE" ]; then
+    echo "✗ $SERVER_NAME: did not become ready within its ${STARTUP_TIMEOUT}s startup timeout"
+    echo "  URL: ${SERVER_URL@Q}"
+    echo "  Last error: ${LAST_ERROR@Q}"
+    echo "  Attempts: $RETRY_COUNT"
+    echo "  Increase 'startup-timeout' for this server if it legitimately needs longer to start"
+    SERVERS_FAILED=$((SERVERS_FAILED + 1))
   else
     echo "✗ $SERVER_NAME: failed to connect"
     echo "  URL: ${SERVER_URL@Q}"
@@ -350,6 +350,46 @@ test_validation_functions_exist() {
   fi
 }
 
+# Test 11: Startup health check fails fast with a clear message
+test_health_check_startup_timeout() {
+  echo ""
+  echo "Test 11: Startup health check timeout"
+  
+  local tmpdir
+  tmpdir=$(mktemp -d)
+  local config_file="$tmpdir/config.json"
+  
+  # Port 9 (discard) refuses connections, so the server never becomes ready
+  cat > "$config_file" <<'EOF'
+{
+  "mcpServers": {
+    "flaky": {
+      "type": "http",
+      "url": "http://localhost:9/mcp/flaky"
+    }
+  }
+}
+EOF
+  
+  local output
+  if output=$(GH_AW_MCP_HEALTH_CHECKS='{"flaky":3}' bash "$SCRIPT_PATH" "$config_file" "http://localhost:8080" "test-key" 2>&1); then
+    print_result "Script should fail when a health-checked server never becomes ready" "FAIL"
+  elif echo "$output" | grep -q "flaky: did not become ready within its 3s startup timeout"; then
+    print_result "Script reports the server that exceeded its startup timeout" "PASS"
+  else
+    print_result "Script should report the server that exceeded its startup timeout" "FAIL"
+  fi
+  
+  # Invalid health check configuration is rejected
+  if ! GH_AW_MCP_HEALTH_CHECKS='not-json' bash "$SCRIPT_PATH" "$config_file" "http://localhost:8080" "test-key" >/dev/null 2>&1; then
+    print_result "Script rejects invalid GH_AW_MCP_HEALTH_CHECKS" "PASS"
+  else
+    print_result "Script should reject invalid GH_AW_MCP_HEALTH_CHECKS" "FAIL"
+  fi
+  
+  rm -rf "$tmpdir"
+}
+
 # Run all tests
 echo "=== Testing check_mcp_servers.sh ==="
 echo "Script: $SCRIPT_PATH"
@@ -364,6 +404,7 @@ test_valid_http_server
 test_server_without_url
 test_mixed_servers
 test_validation_functions_exist
+test_health_check_startup_timeout
 
 # Print summary
 echo ""
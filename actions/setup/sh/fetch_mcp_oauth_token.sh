@@ -0,0 +1,69 @@
+#!/bin/bash
+set -eo pipefail
+
+# fetch_mcp_oauth_token.sh - Obtain a bearer token for an HTTP MCP server using the OAuth client credentials grant
+#
+# Usage: fetch_mcp_oauth_token.sh SERVER_NAME
+#
+# Arguments:
+#   SERVER_NAME : Name of the MCP server, used in log and error messages
+#
+# Environment:
+#   GH_AW_MCP_OAUTH_TOKEN_URL     : OAuth token endpoint (required)
+#   GH_AW_MCP_OAUTH_CLIENT_ID     : OAuth client id (required)
+#   GH_AW_MCP_OAUTH_CLIENT_SECRET : OAuth client secret (required)
+#   GH_AW_MCP_OAUTH_SCOPE         : Space-separated scopes to request (optional)
+#   GH_AW_MCP_OAUTH_AUDIENCE      : Audience to request (optional)
+#
+# Outputs:
+#   token : The access token, masked in the workflow logs, written to $GITHUB_OUTPUT
+#
+# Exit codes:
+#   0 - Token obtained
+#   1 - Missing configuration, token request failed or response has no access_token
+
+if [ "$#" -ne 1 ]; then
+  echo "Usage: $0 SERVER_NAME" >&2
+  exit 1
+fi
+
+SERVER_NAME="$1"
+
+for var in GH_AW_MCP_OAUTH_TOKEN_URL GH_AW_MCP_OAUTH_CLIENT_ID GH_AW_MCP_OAUTH_CLIENT_SECRET; do
+  if [ -z "${!var}" ]; then
+    echo "::error::MCP server '$SERVER_NAME' auth is missing $var. Check that the referenced secrets are configured." >&2
+    exit 1
+  fi
+done
+
+CURL_ARGS=(
+  --silent --show-error --fail-with-body
+  --retry 3 --retry-delay 2
+  --request POST
+  --header "Accept: application/json"
+  --data-urlencode "grant_type=client_credentials"
+  --data-urlencode "client_id=${GH_AW_MCP_OAUTH_CLIENT_ID}"
+  --data-urlencode "client_secret=${GH_AW_MCP_OAUTH_CLIENT_SECRET}"
+)
+if [ -n "${GH_AW_MCP_OAUTH_SCOPE:-}" ]; then
+  CURL_ARGS+=(--data-urlencode "scope=${GH_AW_MCP_OAUTH_SCOPE}")
+fi
+if [ -n "${GH_AW_MCP_OAUTH_AUDIENCE:-}" ]; then
+  CURL_ARGS+=(--data-urlencode "audience=${GH_AW_MCP_OAUTH_AUDIENCE}")
+fi
+
+echo "Requesting OAuth token for MCP server '$SERVER_NAME' from $GH_AW_MCP_OAUTH_TOKEN_URL"
+if ! RESPONSE=$(curl "${CURL_ARGS[@]}" "$GH_AW_MCP_OAUTH_TOKEN_URL"); then
+  echo "::error::Failed to obtain an OAuth token for MCP server '$SERVER_NAME' from $GH_AW_MCP_OAUTH_TOKEN_URL" >&2
+  exit 1
+fi
+
+TOKEN=$(echo "$RESPONSE" | jq -r '.access_token // empty' 2>/dev/null || true)
+if [ -z "$TOKEN" ]; then
+  echo "::error::OAuth token response for MCP server '$SERVER_NAME' does not contain an access_token" >&2
+  exit 1
+fi
+
+echo "::add-mask::${TOKEN}"
+echo "token=${TOKEN}" >> "$GITHUB_OUTPUT"
+echo "OAuth token for MCP server '$SERVER_NAME' obtained"
@@ -31,8 +31,70 @@ get_patch_path() {
   echo "/tmp/gh-aw/aw-${sanitized}.patch"
 }
 
+# Abort patch generation when a patch exceeds MAX_PATCH_BYTES (0 disables the limit)
+enforce_max_patch_size() {
+  local patch_path="$1"
+  local max_bytes="$MAX_PATCH_BYTES"
+  case "$max_bytes" in
+    '' | *[!0-9]*) return 0 ;;
+  esac
+  if [ "$max_bytes" -eq 0 ] || [ ! -f "$patch_path" ]; then
+    return 0
+  fi
+
+  local patch_bytes
+  patch_bytes="$(wc -c < "$patch_path" | tr -d ' ')"
+  if [ "$patch_bytes" -le "$max_bytes" ]; then
+    echo "Patch size ${patch_bytes} bytes is within the limit of ${max_bytes} bytes"
+    return 0
+  fi
+
+  echo "ERROR: Patch ${patch_path@Q} is ${patch_bytes} bytes, which exceeds the maximum of ${max_bytes} bytes (GH_AW_MAX_PATCH_BYTES)"
+  echo "Aborting patch generation. Reduce the size of the changes or raise safe-outputs.create-pull-request.max-patch-size"
+  rm -f "$patch_path"
+  {
+    echo "## Git Patch: size limit exceeded"
+    echo ''
+    echo "The patch for \`$(basename "$patch_path")\` is ${patch_bytes} bytes, which exceeds the maximum of ${max_bytes} bytes."
+    echo 'Patch generation was aborted. Reduce the size of the changes or raise `safe-outputs.create-pull-request.max-patch-size`.'
+    echo ''
+  } >> "$GITHUB_STEP_SUMMARY"
+  exit 1
+}
+
+# Warn about binary files in a revision range. Binary changes are included in the patch
+# (format-patch --binary), but reviewers cannot read them in the patch preview.
+warn_binary_files() {
+  local range="$1"
+  local binary_files
+  binary_files="$(git diff --numstat "$range" 2>/dev/null | awk -F'\t' '$1 == "-" && $2 == "-" { print $3 }')"
+  if [ -n "$binary_files" ]; then
+    echo "WARNING: Patch includes binary files:"
+    echo "$binary_files" | sed 's/^/  /'
+    {
+      echo "> [!WARNING]"
+      echo "> The git patch includes binary files: $(echo "$binary_files" | tr '\n' ' ' | sed 's/ $//')"
+      echo ''
+    } >> "$GITHUB_STEP_SUMMARY"
+  fi
+}
+
+# GH_AW_MAX_PATCH_BYTES is a create-pull-request option. Matches getCreatePullRequestPatchOptions
+# in generate_git_patch.cjs: patches for push_to_pull_request_branch are generated without it.
+MAX_PATCH_BYTES=0
+
+use_create_pull_request_options() {
+  MAX_PATCH_BYTES="${GH_AW_MAX_PATCH_BYTES:-0}"
+}
+
+use_default_patch_options() {
+  MAX_PATCH_BYTES=0
+}
+
 # Extract all branch names from JSONL output (for all create_pull_request and push_to_pull_request_branch entries)
 BRANCH_NAMES=()
+declare -A CREATE_PR_BRANCHES
+HAS_CREATE_PULL_REQUEST=false
 if [ -f "$GH_AW_SAFE_OUTPUTS" ]; then
   echo ""
   echo "Checking for branch names in JSONL output..."
@@ -42,10 +104,18 @@ if [ -f "$GH_AW_SAFE_OUTPUTS" ]; then
       # Extract branch from create-pull-request or push_to_pull_request_branch lines
       # Note: types use underscores (normalized by safe-outputs MCP server)
       if echo "$line" | grep -qE '"type"[[:space:]]*:[[:space:]]*"(create_pull_request|push_to_pull_request_branch)"'; then
+        IS_CREATE_PULL_REQUEST=false
+        if echo "$line" | grep -qE '"type"[[:space:]]*:[[:space:]]*"create_pull_request"'; then
+          IS_CREATE_PULL_REQUEST=true
+          HAS_CREATE_PULL_REQUEST=true
+        fi
         BRANCH_NAME="$(echo "$line" | sed -n 's/.*"branch"[[:space:]]*:[[:space:]]*"\([^"]*\)".*/\1/p')"
         if [ -n "$BRANCH_NAME" ]; then
           echo "Found branch name: ${BRANCH_NAME@Q}"
           BRANCH_NAMES+=("$BRANCH_NAME")
+          if [ "$IS_CREATE_PULL_REQUEST" = true ]; then
+            CREATE_PR_BRANCHES[$BRANCH_NAME]=1
+          fi
         fi
       fi
     fi
@@ -89,6 +159,11 @@ if [ "${#BRANCH_NAMES[@]}" -gt 0 ]; then
     # Check if the branch exists
     if git show-ref --verify --quiet "refs/heads/$BRANCH_NAME"; then
       echo "Branch ${BRANCH_NAME@Q} exists, generating patch from branch changes"
+      if [ "${CREATE_PR_BRANCHES[$BRANCH_NAME]+_}" ]; then
+        use_create_pull_request_options
+      else
+        use_default_patch_options
+      fi
 
       # Check if origin/$BRANCH_NAME exists to use as base
       if git show-ref --verify --quiet "refs/remotes/origin/$BRANCH_NAME"; then
@@ -121,10 +196,13 @@ if [ "${#BRANCH_NAMES[@]}" -gt 0 ]; then
       # Diagnostic logging: Show the exact command being used
       echo ""
       echo "=== Diagnostic: Generating patch ==="
-      echo "Command: git format-patch ${BASE_REF@Q}..${BRANCH_NAME@Q} --stdout > ${PATCH_PATH@Q}"
+      echo "Command: git format-patch --binary ${BASE_REF@Q}..${BRANCH_NAME@Q} --stdout > ${PATCH_PATH@Q}"
 
       # Generate patch from the determined base to the branch
-      git format-patch "$BASE_REF".."$BRANCH_NAME" --stdout > "$PATCH_PATH" || echo "Failed to generate patch from branch" > "$PATCH_PATH"
+      # --binary keeps binary files (images, compiled assets) applicable
+      git format-patch --binary "$BASE_REF".."$BRANCH_NAME" --stdout > "$PATCH_PATH" || echo "Failed to generate patch from branch" > "$PATCH_PATH"
+      warn_binary_files "$BASE_REF".."$BRANCH_NAME"
+      enforce_max_patch_size "$PATCH_PATH"
       echo "Patch file created from branch: ${BRANCH_NAME@Q} (base: ${BASE_REF@Q})"
       PATCH_GENERATED=true
     else
@@ -137,6 +215,11 @@ fi
 if [ "$PATCH_GENERATED" = false ]; then
   echo ""
   echo "=== Strategy 2: Checking for commits on current HEAD ==="
+  if [ "$HAS_CREATE_PULL_REQUEST" = true ]; then
+    use_create_pull_request_options
+  else
+    use_default_patch_options
+  fi
 
   # Get current HEAD SHA
   CURRENT_HEAD="$(git rev-parse HEAD 2>/dev/null || echo '')"
@@ -183,8 +266,10 @@ if [ "$PATCH_GENERATED" = false ]; then
         # Generate patch from GITHUB_SHA to HEAD
         echo ""
         echo "=== Diagnostic: Generating patch ==="
-        echo "Command: git format-patch ${GITHUB_SHA@Q}..HEAD --stdout > ${PATCH_PATH@Q}"
-        git format-patch "${GITHUB_SHA}..HEAD" --stdout > "$PATCH_PATH" || echo "Failed to generate patch from HEAD" > "$PATCH_PATH"
+        echo "Command: git format-patch --binary ${GITHUB_SHA@Q}..HEAD --stdout > ${PATCH_PATH@Q}"
+        git format-patch --binary "${GITHUB_SHA}..HEAD" --stdout > "$PATCH_PATH" || echo "Failed to generate patch from HEAD" > "$PATCH_PATH"
+        warn_binary_files "${GITHUB_SHA}..HEAD"
+        enforce_max_patch_size "$PATCH_PATH"
         echo "Patch file created from commits on HEAD (base: ${GITHUB_SHA@Q})"
         PATCH_GENERATED=true
       else
@@ -0,0 +1,87 @@
+#!/usr/bin/env bash
+# Rotate MCP Server Logs
+# This script applies the sandbox.mcp.log-rotation policy to every file in the MCP logs directory.
+#
+# A log file larger than GH_AW_MCP_LOG_MAX_SIZE_BYTES is copied to <file>.1 and truncated in place,
+# so servers that keep the file open continue writing to it. Older copies shift to <file>.2 and so
+# on, keeping at most GH_AW_MCP_LOG_MAX_FILES copies. Rotated copies last modified more than
+# GH_AW_MCP_LOG_MAX_AGE_SECONDS ago are removed.
+#
+# Usage: rotate_mcp_logs.sh [--watch] [LOGS_DIR]
+#   --watch   Keep rotating every GH_AW_MCP_LOG_ROTATE_INTERVAL seconds (default: 30)
+#   LOGS_DIR  Directory to rotate (default: /tmp/gh-aw/mcp-logs)
+
+set -u
+
+WATCH=false
+if [ "${1:-}" = "--watch" ]; then
+  WATCH=true
+  shift
+fi
+
+LOGS_DIR="${1:-/tmp/gh-aw/mcp-logs}"
+MAX_SIZE="${GH_AW_MCP_LOG_MAX_SIZE_BYTES:-0}"
+MAX_AGE="${GH_AW_MCP_LOG_MAX_AGE_SECONDS:-0}"
+MAX_FILES="${GH_AW_MCP_LOG_MAX_FILES:-3}"
+INTERVAL="${GH_AW_MCP_LOG_ROTATE_INTERVAL:-30}"
+
+if [ "$MAX_FILES" -lt 1 ]; then
+  MAX_FILES=1
+fi
+
+# rotate_file rotates a single log file when it exceeds the size limit
+rotate_file() {
+  local file="$1"
+  local size
+  size=$(stat -c %s "$file" 2>/dev/null || echo 0)
+  if [ "$size" -le "$MAX_SIZE" ]; then
+    return 0
+  fi
+
+  local i
+  for ((i = MAX_FILES - 1; i >= 1; i--)); do
+    if [ -f "$file.$i" ]; then
+      mv -f "$file.$i" "$file.$((i + 1))"
+    fi
+  done
+  cp -p "$file" "$file.1"
+  : > "$file"
+  echo "Rotated $file ($size bytes)"
+}
+
+# remove_expired removes rotated copies older than the age limit
+remove_expired() {
+  local now file mtime
+  now=$(date +%s)
+  while IFS= read -r -d '' file; do
+    mtime=$(stat -c %Y "$file" 2>/dev/null || echo "$now")
+    if [ $((now - mtime)) -gt "$MAX_AGE" ]; then
+      rm -f "$file"
+      echo "Removed expired $file"
+    fi
+  done < <(find "$LOGS_DIR" -type f -regex '.*\.[0-9]+' -print0)
+}
+
+rotate_once() {
+  if [ ! -d "$LOGS_DIR" ]; then
+    return 0
+  fi
+  if [ "$MAX_SIZE" -gt 0 ]; then
+    while IFS= read -r -d '' file; do
+      rotate_file "$file"
+    done < <(find "$LOGS_DIR" -type f ! -regex '.*\.[0-9]+' -print0)
+  fi
+  if [ "$MAX_AGE" -gt 0 ]; then
+    remove_expired
+  fi
+}
+
+if [ "$WATCH" = "true" ]; then
+  echo "Rotating MCP logs in $LOGS_DIR every ${INTERVAL}s (max size: $MAX_SIZE bytes, max age: ${MAX_AGE}s, max files: $MAX_FILES)"
+  while true; do
+    rotate_once
+    sleep "$INTERVAL"
+  done
+fi
+
+rotate_once
@@ -0,0 +1,77 @@
+#!/usr/bin/env bash
+# Tests for rotate_mcp_logs.sh
+# Run: bash rotate_mcp_logs_test.sh
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+ROTATE_SCRIPT="${SCRIPT_DIR}/rotate_mcp_logs.sh"
+
+# Test counter
+TESTS_PASSED=0
+TESTS_FAILED=0
+
+TEST_DIR=$(mktemp -d)
+trap 'rm -rf "$TEST_DIR"' EXIT
+
+# Test helper function
+assert() {
+  local name="$1"
+  shift
+  if "$@"; then
+    echo "✓ $name"
+    TESTS_PASSED=$((TESTS_PASSED + 1))
+  else
+    echo "✗ $name"
+    TESTS_FAILED=$((TESTS_FAILED + 1))
+  fi
+}
+
+run_rotate() {
+  GH_AW_MCP_LOG_MAX_SIZE_BYTES="${MAX_SIZE:-0}" \
+    GH_AW_MCP_LOG_MAX_AGE_SECONDS="${MAX_AGE:-0}" \
+    GH_AW_MCP_LOG_MAX_FILES="${MAX_FILES:-2}" \
+    bash "$ROTATE_SCRIPT" "$TEST_DIR" > /dev/null
+}
+
+echo "Running rotate_mcp_logs.sh tests..."
+echo
+
+# Small files are left alone
+mkdir -p "$TEST_DIR/playwright"
+printf 'small' > "$TEST_DIR/playwright/server.log"
+MAX_SIZE=100 run_rotate
+assert "small file is not rotated" [ ! -f "$TEST_DIR/playwright/server.log.1" ]
+
+# Large files are copied to .1 and truncated in place
+head -c 200 /dev/zero > "$TEST_DIR/gateway.jsonl"
+MAX_SIZE=100 run_rotate
+assert "large file is rotated to .1" [ "$(stat -c %s "$TEST_DIR/gateway.jsonl.1")" -eq 200 ]
+assert "large file is truncated in place" [ "$(stat -c %s "$TEST_DIR/gateway.jsonl")" -eq 0 ]
+
+# Older copies shift and at most max-files copies are kept
+head -c 300 /dev/zero > "$TEST_DIR/gateway.jsonl"
+MAX_SIZE=100 run_rotate
+head -c 400 /dev/zero > "$TEST_DIR/gateway.jsonl"
+MAX_SIZE=100 run_rotate
+assert "newest copy is .1" [ "$(stat -c %s "$TEST_DIR/gateway.jsonl.1")" -eq 400 ]
+assert "previous copy shifts to .2" [ "$(stat -c %s "$TEST_DIR/gateway.jsonl.2")" -eq 300 ]
+assert "copies beyond max-files are dropped" [ ! -f "$TEST_DIR/gateway.jsonl.3" ]
+
+# Rotated copies older than max-age are removed, active logs are kept
+touch -d '2 hours ago' "$TEST_DIR/gateway.jsonl.2" "$TEST_DIR/playwright/server.log"
+MAX_AGE=3600 run_rotate
+assert "expired copy is removed" [ ! -f "$TEST_DIR/gateway.jsonl.2" ]
+assert "recent copy is kept" [ -f "$TEST_DIR/gateway.jsonl.1" ]
+assert "old active log is kept" [ -f "$TEST_DIR/playwright/server.log" ]
+
+# A missing directory is not an error
+assert "missing directory succeeds" bash "$ROTATE_SCRIPT" "$TEST_DIR/missing"
+
+echo
+echo "Tests passed: $TESTS_PASSED"
+echo "Tests failed: $TESTS_FAILED"
+
+if [ "$TESTS_FAILED" -gt 0 ]; then
+  exit 1
+fi
+
+echo "✓ All tests passed!"
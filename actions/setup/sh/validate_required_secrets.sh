@@ -0,0 +1,70 @@
+#!/bin/bash
+set -e
+
+# validate_required_secrets.sh - Validate that every secret declared as required in frontmatter is set
+#
+# Usage: validate_required_secrets.sh SECRET_NAME1 [SECRET_NAME2 ...]
+#
+# Arguments:
+#   SECRET_NAME1, SECRET_NAME2, ... : Environment variable names to check (all must be set)
+#
+# Environment:
+#   The script expects the secret values to be available as environment variables.
+#   An optional description for SECRET_NAME can be provided in GH_AW_SECRET_DESCRIPTION_<SECRET_NAME>.
+#
+# Outputs:
+#   secrets_ok - "true" when all secrets are set
+#
+# Exit codes:
+#   0 - All secrets are configured
+#   1 - At least one secret is empty or not set
+
+if [ "$#" -lt 1 ]; then
+  echo "Usage: $0 SECRET_NAME1 [SECRET_NAME2 ...]" >&2
+  exit 1
+fi
+
+missing=()
+for secret_name in "$@"; do
+  # Use indirect expansion to get the value of the variable named by secret_name
+  if [ -z "${!secret_name}" ]; then
+    missing+=("$secret_name")
+  fi
+done
+
+if [ "${#missing[@]}" -gt 0 ]; then
+  {
+    for secret_name in "${missing[@]}"; do
+      echo "❌ Secret $secret_name is required but not set."
+      description_var="GH_AW_SECRET_DESCRIPTION_${secret_name}"
+      if [ -n "${!description_var}" ]; then
+        echo "   ${!description_var}"
+      fi
+    done
+    echo ""
+    echo "**How to fix:**"
+    echo "1. Go to your repository Settings → Secrets and variables → Actions"
+    echo "2. Add a repository secret for each missing name (names are case-sensitive)"
+  } >> "${GITHUB_STEP_SUMMARY:-/dev/null}"
+
+  for secret_name in "${missing[@]}"; do
+    echo "Error: Secret $secret_name is required but not set." >&2
+    description_var="GH_AW_SECRET_DESCRIPTION_${secret_name}"
+    if [ -n "${!description_var}" ]; then
+      echo "  ${!description_var}" >&2
+    fi
+  done
+
+  if [ -n "$GITHUB_OUTPUT" ]; then
+    echo "secrets_ok=false" >> "$GITHUB_OUTPUT"
+  fi
+  exit 1
+fi
+
+for secret_name in "$@"; do
+  echo "✅ $secret_name: Configured"
+done
+
+if [ -n "$GITHUB_OUTPUT" ]; then
+  echo "secrets_ok=true" >> "$GITHUB_OUTPUT"
+fi
@@ -22,6 +22,7 @@ func TestCommandGroupAssignments(t *testing.T) {
 		{name: "add command in setup group", commandName: "add", expectedGroup: "setup", shouldHaveGroup: true},
 		{name: "remove command in setup group", commandName: "remove", expectedGroup: "setup", shouldHaveGroup: true},
 		{name: "update command in setup group", commandName: "update", expectedGroup: "setup", shouldHaveGroup: true},
+		{name: "manifest command in setup group", commandName: "manifest", expectedGroup: "setup", shouldHaveGroup: true},
 		{name: "secrets command in setup group", commandName: "secrets", expectedGroup: "setup", shouldHaveGroup: true},
 
 		// Development Commands
@@ -29,6 +30,8 @@ func TestCommandGroupAssignments(t *testing.T) {
 		{name: "mcp command in development group", commandName: "mcp", expectedGroup: "development", shouldHaveGroup: true},
 		{name: "status command in development group", commandName: "status", expectedGroup: "development", shouldHaveGroup: true},
 		{name: "fix command in development group", commandName: "fix", expectedGroup: "development", shouldHaveGroup: true},
+		{name: "lint command in development group", commandName: "lint", expectedGroup: "development", shouldHaveGroup: true},
+		{name: "diff command in development group", commandName: "diff", expectedGroup: "development", shouldHaveGroup: true},
 
 		// Execution Commands
 		{name: "run command in execution group", commandName: "run", expectedGroup: "execution", shouldHaveGroup: true},
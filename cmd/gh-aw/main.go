@@ -240,6 +240,10 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` compile --dir custom/workflows  # Compile from custom directory
   ` + string(constants.CLIExtensionPrefix) + ` compile --watch ci-doctor     # Watch and auto-compile
   ` + string(constants.CLIExtensionPrefix) + ` compile --trial --logical-repo owner/repo  # Compile for trial mode
+  ` + string(constants.CLIExtensionPrefix) + ` compile --incremental       # Skip unchanged workflows
+  ` + string(constants.CLIExtensionPrefix) + ` compile --check             # Validate without writing files
+  ` + string(constants.CLIExtensionPrefix) + ` compile --explain ci-doctor # Annotate the lock file with source comments
+  ` + string(constants.CLIExtensionPrefix) + ` compile --target gitlab     # Generate GitLab CI files (experimental)
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot        # Generate Dependabot manifests
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot --force  # Force overwrite existing dependabot.yml`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -266,6 +270,10 @@ Examples:
 		fix, _ := cmd.Flags().GetBool("fix")
 		stats, _ := cmd.Flags().GetBool("stats")
 		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		incremental, _ := cmd.Flags().GetBool("incremental")
+		check, _ := cmd.Flags().GetBool("check")
+		explain, _ := cmd.Flags().GetBool("explain")
+		target, _ := cmd.Flags().GetString("target")
 		noCheckUpdate, _ := cmd.Flags().GetBool("no-check-update")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		if err := validateEngine(engineOverride); err != nil {
@@ -318,6 +326,10 @@ Examples:
 			JSONOutput:             jsonOutput,
 			Stats:                  stats,
 			FailFast:               failFast,
+			Incremental:            incremental,
+			Check:                  check,
+			Explain:                explain,
+			Target:                 target,
 		}
 		if _, err := cli.CompileWorkflows(cmd.Context(), config); err != nil {
 			// Return error as-is without additional formatting
@@ -524,6 +536,9 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	// Create and setup update command
 	updateCmd := cli.NewUpdateCommand(validateEngine)
 
+	// Create and setup manifest command
+	manifestCmd := cli.NewManifestCommand()
+
 	// Create and setup trial command
 	trialCmd := cli.NewTrialCommand(validateEngine)
 
@@ -538,12 +553,13 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().StringP("engine", "e", "", "Override AI engine (claude, codex, copilot, custom)")
 	compileCmd.Flags().String("action-mode", "", "Action script inlining mode (inline, dev, release). Auto-detected if not specified")
 	compileCmd.Flags().String("action-tag", "", "Override action SHA or tag for actions/setup (overrides action-mode to release). Accepts full SHA or tag name")
-	compileCmd.Flags().Bool("validate", false, "Enable GitHub Actions workflow schema validation, container image validation, and action SHA validation")
+	compileCmd.Flags().Bool("validate", false, "Enable GitHub Actions workflow schema validation, container image validation, action SHA validation, and repository feature validation (issues, discussions and discussion categories)")
 	compileCmd.Flags().BoolP("watch", "w", false, "Watch for changes to workflow files and recompile automatically")
 	compileCmd.Flags().StringP("dir", "d", "", "Workflow directory (default: .github/workflows)")
 	compileCmd.Flags().String("workflows-dir", "", "Deprecated: use --dir instead")
 	_ = compileCmd.Flags().MarkDeprecated("workflows-dir", "use --dir instead")
 	compileCmd.Flags().Bool("no-emit", false, "Validate workflow without generating lock files")
+	compileCmd.Flags().Bool("check", false, "Check that workflows would compile without writing any files (for pre-commit hooks); exits non-zero on errors")
 	compileCmd.Flags().Bool("purge", false, "Delete .lock.yml files that were not regenerated during compilation (only when no specific files are specified)")
 	compileCmd.Flags().Bool("strict", false, "Override frontmatter to enforce strict mode validation for all workflows (enforces action pinning, network config, safe-outputs, refuses write permissions and deprecated fields). Note: Workflows default to strict mode unless frontmatter sets strict: false")
 	compileCmd.Flags().Bool("trial", false, "Enable trial mode compilation (modifies workflows for trial execution)")
@@ -559,6 +575,9 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().BoolP("json", "j", false, "Output results in JSON format")
 	compileCmd.Flags().Bool("stats", false, "Display statistics table sorted by file size (shows jobs, steps, scripts, and shells)")
 	compileCmd.Flags().Bool("fail-fast", false, "Stop at the first validation error instead of collecting all errors")
+	compileCmd.Flags().Bool("incremental", false, "Skip workflows whose markdown, imports, and compiler version are unchanged since the last compilation (tracked in .lock.yml.hash sidecar files)")
+	compileCmd.Flags().Bool("explain", false, "Annotate generated .lock.yml files with comments naming the frontmatter that produced each job and section")
+	compileCmd.Flags().String("target", "", "CI system to compile for: github (default) or gitlab (experimental, generates .gitlab-ci.yml files)")
 	compileCmd.Flags().Bool("no-check-update", false, "Skip checking for gh-aw updates")
 	compileCmd.MarkFlagsMutuallyExclusive("dir", "workflows-dir")
 
@@ -610,9 +629,12 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	prCmd := cli.NewPRCommand()
 	secretsCmd := cli.NewSecretsCommand()
 	fixCmd := cli.NewFixCommand()
+	lintCmd := cli.NewLintCommand()
+	diffCmd := cli.NewDiffCommand()
 	upgradeCmd := cli.NewUpgradeCommand()
 	completionCmd := cli.NewCompletionCommand()
 	hashCmd := cli.NewHashCommand()
+	schemaCmd := cli.NewSchemaCommand()
 	projectCmd := cli.NewProjectCommand()
 
 	// Assign commands to groups
@@ -622,6 +644,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	addCmd.GroupID = "setup"
 	removeCmd.GroupID = "setup"
 	updateCmd.GroupID = "setup"
+	manifestCmd.GroupID = "setup"
 	upgradeCmd.GroupID = "setup"
 	secretsCmd.GroupID = "setup"
 
@@ -631,6 +654,8 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	statusCmd.GroupID = "development"
 	listCmd.GroupID = "development"
 	fixCmd.GroupID = "development"
+	lintCmd.GroupID = "development"
+	diffCmd.GroupID = "development"
 
 	// Execution Commands
 	runCmd.GroupID = "execution"
@@ -648,6 +673,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	prCmd.GroupID = "utilities"
 	completionCmd.GroupID = "utilities"
 	hashCmd.GroupID = "utilities"
+	schemaCmd.GroupID = "utilities"
 	projectCmd.GroupID = "utilities"
 
 	// version command is intentionally left without a group (common practice)
@@ -655,6 +681,7 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	// Add all commands to root
 	rootCmd.AddCommand(addCmd)
 	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(manifestCmd)
 	rootCmd.AddCommand(upgradeCmd)
 	rootCmd.AddCommand(trialCmd)
 	rootCmd.AddCommand(newCmd)
@@ -675,8 +702,11 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(secretsCmd)
 	rootCmd.AddCommand(fixCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(diffCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(hashCmd)
+	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(projectCmd)
 }
 
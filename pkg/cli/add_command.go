@@ -10,6 +10,7 @@ import (
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/tty"
 	"github.com/github/gh-aw/pkg/workflow"
 	"github.com/spf13/cobra"
@@ -33,6 +34,8 @@ type AddOptions struct {
 	NoStopAfter            bool
 	StopAfter              string
 	DisableSecurityScanner bool
+	SHA                    string // If set, remote workflows are fetched at this commit SHA
+	NoCompile              bool   // If set, workflows are copied and staged without being compiled
 }
 
 // AddWorkflowsResult contains the result of adding workflows
@@ -66,12 +69,14 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor --non-interactive  # Skip interactive mode
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor@v1.0.0         # Add with version
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/workflows/ci-doctor.md@main
+  ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor --sha 0123456789abcdef0123456789abcdef01234567
   ` + string(constants.CLIExtensionPrefix) + ` add https://github.com/githubnext/agentics/blob/main/workflows/ci-doctor.md
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor --create-pull-request --force
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor --push         # Add and push changes
   ` + string(constants.CLIExtensionPrefix) + ` add ./my-workflow.md                             # Add local workflow
   ` + string(constants.CLIExtensionPrefix) + ` add ./*.md                                       # Add all local workflows
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor --dir shared   # Add to .github/workflows/shared/
+  ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor --no-compile   # Add without compiling
 
 Workflow specifications:
   - Three parts: "owner/repo/workflow-name[@version]" (implicitly looks in workflows/ directory)
@@ -87,6 +92,10 @@ The --create-pull-request flag (or --pr) automatically creates a pull request wi
 The --push flag automatically commits and pushes changes after successful workflow addition.
 The --force flag overwrites existing workflow files.
 The --non-interactive flag skips the guided setup and uses traditional behavior.
+The --sha flag fetches remote workflows at an exact commit and records it in the source field.
+If the workflow specification also has an @version, it must resolve to the same commit.
+The --no-compile flag copies and stages the workflow markdown and its includes without compiling
+them, for example to add workflows in bulk and compile them later in CI.
 
 Note: To create a new workflow from scratch, use the 'new' command instead.`,
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -112,14 +121,19 @@ Note: To create a new workflow from scratch, use the 'new' command instead.`,
 			stopAfter, _ := cmd.Flags().GetString("stop-after")
 			nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
 			disableSecurityScanner, _ := cmd.Flags().GetBool("disable-security-scanner")
+			shaFlag, _ := cmd.Flags().GetString("sha")
+			noCompile, _ := cmd.Flags().GetBool("no-compile")
 			if err := validateEngine(engineOverride); err != nil {
 				return err
 			}
+			if err := validateStopAfterFlag(stopAfter); err != nil {
+				return err
+			}
 
 			// Determine if we should use interactive mode
 			// Interactive mode is the default for TTY unless:
 			// - --non-interactive flag is set
-			// - Any of the batch/automation flags are set (--create-pull-request, --force, --name, --append)
+			// - Any of the batch/automation flags are set (--create-pull-request, --force, --name, --append, --sha, --no-compile)
 			// - Not a TTY (piped input/output)
 			// - In CI environment
 			useInteractive := !nonInteractive &&
@@ -127,6 +141,8 @@ Note: To create a new workflow from scratch, use the 'new' command instead.`,
 				!forceFlag &&
 				nameFlag == "" &&
 				appendText == "" &&
+				shaFlag == "" &&
+				!noCompile &&
 				tty.IsStdoutTerminal() &&
 				os.Getenv("CI") == "" &&
 				os.Getenv("GO_TEST_MODE") != "true"
@@ -150,6 +166,8 @@ Note: To create a new workflow from scratch, use the 'new' command instead.`,
 				NoStopAfter:            noStopAfter,
 				StopAfter:              stopAfter,
 				DisableSecurityScanner: disableSecurityScanner,
+				SHA:                    shaFlag,
+				NoCompile:              noCompile,
 			}
 			_, err := AddWorkflows(workflows, opts)
 			return err
@@ -197,6 +215,12 @@ Note: To create a new workflow from scratch, use the 'new' command instead.`,
 	// Add disable-security-scanner flag to add command
 	cmd.Flags().Bool("disable-security-scanner", false, "Disable security scanning of workflow markdown content")
 
+	// Add sha flag to add command
+	cmd.Flags().String("sha", "", "Pin remote workflows to this full commit SHA and record it in the source field; fails if the @version resolves to a different commit")
+
+	// Add no-compile flag to add command
+	cmd.Flags().Bool("no-compile", false, "Copy and stage workflow files without compiling them")
+
 	// Register completions for add command
 	RegisterEngineFlagCompletion(cmd)
 	RegisterDirFlagCompletion(cmd, "dir")
@@ -208,8 +232,8 @@ Note: To create a new workflow from scratch, use the 'new' command instead.`,
 // with optional repository installation and PR creation.
 // Returns AddWorkflowsResult containing PR number (if created) and other metadata.
 func AddWorkflows(workflows []string, opts AddOptions) (*AddWorkflowsResult, error) {
-	// Resolve workflows first - fetches content directly from GitHub
-	resolved, err := ResolveWorkflows(workflows, opts.Verbose)
+	// Resolve workflows first - fetches content directly from GitHub, at the pinned commit if --sha is set
+	resolved, err := ResolveWorkflowsAtSHA(workflows, opts.SHA, opts.Verbose)
 	if err != nil {
 		return nil, err
 	}
@@ -393,7 +417,11 @@ func addWorkflowWithTracking(resolved *ResolvedWorkflow, tracker *FileTracker, o
 
 	// Security scan: reject workflows containing malicious or dangerous content
 	if !opts.DisableSecurityScanner {
-		if findings := workflow.ScanMarkdownSecurity(string(sourceContent)); len(findings) > 0 {
+		securityRules, err := loadCustomSecurityRules()
+		if err != nil {
+			return err
+		}
+		if findings := workflow.ScanMarkdownSecurityWithRules(string(sourceContent), securityRules); len(findings) > 0 {
 			fmt.Fprintln(os.Stderr, console.FormatErrorMessage("Security scan failed for workflow"))
 			fmt.Fprintln(os.Stderr, workflow.FormatSecurityFindings(findings, workflowSpec.WorkflowPath))
 			return fmt.Errorf("workflow '%s' failed security scan: %d issue(s) detected", workflowSpec.WorkflowPath, len(findings))
@@ -470,6 +498,10 @@ func addWorkflowWithTracking(resolved *ResolvedWorkflow, tracker *FileTracker, o
 		// The source directory is derived from the workflow's path
 		sourceDir := filepath.Dir(workflowSpec.WorkflowPath)
 		includeDeps, err := collectLocalIncludeDependencies(string(sourceContent), sourceDir, opts.Verbose)
+		var cycleErr *parser.ImportCycleError
+		if errors.As(err, &cycleErr) {
+			return parser.FormatImportCycleError(cycleErr)
+		}
 		if err != nil {
 			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to collect include dependencies: %v", err)))
 		}
@@ -491,7 +523,12 @@ func addWorkflowWithTracking(resolved *ResolvedWorkflow, tracker *FileTracker, o
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Overwriting existing file: "+destFile))
 	}
 
-	content := string(sourceContent)
+	// Lock cross-repo imports pinned with a 'ref' field to the commit SHA the ref resolves to now,
+	// so they are recorded with the same provenance as the workflow's own source field
+	content, err := pinImportRefsInContent(string(sourceContent), parser.ResolveRefToSHA, opts.Verbose)
+	if err != nil {
+		return fmt.Errorf("failed to pin imports of workflow '%s': %w", workflowName, err)
+	}
 
 	// Add source field to frontmatter
 	commitSHA := ""
@@ -520,6 +557,10 @@ func addWorkflowWithTracking(resolved *ResolvedWorkflow, tracker *FileTracker, o
 			includeSourceDir = filepath.Dir(workflowSpec.WorkflowPath)
 		}
 		processedContent, err := processIncludesWithWorkflowSpec(content, workflowSpec, commitSHA, includeSourceDir, opts.Verbose)
+		var cycleErr *parser.ImportCycleError
+		if errors.As(err, &cycleErr) {
+			return parser.FormatImportCycleError(cycleErr)
+		}
 		if err != nil {
 			if opts.Verbose {
 				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to process includes: %v", err)))
@@ -604,7 +645,14 @@ func addWorkflowWithTracking(resolved *ResolvedWorkflow, tracker *FileTracker, o
 		}
 	}
 
-	// Compile the workflow
+	// Compile the workflow unless compilation is deferred
+	if opts.NoCompile {
+		addLog.Printf("Skipping compilation of %s (--no-compile)", destFile)
+		if !opts.Quiet {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Skipping compilation (--no-compile); run 'gh aw compile' to generate the lock file"))
+		}
+		return nil
+	}
 	if tracker != nil {
 		if err := compileWorkflowWithTracking(destFile, opts.Verbose, opts.Quiet, opts.EngineOverride, tracker); err != nil {
 			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(err.Error()))
@@ -617,3 +665,27 @@ func addWorkflowWithTracking(resolved *ResolvedWorkflow, tracker *FileTracker, o
 
 	return nil
 }
+
+// validateStopAfterFlag checks that a --stop-after value can be resolved before it is written
+// into the workflow frontmatter
+func validateStopAfterFlag(stopAfter string) error {
+	if stopAfter == "" {
+		return nil
+	}
+	if _, err := workflow.ParseStopAfter(stopAfter); err != nil {
+		return fmt.Errorf("invalid --stop-after value: %w", err)
+	}
+	return nil
+}
+
+// loadCustomSecurityRules loads the organization's custom security scanning rules from
+// .github/aw/security-rules.json in the current repository. Outside a git repository, or
+// when the file does not exist, only the built-in checks apply.
+func loadCustomSecurityRules() ([]workflow.SecurityRule, error) {
+	gitRoot, err := findGitRoot()
+	if err != nil {
+		addLog.Printf("Not in a git repository, skipping custom security rules: %v", err)
+		return nil, nil
+	}
+	return workflow.LoadSecurityRules(filepath.Join(gitRoot, workflow.SecurityRulesFile))
+}
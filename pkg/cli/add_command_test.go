@@ -3,8 +3,13 @@
 package cli
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/testutil"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -298,6 +303,7 @@ func TestAddCommandFlagDefaults(t *testing.T) {
 		{"append", ""},
 		{"dir", ""},
 		{"stop-after", ""},
+		{"sha", ""},
 	}
 
 	for _, tt := range tests {
@@ -313,7 +319,7 @@ func TestAddCommandBooleanFlags(t *testing.T) {
 	cmd := NewAddCommand(validateEngineStub)
 	flags := cmd.Flags()
 
-	boolFlags := []string{"create-pull-request", "pr", "force", "no-gitattributes", "no-stop-after"}
+	boolFlags := []string{"create-pull-request", "pr", "force", "no-gitattributes", "no-stop-after", "no-compile"}
 
 	for _, flagName := range boolFlags {
 		t.Run(flagName, func(t *testing.T) {
@@ -340,3 +346,63 @@ func TestAddCommandArgs(t *testing.T) {
 	err = cmd.Args(cmd, []string{"workflow1", "workflow2"})
 	require.NoError(t, err, "Should not error with multiple arguments")
 }
+
+func TestValidateStopAfterFlag(t *testing.T) {
+	require.NoError(t, validateStopAfterFlag(""), "empty value should leave stop-after unchanged")
+	require.NoError(t, validateStopAfterFlag("+48h"))
+	require.NoError(t, validateStopAfterFlag("2025-12-31 23:59:59"))
+
+	err := validateStopAfterFlag("06/01/2025")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --stop-after value")
+	assert.Contains(t, err.Error(), "ambiguous")
+}
+
+func TestAddWorkflowsNoCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "add-no-compile-test")
+	originalDir, err := os.Getwd()
+	require.NoError(t, err, "Should get current directory")
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(tmpDir), "Should change to temp directory")
+	if err := exec.Command("git", "init").Run(); err != nil {
+		t.Skip("Skipping test - git not available")
+	}
+
+	workflowContent := `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Test Workflow
+
+This is a test workflow.`
+
+	resolved := &ResolvedWorkflow{
+		Spec: &WorkflowSpec{
+			RepoSpec:     RepoSpec{RepoSlug: "test/repo"},
+			WorkflowPath: "./test.md",
+			WorkflowName: "test",
+		},
+		Content: []byte(workflowContent),
+		SourceInfo: &FetchedWorkflow{
+			Content:    []byte(workflowContent),
+			IsLocal:    true,
+			SourcePath: "./test.md",
+		},
+	}
+
+	opts := AddOptions{NoCompile: true, NoGitattributes: true, Quiet: true}
+	require.NoError(t, addWorkflows([]*ResolvedWorkflow{resolved}, opts), "Adding with --no-compile should succeed")
+
+	markdownPath := filepath.Join(tmpDir, ".github", "workflows", "test.md")
+	assert.FileExists(t, markdownPath, "Workflow markdown should be written")
+	assert.NoFileExists(t, filepath.Join(tmpDir, ".github", "workflows", "test.lock.yml"), "Lock file should not be produced")
+
+	staged, err := exec.Command("git", "diff", "--cached", "--name-only").Output()
+	require.NoError(t, err, "Should list staged files")
+	assert.Equal(t, []string{".github/workflows/test.md"}, strings.Fields(string(staged)), "Only the workflow markdown should be staged")
+}
@@ -0,0 +1,118 @@
+//go:build !integration
+
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	pinnedTestSHA = "0123456789abcdef0123456789abcdef01234567"
+	otherTestSHA  = "fedcba9876543210fedcba9876543210fedcba98"
+)
+
+func TestPinWorkflowSpecToSHA(t *testing.T) {
+	tests := []struct {
+		name            string
+		version         string
+		sha             string
+		resolved        string
+		resolveErr      error
+		expectResolve   bool
+		expectError     string
+		expectedVersion string
+	}{
+		{
+			name:            "no version pins to sha",
+			sha:             pinnedTestSHA,
+			expectedVersion: pinnedTestSHA,
+		},
+		{
+			name:            "sha overrides a branch that resolves to it",
+			version:         "main",
+			sha:             pinnedTestSHA,
+			resolved:        pinnedTestSHA,
+			expectResolve:   true,
+			expectedVersion: pinnedTestSHA,
+		},
+		{
+			name:            "version equal to sha is not resolved",
+			version:         pinnedTestSHA,
+			sha:             pinnedTestSHA,
+			expectedVersion: pinnedTestSHA,
+		},
+		{
+			name:            "uppercase sha is normalized",
+			version:         "v1.0.0",
+			sha:             "0123456789ABCDEF0123456789ABCDEF01234567",
+			resolved:        pinnedTestSHA,
+			expectResolve:   true,
+			expectedVersion: pinnedTestSHA,
+		},
+		{
+			name:          "version resolving to a different commit",
+			version:       "v1.0.0",
+			sha:           pinnedTestSHA,
+			resolved:      otherTestSHA,
+			expectResolve: true,
+			expectError:   "version 'v1.0.0' of owner/repo resolves to commit " + otherTestSHA + ", which does not match --sha " + pinnedTestSHA,
+		},
+		{
+			name:          "version that cannot be resolved",
+			version:       "missing-branch",
+			sha:           pinnedTestSHA,
+			resolveErr:    errors.New("not found"),
+			expectResolve: true,
+			expectError:   "failed to resolve owner/repo@missing-branch to verify --sha",
+		},
+		{
+			name:        "short sha is rejected",
+			sha:         "0123456",
+			expectError: "--sha must be a full 40-character commit SHA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &WorkflowSpec{
+				RepoSpec:     RepoSpec{RepoSlug: "owner/repo", Version: tt.version},
+				WorkflowPath: "workflows/ci-doctor.md",
+				WorkflowName: "ci-doctor",
+			}
+			resolveCalled := false
+			resolve := func(owner, repo, ref string) (string, error) {
+				resolveCalled = true
+				assert.Equal(t, "owner", owner)
+				assert.Equal(t, "repo", repo)
+				assert.Equal(t, tt.version, ref)
+				return tt.resolved, tt.resolveErr
+			}
+
+			err := pinWorkflowSpecToSHA(spec, tt.sha, resolve)
+			assert.Equal(t, tt.expectResolve, resolveCalled, "resolver call should match expectation")
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, spec.Version, "spec should be pinned to the sha")
+			assert.Equal(t, "owner/repo/workflows/ci-doctor.md@"+tt.expectedVersion, buildSourceStringWithCommitSHA(spec, ""),
+				"the pinned sha should be recorded in the source field")
+		})
+	}
+}
+
+func TestPinWorkflowSpecToSHALocalWorkflow(t *testing.T) {
+	spec := &WorkflowSpec{WorkflowPath: "./my-workflow.md", WorkflowName: "my-workflow"}
+	err := pinWorkflowSpecToSHA(spec, pinnedTestSHA, func(owner, repo, ref string) (string, error) {
+		t.Fatal("local workflows should not be resolved")
+		return "", nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--sha cannot be used with local workflow './my-workflow.md'")
+}
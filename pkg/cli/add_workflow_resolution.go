@@ -46,7 +46,18 @@ type ResolvedWorkflows struct {
 // For remote workflows, content is fetched directly from GitHub without cloning.
 // Wildcards are only supported for local workflows (not remote repositories).
 func ResolveWorkflows(workflows []string, verbose bool) (*ResolvedWorkflows, error) {
-	resolutionLog.Printf("Resolving workflows: count=%d", len(workflows))
+	return resolveWorkflows(workflows, "", verbose)
+}
+
+// ResolveWorkflowsAtSHA resolves workflow specifications like ResolveWorkflows, but fetches every
+// remote workflow at the given commit SHA (see pinWorkflowSpecToSHA)
+func ResolveWorkflowsAtSHA(workflows []string, sha string, verbose bool) (*ResolvedWorkflows, error) {
+	return resolveWorkflows(workflows, sha, verbose)
+}
+
+// resolveWorkflows implements ResolveWorkflows, pinning remote workflows to pinSHA when it is set
+func resolveWorkflows(workflows []string, pinSHA string, verbose bool) (*ResolvedWorkflows, error) {
+	resolutionLog.Printf("Resolving workflows: count=%d, pinSHA=%s", len(workflows), pinSHA)
 
 	if len(workflows) == 0 {
 		return nil, errors.New("at least one workflow name is required")
@@ -72,6 +83,15 @@ func ResolveWorkflows(workflows []string, verbose bool) (*ResolvedWorkflows, err
 			return nil, fmt.Errorf("wildcards are only supported for local workflows, not remote repositories: %s", workflow)
 		}
 
+		if pinSHA != "" {
+			if err := pinWorkflowSpecToSHA(spec, pinSHA, parser.ResolveRefToSHA); err != nil {
+				return nil, err
+			}
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Pinning %s to commit %s", spec.WorkflowPath, pinSHA)))
+			}
+		}
+
 		parsedSpecs = append(parsedSpecs, spec)
 	}
 
@@ -158,6 +178,35 @@ func ResolveWorkflows(workflows []string, verbose bool) (*ResolvedWorkflows, err
 	}, nil
 }
 
+// pinWorkflowSpecToSHA pins a remote workflow spec to an exact commit SHA so its content is fetched
+// at that commit and the SHA is recorded in the source field. When the spec also names a version,
+// the version must resolve to the same commit; otherwise the workflow is not added.
+func pinWorkflowSpecToSHA(spec *WorkflowSpec, sha string, resolveRef func(owner, repo, ref string) (string, error)) error {
+	if isLocalWorkflowPath(spec.WorkflowPath) {
+		return fmt.Errorf("--sha cannot be used with local workflow '%s': local workflows have no commit to pin", spec.WorkflowPath)
+	}
+
+	if !IsCommitSHA(sha) {
+		return fmt.Errorf("--sha must be a full 40-character commit SHA, got: %s", sha)
+	}
+
+	sha = strings.ToLower(sha)
+	if spec.Version != "" && strings.ToLower(spec.Version) != sha {
+		owner, repo, _ := strings.Cut(spec.RepoSlug, "/")
+		resolved, err := resolveRef(owner, repo, spec.Version)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s@%s to verify --sha %s: %w", spec.RepoSlug, spec.Version, sha, err)
+		}
+		if strings.ToLower(resolved) != sha {
+			return fmt.Errorf("version '%s' of %s resolves to commit %s, which does not match --sha %s. Remove the version or pass the matching SHA", spec.Version, spec.RepoSlug, resolved, sha)
+		}
+		resolutionLog.Printf("Version %s of %s matches pinned SHA %s", spec.Version, spec.RepoSlug, sha)
+	}
+
+	spec.Version = sha
+	return nil
+}
+
 // expandLocalWildcardWorkflows expands wildcard workflow specifications for local workflows only.
 func expandLocalWildcardWorkflows(specs []*WorkflowSpec, verbose bool) ([]*WorkflowSpec, error) {
 	expandedWorkflows := []*WorkflowSpec{}
@@ -207,6 +256,11 @@ func checkWorkflowHasDispatchFromContent(content string) bool {
 		return false
 	}
 
+	// Typed top-level inputs are compiled into a workflow_dispatch trigger
+	if _, hasInputs := result.Frontmatter["inputs"]; hasInputs {
+		return true
+	}
+
 	switch on := onSection.(type) {
 	case map[string]any:
 		_, hasDispatch := on["workflow_dispatch"]
@@ -23,6 +23,13 @@ import (
 
 var auditLog = logger.New("cli:audit")
 
+// Audit output formats accepted by the audit command's --format flag
+const (
+	AuditFormatConsole = "console"
+	AuditFormatJSON    = "json"
+	AuditFormatJUnit   = "junit"
+)
+
 // NewAuditCommand creates the audit command
 func NewAuditCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -60,6 +67,8 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.example.com/owner/repo/actions/runs/1234567890  # Audit from GitHub Enterprise
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 -o ./audit-reports  # Custom output directory
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 -v  # Verbose output
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --format json   # Structured JSON output (same as --json)
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --format junit  # JUnit XML report for CI dashboards
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --parse  # Parse agent logs and firewall logs, generating log.md and firewall.md`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -76,6 +85,15 @@ Examples:
 			verbose, _ := cmd.Flags().GetBool("verbose")
 			jsonOutput, _ := cmd.Flags().GetBool("json")
 			parse, _ := cmd.Flags().GetBool("parse")
+			formatFlag, _ := cmd.Flags().GetString("format")
+
+			format, err := resolveAuditFormat(formatFlag, jsonOutput)
+			if err != nil {
+				return err
+			}
+			if format == AuditFormatJUnit && components.JobID > 0 {
+				return errors.New("--format junit is only supported when auditing a whole workflow run, not a job")
+			}
 
 			return AuditWorkflowRun(
 				cmd.Context(),
@@ -86,7 +104,7 @@ Examples:
 				outputDir,
 				verbose,
 				parse,
-				jsonOutput,
+				format,
 				components.JobID,
 				components.StepNumber,
 			)
@@ -96,6 +114,7 @@ Examples:
 	// Add flags to audit command
 	addOutputFlag(cmd, defaultLogsOutputDir)
 	addJSONFlag(cmd)
+	cmd.Flags().String("format", "", "Output format: console, json, or junit (default console)")
 	cmd.Flags().Bool("parse", false, "Run JavaScript parsers on agent logs and firewall logs, writing Markdown to log.md and firewall.md")
 
 	// Register completions for audit command
@@ -129,8 +148,11 @@ func isPermissionError(err error) bool {
 // AuditWorkflowRun audits a single workflow run and generates a report
 // If jobID is provided (>0), focuses audit on that specific job
 // If stepNumber is provided (>0), extracts output for that specific step
-func AuditWorkflowRun(ctx context.Context, runID int64, owner, repo, hostname string, outputDir string, verbose bool, parse bool, jsonOutput bool, jobID int64, stepNumber int) error {
-	auditLog.Printf("Starting audit for workflow run: runID=%d, owner=%s, repo=%s, jobID=%d, stepNumber=%d", runID, owner, repo, jobID, stepNumber)
+func AuditWorkflowRun(ctx context.Context, runID int64, owner, repo, hostname string, outputDir string, verbose bool, parse bool, format string, jobID int64, stepNumber int) error {
+	auditLog.Printf("Starting audit for workflow run: runID=%d, owner=%s, repo=%s, jobID=%d, stepNumber=%d, format=%s", runID, owner, repo, jobID, stepNumber, format)
+
+	// Structured formats write only the report to stdout
+	jsonOutput := format == AuditFormatJSON || format == AuditFormatJUnit
 
 	// Check context cancellation at the start
 	select {
@@ -195,7 +217,7 @@ func AuditWorkflowRun(ctx context.Context, runID int64, owner, repo, hostname st
 
 		// Download artifacts for the run
 		auditLog.Printf("Downloading artifacts for run %d", runID)
-		err := downloadRunArtifacts(runID, runOutputDir, verbose)
+		err := downloadRunArtifacts(runID, runOutputDir, verbose, false)
 		if err != nil {
 			// Gracefully handle cases where the run legitimately has no artifacts
 			if errors.Is(err, ErrNoArtifacts) {
@@ -339,11 +361,16 @@ func AuditWorkflowRun(ctx context.Context, runID int64, owner, repo, hostname st
 	auditData := buildAuditData(processedRun, metrics, mcpToolUsage)
 
 	// Render output based on format preference
-	if jsonOutput {
+	switch format {
+	case AuditFormatJSON:
 		if err := renderJSON(auditData); err != nil {
 			return fmt.Errorf("failed to render JSON output: %w", err)
 		}
-	} else {
+	case AuditFormatJUnit:
+		if err := renderJUnit([]AuditData{auditData}); err != nil {
+			return fmt.Errorf("failed to render JUnit output: %w", err)
+		}
+	default:
 		renderConsole(auditData, runOutputDir)
 	}
 
@@ -422,6 +449,23 @@ func AuditWorkflowRun(ctx context.Context, runID int64, owner, repo, hostname st
 	return nil
 }
 
+// resolveAuditFormat combines the --format and --json flags into a single output format
+func resolveAuditFormat(format string, jsonOutput bool) (string, error) {
+	switch format {
+	case "":
+		if jsonOutput {
+			return AuditFormatJSON, nil
+		}
+		return AuditFormatConsole, nil
+	case AuditFormatConsole, AuditFormatJSON, AuditFormatJUnit:
+		if jsonOutput && format != AuditFormatJSON {
+			return "", fmt.Errorf("--json cannot be combined with --format %s", format)
+		}
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid format '%s'. Must be one of: %s, %s, %s", format, AuditFormatConsole, AuditFormatJSON, AuditFormatJUnit)
+}
+
 // auditJobRun performs a targeted audit of a specific job within a workflow run
 // If stepNumber > 0, focuses on extracting output for that specific step
 func auditJobRun(runID int64, jobID int64, stepNumber int, owner, repo, hostname string, outputDir string, verbose bool, jsonOutput bool) error {
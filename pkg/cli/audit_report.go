@@ -38,6 +38,16 @@ type AuditData struct {
 	ToolUsage               []ToolUsageInfo          `json:"tool_usage,omitempty"`
 	MCPToolUsage            *MCPToolUsageData        `json:"mcp_tool_usage,omitempty"`
 	CreatedItems            []CreatedItemReport      `json:"created_items,omitempty"`
+	SafeOutputs             *SafeOutputsSummary      `json:"safe_outputs,omitempty"`
+	Patch                   *PatchStats              `json:"patch,omitempty"`
+	CostEstimate            *CostEstimate            `json:"cost_estimate,omitempty"`
+}
+
+// SafeOutputsSummary summarizes the safe output records emitted by the agent
+type SafeOutputsSummary struct {
+	Total       int                   `json:"total"`
+	ByType      map[string]int        `json:"by_type,omitempty"`
+	ParseErrors []SafeOutputLineError `json:"parse_errors,omitempty"`
 }
 
 // Finding represents a key insight discovered during audit
@@ -78,6 +88,7 @@ type PerformanceMetrics struct {
 type OverviewData struct {
 	RunID        int64     `json:"run_id" console:"header:Run ID"`
 	WorkflowName string    `json:"workflow_name" console:"header:Workflow"`
+	Engine       string    `json:"engine,omitempty" console:"header:Engine,omitempty"`
 	Status       string    `json:"status" console:"header:Status"`
 	Conclusion   string    `json:"conclusion,omitempty" console:"header:Conclusion,omitempty"`
 	CreatedAt    time.Time `json:"created_at" console:"header:Created At"`
@@ -190,6 +201,7 @@ type MCPServerStats struct {
 type OverviewDisplay struct {
 	RunID    int64  `console:"header:Run ID"`
 	Workflow string `console:"header:Workflow"`
+	Engine   string `console:"header:Engine,omitempty"`
 	Status   string `console:"header:Status"`
 	Duration string `console:"header:Duration,omitempty"`
 	Event    string `console:"header:Event"`
@@ -258,6 +270,25 @@ func buildAuditData(processedRun ProcessedRun, metrics LogMetrics, mcpToolUsage
 	// Build downloaded files list
 	downloadedFiles := extractDownloadedFiles(run.LogsPath)
 
+	// Parse the structured run artifacts (engine, safe outputs, and patch statistics)
+	var safeOutputs *SafeOutputsSummary
+	var patch *PatchStats
+	var awInfo *AwInfo
+	if artifacts := parseAuditRunArtifacts(run.LogsPath); artifacts != nil {
+		awInfo = artifacts.AwInfo
+		if awInfo != nil {
+			overview.Engine = awInfo.EngineID
+		}
+		safeOutputs = summarizeSafeOutputs(artifacts)
+		patch = artifacts.Patch
+	}
+
+	// Estimate the cost from the token usage when the engine logs did not report one
+	costEstimate := estimateRunCost(awInfo, run.TokenUsage, run.EstimatedCost, run.Duration, resolvePriceTable())
+	if costEstimate != nil {
+		metricsData.EstimatedCost = costEstimate.EstimatedCost
+	}
+
 	// No error/warning extraction since error patterns have been removed
 	var errors []ErrorInfo
 	var warnings []ErrorInfo
@@ -346,6 +377,36 @@ func buildAuditData(processedRun ProcessedRun, metrics LogMetrics, mcpToolUsage
 		ToolUsage:               toolUsage,
 		MCPToolUsage:            mcpToolUsage,
 		CreatedItems:            extractCreatedItemsFromManifest(run.LogsPath),
+		SafeOutputs:             safeOutputs,
+		Patch:                   patch,
+		CostEstimate:            costEstimate,
+	}
+}
+
+// parseAuditRunArtifacts parses the run artifacts in logsPath for the audit report.
+// Parse failures are logged and return nil so the report omits the artifact sections.
+func parseAuditRunArtifacts(logsPath string) *RunArtifacts {
+	if logsPath == "" {
+		return nil
+	}
+	artifacts, err := ParseRunArtifacts(logsPath)
+	if err != nil {
+		auditReportLog.Printf("Failed to parse run artifacts: %v", err)
+		return nil
+	}
+	return artifacts
+}
+
+// summarizeSafeOutputs counts the decoded safe output records by type.
+// Returns nil when the run has no safe_output.jsonl.
+func summarizeSafeOutputs(artifacts *RunArtifacts) *SafeOutputsSummary {
+	if !artifacts.HasSafeOutputFile {
+		return nil
+	}
+	return &SafeOutputsSummary{
+		Total:       len(artifacts.SafeOutputs),
+		ByType:      artifacts.SafeOutputTypeCounts(),
+		ParseErrors: artifacts.SafeOutputErrors,
 	}
 }
 
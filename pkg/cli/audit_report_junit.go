@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite groups the audited runs of a single workflow
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase represents one audited workflow run
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	Classname string         `xml:"classname,attr"`
+	Time      string         `xml:"time,attr"`
+	Failures  []junitFailure `xml:"failure,omitempty"`
+	SystemOut string         `xml:"system-out,omitempty"`
+}
+
+// junitFailure represents an error reported for a run
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// renderJUnit outputs the audit data as a JUnit XML report
+func renderJUnit(runs []AuditData) error {
+	return writeAuditJUnit(os.Stdout, runs)
+}
+
+// writeAuditJUnit writes the audited runs as a JUnit XML report. Each workflow becomes a test
+// suite and each run a test case; run errors and failed conclusions are reported as failures.
+func writeAuditJUnit(w io.Writer, runs []AuditData) error {
+	auditReportLog.Printf("Rendering audit report as JUnit XML for %d run(s)", len(runs))
+
+	report := junitTestSuites{Name: "gh-aw audit"}
+	suiteIndex := make(map[string]int)
+	suiteSeconds := make(map[string]float64)
+	var totalSeconds float64
+
+	for _, data := range runs {
+		testCase := buildJUnitTestCase(data)
+		seconds := auditRunSeconds(data.Overview)
+
+		suiteName := data.Overview.WorkflowName
+		idx, exists := suiteIndex[suiteName]
+		if !exists {
+			idx = len(report.Suites)
+			suiteIndex[suiteName] = idx
+			report.Suites = append(report.Suites, junitTestSuite{Name: suiteName})
+		}
+		suite := &report.Suites[idx]
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		if len(testCase.Failures) > 0 {
+			suite.Failures++
+			report.Failures++
+		}
+		if suite.Timestamp == "" && !data.Overview.CreatedAt.IsZero() {
+			suite.Timestamp = data.Overview.CreatedAt.UTC().Format("2006-01-02T15:04:05")
+		}
+		suiteSeconds[suiteName] += seconds
+		totalSeconds += seconds
+		report.Tests++
+	}
+
+	for i := range report.Suites {
+		report.Suites[i].Time = formatJUnitSeconds(suiteSeconds[report.Suites[i].Name])
+	}
+	report.Time = formatJUnitSeconds(totalSeconds)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// buildJUnitTestCase maps an audited run to a test case
+func buildJUnitTestCase(data AuditData) junitTestCase {
+	overview := data.Overview
+	testCase := junitTestCase{
+		Name:      fmt.Sprintf("run %d", overview.RunID),
+		Classname: overview.WorkflowName,
+		Time:      formatJUnitSeconds(auditRunSeconds(overview)),
+		SystemOut: buildJUnitSystemOut(data),
+	}
+
+	for _, errInfo := range data.Errors {
+		text := errInfo.Message
+		if errInfo.File != "" {
+			text = fmt.Sprintf("%s:%d: %s", errInfo.File, errInfo.Line, errInfo.Message)
+		}
+		testCase.Failures = append(testCase.Failures, junitFailure{
+			Message: errInfo.Message,
+			Type:    errInfo.Type,
+			Text:    text,
+		})
+	}
+
+	// A failed run without extracted errors is still a failure
+	if len(testCase.Failures) == 0 && isFailureConclusion(overview.Conclusion) {
+		message := "Workflow run concluded with " + overview.Conclusion
+		if data.FailureAnalysis != nil && data.FailureAnalysis.ErrorSummary != "" {
+			message = data.FailureAnalysis.ErrorSummary
+		}
+		testCase.Failures = append(testCase.Failures, junitFailure{
+			Message: message,
+			Type:    overview.Conclusion,
+			Text:    overview.URL,
+		})
+	}
+
+	return testCase
+}
+
+// buildJUnitSystemOut summarizes the run details that have no JUnit equivalent
+func buildJUnitSystemOut(data AuditData) string {
+	overview := data.Overview
+	var lines []string
+	if overview.Engine != "" {
+		lines = append(lines, "engine: "+overview.Engine)
+	}
+	if overview.Conclusion != "" {
+		lines = append(lines, "conclusion: "+overview.Conclusion)
+	}
+	if overview.Duration != "" {
+		lines = append(lines, "duration: "+overview.Duration)
+	}
+	if overview.URL != "" {
+		lines = append(lines, "url: "+overview.URL)
+	}
+
+	if data.SafeOutputs != nil {
+		types := make([]string, 0, len(data.SafeOutputs.ByType))
+		for outputType := range data.SafeOutputs.ByType {
+			types = append(types, outputType)
+		}
+		sort.Strings(types)
+		parts := make([]string, 0, len(types))
+		for _, outputType := range types {
+			parts = append(parts, fmt.Sprintf("%s=%d", outputType, data.SafeOutputs.ByType[outputType]))
+		}
+		summary := fmt.Sprintf("safe outputs: %d", data.SafeOutputs.Total)
+		if len(parts) > 0 {
+			summary += " (" + strings.Join(parts, ", ") + ")"
+		}
+		lines = append(lines, summary)
+	}
+
+	if len(data.ToolUsage) > 0 {
+		toolCalls := 0
+		for _, tool := range data.ToolUsage {
+			toolCalls += tool.CallCount
+		}
+		lines = append(lines, fmt.Sprintf("tool calls: %d across %d tool(s)", toolCalls, len(data.ToolUsage)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// auditRunSeconds returns the run duration in seconds from its start and last update times
+func auditRunSeconds(overview OverviewData) float64 {
+	if overview.StartedAt.IsZero() || overview.UpdatedAt.IsZero() || overview.UpdatedAt.Before(overview.StartedAt) {
+		return 0
+	}
+	return overview.UpdatedAt.Sub(overview.StartedAt).Seconds()
+}
+
+// formatJUnitSeconds formats a duration in seconds as JUnit expects it
+func formatJUnitSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
@@ -0,0 +1,175 @@
+//go:build !integration
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleAuditRuns returns audit data for a successful run, a failed run with errors,
+// and a failed run without extracted errors
+func sampleAuditRuns() []AuditData {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	return []AuditData{
+		{
+			Overview: OverviewData{
+				RunID:        101,
+				WorkflowName: "Issue Triage",
+				Engine:       "claude",
+				Status:       "completed",
+				Conclusion:   "success",
+				CreatedAt:    start,
+				StartedAt:    start,
+				UpdatedAt:    start.Add(90 * time.Second),
+				Duration:     "1.5m",
+				URL:          "https://github.com/org/repo/actions/runs/101",
+			},
+			SafeOutputs: &SafeOutputsSummary{Total: 2, ByType: map[string]int{"add_labels": 1, "add_comment": 1}},
+			ToolUsage:   []ToolUsageInfo{{Name: "github.get_issue", CallCount: 3}, {Name: "bash", CallCount: 2}},
+		},
+		{
+			Overview: OverviewData{
+				RunID:        102,
+				WorkflowName: "Issue Triage",
+				Engine:       "claude",
+				Status:       "completed",
+				Conclusion:   "failure",
+				CreatedAt:    start.Add(time.Hour),
+				StartedAt:    start.Add(time.Hour),
+				UpdatedAt:    start.Add(time.Hour + 30*time.Second),
+			},
+			Errors: []ErrorInfo{
+				{File: "agent-stdio.log", Line: 12, Type: "error", Message: "MCP server github failed to start"},
+				{Type: "error", Message: "Agent exited with code 1 & <no output>"},
+			},
+		},
+		{
+			Overview: OverviewData{
+				RunID:        201,
+				WorkflowName: "Docs Updater",
+				Engine:       "copilot",
+				Status:       "completed",
+				Conclusion:   "timed_out",
+				URL:          "https://github.com/org/repo/actions/runs/201",
+			},
+		},
+	}
+}
+
+func TestWriteAuditJSONSampleRuns(t *testing.T) {
+	for _, run := range sampleAuditRuns() {
+		var buf bytes.Buffer
+		require.NoError(t, writeAuditJSON(&buf, run))
+		require.True(t, json.Valid(buf.Bytes()), "output should be well-formed JSON")
+
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		overview, ok := decoded["overview"].(map[string]any)
+		require.True(t, ok, "overview should be an object")
+		assert.Equal(t, run.Overview.Engine, overview["engine"])
+		assert.InDelta(t, float64(run.Overview.RunID), overview["run_id"], 0)
+		if run.SafeOutputs != nil {
+			assert.Contains(t, decoded, "safe_outputs")
+		}
+		if len(run.Errors) > 0 {
+			assert.Len(t, decoded["errors"], len(run.Errors))
+		}
+	}
+}
+
+func TestWriteAuditJUnitSampleRuns(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeAuditJUnit(&buf, sampleAuditRuns()))
+	output := buf.String()
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte(xml.Header)), "report should start with the XML header")
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report), "report should be well-formed XML")
+
+	assert.Equal(t, "testsuites", report.XMLName.Local)
+	assert.Equal(t, 3, report.Tests)
+	assert.Equal(t, 2, report.Failures)
+	assert.Equal(t, "120.000", report.Time)
+
+	require.Len(t, report.Suites, 2, "runs should be grouped into one suite per workflow")
+	triage := report.Suites[0]
+	assert.Equal(t, "Issue Triage", triage.Name)
+	assert.Equal(t, 2, triage.Tests)
+	assert.Equal(t, 1, triage.Failures)
+	assert.Equal(t, "2024-03-01T09:00:00", triage.Timestamp)
+	require.Len(t, triage.TestCases, 2)
+
+	passed := triage.TestCases[0]
+	assert.Equal(t, "run 101", passed.Name)
+	assert.Equal(t, "Issue Triage", passed.Classname)
+	assert.Equal(t, "90.000", passed.Time)
+	assert.Empty(t, passed.Failures)
+	assert.Contains(t, passed.SystemOut, "engine: claude")
+	assert.Contains(t, passed.SystemOut, "safe outputs: 2 (add_comment=1, add_labels=1)")
+	assert.Contains(t, passed.SystemOut, "tool calls: 5 across 2 tool(s)")
+
+	failed := triage.TestCases[1]
+	require.Len(t, failed.Failures, 2, "each error should be a failure")
+	assert.Equal(t, "MCP server github failed to start", failed.Failures[0].Message)
+	assert.Equal(t, "agent-stdio.log:12: MCP server github failed to start", failed.Failures[0].Text)
+	assert.Equal(t, "Agent exited with code 1 & <no output>", failed.Failures[1].Message, "special characters should round-trip")
+
+	docs := report.Suites[1]
+	assert.Equal(t, "Docs Updater", docs.Name)
+	require.Len(t, docs.TestCases, 1)
+	require.Len(t, docs.TestCases[0].Failures, 1, "a failed conclusion without errors should still be a failure")
+	assert.Equal(t, "timed_out", docs.TestCases[0].Failures[0].Type)
+	assert.Equal(t, "0.000", docs.TestCases[0].Time)
+
+	// Every test case and failure must carry the attributes JUnit consumers require
+	for _, suite := range report.Suites {
+		for _, testCase := range suite.TestCases {
+			assert.NotEmpty(t, testCase.Name)
+			assert.NotEmpty(t, testCase.Classname)
+			assert.NotEmpty(t, testCase.Time)
+			for _, failure := range testCase.Failures {
+				assert.NotEmpty(t, failure.Message)
+				assert.NotEmpty(t, failure.Type)
+			}
+		}
+	}
+	assert.Contains(t, output, `<testsuites name="gh-aw audit" tests="3" failures="2" errors="0" time="120.000">`)
+}
+
+func TestResolveAuditFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		jsonOutput bool
+		expected   string
+		errorText  string
+	}{
+		{name: "default is console", expected: AuditFormatConsole},
+		{name: "json flag", jsonOutput: true, expected: AuditFormatJSON},
+		{name: "format json", format: "json", expected: AuditFormatJSON},
+		{name: "format json with json flag", format: "json", jsonOutput: true, expected: AuditFormatJSON},
+		{name: "format junit", format: "junit", expected: AuditFormatJUnit},
+		{name: "junit with json flag", format: "junit", jsonOutput: true, errorText: "cannot be combined"},
+		{name: "unknown format", format: "xml", errorText: "invalid format 'xml'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := resolveAuditFormat(tt.format, tt.jsonOutput)
+			if tt.errorText != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorText)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
@@ -3,9 +3,12 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/stringutil"
@@ -13,8 +16,13 @@ import (
 
 // renderJSON outputs the audit data as JSON
 func renderJSON(data AuditData) error {
+	return writeAuditJSON(os.Stdout, data)
+}
+
+// writeAuditJSON writes the audit data as indented JSON
+func writeAuditJSON(w io.Writer, data AuditData) error {
 	auditReportLog.Print("Rendering audit report as JSON")
-	encoder := json.NewEncoder(os.Stdout)
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
 }
@@ -66,6 +74,13 @@ func renderConsole(data AuditData, logsPath string) {
 	fmt.Fprintln(os.Stderr)
 	renderMetrics(data.Metrics)
 
+	// Cost Estimate Section - how the estimated cost was derived
+	if data.CostEstimate != nil {
+		fmt.Fprintln(os.Stderr, console.FormatSectionHeader("Cost Estimate"))
+		fmt.Fprintln(os.Stderr)
+		renderCostEstimate(data.CostEstimate)
+	}
+
 	// Jobs Section - use new table rendering
 	if len(data.Jobs) > 0 {
 		auditReportLog.Printf("Rendering jobs table with %d jobs", len(data.Jobs))
@@ -110,6 +125,21 @@ func renderConsole(data AuditData, logsPath string) {
 		renderCreatedItemsTable(data.CreatedItems)
 	}
 
+	// Safe Outputs Section - records emitted by the agent and any malformed lines
+	if data.SafeOutputs != nil {
+		fmt.Fprintln(os.Stderr, console.FormatSectionHeader("Safe Outputs"))
+		fmt.Fprintln(os.Stderr)
+		renderSafeOutputsSummary(data.SafeOutputs)
+	}
+
+	// Patch Section - changes made during execution
+	if data.Patch != nil {
+		fmt.Fprintln(os.Stderr, console.FormatSectionHeader("Patch"))
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintf(os.Stderr, "  %d file(s) changed, +%d/-%d lines (%s)\n", data.Patch.FileCount, data.Patch.Additions, data.Patch.Deletions, strings.Join(data.Patch.PatchFiles, ", "))
+		fmt.Fprintln(os.Stderr)
+	}
+
 	// MCP Failures Section
 	if len(data.MCPFailures) > 0 {
 		fmt.Fprintln(os.Stderr, console.FormatSectionHeader("MCP Server Failures"))
@@ -197,6 +227,7 @@ func renderOverview(overview OverviewData) {
 	display := OverviewDisplay{
 		RunID:    overview.RunID,
 		Workflow: overview.WorkflowName,
+		Engine:   overview.Engine,
 		Status:   statusLine,
 		Duration: overview.Duration,
 		Event:    overview.Event,
@@ -213,6 +244,29 @@ func renderMetrics(metrics MetricsData) {
 	fmt.Fprint(os.Stderr, console.RenderStruct(metrics))
 }
 
+// renderCostEstimate renders the estimated cost of the run and the price it was based on
+func renderCostEstimate(estimate *CostEstimate) {
+	fmt.Fprintf(os.Stderr, "  Estimated Cost: $%.4f\n", estimate.EstimatedCost)
+	if estimate.Source == CostSourceEngine {
+		fmt.Fprintln(os.Stderr, "  Source: reported by the engine logs")
+	} else {
+		fmt.Fprintln(os.Stderr, "  Source: price table")
+	}
+	if estimate.Model != "" {
+		fmt.Fprintf(os.Stderr, "  Model: %s\n", estimate.Model)
+	}
+	if estimate.PriceKey != "" {
+		fmt.Fprintf(os.Stderr, "  Price: $%.2f per million tokens (%s)\n", estimate.PricePerMillionTokens, estimate.PriceKey)
+	}
+	if estimate.TokenUsage > 0 {
+		fmt.Fprintf(os.Stderr, "  Tokens: %s\n", console.FormatNumber(estimate.TokenUsage))
+	}
+	if estimate.CostPerMinute > 0 {
+		fmt.Fprintf(os.Stderr, "  Cost per Minute: $%.4f over %.1f minutes\n", estimate.CostPerMinute, estimate.DurationMinutes)
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
 // renderJobsTable renders the jobs as a table using console.RenderTable
 func renderJobsTable(jobs []JobData) {
 	auditReportLog.Printf("Rendering jobs table with %d jobs", len(jobs))
@@ -399,6 +453,24 @@ func renderRedactedDomainsAnalysis(analysis *RedactedDomainsAnalysis) {
 	}
 }
 
+// renderSafeOutputsSummary renders the safe output counts by type followed by the
+// safe_output.jsonl lines that could not be decoded
+func renderSafeOutputsSummary(summary *SafeOutputsSummary) {
+	fmt.Fprintf(os.Stderr, "  Total: %d\n", summary.Total)
+	types := make([]string, 0, len(summary.ByType))
+	for outputType := range summary.ByType {
+		types = append(types, outputType)
+	}
+	sort.Strings(types)
+	for _, outputType := range types {
+		fmt.Fprintf(os.Stderr, "  • %s: %d\n", outputType, summary.ByType[outputType])
+	}
+	for _, lineErr := range summary.ParseErrors {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("safe_output.jsonl line %d: %s", lineErr.Line, lineErr.Error)))
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
 // renderCreatedItemsTable renders the list of items created in GitHub by safe output handlers
 // as a table with clickable URLs for easy auditing.
 func renderCreatedItemsTable(items []CreatedItemReport) {
@@ -33,6 +33,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
 )
 
 var compileBatchOperationsLog = logger.New("cli:compile_batch_operations")
@@ -144,6 +145,8 @@ func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, ver
 			} else {
 				fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Removed orphaned lock file: "+filepath.Base(orphanedFile)))
 			}
+			// Remove the incremental compilation sidecar along with its lock file
+			_ = os.Remove(workflow.IncrementalCachePath(orphanedFile))
 		}
 		if verbose {
 			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Purged %d orphaned .lock.yml files", len(orphanedFiles))))
@@ -291,6 +291,60 @@ func TestCompileWorkflows_DependabotValidation(t *testing.T) {
 	}
 }
 
+// TestCompileWorkflows_TargetValidation tests target flag validation
+// Uses the fast validateCompileConfig function instead of full compilation
+func TestCompileWorkflows_TargetValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      CompileConfig
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:   "github target",
+			config: CompileConfig{Target: "github"},
+		},
+		{
+			name:   "gitlab target",
+			config: CompileConfig{Target: "gitlab"},
+		},
+		{
+			name:        "unknown target",
+			config:      CompileConfig{Target: "jenkins"},
+			expectError: true,
+			errorMsg:    "invalid --target 'jenkins'",
+		},
+		{
+			name:        "gitlab target with actionlint",
+			config:      CompileConfig{Target: "gitlab", Actionlint: true},
+			expectError: true,
+			errorMsg:    "--actionlint flag cannot be used with --target gitlab",
+		},
+		{
+			name:        "gitlab target with dependabot",
+			config:      CompileConfig{Target: "gitlab", Dependabot: true},
+			expectError: true,
+			errorMsg:    "--dependabot flag cannot be used with --target gitlab",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got nil")
+				} else if !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error containing %q, got %q", tt.errorMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
 // TestCompileWorkflows_PurgeValidation tests purge flag validation
 // Uses the fast validateCompileConfig function instead of full compilation
 func TestCompileWorkflows_PurgeValidation(t *testing.T) {
@@ -311,6 +365,38 @@ func TestCompileWorkflows_PurgeValidation(t *testing.T) {
 	}
 }
 
+// TestCompileWorkflows_CheckValidation tests check flag validation
+// Uses the fast validateCompileConfig function instead of full compilation
+func TestCompileWorkflows_CheckValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   CompileConfig
+		errorMsg string
+	}{
+		{name: "check with watch", config: CompileConfig{Check: true, Watch: true}, errorMsg: "cannot be used with --watch"},
+		{name: "check with purge", config: CompileConfig{Check: true, Purge: true}, errorMsg: "cannot be used with --purge"},
+		{name: "check with dependabot", config: CompileConfig{Check: true, Dependabot: true}, errorMsg: "cannot be used with --purge or --dependabot"},
+		{name: "check alone", config: CompileConfig{Check: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+			if tt.errorMsg == "" {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Error("Expected error but got nil")
+			} else if !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error containing %q, got %q", tt.errorMsg, err.Error())
+			}
+		})
+	}
+}
+
 // TestCompileWorkflows_WorkflowDirValidation tests workflow directory validation
 // Uses the fast validateCompileConfig function instead of full compilation
 func TestCompileWorkflows_WorkflowDirValidation(t *testing.T) {
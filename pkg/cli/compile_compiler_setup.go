@@ -127,6 +127,24 @@ func configureCompilerFlags(compiler *workflow.Compiler, config CompileConfig) {
 	// Set strict mode if specified
 	compiler.SetStrictMode(config.Strict)
 
+	// Annotate the generated YAML with source provenance comments
+	compiler.SetExplain(config.Explain)
+	if config.Explain {
+		compileCompilerSetupLog.Print("Explain mode enabled: lock files will include source provenance comments")
+	}
+
+	// Skip unchanged workflows in incremental mode
+	compiler.SetIncremental(config.Incremental)
+	if config.Incremental {
+		compileCompilerSetupLog.Print("Incremental mode enabled: unchanged workflows will be skipped")
+	}
+
+	// Set the CI system to compile for
+	if config.Target != "" {
+		compiler.SetTarget(workflow.CompileTarget(config.Target))
+		compileCompilerSetupLog.Printf("Compile target: %s", config.Target)
+	}
+
 	// Set trial mode if specified
 	if config.TrialMode {
 		compileCompilerSetupLog.Printf("Enabling trial mode: repoSlug=%s", config.TrialLogicalRepoSlug)
@@ -33,6 +33,10 @@ type CompileConfig struct {
 	ActionTag              string   // Override action SHA or tag for actions/setup (overrides action-mode to release)
 	Stats                  bool     // Display statistics table sorted by file size
 	FailFast               bool     // Stop at first error instead of collecting all errors
+	Incremental            bool     // Skip workflows whose sources are unchanged since the last compilation
+	Check                  bool     // Validate workflows without writing any files (for pre-commit hooks)
+	Explain                bool     // Annotate generated lock files with source provenance comments
+	Target                 string   // CI system to compile for: github (default) or gitlab (experimental)
 }
 
 // WorkflowFailure represents a failed workflow with its error count
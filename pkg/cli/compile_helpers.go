@@ -241,39 +241,21 @@ func compileModifiedFiles(compiler *workflow.Compiler, files []string, verbose b
 }
 
 // compileModifiedFilesWithDependencies compiles modified files and their dependencies using the dependency graph
-func compileModifiedFilesWithDependencies(compiler *workflow.Compiler, depGraph *DependencyGraph, files []string, verbose bool) {
+func compileModifiedFilesWithDependencies(compiler *workflow.Compiler, depGraph *DependencyGraph, files []string, watchedFile string, verbose bool) {
 	if len(files) == 0 {
 		return
 	}
 
-	// Clear screen before emitting new output in watch mode
-	console.ClearScreen()
-
 	// Use dependency graph to determine what needs to be recompiled
-	var workflowsToCompile []string
-	uniqueWorkflows := make(map[string]bool)
-
-	for _, modifiedFile := range files {
-		compileHelpersLog.Printf("Processing modified file: %s", modifiedFile)
-
-		// Update the workflow in the dependency graph
-		if err := depGraph.UpdateWorkflow(modifiedFile, compiler); err != nil {
-			compileHelpersLog.Printf("Warning: failed to update workflow in dependency graph: %v", err)
-		}
-
-		// Get affected workflows from dependency graph
-		affected := depGraph.GetAffectedWorkflows(modifiedFile)
-		compileHelpersLog.Printf("File %s affects %d workflow(s)", modifiedFile, len(affected))
-
-		// Add to unique set
-		for _, workflow := range affected {
-			if !uniqueWorkflows[workflow] {
-				uniqueWorkflows[workflow] = true
-				workflowsToCompile = append(workflowsToCompile, workflow)
-			}
-		}
+	workflowsToCompile := resolveWatchRecompileTargets(compiler, depGraph, files, watchedFile)
+	if len(workflowsToCompile) == 0 {
+		compileHelpersLog.Printf("No workflows affected by %d change(s)", len(files))
+		return
 	}
 
+	// Clear screen before emitting new output in watch mode
+	console.ClearScreen()
+
 	fmt.Fprintln(os.Stderr, "Watching for file changes")
 	if verbose {
 		fmt.Fprintln(os.Stderr, console.FormatProgressMessage(fmt.Sprintf("Recompiling %d workflow(s) affected by %d change(s)...", len(workflowsToCompile), len(files))))
@@ -93,11 +93,16 @@ func compileSpecificFiles(
 		result.Workflow = filepath.Base(resolvedFile)
 
 		// Compile regular workflow file (disable per-file security tools)
-		fileResult := compileWorkflowFile(
-			compiler, resolvedFile, config.Verbose, config.JSONOutput,
-			config.NoEmit, false, false, false, // Disable per-file security tools
-			config.Strict, shouldValidate,
-		)
+		var fileResult compileWorkflowFileResult
+		if config.Check {
+			fileResult = checkWorkflowFile(compiler, resolvedFile, config.JSONOutput)
+		} else {
+			fileResult = compileWorkflowFile(
+				compiler, resolvedFile, config.Verbose, config.JSONOutput,
+				config.NoEmit, false, false, false, // Disable per-file security tools
+				config.Strict, shouldValidate,
+			)
+		}
 
 		if !fileResult.success {
 			errorCount++
@@ -251,11 +256,16 @@ func compileAllFilesInDirectory(
 		stats.Total++
 
 		// Compile regular workflow file (disable per-file security tools)
-		fileResult := compileWorkflowFile(
-			compiler, file, config.Verbose, config.JSONOutput,
-			config.NoEmit, false, false, false, // Disable per-file security tools
-			config.Strict, shouldValidate,
-		)
+		var fileResult compileWorkflowFileResult
+		if config.Check {
+			fileResult = checkWorkflowFile(compiler, file, config.JSONOutput)
+		} else {
+			fileResult = compileWorkflowFile(
+				compiler, file, config.Verbose, config.JSONOutput,
+				config.NoEmit, false, false, false, // Disable per-file security tools
+				config.Strict, shouldValidate,
+			)
+		}
 
 		if !fileResult.success {
 			errorCount++
@@ -403,6 +413,11 @@ func runPostProcessing(
 	config CompileConfig,
 	successCount int,
 ) error {
+	// Check mode never writes files, so there is nothing to post-process
+	if config.Check {
+		return nil
+	}
+
 	// Get action cache
 	actionCache := compiler.GetSharedActionCache()
 
@@ -444,6 +459,11 @@ func runPostProcessingForDirectory(
 	gitRoot string,
 	successCount int,
 ) error {
+	// Check mode never writes files, so there is nothing to post-process
+	if config.Check {
+		return nil
+	}
+
 	// Get action cache
 	actionCache := compiler.GetSharedActionCache()
 
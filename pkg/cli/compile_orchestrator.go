@@ -36,6 +36,11 @@ func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.Wo
 		return nil, err
 	}
 
+	// Check mode validates without emitting lock files
+	if config.Check {
+		config.NoEmit = true
+	}
+
 	// Initialize actionlint statistics if actionlint is enabled
 	if config.Actionlint && !config.NoEmit {
 		initActionlintStats()
@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 
 	"github.com/github/gh-aw/pkg/logger"
-	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/workflow"
 	"github.com/goccy/go-yaml"
 )
@@ -65,7 +64,7 @@ func CompileWorkflowWithValidation(compiler *workflow.Compiler, filePath string,
 	}
 
 	// Always validate that the generated lock file is valid YAML (CLI requirement)
-	lockFile := stringutil.MarkdownToLockFile(filePath)
+	lockFile := compiler.OutputFile(filePath)
 	if _, err := os.Stat(lockFile); err != nil {
 		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit mode)")
 		// Lock file doesn't exist (likely due to no-emit), skip YAML validation
@@ -133,7 +132,7 @@ func CompileWorkflowDataWithValidation(compiler *workflow.Compiler, workflowData
 	}
 
 	// Always validate that the generated lock file is valid YAML (CLI requirement)
-	lockFile := stringutil.MarkdownToLockFile(filePath)
+	lockFile := compiler.OutputFile(filePath)
 	if _, err := os.Stat(lockFile); err != nil {
 		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit mode)")
 		// Lock file doesn't exist (likely due to no-emit), skip YAML validation
@@ -212,6 +211,48 @@ func validateCompileConfig(config CompileConfig) error {
 		return errors.New("--purge flag can only be used when compiling all markdown files (no specific files specified)")
 	}
 
+	// Validate check flag usage: check mode never writes files
+	if config.Check {
+		if config.Watch {
+			compileValidationLog.Print("Config validation failed: check flag with watch")
+			return errors.New("--check flag cannot be used with --watch")
+		}
+		if config.Purge || config.Dependabot {
+			compileValidationLog.Print("Config validation failed: check flag with file-writing flags")
+			return errors.New("--check flag cannot be used with --purge or --dependabot")
+		}
+	}
+
+	// Validate target flag usage: lock file tooling does not apply to other CI systems
+	if config.Target != "" {
+		target := workflow.CompileTarget(config.Target)
+		if !target.IsValid() {
+			compileValidationLog.Printf("Config validation failed: invalid target: %s", config.Target)
+			return fmt.Errorf("invalid --target '%s': must be 'github' or 'gitlab'", config.Target)
+		}
+		if target == workflow.CompileTargetGitLab {
+			for _, f := range []struct {
+				name    string
+				enabled bool
+			}{
+				{"--actionlint", config.Actionlint},
+				{"--zizmor", config.Zizmor},
+				{"--poutine", config.Poutine},
+				{"--dependabot", config.Dependabot},
+				{"--purge", config.Purge},
+				{"--stats", config.Stats},
+				{"--incremental", config.Incremental},
+				{"--explain", config.Explain},
+				{"--trial", config.TrialMode},
+			} {
+				if f.enabled {
+					compileValidationLog.Printf("Config validation failed: %s with gitlab target", f.name)
+					return fmt.Errorf("%s flag cannot be used with --target gitlab", f.name)
+				}
+			}
+		}
+	}
+
 	// Validate workflow directory path
 	if config.WorkflowDir != "" && filepath.IsAbs(config.WorkflowDir) {
 		compileValidationLog.Printf("Config validation failed: absolute path in workflowDir: %s", config.WorkflowDir)
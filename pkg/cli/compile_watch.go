@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -96,6 +97,27 @@ func watchAndCompileWorkflows(markdownFile string, compiler *workflow.Compiler,
 		compileWatchLog.Printf("Failed to walk subdirectories: %v", err)
 	}
 
+	// Also watch the directories of imports and includes that live outside the workflows
+	// directory. The set is refreshed after each recompile since edits can add imports.
+	var dependencyWatchMu sync.Mutex
+	watchedDependencyDirs := make(map[string]bool)
+	watchDependencyDirs := func() {
+		dependencyWatchMu.Lock()
+		defer dependencyWatchMu.Unlock()
+		for _, dir := range depGraph.ExternalDependencyDirs() {
+			if watchedDependencyDirs[dir] {
+				continue
+			}
+			if err := addWatchPath(dir); err != nil {
+				compileWatchLog.Printf("Failed to watch dependency directory %s: %v", dir, err)
+				continue
+			}
+			watchedDependencyDirs[dir] = true
+			compileWatchLog.Printf("Watching dependency directory: %s", dir)
+		}
+	}
+	watchDependencyDirs()
+
 	// Always emit the begin pattern for task integration
 	if markdownFile != "" {
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Watching for file changes to %s...", markdownFile)))
@@ -166,13 +188,8 @@ func watchAndCompileWorkflows(markdownFile string, compiler *workflow.Compiler,
 				continue
 			}
 
-			// Only process markdown files and ignore lock files
-			if !strings.HasSuffix(event.Name, ".md") {
-				continue
-			}
-
-			// If watching a specific file, only process that file
-			if markdownFile != "" && event.Name != markdownFile {
+			// Ignore files written by the compiler itself
+			if isCompilerOutputFile(event.Name) {
 				continue
 			}
 
@@ -184,8 +201,13 @@ func watchAndCompileWorkflows(markdownFile string, compiler *workflow.Compiler,
 			// Handle file operations
 			switch {
 			case event.Has(fsnotify.Remove):
-				// Handle file deletion
-				handleFileDeleted(event.Name, verbose)
+				if !strings.HasSuffix(event.Name, ".md") {
+					continue
+				}
+				// Handle file deletion (only the watched workflow's lock file in single-file mode)
+				if markdownFile == "" || event.Name == markdownFile {
+					handleFileDeleted(event.Name, verbose)
+				}
 				// Remove from dependency graph
 				depGraph.RemoveWorkflow(event.Name)
 			case event.Has(fsnotify.Write) || event.Has(fsnotify.Create):
@@ -207,8 +229,9 @@ func watchAndCompileWorkflows(markdownFile string, compiler *workflow.Compiler,
 					modifiedFiles = make(map[string]struct{})
 					debounceMu.Unlock()
 
-					// Compile the modified files using dependency graph
-					compileModifiedFilesWithDependencies(compiler, depGraph, filesToCompile, verbose)
+					// Compile the workflows affected by the modified files using dependency graph
+					compileModifiedFilesWithDependencies(compiler, depGraph, filesToCompile, markdownFile, verbose)
+					watchDependencyDirs()
 				})
 				debounceMu.Unlock()
 			}
@@ -233,3 +256,47 @@ func watchAndCompileWorkflows(markdownFile string, compiler *workflow.Compiler,
 		}
 	}
 }
+
+// isCompilerOutputFile reports whether a path is written by the compiler (lock files and
+// invalid YAML dumps) and must not trigger a recompile
+func isCompilerOutputFile(path string) bool {
+	return strings.HasSuffix(path, ".lock.yml") ||
+		strings.HasSuffix(path, ".invalid.yml")
+}
+
+// resolveWatchRecompileTargets maps changed files to the sorted list of top-level workflows
+// that must be recompiled. Changed workflows are re-scanned so that new imports and
+// includes are tracked. Non-markdown files, and markdown files outside the workflows
+// directory, are only considered when a workflow imports or includes them. When watchedFile is set, only that workflow is returned, and only if it
+// is affected by the changes.
+func resolveWatchRecompileTargets(compiler *workflow.Compiler, depGraph *DependencyGraph, changedFiles []string, watchedFile string) []string {
+	targets := make(map[string]bool)
+	for _, changedFile := range changedFiles {
+		compileWatchLog.Printf("Processing modified file: %s", changedFile)
+
+		if strings.HasSuffix(changedFile, ".md") && (depGraph.isInWorkflowsDir(changedFile) || depGraph.IsDependency(changedFile)) {
+			// Update the workflow in the dependency graph
+			if err := depGraph.UpdateWorkflow(changedFile, compiler); err != nil {
+				compileWatchLog.Printf("Warning: failed to update workflow in dependency graph: %v", err)
+			}
+		} else if !depGraph.IsDependency(changedFile) {
+			compileWatchLog.Printf("Ignoring change to untracked file: %s", changedFile)
+			continue
+		}
+
+		affected := depGraph.GetAffectedWorkflows(changedFile)
+		compileWatchLog.Printf("File %s affects %d workflow(s)", changedFile, len(affected))
+		for _, workflowPath := range affected {
+			if watchedFile == "" || workflowPath == watchedFile {
+				targets[workflowPath] = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(targets))
+	for workflowPath := range targets {
+		result = append(result, workflowPath)
+	}
+	sort.Strings(result)
+	return result
+}
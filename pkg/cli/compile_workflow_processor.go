@@ -14,6 +14,7 @@
 //
 // Workflow Processing:
 //   - processWorkflowFile() - Process a single workflow markdown file
+//   - checkWorkflowFile() - Validate a single workflow file without writing files
 //   - collectLockFilesForLinting() - Collect lock files for batch linting
 //
 // These functions abstract per-file processing, allowing the main compile
@@ -145,3 +146,52 @@ func compileWorkflowFile(
 	compileWorkflowProcessorLog.Printf("Successfully processed workflow file: %s", resolvedFile)
 	return result
 }
+
+// checkWorkflowFile validates a single workflow file without writing any files (--check)
+func checkWorkflowFile(compiler *workflow.Compiler, resolvedFile string, jsonOutput bool) compileWorkflowFileResult {
+	compileWorkflowProcessorLog.Printf("Checking workflow file: %s", resolvedFile)
+
+	result := compileWorkflowFileResult{
+		validationResult: ValidationResult{
+			Workflow: filepath.Base(resolvedFile),
+			Valid:    true,
+			Errors:   []CompileValidationError{},
+			Warnings: []CompileValidationError{},
+		},
+	}
+
+	// Use the same identifier as compilation so schedule validation matches
+	relPath, err := getRepositoryRelativePath(resolvedFile)
+	if err != nil {
+		relPath = filepath.Base(resolvedFile)
+	}
+	compiler.SetWorkflowIdentifier(relPath)
+
+	if err := compiler.ValidateWorkflow(resolvedFile); err != nil {
+		var sharedErr *workflow.SharedWorkflowError
+		if errors.As(err, &sharedErr) {
+			if !jsonOutput {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(sharedErr.Error()))
+			}
+			result.validationResult.Warnings = append(result.validationResult.Warnings, CompileValidationError{
+				Type:    "shared_workflow",
+				Message: "Skipped: Shared workflow component (missing 'on' field)",
+			})
+			result.success = true
+			return result
+		}
+
+		result.validationResult.Valid = false
+		result.validationResult.Errors = append(result.validationResult.Errors, CompileValidationError{
+			Type:    "validation_error",
+			Message: err.Error(),
+		})
+		return result
+	}
+
+	if !jsonOutput {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(console.ToRelativePath(resolvedFile)))
+	}
+	result.success = true
+	return result
+}
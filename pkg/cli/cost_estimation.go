@@ -0,0 +1,174 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (cost_estimation.go) estimates the cost of workflow runs from
+// their token usage and a per-model price table.
+//
+// Key responsibilities:
+//   - Providing the default per-model price table
+//   - Loading repository overrides from .github/aw/price-table.json
+//   - Matching the model recorded in aw_info.json against the price table
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var costEstimationLog = logger.New("cli:cost_estimation")
+
+// priceTableFile is the repository-relative path of the price table override
+const priceTableFile = ".github/aw/price-table.json"
+
+// Cost estimate sources reported in CostEstimate.Source
+const (
+	CostSourceEngine     = "engine"
+	CostSourcePriceTable = "price_table"
+)
+
+// PriceTable maps a model name, model prefix, or engine ID to its price in USD
+// per million tokens. Engine logs only report a combined token count, so the
+// prices are blended input/output rates.
+type PriceTable map[string]float64
+
+// defaultPriceTable holds approximate blended list prices, weighted towards input
+// tokens as agentic runs mostly consist of context. Engine IDs are used when
+// aw_info.json does not record a model and map to the engine's default model.
+var defaultPriceTable = PriceTable{
+	"claude-opus-4":   20.0,
+	"claude-sonnet-4": 4.5,
+	"claude-haiku-4":  1.5,
+	"gpt-5":           2.5,
+	"gpt-5-mini":      0.5,
+	"gpt-4.1":         3.0,
+	"claude":          4.5,
+	"codex":           2.5,
+	"copilot":         4.5,
+}
+
+// CostEstimate is the estimated cost of a single run
+type CostEstimate struct {
+	Model                 string  `json:"model,omitempty"`
+	PriceKey              string  `json:"price_key,omitempty"`
+	TokenUsage            int     `json:"token_usage"`
+	PricePerMillionTokens float64 `json:"price_per_million_tokens,omitempty"`
+	EstimatedCost         float64 `json:"estimated_cost"`
+	Source                string  `json:"source"`
+	DurationMinutes       float64 `json:"duration_minutes,omitempty"`
+	CostPerMinute         float64 `json:"cost_per_minute,omitempty"`
+}
+
+// loadPriceTable returns the default price table with the entries of the JSON
+// file at path applied on top. A missing file returns the defaults.
+func loadPriceTable(path string) (PriceTable, error) {
+	table := make(PriceTable, len(defaultPriceTable))
+	for key, price := range defaultPriceTable {
+		table[key] = price
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return table, nil
+		}
+		return nil, fmt.Errorf("failed to read price table: %w", err)
+	}
+
+	var overrides PriceTable
+	if err := json.Unmarshal(content, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse price table %s: %w", path, err)
+	}
+	for key, price := range overrides {
+		if price < 0 {
+			return nil, fmt.Errorf("price table %s: price for '%s' must not be negative", path, key)
+		}
+		table[strings.ToLower(key)] = price
+	}
+
+	costEstimationLog.Printf("Loaded %d price table overrides from %s", len(overrides), path)
+	return table, nil
+}
+
+// resolvePriceTable loads the price table of the current repository, falling
+// back to the defaults when the override cannot be read
+func resolvePriceTable() PriceTable {
+	root, err := findGitRoot()
+	if err != nil {
+		root = "."
+	}
+	table, err := loadPriceTable(filepath.Join(root, priceTableFile))
+	if err != nil {
+		costEstimationLog.Printf("Using default price table: %v", err)
+		return defaultPriceTable
+	}
+	return table
+}
+
+// lookup returns the price for a model, matching exactly first and then by the
+// longest key the model starts with (e.g. "claude-sonnet-4" for
+// "claude-sonnet-4-20250514"). Falls back to the engine ID.
+func (t PriceTable) lookup(model, engineID string) (string, float64, bool) {
+	model = strings.ToLower(model)
+	if model != "" {
+		if price, ok := t[model]; ok {
+			return model, price, true
+		}
+		bestKey := ""
+		for key := range t {
+			if strings.HasPrefix(model, key) && len(key) > len(bestKey) {
+				bestKey = key
+			}
+		}
+		if bestKey != "" {
+			return bestKey, t[bestKey], true
+		}
+	}
+
+	engineID = strings.ToLower(engineID)
+	if price, ok := t[engineID]; ok && engineID != "" {
+		return engineID, price, true
+	}
+	return "", 0, false
+}
+
+// estimateRunCost estimates the cost of a run. A cost reported by the engine
+// logs is kept as is; otherwise the token usage is priced with the model from
+// aw_info.json. Returns nil when neither a reported cost nor a price is available.
+func estimateRunCost(awInfo *AwInfo, tokenUsage int, reportedCost float64, duration time.Duration, table PriceTable) *CostEstimate {
+	estimate := &CostEstimate{TokenUsage: tokenUsage}
+	engineID := ""
+	if awInfo != nil {
+		estimate.Model = awInfo.Model
+		engineID = awInfo.EngineID
+	}
+
+	if key, price, ok := table.lookup(estimate.Model, engineID); ok {
+		estimate.PriceKey = key
+		estimate.PricePerMillionTokens = price
+	}
+
+	switch {
+	case reportedCost > 0:
+		estimate.EstimatedCost = reportedCost
+		estimate.Source = CostSourceEngine
+	case tokenUsage > 0 && estimate.PriceKey != "":
+		estimate.EstimatedCost = float64(tokenUsage) / 1_000_000 * estimate.PricePerMillionTokens
+		estimate.Source = CostSourcePriceTable
+	default:
+		return nil
+	}
+
+	if duration > 0 {
+		estimate.DurationMinutes = duration.Minutes()
+		estimate.CostPerMinute = estimate.EstimatedCost / estimate.DurationMinutes
+	}
+
+	costEstimationLog.Printf("Estimated run cost: model=%s, price_key=%s, tokens=%d, cost=$%.4f, source=%s",
+		estimate.Model, estimate.PriceKey, tokenUsage, estimate.EstimatedCost, estimate.Source)
+	return estimate
+}
@@ -0,0 +1,158 @@
+//go:build !integration
+
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceTableLookup(t *testing.T) {
+	table := PriceTable{
+		"claude-sonnet-4":   4.5,
+		"claude-sonnet-4-5": 5.0,
+		"gpt-5":             2.5,
+		"copilot":           3.0,
+	}
+
+	tests := []struct {
+		name        string
+		model       string
+		engineID    string
+		expectedKey string
+		expected    float64
+		found       bool
+	}{
+		{name: "exact model", model: "gpt-5", expectedKey: "gpt-5", expected: 2.5, found: true},
+		{name: "case insensitive", model: "GPT-5", expectedKey: "gpt-5", expected: 2.5, found: true},
+		{name: "longest prefix", model: "claude-sonnet-4-5-20250929", expectedKey: "claude-sonnet-4-5", expected: 5.0, found: true},
+		{name: "shorter prefix", model: "claude-sonnet-4-20250514", expectedKey: "claude-sonnet-4", expected: 4.5, found: true},
+		{name: "engine fallback without model", engineID: "copilot", expectedKey: "copilot", expected: 3.0, found: true},
+		{name: "engine fallback for unknown model", model: "o3", engineID: "copilot", expectedKey: "copilot", expected: 3.0, found: true},
+		{name: "unknown model and engine", model: "o3", engineID: "custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, price, ok := table.lookup(tt.model, tt.engineID)
+			assert.Equal(t, tt.found, ok)
+			assert.Equal(t, tt.expectedKey, key)
+			assert.InDelta(t, tt.expected, price, 1e-9)
+		})
+	}
+}
+
+func TestEstimateRunCost(t *testing.T) {
+	table := PriceTable{"claude-sonnet-4": 3.0}
+	awInfo := &AwInfo{EngineID: "claude", Model: "claude-sonnet-4"}
+
+	t.Run("prices token usage", func(t *testing.T) {
+		estimate := estimateRunCost(awInfo, 250_000, 0, 5*time.Minute, table)
+		require.NotNil(t, estimate)
+		assert.Equal(t, CostSourcePriceTable, estimate.Source)
+		assert.Equal(t, "claude-sonnet-4", estimate.PriceKey)
+		assert.InDelta(t, 0.75, estimate.EstimatedCost, 1e-9, "250k tokens at $3/M should cost $0.75")
+		assert.InDelta(t, 5.0, estimate.DurationMinutes, 1e-9)
+		assert.InDelta(t, 0.15, estimate.CostPerMinute, 1e-9)
+	})
+
+	t.Run("keeps cost reported by the engine", func(t *testing.T) {
+		estimate := estimateRunCost(awInfo, 250_000, 1.25, 0, table)
+		require.NotNil(t, estimate)
+		assert.Equal(t, CostSourceEngine, estimate.Source)
+		assert.InDelta(t, 1.25, estimate.EstimatedCost, 1e-9)
+		assert.Zero(t, estimate.CostPerMinute, "Cost per minute needs a duration")
+	})
+
+	t.Run("no price for model", func(t *testing.T) {
+		assert.Nil(t, estimateRunCost(&AwInfo{EngineID: "custom", Model: "o3"}, 250_000, 0, 0, table))
+	})
+
+	t.Run("no token usage", func(t *testing.T) {
+		assert.Nil(t, estimateRunCost(awInfo, 0, 0, time.Minute, table))
+	})
+
+	t.Run("no aw_info", func(t *testing.T) {
+		assert.Nil(t, estimateRunCost(nil, 250_000, 0, 0, table))
+	})
+}
+
+func TestLoadPriceTable(t *testing.T) {
+	dir := testutil.TempDir(t, "price-table-*")
+
+	t.Run("missing file returns defaults", func(t *testing.T) {
+		table, err := loadPriceTable(filepath.Join(dir, "missing.json"))
+		require.NoError(t, err)
+		assert.Equal(t, defaultPriceTable, table)
+	})
+
+	t.Run("overrides are applied on top of defaults", func(t *testing.T) {
+		writeRunArtifact(t, dir, "prices.json", `{"claude-sonnet-4": 3.0, "My-Model": 1.5}`)
+		table, err := loadPriceTable(filepath.Join(dir, "prices.json"))
+		require.NoError(t, err)
+		assert.InDelta(t, 3.0, table["claude-sonnet-4"], 1e-9)
+		assert.InDelta(t, 1.5, table["my-model"], 1e-9)
+		assert.InDelta(t, defaultPriceTable["gpt-5"], table["gpt-5"], 1e-9)
+		assert.InDelta(t, 4.5, defaultPriceTable["claude-sonnet-4"], 1e-9, "Defaults should not be modified")
+	})
+
+	t.Run("malformed file", func(t *testing.T) {
+		writeRunArtifact(t, dir, "bad.json", `{"gpt-5": "cheap"}`)
+		_, err := loadPriceTable(filepath.Join(dir, "bad.json"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse price table")
+	})
+
+	t.Run("negative price", func(t *testing.T) {
+		writeRunArtifact(t, dir, "negative.json", `{"gpt-5": -1}`)
+		_, err := loadPriceTable(filepath.Join(dir, "negative.json"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must not be negative")
+	})
+}
+
+func TestBuildAuditDataEstimatesCost(t *testing.T) {
+	dir := testutil.TempDir(t, "audit-cost-estimate-*")
+	writeRunArtifact(t, dir, "aw_info.json", `{"engine_id":"claude","model":"claude-sonnet-4-20250514"}`)
+
+	processedRun := createTestProcessedRun(func(pr *ProcessedRun) {
+		pr.Run.LogsPath = dir
+		pr.Run.TokenUsage = 2_000_000
+		pr.Run.EstimatedCost = 0
+		pr.Run.Duration = 10 * time.Minute
+	})
+	auditData := buildAuditData(processedRun, LogMetrics{}, nil)
+
+	require.NotNil(t, auditData.CostEstimate, "Cost should be estimated from the token usage")
+	assert.Equal(t, CostSourcePriceTable, auditData.CostEstimate.Source)
+	assert.Equal(t, "claude-sonnet-4-20250514", auditData.CostEstimate.Model)
+	assert.InDelta(t, 9.0, auditData.CostEstimate.EstimatedCost, 1e-9, "2M tokens at $4.50/M should cost $9")
+	assert.InDelta(t, 9.0, auditData.Metrics.EstimatedCost, 1e-9, "Metrics should carry the estimate")
+	assert.InDelta(t, 0.9, auditData.CostEstimate.CostPerMinute, 1e-9)
+}
+
+func TestBuildLogsDataAggregatesEstimatedCost(t *testing.T) {
+	claudeDir := testutil.TempDir(t, "logs-cost-claude-*")
+	writeRunArtifact(t, claudeDir, "aw_info.json", `{"engine_id":"claude","model":"claude-sonnet-4"}`)
+	codexDir := testutil.TempDir(t, "logs-cost-codex-*")
+	writeRunArtifact(t, codexDir, "aw_info.json", `{"engine_id":"codex"}`)
+
+	processedRuns := []ProcessedRun{
+		{Run: WorkflowRun{DatabaseID: 1, LogsPath: claudeDir, TokenUsage: 1_000_000}},
+		{Run: WorkflowRun{DatabaseID: 2, LogsPath: codexDir, TokenUsage: 400_000}},
+		{Run: WorkflowRun{DatabaseID: 3, LogsPath: claudeDir, TokenUsage: 1_000_000, EstimatedCost: 0.5}},
+	}
+
+	data := buildLogsData(processedRuns, t.TempDir(), nil)
+
+	require.Len(t, data.Runs, 3)
+	assert.InDelta(t, 4.5, data.Runs[0].EstimatedCost, 1e-9, "1M tokens at $4.50/M")
+	assert.InDelta(t, 1.0, data.Runs[1].EstimatedCost, 1e-9, "400k tokens at the codex rate of $2.50/M")
+	assert.InDelta(t, 0.5, data.Runs[2].EstimatedCost, 1e-9, "Engine reported costs should be kept")
+	assert.InDelta(t, 6.0, data.Summary.TotalCost, 1e-9, "Total cost should aggregate all runs")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
@@ -151,6 +152,19 @@ func (g *DependencyGraph) extractImportsFromFile(workflowPath string) ([]string,
 	}
 
 	imports := g.extractImportsFromFrontmatter(workflowPath, result.Frontmatter)
+
+	// Track @include directives in the markdown body as well, so that edits to
+	// included fragments recompile the workflows that use them
+	includes, err := collectLocalIncludeDependencies(result.Markdown, filepath.Dir(cleanPath), false)
+	if err != nil {
+		depGraphLog.Printf("Failed to collect includes from %s: %v", cleanPath, err)
+	}
+	for _, include := range includes {
+		if !slices.Contains(imports, include.SourcePath) {
+			imports = append(imports, include.SourcePath)
+		}
+	}
+
 	depGraphLog.Printf("Extracted %d imports from %s", len(imports), workflowPath)
 	return imports, nil
 }
@@ -265,6 +279,11 @@ func (g *DependencyGraph) GetAffectedWorkflows(modifiedPath string) []string {
 	depGraphLog.Printf("Finding affected workflows for modified file: %s", modifiedPath)
 
 	node, exists := g.nodes[modifiedPath]
+	if !exists && g.IsDependency(modifiedPath) {
+		// Tracked import or include that is not itself a workflow (e.g., a non-markdown include)
+		depGraphLog.Printf("Modified file is a tracked dependency: %s", modifiedPath)
+		return g.findAffectedTopLevelWorkflows(modifiedPath)
+	}
 	if !exists {
 		// File not in graph - it might be a new file
 		// If it's a top-level workflow, just compile it
@@ -339,6 +358,36 @@ func (g *DependencyGraph) getAllTopLevelWorkflows() []string {
 	return topLevel
 }
 
+// IsDependency reports whether the file is imported or included by any workflow in the graph
+func (g *DependencyGraph) IsDependency(path string) bool {
+	return len(g.reverseImports[path]) > 0
+}
+
+// ExternalDependencyDirs returns the sorted directories outside the workflows directory
+// that contain tracked imports or includes
+func (g *DependencyGraph) ExternalDependencyDirs() []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for path := range g.reverseImports {
+		if g.isInWorkflowsDir(path) {
+			continue
+		}
+		dir := filepath.Dir(path)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	slices.Sort(dirs)
+	return dirs
+}
+
+// isInWorkflowsDir reports whether the path is inside the workflows directory
+func (g *DependencyGraph) isInWorkflowsDir(path string) bool {
+	relPath, err := filepath.Rel(g.workflowsDir, path)
+	return err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
+
 // UpdateWorkflow updates a workflow in the graph (e.g., after it's been modified)
 func (g *DependencyGraph) UpdateWorkflow(workflowPath string, compiler *workflow.Compiler) error {
 	depGraphLog.Printf("Updating workflow in graph: %s", workflowPath)
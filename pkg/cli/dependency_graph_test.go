@@ -931,3 +931,120 @@ tools:
 		})
 	}
 }
+
+func TestResolveWatchRecompileTargets(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	sharedDir := filepath.Join(workflowsDir, "shared")
+	docsDir := filepath.Join(tmpDir, "docs")
+	for _, dir := range []string{sharedDir, docsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := map[string]string{
+		filepath.Join(docsDir, "guide.md"):       "---\ndescription: Guide\n---\n# Guide",
+		filepath.Join(docsDir, "readme.md"):      "# Untracked readme",
+		filepath.Join(workflowsDir, "docs.md"):   "---\non: push\nimports:\n  - ../../docs/guide.md\n---\n# Docs",
+		filepath.Join(sharedDir, "helper.md"):    "---\ndescription: Helper\n---\n# Helper",
+		filepath.Join(sharedDir, "fragment.md"):  "Included fragment",
+		filepath.Join(sharedDir, "notes.txt"):    "Untracked notes",
+		filepath.Join(workflowsDir, "main.md"):   "---\non: push\nimports:\n  - shared/helper.md\n---\n# Main",
+		filepath.Join(workflowsDir, "other.md"):  "---\non: push\n---\n# Other\n\n@include shared/fragment.md",
+		filepath.Join(workflowsDir, "single.md"): "---\non: push\n---\n# Single",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mainWorkflow := filepath.Join(workflowsDir, "main.md")
+	otherWorkflow := filepath.Join(workflowsDir, "other.md")
+	singleWorkflow := filepath.Join(workflowsDir, "single.md")
+	docsWorkflow := filepath.Join(workflowsDir, "docs.md")
+
+	compiler := workflow.NewCompiler()
+	graph := NewDependencyGraph(workflowsDir)
+	if err := graph.BuildGraph(compiler); err != nil {
+		t.Fatalf("BuildGraph() error = %v", err)
+	}
+
+	if got := graph.ExternalDependencyDirs(); fmt.Sprint(got) != fmt.Sprint([]string{docsDir}) {
+		t.Errorf("ExternalDependencyDirs() = %v, want %v", got, []string{docsDir})
+	}
+
+	tests := []struct {
+		name        string
+		changed     []string
+		watchedFile string
+		want        []string
+	}{
+		{
+			name:    "imported file recompiles importer",
+			changed: []string{filepath.Join(sharedDir, "helper.md")},
+			want:    []string{mainWorkflow},
+		},
+		{
+			name:    "included file recompiles includer",
+			changed: []string{filepath.Join(sharedDir, "fragment.md")},
+			want:    []string{otherWorkflow},
+		},
+		{
+			name:    "import outside the workflows directory recompiles importer",
+			changed: []string{filepath.Join(docsDir, "guide.md")},
+			want:    []string{docsWorkflow},
+		},
+		{
+			name:    "untracked markdown outside the workflows directory is ignored",
+			changed: []string{filepath.Join(docsDir, "readme.md")},
+			want:    []string{},
+		},
+		{
+			name:    "untracked non-markdown file is ignored",
+			changed: []string{filepath.Join(sharedDir, "notes.txt")},
+			want:    []string{},
+		},
+		{
+			name:    "rapid saves of several files are deduplicated",
+			changed: []string{singleWorkflow, filepath.Join(sharedDir, "helper.md"), mainWorkflow, singleWorkflow},
+			want:    []string{mainWorkflow, singleWorkflow},
+		},
+		{
+			name:        "single-file mode recompiles on import change",
+			changed:     []string{filepath.Join(sharedDir, "helper.md")},
+			watchedFile: mainWorkflow,
+			want:        []string{mainWorkflow},
+		},
+		{
+			name:        "single-file mode ignores unrelated workflows",
+			changed:     []string{singleWorkflow, filepath.Join(sharedDir, "fragment.md")},
+			watchedFile: mainWorkflow,
+			want:        []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveWatchRecompileTargets(compiler, graph, tt.changed, tt.watchedFile)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("resolveWatchRecompileTargets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCompilerOutputFile(t *testing.T) {
+	tests := map[string]bool{
+		"ci.lock.yml":      true,
+		"ci.invalid.yml":   true,
+		"ci.md":            false,
+		"shared/tools.yml": false,
+	}
+	for path, want := range tests {
+		if got := isCompilerOutputFile(path); got != want {
+			t.Errorf("isCompilerOutputFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+var diffLog = logger.New("cli:diff_command")
+
+// NewDiffCommand creates the diff command
+func NewDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <workflow>",
+		Short: "Show how recompiling a workflow would change its lock file",
+		Long: `Compile an agentic workflow in memory and show a unified diff against its compiled lock file.
+
+Nothing is written to disk. By default the diff compares the existing .lock.yml file with the
+result of compiling the current Markdown file. With --old-ref, the Markdown file (and the files it
+imports) are taken from the given git ref and compiled instead of reading the existing lock file,
+so the diff shows how the compiled workflow changed since that ref.
+
+` + WorkflowIDExplanation + `
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` diff my-workflow                 # Preview lock file changes
+  ` + string(constants.CLIExtensionPrefix) + ` diff my-workflow --old-ref main  # Compare against the workflow on main
+  ` + string(constants.CLIExtensionPrefix) + ` diff .github/workflows/my-workflow.md --old-ref HEAD~1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			oldRef, _ := cmd.Flags().GetString("old-ref")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			return RunDiff(args[0], oldRef, verbose)
+		},
+	}
+
+	cmd.Flags().String("old-ref", "", "Git ref to compile the previous version of the workflow from instead of reading the lock file")
+
+	// Register completions
+	cmd.ValidArgsFunction = CompleteWorkflowNames
+
+	return cmd
+}
+
+// RunDiff compiles a workflow in memory and prints a unified diff against the previous compilation
+func RunDiff(workflowID string, oldRef string, verbose bool) error {
+	diffLog.Printf("Running diff: workflow=%s, oldRef=%s", workflowID, oldRef)
+
+	markdownPath, err := resolveWorkflowFileInDir(workflowID, verbose, getWorkflowsDir())
+	if err != nil {
+		return err
+	}
+
+	newContent, err := compileWorkflowInMemory(markdownPath, verbose)
+	if err != nil {
+		return err
+	}
+
+	lockPath := stringutil.MarkdownToLockFile(markdownPath)
+	var oldContent, oldLabel string
+	if oldRef != "" {
+		oldContent, err = compileWorkflowAtRef(markdownPath, oldRef, verbose)
+		if err != nil {
+			return err
+		}
+		oldLabel = fmt.Sprintf("%s (%s)", filepath.Base(lockPath), oldRef)
+	} else {
+		data, err := os.ReadFile(lockPath)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read lock file %s: %w", lockPath, err)
+		}
+		if os.IsNotExist(err) && verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Lock file %s does not exist yet", lockPath)))
+		}
+		oldContent = string(data)
+		oldLabel = filepath.Base(lockPath)
+	}
+
+	diff, err := unifiedLockFileDiff(oldContent, newContent, oldLabel, filepath.Base(lockPath)+" (compiled)")
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("No differences: %s is up to date", filepath.Base(lockPath))))
+		return nil
+	}
+
+	fmt.Fprint(os.Stdout, diff)
+	return nil
+}
+
+// compileWorkflowInMemory compiles a workflow file and returns the lock file content without writing it
+func compileWorkflowInMemory(markdownPath string, verbose bool) (string, error) {
+	compiler := workflow.NewCompiler(workflow.WithVerbose(verbose))
+	setupActionMode(compiler, "", "")
+	setupRepositoryContext(compiler)
+
+	// Use the repository-relative path of the original file so fuzzy schedules are
+	// scattered identically for every compilation being compared
+	relPath, err := getRepositoryRelativePath(markdownPath)
+	if err != nil {
+		relPath = filepath.Base(markdownPath)
+	}
+	compiler.SetWorkflowIdentifier(relPath)
+
+	return compiler.CompileWorkflowToYAML(markdownPath)
+}
+
+// compileWorkflowAtRef extracts the workflow's directory tree from a git ref into a temporary
+// directory and compiles the workflow from there, so imports resolve against the same ref
+func compileWorkflowAtRef(markdownPath string, ref string, verbose bool) (string, error) {
+	gitRoot, err := findGitRootForPath(markdownPath)
+	if err != nil {
+		return "", err
+	}
+	absPath, err := filepath.Abs(markdownPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	relPath, err := filepath.Rel(gitRoot, absPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get relative path: %w", err)
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	// Archive the top-level directory containing the workflow (typically .github) so shared
+	// imports are available to the compiler
+	archiveRoot, _, _ := strings.Cut(relPath, "/")
+	diffLog.Printf("Archiving %s at %s from %s", archiveRoot, ref, gitRoot)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "-C", gitRoot, "archive", "--format=tar", ref, "--", archiveRoot)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s at %s: %s", relPath, ref, strings.TrimSpace(stderr.String()))
+	}
+
+	tempDir, err := os.MkdirTemp("", "gh-aw-diff-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := extractTar(bytes.NewReader(output), tempDir); err != nil {
+		return "", fmt.Errorf("failed to extract %s at %s: %w", archiveRoot, ref, err)
+	}
+
+	oldPath := filepath.Join(tempDir, filepath.FromSlash(relPath))
+	if _, err := os.Stat(oldPath); err != nil {
+		return "", fmt.Errorf("workflow %s does not exist at %s", relPath, ref)
+	}
+
+	compiler := workflow.NewCompiler(workflow.WithVerbose(verbose))
+	setupActionMode(compiler, "", "")
+	setupRepositoryContext(compiler)
+	compiler.SetWorkflowIdentifier(relPath)
+
+	return compiler.CompileWorkflowToYAML(oldPath)
+}
+
+// extractTar writes the regular files and directories of a tar stream under destDir
+func extractTar(r io.Reader, destDir string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !filepath.IsLocal(header.Name) {
+			return fmt.Errorf("invalid path in archive: %s", header.Name)
+		}
+		target := filepath.Join(destDir, filepath.FromSlash(header.Name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(file, tr); err != nil {
+				file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// unifiedLockFileDiff returns a unified diff between two compiled lock files, or an empty
+// string when they are identical
+func unifiedLockFileDiff(oldContent, newContent, oldLabel, newLabel string) (string, error) {
+	if oldContent == newContent {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(oldContent),
+		B:        difflib.SplitLines(newContent),
+		FromFile: oldLabel,
+		ToFile:   newLabel,
+		Context:  3,
+	})
+}
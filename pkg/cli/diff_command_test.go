@@ -0,0 +1,96 @@
+//go:build !integration
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const diffTestWorkflow = `---
+on: workflow_dispatch
+engine: copilot
+timeout-minutes: %d
+---
+
+# Diff me
+`
+
+func writeDiffTestWorkflow(t *testing.T, path string, timeout int) {
+	t.Helper()
+	content := []byte(fmt.Sprintf(diffTestWorkflow, timeout))
+	require.NoError(t, os.WriteFile(path, content, 0644))
+}
+
+func TestUnifiedLockFileDiff(t *testing.T) {
+	diff, err := unifiedLockFileDiff("a\nb\nc\n", "a\nb\nc\n", "old", "new")
+	require.NoError(t, err)
+	assert.Empty(t, diff, "identical content should produce no diff")
+
+	diff, err = unifiedLockFileDiff("a\nb\nc\n", "a\nx\nc\n", "old", "new")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- old")
+	assert.Contains(t, diff, "+++ new")
+	assert.Contains(t, diff, "-b\n")
+	assert.Contains(t, diff, "+x\n")
+}
+
+func TestCompiledWorkflowDiff(t *testing.T) {
+	oldDir := t.TempDir()
+	newDir := t.TempDir()
+	oldPath := filepath.Join(oldDir, "diff-me.md")
+	newPath := filepath.Join(newDir, "diff-me.md")
+	writeDiffTestWorkflow(t, oldPath, 10)
+	writeDiffTestWorkflow(t, newPath, 25)
+
+	oldContent, err := compileWorkflowInMemory(oldPath, false)
+	require.NoError(t, err)
+	newContent, err := compileWorkflowInMemory(newPath, false)
+	require.NoError(t, err)
+
+	diff, err := unifiedLockFileDiff(oldContent, newContent, "old.lock.yml", "new.lock.yml")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "-        timeout-minutes: 10\n")
+	assert.Contains(t, diff, "+        timeout-minutes: 25\n")
+	assert.NotContains(t, diff, "GH_AW_WORKFLOW_FILE", "workflows with the same name should only differ in changed lines")
+
+	_, err = os.Stat(filepath.Join(oldDir, "diff-me.lock.yml"))
+	assert.True(t, os.IsNotExist(err), "in-memory compilation should not write a lock file")
+}
+
+func TestRunDiffOldRef(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Chdir(repoDir)
+
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	runGit("init", "-q")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "Test")
+
+	workflowsDir := filepath.Join(repoDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755))
+	workflowPath := filepath.Join(workflowsDir, "diff-me.md")
+	writeDiffTestWorkflow(t, workflowPath, 10)
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+
+	require.NoError(t, RunDiff("diff-me", "HEAD", false), "unchanged workflow should compile at both refs")
+
+	writeDiffTestWorkflow(t, workflowPath, 25)
+	require.NoError(t, RunDiff("diff-me", "HEAD", false))
+
+	err := RunDiff("diff-me", "does-not-exist", false)
+	require.Error(t, err, "unknown refs should be reported")
+}
@@ -3,8 +3,10 @@ package cli
 import (
 	"bufio"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
@@ -77,17 +79,15 @@ func processImportsWithWorkflowSpec(content string, workflow *WorkflowSpec, comm
 		return content, nil // No imports field, return original content
 	}
 
-	// Convert imports to array of strings
-	var imports []string
+	// Imports are strings or objects with path, optional ref and optional inputs
+	var imports []any
 	switch v := importsField.(type) {
 	case []any:
-		for _, item := range v {
-			if str, ok := item.(string); ok {
-				imports = append(imports, str)
-			}
-		}
-	case []string:
 		imports = v
+	case []string:
+		for _, importPath := range v {
+			imports = append(imports, importPath)
+		}
 	default:
 		importsLog.Print("Invalid imports field type, skipping")
 		return content, nil // Invalid imports field, skip processing
@@ -96,24 +96,33 @@ func processImportsWithWorkflowSpec(content string, workflow *WorkflowSpec, comm
 	importsLog.Printf("Found %d imports to process", len(imports))
 
 	// Process each import and replace with workflowspec format
-	processedImports := make([]string, 0, len(imports))
-	for _, importPath := range imports {
-		// Skip if already a workflowspec
-		if isWorkflowSpecFormat(importPath) {
-			importsLog.Printf("Import already in workflowspec format: %s", importPath)
-			processedImports = append(processedImports, importPath)
-			continue
+	processedImports := make([]any, 0, len(imports))
+	for _, item := range imports {
+		switch importItem := item.(type) {
+		case string:
+			processedImports = append(processedImports, importPathToWorkflowSpec(importItem, workflow, commitSHA))
+		case map[string]any:
+			importPath, ok := importItem["path"].(string)
+			if !ok {
+				importsLog.Print("Import object without a string path, keeping as-is")
+				processedImports = append(processedImports, importItem)
+				continue
+			}
+			if _, hasRef := importItem["ref"]; hasRef {
+				// Cross-repo import pinned with ref: lock it to the commit the ref points to
+				pinnedItem, err := pinImportRef(importItem, parser.ResolveRefToSHA)
+				if err != nil {
+					return "", err
+				}
+				processedImports = append(processedImports, pinnedItem)
+				continue
+			}
+			processedItem := maps.Clone(importItem)
+			processedItem["path"] = importPathToWorkflowSpec(importPath, workflow, commitSHA)
+			processedImports = append(processedImports, processedItem)
+		default:
+			processedImports = append(processedImports, importItem)
 		}
-
-		// Resolve the import path relative to the workflow file's directory
-		resolvedPath := resolveImportPath(importPath, workflow.WorkflowPath)
-		importsLog.Printf("Resolved import path: %s -> %s (workflow: %s)", importPath, resolvedPath, workflow.WorkflowPath)
-
-		// Build workflowspec for this import
-		workflowSpec := buildWorkflowSpecRef(workflow.RepoSlug, resolvedPath, commitSHA, workflow.Version)
-
-		importsLog.Printf("Converted import: %s -> %s", importPath, workflowSpec)
-		processedImports = append(processedImports, workflowSpec)
 	}
 
 	// Update frontmatter with processed imports
@@ -123,6 +132,97 @@ func processImportsWithWorkflowSpec(content string, workflow *WorkflowSpec, comm
 	return reconstructWorkflowFileFromMap(result.Frontmatter, result.Markdown)
 }
 
+// importPathToWorkflowSpec converts a local import path to workflowspec format (owner/repo/path@sha),
+// leaving imports that are already workflowspecs unchanged
+func importPathToWorkflowSpec(importPath string, workflow *WorkflowSpec, commitSHA string) string {
+	// Skip if already a workflowspec
+	if isWorkflowSpecFormat(importPath) {
+		importsLog.Printf("Import already in workflowspec format: %s", importPath)
+		return importPath
+	}
+
+	// Resolve the import path relative to the workflow file's directory
+	resolvedPath := resolveImportPath(importPath, workflow.WorkflowPath)
+	importsLog.Printf("Resolved import path: %s -> %s (workflow: %s)", importPath, resolvedPath, workflow.WorkflowPath)
+
+	// Build workflowspec for this import
+	workflowSpec := buildWorkflowSpecRef(workflow.RepoSlug, resolvedPath, commitSHA, workflow.Version)
+	importsLog.Printf("Converted import: %s -> %s", importPath, workflowSpec)
+	return workflowSpec
+}
+
+// pinImportRef resolves the ref of a cross-repo import object such as
+// {path: owner/repo/shared.md, ref: v1.2.0} to a commit SHA and returns a copy of the
+// import with ref replaced by that SHA, so the workflow keeps building against the same content
+func pinImportRef(importItem map[string]any, resolveRef func(owner, repo, ref string) (string, error)) (map[string]any, error) {
+	importPath, _ := importItem["path"].(string)
+	ref, ok := importItem["ref"].(string)
+	if !ok || strings.TrimSpace(ref) == "" {
+		return nil, fmt.Errorf("import '%s' has an empty 'ref': set it to a branch, tag or commit SHA", importPath)
+	}
+	ref = strings.TrimSpace(ref)
+
+	filePath, _, _ := strings.Cut(importPath, "#")
+	if strings.Contains(filePath, "@") {
+		return nil, fmt.Errorf("import '%s' already pins a ref in its path: remove the '@' suffix or the 'ref' field", importPath)
+	}
+	// Local paths (./, /, shared/) mirror the compiler's workflowspec detection
+	isLocal := strings.HasPrefix(filePath, ".") || strings.HasPrefix(filePath, "/") || strings.HasPrefix(filePath, "shared/")
+	parts := strings.SplitN(filePath, "/", 3)
+	if isLocal || len(parts) < 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("import 'ref' can only be used with cross-repository paths in owner/repo/path format, got '%s'", importPath)
+	}
+
+	sha, err := resolveRef(parts[0], parts[1], ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve ref '%s' of import '%s': %w", ref, filePath, err)
+	}
+	importsLog.Printf("Pinned import %s@%s to commit %s", filePath, ref, sha)
+
+	pinnedItem := maps.Clone(importItem)
+	pinnedItem["ref"] = sha
+	return pinnedItem, nil
+}
+
+// pinImportRefsInContent locks every cross-repo import pinned with a 'ref' field to the commit
+// SHA the ref currently resolves to. Content without such imports is returned unchanged.
+func pinImportRefsInContent(content string, resolveRef func(owner, repo, ref string) (string, error), verbose bool) (string, error) {
+	result, err := parser.ExtractFrontmatterFromContent(content)
+	if err != nil {
+		return content, nil
+	}
+	imports, ok := result.Frontmatter["imports"].([]any)
+	if !ok {
+		return content, nil
+	}
+
+	pinned := false
+	for i, item := range imports {
+		importItem, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if _, hasRef := importItem["ref"]; !hasRef {
+			continue
+		}
+		pinnedItem, err := pinImportRef(importItem, resolveRef)
+		if err != nil {
+			return "", err
+		}
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Pinned import %s@%v to commit %v", importItem["path"], importItem["ref"], pinnedItem["ref"])))
+		}
+		imports[i] = pinnedItem
+		pinned = true
+	}
+	if !pinned {
+		return content, nil
+	}
+
+	result.Frontmatter["imports"] = imports
+	return reconstructWorkflowFileFromMap(result.Frontmatter, result.Markdown)
+}
+
 // reconstructWorkflowFileFromMap reconstructs a workflow file from frontmatter map and markdown
 // using proper field ordering and YAML helpers
 func reconstructWorkflowFileFromMap(frontmatter map[string]any, markdown string) (string, error) {
@@ -159,14 +259,11 @@ func processIncludesWithWorkflowSpec(content string, workflow *WorkflowSpec, com
 		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage("Processing @include directives to replace with workflowspec"))
 	}
 
-	// Track visited includes to prevent cycles
+	// Track rewritten includes so repeated top-level includes are only emitted once
 	visited := make(map[string]bool)
 
-	// Use a queue to process files iteratively instead of recursion
-	type fileToProcess struct {
-		path string
-	}
-	queue := []fileToProcess{}
+	// Top-level includes whose nested includes are walked once the content has been rewritten
+	var includedFiles []string
 
 	// Process the main content first
 	scanner := bufio.NewScanner(strings.NewReader(content))
@@ -200,10 +297,10 @@ func processIncludesWithWorkflowSpec(content string, workflow *WorkflowSpec, com
 				continue
 			}
 
-			// Check for cycle detection
+			// Skip includes that were already rewritten
 			if visited[filePath] {
 				if verbose {
-					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Cycle detected for include: %s, skipping", filePath)))
+					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Duplicate include: %s, skipping", filePath)))
 				}
 				continue
 			}
@@ -226,8 +323,8 @@ func processIncludesWithWorkflowSpec(content string, workflow *WorkflowSpec, com
 				result.WriteString("{{#import " + workflowSpec + "}}\n")
 			}
 
-			// Add file to queue for processing nested includes
-			queue = append(queue, fileToProcess{path: filePath})
+			// Remember the file so its nested includes can be walked
+			includedFiles = append(includedFiles, filePath)
 		} else {
 			// Regular line, pass through
 			result.WriteString(line + "\n")
@@ -238,68 +335,70 @@ func processIncludesWithWorkflowSpec(content string, workflow *WorkflowSpec, com
 		return "", err
 	}
 
-	// Process queue of files to check for nested includes
-	for len(queue) > 0 {
-		// Dequeue the first file
-		fileItem := queue[0]
-		queue = queue[1:]
+	// Walk nested includes depth-first so include cycles can be reported
+	walked := make(map[string]bool)
+	for _, filePath := range includedFiles {
+		if err := walkPackageIncludes(filePath, packagePath, []string{filePath}, walked, verbose); err != nil {
+			return "", err
+		}
+	}
+
+	return result.String(), nil
+}
+
+// walkPackageIncludes walks the @include directives nested in a package file. chain holds the files
+// currently being walked, ending with filePath, and an include back into the chain is returned as a
+// parser.ImportCycleError. Files in walked have already been fully walked and are skipped.
+func walkPackageIncludes(filePath, packagePath string, chain []string, walked map[string]bool, verbose bool) error {
+	if walked[filePath] {
+		return nil
+	}
+	walked[filePath] = true
+
+	fullSourcePath := filepath.Join(packagePath, filePath)
+	includedContent, err := os.ReadFile(fullSourcePath)
+	if err != nil {
+		if verbose && !os.IsNotExist(err) {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not read include file %s: %v", fullSourcePath, err)))
+		}
+		return nil // File doesn't exist or can't be read, skip
+	}
 
-		fullSourcePath := filepath.Join(packagePath, fileItem.path)
-		if _, err := os.Stat(fullSourcePath); err != nil {
-			continue // File doesn't exist, skip
+	// Extract markdown content from the included file
+	markdownContent, err := parser.ExtractMarkdownContent(string(includedContent))
+	if err != nil {
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not extract markdown from %s: %v", fullSourcePath, err)))
 		}
+		return nil
+	}
 
-		includedContent, err := os.ReadFile(fullSourcePath)
-		if err != nil {
-			if verbose {
-				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not read include file %s: %v", fullSourcePath, err)))
-			}
+	// Scan for nested includes
+	scanner := bufio.NewScanner(strings.NewReader(markdownContent))
+	for scanner.Scan() {
+		directive := parser.ParseImportDirective(scanner.Text())
+		if directive == nil {
 			continue
 		}
 
-		// Extract markdown content from the included file
-		markdownContent, err := parser.ExtractMarkdownContent(string(includedContent))
-		if err != nil {
-			if verbose {
-				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not extract markdown from %s: %v", fullSourcePath, err)))
-			}
+		// Handle section references
+		nestedFilePath, _, _ := strings.Cut(directive.Path, "#")
+		if nestedFilePath == "" {
 			continue
 		}
 
-		// Scan for nested includes
-		nestedScanner := bufio.NewScanner(strings.NewReader(markdownContent))
-		for nestedScanner.Scan() {
-			line := nestedScanner.Text()
-
-			directive := parser.ParseImportDirective(line)
-			if directive != nil {
-				includePath := directive.Path
-
-				// Handle section references
-				var nestedFilePath string
-				if strings.Contains(includePath, "#") {
-					parts := strings.SplitN(includePath, "#", 2)
-					nestedFilePath = parts[0]
-				} else {
-					nestedFilePath = includePath
-				}
-
-				// Check for cycle detection
-				if visited[nestedFilePath] {
-					if verbose {
-						fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Cycle detected for include: %s, skipping", nestedFilePath)))
-					}
-					continue
-				}
+		if slices.Contains(chain, nestedFilePath) {
+			cycle := append(slices.Clone(chain), nestedFilePath)
+			importsLog.Printf("Include cycle detected: %v", cycle)
+			return &parser.ImportCycleError{Chain: cycle}
+		}
 
-				// Mark as visited and add to queue
-				visited[nestedFilePath] = true
-				queue = append(queue, fileToProcess{path: nestedFilePath})
-			}
+		if err := walkPackageIncludes(nestedFilePath, packagePath, append(slices.Clone(chain), nestedFilePath), walked, verbose); err != nil {
+			return err
 		}
 	}
 
-	return result.String(), nil
+	return scanner.Err()
 }
 
 // processIncludesInContent processes @include directives in workflow content for update command
@@ -3,8 +3,16 @@
 package cli
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProcessIncludesWithWorkflowSpec_NewSyntax(t *testing.T) {
@@ -149,6 +157,72 @@ More content.
 	}
 }
 
+func TestProcessIncludesWithWorkflowSpec_IncludeCycle(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectedCycle string
+	}{
+		{
+			name: "two-file cycle",
+			files: map[string]string{
+				"shared/a.md": "# A\n{{#import shared/b.md}}\n",
+				"shared/b.md": "# B\n@include shared/a.md\n",
+			},
+			expectedCycle: "shared/a.md → shared/b.md → shared/a.md",
+		},
+		{
+			name: "three-file cycle",
+			files: map[string]string{
+				"shared/a.md": "# A\n{{#import shared/b.md}}\n",
+				"shared/b.md": "# B\n{{#import shared/c.md#Details}}\n",
+				"shared/c.md": "# C\n{{#import? shared/a.md}}\n",
+			},
+			expectedCycle: "shared/a.md → shared/b.md → shared/c.md → shared/a.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packagePath := testutil.TempDir(t, "include-cycle-*")
+			require.NoError(t, os.MkdirAll(filepath.Join(packagePath, "shared"), 0755))
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(packagePath, name), []byte(content), 0644))
+			}
+
+			workflow := &WorkflowSpec{RepoSpec: RepoSpec{RepoSlug: "owner/repo", Version: "main"}}
+			content := "---\nengine: copilot\n---\n\n{{#import shared/a.md}}\n"
+
+			_, err := processIncludesWithWorkflowSpec(content, workflow, "", packagePath, false)
+			require.Error(t, err, "include cycle should be reported")
+			var cycleErr *parser.ImportCycleError
+			require.ErrorAs(t, err, &cycleErr)
+			assert.Equal(t, "circular import detected: "+tt.expectedCycle, err.Error())
+		})
+	}
+}
+
+func TestProcessIncludesWithWorkflowSpec_SharedIncludeIsNotACycle(t *testing.T) {
+	packagePath := testutil.TempDir(t, "include-diamond-*")
+	require.NoError(t, os.MkdirAll(filepath.Join(packagePath, "shared"), 0755))
+	files := map[string]string{
+		"shared/a.md":      "# A\n{{#import shared/common.md}}\n",
+		"shared/b.md":      "# B\n{{#import shared/common.md}}\n",
+		"shared/common.md": "# Common\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(packagePath, name), []byte(content), 0644))
+	}
+
+	workflow := &WorkflowSpec{RepoSpec: RepoSpec{RepoSlug: "owner/repo", Version: "main"}}
+	content := "{{#import shared/a.md}}\n{{#import shared/b.md}}\n"
+
+	result, err := processIncludesWithWorkflowSpec(content, workflow, "", packagePath, false)
+	require.NoError(t, err, "a file included from two places is not a cycle")
+	assert.Contains(t, result, "{{#import owner/repo/shared/a.md@main}}")
+	assert.Contains(t, result, "{{#import owner/repo/shared/b.md@main}}")
+}
+
 func TestProcessIncludesInContent_NewSyntax(t *testing.T) {
 	// Test processIncludesInContent with new syntax
 	content := `---
@@ -383,3 +457,96 @@ Test content.
 		}
 	}
 }
+
+func TestProcessImportsWithWorkflowSpec_ObjectImports(t *testing.T) {
+	// A ref that is already a full SHA resolves without calling GitHub
+	pinnedSHA := "0123456789abcdef0123456789abcdef01234567"
+	content := `---
+engine: copilot
+imports:
+  - path: octo/shared/workflows/common.md
+    ref: ` + pinnedSHA + `
+  - path: shared/data-fetch.md
+    inputs:
+      count: 50
+---
+
+# Test Workflow
+`
+
+	workflow := &WorkflowSpec{
+		RepoSpec:     RepoSpec{RepoSlug: "github/gh-aw", Version: "main"},
+		WorkflowPath: ".github/workflows/test-workflow.md",
+	}
+
+	result, err := processImportsWithWorkflowSpec(content, workflow, "abc123def456", false)
+	require.NoError(t, err)
+	assert.Contains(t, result, "path: octo/shared/workflows/common.md\n")
+	assert.Contains(t, result, "ref: "+pinnedSHA, "pinned import should keep its resolved ref")
+	assert.Contains(t, result, "path: github/gh-aw/.github/workflows/shared/data-fetch.md@abc123def456",
+		"local object imports should be converted to workflowspecs")
+	assert.Contains(t, result, "count: 50", "import inputs should be preserved")
+}
+
+func TestPinImportRefsInContent(t *testing.T) {
+	pinnedSHA := "0123456789abcdef0123456789abcdef01234567"
+	resolveRef := func(owner, repo, ref string) (string, error) {
+		if owner == "octo" && repo == "shared" && ref == "v1.2.0" {
+			return pinnedSHA, nil
+		}
+		return "", errors.New("ref not found")
+	}
+
+	t.Run("pinned cross-repo import", func(t *testing.T) {
+		content := `---
+on: issues
+imports:
+  - shared/local.md
+  - path: octo/shared/workflows/common.md
+    ref: v1.2.0
+---
+
+# Test Workflow
+`
+		result, err := pinImportRefsInContent(content, resolveRef, false)
+		require.NoError(t, err)
+		assert.Contains(t, result, "- shared/local.md", "imports without ref should be left alone")
+		assert.Contains(t, result, "path: octo/shared/workflows/common.md\n")
+		assert.Contains(t, result, "ref: "+pinnedSHA, "ref should be locked to the resolved commit")
+		assert.NotContains(t, result, "v1.2.0")
+		assert.Contains(t, result, "# Test Workflow")
+	})
+
+	t.Run("missing ref", func(t *testing.T) {
+		content := `---
+on: issues
+imports:
+  - path: octo/shared/workflows/common.md
+    ref: v9.9.9
+---
+`
+		_, err := pinImportRefsInContent(content, resolveRef, false)
+		require.Error(t, err)
+		assert.Equal(t, "failed to resolve ref 'v9.9.9' of import 'octo/shared/workflows/common.md': ref not found", err.Error())
+	})
+
+	t.Run("ref on a local import", func(t *testing.T) {
+		content := `---
+on: issues
+imports:
+  - path: shared/mcp/local.md
+    ref: v1.2.0
+---
+`
+		_, err := pinImportRefsInContent(content, resolveRef, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "can only be used with cross-repository paths")
+	})
+
+	t.Run("no pinned imports leaves content untouched", func(t *testing.T) {
+		content := "---\non: issues\nimports:\n  - shared/local.md\n---\n\n# Test Workflow\n"
+		result, err := pinImportRefsInContent(content, resolveRef, false)
+		require.NoError(t, err)
+		assert.Equal(t, content, result)
+	})
+}
@@ -0,0 +1,379 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var lintLog = logger.New("cli:lint_command")
+
+// LintSeverity is the severity of a lint finding
+type LintSeverity string
+
+const (
+	// LintSeverityWarning marks findings that are likely mistakes or security concerns
+	LintSeverityWarning LintSeverity = "warning"
+	// LintSeverityInfo marks stylistic findings that are safe to ignore
+	LintSeverityInfo LintSeverity = "info"
+)
+
+// Lint rule categories
+const (
+	lintCategoryTimeout     = "timeout"
+	lintCategoryPermissions = "permissions"
+	lintCategoryTools       = "tools"
+	lintCategoryNetwork     = "network"
+)
+
+// LintIssue is a single advisory finding for a workflow
+type LintIssue struct {
+	Category string
+	Severity LintSeverity
+	Message  string
+}
+
+// ambientTools are tools that are used implicitly by the agent, so they are not expected
+// to be mentioned by name in the prompt
+var ambientTools = map[string]bool{
+	"bash":            true,
+	"edit":            true,
+	"github":          true,
+	"cache-memory":    true,
+	"repo-memory":     true,
+	"timeout":         true,
+	"startup-timeout": true,
+}
+
+// NewLintCommand creates the lint command
+func NewLintCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [workflow]...",
+		Short: "Check agentic workflows for style and best-practice issues",
+		Long: `Check agentic workflow Markdown files for style and best-practice issues.
+
+Unlike compile, lint reports advisory findings that do not make a workflow invalid:
+  • timeout: the workflow does not set timeout-minutes
+  • permissions: write permissions that none of the configured safe-outputs need
+  • tools: tools or MCP servers that the prompt never mentions
+  • network: network.allowed entries already covered by another entry
+
+If no workflows are specified, all Markdown files in .github/workflows will be checked.
+Lint exits with a non-zero status only when --strict is set and findings were reported.
+
+` + WorkflowIDExplanation + `
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` lint                  # Lint all workflows
+  ` + string(constants.CLIExtensionPrefix) + ` lint my-workflow      # Lint a specific workflow
+  ` + string(constants.CLIExtensionPrefix) + ` lint --strict         # Fail when any finding is reported
+  ` + string(constants.CLIExtensionPrefix) + ` lint --dir custom/workflows # Lint workflows in a custom directory`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			strict, _ := cmd.Flags().GetBool("strict")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			dir, _ := cmd.Flags().GetString("dir")
+			return RunLint(args, strict, verbose, dir)
+		},
+	}
+
+	cmd.Flags().Bool("strict", false, "Exit with a non-zero status when any finding is reported")
+	cmd.Flags().StringP("dir", "d", "", "Workflow directory (default: .github/workflows)")
+
+	// Register completions
+	cmd.ValidArgsFunction = CompleteWorkflowNames
+	RegisterDirFlagCompletion(cmd, "dir")
+
+	return cmd
+}
+
+// RunLint lints the specified workflows, or all workflows in workflowDir when none are given
+func RunLint(workflowIDs []string, strict bool, verbose bool, workflowDir string) error {
+	lintLog.Printf("Running lint: workflowIDs=%v, strict=%v, workflowDir=%s", workflowIDs, strict, workflowDir)
+
+	if workflowDir == "" {
+		workflowDir = getWorkflowsDir()
+	} else {
+		workflowDir = filepath.Clean(workflowDir)
+	}
+
+	var files []string
+	if len(workflowIDs) > 0 {
+		for _, workflowID := range workflowIDs {
+			file, err := resolveWorkflowFileInDir(workflowID, verbose, workflowDir)
+			if err != nil {
+				return err
+			}
+			files = append(files, file)
+		}
+	} else {
+		var err error
+		files, err = getMarkdownWorkflowFiles(workflowDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No workflow files found."))
+		return nil
+	}
+
+	compiler := workflow.NewCompiler(workflow.WithVerbose(verbose))
+	totalIssues := 0
+	parseErrors := 0
+	for _, file := range files {
+		// Set workflow identifier so fuzzy schedules can be scattered during parsing
+		relPath, err := getRepositoryRelativePath(file)
+		if err != nil {
+			relPath = filepath.Base(file)
+		}
+		compiler.SetWorkflowIdentifier(relPath)
+
+		workflowData, err := compiler.ParseWorkflowFile(file)
+		if err != nil {
+			var sharedErr *workflow.SharedWorkflowError
+			if errors.As(err, &sharedErr) {
+				lintLog.Printf("Skipping shared workflow: %s", file)
+				continue
+			}
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("Error parsing %s: %v", filepath.Base(file), err)))
+			parseErrors++
+			continue
+		}
+
+		issues := lintWorkflow(workflowData)
+		totalIssues += len(issues)
+		if len(issues) == 0 {
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(filepath.Base(file)+": no issues"))
+			}
+			continue
+		}
+
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(filepath.Base(file)+":"))
+		for _, issue := range issues {
+			line := fmt.Sprintf("  [%s] %s", issue.Category, issue.Message)
+			if issue.Severity == LintSeverityWarning {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(line))
+			} else {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(line))
+			}
+		}
+	}
+
+	if totalIssues == 0 && parseErrors == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Linted %d workflow(s): no issues found", len(files))))
+		return nil
+	}
+
+	summary := fmt.Sprintf("Linted %d workflow(s): %d issue(s) found", len(files), totalIssues)
+	if parseErrors > 0 {
+		summary += fmt.Sprintf(", %d workflow(s) could not be parsed", parseErrors)
+	}
+	if strict {
+		return errors.New(summary)
+	}
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(summary))
+	return nil
+}
+
+// lintWorkflow runs every lint check against a parsed workflow
+func lintWorkflow(workflowData *workflow.WorkflowData) []LintIssue {
+	frontmatter := workflowData.RawFrontmatter
+	var issues []LintIssue
+	issues = append(issues, lintMissingTimeout(frontmatter)...)
+	issues = append(issues, lintBroadPermissions(frontmatter, workflowData.SafeOutputs)...)
+	issues = append(issues, lintUnusedTools(frontmatter, workflowData.MarkdownContent)...)
+	issues = append(issues, lintRedundantNetworkEntries(frontmatter)...)
+	return issues
+}
+
+// lintMissingTimeout reports workflows that rely on the default timeout-minutes
+func lintMissingTimeout(frontmatter map[string]any) []LintIssue {
+	if _, ok := frontmatter["timeout-minutes"]; ok {
+		return nil
+	}
+	return []LintIssue{{
+		Category: lintCategoryTimeout,
+		Severity: LintSeverityWarning,
+		Message:  "timeout-minutes is not set; set an explicit limit so a stuck agent does not run until the default timeout",
+	}}
+}
+
+// lintBroadPermissions reports write permissions that are not needed by any configured safe-output.
+// Safe-outputs perform writes from separate jobs, so the agent job itself rarely needs write access.
+func lintBroadPermissions(frontmatter map[string]any, safeOutputs *workflow.SafeOutputsConfig) []LintIssue {
+	permissionsValue, ok := frontmatter["permissions"]
+	if !ok {
+		return nil
+	}
+
+	if shorthand, ok := permissionsValue.(string); ok && shorthand == "write-all" {
+		return []LintIssue{{
+			Category: lintCategoryPermissions,
+			Severity: LintSeverityWarning,
+			Message:  "permissions: write-all grants every scope; use read permissions and let safe-outputs perform writes",
+		}}
+	}
+
+	declared := workflow.NewPermissionsParserFromValue(permissionsValue).ToPermissions()
+	derived := workflow.DerivePermissions(safeOutputs).Permissions()
+
+	var issues []LintIssue
+	for _, scope := range workflow.GetAllPermissionScopes() {
+		level, ok := declared.Get(scope)
+		if !ok || level != workflow.PermissionWrite {
+			continue
+		}
+		if derivedLevel, ok := derived.Get(scope); ok && derivedLevel == workflow.PermissionWrite {
+			continue
+		}
+		message := fmt.Sprintf("%s: write is broader than needed; no configured safe-output requires it", scope)
+		if writers := safeOutputsRequiringWrite(scope); len(writers) > 0 {
+			if len(writers) > maxLintedSafeOutputWriters {
+				writers = append(writers[:maxLintedSafeOutputWriters], "...")
+			}
+			message += fmt.Sprintf(" (use safe-outputs such as %s instead)", strings.Join(writers, ", "))
+		}
+		issues = append(issues, LintIssue{
+			Category: lintCategoryPermissions,
+			Severity: LintSeverityWarning,
+			Message:  message,
+		})
+	}
+	return issues
+}
+
+// maxLintedSafeOutputWriters limits how many safe-output types a permissions lint message suggests
+const maxLintedSafeOutputWriters = 3
+
+// safeOutputsRequiringWrite returns the keys of the safe-output types that need write access to scope
+func safeOutputsRequiringWrite(scope workflow.PermissionScope) []string {
+	var keys []string
+	for _, info := range workflow.SafeOutputRegistry() {
+		if level, ok := info.Permissions.Get(scope); ok && level == workflow.PermissionWrite {
+			keys = append(keys, info.Key)
+		}
+	}
+	return keys
+}
+
+// lintUnusedTools reports tools and MCP servers that the prompt never mentions by name
+func lintUnusedTools(frontmatter map[string]any, markdown string) []LintIssue {
+	declared := make(map[string]any)
+	if tools, ok := frontmatter["tools"].(map[string]any); ok {
+		for name, config := range tools {
+			if !ambientTools[name] {
+				declared[name] = config
+			}
+		}
+	}
+	if mcpServers, ok := frontmatter["mcp-servers"].(map[string]any); ok {
+		for name, config := range mcpServers {
+			declared[name] = config
+		}
+	}
+
+	prompt := strings.ToLower(markdown)
+	var names []string
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var issues []LintIssue
+	for _, name := range names {
+		if isToolMentioned(prompt, name, declared[name]) {
+			continue
+		}
+		issues = append(issues, LintIssue{
+			Category: lintCategoryTools,
+			Severity: LintSeverityInfo,
+			Message:  fmt.Sprintf("%s is declared but never mentioned in the prompt", name),
+		})
+	}
+	return issues
+}
+
+// isToolMentioned checks whether the lowercased prompt mentions a tool by name, by a spaced
+// variant of its name, or by one of its explicitly allowed tool names
+func isToolMentioned(prompt, name string, config any) bool {
+	candidates := []string{name, strings.ReplaceAll(name, "-", " "), strings.ReplaceAll(name, "-", "_")}
+	if configMap, ok := config.(map[string]any); ok {
+		if allowed, ok := configMap["allowed"].([]any); ok {
+			for _, item := range allowed {
+				if toolName, ok := item.(string); ok && !strings.Contains(toolName, "*") {
+					candidates = append(candidates, toolName)
+				}
+			}
+		}
+	}
+	for _, candidate := range candidates {
+		if strings.Contains(prompt, strings.ToLower(candidate)) {
+			return true
+		}
+	}
+	return false
+}
+
+// lintRedundantNetworkEntries reports network.allowed entries that are duplicated or
+// already covered by a wildcard or ecosystem entry in the same list
+func lintRedundantNetworkEntries(frontmatter map[string]any) []LintIssue {
+	network, ok := frontmatter["network"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	allowed, ok := network["allowed"].([]any)
+	if !ok {
+		return nil
+	}
+
+	var entries []string
+	for _, item := range allowed {
+		if entry, ok := item.(string); ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	var issues []LintIssue
+	seen := make(map[string]bool)
+	for i, entry := range entries {
+		if seen[entry] {
+			issues = append(issues, LintIssue{
+				Category: lintCategoryNetwork,
+				Severity: LintSeverityInfo,
+				Message:  fmt.Sprintf("%s is listed more than once in network.allowed", entry),
+			})
+			continue
+		}
+		seen[entry] = true
+
+		// Ecosystem identifiers are never redundant with individual domains
+		if !strings.Contains(entry, ".") {
+			continue
+		}
+		for j, other := range entries {
+			if i == j || other == entry {
+				continue
+			}
+			if workflow.IsDomainCoveredByAllowedEntry(entry, other) {
+				issues = append(issues, LintIssue{
+					Category: lintCategoryNetwork,
+					Severity: LintSeverityInfo,
+					Message:  fmt.Sprintf("%s is redundant in network.allowed; it is already covered by %s", entry, other),
+				})
+				break
+			}
+		}
+	}
+	return issues
+}
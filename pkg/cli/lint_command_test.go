@@ -0,0 +1,137 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lintMessages(issues []LintIssue) []string {
+	var messages []string
+	for _, issue := range issues {
+		messages = append(messages, issue.Message)
+	}
+	return messages
+}
+
+func TestLintMissingTimeout(t *testing.T) {
+	assert.Empty(t, lintMissingTimeout(map[string]any{"timeout-minutes": 10}))
+
+	issues := lintMissingTimeout(map[string]any{"on": "push"})
+	require.Len(t, issues, 1)
+	assert.Equal(t, lintCategoryTimeout, issues[0].Category)
+	assert.Equal(t, LintSeverityWarning, issues[0].Severity)
+}
+
+func TestLintBroadPermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions any
+		safeOutputs *workflow.SafeOutputsConfig
+		expected    []string
+	}{
+		{
+			name:        "read permissions are fine",
+			permissions: map[string]any{"contents": "read", "issues": "read"},
+			expected:    nil,
+		},
+		{
+			name:        "write not needed by any safe-output",
+			permissions: map[string]any{"contents": "read", "issues": "write", "pull-requests": "write"},
+			safeOutputs: &workflow.SafeOutputsConfig{CreateIssues: &workflow.CreateIssuesConfig{}},
+			expected:    []string{"pull-requests: write is broader than needed; no configured safe-output requires it (use safe-outputs such as close-pull-request, mark-pull-request-as-ready-for-review, create-pull-request, ... instead)"},
+		},
+		{
+			name:        "write without safe-outputs",
+			permissions: map[string]any{"contents": "write"},
+			expected:    []string{"contents: write is broader than needed; no configured safe-output requires it (use safe-outputs such as create-pull-request, push-to-pull-request-branch, upload-asset, ... instead)"},
+		},
+		{
+			name:        "write to a scope no safe-output writes",
+			permissions: map[string]any{"packages": "write"},
+			expected:    []string{"packages: write is broader than needed; no configured safe-output requires it"},
+		},
+		{
+			name:        "write-all shorthand",
+			permissions: "write-all",
+			expected:    []string{"permissions: write-all grants every scope; use read permissions and let safe-outputs perform writes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := lintBroadPermissions(map[string]any{"permissions": tt.permissions}, tt.safeOutputs)
+			assert.Equal(t, tt.expected, lintMessages(issues))
+			for _, issue := range issues {
+				assert.Equal(t, lintCategoryPermissions, issue.Category)
+				assert.Equal(t, LintSeverityWarning, issue.Severity)
+			}
+		})
+	}
+}
+
+func TestLintUnusedTools(t *testing.T) {
+	frontmatter := map[string]any{
+		"tools": map[string]any{
+			"github":     map[string]any{"toolsets": []any{"default"}},
+			"web-fetch":  nil,
+			"playwright": nil,
+		},
+		"mcp-servers": map[string]any{
+			"notion": map[string]any{"allowed": []any{"search_pages"}},
+			"slack":  map[string]any{"allowed": []any{"*"}},
+		},
+	}
+	markdown := "# Triage\n\nUse web fetch to read the docs and search_pages to find the runbook."
+
+	issues := lintUnusedTools(frontmatter, markdown)
+	assert.Equal(t, []string{
+		"playwright is declared but never mentioned in the prompt",
+		"slack is declared but never mentioned in the prompt",
+	}, lintMessages(issues), "ambient tools are skipped and tools are matched by spaced names and allowed tool names")
+}
+
+func TestLintRedundantNetworkEntries(t *testing.T) {
+	frontmatter := map[string]any{
+		"network": map[string]any{
+			"allowed": []any{"defaults", "python", "pypi.org", "*.example.com", "api.example.com", "other.com", "other.com"},
+		},
+	}
+
+	issues := lintRedundantNetworkEntries(frontmatter)
+	assert.Equal(t, []string{
+		"pypi.org is redundant in network.allowed; it is already covered by python",
+		"api.example.com is redundant in network.allowed; it is already covered by *.example.com",
+		"other.com is listed more than once in network.allowed",
+	}, lintMessages(issues))
+
+	assert.Empty(t, lintRedundantNetworkEntries(map[string]any{"network": "defaults"}))
+}
+
+func TestRunLintStrict(t *testing.T) {
+	workflowsDir := filepath.Join(t.TempDir(), ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755))
+	content := `---
+on: workflow_dispatch
+engine: copilot
+strict: false
+permissions:
+  contents: read
+  issues: write
+---
+
+# Lint me
+`
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "lint-me.md"), []byte(content), 0644))
+
+	require.NoError(t, RunLint(nil, false, false, workflowsDir), "findings should not fail without --strict")
+	err := RunLint(nil, true, false, workflowsDir)
+	require.Error(t, err, "findings should fail with --strict")
+	assert.Contains(t, err.Error(), "2 issue(s) found")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +23,7 @@ type WorkflowListItem struct {
 	EngineID string   `json:"engine_id" console:"header:Engine"`
 	Compiled string   `json:"compiled" console:"header:Compiled"`
 	Labels   []string `json:"labels,omitempty" console:"header:Labels,omitempty"`
+	Triggers []string `json:"triggers,omitempty" console:"header:Triggers,omitempty"`
 	On       any      `json:"on,omitempty" console:"-"`
 }
 
@@ -32,7 +34,10 @@ func NewListCommand() *cobra.Command {
 		Short: "List agentic workflows in the repository",
 		Long: `List all agentic workflows in a repository without checking their status.
 
-Displays a simplified table with workflow name, AI engine, and compilation status.
+Displays a simplified table with workflow name, AI engine, triggers, and compilation status.
+A lock file is reported as up to date when the incremental compilation hash recorded by
+'compile --incremental' matches the current workflow sources, falling back to file
+modification times when no hash has been recorded.
 Unlike 'status', this command does not check GitHub workflow state or time remaining.
 
 The optional pattern argument filters workflows by name (case-insensitive substring match).
@@ -123,6 +128,13 @@ func RunListWorkflows(repo, path, pattern string, verbose bool, jsonOutput bool,
 	// Build workflow list
 	var workflows []WorkflowListItem
 
+	// Local workflows are hashed with the same defaults as compile so that lock file
+	// staleness can be checked against the incremental compilation hash
+	var compiler *workflow.Compiler
+	if !isRemote {
+		compiler = createAndConfigureCompiler(CompileConfig{Verbose: verbose})
+	}
+
 	for _, file := range mdFiles {
 		name := extractWorkflowNameFromPath(file)
 
@@ -143,49 +155,15 @@ func RunListWorkflows(repo, path, pattern string, verbose bool, jsonOutput bool,
 				On:       nil,
 			})
 		} else {
-			// Extract engine ID from workflow file
-			agent := extractEngineIDFromFile(file)
-
-			// Check if compiled (.lock.yml file is in .github/workflows)
-			lockFile := stringutil.MarkdownToLockFile(file)
-			compiled := "N/A"
-
-			if _, err := os.Stat(lockFile); err == nil {
-				// Check if up to date
-				mdStat, _ := os.Stat(file)
-				lockStat, _ := os.Stat(lockFile)
-				if mdStat.ModTime().After(lockStat.ModTime()) {
-					compiled = "No"
-				} else {
-					compiled = "Yes"
-				}
-			}
-
-			// Extract "on" field and labels from frontmatter
-			var onField any
-			var labels []string
-			if content, err := os.ReadFile(file); err == nil {
-				if result, err := parser.ExtractFrontmatterFromContent(string(content)); err == nil {
-					if result.Frontmatter != nil {
-						onField = result.Frontmatter["on"]
-						// Extract labels field if present
-						if labelsField, ok := result.Frontmatter["labels"]; ok {
-							if labelsArray, ok := labelsField.([]any); ok {
-								for _, label := range labelsArray {
-									if labelStr, ok := label.(string); ok {
-										labels = append(labels, labelStr)
-									}
-								}
-							}
-						}
-					}
-				}
+			item, ok := buildLocalWorkflowListItem(compiler, file)
+			if !ok {
+				continue
 			}
 
 			// Skip if label filter specified and workflow doesn't have the label
 			if labelFilter != "" {
 				hasLabel := false
-				for _, label := range labels {
+				for _, label := range item.Labels {
 					if strings.EqualFold(label, labelFilter) {
 						hasLabel = true
 						break
@@ -196,14 +174,7 @@ func RunListWorkflows(repo, path, pattern string, verbose bool, jsonOutput bool,
 				}
 			}
 
-			// Build workflow list item
-			workflows = append(workflows, WorkflowListItem{
-				Workflow: name,
-				EngineID: agent,
-				Compiled: compiled,
-				Labels:   labels,
-				On:       onField,
-			})
+			workflows = append(workflows, item)
 		}
 	}
 
@@ -231,6 +202,77 @@ func RunListWorkflows(repo, path, pattern string, verbose bool, jsonOutput bool,
 	return nil
 }
 
+// buildLocalWorkflowListItem summarizes a local workflow file from its frontmatter. The workflow
+// is not compiled, so listing prints no compiler warnings and makes no network requests.
+// ok is false for shared workflows.
+func buildLocalWorkflowListItem(compiler *workflow.Compiler, file string) (item WorkflowListItem, ok bool) {
+	item = WorkflowListItem{
+		Workflow: extractWorkflowNameFromPath(file),
+		Compiled: "N/A",
+	}
+
+	frontmatter, _, err := workflow.ParseFrontmatterOnly(file)
+	if err != nil {
+		listWorkflowsLog.Printf("Failed to parse frontmatter of %s: %v", file, err)
+		item.Compiled = getLockFileModTimeStatus(file)
+		return item, true
+	}
+
+	// Shared workflows have no "on" field and are only used as imports
+	if _, hasOn := frontmatter["on"]; !hasOn {
+		listWorkflowsLog.Printf("Skipping shared workflow: %s", file)
+		return item, false
+	}
+
+	item.On = frontmatter["on"]
+	item.Labels = extractListLabels(frontmatter)
+	item.EngineID = extractEngineIDFromFrontmatter(frontmatter)
+	item.Triggers = workflow.FrontmatterTriggers(frontmatter)
+
+	lockFile := stringutil.MarkdownToLockFile(file)
+	if _, err := os.Stat(lockFile); err != nil {
+		return item, true
+	}
+	if upToDate, hashed := compiler.IsLockFileUpToDate(file); hashed {
+		item.Compiled = "No"
+		if upToDate {
+			item.Compiled = "Yes"
+		}
+	} else {
+		item.Compiled = getLockFileModTimeStatus(file)
+	}
+	return item, true
+}
+
+// getLockFileModTimeStatus reports whether the lock file is newer than the workflow file
+func getLockFileModTimeStatus(file string) string {
+	mdStat, err := os.Stat(file)
+	if err != nil {
+		return "N/A"
+	}
+	lockStat, err := os.Stat(stringutil.MarkdownToLockFile(file))
+	if err != nil {
+		return "N/A"
+	}
+	if mdStat.ModTime().After(lockStat.ModTime()) {
+		return "No"
+	}
+	return "Yes"
+}
+
+// extractListLabels returns the string entries of the frontmatter labels field
+func extractListLabels(frontmatter map[string]any) []string {
+	var labels []string
+	if labelsArray, ok := frontmatter["labels"].([]any); ok {
+		for _, label := range labelsArray {
+			if labelStr, ok := label.(string); ok {
+				labels = append(labels, labelStr)
+			}
+		}
+	}
+	return labels
+}
+
 // getRemoteWorkflowFiles fetches the list of workflow files from a remote repository
 func getRemoteWorkflowFiles(repoSpec, workflowPath string, verbose bool, jsonOutput bool) ([]string, error) {
 	// Parse repo spec: owner/repo[@ref]
@@ -3,10 +3,12 @@
 package cli
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -120,3 +122,105 @@ func TestNewListCommand(t *testing.T) {
 	labelFlag := cmd.Flags().Lookup("label")
 	assert.NotNil(t, labelFlag, "Command should have --label flag")
 }
+
+func TestBuildLocalWorkflowListItem(t *testing.T) {
+	workflowsDir := filepath.Join(t.TempDir(), ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755))
+
+	triagePath := filepath.Join(workflowsDir, "triage.md")
+	triageContent := `---
+on:
+  issues:
+    types: [opened]
+  workflow_dispatch:
+engine: claude
+labels: [automation]
+---
+
+# Triage
+`
+	require.NoError(t, os.WriteFile(triagePath, []byte(triageContent), 0644))
+
+	reportPath := filepath.Join(workflowsDir, "report.md")
+	reportContent := `---
+on: push
+engine: copilot
+---
+
+# Report
+`
+	require.NoError(t, os.WriteFile(reportPath, []byte(reportContent), 0644))
+
+	// Compile triage in incremental mode so that a hash sidecar is recorded in a test cache directory
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	require.NoError(t, createAndConfigureCompiler(CompileConfig{Incremental: true}).CompileWorkflow(triagePath))
+
+	compiler := createAndConfigureCompiler(CompileConfig{})
+
+	item, ok := buildLocalWorkflowListItem(compiler, triagePath)
+	require.True(t, ok)
+	assert.Equal(t, "triage", item.Workflow)
+	assert.Equal(t, "claude", item.EngineID)
+	assert.Equal(t, []string{"issues", "workflow_dispatch"}, item.Triggers)
+	assert.Equal(t, []string{"automation"}, item.Labels)
+	assert.Equal(t, "Yes", item.Compiled, "lock file matches the recorded hash")
+
+	item, ok = buildLocalWorkflowListItem(compiler, reportPath)
+	require.True(t, ok)
+	assert.Equal(t, "copilot", item.EngineID)
+	assert.Equal(t, []string{"push"}, item.Triggers)
+	assert.Equal(t, "N/A", item.Compiled, "workflow without a lock file")
+
+	// Editing the workflow makes the lock file stale even if its modification time is newer
+	require.NoError(t, os.WriteFile(triagePath, []byte(triageContent+"\nAlso add a label.\n"), 0644))
+	lockPath := filepath.Join(workflowsDir, "triage.lock.yml")
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(lockPath, future, future))
+
+	item, ok = buildLocalWorkflowListItem(compiler, triagePath)
+	require.True(t, ok)
+	assert.Equal(t, "No", item.Compiled, "workflow sources changed since the hash was recorded")
+}
+
+func TestBuildLocalWorkflowListItemReadsFrontmatterOnly(t *testing.T) {
+	workflowsDir := filepath.Join(t.TempDir(), ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755))
+
+	// rate-limit makes the compiler print an experimental feature warning
+	dailyPath := filepath.Join(workflowsDir, "daily.md")
+	require.NoError(t, os.WriteFile(dailyPath, []byte(`---
+on: daily
+engine: copilot
+rate-limit:
+  max: 5
+  window: 60
+---
+
+# Daily
+`), 0644))
+
+	sharedPath := filepath.Join(workflowsDir, "shared.md")
+	require.NoError(t, os.WriteFile(sharedPath, []byte("---\ntools:\n  bash: true\n---\n\n# Shared\n"), 0644))
+
+	compiler := createAndConfigureCompiler(CompileConfig{})
+
+	// Capture stderr output
+	originalStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	item, ok := buildLocalWorkflowListItem(compiler, dailyPath)
+	_, sharedOK := buildLocalWorkflowListItem(compiler, sharedPath)
+
+	// Restore stderr and get output
+	w.Close()
+	os.Stderr = originalStderr
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	require.True(t, ok)
+	assert.Equal(t, []string{"schedule", "workflow_dispatch"}, item.Triggers, "shorthand triggers should be expanded")
+	assert.Equal(t, "copilot", item.EngineID)
+	assert.False(t, sharedOK, "shared workflows should not be listed")
+	assert.Empty(t, buf.String(), "listing should not print compiler warnings")
+}
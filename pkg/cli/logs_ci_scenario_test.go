@@ -50,6 +50,8 @@ func TestLogsJSONOutputWithNoRuns(t *testing.T) {
 		10,                           // timeout
 		"summary.json",               // summaryFile
 		"",                           // safeOutputType
+		"",                           // status
+		false,                        // refresh
 	)
 
 	// Restore stdout and read output
@@ -45,6 +45,10 @@ Downloaded artifacts include:
 - workflow-logs/: GitHub Actions workflow run logs (job logs organized in subdirectory)
 - summary.json: Complete metrics and run data for all downloaded runs
 
+Runs that were already downloaded are cached in their run folder and reused on
+subsequent invocations. Use --refresh to discard the cached folders and download
+the artifacts again.
+
 ` + WorkflowIDExplanation + `
 
 Examples:
@@ -61,11 +65,14 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` logs --start-date -1w -c 5     # Download all runs from last week, show up to 5
   ` + string(constants.CLIExtensionPrefix) + ` logs --end-date -1d            # Download all runs until yesterday
   ` + string(constants.CLIExtensionPrefix) + ` logs --start-date -1mo         # Download all runs from last month
+  ` + string(constants.CLIExtensionPrefix) + ` logs --since -1w --until -1d   # Same as --start-date/--end-date
 
   # Content filtering
   ` + string(constants.CLIExtensionPrefix) + ` logs --engine claude           # Filter logs by claude engine
   ` + string(constants.CLIExtensionPrefix) + ` logs --engine codex            # Filter logs by codex engine
   ` + string(constants.CLIExtensionPrefix) + ` logs --engine copilot          # Filter logs by copilot engine
+  ` + string(constants.CLIExtensionPrefix) + ` logs --status failure          # Filter logs by run status or conclusion
+  ` + string(constants.CLIExtensionPrefix) + ` logs --engine claude --status failure --since -1w  # Failed claude runs from last week
   ` + string(constants.CLIExtensionPrefix) + ` logs --firewall                # Filter logs with firewall enabled
   ` + string(constants.CLIExtensionPrefix) + ` logs --no-firewall             # Filter logs without firewall
   ` + string(constants.CLIExtensionPrefix) + ` logs --safe-output missing-tool     # Filter logs with missing_tool messages
@@ -85,6 +92,7 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` logs --parse                   # Parse logs and generate Markdown reports
   ` + string(constants.CLIExtensionPrefix) + ` logs --json                    # Output metrics in JSON format
   ` + string(constants.CLIExtensionPrefix) + ` logs --parse --json            # Generate both Markdown and JSON
+  ` + string(constants.CLIExtensionPrefix) + ` logs --refresh                 # Re-download runs already cached locally
 
   # Cross-repository
   ` + string(constants.CLIExtensionPrefix) + ` logs weekly-research --repo owner/repo  # Download logs from specific repository`,
@@ -138,6 +146,14 @@ Examples:
 			repoOverride, _ := cmd.Flags().GetString("repo")
 			summaryFile, _ := cmd.Flags().GetString("summary-file")
 			safeOutputType, _ := cmd.Flags().GetString("safe-output")
+			status, _ := cmd.Flags().GetString("status")
+			refresh, _ := cmd.Flags().GetBool("refresh")
+			if since, _ := cmd.Flags().GetString("since"); since != "" {
+				startDate = since
+			}
+			if until, _ := cmd.Flags().GetString("until"); until != "" {
+				endDate = until
+			}
 
 			// Resolve relative dates to absolute dates for GitHub CLI
 			now := time.Now()
@@ -147,6 +163,9 @@ Examples:
 				if err != nil {
 					return fmt.Errorf("invalid start-date format '%s': %w", startDate, err)
 				}
+				if _, err := parseRunFilterDate(resolvedStartDate, false); err != nil {
+					return fmt.Errorf("invalid start-date format '%s': %w", startDate, err)
+				}
 				startDate = resolvedStartDate
 				logsCommandLog.Printf("Resolved start date to: %s", startDate)
 			}
@@ -156,6 +175,9 @@ Examples:
 				if err != nil {
 					return fmt.Errorf("invalid end-date format '%s': %w", endDate, err)
 				}
+				if _, err := parseRunFilterDate(resolvedEndDate, true); err != nil {
+					return fmt.Errorf("invalid end-date format '%s': %w", endDate, err)
+				}
 				endDate = resolvedEndDate
 				logsCommandLog.Printf("Resolved end date to: %s", endDate)
 			}
@@ -170,9 +192,13 @@ Examples:
 				}
 			}
 
+			if err := validateRunStatusFilter(status); err != nil {
+				return err
+			}
+
 			logsCommandLog.Printf("Executing logs download: workflow=%s, count=%d, engine=%s", workflowName, count, engine)
 
-			return DownloadWorkflowLogs(cmd.Context(), workflowName, count, startDate, endDate, outputDir, engine, ref, beforeRunID, afterRunID, repoOverride, verbose, toolGraph, noStaged, firewallOnly, noFirewall, parse, jsonOutput, timeout, summaryFile, safeOutputType)
+			return DownloadWorkflowLogs(cmd.Context(), workflowName, count, startDate, endDate, outputDir, engine, ref, beforeRunID, afterRunID, repoOverride, verbose, toolGraph, noStaged, firewallOnly, noFirewall, parse, jsonOutput, timeout, summaryFile, safeOutputType, status, refresh)
 		},
 	}
 
@@ -180,8 +206,11 @@ Examples:
 	logsCmd.Flags().IntP("count", "c", 10, "Maximum number of matching workflow runs to return (after applying filters)")
 	logsCmd.Flags().String("start-date", "", "Filter runs created after this date (YYYY-MM-DD or delta like -1d, -1w, -1mo)")
 	logsCmd.Flags().String("end-date", "", "Filter runs created before this date (YYYY-MM-DD or delta like -1d, -1w, -1mo)")
+	logsCmd.Flags().String("since", "", "Alias for --start-date")
+	logsCmd.Flags().String("until", "", "Alias for --end-date")
 	addOutputFlag(logsCmd, defaultLogsOutputDir)
 	addEngineFilterFlag(logsCmd)
+	logsCmd.Flags().String("status", "", "Filter runs by status or conclusion (e.g., failure, success, cancelled, timed_out)")
 	logsCmd.Flags().String("ref", "", "Filter runs by branch or tag name (e.g., main, v1.0.0)")
 	logsCmd.Flags().Int64("before-run-id", 0, "Filter runs with database ID before this value (exclusive)")
 	logsCmd.Flags().Int64("after-run-id", 0, "Filter runs with database ID after this value (exclusive)")
@@ -194,8 +223,11 @@ Examples:
 	logsCmd.Flags().Bool("parse", false, "Run JavaScript parsers on agent logs and firewall logs, writing Markdown to log.md and firewall.md")
 	addJSONFlag(logsCmd)
 	logsCmd.Flags().Int("timeout", 0, "Download timeout in seconds (0 = no timeout)")
+	logsCmd.Flags().Bool("refresh", false, "Re-download artifacts for runs already cached in the output directory")
 	logsCmd.Flags().String("summary-file", "summary.json", "Path to write the summary JSON file relative to output directory (use empty string to disable)")
 	logsCmd.MarkFlagsMutuallyExclusive("firewall", "no-firewall")
+	logsCmd.MarkFlagsMutuallyExclusive("start-date", "since")
+	logsCmd.MarkFlagsMutuallyExclusive("end-date", "until")
 
 	// Register completions for logs command
 	logsCmd.ValidArgsFunction = CompleteWorkflowNames
@@ -5,7 +5,7 @@
 // Key responsibilities:
 //   - Downloading workflow run artifacts via gh CLI
 //   - Extracting and organizing zip archives
-//   - Flattening single-file artifact directories
+//   - Flattening single-file artifact directories (idempotently, so cached run folders are reused as-is)
 //   - Managing local file system operations
 
 package cli
@@ -30,7 +30,14 @@ var logsDownloadLog = logger.New("cli:logs_download")
 
 // flattenSingleFileArtifacts checks artifact directories and flattens any that contain a single file
 // This handles the case where gh CLI creates a directory for each artifact, even if it's just one file
+// Run folders that were already flattened are left untouched, since their remaining subdirectories
+// are part of the flattened layout rather than artifact download folders
 func flattenSingleFileArtifacts(outputDir string, verbose bool) error {
+	if isArtifactsFlattened(outputDir) {
+		logsDownloadLog.Printf("Artifacts already flattened, skipping single-file flattening: %s", outputDir)
+		return nil
+	}
+
 	logsDownloadLog.Printf("Flattening single-file artifacts in: %s", outputDir)
 	entries, err := os.ReadDir(outputDir)
 	if err != nil {
@@ -79,6 +86,15 @@ func flattenSingleFileArtifacts(outputDir string, verbose bool) error {
 		sourcePath := filepath.Join(artifactDir, singleEntry.Name())
 		destPath := filepath.Join(outputDir, singleEntry.Name())
 
+		// Never overwrite an existing file at the root of the run folder
+		if _, err := os.Stat(destPath); err == nil {
+			logsDownloadLog.Printf("Destination %s already exists, not flattening %s", destPath, entry.Name())
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Not flattening %s: %s already exists", entry.Name(), singleEntry.Name())))
+			}
+			continue
+		}
+
 		logsDownloadLog.Printf("Flattening: %s → %s", sourcePath, destPath)
 
 		// Move the file to root (parent directory)
@@ -108,6 +124,48 @@ func flattenSingleFileArtifacts(outputDir string, verbose bool) error {
 	return nil
 }
 
+// flattenRunArtifacts flattens all downloaded artifacts in a run folder and marks the folder as
+// flattened. Calling it again on a flattened run folder is a no-op.
+func flattenRunArtifacts(outputDir string, verbose bool) error {
+	if isArtifactsFlattened(outputDir) {
+		logsDownloadLog.Printf("Artifacts already flattened: %s", outputDir)
+		return nil
+	}
+
+	// Flatten single-file artifacts
+	if err := flattenSingleFileArtifacts(outputDir, verbose); err != nil {
+		return fmt.Errorf("failed to flatten artifacts: %w", err)
+	}
+
+	// Flatten unified agent-artifacts directory structure
+	if err := flattenUnifiedArtifact(outputDir, verbose); err != nil {
+		return fmt.Errorf("failed to flatten unified artifact: %w", err)
+	}
+
+	// Flatten agent_outputs artifact if present
+	if err := flattenAgentOutputsArtifact(outputDir, verbose); err != nil {
+		return fmt.Errorf("failed to flatten agent_outputs artifact: %w", err)
+	}
+
+	markerPath := filepath.Join(outputDir, artifactsFlattenedMarkerFileName)
+	if err := os.WriteFile(markerPath, nil, 0644); err != nil {
+		return fmt.Errorf("failed to mark artifacts as flattened: %w", err)
+	}
+	logsDownloadLog.Printf("Marked artifacts as flattened: %s", markerPath)
+	return nil
+}
+
+// isArtifactsFlattened reports whether the run folder has already been flattened
+func isArtifactsFlattened(outputDir string) bool {
+	_, err := os.Stat(filepath.Join(outputDir, artifactsFlattenedMarkerFileName))
+	return err == nil
+}
+
+// isRunCacheMetadataFile reports whether a file in a run folder is cache bookkeeping rather than an artifact
+func isRunCacheMetadataFile(name string) bool {
+	return name == runSummaryFileName || name == artifactsFlattenedMarkerFileName
+}
+
 // flattenUnifiedArtifact flattens the unified agent-artifacts directory structure
 // After artifact refactoring, files are stored directly in agent-artifacts/ without the tmp/gh-aw/ prefix
 // This function moves those files to the root output directory and removes the nested structure
@@ -446,8 +504,8 @@ func listArtifacts(outputDir string) ([]string, error) {
 			return err
 		}
 
-		// Skip directories and the summary file itself
-		if info.IsDir() || filepath.Base(path) == runSummaryFileName {
+		// Skip directories and the cache metadata files
+		if info.IsDir() || isRunCacheMetadataFile(filepath.Base(path)) {
 			return nil
 		}
 
@@ -469,8 +527,20 @@ func listArtifacts(outputDir string) ([]string, error) {
 }
 
 // downloadRunArtifacts downloads artifacts for a specific workflow run
-func downloadRunArtifacts(runID int64, outputDir string, verbose bool) error {
-	logsDownloadLog.Printf("Downloading run artifacts: run_id=%d, output_dir=%s", runID, outputDir)
+// Previously downloaded artifacts in outputDir are reused unless refresh is set,
+// in which case the run folder is removed and the artifacts are downloaded again
+func downloadRunArtifacts(runID int64, outputDir string, verbose bool, refresh bool) error {
+	logsDownloadLog.Printf("Downloading run artifacts: run_id=%d, output_dir=%s, refresh=%v", runID, outputDir, refresh)
+
+	if refresh && fileutil.DirExists(outputDir) {
+		logsDownloadLog.Printf("Refresh requested, removing cached artifacts for run %d", runID)
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Removing cached artifacts for run %d at %s", runID, outputDir)))
+		}
+		if err := os.RemoveAll(outputDir); err != nil {
+			return fmt.Errorf("failed to remove cached artifacts for run %d: %w", runID, err)
+		}
+	}
 
 	// Check if artifacts already exist on disk (since they're immutable)
 	if fileutil.DirExists(outputDir) && !fileutil.IsDirEmpty(outputDir) {
@@ -544,19 +614,9 @@ func downloadRunArtifacts(runID int64, outputDir string, verbose bool) error {
 		spinner.StopWithMessage(fmt.Sprintf("✓ Downloaded artifacts for run %d", runID))
 	}
 
-	// Flatten single-file artifacts
-	if err := flattenSingleFileArtifacts(outputDir, verbose); err != nil {
-		return fmt.Errorf("failed to flatten artifacts: %w", err)
-	}
-
-	// Flatten unified agent-artifacts directory structure
-	if err := flattenUnifiedArtifact(outputDir, verbose); err != nil {
-		return fmt.Errorf("failed to flatten unified artifact: %w", err)
-	}
-
-	// Flatten agent_outputs artifact if present
-	if err := flattenAgentOutputsArtifact(outputDir, verbose); err != nil {
-		return fmt.Errorf("failed to flatten agent_outputs artifact: %w", err)
+	// Flatten the downloaded artifacts into the run folder layout
+	if err := flattenRunArtifacts(outputDir, verbose); err != nil {
+		return err
 	}
 
 	// Download and unzip workflow run logs
@@ -577,7 +637,7 @@ func downloadRunArtifacts(runID int64, outputDir string, verbose bool) error {
 			if err != nil {
 				return nil
 			}
-			if info.IsDir() {
+			if info.IsDir() || isRunCacheMetadataFile(info.Name()) {
 				return nil
 			}
 			fileCount++
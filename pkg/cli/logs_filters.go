@@ -0,0 +1,105 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (logs_filters.go) contains the run filter predicates used by the logs command.
+//
+// Key responsibilities:
+//   - Matching run status and creation date from run metadata before artifacts are downloaded
+//   - Matching the engine recorded in aw_info.json after artifacts are downloaded
+
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var logsFiltersLog = logger.New("cli:logs_filters")
+
+// runStatusFilters are the values accepted by --status. They mirror `gh run list --status`,
+// which matches either the run status or its conclusion.
+var runStatusFilters = []string{
+	"queued", "completed", "in_progress", "requested", "waiting", "pending",
+	"action_required", "cancelled", "failure", "neutral", "skipped", "stale",
+	"startup_failure", "success", "timed_out",
+}
+
+// validateRunStatusFilter checks that status is a value accepted by --status
+func validateRunStatusFilter(status string) error {
+	if status == "" || slices.Contains(runStatusFilters, status) {
+		return nil
+	}
+	return fmt.Errorf("invalid status value '%s'. Must be one of: %s", status, strings.Join(runStatusFilters, ", "))
+}
+
+// matchesRunStatus reports whether the run's status or conclusion equals the status filter.
+// An empty filter matches every run.
+func matchesRunStatus(run WorkflowRun, status string) bool {
+	if status == "" {
+		return true
+	}
+	return run.Status == status || run.Conclusion == status
+}
+
+// parseRunFilterDate parses a resolved --start-date/--end-date value, which is either a
+// YYYY-MM-DD date or an RFC 3339 timestamp produced from a relative delta. Plain dates used
+// as an upper bound cover the whole day.
+func parseRunFilterDate(value string, upperBound bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD, an RFC 3339 timestamp, or a delta like -1d, -1w, -1mo: %w", err)
+	}
+	if upperBound {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// matchesRunDateRange reports whether the run was created within [since, until].
+// A zero bound is not applied.
+func matchesRunDateRange(run WorkflowRun, since, until time.Time) bool {
+	if !since.IsZero() && run.CreatedAt.Before(since) {
+		return false
+	}
+	if !until.IsZero() && run.CreatedAt.After(until) {
+		return false
+	}
+	return true
+}
+
+// filterRunsByMetadata keeps the runs that match the status and creation date filters.
+// It runs before any artifacts are downloaded.
+func filterRunsByMetadata(runs []WorkflowRun, status, startDate, endDate string) []WorkflowRun {
+	if status == "" && startDate == "" && endDate == "" {
+		return runs
+	}
+	// Dates are validated by the logs command, so parse errors here leave the bound unset
+	since, _ := parseRunFilterDate(startDate, false)
+	until, _ := parseRunFilterDate(endDate, true)
+
+	var filtered []WorkflowRun
+	for _, run := range runs {
+		if matchesRunStatus(run, status) && matchesRunDateRange(run, since, until) {
+			filtered = append(filtered, run)
+		}
+	}
+	logsFiltersLog.Printf("Filtered runs by metadata: %d of %d match (status=%s, startDate=%s, endDate=%s)", len(filtered), len(runs), status, startDate, endDate)
+	return filtered
+}
+
+// matchesEngineFilter reports whether the engine_id recorded in aw_info.json matches the
+// engine filter. Runs without aw_info.json or an engine_id never match a non-empty filter.
+func matchesEngineFilter(info *AwInfo, engine string) bool {
+	if engine == "" {
+		return true
+	}
+	return info != nil && info.EngineID == engine
+}
@@ -0,0 +1,104 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesRunStatus(t *testing.T) {
+	completedFailure := WorkflowRun{Status: "completed", Conclusion: "failure"}
+	inProgress := WorkflowRun{Status: "in_progress"}
+
+	assert.True(t, matchesRunStatus(completedFailure, ""), "empty filter should match every run")
+	assert.True(t, matchesRunStatus(completedFailure, "failure"), "should match the conclusion")
+	assert.True(t, matchesRunStatus(completedFailure, "completed"), "should match the status")
+	assert.False(t, matchesRunStatus(completedFailure, "success"))
+	assert.True(t, matchesRunStatus(inProgress, "in_progress"))
+	assert.False(t, matchesRunStatus(inProgress, "failure"))
+}
+
+func TestValidateRunStatusFilter(t *testing.T) {
+	require.NoError(t, validateRunStatusFilter(""))
+	require.NoError(t, validateRunStatusFilter("timed_out"))
+
+	err := validateRunStatusFilter("failed")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid status value 'failed'")
+}
+
+func TestParseRunFilterDate(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		upperBound bool
+		expected   time.Time
+		wantErr    bool
+	}{
+		{name: "empty", value: "", expected: time.Time{}},
+		{name: "date lower bound", value: "2024-01-15", expected: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "date upper bound covers the day", value: "2024-01-15", upperBound: true, expected: time.Date(2024, 1, 15, 23, 59, 59, 999999999, time.UTC)},
+		{name: "resolved relative delta", value: "2024-01-15T10:30:00Z", upperBound: true, expected: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{name: "invalid", value: "15/01/2024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRunFilterDate(tt.value, tt.upperBound)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "expected %v, got %v", tt.expected, got)
+		})
+	}
+}
+
+func TestMatchesRunDateRange(t *testing.T) {
+	run := WorkflowRun{CreatedAt: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)}
+	before := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, matchesRunDateRange(run, time.Time{}, time.Time{}), "no bounds should match")
+	assert.True(t, matchesRunDateRange(run, before, after))
+	assert.False(t, matchesRunDateRange(run, after, time.Time{}), "run created before since")
+	assert.False(t, matchesRunDateRange(run, time.Time{}, before), "run created after until")
+}
+
+func TestFilterRunsByMetadata(t *testing.T) {
+	runs := []WorkflowRun{
+		{DatabaseID: 1, Status: "completed", Conclusion: "failure", CreatedAt: time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)},
+		{DatabaseID: 2, Status: "completed", Conclusion: "success", CreatedAt: time.Date(2024, 1, 12, 8, 0, 0, 0, time.UTC)},
+		{DatabaseID: 3, Status: "completed", Conclusion: "failure", CreatedAt: time.Date(2024, 1, 15, 23, 0, 0, 0, time.UTC)},
+		{DatabaseID: 4, Status: "completed", Conclusion: "failure", CreatedAt: time.Date(2024, 1, 16, 1, 0, 0, 0, time.UTC)},
+	}
+
+	ids := func(filtered []WorkflowRun) []int64 {
+		var result []int64
+		for _, run := range filtered {
+			result = append(result, run.DatabaseID)
+		}
+		return result
+	}
+
+	assert.Len(t, filterRunsByMetadata(runs, "", "", ""), 4, "no filters should keep every run")
+	assert.Equal(t, []int64{1, 3, 4}, ids(filterRunsByMetadata(runs, "failure", "", "")))
+	assert.Equal(t, []int64{2, 3}, ids(filterRunsByMetadata(runs, "", "2024-01-11", "2024-01-15")))
+	assert.Equal(t, []int64{3}, ids(filterRunsByMetadata(runs, "failure", "2024-01-11T00:00:00Z", "2024-01-15")))
+}
+
+func TestMatchesEngineFilter(t *testing.T) {
+	claudeInfo := &AwInfo{EngineID: "claude"}
+
+	assert.True(t, matchesEngineFilter(claudeInfo, ""), "empty filter should match every run")
+	assert.True(t, matchesEngineFilter(nil, ""), "empty filter should match runs without aw_info.json")
+	assert.True(t, matchesEngineFilter(claudeInfo, "claude"))
+	assert.False(t, matchesEngineFilter(claudeInfo, "copilot"))
+	assert.False(t, matchesEngineFilter(nil, "claude"), "runs without aw_info.json should not match")
+	assert.False(t, matchesEngineFilter(&AwInfo{}, "claude"), "runs without engine_id should not match")
+}
@@ -481,3 +481,143 @@ func TestFlattenUnifiedArtifact(t *testing.T) {
 		})
 	}
 }
+
+// snapshotRunFolder returns the relative path and content of every file under dir
+func snapshotRunFolder(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[relPath] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to snapshot %s: %v", dir, err)
+	}
+	return files
+}
+
+// setupDownloadedRunFolder creates a run folder laid out like a fresh gh run download
+func setupDownloadedRunFolder(t *testing.T, dir string) {
+	t.Helper()
+	files := map[string]string{
+		"aw-info/aw_info.json":                      `{"engine_id":"copilot"}`,
+		"safe-output/safe_output.jsonl":             `{"type":"noop"}`,
+		"agent-artifacts/aw-prompts/prompt.txt":     "prompt",
+		"agent-artifacts/mcp-logs/github.log":       "github",
+		"agent-artifacts/mcp-logs/safeoutputs.log":  "safeoutputs",
+		"agent-artifacts/agent-stdio.log":           "stdio",
+		"agent_outputs/session-1.log":               "session",
+		"multi-file-artifact/first.txt":             "first",
+		"multi-file-artifact/second.txt":            "second",
+		"single-dir-artifact/nested/only-child.txt": "nested",
+	}
+	for relPath, content := range files {
+		path := filepath.Join(dir, relPath)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", relPath, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", relPath, err)
+		}
+	}
+}
+
+func TestFlattenRunArtifactsIsIdempotent(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-flatten-idempotent-*")
+	setupDownloadedRunFolder(t, tmpDir)
+
+	if err := flattenRunArtifacts(tmpDir, false); err != nil {
+		t.Fatalf("First flattenRunArtifacts failed: %v", err)
+	}
+	flattened := snapshotRunFolder(t, tmpDir)
+
+	// Sanity check the flattened layout, including a single-file subdirectory
+	// produced by the unified artifact that must survive later passes
+	for _, file := range []string{"aw_info.json", "safe_output.jsonl", "aw-prompts/prompt.txt", "agent-stdio.log", "session-1.log", artifactsFlattenedMarkerFileName} {
+		if _, ok := flattened[file]; !ok {
+			t.Errorf("Expected %s after first flattening, got %v", file, flattened)
+		}
+	}
+
+	// Reprocess the cached folder with every flatten function
+	if err := flattenRunArtifacts(tmpDir, false); err != nil {
+		t.Fatalf("Second flattenRunArtifacts failed: %v", err)
+	}
+	if err := flattenSingleFileArtifacts(tmpDir, false); err != nil {
+		t.Fatalf("flattenSingleFileArtifacts on cached folder failed: %v", err)
+	}
+	if err := flattenUnifiedArtifact(tmpDir, false); err != nil {
+		t.Fatalf("flattenUnifiedArtifact on cached folder failed: %v", err)
+	}
+	if err := flattenAgentOutputsArtifact(tmpDir, false); err != nil {
+		t.Fatalf("flattenAgentOutputsArtifact on cached folder failed: %v", err)
+	}
+
+	reprocessed := snapshotRunFolder(t, tmpDir)
+	if len(reprocessed) != len(flattened) {
+		t.Errorf("Reprocessing changed the file set: before %v, after %v", flattened, reprocessed)
+	}
+	for file, content := range flattened {
+		if got, ok := reprocessed[file]; !ok {
+			t.Errorf("File %s missing after reprocessing", file)
+		} else if got != content {
+			t.Errorf("File %s corrupted after reprocessing: expected %q, got %q", file, content, got)
+		}
+	}
+}
+
+func TestFlattenSingleFileArtifactsDoesNotOverwrite(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-flatten-no-overwrite-*")
+
+	// A file already at the root must not be replaced by an artifact with the same file name
+	if err := os.WriteFile(filepath.Join(tmpDir, "output.json"), []byte("root"), 0644); err != nil {
+		t.Fatalf("Failed to write root file: %v", err)
+	}
+	artifactDir := filepath.Join(tmpDir, "my-artifact")
+	if err := os.MkdirAll(artifactDir, 0755); err != nil {
+		t.Fatalf("Failed to create artifact directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(artifactDir, "output.json"), []byte("artifact"), 0644); err != nil {
+		t.Fatalf("Failed to write artifact file: %v", err)
+	}
+
+	if err := flattenSingleFileArtifacts(tmpDir, false); err != nil {
+		t.Fatalf("flattenSingleFileArtifacts failed: %v", err)
+	}
+
+	files := snapshotRunFolder(t, tmpDir)
+	if files["output.json"] != "root" {
+		t.Errorf("Root file was overwritten: got %q", files["output.json"])
+	}
+	if files[filepath.Join("my-artifact", "output.json")] != "artifact" {
+		t.Errorf("Artifact file should be left in place, got %v", files)
+	}
+}
+
+func TestListArtifactsSkipsCacheMetadata(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-list-artifacts-*")
+	for _, name := range []string{"aw_info.json", runSummaryFileName, artifactsFlattenedMarkerFileName} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("{}"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	artifacts, err := listArtifacts(tmpDir)
+	if err != nil {
+		t.Fatalf("listArtifacts failed: %v", err)
+	}
+	if len(artifacts) != 1 || artifacts[0] != "aw_info.json" {
+		t.Errorf("Expected only aw_info.json, got %v", artifacts)
+	}
+}
@@ -125,6 +125,7 @@ type ListWorkflowRunsOptions struct {
 	Limit          int    // maximum number of runs to fetch in this API call (batch size)
 	StartDate      string // filter by creation date (>=)
 	EndDate        string // filter by creation date (<=)
+	Status         string // filter by run status or conclusion (e.g., failure, success)
 	BeforeDate     string // used for pagination (fetch runs created before this date)
 	Ref            string // filter by branch or tag name
 	BeforeRunID    int64  // filter by run database ID (< this ID)
@@ -169,6 +170,9 @@ func listWorkflowRunsWithPagination(opts ListWorkflowRunsOptions) ([]WorkflowRun
 	if opts.EndDate != "" {
 		args = append(args, "--created", "<="+opts.EndDate)
 	}
+	if opts.Status != "" {
+		args = append(args, "--status", opts.Status)
+	}
 	// Add beforeDate filter for pagination
 	if opts.BeforeDate != "" {
 		args = append(args, "--created", "<"+opts.BeforeDate)
@@ -301,5 +305,8 @@ func listWorkflowRunsWithPagination(opts ListWorkflowRunsOptions) ([]WorkflowRun
 		agenticRuns = filteredRuns
 	}
 
+	// Apply status and date filtering on the run metadata so non-matching runs are never downloaded
+	agenticRuns = filterRunsByMetadata(agenticRuns, opts.Status, opts.StartDate, opts.EndDate)
+
 	return agenticRuns, totalFetched, nil
 }
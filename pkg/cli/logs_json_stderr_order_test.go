@@ -58,6 +58,8 @@ func TestLogsJSONOutputBeforeStderr(t *testing.T) {
 		10,                                // timeout
 		"summary.json",                    // summaryFile
 		"",                                // safeOutputType
+		"",                                // status
+		false,                             // refresh
 	)
 
 	// Close writers first
@@ -177,7 +179,9 @@ func TestLogsJSONAndStderrRedirected(t *testing.T) {
 		true, // jsonOutput
 		10,
 		"summary.json",
-		"", // safeOutputType
+		"",    // safeOutputType
+		"",    // status
+		false, // refresh
 	)
 
 	// Close the writer
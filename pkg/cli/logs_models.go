@@ -15,6 +15,8 @@ const (
 	defaultAgentStdioLogPath = "/tmp/gh-aw/agent-stdio.log"
 	// runSummaryFileName is the name of the summary file created in each run folder
 	runSummaryFileName = "run_summary.json"
+	// artifactsFlattenedMarkerFileName marks a run folder whose downloaded artifacts have been flattened
+	artifactsFlattenedMarkerFileName = ".artifacts-flattened"
 	// defaultLogsOutputDir is the default directory for downloaded workflow logs
 	defaultLogsOutputDir = ".github/aw/logs"
 )
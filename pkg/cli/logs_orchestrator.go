@@ -26,7 +26,6 @@ import (
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/envutil"
 	"github.com/github/gh-aw/pkg/logger"
-	"github.com/github/gh-aw/pkg/workflow"
 	"github.com/sourcegraph/conc/pool"
 )
 
@@ -40,8 +39,8 @@ func getMaxConcurrentDownloads() int {
 }
 
 // DownloadWorkflowLogs downloads and analyzes workflow logs with metrics
-func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, startDate, endDate, outputDir, engine, ref string, beforeRunID, afterRunID int64, repoOverride string, verbose bool, toolGraph bool, noStaged bool, firewallOnly bool, noFirewall bool, parse bool, jsonOutput bool, timeout int, summaryFile string, safeOutputType string) error {
-	logsOrchestratorLog.Printf("Starting workflow log download: workflow=%s, count=%d, startDate=%s, endDate=%s, outputDir=%s, summaryFile=%s, safeOutputType=%s", workflowName, count, startDate, endDate, outputDir, summaryFile, safeOutputType)
+func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, startDate, endDate, outputDir, engine, ref string, beforeRunID, afterRunID int64, repoOverride string, verbose bool, toolGraph bool, noStaged bool, firewallOnly bool, noFirewall bool, parse bool, jsonOutput bool, timeout int, summaryFile string, safeOutputType string, status string, refresh bool) error {
+	logsOrchestratorLog.Printf("Starting workflow log download: workflow=%s, count=%d, startDate=%s, endDate=%s, outputDir=%s, summaryFile=%s, safeOutputType=%s, refresh=%v", workflowName, count, startDate, endDate, outputDir, summaryFile, safeOutputType, refresh)
 
 	// Ensure .github/aw/logs/.gitignore exists on every invocation
 	if err := ensureLogsGitignore(); err != nil {
@@ -148,6 +147,7 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 			Limit:          batchSize,
 			StartDate:      startDate,
 			EndDate:        endDate,
+			Status:         status,
 			BeforeDate:     beforeDate,
 			Ref:            ref,
 			BeforeRunID:    beforeRunID,
@@ -188,7 +188,7 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 			chunk := runsRemaining[:chunkSize]
 			runsRemaining = runsRemaining[chunkSize:]
 
-			downloadResults := downloadRunArtifactsConcurrent(ctx, chunk, outputDir, verbose, remainingNeeded)
+			downloadResults := downloadRunArtifactsConcurrent(ctx, chunk, outputDir, verbose, remainingNeeded, refresh)
 
 			for _, result := range downloadResults {
 				if result.Skipped {
@@ -215,40 +215,16 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 					awInfo, awInfoErr = parseAwInfo(awInfoPath, verbose)
 				}
 
-				// Apply engine filtering if specified
-				if engine != "" {
-					// Check if the run's engine matches the filter
-					detectedEngine := extractEngineFromAwInfo(awInfoPath, verbose)
-
-					var engineMatches bool
-					if detectedEngine != nil {
-						// Get the engine ID to compare with the filter
-						registry := workflow.GetGlobalEngineRegistry()
-						for _, supportedEngine := range constants.AgenticEngines {
-							if testEngine, err := registry.GetEngine(supportedEngine); err == nil && testEngine == detectedEngine {
-								engineMatches = (supportedEngine == engine)
-								break
-							}
-						}
-					}
-
-					if !engineMatches {
-						if verbose {
-							engineName := "unknown"
-							if detectedEngine != nil {
-								// Try to get a readable name for the detected engine
-								registry := workflow.GetGlobalEngineRegistry()
-								for _, supportedEngine := range constants.AgenticEngines {
-									if testEngine, err := registry.GetEngine(supportedEngine); err == nil && testEngine == detectedEngine {
-										engineName = supportedEngine
-										break
-									}
-								}
-							}
-							fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping run %d: engine '%s' does not match filter '%s'", result.Run.DatabaseID, engineName, engine)))
+				// Apply engine filtering if specified, using the engine_id recorded in aw_info.json
+				if engine != "" && !matchesEngineFilter(awInfo, engine) {
+					if verbose {
+						engineName := "unknown"
+						if awInfo != nil && awInfo.EngineID != "" {
+							engineName = awInfo.EngineID
 						}
-						continue
+						fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping run %d: engine '%s' does not match filter '%s'", result.Run.DatabaseID, engineName, engine)))
 					}
+					continue
 				}
 
 				// Apply staged filtering if --no-staged flag is specified
@@ -504,8 +480,9 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 }
 
 // downloadRunArtifactsConcurrent downloads artifacts for multiple workflow runs concurrently
-func downloadRunArtifactsConcurrent(ctx context.Context, runs []WorkflowRun, outputDir string, verbose bool, maxRuns int) []DownloadResult {
-	logsOrchestratorLog.Printf("Starting concurrent artifact download: runs=%d, outputDir=%s, maxRuns=%d", len(runs), outputDir, maxRuns)
+// Runs with a valid cached summary are reused unless refresh is set
+func downloadRunArtifactsConcurrent(ctx context.Context, runs []WorkflowRun, outputDir string, verbose bool, maxRuns int, refresh bool) []DownloadResult {
+	logsOrchestratorLog.Printf("Starting concurrent artifact download: runs=%d, outputDir=%s, maxRuns=%d, refresh=%v", len(runs), outputDir, maxRuns, refresh)
 	if len(runs) == 0 {
 		return []DownloadResult{}
 	}
@@ -570,8 +547,13 @@ func downloadRunArtifactsConcurrent(ctx context.Context, runs []WorkflowRun, out
 			// Download artifacts and logs for this run
 			runOutputDir := filepath.Join(outputDir, fmt.Sprintf("run-%d", run.DatabaseID))
 
-			// Try to load cached summary first
-			if summary, ok := loadRunSummary(runOutputDir, verbose); ok {
+			// Try to load cached summary first, unless a refresh is requested
+			var summary *RunSummary
+			cached := false
+			if !refresh {
+				summary, cached = loadRunSummary(runOutputDir, verbose)
+			}
+			if cached {
 				// Valid cached summary exists, use it directly
 				result := DownloadResult{
 					Run:                     summary.Run,
@@ -597,7 +579,7 @@ func downloadRunArtifactsConcurrent(ctx context.Context, runs []WorkflowRun, out
 			}
 
 			// No cached summary or version mismatch - download and process
-			err := downloadRunArtifacts(run.DatabaseID, runOutputDir, verbose)
+			err := downloadRunArtifacts(run.DatabaseID, runOutputDir, verbose, refresh)
 
 			result := DownloadResult{
 				Run:      run,
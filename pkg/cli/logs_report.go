@@ -150,9 +150,23 @@ func buildLogsData(processedRuns []ProcessedRun, outputDir string, continuation
 	// Build runs data
 	// Initialize as empty slice to ensure JSON marshals to [] instead of null
 	runs := make([]RunData, 0, len(processedRuns))
+	priceTable := resolvePriceTable()
 	for _, pr := range processedRuns {
 		run := pr.Run
 
+		// Extract agent/engine ID and model from aw_info.json
+		agentID := ""
+		awInfoPath := filepath.Join(run.LogsPath, "aw_info.json")
+		info, _ := parseAwInfo(awInfoPath, false)
+		if info != nil {
+			agentID = info.EngineID
+		}
+
+		// Estimate the cost from the token usage when the engine logs did not report one
+		if estimate := estimateRunCost(info, run.TokenUsage, run.EstimatedCost, run.Duration, priceTable); estimate != nil {
+			run.EstimatedCost = estimate.EstimatedCost
+		}
+
 		if run.Duration > 0 {
 			totalDuration += run.Duration
 		}
@@ -165,13 +179,6 @@ func buildLogsData(processedRuns []ProcessedRun, outputDir string, continuation
 		totalMissingData += run.MissingDataCount
 		totalSafeItems += run.SafeItemsCount
 
-		// Extract agent/engine ID from aw_info.json
-		agentID := ""
-		awInfoPath := filepath.Join(run.LogsPath, "aw_info.json")
-		if info, err := parseAwInfo(awInfoPath, false); err == nil && info != nil {
-			agentID = info.EngineID
-		}
-
 		runData := RunData{
 			DatabaseID:       run.DatabaseID,
 			Number:           run.Number,
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var manifestLog = logger.New("cli:manifest_command")
+
+// workflowManifestPath is where the manifest of installed workflows is written by default
+var workflowManifestPath = filepath.Join(".github", "aw", "workflows-manifest.json")
+
+// WorkflowManifest lists the workflows installed from upstream repositories
+type WorkflowManifest struct {
+	Workflows []WorkflowManifestEntry `json:"workflows"`
+}
+
+// WorkflowManifestEntry records the provenance of one installed workflow, taken from its source field
+type WorkflowManifestEntry struct {
+	Name       string `json:"name"`          // Workflow ID, e.g. "repo-assist"
+	Path       string `json:"path"`          // Local path of the workflow markdown file
+	Source     string `json:"source"`        // Raw source field, e.g. "owner/repo/workflows/repo-assist.md@<sha>"
+	Repo       string `json:"repo"`          // Upstream repository, e.g. "owner/repo"
+	SourcePath string `json:"source_path"`   // Path of the workflow in the upstream repository
+	Ref        string `json:"ref,omitempty"` // Recorded ref: a commit SHA, release tag or branch
+	SHA        string `json:"sha,omitempty"` // Recorded commit SHA, when the ref pins one
+}
+
+// NewManifestCommand creates the manifest command
+func NewManifestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Write a manifest of workflows installed from upstream repositories",
+		Long: `Write a manifest of every workflow that records its upstream provenance in a 'source' field.
+
+Workflows added with 'gh aw add' record the repository, path and commit they were installed from.
+The manifest collects this provenance in one JSON file so that automation can track installed
+workflows. Run 'gh aw update --check' to compare the recorded commits with upstream, and
+'gh aw update' to apply updates.
+
+The manifest is written to .github/aw/workflows-manifest.json unless --output or --json is used.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` manifest                           # Write .github/aw/workflows-manifest.json
+  ` + string(constants.CLIExtensionPrefix) + ` manifest --json                    # Print the manifest instead of writing it
+  ` + string(constants.CLIExtensionPrefix) + ` manifest -o manifest.json          # Write the manifest to a custom path
+  ` + string(constants.CLIExtensionPrefix) + ` manifest --dir custom/workflows    # Read workflows from a custom directory`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workflowDir, _ := cmd.Flags().GetString("dir")
+			outputPath, _ := cmd.Flags().GetString("output")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			return RunManifest(workflowDir, outputPath, jsonOutput, verbose)
+		},
+	}
+
+	cmd.Flags().StringP("dir", "d", "", "Workflow directory (default: .github/workflows)")
+	cmd.Flags().StringP("output", "o", "", "Path to write the manifest to (default: .github/aw/workflows-manifest.json)")
+	addJSONFlag(cmd)
+
+	RegisterDirFlagCompletion(cmd, "dir")
+
+	return cmd
+}
+
+// RunManifest builds the manifest of installed workflows and writes or prints it
+func RunManifest(workflowsDir, outputPath string, jsonOutput, verbose bool) error {
+	manifestLog.Printf("Running manifest: dir=%s, output=%s, json=%v", workflowsDir, outputPath, jsonOutput)
+
+	if workflowsDir == "" {
+		workflowsDir = getWorkflowsDir()
+	}
+
+	manifest, err := buildWorkflowManifest(workflowsDir, nil, verbose)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal workflow manifest: %w", err)
+	}
+	data = append(data, '\n')
+
+	if jsonOutput {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if outputPath == "" {
+		outputPath = workflowManifestPath
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write workflow manifest: %w", err)
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Wrote manifest of %d workflow(s) to %s", len(manifest.Workflows), outputPath)))
+	return nil
+}
+
+// buildWorkflowManifest collects the provenance recorded in the source field of every workflow
+// in workflowsDir, optionally limited to filterNames. Workflows with a malformed source field are
+// skipped with a warning.
+func buildWorkflowManifest(workflowsDir string, filterNames []string, verbose bool) (*WorkflowManifest, error) {
+	workflows, err := findWorkflowsWithSource(workflowsDir, filterNames, verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &WorkflowManifest{Workflows: []WorkflowManifestEntry{}}
+	for _, wf := range workflows {
+		sourceSpec, err := parseSourceSpec(wf.SourceSpec)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Skipping %s: %v", wf.Name, err)))
+			continue
+		}
+
+		entry := WorkflowManifestEntry{
+			Name:       wf.Name,
+			Path:       filepath.ToSlash(wf.Path),
+			Source:     wf.SourceSpec,
+			Repo:       sourceSpec.Repo,
+			SourcePath: sourceSpec.Path,
+			Ref:        sourceSpec.Ref,
+		}
+		if IsCommitSHA(sourceSpec.Ref) {
+			entry.SHA = sourceSpec.Ref
+		}
+		manifest.Workflows = append(manifest.Workflows, entry)
+	}
+
+	sort.Slice(manifest.Workflows, func(i, j int) bool {
+		return manifest.Workflows[i].Name < manifest.Workflows[j].Name
+	})
+
+	manifestLog.Printf("Built manifest with %d workflow(s)", len(manifest.Workflows))
+	return manifest, nil
+}
@@ -0,0 +1,71 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifestTestWorkflow(t *testing.T, dir, name, source string) {
+	t.Helper()
+	frontmatter := "on: push\n"
+	if source != "" {
+		frontmatter += "source: " + source + "\n"
+	}
+	content := "---\n" + frontmatter + "---\n\n# " + name + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".md"), []byte(content), 0644))
+}
+
+func TestBuildWorkflowManifest(t *testing.T) {
+	workflowsDir := testutil.TempDir(t, "manifest-test")
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	writeManifestTestWorkflow(t, workflowsDir, "triage", "githubnext/agentics/workflows/triage.md@"+sha)
+	writeManifestTestWorkflow(t, workflowsDir, "ci-doctor", "githubnext/agentics/workflows/ci-doctor.md@v1.2.0")
+	writeManifestTestWorkflow(t, workflowsDir, "local", "")
+
+	manifest, err := buildWorkflowManifest(workflowsDir, nil, false)
+	require.NoError(t, err, "manifest should build")
+	require.Len(t, manifest.Workflows, 2, "only workflows with a source field should be listed")
+
+	assert.Equal(t, "ci-doctor", manifest.Workflows[0].Name, "workflows should be sorted by name")
+	assert.Equal(t, "v1.2.0", manifest.Workflows[0].Ref, "tag ref should be recorded")
+	assert.Empty(t, manifest.Workflows[0].SHA, "tag ref should not be recorded as a SHA")
+
+	triage := manifest.Workflows[1]
+	assert.Equal(t, "triage", triage.Name)
+	assert.Equal(t, "githubnext/agentics", triage.Repo, "repo should be parsed from the source")
+	assert.Equal(t, "workflows/triage.md", triage.SourcePath, "source path should be parsed from the source")
+	assert.Equal(t, sha, triage.SHA, "commit SHA should be recorded")
+	assert.Equal(t, filepath.ToSlash(filepath.Join(workflowsDir, "triage.md")), triage.Path, "local path should be recorded")
+
+	filtered, err := buildWorkflowManifest(workflowsDir, []string{"triage.md"}, false)
+	require.NoError(t, err, "filtered manifest should build")
+	require.Len(t, filtered.Workflows, 1, "filter should limit the manifest")
+	assert.Equal(t, "triage", filtered.Workflows[0].Name)
+}
+
+func TestRunManifestWritesFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "manifest-write-test")
+	workflowsDir := filepath.Join(tmpDir, "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755))
+	writeManifestTestWorkflow(t, workflowsDir, "triage", "githubnext/agentics/workflows/triage.md@main")
+
+	outputPath := filepath.Join(tmpDir, "aw", "workflows-manifest.json")
+	require.NoError(t, RunManifest(workflowsDir, outputPath, false, false), "manifest should be written")
+
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err, "manifest file should exist")
+
+	var manifest WorkflowManifest
+	require.NoError(t, json.Unmarshal(data, &manifest), "manifest should be valid JSON")
+	require.Len(t, manifest.Workflows, 1)
+	assert.Equal(t, "main", manifest.Workflows[0].Ref, "branch ref should be recorded")
+	assert.Empty(t, manifest.Workflows[0].SHA, "branch ref should not be recorded as a SHA")
+}
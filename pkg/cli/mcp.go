@@ -20,10 +20,11 @@ standardized servers. This command provides tools for inspecting and managing
 MCP server configurations in your agentic workflows.
 
 Available subcommands:
-  • list       - List MCP servers defined in agentic workflows
-  • list-tools - List available tools for a specific MCP server
-  • inspect    - Inspect MCP servers and list available tools, resources, and roots
-  • add        - Add an MCP tool to an agentic workflow
+  • list        - List MCP servers defined in agentic workflows
+  • list-tools  - List available tools for a specific MCP server
+  • inspect     - Inspect MCP servers and list available tools, resources, and roots
+  • add         - Add an MCP tool to an agentic workflow
+  • clear-cache - Clear the local MCP registry lookup cache
 
 Examples:
   gh aw mcp list                              # List all workflows with MCP servers
@@ -40,6 +41,7 @@ Examples:
 	cmd.AddCommand(NewMCPListSubcommand())
 	cmd.AddCommand(NewMCPListToolsSubcommand())
 	cmd.AddCommand(NewMCPInspectSubcommand())
+	cmd.AddCommand(NewMCPClearCacheSubcommand())
 
 	return cmd
 }
@@ -18,8 +18,8 @@ import (
 var mcpAddLog = logger.New("cli:mcp_add")
 
 // AddMCPTool adds an MCP tool to an agentic workflow
-func AddMCPTool(workflowFile string, mcpServerID string, registryURL string, transportType string, customToolID string, verbose bool) error {
-	mcpAddLog.Printf("Adding MCP tool: serverID=%s, registryURL=%s, transport=%s", mcpServerID, registryURL, transportType)
+func AddMCPTool(workflowFile string, mcpServerID string, registryURL string, transportType string, customToolID string, noCache bool, verbose bool) error {
+	mcpAddLog.Printf("Adding MCP tool: serverID=%s, registryURL=%s, transport=%s, noCache=%v", mcpServerID, registryURL, transportType, noCache)
 
 	// Resolve the workflow file path
 	workflowPath, err := ResolveWorkflowPath(workflowFile)
@@ -35,6 +35,9 @@ func AddMCPTool(workflowFile string, mcpServerID string, registryURL string, tra
 
 	// Create registry client
 	registryClient := NewMCPRegistryClient(registryURL)
+	if noCache {
+		registryClient.DisableCache()
+	}
 
 	// Search for the MCP server in the registry
 	if verbose {
@@ -305,6 +308,7 @@ func NewMCPAddSubcommand() *cobra.Command {
 	var registryURL string
 	var transportType string
 	var customToolID string
+	var noCache bool
 
 	cmd := &cobra.Command{
 		Use:   "add [workflow] [server]",
@@ -326,6 +330,7 @@ Examples:
   gh aw mcp add weekly-research makenotion/notion-mcp-server --transport stdio  # Prefer stdio transport
   gh aw mcp add weekly-research makenotion/notion-mcp-server --registry https://custom.registry.com/v1  # Use custom registry
   gh aw mcp add weekly-research makenotion/notion-mcp-server --tool-id my-notion  # Use custom tool ID
+  gh aw mcp add weekly-research makenotion/notion-mcp-server --no-cache  # Bypass the registry lookup cache
 
 The command will:
 - Search the MCP registry for the specified server
@@ -333,6 +338,9 @@ The command will:
 - Add the MCP tool configuration to the workflow's frontmatter
 - Automatically compile the workflow to generate the .lock.yml file
 
+Registry lookups are cached locally for 24 hours. Use --no-cache to query the registry
+directly, or 'gh aw mcp clear-cache' to remove the cache.
+
 Registry URL defaults to: https://api.mcp.github.com/v0.1`,
 		Args: cobra.RangeArgs(0, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -344,7 +352,7 @@ Registry URL defaults to: https://api.mcp.github.com/v0.1`,
 				if registryURL == "" {
 					registryURL = string(constants.DefaultMCPRegistryURL)
 				}
-				return listAvailableServers(registryURL, verbose)
+				return listAvailableServers(registryURL, noCache, verbose)
 			}
 
 			// If only workflow ID/file is provided, show error (need both workflow and server)
@@ -356,13 +364,14 @@ Registry URL defaults to: https://api.mcp.github.com/v0.1`,
 			workflowFile := args[0]
 			mcpServerID := args[1]
 
-			return AddMCPTool(workflowFile, mcpServerID, registryURL, transportType, customToolID, verbose)
+			return AddMCPTool(workflowFile, mcpServerID, registryURL, transportType, customToolID, noCache, verbose)
 		},
 	}
 
 	cmd.Flags().StringVar(&registryURL, "registry", "", "MCP registry URL (default: https://api.mcp.github.com/v0.1)")
 	cmd.Flags().StringVar(&transportType, "transport", "", "Preferred transport type (stdio, http, docker)")
 	cmd.Flags().StringVar(&customToolID, "tool-id", "", "Custom tool ID to use in the workflow (default: uses server ID)")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Query the MCP registry directly instead of using the local lookup cache")
 
 	return cmd
 }
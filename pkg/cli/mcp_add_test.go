@@ -117,7 +117,7 @@ This is a test workflow.
 	defer registryServer.Close()
 
 	// Test adding MCP tool
-	err = AddMCPTool("test-workflow", "notion", registryServer.URL, "", "", false)
+	err = AddMCPTool("test-workflow", "notion", registryServer.URL, "", "", false, false)
 	if err != nil {
 		t.Fatalf("AddMCPTool failed: %v", err)
 	}
@@ -184,7 +184,7 @@ func TestAddMCPTool_WorkflowNotFound(t *testing.T) {
 	defer registryServer.Close()
 
 	// Test with nonexistent workflow
-	err = AddMCPTool("nonexistent-workflow", "notion", registryServer.URL, "", "", false)
+	err = AddMCPTool("nonexistent-workflow", "notion", registryServer.URL, "", "", false, false)
 	if err == nil {
 		t.Fatal("Expected error for nonexistent workflow, got nil")
 	}
@@ -291,7 +291,7 @@ This is a test workflow.
 	defer registryServer.Close()
 
 	// Test adding tool that already exists (search for the full server name)
-	err = AddMCPTool("test-workflow", "io.github.makenotion/notion-mcp-server", registryServer.URL, "", "", false)
+	err = AddMCPTool("test-workflow", "io.github.makenotion/notion-mcp-server", registryServer.URL, "", "", false, false)
 	if err == nil {
 		t.Fatal("Expected error for existing tool, got nil")
 	}
@@ -394,7 +394,7 @@ This is a test workflow.
 
 	// Test adding tool with custom ID
 	customToolID := "my-notion"
-	err = AddMCPTool("test-workflow", "notion", registryServer.URL, "", customToolID, false)
+	err = AddMCPTool("test-workflow", "notion", registryServer.URL, "", customToolID, false, false)
 	if err != nil {
 		t.Fatalf("AddMCPTool failed: %v", err)
 	}
@@ -545,7 +545,7 @@ func TestListAvailableServers(t *testing.T) {
 	defer testServer.Close()
 
 	// Test listing servers
-	err := listAvailableServers(testServer.URL, false)
+	err := listAvailableServers(testServer.URL, false, false)
 	if err != nil {
 		t.Errorf("listAvailableServers failed: %v", err)
 	}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+var mcpClearCacheLog = logger.New("cli:mcp_clear_cache")
+
+// ClearMCPRegistryCache removes the local MCP registry lookup cache
+func ClearMCPRegistryCache(verbose bool) error {
+	cache := newMCPRegistryCache()
+	if cache == nil {
+		return fmt.Errorf("could not determine the gh-aw config directory for the MCP registry cache")
+	}
+	mcpClearCacheLog.Printf("Clearing MCP registry cache: %s", cache.path)
+
+	if err := cache.Clear(); err != nil {
+		return err
+	}
+
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Removed "+cache.path))
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Cleared MCP registry cache"))
+	return nil
+}
+
+// NewMCPClearCacheSubcommand creates the mcp clear-cache subcommand
+func NewMCPClearCacheSubcommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear-cache",
+		Short: "Clear the local MCP registry lookup cache",
+		Long: `Clear the local MCP registry lookup cache.
+
+Registry lookups made by 'gh aw mcp add' are cached under the gh-aw config directory
+for 24 hours, keyed by registry URL and server name. This command removes the cache so
+the next lookup queries the registry again.
+
+Examples:
+  gh aw mcp clear-cache              # Remove all cached registry lookups`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			return ClearMCPRegistryCache(verbose)
+		},
+	}
+
+	return cmd
+}
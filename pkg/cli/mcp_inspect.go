@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/github/gh-aw/pkg/console"
@@ -17,10 +18,12 @@ import (
 
 var mcpInspectLog = logger.New("cli:mcp_inspect")
 
-// InspectWorkflowMCP inspects MCP servers used by a workflow and lists available tools, resources, and roots
-func InspectWorkflowMCP(workflowFile string, serverFilter string, toolFilter string, verbose bool, useActionsSecrets bool) error {
-	mcpInspectLog.Printf("Inspecting workflow MCP: workflow=%s, serverFilter=%s, toolFilter=%s",
-		workflowFile, serverFilter, toolFilter)
+// InspectWorkflowMCP inspects MCP servers used by a workflow and lists available tools, resources, and roots.
+// When validateAllowed is set, each server's allowed list is checked against the tools it advertises
+// and an error is returned if any server lists unknown tools or cannot be reached.
+func InspectWorkflowMCP(workflowFile string, serverFilter string, toolFilter string, verbose bool, useActionsSecrets bool, validateAllowed bool) error {
+	mcpInspectLog.Printf("Inspecting workflow MCP: workflow=%s, serverFilter=%s, toolFilter=%s, validateAllowed=%v",
+		workflowFile, serverFilter, toolFilter, validateAllowed)
 
 	workflowsDir := getWorkflowsDir()
 
@@ -142,11 +145,13 @@ func InspectWorkflowMCP(workflowFile string, serverFilter string, toolFilter str
 	}
 	fmt.Fprintln(os.Stderr)
 
+	var failedServers []string
 	for i, config := range mcpConfigs {
 		if i > 0 {
 			fmt.Fprintln(os.Stderr)
 		}
-		if err := inspectMCPServer(config, toolFilter, verbose, useActionsSecrets); err != nil {
+		if err := inspectMCPServer(config, toolFilter, verbose, useActionsSecrets, validateAllowed); err != nil {
+			failedServers = append(failedServers, config.Name)
 			fmt.Fprintln(os.Stderr, console.FormatError(console.CompilerError{
 				Type:    "error",
 				Message: fmt.Sprintf("Failed to inspect MCP server '%s': %v", config.Name, err),
@@ -154,6 +159,10 @@ func InspectWorkflowMCP(workflowFile string, serverFilter string, toolFilter str
 		}
 	}
 
+	if validateAllowed && len(failedServers) > 0 {
+		return fmt.Errorf("allowed tool validation failed for MCP server(s): %s", strings.Join(failedServers, ", "))
+	}
+
 	return nil
 }
 
@@ -164,6 +173,7 @@ func NewMCPInspectSubcommand() *cobra.Command {
 	var toolFilter string
 	var spawnInspector bool
 	var checkSecrets bool
+	var validateAllowed bool
 
 	cmd := &cobra.Command{
 		Use:   "inspect [workflow]",
@@ -187,6 +197,7 @@ Examples:
   gh aw mcp inspect weekly-research -v # Verbose output with detailed connection info
   gh aw mcp inspect weekly-research --inspector  # Launch @modelcontextprotocol/inspector
   gh aw mcp inspect weekly-research --check-secrets  # Check GitHub Actions secrets
+  gh aw mcp inspect weekly-research --validate-allowed  # Fail if 'allowed' lists unknown tools
 
 The command will:
 - Parse the workflow file to extract MCP server configurations
@@ -194,6 +205,7 @@ The command will:
 - Automatically start and inspect safe-inputs server if present
 - Query available tools, resources, and roots
 - Validate required secrets are available  
+- Optionally validate that every 'allowed' tool is advertised by its server (--validate-allowed)
 - Display results in formatted tables with error details`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -225,7 +237,7 @@ The command will:
 				return spawnMCPInspector(workflowFile, serverFilter, verbose)
 			}
 
-			return InspectWorkflowMCP(workflowFile, serverFilter, toolFilter, verbose, checkSecrets)
+			return InspectWorkflowMCP(workflowFile, serverFilter, toolFilter, verbose, checkSecrets, validateAllowed)
 		},
 	}
 
@@ -233,6 +245,7 @@ The command will:
 	cmd.Flags().StringVar(&toolFilter, "tool", "", "Show detailed information about a specific tool (requires --server)")
 	cmd.Flags().BoolVar(&spawnInspector, "inspector", false, "Launch the official @modelcontextprotocol/inspector tool")
 	cmd.Flags().BoolVar(&checkSecrets, "check-secrets", false, "Check GitHub Actions repository secrets for missing secrets")
+	cmd.Flags().BoolVar(&validateAllowed, "validate-allowed", false, "Connect to each MCP server and fail if its 'allowed' list names tools the server does not advertise")
 
 	// Register completions for mcp inspect command
 	cmd.ValidArgsFunction = CompleteWorkflowNames
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var mcpInspectAllowedLog = logger.New("cli:mcp_inspect_allowed")
+
+// findUnknownAllowedTools returns the entries of the server's allowed list that do not
+// match any tool advertised by the server's tools/list response, in allowlist order.
+// The "*" wildcard always matches and is never reported; patterns such as "issue_*"
+// are reported only when they match no advertised tool.
+func findUnknownAllowedTools(info *parser.MCPServerInfo) []string {
+	var unknown []string
+	for _, allowed := range info.Config.Allowed {
+		if allowed == "*" {
+			continue
+		}
+		matchesTool := slices.ContainsFunc(info.Tools, func(tool *mcp.Tool) bool {
+			return parser.MatchToolPattern(allowed, tool.Name)
+		})
+		if !matchesTool {
+			unknown = append(unknown, allowed)
+		}
+	}
+
+	mcpInspectAllowedLog.Printf("Validated %d allowed tools for server %s against %d advertised tools: %d unknown",
+		len(info.Config.Allowed), info.Config.Name, len(info.Tools), len(unknown))
+	return unknown
+}
+
+// validateAllowedTools checks that every tool in the server's allowed list is advertised by
+// the server and returns an error naming each unknown tool with close matches when available.
+func validateAllowedTools(info *parser.MCPServerInfo) error {
+	unknown := findUnknownAllowedTools(info)
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	toolNames := make([]string, 0, len(info.Tools))
+	for _, tool := range info.Tools {
+		toolNames = append(toolNames, tool.Name)
+	}
+
+	descriptions := make([]string, 0, len(unknown))
+	for _, name := range unknown {
+		if matches := parser.FindClosestMatches(name, toolNames, 3); len(matches) > 0 {
+			descriptions = append(descriptions, fmt.Sprintf("%s (did you mean: %s?)", name, strings.Join(matches, ", ")))
+		} else {
+			descriptions = append(descriptions, name)
+		}
+	}
+
+	return fmt.Errorf("allowed list for MCP server '%s' contains %d tool(s) not advertised by the server: %s",
+		info.Config.Name, len(unknown), strings.Join(descriptions, "; "))
+}
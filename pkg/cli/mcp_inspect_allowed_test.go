@@ -0,0 +1,117 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockMCPServer starts an HTTP MCP server that advertises the given tools in its tools/list response
+func newMockMCPServer(t *testing.T, toolNames ...string) *httptest.Server {
+	t.Helper()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "mock-server", Version: "1.0.0"}, nil)
+	for _, name := range toolNames {
+		mcp.AddTool(server, &mcp.Tool{Name: name, Description: "mock tool " + name},
+			func(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+				return &mcp.CallToolResult{}, nil, nil
+			})
+	}
+
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil)
+	httpServer := httptest.NewServer(handler)
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func TestFindUnknownAllowedTools(t *testing.T) {
+	tools := []*mcp.Tool{{Name: "create_issue"}, {Name: "get_issue"}}
+
+	tests := []struct {
+		name     string
+		allowed  []string
+		expected []string
+	}{
+		{name: "no allowed list", allowed: nil, expected: nil},
+		{name: "all allowed tools exist", allowed: []string{"create_issue", "get_issue"}, expected: nil},
+		{name: "wildcard", allowed: []string{"*"}, expected: nil},
+		{name: "typo in allowed tool", allowed: []string{"issue_create", "get_issue"}, expected: []string{"issue_create"}},
+		{name: "multiple unknown tools keep order", allowed: []string{"b_tool", "create_issue", "a_tool"}, expected: []string{"b_tool", "a_tool"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &parser.MCPServerInfo{
+				Config: parser.MCPServerConfig{Name: "github", Allowed: tt.allowed},
+				Tools:  tools,
+			}
+			assert.Equal(t, tt.expected, findUnknownAllowedTools(info), "unexpected unknown allowed tools")
+		})
+	}
+}
+
+func TestValidateAllowedToolsSuggestsCloseMatches(t *testing.T) {
+	info := &parser.MCPServerInfo{
+		Config: parser.MCPServerConfig{Name: "github", Allowed: []string{"create_isue", "delete_everything"}},
+		Tools:  []*mcp.Tool{{Name: "create_issue"}, {Name: "get_issue"}},
+	}
+
+	err := validateAllowedTools(info)
+	require.Error(t, err, "unknown allowed tools should produce an error")
+	assert.Contains(t, err.Error(), "'github'", "error should name the server")
+	assert.Contains(t, err.Error(), "create_isue (did you mean: create_issue?)", "error should suggest the closest tool")
+	assert.Contains(t, err.Error(), "delete_everything", "error should list every unknown tool")
+}
+
+func TestInspectMCPServerValidateAllowedWithMockServer(t *testing.T) {
+	httpServer := newMockMCPServer(t, "create_issue", "list_issues")
+
+	tests := []struct {
+		name            string
+		allowed         []string
+		validateAllowed bool
+		expectError     bool
+	}{
+		{name: "known tools pass validation", allowed: []string{"create_issue", "list_issues"}, validateAllowed: true},
+		{name: "typo fails validation", allowed: []string{"issue_create"}, validateAllowed: true, expectError: true},
+		{name: "typo ignored without flag", allowed: []string{"issue_create"}, validateAllowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := parser.MCPServerConfig{
+				BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "http", URL: httpServer.URL},
+				Name:                "mock",
+				Allowed:             tt.allowed,
+			}
+
+			err := inspectMCPServer(config, "", false, false, tt.validateAllowed)
+			if tt.expectError {
+				require.Error(t, err, "expected allowed tool validation to fail")
+				assert.Contains(t, err.Error(), "issue_create", "error should name the unknown tool")
+			} else {
+				assert.NoError(t, err, "expected inspection to succeed")
+			}
+		})
+	}
+}
+
+func TestInspectMCPServerValidateAllowedConnectionFailure(t *testing.T) {
+	config := parser.MCPServerConfig{
+		BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "unsupported"},
+		Name:                "broken",
+		Allowed:             []string{"create_issue"},
+	}
+
+	require.Error(t, inspectMCPServer(config, "", false, false, true), "unreachable server should fail validation")
+	assert.NoError(t, inspectMCPServer(config, "", false, false, false), "unreachable server should not fail plain inspection")
+}
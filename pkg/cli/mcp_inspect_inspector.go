@@ -119,8 +119,8 @@ func spawnMCPInspector(workflowFile string, serverFilter string, verbose bool) e
 					// Set environment variables
 					cmd.Env = os.Environ()
 					for key, value := range config.Env {
-						// Resolve environment variable references
-						resolvedValue := os.ExpandEnv(value)
+						// Resolve environment variable references, including ${VAR:-default} fallbacks
+						resolvedValue := parser.ExpandEnvWithDefaults(value, os.LookupEnv)
 						cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, resolvedValue))
 					}
 
@@ -170,7 +170,7 @@ func spawnMCPInspector(workflowFile string, serverFilter string, verbose bool) e
 					fmt.Fprintf(os.Stderr, "  URL: %s\n", config.URL)
 				}
 				if len(config.Env) > 0 {
-					fmt.Fprintf(os.Stderr, "  Environment Variables: %v\n", config.Env)
+					fmt.Fprintf(os.Stderr, "  Environment Variables: %v\n", workflow.RedactMCPSecretValues(config.Env))
 				}
 			}
 			fmt.Fprintln(os.Stderr)
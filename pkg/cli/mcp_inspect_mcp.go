@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
-	"slices"
 	"strings"
 	"time"
 
@@ -48,8 +47,10 @@ func (h *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error
 	return h.base.RoundTrip(reqCopy)
 }
 
-// inspectMCPServer connects to an MCP server and queries its capabilities
-func inspectMCPServer(config parser.MCPServerConfig, toolFilter string, verbose bool, useActionsSecrets bool) error {
+// inspectMCPServer connects to an MCP server and queries its capabilities.
+// When validateAllowed is set, an error is returned if the server cannot be reached or
+// its allowed list names tools the server does not advertise.
+func inspectMCPServer(config parser.MCPServerConfig, toolFilter string, verbose bool, useActionsSecrets bool, validateAllowed bool) error {
 	mcpInspectServerLog.Printf("Inspecting MCP server: name=%s, type=%s", config.Name, config.Type)
 	fmt.Fprintf(os.Stderr, "%s %s (%s)\n",
 		console.FormatCommandMessage(config.Name),
@@ -76,6 +77,9 @@ func inspectMCPServer(config parser.MCPServerConfig, toolFilter string, verbose
 		for _, line := range errorBox {
 			fmt.Fprintln(os.Stderr, line)
 		}
+		if validateAllowed {
+			return fmt.Errorf("cannot validate allowed tools: %w", err)
+		}
 		return nil // Don't return error, just show connection failure
 	}
 
@@ -88,6 +92,13 @@ func inspectMCPServer(config parser.MCPServerConfig, toolFilter string, verbose
 	// Display server capabilities
 	displayServerCapabilities(info, toolFilter)
 
+	if validateAllowed {
+		if err := validateAllowedTools(info); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("All allowed tools are advertised by the server"))
+	}
+
 	return nil
 }
 
@@ -156,8 +167,8 @@ func connectStdioMCPServer(ctx context.Context, config parser.MCPServerConfig, v
 	// Set environment variables
 	cmd.Env = os.Environ()
 	for key, value := range config.Env {
-		// Resolve environment variable references
-		resolvedValue := os.ExpandEnv(value)
+		// Resolve environment variable references, including ${VAR:-default} fallbacks
+		resolvedValue := parser.ExpandEnvWithDefaults(value, os.LookupEnv)
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, resolvedValue))
 	}
 
@@ -452,10 +463,7 @@ func displayDetailedToolInfo(info *parser.MCPServerInfo, toolName string) {
 	}
 
 	// Check if tool is allowed
-	isAllowed := len(info.Config.Allowed) == 0 // Default to allowed if no allowlist
-	if slices.Contains(info.Config.Allowed, toolName) {
-		isAllowed = true
-	}
+	isAllowed := parser.IsToolAllowed(info.Config.Allowed, toolName)
 
 	fmt.Fprintf(os.Stderr, "\n%s\n", console.FormatSectionHeader("🛠️  Tool Details: "+foundTool.Name))
 
@@ -539,16 +547,10 @@ func displayDetailedToolInfo(info *parser.MCPServerInfo, toolName string) {
 
 // displayToolAllowanceHint shows helpful information about how to allow tools in workflow frontmatter
 func displayToolAllowanceHint(info *parser.MCPServerInfo) {
-	// Create a map for quick lookup of allowed tools
-	allowedMap := make(map[string]bool)
-	for _, allowed := range info.Config.Allowed {
-		allowedMap[allowed] = true
-	}
-
 	// Count blocked tools and collect their names
 	var blockedTools []string
 	for _, tool := range info.Tools {
-		if len(info.Config.Allowed) > 0 && !allowedMap[tool.Name] {
+		if !parser.IsToolAllowed(info.Config.Allowed, tool.Name) {
 			blockedTools = append(blockedTools, tool.Name)
 		}
 	}
@@ -130,5 +130,5 @@ func spawnSafeInputsInspector(workflowFile string, verbose bool) error {
 	}
 
 	// Inspect the safe-inputs MCP server using the Go SDK (like other MCP servers)
-	return inspectMCPServer(safeInputsMCPConfig, "", verbose, false)
+	return inspectMCPServer(safeInputsMCPConfig, "", verbose, false, false)
 }
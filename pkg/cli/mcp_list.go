@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,9 +17,33 @@ import (
 
 var mcpListLog = logger.New("cli:mcp_list")
 
+// MCPServerListItem describes a resolved MCP server for `mcp list --json` output
+type MCPServerListItem struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Command      string   `json:"command,omitempty"`
+	Args         []string `json:"args,omitempty"`
+	Container    string   `json:"container,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	AllowedTools []string `json:"allowed_tools,omitempty"`
+}
+
+// newMCPServerListItem converts a resolved MCP server configuration to a list item
+func newMCPServerListItem(config parser.MCPServerConfig) MCPServerListItem {
+	return MCPServerListItem{
+		Name:         config.Name,
+		Type:         config.Type,
+		Command:      config.Command,
+		Args:         config.Args,
+		Container:    config.Container,
+		URL:          config.URL,
+		AllowedTools: config.Allowed,
+	}
+}
+
 // ListWorkflowMCP lists MCP servers defined in a workflow
-func ListWorkflowMCP(workflowFile string, verbose bool) error {
-	mcpListLog.Printf("Listing MCP servers: workflow=%s, verbose=%t", workflowFile, verbose)
+func ListWorkflowMCP(workflowFile string, verbose bool, jsonOutput bool) error {
+	mcpListLog.Printf("Listing MCP servers: workflow=%s, verbose=%t, json=%t", workflowFile, verbose, jsonOutput)
 	// Determine the workflow directory and file
 	workflowsDir := ".github/workflows"
 	var workflowPath string
@@ -35,90 +60,76 @@ func ListWorkflowMCP(workflowFile string, verbose bool) error {
 	} else {
 		// No specific workflow file provided, list all workflows with MCP servers
 		mcpListLog.Print("No workflow file specified, listing all workflows with MCP servers")
-		return listWorkflowsWithMCPServers(workflowsDir, verbose)
+		return listWorkflowsWithMCPServers(workflowsDir, verbose, jsonOutput)
 	}
 
-	// Parse the specific workflow file and extract MCP configurations
-	frontmatter, mcpConfigs, err := loadWorkflowMCPConfigs(workflowPath, "")
+	// Resolve the MCP servers the workflow ends up with after defaults, imports and merges
+	frontmatter, mcpConfigs, err := loadResolvedWorkflowMCPConfigs(workflowPath)
 	if err != nil {
 		mcpListLog.Printf("Failed to load MCP configs from workflow: %v", err)
 		return err
 	}
 
 	mcpListLog.Printf("Found %d MCP servers in workflow", len(mcpConfigs))
+	if jsonOutput {
+		items := make([]MCPServerListItem, 0, len(mcpConfigs))
+		for _, config := range mcpConfigs {
+			items = append(items, newMCPServerListItem(config))
+		}
+		jsonBytes, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
 	if len(mcpConfigs) == 0 {
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No MCP servers found in workflow"))
 		return nil
 	}
 
 	// Check if workflow has network access configured
-	hasNetworkAccess := checkNetworkAccess(frontmatter.Frontmatter)
+	hasNetworkAccess := checkNetworkAccess(frontmatter)
 
 	// Display the MCP servers
+	headers := []string{"Server Name", "Type", "Command/URL", "Allowed Tools"}
 	if verbose {
-		// Create detailed table for verbose mode
-		headers := []string{"Server Name", "Type", "Status", "Tools Count", "Network Access", "Command/URL"}
-		rows := make([][]string, 0, len(mcpConfigs))
+		headers = append(headers, "Status", "Tools Count", "Network Access")
+	}
+	rows := make([][]string, 0, len(mcpConfigs))
 
-		for _, config := range mcpConfigs {
-			status := determineConfigStatus(config)
-			toolsCount := formatToolsCount(config.Allowed)
-			networkAccess := formatNetworkAccess(hasNetworkAccess)
-
-			commandOrURL := ""
-			if config.Command != "" {
-				commandOrURL = config.Command
-			} else if config.URL != "" {
-				commandOrURL = config.URL
-			} else if config.Container != "" {
-				commandOrURL = config.Container
-			}
+	for _, config := range mcpConfigs {
+		commandOrURL := formatCommandOrURL(config)
+		allowedTools := formatAllowedTools(config.Allowed)
+		if !verbose {
 			// Truncate if too long
 			if len(commandOrURL) > 40 {
 				commandOrURL = commandOrURL[:37] + "..."
 			}
-
-			rows = append(rows, []string{
-				config.Name,
-				config.Type,
-				status,
-				toolsCount,
-				networkAccess,
-				commandOrURL,
-			})
+			if len(allowedTools) > 50 {
+				allowedTools = allowedTools[:47] + "..."
+			}
 		}
 
-		tableConfig := console.TableConfig{
-			Title:   "MCP servers in " + filepath.Base(workflowPath),
-			Headers: headers,
-			Rows:    rows,
+		row := []string{
+			config.Name,
+			config.Type,
+			commandOrURL,
+			allowedTools,
 		}
-		fmt.Fprint(os.Stderr, console.RenderTable(tableConfig))
-	} else {
-		// Simple table for basic mode
-		headers := []string{"Server Name", "Status", "Tools Count", "Network Access"}
-		rows := make([][]string, 0, len(mcpConfigs))
-
-		for _, config := range mcpConfigs {
-			status := determineConfigStatus(config)
-			toolsCount := formatToolsCount(config.Allowed)
-			networkAccess := formatNetworkAccess(hasNetworkAccess)
-
-			rows = append(rows, []string{
-				config.Name,
-				status,
-				toolsCount,
-				networkAccess,
-			})
+		if verbose {
+			row = append(row, determineConfigStatus(config), formatToolsCount(config.Allowed), formatNetworkAccess(hasNetworkAccess))
 		}
+		rows = append(rows, row)
+	}
 
-		tableConfig := console.TableConfig{
-			Title:   "MCP servers in " + filepath.Base(workflowPath),
-			Headers: headers,
-			Rows:    rows,
-		}
-		fmt.Fprint(os.Stderr, console.RenderTable(tableConfig))
+	tableConfig := console.TableConfig{
+		Title:   "MCP servers in " + filepath.Base(workflowPath),
+		Headers: headers,
+		Rows:    rows,
 	}
+	fmt.Fprint(os.Stderr, console.RenderTable(tableConfig))
 
 	if !verbose {
 		fmt.Fprintf(os.Stderr, "\nRun 'gh aw mcp list %s --verbose' for detailed information\n", workflowFile)
@@ -127,9 +138,35 @@ func ListWorkflowMCP(workflowFile string, verbose bool) error {
 	return nil
 }
 
+// formatCommandOrURL returns the URL of an HTTP server, the image of a container server, or the
+// command line of a stdio server
+func formatCommandOrURL(config parser.MCPServerConfig) string {
+	switch {
+	case config.URL != "":
+		return config.URL
+	case config.Container != "":
+		return config.Container
+	case config.Command != "":
+		return strings.TrimSpace(config.Command + " " + strings.Join(config.Args, " "))
+	default:
+		return "-"
+	}
+}
+
+// formatAllowedTools returns the allowed tools of a server, or "All tools" when the server does
+// not restrict its tools
+func formatAllowedTools(allowed []string) string {
+	if len(allowed) == 0 || slices.Contains(allowed, "*") {
+		return "All tools"
+	}
+	sorted := slices.Clone(allowed)
+	slices.Sort(sorted)
+	return strings.Join(sorted, ", ")
+}
+
 // listWorkflowsWithMCPServers shows available workflow files that contain MCP configurations
 // with optional interactive selection
-func listWorkflowsWithMCPServers(workflowsDir string, verbose bool) error {
+func listWorkflowsWithMCPServers(workflowsDir string, verbose bool, jsonOutput bool) error {
 	// Scan workflows for MCP configurations
 	results, err := ScanWorkflowsForMCP(workflowsDir, "", verbose)
 	if err != nil {
@@ -161,6 +198,23 @@ func listWorkflowsWithMCPServers(workflowsDir string, verbose bool) error {
 		totalMCPCount += len(result.MCPConfigs)
 	}
 
+	if jsonOutput {
+		type workflowMCPServers struct {
+			Workflow string   `json:"workflow"`
+			Servers  []string `json:"servers"`
+		}
+		items := make([]workflowMCPServers, 0, len(workflowData))
+		for _, workflow := range workflowData {
+			items = append(items, workflowMCPServers{Workflow: workflow.name, Servers: workflow.serverNames})
+		}
+		jsonBytes, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
 	if len(workflowData) == 0 {
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No workflows with MCP servers found"))
 		return nil
@@ -171,7 +225,7 @@ func listWorkflowsWithMCPServers(workflowsDir string, verbose bool) error {
 	if err == nil && selectedWorkflow != "" {
 		// User selected a workflow, show its details
 		mcpListLog.Printf("User selected workflow: %s", selectedWorkflow)
-		return ListWorkflowMCP(selectedWorkflow, verbose)
+		return ListWorkflowMCP(selectedWorkflow, verbose, false)
 	}
 
 	// If interactive selection failed or was cancelled, fall back to table display
@@ -335,18 +389,24 @@ The workflow-id-or-file can be:
 - A workflow ID (basename without .md extension, e.g., "weekly-research")
 - A file path (e.g., "weekly-research.md" or ".github/workflows/weekly-research.md")
 
+The servers listed for a workflow are the ones it ends up with after default tools, imports,
+includes and mcp-servers are merged. The command is read-only and works offline: no server is
+started and nothing is compiled to disk.
+
 Examples:
-  gh aw mcp list                     # List all workflows with MCP servers
-  gh aw mcp list weekly-research     # List MCP servers in weekly-research.md
-  gh aw mcp list weekly-research -v  # List with detailed information
-  gh aw mcp list --verbose           # List all workflows with detailed MCP server info
+  gh aw mcp list                          # List all workflows with MCP servers
+  gh aw mcp list weekly-research          # List MCP servers in weekly-research.md
+  gh aw mcp list weekly-research -v       # List with detailed information
+  gh aw mcp list weekly-research --json   # Output the resolved servers as JSON
+  gh aw mcp list --verbose                # List all workflows with detailed MCP server info
 
 The command displays:
 - Server Name: MCP server identifier
-- Status: Configuration status (✓ Ready or ⚠ Incomplete)
-- Tools Count: Number of allowed tools or "All tools"
-- Network Access: Whether network permissions are configured (✓ Enabled or ✗ Disabled)
-- In verbose mode: Also shows Type and Command/URL`,
+- Type: Transport type (stdio, http, ...)
+- Command/URL: Command line, container image or URL of the server
+- Allowed Tools: Tools the agent may call, or "All tools"
+- In verbose mode: Also shows Status (✓ Ready or ⚠ Incomplete), Tools Count and
+  Network Access (✓ Enabled or ✗ Disabled)`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var workflowFile string
@@ -370,10 +430,13 @@ The command displays:
 				}
 			}
 
-			return ListWorkflowMCP(workflowFile, verbose)
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return ListWorkflowMCP(workflowFile, verbose, jsonOutput)
 		},
 	}
 
+	addJSONFlag(cmd)
+
 	// Register completions for mcp list command
 	cmd.ValidArgsFunction = CompleteWorkflowNames
 
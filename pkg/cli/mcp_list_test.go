@@ -3,6 +3,8 @@
 package cli
 
 import (
+	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +14,8 @@ import (
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/testutil"
 	"github.com/github/gh-aw/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListWorkflowMCP(t *testing.T) {
@@ -36,11 +40,7 @@ safe-outputs:
 
 tools:
   github:
-    mcp:
-      type: stdio
-      command: "npx"
-      args: ["@github/github-mcp-server"]
-      allowed: ["create_issue"]
+    allowed: ["create_issue"]
 
 ---
 
@@ -59,28 +59,28 @@ This is a test workflow.`
 	os.Chdir(tmpDir)
 
 	t.Run("list_specific_workflow", func(t *testing.T) {
-		err := ListWorkflowMCP("test-workflow", false)
+		err := ListWorkflowMCP("test-workflow", false, false)
 		if err != nil {
 			t.Errorf("ListWorkflowMCP failed: %v", err)
 		}
 	})
 
 	t.Run("list_specific_workflow_verbose", func(t *testing.T) {
-		err := ListWorkflowMCP("test-workflow", true)
+		err := ListWorkflowMCP("test-workflow", true, false)
 		if err != nil {
 			t.Errorf("ListWorkflowMCP verbose failed: %v", err)
 		}
 	})
 
 	t.Run("list_all_workflows", func(t *testing.T) {
-		err := ListWorkflowMCP("", false)
+		err := ListWorkflowMCP("", false, false)
 		if err != nil {
 			t.Errorf("ListWorkflowMCP all workflows failed: %v", err)
 		}
 	})
 
 	t.Run("nonexistent_workflow", func(t *testing.T) {
-		err := ListWorkflowMCP("nonexistent", false)
+		err := ListWorkflowMCP("nonexistent", false, false)
 		if err == nil {
 			t.Error("Expected error for nonexistent workflow, got nil")
 		}
@@ -134,21 +134,21 @@ on:
 	os.Chdir(tmpDir)
 
 	t.Run("list_workflows_with_mcp", func(t *testing.T) {
-		err := listWorkflowsWithMCPServers(".github/workflows", false)
+		err := listWorkflowsWithMCPServers(".github/workflows", false, false)
 		if err != nil {
 			t.Errorf("listWorkflowsWithMCPServers failed: %v", err)
 		}
 	})
 
 	t.Run("list_workflows_with_mcp_verbose", func(t *testing.T) {
-		err := listWorkflowsWithMCPServers(".github/workflows", true)
+		err := listWorkflowsWithMCPServers(".github/workflows", true, false)
 		if err != nil {
 			t.Errorf("listWorkflowsWithMCPServers verbose failed: %v", err)
 		}
 	})
 
 	t.Run("nonexistent_directory", func(t *testing.T) {
-		err := listWorkflowsWithMCPServers("nonexistent", false)
+		err := listWorkflowsWithMCPServers("nonexistent", false, false)
 		if err == nil {
 			t.Error("Expected error for nonexistent directory, got nil")
 		}
@@ -349,3 +349,126 @@ func TestCheckNetworkAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestListWorkflowMCPJSONResolvesServers(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-list-json-*")
+	workflowsDir := filepath.Join(tmpDir, constants.GetWorkflowDir())
+	require.NoError(t, os.MkdirAll(filepath.Join(workflowsDir, "shared"), 0755), "Should create workflows directory")
+
+	sharedContent := `---
+mcp-servers:
+  shared-search:
+    url: "https://search.example.com/mcp"
+    allowed: ["search"]
+---
+`
+	workflowContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+  issues: read
+imports:
+  - shared/search.md
+tools:
+  github:
+    allowed: [get_issue, list_issues]
+mcp-servers:
+  my-server:
+    command: "node"
+    args: ["server.js"]
+    allowed: ["lookup"]
+---
+
+# MCP List
+
+List the servers.
+`
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "shared", "search.md"), []byte(sharedContent), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "mcp-list.md"), []byte(workflowContent), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err, "Should get current directory")
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(tmpDir), "Should change to temp directory")
+
+	// Redirect stdout to capture JSON output
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	listErr := ListWorkflowMCP("mcp-list", false, true)
+
+	// Restore stdout
+	w.Close()
+	os.Stdout = oldStdout
+	output, _ := io.ReadAll(r)
+	require.NoError(t, listErr, "Listing MCP servers should succeed")
+
+	var items []MCPServerListItem
+	require.NoError(t, json.Unmarshal(output, &items), "Output should be valid JSON: %s", output)
+
+	servers := make(map[string]MCPServerListItem)
+	var names []string
+	for _, item := range items {
+		servers[item.Name] = item
+		names = append(names, item.Name)
+	}
+	assert.Equal(t, []string{"github", "my-server", "shared-search"}, names, "Servers should be listed by name, including imported ones")
+
+	github := servers["github"]
+	assert.Equal(t, "docker", github.Type, "Local GitHub MCP server should run in a container")
+	assert.ElementsMatch(t, []string{"get_issue", "list_issues"}, github.AllowedTools)
+
+	custom := servers["my-server"]
+	assert.Equal(t, "stdio", custom.Type, "Custom command server should be a stdio server")
+	assert.Equal(t, "node", custom.Command)
+	assert.Equal(t, []string{"server.js"}, custom.Args)
+	assert.Equal(t, []string{"lookup"}, custom.AllowedTools)
+
+	imported := servers["shared-search"]
+	assert.Equal(t, "http", imported.Type, "Imported URL server should be an HTTP server")
+	assert.Equal(t, "https://search.example.com/mcp", imported.URL)
+}
+
+func TestFormatCommandOrURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   parser.MCPServerConfig
+		expected string
+	}{
+		{
+			name:     "url",
+			config:   parser.MCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{URL: "https://example.com/mcp"}},
+			expected: "https://example.com/mcp",
+		},
+		{
+			name:     "container",
+			config:   parser.MCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Command: "docker", Container: "mcp/server:1.0"}},
+			expected: "mcp/server:1.0",
+		},
+		{
+			name:     "command with args",
+			config:   parser.MCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Command: "node", Args: []string{"server.js", "--stdio"}}},
+			expected: "node server.js --stdio",
+		},
+		{
+			name:     "nothing",
+			config:   parser.MCPServerConfig{},
+			expected: "-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatCommandOrURL(tt.config))
+		})
+	}
+}
+
+func TestFormatAllowedTools(t *testing.T) {
+	assert.Equal(t, "All tools", formatAllowedTools(nil))
+	assert.Equal(t, "All tools", formatAllowedTools([]string{"search", "*"}))
+	assert.Equal(t, "get_issue, list_issues", formatAllowedTools([]string{"list_issues", "get_issue"}))
+}
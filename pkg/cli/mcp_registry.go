@@ -33,6 +33,7 @@ type MCPRegistryServerForProcessing struct {
 type MCPRegistryClient struct {
 	registryURL string
 	httpClient  *http.Client
+	cache       *mcpRegistryCache
 }
 
 // NewMCPRegistryClient creates a new MCP registry client
@@ -48,9 +49,16 @@ func NewMCPRegistryClient(registryURL string) *MCPRegistryClient {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		cache: newMCPRegistryCache(),
 	}
 }
 
+// DisableCache makes the client always query the registry instead of the local lookup cache
+func (c *MCPRegistryClient) DisableCache() {
+	mcpRegistryLog.Print("Disabling MCP registry cache")
+	c.cache = nil
+}
+
 // createRegistryRequest creates an HTTP request with appropriate headers for the MCP registry
 func (c *MCPRegistryClient) createRegistryRequest(method, url string) (*http.Request, error) {
 	req, err := http.NewRequest(method, url, nil)
@@ -69,6 +77,11 @@ func (c *MCPRegistryClient) createRegistryRequest(method, url string) (*http.Req
 func (c *MCPRegistryClient) SearchServers(query string) ([]MCPRegistryServerForProcessing, error) {
 	mcpRegistryLog.Printf("Searching MCP servers: query=%q", query)
 
+	cacheKey := mcpRegistrySearchCacheKey(c.registryURL, query)
+	if servers, ok := c.cache.Get(cacheKey); ok {
+		return servers, nil
+	}
+
 	// Always use servers endpoint for listing all servers
 	searchURL := c.registryURL + "/servers"
 
@@ -246,6 +259,7 @@ func (c *MCPRegistryClient) SearchServers(query string) ([]MCPRegistryServerForP
 		}
 
 		mcpRegistryLog.Printf("Filtered to %d servers matching query", len(filteredServers))
+		c.cache.Set(cacheKey, filteredServers)
 		return filteredServers, nil
 	}
 
@@ -256,6 +270,7 @@ func (c *MCPRegistryClient) SearchServers(query string) ([]MCPRegistryServerForP
 		return nil, fmt.Errorf("registry validation failed: expected at least 10 servers from production registry, got %d\nThis may indicate an issue with the registry API or access restrictions", len(servers))
 	}
 
+	c.cache.Set(cacheKey, servers)
 	return servers, nil
 }
 
@@ -263,6 +278,11 @@ func (c *MCPRegistryClient) SearchServers(query string) ([]MCPRegistryServerForP
 func (c *MCPRegistryClient) GetServer(serverName string) (*MCPRegistryServerForProcessing, error) {
 	mcpRegistryLog.Printf("Getting MCP server: name=%s", serverName)
 
+	cacheKey := mcpRegistryServerCacheKey(c.registryURL, serverName)
+	if servers, ok := c.cache.Get(cacheKey); ok && len(servers) == 1 {
+		return &servers[0], nil
+	}
+
 	// Use the servers endpoint and filter locally, just like SearchServers
 	serversURL := c.registryURL + "/servers"
 
@@ -424,6 +444,7 @@ func (c *MCPRegistryClient) GetServer(serverName string) (*MCPRegistryServerForP
 			}
 
 			mcpRegistryLog.Printf("Found MCP server: name=%s, transport=%s", serverName, processedServer.Transport)
+			c.cache.Set(cacheKey, []MCPRegistryServerForProcessing{processedServer})
 			return &processedServer, nil
 		}
 	}
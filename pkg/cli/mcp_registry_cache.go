@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var mcpRegistryCacheLog = logger.New("cli:mcp_registry_cache")
+
+const (
+	// mcpRegistryCacheFileName is the cache file stored under the gh-aw config directory
+	mcpRegistryCacheFileName = "mcp-registry-cache.json"
+
+	// mcpRegistryCacheTTL is how long a registry lookup is served from the cache
+	mcpRegistryCacheTTL = 24 * time.Hour
+
+	// mcpRegistryCacheLockTimeout bounds how long a writer waits for another process's lock
+	mcpRegistryCacheLockTimeout = 5 * time.Second
+
+	// mcpRegistryCacheStaleLock is the age after which a leftover lock file is treated as abandoned
+	mcpRegistryCacheStaleLock = 30 * time.Second
+)
+
+var (
+	// getMCPRegistryCachePathFunc allows overriding in tests
+	getMCPRegistryCachePathFunc = getMCPRegistryCachePathImpl
+)
+
+// mcpRegistryCacheEntry is a single cached registry lookup
+type mcpRegistryCacheEntry struct {
+	Servers   []MCPRegistryServerForProcessing `json:"servers"`
+	FetchedAt time.Time                        `json:"fetched_at"`
+}
+
+// mcpRegistryCacheFile is the on-disk layout of the registry cache
+type mcpRegistryCacheFile struct {
+	Entries map[string]mcpRegistryCacheEntry `json:"entries"`
+}
+
+// mcpRegistryCache is a file-backed cache of MCP registry lookups keyed by registry URL and server name.
+// Writes take a lock file and replace the cache file atomically, so concurrent gh-aw processes
+// never observe or produce a partially written cache.
+type mcpRegistryCache struct {
+	path string
+	ttl  time.Duration
+}
+
+// newMCPRegistryCache returns the registry cache at the default location, or nil if the
+// gh-aw config directory cannot be determined
+func newMCPRegistryCache() *mcpRegistryCache {
+	path := getMCPRegistryCachePathFunc()
+	if path == "" {
+		return nil
+	}
+	return &mcpRegistryCache{path: path, ttl: mcpRegistryCacheTTL}
+}
+
+// getMCPRegistryCachePathImpl returns the cache file path under the user's gh-aw config directory
+func getMCPRegistryCachePathImpl() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		mcpRegistryCacheLog.Printf("Could not determine user config directory: %v", err)
+		return ""
+	}
+	return filepath.Join(configDir, "gh-aw", mcpRegistryCacheFileName)
+}
+
+// mcpRegistryServerCacheKey returns the cache key for a single server lookup
+func mcpRegistryServerCacheKey(registryURL, serverName string) string {
+	return registryURL + "/servers/" + serverName
+}
+
+// mcpRegistrySearchCacheKey returns the cache key for a server search
+func mcpRegistrySearchCacheKey(registryURL, query string) string {
+	return registryURL + "/servers?search=" + query
+}
+
+// Get returns the cached servers for key, or (nil, false) if missing or expired
+func (c *mcpRegistryCache) Get(key string) ([]MCPRegistryServerForProcessing, bool) {
+	if c == nil {
+		return nil, false
+	}
+	cacheFile, err := c.read()
+	if err != nil {
+		mcpRegistryCacheLog.Printf("Ignoring unreadable registry cache: %v", err)
+		return nil, false
+	}
+	entry, ok := cacheFile.Entries[key]
+	if !ok {
+		mcpRegistryCacheLog.Printf("Registry cache miss: key=%s", key)
+		return nil, false
+	}
+	if time.Since(entry.FetchedAt) >= c.ttl {
+		mcpRegistryCacheLog.Printf("Registry cache entry expired: key=%s", key)
+		return nil, false
+	}
+	mcpRegistryCacheLog.Printf("Registry cache hit: key=%s, servers=%d", key, len(entry.Servers))
+	return entry.Servers, true
+}
+
+// Set stores servers under key. Failures are logged and ignored since the cache is best-effort.
+func (c *mcpRegistryCache) Set(key string, servers []MCPRegistryServerForProcessing) {
+	if c == nil {
+		return
+	}
+	err := c.withLock(func() error {
+		cacheFile, err := c.read()
+		if err != nil {
+			// Start over rather than failing on a corrupt cache
+			mcpRegistryCacheLog.Printf("Replacing unreadable registry cache: %v", err)
+			cacheFile = &mcpRegistryCacheFile{Entries: make(map[string]mcpRegistryCacheEntry)}
+		}
+		// Drop expired entries so the file does not grow without bound
+		for k, entry := range cacheFile.Entries {
+			if time.Since(entry.FetchedAt) >= c.ttl {
+				delete(cacheFile.Entries, k)
+			}
+		}
+		cacheFile.Entries[key] = mcpRegistryCacheEntry{Servers: servers, FetchedAt: time.Now()}
+		return c.write(cacheFile)
+	})
+	if err != nil {
+		mcpRegistryCacheLog.Printf("Failed to update registry cache: %v", err)
+		return
+	}
+	mcpRegistryCacheLog.Printf("Cached registry lookup: key=%s, servers=%d", key, len(servers))
+}
+
+// Clear removes the cache file
+func (c *mcpRegistryCache) Clear() error {
+	if c == nil {
+		return nil
+	}
+	return c.withLock(func() error {
+		if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove MCP registry cache: %w", err)
+		}
+		mcpRegistryCacheLog.Printf("Cleared registry cache: %s", c.path)
+		return nil
+	})
+}
+
+// read loads the cache file, returning an empty cache when it does not exist yet
+func (c *mcpRegistryCache) read() (*mcpRegistryCacheFile, error) {
+	cacheFile := &mcpRegistryCacheFile{Entries: make(map[string]mcpRegistryCacheEntry)}
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cacheFile, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cacheFile); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", c.path, err)
+	}
+	if cacheFile.Entries == nil {
+		cacheFile.Entries = make(map[string]mcpRegistryCacheEntry)
+	}
+	return cacheFile, nil
+}
+
+// write replaces the cache file atomically via a temp file and rename
+func (c *mcpRegistryCache) write(cacheFile *mcpRegistryCacheFile) error {
+	data, err := json.MarshalIndent(cacheFile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal registry cache: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), mcpRegistryCacheFileName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp cache file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write temp cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temp cache file: %w", err)
+	}
+	if err := os.Rename(tmpPath, c.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace registry cache: %w", err)
+	}
+	return nil
+}
+
+// withLock runs fn while holding an exclusive lock file next to the cache file.
+// Lock files older than mcpRegistryCacheStaleLock are assumed abandoned by a crashed process.
+func (c *mcpRegistryCache) withLock(fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	lockPath := c.path + ".lock"
+	deadline := time.Now().Add(mcpRegistryCacheLockTimeout)
+	for {
+		lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			lock.Close()
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("failed to create cache lock: %w", err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > mcpRegistryCacheStaleLock {
+			mcpRegistryCacheLog.Printf("Removing stale registry cache lock: %s", lockPath)
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for registry cache lock %s", lockPath)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	defer os.Remove(lockPath)
+	return fn()
+}
@@ -0,0 +1,175 @@
+//go:build !integration
+
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mockRegistryResponse = `{
+	"servers": [
+		{
+			"server": {
+				"name": "io.github.example/cached-server",
+				"description": "Cached server",
+				"version": "1.0.0",
+				"packages": [
+					{
+						"registryType": "npm",
+						"identifier": "cached-server",
+						"version": "1.0.0",
+						"transport": {"type": "stdio"}
+					}
+				]
+			},
+			"_meta": {
+				"io.modelcontextprotocol.registry/official": {"status": "active"}
+			}
+		}
+	]
+}`
+
+// newCountingRegistry starts a mock registry that counts how many times it is queried
+func newCountingRegistry(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(mockRegistryResponse))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// newTestRegistryClient returns a client for registryURL backed by a cache in a temp directory
+func newTestRegistryClient(t *testing.T, registryURL string) *MCPRegistryClient {
+	t.Helper()
+	client := NewMCPRegistryClient(registryURL)
+	client.cache = &mcpRegistryCache{path: filepath.Join(t.TempDir(), mcpRegistryCacheFileName), ttl: time.Hour}
+	return client
+}
+
+func TestMCPRegistryClient_GetServerServedFromCache(t *testing.T) {
+	registry, requests := newCountingRegistry(t)
+	client := newTestRegistryClient(t, registry.URL)
+
+	first, err := client.GetServer("io.github.example/cached-server")
+	require.NoError(t, err, "first lookup should succeed")
+	second, err := client.GetServer("io.github.example/cached-server")
+	require.NoError(t, err, "second lookup should succeed")
+
+	assert.Equal(t, int32(1), requests.Load(), "second lookup should be served from the cache")
+	assert.Equal(t, first, second, "cached server should match the fetched server")
+
+	// A new client sharing the cache file (e.g. the next compile) is also served offline
+	registry.Close()
+	next := NewMCPRegistryClient(registry.URL)
+	next.cache = client.cache
+	cached, err := next.GetServer("io.github.example/cached-server")
+	require.NoError(t, err, "lookup should be served from the cache file without the registry")
+	assert.Equal(t, "cached-server", cached.Command, "cached server should keep its command")
+}
+
+func TestMCPRegistryClient_SearchServersServedFromCache(t *testing.T) {
+	registry, requests := newCountingRegistry(t)
+	client := newTestRegistryClient(t, registry.URL)
+
+	_, err := client.SearchServers("cached")
+	require.NoError(t, err, "first search should succeed")
+	servers, err := client.SearchServers("cached")
+	require.NoError(t, err, "second search should succeed")
+	require.Len(t, servers, 1, "cached search should return the matching server")
+	assert.Equal(t, int32(1), requests.Load(), "second search should be served from the cache")
+
+	_, err = client.SearchServers("other")
+	require.NoError(t, err, "search with a different query should succeed")
+	assert.Equal(t, int32(2), requests.Load(), "a different query should miss the cache")
+}
+
+func TestMCPRegistryClient_DisableCache(t *testing.T) {
+	registry, requests := newCountingRegistry(t)
+	client := newTestRegistryClient(t, registry.URL)
+	client.DisableCache()
+
+	for range 2 {
+		_, err := client.GetServer("io.github.example/cached-server")
+		require.NoError(t, err, "lookup should succeed")
+	}
+	assert.Equal(t, int32(2), requests.Load(), "every lookup should query the registry when the cache is disabled")
+}
+
+func TestMCPRegistryCache_Expiry(t *testing.T) {
+	cache := &mcpRegistryCache{path: filepath.Join(t.TempDir(), mcpRegistryCacheFileName), ttl: time.Hour}
+	key := mcpRegistryServerCacheKey("https://registry.example.com", "server")
+	cache.Set(key, []MCPRegistryServerForProcessing{{Name: "server"}})
+
+	_, ok := cache.Get(key)
+	assert.True(t, ok, "fresh entry should be served")
+
+	cache.ttl = 0
+	_, ok = cache.Get(key)
+	assert.False(t, ok, "expired entry should not be served")
+}
+
+func TestMCPRegistryCache_Clear(t *testing.T) {
+	cache := &mcpRegistryCache{path: filepath.Join(t.TempDir(), mcpRegistryCacheFileName), ttl: time.Hour}
+	require.NoError(t, cache.Clear(), "clearing a missing cache should succeed")
+
+	key := mcpRegistryServerCacheKey("https://registry.example.com", "server")
+	cache.Set(key, []MCPRegistryServerForProcessing{{Name: "server"}})
+	require.FileExists(t, cache.path, "cache file should be written")
+
+	require.NoError(t, cache.Clear(), "clearing the cache should succeed")
+	assert.NoFileExists(t, cache.path, "cache file should be removed")
+	_, ok := cache.Get(key)
+	assert.False(t, ok, "cleared entry should not be served")
+}
+
+func TestMCPRegistryCache_CorruptFile(t *testing.T) {
+	cache := &mcpRegistryCache{path: filepath.Join(t.TempDir(), mcpRegistryCacheFileName), ttl: time.Hour}
+	require.NoError(t, os.WriteFile(cache.path, []byte("{not json"), 0644))
+
+	key := mcpRegistryServerCacheKey("https://registry.example.com", "server")
+	_, ok := cache.Get(key)
+	assert.False(t, ok, "corrupt cache should be treated as a miss")
+
+	cache.Set(key, []MCPRegistryServerForProcessing{{Name: "server"}})
+	_, ok = cache.Get(key)
+	assert.True(t, ok, "corrupt cache should be replaced on the next write")
+}
+
+func TestMCPRegistryCache_ConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), mcpRegistryCacheFileName)
+
+	const numWriters = 20
+	var wg sync.WaitGroup
+	for i := range numWriters {
+		wg.Go(func() {
+			// Separate instances mimic separate gh-aw processes sharing the file
+			cache := &mcpRegistryCache{path: path, ttl: time.Hour}
+			name := string(rune('a' + i))
+			cache.Set(mcpRegistryServerCacheKey("https://registry.example.com", name), []MCPRegistryServerForProcessing{{Name: name}})
+		})
+	}
+	wg.Wait()
+
+	cache := &mcpRegistryCache{path: path, ttl: time.Hour}
+	for i := range numWriters {
+		name := string(rune('a' + i))
+		servers, ok := cache.Get(mcpRegistryServerCacheKey("https://registry.example.com", name))
+		require.True(t, ok, "entry %s should survive concurrent writes", name)
+		assert.Equal(t, name, servers[0].Name, "entry %s should not be corrupted", name)
+	}
+	assert.NoFileExists(t, path+".lock", "lock file should be released")
+}
@@ -11,10 +11,13 @@ import (
 var mcpRegistryListLog = logger.New("cli:mcp_registry_list")
 
 // listAvailableServers shows a list of available MCP servers from the registry
-func listAvailableServers(registryURL string, verbose bool) error {
-	mcpRegistryListLog.Printf("Listing available MCP servers: registry_url=%s", registryURL)
+func listAvailableServers(registryURL string, noCache bool, verbose bool) error {
+	mcpRegistryListLog.Printf("Listing available MCP servers: registry_url=%s, noCache=%v", registryURL, noCache)
 	// Create registry client
 	registryClient := NewMCPRegistryClient(registryURL)
+	if noCache {
+		registryClient.DisableCache()
+	}
 
 	// Search for all servers (empty query)
 	if verbose {
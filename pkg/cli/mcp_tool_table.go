@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
@@ -44,19 +45,10 @@ func renderMCPToolTable(info *parser.MCPServerInfo, opts MCPToolTableOptions) st
 		return ""
 	}
 
-	// Create a map for quick lookup of allowed tools from workflow configuration
-	allowedMap := make(map[string]bool)
-
 	// Check for wildcard "*" which means all tools are allowed
-	hasWildcard := false
-	for _, allowed := range info.Config.Allowed {
-		if allowed == "*" {
-			hasWildcard = true
-		}
-		allowedMap[allowed] = true
-	}
+	hasWildcard := slices.Contains(info.Config.Allowed, "*")
 
-	mcpToolTableLog.Printf("Tool permissions: has_wildcard=%v, allowed_count=%d", hasWildcard, len(allowedMap))
+	mcpToolTableLog.Printf("Tool permissions: has_wildcard=%v, allowed_count=%d", hasWildcard, len(info.Config.Allowed))
 
 	// Build table headers and rows
 	headers := []string{"Tool Name", "Allow", "Description"}
@@ -75,11 +67,10 @@ func renderMCPToolTable(info *parser.MCPServerInfo, opts MCPToolTableOptions) st
 		}
 
 		// Determine status
+		// If no allowed list is specified or "*" wildcard is present, all tools are allowed;
+		// otherwise the tool must match an exact name or a pattern like "issue_*"
 		status := "🚫"
-		if len(info.Config.Allowed) == 0 || hasWildcard {
-			// If no allowed list is specified or "*" wildcard is present, assume all tools are allowed
-			status = "✅"
-		} else if allowedMap[tool.Name] {
+		if parser.IsToolAllowed(info.Config.Allowed, tool.Name) {
 			status = "✅"
 		}
 
@@ -98,7 +89,7 @@ func renderMCPToolTable(info *parser.MCPServerInfo, opts MCPToolTableOptions) st
 	if opts.ShowSummary {
 		allowedCount := 0
 		for _, tool := range info.Tools {
-			if len(info.Config.Allowed) == 0 || hasWildcard || allowedMap[tool.Name] {
+			if parser.IsToolAllowed(info.Config.Allowed, tool.Name) {
 				allowedCount++
 			}
 		}
@@ -144,16 +135,6 @@ func renderMCPHierarchyTree(configs []parser.MCPServerConfig, serverInfos map[st
 
 		// Add server info if available
 		if info, ok := serverInfos[config.Name]; ok && info != nil {
-			// Create a map for quick lookup of allowed tools
-			allowedMap := make(map[string]bool)
-			hasWildcard := false
-			for _, allowed := range config.Allowed {
-				if allowed == "*" {
-					hasWildcard = true
-				}
-				allowedMap[allowed] = true
-			}
-
 			// Add tools section
 			if len(info.Tools) > 0 {
 				toolsNode := console.TreeNode{
@@ -163,7 +144,7 @@ func renderMCPHierarchyTree(configs []parser.MCPServerConfig, serverInfos map[st
 
 				for _, tool := range info.Tools {
 					// Determine if tool is allowed
-					isAllowed := len(config.Allowed) == 0 || hasWildcard || allowedMap[tool.Name]
+					isAllowed := parser.IsToolAllowed(config.Allowed, tool.Name)
 					allowIcon := "🚫"
 					if isAllowed {
 						allowIcon = "✅"
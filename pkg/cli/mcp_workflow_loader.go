@@ -2,10 +2,14 @@ package cli
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"sort"
 
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/workflow"
 )
 
 var mcpWorkflowLoaderLog = logger.New("cli:mcp_workflow_loader")
@@ -36,6 +40,11 @@ func loadWorkflowMCPConfigs(workflowPath string, serverFilter string) (*parser.F
 		return nil, nil, fmt.Errorf("failed to parse workflow file: %w", err)
 	}
 
+	// Inline shared MCP server definitions referenced with $ref
+	if err := parser.ResolveFrontmatterMCPServerRefs(workflowData.Frontmatter, filepath.Dir(workflowPath)); err != nil {
+		return nil, nil, err
+	}
+
 	// Extract MCP configurations
 	mcpConfigs, err := parser.ExtractMCPConfigurations(workflowData.Frontmatter, serverFilter)
 	if err != nil {
@@ -45,3 +54,86 @@ func loadWorkflowMCPConfigs(workflowPath string, serverFilter string) (*parser.F
 	mcpWorkflowLoaderLog.Printf("Loaded %d MCP configurations from workflow", len(mcpConfigs))
 	return workflowData, mcpConfigs, nil
 }
+
+// builtinMCPToolNames lists the tools that parser.ExtractMCPConfigurations reads from the tools
+// section as built-in MCP servers
+var builtinMCPToolNames = map[string]bool{
+	"github":     true,
+	"playwright": true,
+	"serena":     true,
+}
+
+// loadResolvedWorkflowMCPConfigs parses a workflow file with the compiler and extracts the
+// MCP configurations the workflow ends up with, after default tools, imports, includes and
+// mcp-servers have been merged. Nothing is fetched or started.
+//
+// Returns the merged frontmatter and the MCP server configurations sorted by name.
+func loadResolvedWorkflowMCPConfigs(workflowPath string) (map[string]any, []parser.MCPServerConfig, error) {
+	mcpWorkflowLoaderLog.Printf("Loading resolved MCP configs: path=%s", workflowPath)
+
+	compiler := workflow.NewCompiler()
+	workflowData, err := compiler.ParseWorkflowFile(workflowPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse workflow file: %w", err)
+	}
+
+	content, err := os.ReadFile(workflowPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read workflow file: %w", err)
+	}
+	result, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse workflow file: %w", err)
+	}
+	frontmatter := maps.Clone(result.Frontmatter)
+	if frontmatter == nil {
+		frontmatter = make(map[string]any)
+	}
+
+	// Safe outputs may be enabled by an import or by default
+	if _, ok := frontmatter["safe-outputs"]; !ok && workflowData.SafeOutputs != nil {
+		frontmatter["safe-outputs"] = map[string]any{}
+	}
+
+	// The merged tools hold the built-in tools with defaults applied and every mcp-servers
+	// entry from the workflow and its imports. Split them back into the sections
+	// ExtractMCPConfigurations reads.
+	tools := make(map[string]any)
+	mcpServers := make(map[string]any)
+	for name, value := range workflowData.Tools {
+		if builtinMCPToolNames[name] {
+			tools[name] = value
+			continue
+		}
+		if toolConfig, ok := value.(map[string]any); ok && isCustomMCPServerConfig(toolConfig) {
+			mcpServers[name] = toolConfig
+		}
+	}
+	frontmatter["tools"] = tools
+	frontmatter["mcp-servers"] = mcpServers
+
+	mcpConfigs, err := parser.ExtractMCPConfigurations(frontmatter, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to extract MCP configurations: %w", err)
+	}
+	sort.Slice(mcpConfigs, func(i, j int) bool {
+		return mcpConfigs[i].Name < mcpConfigs[j].Name
+	})
+
+	mcpWorkflowLoaderLog.Printf("Resolved %d MCP configurations from workflow", len(mcpConfigs))
+	return frontmatter, mcpConfigs, nil
+}
+
+// isCustomMCPServerConfig reports whether a merged tool configuration defines a custom MCP
+// server, either with an explicit MCP type or with a url, command or container
+func isCustomMCPServerConfig(toolConfig map[string]any) bool {
+	if mcpType, ok := toolConfig["type"].(string); ok && parser.IsMCPType(mcpType) {
+		return true
+	}
+	for _, field := range []string{"url", "command", "container"} {
+		if _, ok := toolConfig[field]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -61,6 +61,13 @@ func ScanWorkflowsForMCP(workflowsDir string, serverFilter string, verbose bool)
 			continue
 		}
 
+		if err := parser.ResolveFrontmatterMCPServerRefs(frontmatterData.Frontmatter, filepath.Dir(file)); err != nil {
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Skipping %s: %v", filepath.Base(file), err)))
+			}
+			continue
+		}
+
 		mcpConfigs, err := parser.ExtractMCPConfigurations(frontmatterData.Frontmatter, serverFilter)
 		if err != nil {
 			if verbose {
@@ -2,10 +2,12 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
@@ -69,13 +71,67 @@ func collectLocalIncludeDependencies(content, packagePath string, verbose bool)
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Collecting package dependencies from: "+packagePath))
 	}
 
-	err := collectLocalIncludeDependenciesRecursive(content, packagePath, &dependencies, seen, verbose)
+	// Shared MCP server definitions referenced with $ref in mcp-servers
+	if result, err := parser.ExtractFrontmatterFromContent(content); err == nil {
+		for _, ref := range parser.ExtractMCPServerRefs(result.Frontmatter) {
+			// The compiler rejects refs outside the workflow directory, so never copy them
+			if !filepath.IsLocal(filepath.FromSlash(ref)) {
+				continue
+			}
+			fullSourcePath := filepath.Join(packagePath, ref)
+			if seen[fullSourcePath] {
+				continue
+			}
+			seen[fullSourcePath] = true
+			dependencies = append(dependencies, IncludeDependency{
+				SourcePath: fullSourcePath,
+				TargetPath: ref,
+			})
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found MCP server definition dependency: %s -> %s", fullSourcePath, ref)))
+			}
+		}
+
+		// Shared network allowlist referenced with network.allowed-from (relative to the repository root)
+		if dep, ok := networkAllowlistDependency(result.Frontmatter, packagePath); ok && !seen[dep.SourcePath] {
+			seen[dep.SourcePath] = true
+			dependencies = append(dependencies, dep)
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found network allowlist dependency: %s -> %s", dep.SourcePath, dep.TargetPath)))
+			}
+		}
+	}
+
+	err := collectLocalIncludeDependenciesRecursive(content, packagePath, &dependencies, seen, nil, verbose)
 	packagesLog.Printf("Collected %d include dependencies from %s", len(dependencies), packagePath)
 	return dependencies, err
 }
 
-// collectLocalIncludeDependenciesRecursive recursively processes @include directives in package content
-func collectLocalIncludeDependenciesRecursive(content, baseDir string, dependencies *[]IncludeDependency, seen map[string]bool, verbose bool) error {
+// networkAllowlistDependency returns the network.allowed-from file of a workflow as an include
+// dependency. The path is relative to the repository root, so the target is expressed relative to
+// .github/workflows and the source is resolved the same way from the workflow's directory.
+func networkAllowlistDependency(frontmatter map[string]any, packagePath string) (IncludeDependency, bool) {
+	network, ok := frontmatter["network"].(map[string]any)
+	if !ok {
+		return IncludeDependency{}, false
+	}
+	allowedFrom, ok := network["allowed-from"].(string)
+	if !ok || !filepath.IsLocal(filepath.FromSlash(allowedFrom)) {
+		return IncludeDependency{}, false
+	}
+	targetPath, err := filepath.Rel(filepath.Join(".github", "workflows"), filepath.FromSlash(allowedFrom))
+	if err != nil {
+		return IncludeDependency{}, false
+	}
+	return IncludeDependency{
+		SourcePath: filepath.Join(packagePath, targetPath),
+		TargetPath: targetPath,
+	}, true
+}
+
+// collectLocalIncludeDependenciesRecursive recursively processes @include directives in package content.
+// chain holds the files currently being walked; including one of them again returns a parser.ImportCycleError.
+func collectLocalIncludeDependenciesRecursive(content, baseDir string, dependencies *[]IncludeDependency, seen map[string]bool, chain []string, verbose bool) error {
 	scanner := bufio.NewScanner(strings.NewReader(content))
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -95,6 +151,11 @@ func collectLocalIncludeDependenciesRecursive(content, baseDir string, dependenc
 			// Resolve the full source path relative to base directory
 			fullSourcePath := filepath.Join(baseDir, filePath)
 
+			// A file on the current include chain means the includes loop back on themselves
+			if slices.Contains(chain, fullSourcePath) {
+				return &parser.ImportCycleError{Chain: append(slices.Clone(chain), fullSourcePath)}
+			}
+
 			// Skip if we've already processed this file
 			if seen[fullSourcePath] {
 				continue
@@ -133,7 +194,11 @@ func collectLocalIncludeDependenciesRecursive(content, baseDir string, dependenc
 
 			// Recursively process includes in the included file
 			includedDir := filepath.Dir(fullSourcePath)
-			if err := collectLocalIncludeDependenciesRecursive(markdownContent, includedDir, dependencies, seen, verbose); err != nil {
+			if err := collectLocalIncludeDependenciesRecursive(markdownContent, includedDir, dependencies, seen, append(slices.Clone(chain), fullSourcePath), verbose); err != nil {
+				var cycleErr *parser.ImportCycleError
+				if errors.As(err, &cycleErr) {
+					return err
+				}
 				if verbose {
 					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Error processing includes in %s: %v", fullSourcePath, err)))
 				}
@@ -253,6 +318,13 @@ func ExtractWorkflowEngine(content string) string {
 			packagesLog.Printf("Extracted engine (string format): %s", engineStr)
 			return engineStr
 		}
+		// Handle fallback chain format: engine: [copilot, claude] (first entry is the primary engine)
+		if engineList, ok := engine.([]any); ok && len(engineList) > 0 {
+			if engineStr, ok := engineList[0].(string); ok {
+				packagesLog.Printf("Extracted engine (list format): %s", engineStr)
+				return engineStr
+			}
+		}
 		// Handle nested format: engine: { id: copilot }
 		if engineMap, ok := engine.(map[string]any); ok {
 			if id, ok := engineMap["id"]; ok {
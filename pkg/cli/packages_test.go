@@ -9,7 +9,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/parser"
 	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestCollectPackageIncludesRecursive tests the recursive include dependency collection
@@ -119,7 +122,7 @@ on: push
 			// Collect includes
 			var dependencies []IncludeDependency
 			seen := make(map[string]bool)
-			err := collectLocalIncludeDependenciesRecursive(tt.content, tmpDir, &dependencies, seen, false)
+			err := collectLocalIncludeDependenciesRecursive(tt.content, tmpDir, &dependencies, seen, nil, false)
 
 			// Check error expectation
 			if tt.expectedError && err == nil {
@@ -148,36 +151,127 @@ on: push
 	}
 }
 
-// TestCollectPackageIncludesRecursive_CircularReference tests handling of circular includes
+// TestCollectPackageIncludesRecursive_CircularReference tests that circular includes are reported with the cycle path
 func TestCollectPackageIncludesRecursive_CircularReference(t *testing.T) {
-	tmpDir := testutil.TempDir(t, "test-*")
+	tests := []struct {
+		name          string
+		files         map[string]string
+		content       string
+		expectedCycle []string
+	}{
+		{
+			name: "two-file cycle",
+			files: map[string]string{
+				"a.md": "@include b.md\n# File A",
+				"b.md": "@include a.md\n# File B",
+			},
+			content:       "@include a.md\n# Main",
+			expectedCycle: []string{"a.md", "b.md", "a.md"},
+		},
+		{
+			name: "three-file cycle",
+			files: map[string]string{
+				"a.md": "@include b.md\n# File A",
+				"b.md": "@include c.md\n# File B",
+				"c.md": "@include a.md\n# File C",
+			},
+			content:       "@include a.md\n# Main",
+			expectedCycle: []string{"a.md", "b.md", "c.md", "a.md"},
+		},
+		{
+			name: "cycle reached through an already collected file",
+			files: map[string]string{
+				"a.md": "@include b.md\n# File A",
+				"b.md": "@include a.md\n# File B",
+			},
+			content:       "@include b.md\n@include a.md\n# Main",
+			expectedCycle: []string{"b.md", "a.md", "b.md"},
+		},
+	}
 
-	// Create circular reference: a.md includes b.md, b.md includes a.md
-	aContent := `@include b.md
-# File A`
-	bContent := `@include a.md
-# File B`
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "test-*")
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644))
+			}
 
-	if err := os.WriteFile(filepath.Join(tmpDir, "a.md"), []byte(aContent), 0644); err != nil {
-		t.Fatalf("Failed to write a.md: %v", err)
-	}
-	if err := os.WriteFile(filepath.Join(tmpDir, "b.md"), []byte(bContent), 0644); err != nil {
-		t.Fatalf("Failed to write b.md: %v", err)
+			var dependencies []IncludeDependency
+			err := collectLocalIncludeDependenciesRecursive(tt.content, tmpDir, &dependencies, make(map[string]bool), nil, false)
+			require.Error(t, err, "circular includes should be reported")
+
+			var cycleErr *parser.ImportCycleError
+			require.ErrorAs(t, err, &cycleErr)
+			expected := make([]string, 0, len(tt.expectedCycle))
+			for _, name := range tt.expectedCycle {
+				expected = append(expected, filepath.Join(tmpDir, name))
+			}
+			assert.Equal(t, expected, cycleErr.Chain)
+			assert.Contains(t, err.Error(), strings.Join(expected, " → "), "error should list the loop")
+		})
 	}
+}
+
+func TestCollectLocalIncludeDependencies_MCPServerRefs(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-*")
 
-	// Collect includes starting from a.md
-	var dependencies []IncludeDependency
-	seen := make(map[string]bool)
-	err := collectLocalIncludeDependenciesRecursive(aContent, tmpDir, &dependencies, seen, false)
+	content := `---
+on: issues
+mcp-servers:
+  first:
+    $ref: shared/myserver.mcp.json
+  second:
+    $ref: shared/myserver.mcp.json
+  outside:
+    $ref: ../outside.mcp.json
+---
+# Workflow`
 
+	dependencies, err := collectLocalIncludeDependencies(content, tmpDir, false)
 	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+		t.Fatalf("Unexpected error: %v", err)
 	}
 
-	// Should have exactly 2 dependencies (a.md and b.md), circular reference prevented by seen map
-	if len(dependencies) != 2 {
-		t.Errorf("Expected 2 dependencies (circular handled by seen map), got %d", len(dependencies))
+	// Duplicate refs are collected once and refs outside the workflow directory are skipped
+	if len(dependencies) != 1 {
+		t.Fatalf("Expected 1 dependency, got %d: %+v", len(dependencies), dependencies)
+	}
+	if dependencies[0].TargetPath != "shared/myserver.mcp.json" {
+		t.Errorf("Expected target path shared/myserver.mcp.json, got %s", dependencies[0].TargetPath)
 	}
+	if dependencies[0].SourcePath != filepath.Join(tmpDir, "shared/myserver.mcp.json") {
+		t.Errorf("Expected source path relative to the workflow, got %s", dependencies[0].SourcePath)
+	}
+}
+
+func TestCollectLocalIncludeDependencies_NetworkAllowedFrom(t *testing.T) {
+	srcRoot := testutil.TempDir(t, "test-*")
+	workflowsDir := filepath.Join(srcRoot, ".github", "workflows")
+	allowlistDir := filepath.Join(srcRoot, ".github", "aw")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755))
+	require.NoError(t, os.MkdirAll(allowlistDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(allowlistDir, "network-allowlist.txt"), []byte("corp.example.com\n"), 0644))
+
+	content := `---
+on: issues
+network:
+  allowed-from: .github/aw/network-allowlist.txt
+---
+# Workflow`
+
+	dependencies, err := collectLocalIncludeDependencies(content, workflowsDir, false)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 1)
+	assert.Equal(t, filepath.Join("..", "aw", "network-allowlist.txt"), dependencies[0].TargetPath)
+	assert.Equal(t, filepath.Join(allowlistDir, "network-allowlist.txt"), filepath.Clean(dependencies[0].SourcePath))
+
+	// The allowlist is copied to the same repository-relative location
+	destRoot := testutil.TempDir(t, "test-*")
+	destWorkflowsDir := filepath.Join(destRoot, ".github", "workflows")
+	require.NoError(t, copyIncludeDependenciesFromPackageWithForce(dependencies, destWorkflowsDir, false, false, nil))
+	copied, err := os.ReadFile(filepath.Join(destRoot, ".github", "aw", "network-allowlist.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "corp.example.com\n", string(copied))
 }
 
 // TestCopyIncludeDependenciesFromPackageWithForce tests copying include dependencies
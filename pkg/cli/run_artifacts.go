@@ -0,0 +1,226 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (run_artifacts.go) contains the structured parser for the
+// artifacts downloaded for a single workflow run.
+//
+// Key responsibilities:
+//   - Parsing aw_info.json into a typed AwInfo
+//   - Decoding safe_output.jsonl records, reporting malformed lines individually
+//   - Computing file and line statistics for the git patches (aw.patch, aw-*.patch)
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var runArtifactsLog = logger.New("cli:run_artifacts")
+
+// maxSafeOutputLineSize bounds the size of a single safe_output.jsonl record
+const maxSafeOutputLineSize = 10 * 1024 * 1024
+
+// RunArtifacts is the parsed content of a flattened run artifacts directory
+type RunArtifacts struct {
+	Dir               string                `json:"dir"`
+	AwInfo            *AwInfo               `json:"aw_info,omitempty"`
+	SafeOutputs       []SafeOutputRecord    `json:"safe_outputs,omitempty"`
+	SafeOutputErrors  []SafeOutputLineError `json:"safe_output_errors,omitempty"`
+	Patch             *PatchStats           `json:"patch,omitempty"`
+	HasSafeOutputFile bool                  `json:"has_safe_output_file"`
+}
+
+// SafeOutputRecord is a single decoded line of safe_output.jsonl
+type SafeOutputRecord struct {
+	Line int            `json:"line"`
+	Type string         `json:"type"`
+	Data map[string]any `json:"data"`
+}
+
+// SafeOutputLineError describes a safe_output.jsonl line that could not be decoded
+type SafeOutputLineError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// PatchStats summarizes the git patches produced by a run
+type PatchStats struct {
+	PatchFiles []string `json:"patch_files"`
+	Files      []string `json:"files"`
+	FileCount  int      `json:"file_count"`
+	Additions  int      `json:"additions"`
+	Deletions  int      `json:"deletions"`
+}
+
+// SafeOutputTypeCounts returns the number of decoded safe output records per type
+func (a *RunArtifacts) SafeOutputTypeCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, record := range a.SafeOutputs {
+		counts[record.Type]++
+	}
+	return counts
+}
+
+// ParseRunArtifacts parses the artifacts of a run from a flattened directory such as the
+// one created by `gh aw logs` or `gh aw audit`. Missing artifacts are left empty; a malformed
+// aw_info.json is returned as an error, while malformed safe_output.jsonl lines are reported
+// in SafeOutputErrors so the remaining records are still available.
+func ParseRunArtifacts(dir string) (*RunArtifacts, error) {
+	cleanDir := filepath.Clean(dir)
+	runArtifactsLog.Printf("Parsing run artifacts from: %s", cleanDir)
+
+	stat, err := os.Stat(cleanDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run artifacts directory: %w", err)
+	}
+	if !stat.IsDir() {
+		return nil, fmt.Errorf("run artifacts path is not a directory: %s", cleanDir)
+	}
+
+	artifacts := &RunArtifacts{Dir: cleanDir}
+
+	awInfoPath := filepath.Join(cleanDir, "aw_info.json")
+	if _, err := os.Stat(awInfoPath); err == nil {
+		info, err := parseAwInfo(awInfoPath, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse aw_info.json: %w", err)
+		}
+		artifacts.AwInfo = info
+	}
+
+	safeOutputPath := filepath.Join(cleanDir, "safe_output.jsonl")
+	if _, err := os.Stat(safeOutputPath); err == nil {
+		artifacts.HasSafeOutputFile = true
+		records, lineErrors, err := parseSafeOutputJSONL(safeOutputPath)
+		if err != nil {
+			return nil, err
+		}
+		artifacts.SafeOutputs = records
+		artifacts.SafeOutputErrors = lineErrors
+	}
+
+	patch, err := parsePatchStats(cleanDir)
+	if err != nil {
+		return nil, err
+	}
+	artifacts.Patch = patch
+
+	runArtifactsLog.Printf("Parsed run artifacts: aw_info=%t, safe_outputs=%d, safe_output_errors=%d, patch=%t",
+		artifacts.AwInfo != nil, len(artifacts.SafeOutputs), len(artifacts.SafeOutputErrors), artifacts.Patch != nil)
+	return artifacts, nil
+}
+
+// parseSafeOutputJSONL decodes each non-empty line of a safe_output.jsonl file.
+// Lines that are not JSON objects are returned as per-line errors.
+func parseSafeOutputJSONL(path string) ([]SafeOutputRecord, []SafeOutputLineError, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open safe_output.jsonl: %w", err)
+	}
+	defer file.Close()
+
+	var records []SafeOutputRecord
+	var lineErrors []SafeOutputLineError
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSafeOutputLineSize)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var data map[string]any
+		if err := json.Unmarshal([]byte(line), &data); err != nil {
+			runArtifactsLog.Printf("Malformed safe output on line %d: %v", lineNumber, err)
+			lineErrors = append(lineErrors, SafeOutputLineError{Line: lineNumber, Error: err.Error()})
+			continue
+		}
+		if data == nil {
+			lineErrors = append(lineErrors, SafeOutputLineError{Line: lineNumber, Error: "expected a JSON object"})
+			continue
+		}
+
+		recordType, _ := data["type"].(string)
+		records = append(records, SafeOutputRecord{Line: lineNumber, Type: recordType, Data: data})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read safe_output.jsonl: %w", err)
+	}
+	return records, lineErrors, nil
+}
+
+// parsePatchStats computes file and line statistics across aw.patch and the
+// branch-named aw-*.patch files. Returns nil when the run produced no patch.
+func parsePatchStats(dir string) (*PatchStats, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run artifacts directory: %w", err)
+	}
+
+	var patchFiles []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if matched, _ := filepath.Match("aw-*.patch", name); matched || name == "aw.patch" {
+			patchFiles = append(patchFiles, name)
+		}
+	}
+	if len(patchFiles) == 0 {
+		return nil, nil
+	}
+	sort.Strings(patchFiles)
+
+	stats := &PatchStats{PatchFiles: patchFiles}
+	seen := make(map[string]bool)
+	for _, name := range patchFiles {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		// Only lines inside hunks are counted so the ---/+++ file headers are skipped
+		inHunk := false
+		for line := range strings.SplitSeq(string(content), "\n") {
+			switch {
+			case strings.HasPrefix(line, "diff --git "):
+				inHunk = false
+				if file, ok := patchTargetFile(line); ok && !seen[file] {
+					seen[file] = true
+					stats.Files = append(stats.Files, file)
+				}
+			case strings.HasPrefix(line, "@@"):
+				inHunk = true
+			case line == "-- ":
+				// Signature separator that git format-patch appends after the last hunk
+				inHunk = false
+			case !inHunk:
+				// Patch headers and commit messages
+			case strings.HasPrefix(line, "+"):
+				stats.Additions++
+			case strings.HasPrefix(line, "-"):
+				stats.Deletions++
+			}
+		}
+	}
+	stats.FileCount = len(stats.Files)
+	return stats, nil
+}
+
+// patchTargetFile extracts the destination path from a "diff --git a/<path> b/<path>" header
+func patchTargetFile(header string) (string, bool) {
+	idx := strings.LastIndex(header, " b/")
+	if idx < 0 {
+		return "", false
+	}
+	return header[idx+len(" b/"):], true
+}
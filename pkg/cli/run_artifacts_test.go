@@ -0,0 +1,129 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRunPatch = `From 1234567890abcdef Mon Sep 17 00:00:00 2001
+Subject: [PATCH] Update docs
+
+---
+diff --git a/README.md b/README.md
+index 1111111..2222222 100644
+--- a/README.md
++++ b/README.md
+@@ -1,3 +1,4 @@
+ # Title
+-old line
++new line
++another line
+diff --git a/docs/guide.md b/docs/guide.md
+new file mode 100644
+--- /dev/null
++++ b/docs/guide.md
+@@ -0,0 +1,2 @@
++-- not a signature
++second line
+` + "-- \n2.43.0\n"
+
+func writeRunArtifact(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func TestParseRunArtifacts(t *testing.T) {
+	dir := testutil.TempDir(t, "run-artifacts-*")
+
+	writeRunArtifact(t, dir, "aw_info.json", `{"engine_id":"copilot","engine_name":"GitHub Copilot CLI","model":"gpt-5","workflow_name":"Docs Updater","staged":true}`)
+	writeRunArtifact(t, dir, "safe_output.jsonl", `{"type":"create_issue","title":"First"}
+{"type":"add_comment","body":"hello"}
+{"type":"create_issue","title":"unterminated"
+
+{"type":"noop","message":"done"}
+[1,2,3]
+`)
+	writeRunArtifact(t, dir, "aw-docs-update.patch", testRunPatch)
+
+	artifacts, err := ParseRunArtifacts(dir)
+	require.NoError(t, err, "malformed safe output lines should not abort parsing")
+
+	require.NotNil(t, artifacts.AwInfo)
+	assert.Equal(t, "copilot", artifacts.AwInfo.EngineID)
+	assert.Equal(t, "gpt-5", artifacts.AwInfo.Model)
+	assert.Equal(t, "Docs Updater", artifacts.AwInfo.WorkflowName)
+	assert.True(t, artifacts.AwInfo.Staged)
+
+	assert.True(t, artifacts.HasSafeOutputFile)
+	require.Len(t, artifacts.SafeOutputs, 3)
+	assert.Equal(t, 1, artifacts.SafeOutputs[0].Line)
+	assert.Equal(t, "create_issue", artifacts.SafeOutputs[0].Type)
+	assert.Equal(t, "First", artifacts.SafeOutputs[0].Data["title"])
+	assert.Equal(t, "add_comment", artifacts.SafeOutputs[1].Type)
+	assert.Equal(t, 5, artifacts.SafeOutputs[2].Line, "line numbers should count blank lines")
+	assert.Equal(t, map[string]int{"create_issue": 1, "add_comment": 1, "noop": 1}, artifacts.SafeOutputTypeCounts())
+
+	require.Len(t, artifacts.SafeOutputErrors, 2)
+	assert.Equal(t, 3, artifacts.SafeOutputErrors[0].Line)
+	assert.NotEmpty(t, artifacts.SafeOutputErrors[0].Error)
+	assert.Equal(t, 6, artifacts.SafeOutputErrors[1].Line)
+
+	require.NotNil(t, artifacts.Patch)
+	assert.Equal(t, []string{"aw-docs-update.patch"}, artifacts.Patch.PatchFiles)
+	assert.Equal(t, []string{"README.md", "docs/guide.md"}, artifacts.Patch.Files)
+	assert.Equal(t, 2, artifacts.Patch.FileCount)
+	assert.Equal(t, 4, artifacts.Patch.Additions)
+	assert.Equal(t, 1, artifacts.Patch.Deletions)
+}
+
+func TestParseRunArtifacts_MissingArtifacts(t *testing.T) {
+	dir := testutil.TempDir(t, "run-artifacts-empty-*")
+
+	artifacts, err := ParseRunArtifacts(dir)
+	require.NoError(t, err)
+	assert.Nil(t, artifacts.AwInfo)
+	assert.False(t, artifacts.HasSafeOutputFile)
+	assert.Empty(t, artifacts.SafeOutputs)
+	assert.Nil(t, artifacts.Patch)
+
+	_, err = ParseRunArtifacts(filepath.Join(dir, "does-not-exist"))
+	require.Error(t, err)
+}
+
+func TestParseRunArtifacts_MalformedAwInfo(t *testing.T) {
+	dir := testutil.TempDir(t, "run-artifacts-bad-info-*")
+	writeRunArtifact(t, dir, "aw_info.json", `{"engine_id":`)
+
+	_, err := ParseRunArtifacts(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "aw_info.json")
+}
+
+func TestBuildAuditDataIncludesRunArtifacts(t *testing.T) {
+	dir := testutil.TempDir(t, "audit-run-artifacts-*")
+	writeRunArtifact(t, dir, "safe_output.jsonl", "{\"type\":\"create_issue\"}\nnot json\n")
+	writeRunArtifact(t, dir, "aw.patch", testRunPatch)
+
+	processedRun := createTestProcessedRun(func(pr *ProcessedRun) {
+		pr.Run.LogsPath = dir
+	})
+	auditData := buildAuditData(processedRun, LogMetrics{}, nil)
+
+	require.NotNil(t, auditData.SafeOutputs)
+	assert.Equal(t, 1, auditData.SafeOutputs.Total)
+	assert.Equal(t, map[string]int{"create_issue": 1}, auditData.SafeOutputs.ByType)
+	require.Len(t, auditData.SafeOutputs.ParseErrors, 1)
+	assert.Equal(t, 2, auditData.SafeOutputs.ParseErrors[0].Line)
+
+	require.NotNil(t, auditData.Patch)
+	assert.Equal(t, 2, auditData.Patch.FileCount)
+	assert.Equal(t, 4, auditData.Patch.Additions)
+	assert.Equal(t, 1, auditData.Patch.Deletions)
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+var schemaLog = logger.New("cli:schema")
+
+// NewSchemaCommand creates the schema command
+func NewSchemaCommand() *cobra.Command {
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema for workflow frontmatter",
+		Long: `Print the JSON Schema that describes the supported workflow frontmatter.
+
+The schema is the same one the compiler validates frontmatter against, covering
+triggers, permissions, tools, mcp-servers, safe-outputs, engine, network,
+concurrency and every other supported field. Unknown fields (such as a misspelled
+'permision:') and type mismatches are reported as compilation errors.
+
+Point your editor's YAML language server at the schema to get completion and
+validation while authoring workflows.
+
+Examples:
+  gh aw schema                                   # Print the schema to stdout
+  gh aw schema -o .vscode/gh-aw.schema.json      # Write the schema to a file`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunSchema(outputFile)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the schema to this file instead of stdout")
+
+	return cmd
+}
+
+// RunSchema prints the frontmatter JSON Schema to stdout or writes it to outputFile
+func RunSchema(outputFile string) error {
+	schema := parser.GetMainWorkflowSchema()
+
+	if outputFile == "" {
+		schemaLog.Print("Printing frontmatter schema to stdout")
+		fmt.Print(schema)
+		return nil
+	}
+
+	schemaLog.Printf("Writing frontmatter schema to %s", outputFile)
+	if dir := filepath.Dir(outputFile); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(outputFile, []byte(schema), 0644); err != nil {
+		return fmt.Errorf("failed to write schema to %s: %w", outputFile, err)
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Wrote frontmatter schema to "+outputFile))
+	return nil
+}
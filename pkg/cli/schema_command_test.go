@@ -0,0 +1,52 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSchemaWritesFrontmatterSchema(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "schema-command-test")
+	outputFile := filepath.Join(tmpDir, "nested", "gh-aw.schema.json")
+
+	require.NoError(t, RunSchema(outputFile), "writing the schema should succeed")
+
+	content, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(content, &schema), "schema should be valid JSON")
+	properties, ok := schema["properties"].(map[string]any)
+	require.True(t, ok, "schema should describe frontmatter properties")
+	for _, field := range []string{"on", "permissions", "tools", "mcp-servers", "safe-outputs", "engine", "network", "concurrency"} {
+		assert.Contains(t, properties, field, "schema should describe the %s field", field)
+	}
+	assert.Equal(t, false, schema["additionalProperties"], "schema should reject unknown top-level fields")
+}
+
+func TestSchemaFlagsMisspelledKey(t *testing.T) {
+	frontmatter := map[string]any{
+		"on":        "workflow_dispatch",
+		"permision": map[string]any{"contents": "read"},
+	}
+
+	err := parser.ValidateMainWorkflowFrontmatterWithSchema(frontmatter)
+	require.Error(t, err, "a misspelled top-level key should be flagged")
+	assert.Contains(t, err.Error(), "permision", "error should name the misspelled key")
+}
+
+func TestSchemaCommandRegistersOutputFlag(t *testing.T) {
+	cmd := NewSchemaCommand()
+	flag := cmd.Flags().Lookup("output")
+	require.NotNil(t, flag, "schema command should have an --output flag")
+	assert.Equal(t, "o", flag.Shorthand, "--output should have a -o shorthand")
+}
@@ -17,9 +17,20 @@ func TestMain(m *testing.M) {
 		panic("Failed to get current working directory: " + err.Error())
 	}
 
+	// Keep MCP registry lookups made against mock registries out of the user's config directory
+	registryCacheDir, err := os.MkdirTemp("", "gh-aw-mcp-registry-cache-*")
+	if err != nil {
+		panic("Failed to create MCP registry cache directory: " + err.Error())
+	}
+	getMCPRegistryCachePathFunc = func() string {
+		return filepath.Join(registryCacheDir, mcpRegistryCacheFileName)
+	}
+
 	// Run all tests
 	code := m.Run()
 
+	_ = os.RemoveAll(registryCacheDir)
+
 	// Clean up any action cache files created during tests
 	// Tests may create .github/aw/actions-lock.json in the pkg/cli directory
 	actionCacheDir := filepath.Join(wd, ".github")
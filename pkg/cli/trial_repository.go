@@ -335,7 +335,11 @@ type trialWorkflowWriteResult struct {
 func writeWorkflowToTrialDir(tempDir string, workflowName string, content []byte, opts *TrialOptions) (*trialWorkflowWriteResult, error) {
 	// Security scan: reject workflows containing malicious or dangerous content
 	if !opts.DisableSecurityScanner {
-		if findings := workflow.ScanMarkdownSecurity(string(content)); len(findings) > 0 {
+		securityRules, err := loadCustomSecurityRules()
+		if err != nil {
+			return nil, err
+		}
+		if findings := workflow.ScanMarkdownSecurityWithRules(string(content), securityRules); len(findings) > 0 {
 			fmt.Fprintln(os.Stderr, console.FormatErrorMessage("Security scan failed for workflow"))
 			fmt.Fprintln(os.Stderr, workflow.FormatSecurityFindings(findings, workflowName))
 			return nil, fmt.Errorf("workflow '%s' failed security scan: %d issue(s) detected", workflowName, len(findings))
@@ -30,6 +30,9 @@ For workflow updates, it fetches the latest version based on the current ref:
 - If the ref is a branch, it fetches the latest commit from that branch
 - If the ref is a commit SHA, it fetches the latest commit from the default branch
 
+Use --check to report which workflows have upstream updates without changing any files.
+Workflows pinned to a branch do not record the installed commit, so their status is unknown.
+
 For extension updates, action updates, agent files, and codemods, use 'gh aw upgrade'.
 
 ` + WorkflowIDExplanation + `
@@ -41,6 +44,7 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` update --no-merge         # Override local changes with upstream
   ` + string(constants.CLIExtensionPrefix) + ` update repo-assist --major # Allow major version updates
   ` + string(constants.CLIExtensionPrefix) + ` update --force            # Force update even if no changes
+  ` + string(constants.CLIExtensionPrefix) + ` update --check            # Report available updates without applying them
   ` + string(constants.CLIExtensionPrefix) + ` update --dir custom/workflows  # Update workflows in custom directory`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			majorFlag, _ := cmd.Flags().GetBool("major")
@@ -51,10 +55,19 @@ Examples:
 			noStopAfter, _ := cmd.Flags().GetBool("no-stop-after")
 			stopAfter, _ := cmd.Flags().GetString("stop-after")
 			noMergeFlag, _ := cmd.Flags().GetBool("no-merge")
+			checkFlag, _ := cmd.Flags().GetBool("check")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+
+			if checkFlag {
+				return RunUpdateCheck(args, majorFlag, verbose, jsonOutput, workflowDir)
+			}
 
 			if err := validateEngine(engineOverride); err != nil {
 				return err
 			}
+			if err := validateStopAfterFlag(stopAfter); err != nil {
+				return err
+			}
 
 			return RunUpdateWorkflows(args, majorFlag, forceFlag, verbose, engineOverride, workflowDir, noStopAfter, stopAfter, noMergeFlag)
 		},
@@ -67,6 +80,8 @@ Examples:
 	cmd.Flags().Bool("no-stop-after", false, "Remove any stop-after field from the workflow")
 	cmd.Flags().String("stop-after", "", "Override stop-after value in the workflow (e.g., '+48h', '2025-12-31 23:59:59')")
 	cmd.Flags().Bool("no-merge", false, "Override local changes with upstream version instead of merging")
+	cmd.Flags().Bool("check", false, "Report workflows with upstream updates available without changing any files")
+	addJSONFlag(cmd)
 
 	// Register completions for update command
 	cmd.ValidArgsFunction = CompleteWorkflowNames
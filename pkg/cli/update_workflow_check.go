@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var workflowCheckLog = logger.New("cli:update_workflow_check")
+
+// Update check statuses
+const (
+	updateStatusUpToDate  = "up-to-date"
+	updateStatusAvailable = "update-available"
+	updateStatusUnknown   = "unknown"
+	updateStatusError     = "error"
+)
+
+// WorkflowUpdateCheck reports whether an installed workflow is behind its upstream source
+type WorkflowUpdateCheck struct {
+	Name    string `json:"name" console:"header:Workflow"`
+	Repo    string `json:"repo" console:"header:Source"`
+	Current string `json:"current" console:"header:Current"`
+	Latest  string `json:"latest,omitempty" console:"header:Latest"`
+	Status  string `json:"status" console:"header:Status"`
+	Error   string `json:"error,omitempty" console:"-"`
+}
+
+// RunUpdateCheck compares the provenance recorded in each workflow's source field with its
+// upstream repository and reports which workflows have updates available. No files are changed.
+func RunUpdateCheck(workflowNames []string, allowMajor, verbose, jsonOutput bool, workflowsDir string) error {
+	workflowCheckLog.Printf("Checking workflows for updates: workflows=%v, allowMajor=%v", workflowNames, allowMajor)
+
+	if workflowsDir == "" {
+		workflowsDir = getWorkflowsDir()
+	}
+
+	manifest, err := buildWorkflowManifest(workflowsDir, workflowNames, verbose)
+	if err != nil {
+		return err
+	}
+	if len(manifest.Workflows) == 0 {
+		if len(workflowNames) > 0 {
+			return errors.New("no workflows found matching the specified names with source field")
+		}
+		return errors.New("no workflows found with source field")
+	}
+
+	checks := checkWorkflowUpdates(manifest.Workflows, func(repo, ref string) (string, error) {
+		return resolveLatestRef(repo, ref, allowMajor, verbose)
+	})
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(checks, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal update check results: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	showUpdateCheckReport(checks)
+	return nil
+}
+
+// checkWorkflowUpdates resolves the latest upstream ref for each manifest entry with resolve
+// and compares it with the recorded ref
+func checkWorkflowUpdates(entries []WorkflowManifestEntry, resolve func(repo, ref string) (string, error)) []WorkflowUpdateCheck {
+	checks := make([]WorkflowUpdateCheck, 0, len(entries))
+	for _, entry := range entries {
+		current := entry.Ref
+		if current == "" {
+			// Sources without a ref are tracked on main, matching updateWorkflow
+			current = "main"
+		}
+
+		check := WorkflowUpdateCheck{
+			Name:    entry.Name,
+			Repo:    entry.Repo,
+			Current: shortRef(current),
+		}
+
+		latest, err := resolve(entry.Repo, current)
+		if err != nil {
+			workflowCheckLog.Printf("Failed to resolve latest ref for %s: %v", entry.Name, err)
+			check.Status = updateStatusError
+			check.Error = err.Error()
+			checks = append(checks, check)
+			continue
+		}
+
+		check.Latest = shortRef(latest)
+		check.Status = compareWorkflowRefs(current, latest)
+		workflowCheckLog.Printf("Workflow %s: current=%s, latest=%s, status=%s", entry.Name, current, latest, check.Status)
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// compareWorkflowRefs decides whether the recorded ref is behind the latest upstream ref.
+// A branch ref does not record which commit was installed, so its status is unknown.
+func compareWorkflowRefs(current, latest string) string {
+	if isBranchRef(current) {
+		return updateStatusUnknown
+	}
+	if current == latest {
+		return updateStatusUpToDate
+	}
+	return updateStatusAvailable
+}
+
+// showUpdateCheckReport prints the update check results as a table followed by a summary
+func showUpdateCheckReport(checks []WorkflowUpdateCheck) {
+	rows := make([][]string, 0, len(checks))
+	available := 0
+	for _, check := range checks {
+		status := check.Status
+		if check.Error != "" {
+			status = fmt.Sprintf("%s: %s", check.Status, check.Error)
+		}
+		if check.Status == updateStatusAvailable {
+			available++
+		}
+		rows = append(rows, []string{check.Name, check.Repo, check.Current, check.Latest, status})
+	}
+
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:   "Workflow Updates",
+		Headers: []string{"Workflow", "Source", "Current", "Latest", "Status"},
+		Rows:    rows,
+	}))
+
+	if available == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("All workflows with a recorded commit or release are up to date"))
+		return
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("%d workflow(s) have updates available. Run 'gh aw update' to apply them.", available)))
+}
@@ -0,0 +1,56 @@
+//go:build !integration
+
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWorkflowUpdates(t *testing.T) {
+	oldSHA := "1111111111111111111111111111111111111111"
+	newSHA := "2222222222222222222222222222222222222222"
+
+	entries := []WorkflowManifestEntry{
+		{Name: "pinned-current", Repo: "owner/current", Ref: newSHA, SHA: newSHA},
+		{Name: "pinned-stale", Repo: "owner/stale", Ref: oldSHA, SHA: oldSHA},
+		{Name: "tagged", Repo: "owner/tagged", Ref: "v1.0.0"},
+		{Name: "branch", Repo: "owner/branch", Ref: "develop"},
+		{Name: "no-ref", Repo: "owner/no-ref"},
+		{Name: "broken", Repo: "owner/broken", Ref: oldSHA, SHA: oldSHA},
+	}
+
+	var resolvedRefs []string
+	resolve := func(repo, ref string) (string, error) {
+		resolvedRefs = append(resolvedRefs, ref)
+		switch repo {
+		case "owner/tagged":
+			return "v1.1.0", nil
+		case "owner/broken":
+			return "", errors.New("repository not found")
+		}
+		return newSHA, nil
+	}
+
+	checks := checkWorkflowUpdates(entries, resolve)
+	require.Len(t, checks, len(entries), "every workflow should be checked")
+
+	statuses := make(map[string]string)
+	for _, check := range checks {
+		statuses[check.Name] = check.Status
+	}
+	assert.Equal(t, updateStatusUpToDate, statuses["pinned-current"], "matching SHA should be up to date")
+	assert.Equal(t, updateStatusAvailable, statuses["pinned-stale"], "older SHA should have an update")
+	assert.Equal(t, updateStatusAvailable, statuses["tagged"], "older release should have an update")
+	assert.Equal(t, updateStatusUnknown, statuses["branch"], "branch ref has no installed commit to compare")
+	assert.Equal(t, updateStatusUnknown, statuses["no-ref"], "missing ref tracks main")
+	assert.Equal(t, updateStatusError, statuses["broken"], "resolve failures should be reported")
+
+	assert.Equal(t, "1111111", checks[1].Current, "SHAs should be shortened for display")
+	assert.Equal(t, "2222222", checks[1].Latest, "SHAs should be shortened for display")
+	assert.Equal(t, "repository not found", checks[5].Error, "resolve error should be recorded")
+	assert.Equal(t, "main", resolvedRefs[4], "missing ref should resolve against main")
+}
@@ -22,6 +22,7 @@ type UpgradeConfig struct {
 	NoActions   bool
 	Audit       bool
 	JSON        bool
+	All         bool // Upgrade every workflow, even those whose source is unchanged
 }
 
 // RunUpgrade runs the upgrade command with the given configuration
@@ -29,7 +30,7 @@ func RunUpgrade(config UpgradeConfig) error {
 	if config.Audit {
 		return runDependencyAudit(config.Verbose, config.JSON)
 	}
-	return runUpgradeCommand(config.Verbose, config.WorkflowDir, config.NoFix, false, config.Push, config.NoActions)
+	return runUpgradeCommand(config.Verbose, config.WorkflowDir, config.NoFix, false, config.Push, config.NoActions, config.All)
 }
 
 // NewUpgradeCommand creates the upgrade command
@@ -41,9 +42,16 @@ func NewUpgradeCommand() *cobra.Command {
 
 This command:
   1. Updates all agent and prompt files to the latest templates (like 'init' command)
-  2. Applies automatic codemods to fix deprecated fields in all workflows (like 'fix --write')
+  2. Applies automatic codemods to fix deprecated fields in workflows (like 'fix --write')
   3. Updates GitHub Actions versions in .github/aw/actions-lock.json (unless --no-actions is set)
-  4. Compiles all workflows to generate lock files (like 'compile' command)
+  4. Compiles workflows to generate lock files (like 'compile' command)
+
+WORKFLOW SELECTION:
+Workflows added from another repository record their provenance in the 'source' field.
+Such a workflow is only codemodded and recompiled when its upstream has changed since it
+was added: a newer release for a version tag, or a newer default-branch commit for a
+commit SHA. Workflows whose source is a branch, and workflows without a source field, are
+always upgraded. Use --all to upgrade every workflow regardless of its source.
 
 DEPENDENCY HEALTH AUDIT:
 Use --audit to check dependency health without performing upgrades. This includes:
@@ -62,10 +70,9 @@ The upgrade process ensures:
 - GitHub Actions are pinned to the latest versions
 - All workflows are compiled and lock files are up-to-date
 
-This command always upgrades all Markdown files in .github/workflows.
-
 Examples:
-  ` + string(constants.CLIExtensionPrefix) + ` upgrade                    # Upgrade all workflows
+  ` + string(constants.CLIExtensionPrefix) + ` upgrade                    # Upgrade workflows whose source changed
+  ` + string(constants.CLIExtensionPrefix) + ` upgrade --all              # Upgrade all workflows
   ` + string(constants.CLIExtensionPrefix) + ` upgrade --no-fix          # Update agent files only (skip codemods, actions, and compilation)
   ` + string(constants.CLIExtensionPrefix) + ` upgrade --no-actions      # Skip updating GitHub Actions versions
   ` + string(constants.CLIExtensionPrefix) + ` upgrade --push            # Upgrade and automatically commit/push changes
@@ -81,13 +88,14 @@ Examples:
 			noActions, _ := cmd.Flags().GetBool("no-actions")
 			auditFlag, _ := cmd.Flags().GetBool("audit")
 			jsonOutput, _ := cmd.Flags().GetBool("json")
+			all, _ := cmd.Flags().GetBool("all")
 
 			// Handle audit mode
 			if auditFlag {
 				return runDependencyAudit(verbose, jsonOutput)
 			}
 
-			return runUpgradeCommand(verbose, dir, noFix, false, push, noActions)
+			return runUpgradeCommand(verbose, dir, noFix, false, push, noActions, all)
 		},
 	}
 
@@ -96,6 +104,7 @@ Examples:
 	cmd.Flags().Bool("no-actions", false, "Skip updating GitHub Actions versions")
 	cmd.Flags().Bool("push", false, "Automatically commit and push changes after successful upgrade")
 	cmd.Flags().Bool("audit", false, "Check dependency health without performing upgrades")
+	cmd.Flags().Bool("all", false, "Upgrade all workflows, including those whose source is unchanged")
 	addJSONFlag(cmd)
 
 	// Register completions
@@ -124,9 +133,9 @@ func runDependencyAudit(verbose bool, jsonOutput bool) error {
 }
 
 // runUpgradeCommand executes the upgrade process
-func runUpgradeCommand(verbose bool, workflowDir string, noFix bool, noCompile bool, push bool, noActions bool) error {
-	upgradeLog.Printf("Running upgrade command: verbose=%v, workflowDir=%s, noFix=%v, noCompile=%v, push=%v, noActions=%v",
-		verbose, workflowDir, noFix, noCompile, push, noActions)
+func runUpgradeCommand(verbose bool, workflowDir string, noFix bool, noCompile bool, push bool, noActions bool, all bool) error {
+	upgradeLog.Printf("Running upgrade command: verbose=%v, workflowDir=%s, noFix=%v, noCompile=%v, push=%v, noActions=%v, all=%v",
+		verbose, workflowDir, noFix, noCompile, push, noActions, all)
 
 	// Step 0a: If --push is enabled, ensure git status is clean before starting
 	if push {
@@ -161,13 +170,27 @@ func runUpgradeCommand(verbose bool, workflowDir string, noFix bool, noCompile b
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("✓ Updated agent and prompt files"))
 	}
 
-	// Step 2: Apply codemods to all workflows (unless --no-fix is specified)
-	if !noFix {
-		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Applying codemods to all workflows..."))
-		upgradeLog.Print("Applying codemods to all workflows")
+	// Select the workflows to codemod and recompile: nil means all workflows, otherwise only
+	// workflows whose source changed and workflows without a source (unless --all is specified)
+	var workflowFiles []string
+	if !noFix && !all {
+		selected, err := selectUpgradeWorkflows(workflowDir, verbose)
+		if err != nil {
+			upgradeLog.Printf("Failed to select workflows by source, upgrading all: %v", err)
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: Failed to check workflow sources, upgrading all workflows: %v", err)))
+		} else {
+			workflowFiles = selected
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Upgrading %d workflow(s) with changed or local sources (use --all to upgrade every workflow)", len(workflowFiles))))
+		}
+	}
+
+	// Step 2: Apply codemods to the selected workflows (unless --no-fix is specified)
+	if !noFix && (workflowFiles == nil || len(workflowFiles) > 0) {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Applying codemods to workflows..."))
+		upgradeLog.Print("Applying codemods to workflows")
 
 		fixConfig := FixConfig{
-			WorkflowIDs: nil, // nil means all workflows
+			WorkflowIDs: workflowFiles, // nil means all workflows
 			Write:       true,
 			Verbose:     verbose,
 			WorkflowDir: workflowDir,
@@ -178,7 +201,7 @@ func runUpgradeCommand(verbose bool, workflowDir string, noFix bool, noCompile b
 			// Don't fail the upgrade if fix fails - this is non-critical
 			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: Failed to apply codemods: %v", err)))
 		}
-	} else {
+	} else if noFix {
 		upgradeLog.Print("Skipping codemods (--no-fix specified)")
 		if verbose {
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Skipping codemods (--no-fix specified)"))
@@ -211,10 +234,10 @@ func runUpgradeCommand(verbose bool, workflowDir string, noFix bool, noCompile b
 		}
 	}
 
-	// Step 4: Compile all workflows (unless --no-fix is specified)
-	if !noFix {
-		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Compiling all workflows..."))
-		upgradeLog.Print("Compiling all workflows")
+	// Step 4: Compile the selected workflows (unless --no-fix is specified)
+	if !noFix && (workflowFiles == nil || len(workflowFiles) > 0) {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Compiling workflows..."))
+		upgradeLog.Print("Compiling workflows")
 
 		// Create and configure compiler
 		compiler := createAndConfigureCompiler(CompileConfig{
@@ -228,8 +251,8 @@ func runUpgradeCommand(verbose bool, workflowDir string, noFix bool, noCompile b
 			workflowsDir = ".github/workflows"
 		}
 
-		// Compile all workflow files
-		stats, compileErr := compileAllWorkflowFiles(compiler, workflowsDir, verbose)
+		// Compile the selected workflow files
+		stats, compileErr := compileUpgradeWorkflows(compiler, workflowsDir, workflowFiles, verbose)
 		if compileErr != nil {
 			upgradeLog.Printf("Failed to compile workflows: %v", compileErr)
 			// Don't fail the upgrade if compilation fails - this is non-critical
@@ -243,7 +266,7 @@ func runUpgradeCommand(verbose bool, workflowDir string, noFix bool, noCompile b
 				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Warning: %d workflow(s) failed to compile", stats.Errors)))
 			}
 		}
-	} else {
+	} else if noFix {
 		upgradeLog.Print("Skipping compilation (--no-fix specified)")
 		if verbose {
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Skipping compilation (--no-fix specified)"))
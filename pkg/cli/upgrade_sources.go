@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var upgradeSourcesLog = logger.New("cli:upgrade_sources")
+
+// resolveLatestSourceRef resolves the latest ref of a workflow source. It is a variable so
+// tests can avoid GitHub API calls.
+var resolveLatestSourceRef = func(repo, currentRef string, verbose bool) (string, error) {
+	return resolveLatestRef(repo, currentRef, false, verbose)
+}
+
+// selectUpgradeWorkflows returns the workflow files in workflowsDir that upgrade should codemod
+// and recompile. Workflows added from another repository are selected only when the upstream
+// recorded in their source field has changed since they were added; workflows without a source
+// field are always selected. Workflows whose source is a branch, or whose latest ref cannot be
+// resolved, are selected because their recorded provenance cannot be compared.
+func selectUpgradeWorkflows(workflowsDir string, verbose bool) ([]string, error) {
+	if workflowsDir == "" {
+		workflowsDir = getWorkflowsDir()
+	}
+
+	files, err := getMarkdownWorkflowFiles(workflowsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	sourced, err := findWorkflowsWithSource(workflowsDir, nil, verbose)
+	if err != nil {
+		return nil, err
+	}
+	upgradeSourcesLog.Printf("Checking %d workflow(s) with source field for upstream changes", len(sourced))
+
+	unchanged := make(map[string]bool)
+	for _, wf := range sourced {
+		if !isSourceStale(wf, verbose) {
+			unchanged[filepath.Clean(wf.Path)] = true
+		}
+	}
+
+	selected := make([]string, 0, len(files))
+	for _, file := range files {
+		if !unchanged[filepath.Clean(file)] {
+			selected = append(selected, file)
+		}
+	}
+	upgradeSourcesLog.Printf("Selected %d of %d workflow(s) for upgrade", len(selected), len(files))
+	return selected, nil
+}
+
+// isSourceStale reports whether the upstream of a workflow has changed since it was added
+func isSourceStale(wf *workflowWithSource, verbose bool) bool {
+	sourceSpec, err := parseSourceSpec(wf.SourceSpec)
+	if err != nil {
+		upgradeSourcesLog.Printf("Treating %s as stale: %v", wf.Name, err)
+		return true
+	}
+
+	currentRef := sourceSpec.Ref
+	if currentRef == "" || isBranchRef(currentRef) {
+		upgradeSourcesLog.Printf("Treating %s as stale: source %q records no commit or release", wf.Name, wf.SourceSpec)
+		return true
+	}
+
+	latestRef, err := resolveLatestSourceRef(sourceSpec.Repo, currentRef, verbose)
+	if err != nil {
+		upgradeSourcesLog.Printf("Treating %s as stale: failed to resolve latest ref: %v", wf.Name, err)
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to check source of %s, upgrading it: %v", wf.Name, err)))
+		}
+		return true
+	}
+
+	if latestRef == currentRef {
+		upgradeSourcesLog.Printf("Workflow %s is up to date with its source (%s)", wf.Name, shortRef(currentRef))
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Skipping %s: source is unchanged (%s)", wf.Name, shortRef(currentRef))))
+		}
+		return false
+	}
+
+	upgradeSourcesLog.Printf("Workflow %s source changed: %s -> %s", wf.Name, shortRef(currentRef), shortRef(latestRef))
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Source of %s changed (%s -> %s)", wf.Name, shortRef(currentRef), shortRef(latestRef))))
+	}
+	return true
+}
+
+// compileUpgradeWorkflows compiles the given workflow files, or every workflow in workflowsDir
+// when files is nil
+func compileUpgradeWorkflows(compiler *workflow.Compiler, workflowsDir string, files []string, verbose bool) (*CompilationStats, error) {
+	if files == nil {
+		return compileAllWorkflowFiles(compiler, workflowsDir, verbose)
+	}
+
+	// Reuse one compiler for all files so the action pin and import caches are loaded once
+	compiler.Reset()
+	stats := &CompilationStats{}
+	for _, file := range files {
+		compileSingleFile(compiler, file, stats, verbose, false)
+	}
+	stats.Warnings = compiler.GetWarningCount()
+
+	// Save the action cache after compilations
+	if actionCache := compiler.GetSharedActionCache(); actionCache != nil {
+		if err := actionCache.Save(); err != nil {
+			upgradeSourcesLog.Printf("Failed to save action cache: %v", err)
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to save action cache: %v", err)))
+			}
+		}
+	}
+
+	// Ensure .gitattributes marks .lock.yml files as generated
+	if stats.Total > stats.Errors {
+		if err := ensureGitAttributes(); err != nil && verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to update .gitattributes: %v", err)))
+		}
+	}
+
+	return stats, nil
+}
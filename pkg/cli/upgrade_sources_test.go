@@ -0,0 +1,125 @@
+//go:build !integration
+
+package cli
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	upgradeTestStaleSHA   = "1111111111111111111111111111111111111111"
+	upgradeTestLatestSHA  = "2222222222222222222222222222222222222222"
+	upgradeTestCurrentSHA = "3333333333333333333333333333333333333333"
+)
+
+// stubResolveLatestSourceRef makes upgradeTestStaleSHA resolve to upgradeTestLatestSHA and
+// every other ref resolve to itself
+func stubResolveLatestSourceRef(t *testing.T) {
+	original := resolveLatestSourceRef
+	t.Cleanup(func() { resolveLatestSourceRef = original })
+	resolveLatestSourceRef = func(repo, currentRef string, verbose bool) (string, error) {
+		if repo == "test/unreachable" {
+			return "", errors.New("repository not found")
+		}
+		if currentRef == upgradeTestStaleSHA {
+			return upgradeTestLatestSHA, nil
+		}
+		return currentRef, nil
+	}
+}
+
+func upgradeTestWorkflow(source string) string {
+	sourceLine := ""
+	if source != "" {
+		sourceLine = "source: " + source + "\n"
+	}
+	return `---
+on:
+  workflow_dispatch:
+` + sourceLine + `timeout_minutes: 30
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Test Workflow
+
+This is a test workflow.
+`
+}
+
+func TestSelectUpgradeWorkflows(t *testing.T) {
+	stubResolveLatestSourceRef(t)
+	workflowsDir := testutil.TempDir(t, "upgrade-select-test")
+
+	files := map[string]string{
+		"stale.md":       upgradeTestWorkflow("test/repo/workflows/stale.md@" + upgradeTestStaleSHA),
+		"current.md":     upgradeTestWorkflow("test/repo/workflows/current.md@" + upgradeTestCurrentSHA),
+		"branch.md":      upgradeTestWorkflow("test/repo/workflows/branch.md@main"),
+		"unreachable.md": upgradeTestWorkflow("test/unreachable/workflows/unreachable.md@" + upgradeTestCurrentSHA),
+		"local.md":       upgradeTestWorkflow(""),
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, name), []byte(content), 0644), "Should write %s", name)
+	}
+
+	selected, err := selectUpgradeWorkflows(workflowsDir, false)
+	require.NoError(t, err, "Selection should succeed")
+
+	var names []string
+	for _, file := range selected {
+		names = append(names, filepath.Base(file))
+	}
+	assert.ElementsMatch(t, []string{"stale.md", "branch.md", "unreachable.md", "local.md"}, names,
+		"Only workflows with an unchanged source should be skipped")
+}
+
+func TestUpgradeOnlyTouchesWorkflowsWithStaleSource(t *testing.T) {
+	stubResolveLatestSourceRef(t)
+	tmpDir := testutil.TempDir(t, "upgrade-stale-source-test")
+	originalDir, err := os.Getwd()
+	require.NoError(t, err, "Should get current directory")
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+	require.NoError(t, os.Chdir(tmpDir), "Should change to temp directory")
+	if err := exec.Command("git", "init").Run(); err != nil {
+		t.Skip("Skipping test - git not available")
+	}
+
+	workflowsDir := filepath.Join(".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Should create workflows directory")
+	staleFile := filepath.Join(workflowsDir, "stale.md")
+	currentFile := filepath.Join(workflowsDir, "current.md")
+	currentContent := upgradeTestWorkflow("test/repo/workflows/current.md@" + upgradeTestCurrentSHA)
+	require.NoError(t, os.WriteFile(staleFile, []byte(upgradeTestWorkflow("test/repo/workflows/stale.md@"+upgradeTestStaleSHA)), 0644))
+	require.NoError(t, os.WriteFile(currentFile, []byte(currentContent), 0644))
+
+	selected, err := selectUpgradeWorkflows(workflowsDir, false)
+	require.NoError(t, err, "Selection should succeed")
+	require.Equal(t, []string{staleFile}, selected, "Only the workflow with a stale source should be selected")
+
+	require.NoError(t, RunFix(FixConfig{WorkflowIDs: selected, Write: true, WorkflowDir: workflowsDir}), "Codemods should apply")
+	compiler := createAndConfigureCompiler(CompileConfig{WorkflowDir: workflowsDir})
+	stats, err := compileUpgradeWorkflows(compiler, workflowsDir, selected, false)
+	require.NoError(t, err, "Compilation should succeed")
+	assert.Equal(t, 1, stats.Total, "Only one workflow should be compiled")
+
+	staleContent, err := os.ReadFile(staleFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(staleContent), "timeout-minutes: 30", "Stale workflow should be codemodded")
+	assert.FileExists(t, filepath.Join(workflowsDir, "stale.lock.yml"), "Stale workflow should be recompiled")
+
+	unchangedContent, err := os.ReadFile(currentFile)
+	require.NoError(t, err)
+	assert.Equal(t, currentContent, string(unchangedContent), "Workflow with an unchanged source should not be codemodded")
+	assert.NoFileExists(t, filepath.Join(workflowsDir, "current.lock.yml"), "Workflow with an unchanged source should not be recompiled")
+}
@@ -332,9 +332,15 @@ func extractEngineIDFromFile(filePath string) string {
 		return "" // Return empty string if frontmatter cannot be parsed
 	}
 
+	return extractEngineIDFromFrontmatter(result.Frontmatter)
+}
+
+// extractEngineIDFromFrontmatter returns the engine ID set in a workflow's frontmatter,
+// or the default engine when none is set
+func extractEngineIDFromFrontmatter(frontmatter map[string]any) string {
 	// Use the workflow package's extractEngineConfig to handle both string and object formats
 	compiler := &workflow.Compiler{}
-	engineSetting, engineConfig := compiler.ExtractEngineConfig(result.Frontmatter)
+	engineSetting, engineConfig := compiler.ExtractEngineConfig(frontmatter)
 
 	// If engine is specified, return the ID from the config
 	if engineConfig != nil && engineConfig.ID != "" {
@@ -365,6 +365,10 @@ const (
 	// for selecting the model. Setting this env var is equivalent to passing --model to the CLI.
 	GeminiCLIModelEnvVar = "GEMINI_MODEL"
 
+	// ClaudeCLIMaxOutputTokensEnvVar is the native environment variable name supported by the
+	// Claude Code CLI for limiting the number of output tokens per model response.
+	ClaudeCLIMaxOutputTokensEnvVar = "CLAUDE_CODE_MAX_OUTPUT_TOKENS"
+
 	// Common environment variable names used across all engines
 
 	// EnvVarPrompt is the path to the workflow prompt file
@@ -382,6 +386,18 @@ const (
 	// EnvVarStartupTimeout is the tool startup timeout in seconds
 	EnvVarStartupTimeout = "GH_AW_STARTUP_TIMEOUT"
 
+	// EnvVarMCPHealthChecks is the JSON object mapping MCP server names to their startup health check timeout in seconds
+	EnvVarMCPHealthChecks = "GH_AW_MCP_HEALTH_CHECKS"
+
+	// EnvVarMCPLogMaxSizeBytes is the size in bytes above which rotate_mcp_logs.sh rotates an MCP log file
+	EnvVarMCPLogMaxSizeBytes = "GH_AW_MCP_LOG_MAX_SIZE_BYTES"
+
+	// EnvVarMCPLogMaxAgeSeconds is the age in seconds after which rotated MCP log files are removed
+	EnvVarMCPLogMaxAgeSeconds = "GH_AW_MCP_LOG_MAX_AGE_SECONDS"
+
+	// EnvVarMCPLogMaxFiles is the number of rotated copies kept for each MCP log file
+	EnvVarMCPLogMaxFiles = "GH_AW_MCP_LOG_MAX_FILES"
+
 	// EnvVarToolTimeout is the tool execution timeout in seconds
 	EnvVarToolTimeout = "GH_AW_TOOL_TIMEOUT"
 
@@ -422,6 +438,10 @@ const DefaultMCPGatewayContainer = "ghcr.io/github/gh-aw-mcpg"
 // This directory is shared between the agent container and MCP gateway for large payload exchange
 const DefaultMCPGatewayPayloadDir = "/tmp/gh-aw/mcp-payloads"
 
+// DefaultMCPLogsDir is the directory where MCP servers and the MCP gateway write their logs.
+// Everything under it is uploaded with the agent artifacts.
+const DefaultMCPLogsDir = "/tmp/gh-aw/mcp-logs"
+
 // DefaultFirewallRegistry is the container image registry for AWF (gh-aw-firewall) Docker images
 const DefaultFirewallRegistry = "ghcr.io/github/gh-aw-firewall"
 
@@ -535,6 +555,10 @@ const DefaultToolTimeout = 60 * time.Second
 // DefaultMCPStartupTimeout is the default timeout for MCP server startup
 const DefaultMCPStartupTimeout = 120 * time.Second
 
+// DefaultMCPServerStartupTimeout is the default time a custom MCP server has to pass its
+// startup health check before the MCP gateway step fails
+const DefaultMCPServerStartupTimeout = 60 * time.Second
+
 // DefaultActivationJobRunnerImage is the default runner image for activation and pre-activation jobs
 const DefaultActivationJobRunnerImage = "ubuntu-slim"
 
@@ -686,6 +710,10 @@ const (
 	DangerousPermissionsWriteFeatureFlag FeatureFlag = "dangerous-permissions-write"
 	// DisableXPIAPromptFeatureFlag is the feature flag name for disabling XPIA prompt
 	DisableXPIAPromptFeatureFlag FeatureFlag = "disable-xpia-prompt"
+	// DerivePermissionsFeatureFlag is the feature flag name for deriving default permissions from safe-outputs
+	DerivePermissionsFeatureFlag FeatureFlag = "derive-permissions"
+	// DisableSafeOutputsPromptCheckFeatureFlag is the feature flag name for disabling the check for safe outputs mentioned in the prompt but not configured
+	DisableSafeOutputsPromptCheckFeatureFlag FeatureFlag = "disable-safe-outputs-prompt-check"
 )
 
 // Step IDs for pre-activation job
@@ -697,6 +725,8 @@ const CheckCommandPositionStepID StepID = "check_command_position"
 const CheckRateLimitStepID StepID = "check_rate_limit"
 const CheckSkipRolesStepID StepID = "check_skip_roles"
 const CheckSkipBotsStepID StepID = "check_skip_bots"
+const CheckRequiredSecretsStepID StepID = "check_required_secrets"
+const CheckChangedFilesStepID StepID = "check_changed_files"
 
 // Output names for pre-activation job steps
 const IsTeamMemberOutput = "is_team_member"
@@ -708,7 +738,10 @@ const MatchedCommandOutput = "matched_command"
 const RateLimitOkOutput = "rate_limit_ok"
 const SkipRolesOkOutput = "skip_roles_ok"
 const SkipBotsOkOutput = "skip_bots_ok"
+const RequiredSecretsOkOutput = "secrets_ok"
+const ChangedFilesOkOutput = "changed_files_ok"
 const ActivatedOutput = "activated"
+const ReactionIDOutput = "reaction_id"
 
 // Rate limit defaults
 const DefaultRateLimitMax = 5     // Default maximum runs per time window
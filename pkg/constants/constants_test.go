@@ -302,6 +302,7 @@ func TestTimeoutConstants(t *testing.T) {
 		{"DefaultAgenticWorkflowTimeout", DefaultAgenticWorkflowTimeout, 1 * time.Minute},
 		{"DefaultToolTimeout", DefaultToolTimeout, 1 * time.Second},
 		{"DefaultMCPStartupTimeout", DefaultMCPStartupTimeout, 1 * time.Second},
+		{"DefaultMCPServerStartupTimeout", DefaultMCPServerStartupTimeout, 1 * time.Second},
 	}
 
 	for _, tt := range tests {
@@ -324,6 +325,7 @@ func TestFeatureFlagConstants(t *testing.T) {
 		{"MCPGatewayFeatureFlag", MCPGatewayFeatureFlag, "mcp-gateway"},
 		{"DangerousPermissionsWriteFeatureFlag", DangerousPermissionsWriteFeatureFlag, "dangerous-permissions-write"},
 		{"DisableXPIAPromptFeatureFlag", DisableXPIAPromptFeatureFlag, "disable-xpia-prompt"},
+		{"DisableSafeOutputsPromptCheckFeatureFlag", DisableSafeOutputsPromptCheckFeatureFlag, "disable-safe-outputs-prompt-check"},
 	}
 
 	for _, tt := range tests {
@@ -3,8 +3,10 @@
 package parser
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -546,39 +548,81 @@ func TestProcessIncludesOptional(t *testing.T) {
 }
 
 func TestProcessIncludesWithCycleDetection(t *testing.T) {
-	// Create a temporary directory for test files
-	tempDir, err := os.MkdirTemp("", "test_cycle_detection")
-	if err != nil {
-		t.Fatalf("Failed to create temp dir: %v", err)
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectedCycle []string
+	}{
+		{
+			name: "two-file cycle",
+			files: map[string]string{
+				"fileA.md": "# File A\n@include fileB.md\n",
+				"fileB.md": "# File B\n@include fileA.md\n",
+			},
+			expectedCycle: []string{"fileA.md", "fileB.md", "fileA.md"},
+		},
+		{
+			name: "three-file cycle",
+			files: map[string]string{
+				"fileA.md": "# File A\n{{#import fileB.md}}\n",
+				"fileB.md": "# File B\n{{#import fileC.md#Section}}\n",
+				"fileC.md": "# File C\n## Section\n{{#import? fileA.md}}\n",
+			},
+			expectedCycle: []string{"fileA.md", "fileB.md", "fileC.md", "fileA.md"},
+		},
 	}
-	defer os.RemoveAll(tempDir)
 
-	// Create file A that includes file B
-	fileA := filepath.Join(tempDir, "fileA.md")
-	if err := os.WriteFile(fileA, []byte("# File A\n@include fileB.md\n"), 0644); err != nil {
-		t.Fatalf("Failed to write fileA: %v", err)
-	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
 
-	// Create file B that includes file A (creating a cycle)
-	fileB := filepath.Join(tempDir, "fileB.md")
-	if err := os.WriteFile(fileB, []byte("# File B\n@include fileA.md\n"), 0644); err != nil {
-		t.Fatalf("Failed to write fileB: %v", err)
-	}
+			_, err := ProcessIncludes("# Main\n@include fileA.md\n", tempDir, false)
+			if err == nil {
+				t.Fatal("ProcessIncludes with a cycle should return an error")
+			}
 
-	// Process includes from file A - should not hang due to cycle detection
-	content := "# Main\n@include fileA.md\n"
-	result, err := ProcessIncludes(content, tempDir, false)
+			var cycleErr *ImportCycleError
+			if !errors.As(err, &cycleErr) {
+				t.Fatalf("Expected ImportCycleError, got: %v", err)
+			}
+			expected := make([]string, 0, len(tt.expectedCycle))
+			for _, name := range tt.expectedCycle {
+				expected = append(expected, filepath.Join(tempDir, name))
+			}
+			if !slices.Equal(cycleErr.Chain, expected) {
+				t.Errorf("Cycle chain = %v, expected %v", cycleErr.Chain, expected)
+			}
+			if !strings.Contains(err.Error(), strings.Join(expected, " → ")) {
+				t.Errorf("Error should list the loop, got: %v", err)
+			}
+		})
+	}
+}
 
-	if err != nil {
-		t.Errorf("ProcessIncludes with cycle should not error: %v", err)
+func TestProcessIncludesSharedFileIsNotACycle(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"fileA.md":  "# File A\n@include common.md\n",
+		"fileB.md":  "# File B\n@include common.md\n",
+		"common.md": "# Common\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
 	}
 
-	// Result should contain content from fileA and fileB, but cycle should be prevented
-	if !strings.Contains(result, "File A") {
-		t.Errorf("ProcessIncludes result should contain File A content")
+	result, err := ProcessIncludes("@include fileA.md\n@include fileB.md\n", tempDir, false)
+	if err != nil {
+		t.Fatalf("A file included from two places is not a cycle: %v", err)
 	}
-	if !strings.Contains(result, "File B") {
-		t.Errorf("ProcessIncludes result should contain File B content")
+	if strings.Count(result, "# Common") != 1 {
+		t.Errorf("Shared include should be expanded once, got:\n%s", result)
 	}
 }
 
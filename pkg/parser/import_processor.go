@@ -198,7 +198,7 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 				// Simple string import
 				importSpecs = append(importSpecs, ImportSpec{Path: importItem})
 			case map[string]any:
-				// Object import with path and optional inputs
+				// Object import with path, optional ref and optional inputs
 				pathValue, hasPath := importItem["path"]
 				if !hasPath {
 					return nil, errors.New("import object must have a 'path' field")
@@ -207,6 +207,13 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 				if !ok {
 					return nil, errors.New("import 'path' must be a string")
 				}
+				if refValue, hasRef := importItem["ref"]; hasRef {
+					pinnedPath, err := pinImportPath(pathStr, refValue)
+					if err != nil {
+						return nil, err
+					}
+					pathStr = pinnedPath
+				}
 				var inputs map[string]any
 				if inputsValue, hasInputs := importItem["inputs"]; hasInputs {
 					if inputsMap, ok := inputsValue.(map[string]any); ok {
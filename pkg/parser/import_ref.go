@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var importRefLog = logger.New("parser:import_ref")
+
+// pinImportPath resolves an import declared as an object with a 'ref' field, e.g.
+//
+//	imports:
+//	  - path: owner/repo/shared.md
+//	    ref: v1.2.0
+//
+// The ref is resolved to a commit SHA and the returned workflowspec (owner/repo/shared.md@<sha>)
+// locks the import to that commit, so the compiled workflow and its manifest record exactly
+// which content was imported.
+func pinImportPath(importPath string, refValue any) (string, error) {
+	ref, ok := refValue.(string)
+	if !ok {
+		return "", errors.New("import 'ref' must be a string")
+	}
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", fmt.Errorf("import '%s' has an empty 'ref': set it to a branch, tag or commit SHA", importPath)
+	}
+
+	filePath, sectionName, hasSection := strings.Cut(importPath, "#")
+	if !isWorkflowSpec(filePath) {
+		return "", fmt.Errorf("import 'ref' can only be used with cross-repository paths in owner/repo/path format, got '%s'", importPath)
+	}
+	if strings.Contains(filePath, "@") {
+		return "", fmt.Errorf("import '%s' already pins a ref in its path: remove the '@' suffix or the 'ref' field", importPath)
+	}
+
+	parts := strings.SplitN(filePath, "/", 3)
+	owner, repo := parts[0], parts[1]
+	sha, err := resolveImportRefFunc(owner, repo, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve ref '%s' of import '%s': %w", ref, filePath, err)
+	}
+	importRefLog.Printf("Pinned import %s@%s to commit %s", filePath, ref, sha)
+
+	pinned := filePath + "@" + sha
+	if hasSection {
+		pinned += "#" + sectionName
+	}
+	return pinned, nil
+}
@@ -0,0 +1,107 @@
+//go:build !integration
+
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testImportSHA = "0123456789abcdef0123456789abcdef01234567"
+
+// stubImportRefResolver replaces the ref resolver for the duration of a test
+func stubImportRefResolver(t *testing.T, refs map[string]string) {
+	t.Helper()
+	original := resolveImportRefFunc
+	resolveImportRefFunc = func(owner, repo, ref string) (string, error) {
+		if sha, ok := refs[owner+"/"+repo+"@"+ref]; ok {
+			return sha, nil
+		}
+		return "", errors.New("ref not found")
+	}
+	t.Cleanup(func() { resolveImportRefFunc = original })
+}
+
+func TestPinImportPath(t *testing.T) {
+	stubImportRefResolver(t, map[string]string{"octo/shared@v1.2.0": testImportSHA})
+
+	tests := []struct {
+		name        string
+		path        string
+		ref         any
+		expected    string
+		expectError string
+	}{
+		{
+			name:     "cross-repo import pinned to tag",
+			path:     "octo/shared/workflows/common.md",
+			ref:      "v1.2.0",
+			expected: "octo/shared/workflows/common.md@" + testImportSHA,
+		},
+		{
+			name:     "section reference is kept",
+			path:     "octo/shared/workflows/common.md#Setup",
+			ref:      " v1.2.0 ",
+			expected: "octo/shared/workflows/common.md@" + testImportSHA + "#Setup",
+		},
+		{
+			name:        "missing ref",
+			path:        "octo/shared/workflows/common.md",
+			ref:         "v9.9.9",
+			expectError: "failed to resolve ref 'v9.9.9' of import 'octo/shared/workflows/common.md': ref not found",
+		},
+		{
+			name:        "empty ref",
+			path:        "octo/shared/workflows/common.md",
+			ref:         "",
+			expectError: "has an empty 'ref'",
+		},
+		{
+			name:        "non-string ref",
+			path:        "octo/shared/workflows/common.md",
+			ref:         12,
+			expectError: "import 'ref' must be a string",
+		},
+		{
+			name:        "local path",
+			path:        "shared/common.md",
+			ref:         "v1.2.0",
+			expectError: "can only be used with cross-repository paths",
+		},
+		{
+			name:        "ref already in path",
+			path:        "octo/shared/workflows/common.md@main",
+			ref:         "v1.2.0",
+			expectError: "already pins a ref in its path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pinned, err := pinImportPath(tt.path, tt.ref)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, pinned)
+		})
+	}
+}
+
+func TestProcessImportsWithMissingRef(t *testing.T) {
+	stubImportRefResolver(t, nil)
+
+	frontmatter := map[string]any{
+		"imports": []any{
+			map[string]any{"path": "octo/shared/workflows/common.md", "ref": "v9.9.9"},
+		},
+	}
+	_, err := ProcessImportsFromFrontmatterWithManifest(frontmatter, t.TempDir(), nil)
+	require.Error(t, err, "an import pinned to a ref that does not exist should fail")
+	assert.Contains(t, err.Error(), "failed to resolve ref 'v9.9.9' of import 'octo/shared/workflows/common.md'")
+}
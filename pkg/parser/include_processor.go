@@ -4,9 +4,11 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
@@ -25,6 +27,13 @@ func ProcessIncludes(content, baseDir string, extractTools bool) (string, error)
 
 // processIncludesWithVisited processes import directives with cycle detection
 func processIncludesWithVisited(content, baseDir string, extractTools bool, visited map[string]bool) (string, error) {
+	return processIncludesWithChain(content, baseDir, extractTools, visited, nil)
+}
+
+// processIncludesWithChain processes import directives, where chain holds the files currently being
+// expanded. Including a file that is already in the chain is reported as an ImportCycleError, while
+// a file that was fully expanded earlier (e.g. included twice) is skipped.
+func processIncludesWithChain(content, baseDir string, extractTools bool, visited map[string]bool, chain []string) (string, error) {
 	scanner := bufio.NewScanner(strings.NewReader(content))
 	var result bytes.Buffer
 
@@ -76,6 +85,13 @@ func processIncludesWithVisited(content, baseDir string, extractTools bool, visi
 				return "", fmt.Errorf("failed to resolve required include '%s': %w", filePath, err)
 			}
 
+			// A file that includes itself, directly or through other files, can never be expanded
+			if slices.Contains(chain, fullPath) {
+				cycle := append(slices.Clone(chain), fullPath)
+				includeLog.Printf("Include cycle detected: %v", cycle)
+				return "", &ImportCycleError{Chain: cycle}
+			}
+
 			// Check for repeated imports using the resolved full path
 			if visited[fullPath] {
 				includeLog.Printf("Skipping already included file: %s", fullPath)
@@ -90,8 +106,13 @@ func processIncludesWithVisited(content, baseDir string, extractTools bool, visi
 			visited[fullPath] = true
 
 			// Process the included file
-			includedContent, err := processIncludedFileWithVisited(fullPath, sectionName, extractTools, visited)
+			includedContent, err := processIncludedFileWithChain(fullPath, sectionName, extractTools, visited, append(slices.Clone(chain), fullPath))
 			if err != nil {
+				// Return cycle errors unwrapped so the chain is not buried under one wrapper per level
+				var cycleErr *ImportCycleError
+				if errors.As(err, &cycleErr) {
+					return "", err
+				}
 				// For any processing errors, fail compilation
 				return "", fmt.Errorf("failed to process included file '%s': %w", fullPath, err)
 			}
@@ -116,6 +137,12 @@ func processIncludesWithVisited(content, baseDir string, extractTools bool, visi
 // processIncludedFile processes a single included file, optionally extracting a section
 // processIncludedFileWithVisited processes a single included file with cycle detection for nested includes
 func processIncludedFileWithVisited(filePath, sectionName string, extractTools bool, visited map[string]bool) (string, error) {
+	return processIncludedFileWithChain(filePath, sectionName, extractTools, visited, []string{filePath})
+}
+
+// processIncludedFileWithChain processes a single included file whose include chain, ending with
+// filePath itself, is used to detect cycles in nested includes
+func processIncludedFileWithChain(filePath, sectionName string, extractTools bool, visited map[string]bool, chain []string) (string, error) {
 	includeLog.Printf("Reading included file: %s (extractTools=%t, section=%s)", filePath, extractTools, sectionName)
 	content, err := readFileFunc(filePath)
 	if err != nil {
@@ -250,8 +277,12 @@ func processIncludedFileWithVisited(filePath, sectionName string, extractTools b
 
 	// Process nested includes recursively
 	includedDir := filepath.Dir(filePath)
-	markdownContent, err = processIncludesWithVisited(markdownContent, includedDir, extractTools, visited)
+	markdownContent, err = processIncludesWithChain(markdownContent, includedDir, extractTools, visited, chain)
 	if err != nil {
+		var cycleErr *ImportCycleError
+		if errors.As(err, &cycleErr) {
+			return "", err
+		}
 		return "", fmt.Errorf("failed to process nested includes in %s: %w", filePath, err)
 	}
 
@@ -2,7 +2,9 @@ package parser
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -17,23 +19,41 @@ var mcpLog = logger.New("parser:mcp")
 
 // ValidMCPTypes defines all supported MCP server types.
 // "local" is an alias for "stdio" and gets normalized during parsing.
-var ValidMCPTypes = []string{"stdio", "http", "local"}
+// "ws" and "wss" are aliases for "websocket" and get normalized during parsing.
+var ValidMCPTypes = []string{"stdio", "http", "local", "websocket", "ws", "wss"}
 
 // IsMCPType checks if a type string is a valid MCP server type.
-// Returns true for "stdio", "http", and "local" (which is an alias for "stdio").
+// Returns true for "stdio", "http", "websocket", and their aliases
+// ("local" for "stdio", "ws"/"wss" for "websocket").
 func IsMCPType(typeStr string) bool {
 	switch typeStr {
-	case "stdio", "http", "local":
+	case "stdio", "http", "local", "websocket", "ws", "wss":
 		return true
 	default:
 		return false
 	}
 }
 
+// NormalizeMCPType maps MCP type aliases to their canonical form.
+// "local" becomes "stdio" and "ws"/"wss" become "websocket"; other values are returned unchanged.
+func NormalizeMCPType(typeStr string) string {
+	switch typeStr {
+	case "local":
+		return "stdio"
+	case "ws", "wss":
+		return "websocket"
+	default:
+		return typeStr
+	}
+}
+
 // EnsureLocalhostDomains ensures that localhost and 127.0.0.1 are always included
 // in the allowed domains list for Playwright, even when custom domains are specified
-// Includes port variations to allow all ports on localhost and 127.0.0.1
+// Includes port variations to allow all ports on localhost and 127.0.0.1.
+// Entries are lowercased and duplicates are dropped.
 func EnsureLocalhostDomains(domains []string) []string {
+	domains, _ = dedupeDomains(domains)
+
 	hasLocalhost := false
 	hasLocalhostPorts := false
 	hasLoopback := false
@@ -79,6 +99,70 @@ func EnsureLocalhostDomains(domains []string) []string {
 	return result
 }
 
+// domainHostPattern matches a hostname or IPv4 address, optionally with a leading "*." wildcard
+// and a ":port" or ":*" suffix
+var domainHostPattern = regexp.MustCompile(`^(\*\.)?[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*(:(\*|[0-9]{1,5}))?$`)
+
+// NormalizeDomains lowercases, deduplicates, and validates a list of domain hosts.
+// Entries must be plain hosts such as "github.com", "*.github.com", or "localhost:*";
+// entries with spaces, URL schemes (e.g. "https://"), or paths are rejected.
+// Returns the normalized domains in their original order and the duplicates that were removed.
+func NormalizeDomains(domains []string) ([]string, []string, error) {
+	normalized, duplicates := dedupeDomains(domains)
+
+	var errs []error
+	for _, domain := range normalized {
+		if err := ValidateDomainHost(domain); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	if len(duplicates) > 0 {
+		mcpLog.Printf("Removed %d duplicate domain(s): %v", len(duplicates), duplicates)
+	}
+	return normalized, duplicates, nil
+}
+
+// ValidateDomainHost checks that a lowercased domain entry is a plain host
+func ValidateDomainHost(domain string) error {
+	switch {
+	case domain == "":
+		return errors.New("domain cannot be empty")
+	case strings.ContainsFunc(domain, func(r rune) bool { return r == ' ' || r == '\t' || r == '\n' }):
+		return fmt.Errorf("invalid domain %q: domains cannot contain spaces", domain)
+	case strings.Contains(domain, "://"):
+		return fmt.Errorf("invalid domain %q: remove the URL scheme and list the host only (e.g. %q)", domain, domain[strings.Index(domain, "://")+3:])
+	case strings.Contains(domain, "/"):
+		return fmt.Errorf("invalid domain %q: domains cannot contain a path", domain)
+	case !domainHostPattern.MatchString(domain):
+		return fmt.Errorf("invalid domain %q: expected a hostname such as github.com or *.github.com", domain)
+	}
+	return nil
+}
+
+// dedupeDomains trims and lowercases each domain, dropping empty entries and duplicates.
+// Returns the unique domains in their original order and the duplicates that were dropped.
+func dedupeDomains(domains []string) ([]string, []string) {
+	seen := make(map[string]bool, len(domains))
+	var unique, duplicates []string
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain == "" {
+			continue
+		}
+		if seen[domain] {
+			duplicates = append(duplicates, domain)
+			continue
+		}
+		seen[domain] = true
+		unique = append(unique, domain)
+	}
+	return unique, duplicates
+}
+
 // MCPServerConfig represents a parsed MCP server configuration.
 // It embeds BaseMCPServerConfig for common fields and adds parser-specific fields.
 type MCPServerConfig struct {
@@ -294,6 +378,32 @@ func ExtractMCPConfigurations(frontmatter map[string]any, serverFilter string) (
 	return configs, nil
 }
 
+// imageDigestPattern matches a sha256 content digest used to pin a container image
+var imageDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// IsImageDigest reports whether an image version is a content digest (sha256:...) rather than a tag
+func IsImageDigest(version string) bool {
+	return strings.HasPrefix(version, "sha256:")
+}
+
+// ValidateImageDigest checks that a version starting with "sha256:" is a well-formed digest of
+// 64 lowercase hex characters. Tag versions are not checked.
+func ValidateImageDigest(version string) error {
+	if !IsImageDigest(version) || imageDigestPattern.MatchString(version) {
+		return nil
+	}
+	return fmt.Errorf("image digest '%s' must be 'sha256:' followed by 64 lowercase hex characters", version)
+}
+
+// DockerImageReference returns the reference for an image at the given version. Digests are
+// pinned with "@" (image@sha256:...) and tags with ":" (image:tag).
+func DockerImageReference(image, version string) string {
+	if IsImageDigest(version) {
+		return image + "@" + version
+	}
+	return image + ":" + version
+}
+
 // processBuiltinMCPTool handles built-in MCP tools (github, playwright, and serena)
 func processBuiltinMCPTool(toolName string, toolValue any, serverFilter string) (*MCPServerConfig, error) {
 	// Apply server filter if specified
@@ -400,7 +510,10 @@ func processBuiltinMCPTool(toolName string, toolValue any, serverFilter string)
 			if !useRemote {
 				if version, exists := toolConfig["version"]; exists {
 					if versionStr := stringutil.ParseVersionValue(version); versionStr != "" {
-						dockerImage := "ghcr.io/github/github-mcp-server:" + versionStr
+						if err := ValidateImageDigest(versionStr); err != nil {
+							return nil, fmt.Errorf("invalid tools.github.version: %w", err)
+						}
+						dockerImage := DockerImageReference("ghcr.io/github/github-mcp-server", versionStr)
 						// Update the Docker image in args
 						for i, arg := range config.Args {
 							if strings.HasPrefix(arg, "ghcr.io/github/github-mcp-server:") {
@@ -432,22 +545,26 @@ func processBuiltinMCPTool(toolName string, toolValue any, serverFilter string)
 		return &config, nil
 	} else if toolName == "playwright" {
 		// Handle Playwright MCP server - always use Docker by default
+		toolConfig, _ := toolValue.(map[string]any)
+		dockerArgs, err := playwrightDockerArgs(toolConfig)
+		if err != nil {
+			return nil, err
+		}
+		args := append([]string{"run", "-i", "--rm"}, dockerArgs...)
+		args = append(args, "mcr.microsoft.com/playwright:"+string(constants.DefaultPlaywrightBrowserVersion))
+
 		config := MCPServerConfig{
 			BaseMCPServerConfig: types.BaseMCPServerConfig{
 				Type:    "docker", // Playwright defaults to Docker (containerized)
 				Command: "docker",
-				Args: []string{
-					"run", "-i", "--rm", "--shm-size=2gb", "--cap-add=SYS_ADMIN",
-					"-v", "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs",
-					"mcr.microsoft.com/playwright:" + string(constants.DefaultPlaywrightBrowserVersion),
-				},
-				Env: make(map[string]string),
+				Args:    args,
+				Env:     make(map[string]string),
 			},
 			Name: "playwright",
 		}
 
 		// Check for custom Playwright configuration
-		if toolConfig, ok := toolValue.(map[string]any); ok {
+		if toolConfig != nil {
 			// Check for custom Docker image version
 			if version, exists := toolConfig["version"]; exists {
 				if versionStr := stringutil.ParseVersionValue(version); versionStr != "" {
@@ -554,6 +671,9 @@ func ParseMCPConfig(toolName string, mcpSection any, toolConfig map[string]any)
 		if allowedSlice, ok := allowed.([]any); ok {
 			for _, item := range allowedSlice {
 				if str, ok := item.(string); ok {
+					if err := ValidateCustomServerToolName(str); err != nil {
+						return config, fmt.Errorf("invalid allowed tool in tool '%s': %w", toolName, err)
+					}
 					config.Allowed = append(config.Allowed, str)
 				}
 			}
@@ -578,14 +698,10 @@ func ParseMCPConfig(toolName string, mcpSection any, toolConfig map[string]any)
 	// Extract type (explicit or inferred)
 	if typeVal, hasType := mcpConfig["type"]; hasType {
 		if typeStr, ok := typeVal.(string); ok {
-			// Normalize "local" to "stdio"
-			if typeStr == "local" {
-				config.Type = "stdio"
-			} else {
-				config.Type = typeStr
-			}
+			// Normalize aliases ("local" -> "stdio", "ws"/"wss" -> "websocket")
+			config.Type = NormalizeMCPType(typeStr)
 		} else {
-			return config, fmt.Errorf("type field must be a string, got %T. Valid types are: stdio, http. Example:\nmcp-servers:\n  %s:\n    type: stdio\n    command: \"npx @my/tool\"", typeVal, toolName)
+			return config, fmt.Errorf("type field must be a string, got %T. Valid types are: stdio, http, websocket. Example:\nmcp-servers:\n  %s:\n    type: stdio\n    command: \"npx @my/tool\"", typeVal, toolName)
 		}
 	} else {
 		// Infer type from presence of fields
@@ -612,6 +728,22 @@ func ParseMCPConfig(toolName string, mcpSection any, toolConfig map[string]any)
 		}
 	}
 
+	// Extract startup fields (available for all types)
+	if startupTimeout, hasStartupTimeout := mcpConfig["startup-timeout"]; hasStartupTimeout {
+		seconds, err := ParseMCPStartupTimeout(startupTimeout)
+		if err != nil {
+			return config, fmt.Errorf("invalid startup-timeout for tool '%s': %w. Example:\nmcp-servers:\n  %s:\n    command: \"npx @my/tool\"\n    startup-timeout: 90\n    health-check: true", toolName, err, toolName)
+		}
+		config.StartupTimeout = seconds
+	}
+	if healthCheck, hasHealthCheck := mcpConfig["health-check"]; hasHealthCheck {
+		if healthCheckBool, ok := healthCheck.(bool); ok {
+			config.HealthCheck = healthCheckBool
+		} else {
+			return config, fmt.Errorf("health-check field must be a boolean, got %T. Example:\nmcp-servers:\n  %s:\n    command: \"npx @my/tool\"\n    health-check: true", healthCheck, toolName)
+		}
+	}
+
 	// Extract configuration based on type
 	mcpLog.Printf("Extracting %s configuration for tool: %s", config.Type, toolName)
 	switch config.Type {
@@ -636,6 +768,9 @@ func ParseMCPConfig(toolName string, mcpSection any, toolConfig map[string]any)
 
 						for _, key := range envKeys {
 							if valueStr, ok := envMap[key].(string); ok {
+								if err := ValidateEnvInterpolation(valueStr); err != nil {
+									return config, fmt.Errorf("invalid env value for '%s' in tool '%s': %w. Use ${VAR:-default} to fall back to a default value", key, toolName, err)
+								}
 								config.Args = append(config.Args, "-e", key)
 								config.Env[key] = valueStr
 							}
@@ -662,6 +797,25 @@ func ParseMCPConfig(toolName string, mcpSection any, toolConfig map[string]any)
 					}
 				}
 
+				// Add docker volumes if configured, validating the host:container[:ro|rw] syntax
+				if volumes, hasVolumes := mcpConfig["volumes"]; hasVolumes {
+					volumesSlice, ok := volumes.([]any)
+					if !ok {
+						return config, fmt.Errorf("volumes field must be an array of strings, got %T. Example:\nmcp-servers:\n  %s:\n    container: \"myorg/my-tool:latest\"\n    volumes:\n      - \"/host/data:/data:ro\"", volumes, toolName)
+					}
+					for i, volume := range volumesSlice {
+						volumeStr, ok := volume.(string)
+						if !ok {
+							return config, fmt.Errorf("volumes[%d] for tool '%s' must be a string, got %T", i, toolName, volume)
+						}
+						if err := ValidateVolumeSyntax(volumeStr); err != nil {
+							return config, fmt.Errorf("invalid volumes[%d] for tool '%s': %w. Expected format 'host:container[:ro]', e.g. \"/host/data:/data:ro\"", i, toolName, err)
+						}
+						config.Volumes = append(config.Volumes, volumeStr)
+						config.Args = append(config.Args, "-v", volumeStr)
+					}
+				}
+
 				// Add entrypoint override if specified
 				if entrypoint, hasEntrypoint := mcpConfig["entrypoint"]; hasEntrypoint {
 					if entrypointStr, ok := entrypoint.(string); ok {
@@ -725,6 +879,9 @@ func ParseMCPConfig(toolName string, mcpSection any, toolConfig map[string]any)
 			if envMap, ok := env.(map[string]any); ok {
 				for key, value := range envMap {
 					if valueStr, ok := value.(string); ok {
+						if err := ValidateEnvInterpolation(valueStr); err != nil {
+							return config, fmt.Errorf("invalid env value for '%s' in tool '%s': %w. Use ${VAR:-default} to fall back to a default value", key, toolName, err)
+						}
 						config.Env[key] = valueStr
 					}
 				}
@@ -788,9 +945,77 @@ func ParseMCPConfig(toolName string, mcpSection any, toolConfig map[string]any)
 			}
 		}
 
+		// Extract OAuth client credentials used to obtain a bearer token at runtime
+		if auth, hasAuth := mcpConfig["auth"]; hasAuth {
+			authConfig, err := ParseMCPAuthConfig(toolName, auth, config.Headers)
+			if err != nil {
+				return config, err
+			}
+			mcpLog.Printf("Tool %s uses OAuth client credentials from %s", toolName, authConfig.TokenURL)
+			config.Auth = authConfig
+		}
+
+	case "websocket":
+		if url, hasURL := mcpConfig["url"]; hasURL {
+			if urlStr, ok := url.(string); ok {
+				mcpLog.Printf("Tool %s uses WebSocket transport with URL: %s", toolName, urlStr)
+				config.URL = urlStr
+			} else {
+				return config, fmt.Errorf(
+					"url field must be a string, got %T. Example:\n"+
+						"mcp-servers:\n"+
+						"  %s:\n"+
+						"    type: websocket\n"+
+						"    url: \"wss://api.example.com/mcp\"",
+					url, toolName)
+			}
+		} else {
+			return config, fmt.Errorf(
+				"websocket MCP tool '%s' missing required 'url' field. WebSocket MCP servers must specify a ws:// or wss:// URL endpoint. "+
+					"Example:\n"+
+					"mcp-servers:\n"+
+					"  %s:\n"+
+					"    type: websocket\n"+
+					"    url: \"wss://api.example.com/mcp\"\n"+
+					"    headers:\n"+
+					"      Authorization: \"Bearer ${{ secrets.API_KEY }}\"",
+				toolName, toolName,
+			)
+		}
+
+		// Extract headers sent with the WebSocket upgrade request
+		if headers, hasHeaders := mcpConfig["headers"]; hasHeaders {
+			if headersMap, ok := headers.(map[string]any); ok {
+				for key, value := range headersMap {
+					if valueStr, ok := value.(string); ok {
+						config.Headers[key] = valueStr
+					}
+				}
+			}
+		}
+
 	default:
-		return config, fmt.Errorf("unsupported MCP type '%s' for tool '%s'. Valid types are: stdio, http. Example:\nmcp-servers:\n  %s:\n    type: stdio\n    command: \"npx @my/tool\"\n    args: [\"--port\", \"3000\"]", config.Type, toolName, toolName)
+		return config, fmt.Errorf("unsupported MCP type '%s' for tool '%s'. Valid types are: stdio, http, websocket. Example:\nmcp-servers:\n  %s:\n    type: stdio\n    command: \"npx @my/tool\"\n    args: [\"--port\", \"3000\"]", config.Type, toolName, toolName)
 	}
 
 	return config, nil
 }
+
+// ValidateVolumeSyntax checks that a docker volume follows the host:container[:ro|rw] format.
+// Both paths must be non-empty and the container path must be absolute.
+func ValidateVolumeSyntax(volume string) error {
+	parts := strings.Split(volume, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("volume %q must have 2 or 3 colon-separated parts", volume)
+	}
+	if parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("volume %q must specify both a host and a container path", volume)
+	}
+	if !strings.HasPrefix(parts[1], "/") {
+		return fmt.Errorf("volume %q container path must be absolute", volume)
+	}
+	if len(parts) == 3 && parts[2] != "ro" && parts[2] != "rw" {
+		return fmt.Errorf("volume %q mode must be 'ro' or 'rw', got %q", volume, parts[2])
+	}
+	return nil
+}
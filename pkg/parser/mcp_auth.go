@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/types"
+)
+
+// secretExpressionPattern matches a single GitHub Actions secrets expression such as ${{ secrets.CLIENT_SECRET }}
+var secretExpressionPattern = regexp.MustCompile(`^\$\{\{\s*secrets\.[A-Za-z_][A-Za-z0-9_]*\s*\}\}$`)
+
+// mcpAuthFields are the fields accepted in the auth block of an HTTP MCP server
+var mcpAuthFields = []string{"token-url", "client-id", "client-secret", "scope", "audience"}
+
+// ParseMCPAuthConfig parses the auth block of an HTTP MCP server. The block describes an OAuth
+// client credentials grant: token-url must be an https:// endpoint, client-id is required and
+// client-secret must reference a secret so it never appears in the compiled workflow.
+func ParseMCPAuthConfig(toolName string, value any, headers map[string]string) (*types.MCPAuthConfig, error) {
+	authMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf(
+			"auth field for MCP tool '%s' must be an object, got %T. Example:\n"+
+				"mcp-servers:\n"+
+				"  %s:\n"+
+				"    url: \"https://api.example.com/mcp\"\n"+
+				"    auth:\n"+
+				"      token-url: \"https://auth.example.com/oauth/token\"\n"+
+				"      client-id: \"${{ secrets.MCP_CLIENT_ID }}\"\n"+
+				"      client-secret: \"${{ secrets.MCP_CLIENT_SECRET }}\"",
+			toolName, value, toolName)
+	}
+
+	for key := range authMap {
+		if !slices.Contains(mcpAuthFields, key) {
+			return nil, fmt.Errorf("unknown property '%s' in auth for MCP tool '%s'. Valid properties are: %s", key, toolName, strings.Join(mcpAuthFields, ", "))
+		}
+	}
+
+	fields := make(map[string]string, len(authMap))
+	for key, raw := range authMap {
+		str, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("auth.%s for MCP tool '%s' must be a string, got %T", key, toolName, raw)
+		}
+		fields[key] = strings.TrimSpace(str)
+	}
+
+	for _, required := range []string{"token-url", "client-id", "client-secret"} {
+		if fields[required] == "" {
+			return nil, fmt.Errorf("auth for MCP tool '%s' is missing the required '%s' field", toolName, required)
+		}
+	}
+	if !strings.HasPrefix(fields["token-url"], "https://") {
+		return nil, fmt.Errorf("auth.token-url for MCP tool '%s' must be an https:// URL, got '%s'", toolName, fields["token-url"])
+	}
+	if !secretExpressionPattern.MatchString(fields["client-secret"]) {
+		return nil, fmt.Errorf("auth.client-secret for MCP tool '%s' must reference a secret, e.g. \"${{ secrets.MCP_CLIENT_SECRET }}\"", toolName)
+	}
+
+	// The bearer token obtained from the grant is sent as the Authorization header
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	for _, name := range headerNames {
+		if strings.EqualFold(name, "Authorization") {
+			return nil, fmt.Errorf("MCP tool '%s' cannot set both auth and an '%s' header. Remove the header; the token obtained through auth is sent as the Authorization header", toolName, name)
+		}
+	}
+
+	return &types.MCPAuthConfig{
+		TokenURL:     fields["token-url"],
+		ClientID:     fields["client-id"],
+		ClientSecret: fields["client-secret"],
+		Scope:        fields["scope"],
+		Audience:     fields["audience"],
+	}, nil
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// envVarNamePattern matches valid shell environment variable names
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateEnvInterpolation checks the shell-style interpolations in an MCP env value.
+// Supported forms are ${VAR} and ${VAR:-default}; GitHub Actions expressions such as
+// ${{ secrets.X }} are left untouched. Nested (recursive) interpolations like
+// ${A:-${B}} and malformed references are rejected.
+func ValidateEnvInterpolation(value string) error {
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 >= len(value) || value[i+1] != '{' {
+			continue
+		}
+
+		// GitHub Actions expression - skip to its closing braces
+		if strings.HasPrefix(value[i:], "${{") {
+			end := strings.Index(value[i:], "}}")
+			if end == -1 {
+				return fmt.Errorf("unterminated GitHub expression in %q", value)
+			}
+			i += end + 1
+			continue
+		}
+
+		end := strings.IndexByte(value[i+2:], '}')
+		if end == -1 {
+			return fmt.Errorf("unterminated interpolation in %q", value)
+		}
+		body := value[i+2 : i+2+end]
+		if strings.ContainsAny(body, "${") {
+			return fmt.Errorf("nested interpolation is not supported in %q", value)
+		}
+
+		name, _, hasDefault := strings.Cut(body, ":-")
+		if !envVarNamePattern.MatchString(name) {
+			if !hasDefault && strings.Contains(body, ":") {
+				return fmt.Errorf("unsupported interpolation ${%s} in %q: only ${VAR} and ${VAR:-default} are supported", body, value)
+			}
+			return fmt.Errorf("invalid environment variable name %q in %q", name, value)
+		}
+		i += 2 + end
+	}
+	return nil
+}
+
+// ExpandEnvWithDefaults expands ${VAR} and ${VAR:-default} references in value using lookup.
+// Like the shell, the default is used when the variable is unset or empty.
+func ExpandEnvWithDefaults(value string, lookup func(string) (string, bool)) string {
+	return os.Expand(value, func(name string) string {
+		name, defaultValue, hasDefault := strings.Cut(name, ":-")
+		resolved, ok := lookup(name)
+		if hasDefault && (!ok || resolved == "") {
+			return defaultValue
+		}
+		return resolved
+	})
+}
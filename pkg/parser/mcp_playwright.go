@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+)
+
+// Default container settings for the Playwright MCP server launched by ExtractMCPConfigurations
+const (
+	defaultPlaywrightShmSize = "2gb"
+	playwrightLogsVolume     = constants.DefaultMCPLogsDir + ":" + constants.DefaultMCPLogsDir
+)
+
+// DefaultPlaywrightLogDir is the subdirectory of the MCP logs directory that Playwright writes to
+// when log-dir is not set
+const DefaultPlaywrightLogDir = "playwright"
+
+// logDirPattern matches a relative log directory made of simple path segments
+var logDirPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*(/[A-Za-z0-9_][A-Za-z0-9._-]*)*$`)
+
+// ResolveMCPServerLogDir validates a per-server log-dir and returns its absolute path inside the
+// MCP logs directory. Keeping every server under that directory gives each one its own folder
+// while the whole tree is still uploaded with the run artifacts.
+func ResolveMCPServerLogDir(logDir string) (string, error) {
+	if !logDirPattern.MatchString(logDir) || slices.Contains(strings.Split(logDir, "/"), "..") {
+		return "", fmt.Errorf("invalid log-dir '%s': expected a relative directory such as 'playwright' or 'browser/run-1' inside %s", logDir, constants.DefaultMCPLogsDir)
+	}
+	return constants.DefaultMCPLogsDir + "/" + logDir, nil
+}
+
+// defaultPlaywrightCaps are the capabilities added to the Playwright container when caps is not set
+var defaultPlaywrightCaps = []string{"SYS_ADMIN"}
+
+// linuxCapabilities are the capability names accepted by `docker run --cap-add`, without the CAP_ prefix
+var linuxCapabilities = []string{
+	"ALL", "AUDIT_CONTROL", "AUDIT_READ", "AUDIT_WRITE", "BLOCK_SUSPEND", "BPF", "CHECKPOINT_RESTORE",
+	"CHOWN", "DAC_OVERRIDE", "DAC_READ_SEARCH", "FOWNER", "FSETID", "IPC_LOCK", "IPC_OWNER", "KILL",
+	"LEASE", "LINUX_IMMUTABLE", "MAC_ADMIN", "MAC_OVERRIDE", "MKNOD", "NET_ADMIN", "NET_BIND_SERVICE",
+	"NET_BROADCAST", "NET_RAW", "PERFMON", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYSLOG",
+	"SYS_ADMIN", "SYS_BOOT", "SYS_CHROOT", "SYS_MODULE", "SYS_NICE", "SYS_PACCT", "SYS_PTRACE",
+	"SYS_RAWIO", "SYS_RESOURCE", "SYS_TIME", "SYS_TTY_CONFIG", "WAKE_ALARM",
+}
+
+// shmSizePattern matches a docker --shm-size value such as 512m or 2gb
+var shmSizePattern = regexp.MustCompile(`(?i)^[0-9]+(b|k|kb|m|mb|g|gb)?$`)
+
+// playwrightDockerArgs returns the docker run options for the Playwright container. The shm-size,
+// caps and extra-volumes fields of the tool configuration override the default 2gb shared memory
+// and SYS_ADMIN capability; extra volumes are mounted in addition to the MCP logs directory, which
+// is narrowed to the pinned folder when log-dir is set.
+func playwrightDockerArgs(toolConfig map[string]any) ([]string, error) {
+	shmSize := defaultPlaywrightShmSize
+	if value, exists := toolConfig["shm-size"]; exists {
+		size, ok := value.(string)
+		if !ok || !shmSizePattern.MatchString(size) {
+			return nil, fmt.Errorf("invalid playwright shm-size '%v': expected a size such as 512m or 2gb", value)
+		}
+		shmSize = size
+	}
+
+	caps := defaultPlaywrightCaps
+	if value, exists := toolConfig["caps"]; exists {
+		names, err := playwrightStringList(value, "caps")
+		if err != nil {
+			return nil, err
+		}
+		caps = make([]string, 0, len(names))
+		for _, name := range names {
+			normalized := strings.TrimPrefix(strings.ToUpper(name), "CAP_")
+			if !slices.Contains(linuxCapabilities, normalized) {
+				return nil, fmt.Errorf("invalid playwright capability '%s': expected a Linux capability name such as SYS_ADMIN or NET_ADMIN", name)
+			}
+			caps = append(caps, normalized)
+		}
+	}
+
+	var extraVolumes []string
+	if value, exists := toolConfig["extra-volumes"]; exists {
+		volumes, err := playwrightStringList(value, "extra-volumes")
+		if err != nil {
+			return nil, err
+		}
+		for _, volume := range volumes {
+			if !strings.Contains(volume, ":") {
+				return nil, fmt.Errorf("invalid playwright extra volume '%s': expected host-path:container-path[:options]", volume)
+			}
+		}
+		extraVolumes = volumes
+	}
+
+	// A pinned log-dir replaces the shared logs mount so parallel servers never write to the same folder
+	logsVolume := playwrightLogsVolume
+	if value, exists := toolConfig["log-dir"]; exists {
+		logDir, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("playwright log-dir must be a string, got %T", value)
+		}
+		path, err := ResolveMCPServerLogDir(logDir)
+		if err != nil {
+			return nil, err
+		}
+		logsVolume = path + ":" + path
+	}
+
+	args := []string{"--shm-size=" + shmSize}
+	for _, capability := range caps {
+		args = append(args, "--cap-add="+capability)
+	}
+	args = append(args, "-v", logsVolume)
+	for _, volume := range extraVolumes {
+		args = append(args, "-v", volume)
+	}
+	return args, nil
+}
+
+// playwrightStringList converts a YAML list of strings from the playwright tool configuration
+func playwrightStringList(value any, field string) ([]string, error) {
+	switch list := value.(type) {
+	case []string:
+		return list, nil
+	case []any:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("playwright %s must be a list of strings, got item %v", field, item)
+			}
+			result = append(result, str)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("playwright %s must be a list of strings, got %T", field, value)
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// MCPServerRefKey is the mcp-servers entry field that points at a shared MCP server definition file
+const MCPServerRefKey = "$ref"
+
+// ExtractMCPServerRefs returns the $ref paths used by mcp-servers entries in frontmatter, sorted and deduplicated
+func ExtractMCPServerRefs(frontmatter map[string]any) []string {
+	mcpServers, ok := frontmatter["mcp-servers"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var refs []string
+	for _, serverValue := range mcpServers {
+		serverConfig, ok := serverValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		ref, ok := serverConfig[MCPServerRefKey].(string)
+		if !ok || ref == "" || seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// ResolveMCPServerRefs inlines mcp-servers entries that reference a shared definition file with $ref.
+// The referenced file must be a JSON object holding a single MCP server configuration and is
+// resolved relative to baseDir (the workflow's directory). Fields set next to $ref (such as
+// allowed) override the shared definition. It returns the resolved servers along with the
+// referenced files relative to baseDir so they can be tracked as include dependencies.
+func ResolveMCPServerRefs(mcpServers map[string]any, baseDir string) (map[string]any, []string, error) {
+	if len(mcpServers) == 0 {
+		return mcpServers, nil, nil
+	}
+
+	resolved := make(map[string]any, len(mcpServers))
+	refFiles := make(map[string]bool)
+	for serverName, serverValue := range mcpServers {
+		serverConfig, ok := serverValue.(map[string]any)
+		if !ok {
+			resolved[serverName] = serverValue
+			continue
+		}
+		refValue, hasRef := serverConfig[MCPServerRefKey]
+		if !hasRef {
+			resolved[serverName] = serverValue
+			continue
+		}
+
+		ref, ok := refValue.(string)
+		if !ok || ref == "" {
+			return nil, nil, fmt.Errorf("mcp-servers.%s.%s must be a non-empty file path, got %v", serverName, MCPServerRefKey, refValue)
+		}
+		definition, err := loadMCPServerDefinition(ref, baseDir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve mcp-servers.%s.%s: %w", serverName, MCPServerRefKey, err)
+		}
+		mcpLog.Printf("Resolved shared MCP server definition for %s from %s", serverName, ref)
+
+		// Fields next to $ref override the shared definition
+		for key, value := range serverConfig {
+			if key != MCPServerRefKey {
+				definition[key] = value
+			}
+		}
+		resolved[serverName] = definition
+		refFiles[filepath.ToSlash(filepath.Clean(ref))] = true
+	}
+
+	var files []string
+	for file := range refFiles {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return resolved, files, nil
+}
+
+// ResolveFrontmatterMCPServerRefs inlines $ref entries in the frontmatter's mcp-servers section in place
+func ResolveFrontmatterMCPServerRefs(frontmatter map[string]any, baseDir string) error {
+	mcpServers, ok := frontmatter["mcp-servers"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	resolved, _, err := ResolveMCPServerRefs(mcpServers, baseDir)
+	if err != nil {
+		return err
+	}
+	frontmatter["mcp-servers"] = resolved
+	return nil
+}
+
+// loadMCPServerDefinition reads a shared MCP server definition from a JSON file relative to baseDir
+func loadMCPServerDefinition(ref, baseDir string) (map[string]any, error) {
+	refPath := filepath.FromSlash(ref)
+	if !filepath.IsLocal(refPath) {
+		return nil, fmt.Errorf("'%s' must be a relative path inside the workflow directory", ref)
+	}
+
+	fullPath := filepath.Join(baseDir, refPath)
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("shared MCP server definition '%s' not found (looked in %s)", ref, fullPath)
+		}
+		return nil, fmt.Errorf("failed to read shared MCP server definition '%s': %w", ref, err)
+	}
+
+	var definition map[string]any
+	if err := json.Unmarshal(content, &definition); err != nil {
+		return nil, fmt.Errorf("shared MCP server definition '%s' is not valid JSON: %w", ref, err)
+	}
+	if definition == nil {
+		return nil, fmt.Errorf("shared MCP server definition '%s' must be a JSON object", ref)
+	}
+	if _, nested := definition[MCPServerRefKey]; nested {
+		return nil, fmt.Errorf("shared MCP server definition '%s' cannot itself use %s", ref, MCPServerRefKey)
+	}
+	return definition, nil
+}
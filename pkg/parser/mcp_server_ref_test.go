@@ -0,0 +1,121 @@
+//go:build !integration
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeMCPServerDefinition(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
+
+func TestResolveMCPServerRefs(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeMCPServerDefinition(t, tmpDir, "shared/myserver.mcp.json", `{
+  "container": "myorg/myserver:1.0",
+  "env": {"API_KEY": "${{ secrets.API_KEY }}"},
+  "allowed": ["*"]
+}`)
+
+	mcpServers := map[string]any{
+		"myserver": map[string]any{
+			"$ref":    "shared/myserver.mcp.json",
+			"allowed": []any{"search"},
+		},
+		"inline": map[string]any{
+			"command": "node",
+		},
+	}
+
+	resolved, files, err := ResolveMCPServerRefs(mcpServers, tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]any{
+		"container": "myorg/myserver:1.0",
+		"env":       map[string]any{"API_KEY": "${{ secrets.API_KEY }}"},
+		"allowed":   []any{"search"},
+	}
+	if !reflect.DeepEqual(resolved["myserver"], expected) {
+		t.Errorf("Expected shared definition with allowed override %v, got %v", expected, resolved["myserver"])
+	}
+	if !reflect.DeepEqual(resolved["inline"], mcpServers["inline"]) {
+		t.Errorf("Expected inline server to be unchanged, got %v", resolved["inline"])
+	}
+	if !reflect.DeepEqual(files, []string{"shared/myserver.mcp.json"}) {
+		t.Errorf("Expected referenced file to be reported, got %v", files)
+	}
+	if _, stillRef := mcpServers["myserver"].(map[string]any)["$ref"]; !stillRef {
+		t.Error("Expected the input map to be left unmodified")
+	}
+}
+
+func TestResolveMCPServerRefsErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeMCPServerDefinition(t, tmpDir, "shared/malformed.mcp.json", `{"container": "myorg/server",`)
+	writeMCPServerDefinition(t, tmpDir, "shared/array.mcp.json", `["not", "an", "object"]`)
+	writeMCPServerDefinition(t, tmpDir, "shared/nested.mcp.json", `{"$ref": "other.mcp.json"}`)
+
+	tests := []struct {
+		name     string
+		ref      any
+		errorMsg string
+	}{
+		{name: "missing file", ref: "shared/missing.mcp.json", errorMsg: "not found"},
+		{name: "malformed JSON", ref: "shared/malformed.mcp.json", errorMsg: "is not valid JSON"},
+		{name: "JSON array", ref: "shared/array.mcp.json", errorMsg: "is not valid JSON"},
+		{name: "nested ref", ref: "shared/nested.mcp.json", errorMsg: "cannot itself use $ref"},
+		{name: "outside workflow directory", ref: "../secrets.json", errorMsg: "inside the workflow directory"},
+		{name: "absolute path", ref: "/etc/myserver.mcp.json", errorMsg: "inside the workflow directory"},
+		{name: "non-string ref", ref: 42, errorMsg: "must be a non-empty file path"},
+		{name: "empty ref", ref: "", errorMsg: "must be a non-empty file path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mcpServers := map[string]any{"myserver": map[string]any{"$ref": tt.ref}}
+			_, _, err := ResolveMCPServerRefs(mcpServers, tmpDir)
+			if err == nil {
+				t.Fatal("Expected an error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error containing %q, got %q", tt.errorMsg, err.Error())
+			}
+			if !strings.Contains(err.Error(), "mcp-servers.myserver.$ref") {
+				t.Errorf("Expected error to name the server, got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestExtractMCPServerRefs(t *testing.T) {
+	frontmatter := map[string]any{
+		"mcp-servers": map[string]any{
+			"b": map[string]any{"$ref": "shared/b.mcp.json"},
+			"a": map[string]any{"$ref": "shared/a.mcp.json"},
+			"c": map[string]any{"$ref": "shared/a.mcp.json"},
+			"d": map[string]any{"command": "node"},
+		},
+	}
+
+	refs := ExtractMCPServerRefs(frontmatter)
+	if !reflect.DeepEqual(refs, []string{"shared/a.mcp.json", "shared/b.mcp.json"}) {
+		t.Errorf("Expected sorted, deduplicated refs, got %v", refs)
+	}
+	if refs := ExtractMCPServerRefs(map[string]any{}); refs != nil {
+		t.Errorf("Expected no refs without mcp-servers, got %v", refs)
+	}
+}
@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+)
+
+// ParseMCPStartupTimeout converts a startup-timeout value from frontmatter into whole seconds.
+// YAML yields int or uint64 and JSON yields float64; fractional and non-positive values are rejected.
+func ParseMCPStartupTimeout(value any) (int, error) {
+	var seconds int
+	switch v := value.(type) {
+	case int:
+		seconds = v
+	case int64:
+		seconds = int(v)
+	case uint64:
+		if v > math.MaxInt32 {
+			return 0, fmt.Errorf("startup-timeout %d is too large", v)
+		}
+		seconds = int(v)
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("startup-timeout must be a whole number of seconds, got %v", v)
+		}
+		seconds = int(v)
+	default:
+		return 0, fmt.Errorf("startup-timeout must be an integer number of seconds, got %T", value)
+	}
+	if seconds < 1 {
+		return 0, fmt.Errorf("startup-timeout must be at least 1 second, got %d", seconds)
+	}
+	return seconds, nil
+}
+
+// StartupTimeoutSeconds returns the configured startup timeout in seconds, falling back to
+// constants.DefaultMCPServerStartupTimeout when the server does not set startup-timeout
+func (c MCPServerConfig) StartupTimeoutSeconds() int {
+	if c.StartupTimeout > 0 {
+		return c.StartupTimeout
+	}
+	return int(constants.DefaultMCPServerStartupTimeout / time.Second)
+}
@@ -5,7 +5,9 @@ package parser
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/github/gh-aw/pkg/types"
 
@@ -59,6 +61,11 @@ func TestEnsureLocalhostDomains(t *testing.T) {
 			input:    []string{"localhost:*", "127.0.0.1", "example.com"},
 			expected: []string{"localhost", "127.0.0.1:*", "localhost:*", "127.0.0.1", "example.com"},
 		},
+		{
+			name:     "Duplicate and mixed-case domains should be collapsed",
+			input:    []string{"GitHub.com", "localhost", "github.com", "LOCALHOST"},
+			expected: []string{"localhost:*", "127.0.0.1", "127.0.0.1:*", "github.com", "localhost"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,6 +78,83 @@ func TestEnsureLocalhostDomains(t *testing.T) {
 	}
 }
 
+func TestNormalizeDomains(t *testing.T) {
+	tests := []struct {
+		name               string
+		input              []string
+		expected           []string
+		expectedDuplicates []string
+		errorText          string
+	}{
+		{
+			name:               "duplicate domain is removed",
+			input:              []string{"github.com", "api.github.com", "github.com"},
+			expected:           []string{"github.com", "api.github.com"},
+			expectedDuplicates: []string{"github.com"},
+		},
+		{
+			name:               "domains are lowercased and trimmed before deduplication",
+			input:              []string{" GitHub.com", "github.COM ", "*.Example.org"},
+			expected:           []string{"github.com", "*.example.org"},
+			expectedDuplicates: []string{"github.com"},
+		},
+		{
+			name:     "localhost variants are kept",
+			input:    []string{"localhost", "localhost:*", "127.0.0.1", "127.0.0.1:*", "localhost:8080"},
+			expected: []string{"localhost", "localhost:*", "127.0.0.1", "127.0.0.1:*", "localhost:8080"},
+		},
+		{
+			name:      "scheme is rejected",
+			input:     []string{"https://github.com"},
+			errorText: "remove the URL scheme",
+		},
+		{
+			name:      "space is rejected",
+			input:     []string{"git hub.com"},
+			errorText: "cannot contain spaces",
+		},
+		{
+			name:      "path is rejected",
+			input:     []string{"github.com/org"},
+			errorText: "cannot contain a path",
+		},
+		{
+			name:      "invalid host is rejected",
+			input:     []string{"github..com"},
+			errorText: "expected a hostname",
+		},
+		{
+			name:      "wildcard in the middle is rejected",
+			input:     []string{"api.*.github.com"},
+			errorText: "expected a hostname",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, duplicates, err := NormalizeDomains(tt.input)
+			if tt.errorText != "" {
+				if err == nil {
+					t.Fatalf("NormalizeDomains(%v) expected error containing %q, got nil", tt.input, tt.errorText)
+				}
+				if !strings.Contains(err.Error(), tt.errorText) {
+					t.Errorf("NormalizeDomains(%v) error = %q, want it to contain %q", tt.input, err.Error(), tt.errorText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeDomains(%v) unexpected error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("NormalizeDomains(%v) = %v, want %v", tt.input, result, tt.expected)
+			}
+			if !reflect.DeepEqual(duplicates, tt.expectedDuplicates) {
+				t.Errorf("NormalizeDomains(%v) duplicates = %v, want %v", tt.input, duplicates, tt.expectedDuplicates)
+			}
+		})
+	}
+}
+
 func TestExtractMCPConfigurations(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -207,6 +291,53 @@ func TestExtractMCPConfigurations(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "GitHub tool with wildcard tool patterns preserved",
+			frontmatter: map[string]any{
+				"tools": map[string]any{
+					"github": map[string]any{
+						"allowed": []any{"issue_*", "get_me"},
+						"version": "latest",
+					},
+				},
+			},
+			expected: []MCPServerConfig{
+				{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "docker",
+					Command: "docker",
+					Args: []string{
+						"run", "-i", "--rm", "-e", "GITHUB_PERSONAL_ACCESS_TOKEN",
+						"ghcr.io/github/github-mcp-server:latest",
+					},
+					Env: map[string]string{"GITHUB_PERSONAL_ACCESS_TOKEN": "${GITHUB_TOKEN_REQUIRED}"}}, Name: "github",
+
+					Allowed: []string{"issue_*", "get_me"},
+				},
+			},
+		},
+		{
+			name: "Custom MCP server with prefix tool pattern",
+			frontmatter: map[string]any{
+				"mcp-servers": map[string]any{
+					"custom": map[string]any{
+						"command": "custom-server",
+						"allowed": []any{"issue_*", "get_status"},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Custom MCP server with unsupported tool pattern",
+			frontmatter: map[string]any{
+				"mcp-servers": map[string]any{
+					"custom": map[string]any{
+						"command": "custom-server",
+						"allowed": []any{"*_issue"},
+					},
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "GitHub tool with integer version",
 			frontmatter: map[string]any{
@@ -251,6 +382,39 @@ func TestExtractMCPConfigurations(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "GitHub tool with digest-pinned version",
+			frontmatter: map[string]any{
+				"tools": map[string]any{
+					"github": map[string]any{
+						"version": "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+					},
+				},
+			},
+			expected: []MCPServerConfig{
+				{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "docker",
+					Command: "docker",
+					Args: []string{
+						"run", "-i", "--rm", "-e", "GITHUB_PERSONAL_ACCESS_TOKEN",
+						"ghcr.io/github/github-mcp-server@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+					},
+					Env: map[string]string{"GITHUB_PERSONAL_ACCESS_TOKEN": "${GITHUB_TOKEN_REQUIRED}"}}, Name: "github",
+
+					Allowed: []string{},
+				},
+			},
+		},
+		{
+			name: "GitHub tool with malformed digest",
+			frontmatter: map[string]any{
+				"tools": map[string]any{
+					"github": map[string]any{
+						"version": "sha256:abc123",
+					},
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "Playwright tool default configuration",
 			frontmatter: map[string]any{
@@ -354,6 +518,125 @@ func TestExtractMCPConfigurations(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Playwright tool with custom shm-size",
+			frontmatter: map[string]any{
+				"tools": map[string]any{
+					"playwright": map[string]any{
+						"shm-size": "512m",
+					},
+				},
+			},
+			expected: []MCPServerConfig{
+				{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "docker",
+					Command: "docker",
+					Args: []string{
+						"run", "-i", "--rm", "--shm-size=512m", "--cap-add=SYS_ADMIN",
+						"-v", "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs",
+						"mcr.microsoft.com/playwright:" + string(constants.DefaultPlaywrightBrowserVersion),
+					},
+					Env: map[string]string{}}, Name: "playwright",
+				},
+			},
+		},
+		{
+			name: "Playwright tool with empty caps and extra volumes",
+			frontmatter: map[string]any{
+				"tools": map[string]any{
+					"playwright": map[string]any{
+						"caps":          []any{},
+						"extra-volumes": []any{"/tmp/fixtures:/fixtures:ro"},
+					},
+				},
+			},
+			expected: []MCPServerConfig{
+				{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "docker",
+					Command: "docker",
+					Args: []string{
+						"run", "-i", "--rm", "--shm-size=2gb",
+						"-v", "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs",
+						"-v", "/tmp/fixtures:/fixtures:ro",
+						"mcr.microsoft.com/playwright:" + string(constants.DefaultPlaywrightBrowserVersion),
+					},
+					Env: map[string]string{}}, Name: "playwright",
+				},
+			},
+		},
+		{
+			name: "Playwright tool with normalized caps",
+			frontmatter: map[string]any{
+				"tools": map[string]any{
+					"playwright": map[string]any{
+						"caps": []any{"cap_net_admin", "SYS_PTRACE"},
+					},
+				},
+			},
+			expected: []MCPServerConfig{
+				{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "docker",
+					Command: "docker",
+					Args: []string{
+						"run", "-i", "--rm", "--shm-size=2gb", "--cap-add=NET_ADMIN", "--cap-add=SYS_PTRACE",
+						"-v", "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs",
+						"mcr.microsoft.com/playwright:" + string(constants.DefaultPlaywrightBrowserVersion),
+					},
+					Env: map[string]string{}}, Name: "playwright",
+				},
+			},
+		},
+		{
+			name: "Playwright tool with unknown capability",
+			frontmatter: map[string]any{
+				"tools": map[string]any{
+					"playwright": map[string]any{
+						"caps": []any{"SUPER_USER"},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Playwright tool with invalid shm-size",
+			frontmatter: map[string]any{
+				"tools": map[string]any{
+					"playwright": map[string]any{
+						"shm-size": "lots",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Playwright tool with pinned log-dir",
+			frontmatter: map[string]any{
+				"tools": map[string]any{
+					"playwright": map[string]any{
+						"log-dir": "browser/checkout",
+					},
+				},
+			},
+			expected: []MCPServerConfig{
+				{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "docker",
+					Command: "docker",
+					Args: []string{
+						"run", "-i", "--rm", "--shm-size=2gb", "--cap-add=SYS_ADMIN",
+						"-v", "/tmp/gh-aw/mcp-logs/browser/checkout:/tmp/gh-aw/mcp-logs/browser/checkout",
+						"mcr.microsoft.com/playwright:" + string(constants.DefaultPlaywrightBrowserVersion),
+					},
+					Env: map[string]string{}}, Name: "playwright",
+				},
+			},
+		},
+		{
+			name: "Playwright tool with log-dir escaping the logs directory",
+			frontmatter: map[string]any{
+				"tools": map[string]any{
+					"playwright": map[string]any{
+						"log-dir": "../etc",
+					},
+				},
+			},
+			expectError: true,
+		},
 
 		{
 			name: "Server filter - matching",
@@ -510,7 +793,7 @@ func TestParseMCPConfig(t *testing.T) {
 			expected: MCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "stdio",
 				Container: "myregistry/server:latest",
 				Command:   "docker",
-				Args:      []string{"run", "--rm", "-i", "-e", "DEBUG", "-e", "API_URL", "myregistry/server:latest"},
+				Args:      []string{"run", "--rm", "-i", "-e", "API_URL", "-e", "DEBUG", "myregistry/server:latest"},
 				Env: map[string]string{
 					"DEBUG":   "1",
 					"API_URL": "https://api.example.com",
@@ -520,6 +803,80 @@ func TestParseMCPConfig(t *testing.T) {
 				Allowed: []string{},
 			},
 		},
+		{
+			name:     "Stdio container with env and mounts in sorted order",
+			toolName: "sorted-server",
+			mcpSection: map[string]any{
+				"container": "myregistry/server:latest",
+				"env": map[string]any{
+					"ZETA":  "z",
+					"ALPHA": "a",
+					"MIKE":  "m",
+				},
+				"mounts": []any{"/tmp/z:/z:ro", "/tmp/a:/a:ro"},
+			},
+			toolConfig: map[string]any{},
+			expected: MCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "stdio",
+				Container: "myregistry/server:latest",
+				Command:   "docker",
+				Args: []string{"run", "--rm", "-i", "-e", "ALPHA", "-e", "MIKE", "-e", "ZETA",
+					"-v", "/tmp/a:/a:ro", "-v", "/tmp/z:/z:ro", "myregistry/server:latest"},
+				Env: map[string]string{
+					"ZETA":  "z",
+					"ALPHA": "a",
+					"MIKE":  "m",
+				},
+				Headers: map[string]string{}}, Name: "sorted-server",
+
+				Allowed: []string{},
+			},
+		},
+		{
+			name:     "Stdio container with volumes and custom entrypoint",
+			toolName: "volume-server",
+			mcpSection: map[string]any{
+				"container":      "myregistry/server:latest",
+				"entrypoint":     "/usr/local/bin/serve",
+				"entrypointArgs": []any{"--stdio"},
+				"volumes":        []any{"/tmp/data:/data:ro", "/tmp/cache:/cache"},
+			},
+			toolConfig: map[string]any{},
+			expected: MCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "stdio",
+				Container:  "myregistry/server:latest",
+				Command:    "docker",
+				Args:       []string{"run", "--rm", "-i", "-v", "/tmp/data:/data:ro", "-v", "/tmp/cache:/cache", "--entrypoint", "/usr/local/bin/serve", "myregistry/server:latest", "--stdio"},
+				Entrypoint: "/usr/local/bin/serve",
+				Volumes:    []string{"/tmp/data:/data:ro", "/tmp/cache:/cache"},
+				Env:        map[string]string{},
+				Headers:    map[string]string{}}, Name: "volume-server",
+
+				Allowed: []string{},
+			},
+		},
+		{
+			name:     "Stdio env with default interpolation",
+			toolName: "default-env-server",
+			mcpSection: map[string]any{
+				"command": "node",
+				"args":    []any{"server.js"},
+				"env": map[string]any{
+					"API_URL": "${API_URL:-https://default.example.com}",
+					"API_KEY": "${{ secrets.API_KEY }}",
+				},
+			},
+			toolConfig: map[string]any{},
+			expected: MCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "stdio",
+				Command: "node",
+				Args:    []string{"server.js"},
+				Env: map[string]string{
+					"API_URL": "${API_URL:-https://default.example.com}",
+					"API_KEY": "${{ secrets.API_KEY }}",
+				},
+				Headers: map[string]string{}}, Name: "default-env-server",
+
+				Allowed: []string{},
+			},
+		},
 		{
 			name:     "HTTP server",
 			toolName: "http-server",
@@ -543,6 +900,43 @@ func TestParseMCPConfig(t *testing.T) {
 				Allowed: []string{},
 			},
 		},
+		{
+			name:     "WebSocket server",
+			toolName: "ws-server",
+			mcpSection: map[string]any{
+				"type": "websocket",
+				"url":  "wss://mcp.example.com/ws",
+				"headers": map[string]any{
+					"Authorization": "Bearer token123",
+				},
+			},
+			toolConfig: map[string]any{},
+			expected: MCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "websocket",
+				URL: "wss://mcp.example.com/ws",
+				Headers: map[string]string{
+					"Authorization": "Bearer token123",
+				},
+				Env: map[string]string{}}, Name: "ws-server",
+
+				Allowed: []string{},
+			},
+		},
+		{
+			name:     "WebSocket server with wss alias",
+			toolName: "wss-server",
+			mcpSection: map[string]any{
+				"type": "wss",
+				"url":  "wss://mcp.example.com/ws",
+			},
+			toolConfig: map[string]any{},
+			expected: MCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "websocket",
+				URL:     "wss://mcp.example.com/ws",
+				Headers: map[string]string{},
+				Env:     map[string]string{}}, Name: "wss-server",
+
+				Allowed: []string{},
+			},
+		},
 		{
 			name:     "HTTP server with underscored headers",
 			toolName: "datadog-server",
@@ -586,6 +980,24 @@ func TestParseMCPConfig(t *testing.T) {
 				Allowed: []string{"tool1", "tool2", "tool3"},
 			},
 		},
+		{
+			name:     "With allowed tool wildcard",
+			toolName: "server-with-wildcard",
+			mcpSection: map[string]any{
+				"type":    "stdio",
+				"command": "server",
+			},
+			toolConfig: map[string]any{
+				"allowed": []any{"*"},
+			},
+			expected: MCPServerConfig{BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "stdio",
+				Command: "server",
+				Env:     map[string]string{},
+				Headers: map[string]string{}}, Name: "server-with-wildcard",
+
+				Allowed: []string{"*"},
+			},
+		},
 		{
 			name:     "JSON string config",
 			toolName: "json-server",
@@ -748,7 +1160,7 @@ func TestParseMCPConfig(t *testing.T) {
 		{
 			name:        "Unsupported type",
 			toolName:    "unsupported",
-			mcpSection:  map[string]any{"type": "websocket"},
+			mcpSection:  map[string]any{"type": "grpc"},
 			toolConfig:  map[string]any{},
 			expectError: true,
 		},
@@ -766,6 +1178,13 @@ func TestParseMCPConfig(t *testing.T) {
 			toolConfig:  map[string]any{},
 			expectError: true,
 		},
+		{
+			name:        "WebSocket missing URL",
+			toolName:    "no-ws-url",
+			mcpSection:  map[string]any{"type": "websocket"},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
 		{
 			name:        "Invalid JSON string",
 			toolName:    "invalid-json",
@@ -790,6 +1209,276 @@ func TestParseMCPConfig(t *testing.T) {
 			toolConfig:  map[string]any{},
 			expectError: true,
 		},
+		{
+			name:     "Volume missing container path",
+			toolName: "bad-volume",
+			mcpSection: map[string]any{
+				"container": "myregistry/server:latest",
+				"volumes":   []any{"/tmp/data"},
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "Volume with invalid mode",
+			toolName: "bad-volume-mode",
+			mcpSection: map[string]any{
+				"container": "myregistry/server:latest",
+				"volumes":   []any{"/tmp/data:/data:readonly"},
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "Volume with relative container path",
+			toolName: "relative-volume",
+			mcpSection: map[string]any{
+				"container": "myregistry/server:latest",
+				"volumes":   []any{"/tmp/data:data"},
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "Allowed tool with pattern",
+			toolName: "bad-pattern",
+			mcpSection: map[string]any{
+				"command": "node",
+			},
+			toolConfig:  map[string]any{"allowed": []any{"issue_*_read"}},
+			expectError: true,
+		},
+		{
+			name:     "Env with recursive interpolation",
+			toolName: "recursive-env",
+			mcpSection: map[string]any{
+				"command": "node",
+				"env":     map[string]any{"API_URL": "${API_URL:-${FALLBACK_URL}}"},
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "Container env with malformed interpolation",
+			toolName: "malformed-env",
+			mcpSection: map[string]any{
+				"container": "myregistry/server:latest",
+				"env":       map[string]any{"API_URL": "${API_URL:-https://default.example.com"},
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "Startup timeout and health check",
+			toolName: "slow-server",
+			mcpSection: map[string]any{
+				"command":         "node",
+				"args":            []any{"server.js"},
+				"startup-timeout": 90,
+				"health-check":    true,
+			},
+			toolConfig: map[string]any{},
+			expected: MCPServerConfig{
+				BaseMCPServerConfig: types.BaseMCPServerConfig{
+					Type:           "stdio",
+					Command:        "node",
+					Args:           []string{"server.js"},
+					Env:            map[string]string{},
+					Headers:        map[string]string{},
+					StartupTimeout: 90,
+					HealthCheck:    true,
+				},
+				Name: "slow-server",
+			},
+		},
+		{
+			name:     "HTTP health check with startup timeout from JSON",
+			toolName: "remote-health",
+			mcpSection: map[string]any{
+				"type":            "http",
+				"url":             "https://api.example.com/mcp",
+				"startup-timeout": float64(30),
+				"health-check":    true,
+			},
+			toolConfig: map[string]any{},
+			expected: MCPServerConfig{
+				BaseMCPServerConfig: types.BaseMCPServerConfig{
+					Type:           "http",
+					URL:            "https://api.example.com/mcp",
+					Env:            map[string]string{},
+					Headers:        map[string]string{},
+					StartupTimeout: 30,
+					HealthCheck:    true,
+				},
+				Name: "remote-health",
+			},
+		},
+		{
+			name:     "Zero startup timeout",
+			toolName: "zero-timeout",
+			mcpSection: map[string]any{
+				"command":         "node",
+				"startup-timeout": 0,
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "Fractional startup timeout",
+			toolName: "fractional-timeout",
+			mcpSection: map[string]any{
+				"command":         "node",
+				"startup-timeout": 1.5,
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "String startup timeout",
+			toolName: "string-timeout",
+			mcpSection: map[string]any{
+				"command":         "node",
+				"startup-timeout": "60s",
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "Non-boolean health check",
+			toolName: "bad-health-check",
+			mcpSection: map[string]any{
+				"command":      "node",
+				"health-check": "yes",
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "HTTP static Authorization header without auth",
+			toolName: "static-auth",
+			mcpSection: map[string]any{
+				"url": "https://api.example.com/mcp",
+				"headers": map[string]any{
+					"Authorization": "Bearer ${{ secrets.API_TOKEN }}",
+				},
+			},
+			toolConfig: map[string]any{},
+			expected: MCPServerConfig{
+				BaseMCPServerConfig: types.BaseMCPServerConfig{
+					Type:    "http",
+					URL:     "https://api.example.com/mcp",
+					Env:     map[string]string{},
+					Headers: map[string]string{"Authorization": "Bearer ${{ secrets.API_TOKEN }}"},
+				},
+				Name: "static-auth",
+			},
+		},
+		{
+			name:     "HTTP OAuth client credentials",
+			toolName: "oauth-server",
+			mcpSection: map[string]any{
+				"type": "http",
+				"url":  "https://api.example.com/mcp",
+				"headers": map[string]any{
+					"X-Tenant": "acme",
+				},
+				"auth": map[string]any{
+					"token-url":     "https://auth.example.com/oauth/token",
+					"client-id":     "${{ secrets.MCP_CLIENT_ID }}",
+					"client-secret": "${{ secrets.MCP_CLIENT_SECRET }}",
+					"scope":         "mcp.read mcp.write",
+				},
+			},
+			toolConfig: map[string]any{},
+			expected: MCPServerConfig{
+				BaseMCPServerConfig: types.BaseMCPServerConfig{
+					Type:    "http",
+					URL:     "https://api.example.com/mcp",
+					Env:     map[string]string{},
+					Headers: map[string]string{"X-Tenant": "acme"},
+					Auth: &types.MCPAuthConfig{
+						TokenURL:     "https://auth.example.com/oauth/token",
+						ClientID:     "${{ secrets.MCP_CLIENT_ID }}",
+						ClientSecret: "${{ secrets.MCP_CLIENT_SECRET }}",
+						Scope:        "mcp.read mcp.write",
+					},
+				},
+				Name: "oauth-server",
+			},
+		},
+		{
+			name:     "HTTP auth missing client-secret",
+			toolName: "oauth-missing-secret",
+			mcpSection: map[string]any{
+				"url": "https://api.example.com/mcp",
+				"auth": map[string]any{
+					"token-url": "https://auth.example.com/oauth/token",
+					"client-id": "my-client",
+				},
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "HTTP auth with literal client-secret",
+			toolName: "oauth-literal-secret",
+			mcpSection: map[string]any{
+				"url": "https://api.example.com/mcp",
+				"auth": map[string]any{
+					"token-url":     "https://auth.example.com/oauth/token",
+					"client-id":     "my-client",
+					"client-secret": "hunter2",
+				},
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "HTTP auth with plain http token-url",
+			toolName: "oauth-insecure",
+			mcpSection: map[string]any{
+				"url": "https://api.example.com/mcp",
+				"auth": map[string]any{
+					"token-url":     "http://auth.example.com/oauth/token",
+					"client-id":     "my-client",
+					"client-secret": "${{ secrets.MCP_CLIENT_SECRET }}",
+				},
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "HTTP auth with unknown field",
+			toolName: "oauth-unknown",
+			mcpSection: map[string]any{
+				"url": "https://api.example.com/mcp",
+				"auth": map[string]any{
+					"token-url":     "https://auth.example.com/oauth/token",
+					"client-id":     "my-client",
+					"client-secret": "${{ secrets.MCP_CLIENT_SECRET }}",
+					"grant-type":    "password",
+				},
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
+		{
+			name:     "HTTP auth combined with static Authorization header",
+			toolName: "oauth-conflict",
+			mcpSection: map[string]any{
+				"url": "https://api.example.com/mcp",
+				"headers": map[string]any{
+					"authorization": "Bearer ${{ secrets.API_TOKEN }}",
+				},
+				"auth": map[string]any{
+					"token-url":     "https://auth.example.com/oauth/token",
+					"client-id":     "my-client",
+					"client-secret": "${{ secrets.MCP_CLIENT_SECRET }}",
+				},
+			},
+			toolConfig:  map[string]any{},
+			expectError: true,
+		},
 		{
 			name:     "Invalid URL type",
 			toolName: "invalid-url",
@@ -830,32 +1519,32 @@ func TestParseMCPConfig(t *testing.T) {
 			if result.Container != tt.expected.Container {
 				t.Errorf("Expected container %q, got %q", tt.expected.Container, result.Container)
 			}
+			if result.Entrypoint != tt.expected.Entrypoint {
+				t.Errorf("Expected entrypoint %q, got %q", tt.expected.Entrypoint, result.Entrypoint)
+			}
+			if !reflect.DeepEqual(result.Volumes, tt.expected.Volumes) {
+				t.Errorf("Expected volumes %v, got %v", tt.expected.Volumes, result.Volumes)
+			}
 			if result.URL != tt.expected.URL {
 				t.Errorf("Expected URL %q, got %q", tt.expected.URL, result.URL)
 			}
-			// For Docker containers, the environment variable order in args may vary
-			// due to map iteration order, so check for presence rather than exact order
-			if result.Container != "" {
-				// Check that all expected elements are present in args
-				expectedElements := make(map[string]bool)
-				for _, arg := range tt.expected.Args {
-					expectedElements[arg] = true
-				}
-				actualElements := make(map[string]bool)
-				for _, arg := range result.Args {
-					actualElements[arg] = true
-				}
-				if !reflect.DeepEqual(expectedElements, actualElements) {
-					t.Errorf("Expected args elements %v, got %v", tt.expected.Args, result.Args)
-				}
-			} else {
-				if !reflect.DeepEqual(result.Args, tt.expected.Args) {
-					t.Errorf("Expected args %v, got %v", tt.expected.Args, result.Args)
-				}
+			if result.StartupTimeout != tt.expected.StartupTimeout {
+				t.Errorf("Expected startup timeout %d, got %d", tt.expected.StartupTimeout, result.StartupTimeout)
+			}
+			if result.HealthCheck != tt.expected.HealthCheck {
+				t.Errorf("Expected health check %v, got %v", tt.expected.HealthCheck, result.HealthCheck)
+			}
+			// Args are compared in order: container env vars and mounts are sorted so that
+			// the same configuration always yields byte-identical lock files
+			if !reflect.DeepEqual(result.Args, tt.expected.Args) {
+				t.Errorf("Expected args %v, got %v", tt.expected.Args, result.Args)
 			}
 			if !reflect.DeepEqual(result.Headers, tt.expected.Headers) {
 				t.Errorf("Expected headers %v, got %v", tt.expected.Headers, result.Headers)
 			}
+			if !reflect.DeepEqual(result.Auth, tt.expected.Auth) {
+				t.Errorf("Expected auth %+v, got %+v", tt.expected.Auth, result.Auth)
+			}
 			if !reflect.DeepEqual(result.Env, tt.expected.Env) {
 				t.Errorf("Expected env %v, got %v", tt.expected.Env, result.Env)
 			}
@@ -958,9 +1647,19 @@ func TestIsMCPType(t *testing.T) {
 			expected: false,
 		},
 		{
-			name:     "websocket type (not valid)",
+			name:     "websocket type",
 			typeStr:  "websocket",
-			expected: false,
+			expected: true,
+		},
+		{
+			name:     "ws type (alias for websocket)",
+			typeStr:  "ws",
+			expected: true,
+		},
+		{
+			name:     "wss type (alias for websocket)",
+			typeStr:  "wss",
+			expected: true,
 		},
 		{
 			name:     "grpc type (not valid)",
@@ -996,7 +1695,7 @@ func TestIsMCPType(t *testing.T) {
 
 // TestValidMCPTypes tests that ValidMCPTypes constant is properly defined
 func TestValidMCPTypes(t *testing.T) {
-	expected := []string{"stdio", "http", "local"}
+	expected := []string{"stdio", "http", "local", "websocket", "ws", "wss"}
 	if !reflect.DeepEqual(ValidMCPTypes, expected) {
 		t.Errorf("ValidMCPTypes = %v, want %v", ValidMCPTypes, expected)
 	}
@@ -1008,3 +1707,21 @@ func TestValidMCPTypes(t *testing.T) {
 		}
 	}
 }
+
+func TestMCPServerConfigStartupTimeoutSeconds(t *testing.T) {
+	config, err := ParseMCPConfig("default-timeout", map[string]any{"command": "node", "health-check": true}, map[string]any{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.StartupTimeout != 0 {
+		t.Errorf("Expected unset startup timeout, got %d", config.StartupTimeout)
+	}
+	if got, want := config.StartupTimeoutSeconds(), int(constants.DefaultMCPServerStartupTimeout/time.Second); got != want {
+		t.Errorf("Expected default startup timeout %d, got %d", want, got)
+	}
+
+	config.StartupTimeout = 90
+	if got := config.StartupTimeoutSeconds(); got != 90 {
+		t.Errorf("Expected configured startup timeout 90, got %d", got)
+	}
+}
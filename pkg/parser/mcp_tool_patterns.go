@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// IsToolPattern reports whether an allowed tool entry is a glob pattern such as "issue_*".
+// The bare "*" wildcard is not a pattern: it allows every tool on the server.
+func IsToolPattern(name string) bool {
+	return name != "*" && strings.ContainsAny(name, "*?[")
+}
+
+// MatchToolPattern reports whether tool is matched by an allowed entry.
+// The entry may be the "*" wildcard, a glob pattern, or an exact tool name.
+// Malformed patterns never match.
+func MatchToolPattern(pattern, tool string) bool {
+	if pattern == "*" || pattern == tool {
+		return true
+	}
+	if !IsToolPattern(pattern) {
+		return false
+	}
+	matched, err := path.Match(pattern, tool)
+	return err == nil && matched
+}
+
+// IsToolAllowed reports whether tool is permitted by an allowed list.
+// An empty list allows every tool, matching the default when no allowlist is configured.
+func IsToolAllowed(allowed []string, tool string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, pattern := range allowed {
+		if MatchToolPattern(pattern, tool) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateCustomServerToolName checks an allowed entry for a custom MCP server.
+// Custom servers have no tool catalog at compile time and the engines only accept exact
+// tool names, so patterns such as "issue_*" cannot be expanded or enforced and are rejected.
+// The bare "*" wildcard is still accepted.
+func ValidateCustomServerToolName(name string) error {
+	if !IsToolPattern(name) {
+		return nil
+	}
+	return fmt.Errorf("unsupported tool pattern %q: custom MCP servers do not support tool patterns. List the tool names explicitly, or use \"*\" to allow every tool", name)
+}
@@ -0,0 +1,84 @@
+//go:build !integration
+
+package parser
+
+import "testing"
+
+func TestMatchToolPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		tool     string
+		expected bool
+	}{
+		{pattern: "*", tool: "create_issue", expected: true},
+		{pattern: "create_issue", tool: "create_issue", expected: true},
+		{pattern: "create_issue", tool: "create_issues", expected: false},
+		{pattern: "issue_*", tool: "issue_read", expected: true},
+		{pattern: "issue_*", tool: "issue_", expected: true},
+		{pattern: "issue_*", tool: "list_issues", expected: false},
+		{pattern: "*_issue", tool: "create_issue", expected: true},
+		{pattern: "get_?e", tool: "get_me", expected: true},
+		{pattern: "issue_[", tool: "issue_[", expected: true},
+		{pattern: "issue_[*", tool: "issue_read", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.tool, func(t *testing.T) {
+			if got := MatchToolPattern(tt.pattern, tt.tool); got != tt.expected {
+				t.Errorf("MatchToolPattern(%q, %q) = %v, want %v", tt.pattern, tt.tool, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsToolAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		allowed  []string
+		tool     string
+		expected bool
+	}{
+		{name: "empty list allows everything", allowed: nil, tool: "anything", expected: true},
+		{name: "wildcard", allowed: []string{"*"}, tool: "anything", expected: true},
+		{name: "exact match", allowed: []string{"get_me", "create_issue"}, tool: "create_issue", expected: true},
+		{name: "pattern match", allowed: []string{"get_me", "issue_*"}, tool: "issue_write", expected: true},
+		{name: "no match", allowed: []string{"get_me", "issue_*"}, tool: "list_issues", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsToolAllowed(tt.allowed, tt.tool); got != tt.expected {
+				t.Errorf("IsToolAllowed(%v, %q) = %v, want %v", tt.allowed, tt.tool, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateCustomServerToolName(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectError bool
+	}{
+		{name: "exact name", input: "create_issue"},
+		{name: "wildcard", input: "*"},
+		{name: "prefix pattern", input: "issue_*", expectError: true},
+		{name: "suffix pattern", input: "*_issue", expectError: true},
+		{name: "infix pattern", input: "issue_*_read", expectError: true},
+		{name: "double star", input: "issue_**", expectError: true},
+		{name: "question mark", input: "issue_?", expectError: true},
+		{name: "character class", input: "issue_[rw]*", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCustomServerToolName(tt.input)
+			if tt.expectError && err == nil {
+				t.Errorf("ValidateCustomServerToolName(%q) expected error, got nil", tt.input)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("ValidateCustomServerToolName(%q) unexpected error: %v", tt.input, err)
+			}
+		})
+	}
+}
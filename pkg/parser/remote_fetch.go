@@ -619,6 +619,10 @@ func ResolveRefToSHA(owner, repo, ref string) (string, error) {
 	return resolveRefToSHA(owner, repo, ref)
 }
 
+// resolveImportRefFunc resolves the ref of an import pinned with the 'ref' field to a commit SHA.
+// Tests override it to avoid GitHub API calls.
+var resolveImportRefFunc = resolveRefToSHA
+
 func downloadFileFromGitHub(owner, repo, path, ref string) ([]byte, error) {
 	return downloadFileFromGitHubWithDepth(owner, repo, path, ref, 0)
 }
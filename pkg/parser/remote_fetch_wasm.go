@@ -55,6 +55,10 @@ func isRepositoryImport(importPath string) bool {
 	return true
 }
 
+var resolveImportRefFunc = func(owner, repo, ref string) (string, error) {
+	return "", fmt.Errorf("remote imports not available in Wasm: %s/%s@%s", owner, repo, ref)
+}
+
 func ResolveIncludePath(filePath, baseDir string, cache *ImportCache) (string, error) {
 	if isWorkflowSpec(filePath) {
 		return "", fmt.Errorf("remote imports not available in Wasm: %s", filePath)
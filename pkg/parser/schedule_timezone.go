@@ -0,0 +1,261 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Embed the tz database so timezone validation does not depend on the host
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var scheduleTimezoneLog = logger.New("parser:schedule_timezone")
+
+// This file converts cron expressions written in a local time zone into UTC cron expressions,
+// since GitHub Actions evaluates schedules in UTC only.
+//
+// Zones with daylight saving time use different UTC offsets across the year. A single cron
+// expression cannot follow the clock change, so the schedule is expanded into one UTC cron per
+// offset, each restricted to the months in which that offset is in effect. Months in which
+// the clock changes use the offset that applies on most of their days, so runs in the days
+// around a clock change happen one hour early or late in local time. No run is skipped or
+// duplicated.
+
+// TimezoneCron is a UTC cron expression derived from a local-time schedule
+type TimezoneCron struct {
+	Cron   string // UTC cron expression
+	Offset string // UTC offset of the local time zone assumed by Cron, e.g. UTC-04:00
+}
+
+// cronMonthNames and cronWeekdayNames map the three-letter names accepted by cron to numbers
+var (
+	cronMonthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	cronWeekdayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// LoadScheduleTimezone validates tz against the tz database and returns its location
+func LoadScheduleTimezone(tz string) (*time.Location, error) {
+	if tz == "" || tz == "Local" {
+		return nil, fmt.Errorf("invalid time zone '%s': use an IANA time zone name such as America/New_York", tz)
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone '%s': use an IANA time zone name such as America/New_York", tz)
+	}
+	return loc, nil
+}
+
+// ConvertCronToUTC converts a cron expression in the time zone tz into one or more UTC cron
+// expressions, using the time zone rules of the given year. The minute and hour fields must
+// be fixed values. Day-of-month and month restrictions are only supported when the
+// conversion does not move the run to another day.
+func ConvertCronToUTC(cronExpr, tz string, year int) ([]TimezoneCron, error) {
+	scheduleTimezoneLog.Printf("Converting cron %q from %s to UTC (year %d)", cronExpr, tz, year)
+
+	loc, err := LoadScheduleTimezone(tz)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(cronExpr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s': must have exactly 5 fields", cronExpr)
+	}
+	minute, minuteErr := strconv.Atoi(fields[0])
+	hour, hourErr := strconv.Atoi(fields[1])
+	if minuteErr != nil || hourErr != nil || minute < 0 || minute > 59 || hour < 0 || hour > 23 {
+		return nil, fmt.Errorf("cron expression '%s' must use a fixed minute and hour to be converted from time zone %s", cronExpr, tz)
+	}
+
+	domRestricted := fields[2] != "*"
+	monthRestricted := fields[3] != "*"
+	var months []int
+	if monthRestricted {
+		if months, err = parseCronListField(fields[3], 1, 12, cronMonthNames); err != nil {
+			return nil, fmt.Errorf("invalid month field in cron expression '%s': %w", cronExpr, err)
+		}
+	}
+
+	// Find the offset in effect for the runs of each UTC month
+	localMinutes := hour*60 + minute
+	offsetCounts := make(map[time.Month]map[int]int)
+	for day := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		if monthRestricted && !slices.Contains(months, int(day.Month())) {
+			continue
+		}
+		local := time.Date(year, day.Month(), day.Day(), hour, minute, 0, 0, loc)
+		_, offsetSeconds := local.Zone()
+		utcMonth := local.UTC().Month()
+		if offsetCounts[utcMonth] == nil {
+			offsetCounts[utcMonth] = make(map[int]int)
+		}
+		offsetCounts[utcMonth][offsetSeconds/60]++
+	}
+
+	monthsByOffset := make(map[int][]int)
+	var offsets []int
+	for month := time.January; month <= time.December; month++ {
+		counts, ok := offsetCounts[month]
+		if !ok {
+			continue
+		}
+		offset := majorityOffset(counts)
+		if _, seen := monthsByOffset[offset]; !seen {
+			offsets = append(offsets, offset)
+		}
+		monthsByOffset[offset] = append(monthsByOffset[offset], int(month))
+	}
+
+	var result []TimezoneCron
+	for _, offset := range offsets {
+		utcMinutes := localMinutes - offset
+		dayShift := floorDiv(utcMinutes, 24*60)
+		utcMinutes -= dayShift * 24 * 60
+
+		dayOfWeek := fields[4]
+		if dayShift != 0 {
+			if domRestricted || monthRestricted {
+				return nil, fmt.Errorf("cron expression '%s' in time zone %s runs on a different day in UTC; day-of-month and month restrictions cannot be converted, use day-of-week instead", cronExpr, tz)
+			}
+			if dayOfWeek, err = shiftDayOfWeekField(dayOfWeek, dayShift); err != nil {
+				return nil, fmt.Errorf("invalid day-of-week field in cron expression '%s': %w", cronExpr, err)
+			}
+		}
+
+		monthField := fields[3]
+		if len(offsets) > 1 {
+			monthField = formatCronList(monthsByOffset[offset])
+		}
+
+		cron := fmt.Sprintf("%d %d %s %s %s", utcMinutes%60, utcMinutes/60, fields[2], monthField, dayOfWeek)
+		result = append(result, TimezoneCron{Cron: cron, Offset: formatUTCOffset(offset)})
+	}
+
+	scheduleTimezoneLog.Printf("Converted cron %q in %s to %d UTC expression(s)", cronExpr, tz, len(result))
+	return result, nil
+}
+
+// majorityOffset returns the offset (in minutes) counted most often, preferring the smaller
+// offset on ties so the result is deterministic
+func majorityOffset(counts map[int]int) int {
+	best, bestCount := 0, -1
+	for offset, count := range counts {
+		if count > bestCount || (count == bestCount && offset < best) {
+			best, bestCount = offset, count
+		}
+	}
+	return best
+}
+
+// shiftDayOfWeekField moves every day in a day-of-week field by shift days
+func shiftDayOfWeekField(field string, shift int) (string, error) {
+	if field == "*" {
+		return field, nil
+	}
+	days, err := parseCronListField(field, 0, 7, cronWeekdayNames)
+	if err != nil {
+		return "", err
+	}
+	var shifted []int
+	for _, day := range days {
+		day = ((day+shift)%7 + 7) % 7
+		if !slices.Contains(shifted, day) {
+			shifted = append(shifted, day)
+		}
+	}
+	slices.Sort(shifted)
+	return formatCronList(shifted), nil
+}
+
+// parseCronListField expands a cron field made of values, names and ranges separated by
+// commas into the list of values it matches. Step values are not supported.
+func parseCronListField(field string, minValue, maxValue int, names map[string]int) ([]int, error) {
+	parseValue := func(s string) (int, error) {
+		if value, ok := names[strings.ToLower(s)]; ok {
+			return value, nil
+		}
+		value, err := strconv.Atoi(s)
+		if err != nil || value < minValue || value > maxValue {
+			return 0, fmt.Errorf("'%s' must be a value between %d and %d", s, minValue, maxValue)
+		}
+		return value, nil
+	}
+
+	var values []int
+	for part := range strings.SplitSeq(field, ",") {
+		if strings.Contains(part, "/") || part == "*" {
+			return nil, errors.New("steps and wildcards inside lists are not supported")
+		}
+		start, end, isRange := strings.Cut(part, "-")
+		first, err := parseValue(start)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = parseValue(end); err != nil {
+				return nil, err
+			}
+			if last < first {
+				return nil, fmt.Errorf("range '%s' is reversed", part)
+			}
+		}
+		for value := first; value <= last; value++ {
+			normalized := value
+			// 7 is an alias for Sunday in the day-of-week field
+			if maxValue == 7 && value == 7 {
+				normalized = 0
+			}
+			if !slices.Contains(values, normalized) {
+				values = append(values, normalized)
+			}
+		}
+	}
+	slices.Sort(values)
+	return values, nil
+}
+
+// formatCronList renders sorted values as a cron list, collapsing consecutive values into ranges
+func formatCronList(values []int) string {
+	var parts []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%d-%d", values[i], values[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(values[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// formatUTCOffset renders an offset in minutes as UTC+HH:MM
+func formatUTCOffset(offsetMinutes int) string {
+	sign := "+"
+	if offsetMinutes < 0 {
+		sign = "-"
+		offsetMinutes = -offsetMinutes
+	}
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, offsetMinutes/60, offsetMinutes%60)
+}
+
+// floorDiv divides rounding toward negative infinity
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
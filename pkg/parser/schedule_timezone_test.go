@@ -0,0 +1,96 @@
+//go:build !integration
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertCronToUTC(t *testing.T) {
+	tests := []struct {
+		name     string
+		cron     string
+		tz       string
+		expected []TimezoneCron
+	}{
+		{
+			name: "daylight saving time expands into one cron per offset",
+			cron: "0 9 * * 1",
+			tz:   "America/New_York",
+			expected: []TimezoneCron{
+				{Cron: "0 14 * 1-2,11-12 1", Offset: "UTC-05:00"},
+				{Cron: "0 13 * 3-10 1", Offset: "UTC-04:00"},
+			},
+		},
+		{
+			name:     "zone without daylight saving time keeps a single cron",
+			cron:     "30 8 * * 1-5",
+			tz:       "Asia/Kolkata",
+			expected: []TimezoneCron{{Cron: "0 3 * * 1-5", Offset: "UTC+05:30"}},
+		},
+		{
+			name:     "run moves to the previous day in UTC",
+			cron:     "0 7 * * mon,fri",
+			tz:       "Asia/Tokyo",
+			expected: []TimezoneCron{{Cron: "0 22 * * 0,4", Offset: "UTC+09:00"}},
+		},
+		{
+			name:     "run moves to the next day in UTC and wraps Saturday to Sunday",
+			cron:     "0 20 * * 6",
+			tz:       "America/Los_Angeles",
+			expected: []TimezoneCron{{Cron: "0 4 * 1-2,11-12 0", Offset: "UTC-08:00"}, {Cron: "0 3 * 3-10 0", Offset: "UTC-07:00"}},
+		},
+		{
+			name:     "UTC is unchanged",
+			cron:     "15 6 1 * *",
+			tz:       "UTC",
+			expected: []TimezoneCron{{Cron: "15 6 1 * *", Offset: "UTC+00:00"}},
+		},
+		{
+			name:     "month restriction is kept when the day does not change",
+			cron:     "0 12 * 6-8 *",
+			tz:       "Europe/Berlin",
+			expected: []TimezoneCron{{Cron: "0 10 * 6-8 *", Offset: "UTC+02:00"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ConvertCronToUTC(tt.cron, tt.tz, 2026)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestConvertCronToUTCErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		cron    string
+		tz      string
+		errText string
+	}{
+		{name: "unknown time zone", cron: "0 9 * * 1", tz: "Mars/Olympus_Mons", errText: "unknown time zone 'Mars/Olympus_Mons'"},
+		{name: "local time zone", cron: "0 9 * * 1", tz: "Local", errText: "invalid time zone 'Local'"},
+		{name: "interval hour", cron: "0 */2 * * *", tz: "Europe/Paris", errText: "must use a fixed minute and hour"},
+		{name: "day of month with day change", cron: "0 0 1 * *", tz: "Europe/Paris", errText: "runs on a different day in UTC"},
+		{name: "step in day of week", cron: "0 7 * * */2", tz: "Asia/Tokyo", errText: "steps and wildcards"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertCronToUTC(tt.cron, tt.tz, 2026)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errText)
+		})
+	}
+}
+
+func TestFormatCronList(t *testing.T) {
+	assert.Equal(t, "1-2,11-12", formatCronList([]int{1, 2, 11, 12}))
+	assert.Equal(t, "0,4", formatCronList([]int{0, 4}))
+	assert.Equal(t, "3-10", formatCronList([]int{3, 4, 5, 6, 7, 8, 9, 10}))
+}
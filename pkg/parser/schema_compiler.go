@@ -85,6 +85,9 @@ func compileSchema(schemaJSON, schemaURL string) (*jsonschema.Schema, error) {
 var safeOutputMetaFields = map[string]bool{
 	"allowed-domains": true,
 	"staged":          true,
+	"dry-run":         true,
+	"max-total":       true,
+	"throttle-ms":     true,
 	"env":             true,
 	"github-token":    true,
 	"app":             true,
@@ -62,15 +62,23 @@ func TestPassThroughFieldValidation(t *testing.T) {
 			errContains: "oneOf",
 		},
 		{
-			name: "invalid concurrency - object missing required group",
+			name: "invalid concurrency - object missing group, group-template and cancel-in-progress",
+			frontmatter: map[string]any{
+				"on":          "push",
+				"concurrency": map[string]any{},
+			},
+			wantErr:     true,
+			errContains: "missing property",
+		},
+		{
+			name: "valid concurrency - cancel-in-progress override without group",
 			frontmatter: map[string]any{
 				"on": "push",
 				"concurrency": map[string]any{
 					"cancel-in-progress": true,
 				},
 			},
-			wantErr:     true,
-			errContains: "missing property 'group'",
+			wantErr: false,
 		},
 		{
 			name: "invalid concurrency - object with invalid field",
@@ -283,6 +291,19 @@ func TestPassThroughFieldValidation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid secrets - required secret without value",
+			frontmatter: map[string]any{
+				"on": "push",
+				"secrets": map[string]any{
+					"API_TOKEN": map[string]any{
+						"required":    true,
+						"description": "Value defaults to secrets.API_TOKEN",
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid secrets - object missing required value field",
 			frontmatter: map[string]any{
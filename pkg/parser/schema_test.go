@@ -1691,14 +1691,23 @@ func TestValidateIncludedFileFrontmatterWithSchema(t *testing.T) {
 			errContains: "additional properties 'invalid_option' not allowed",
 		},
 		{
-			name: "invalid: included file cannot have inputs at root level",
+			name: "valid: included file declares import inputs at root level",
 			frontmatter: map[string]any{
 				"inputs": map[string]any{
 					"input1": map[string]any{"description": "Input 1", "type": "string"},
 				},
 			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: included file input with unknown type",
+			frontmatter: map[string]any{
+				"inputs": map[string]any{
+					"input1": map[string]any{"type": "integer"},
+				},
+			},
 			wantErr:     true,
-			errContains: "additional properties 'inputs' not allowed",
+			errContains: "value must be one of",
 		},
 	}
 
@@ -1965,6 +1974,9 @@ func TestGetSafeOutputTypeKeys(t *testing.T) {
 	metaFields := []string{
 		"allowed-domains",
 		"staged",
+		"dry-run",
+		"max-total",
+		"throttle-ms",
 		"env",
 		"github-token",
 		"app",
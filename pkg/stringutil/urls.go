@@ -20,9 +20,11 @@ import (
 //	ExtractDomainFromURL("github.com:443")                        // returns "github.com"
 //	ExtractDomainFromURL("http://sub.domain.com:8080/path")       // returns "sub.domain.com"
 //	ExtractDomainFromURL("localhost:8080")                        // returns "localhost"
+//	ExtractDomainFromURL("wss://mcp.example.com/ws")              // returns "mcp.example.com"
 func ExtractDomainFromURL(urlStr string) string {
-	// Handle full URLs with protocols (http://, https://)
-	if strings.HasPrefix(urlStr, "http://") || strings.HasPrefix(urlStr, "https://") {
+	// Handle full URLs with protocols (http://, https://, ws://, wss://)
+	if strings.HasPrefix(urlStr, "http://") || strings.HasPrefix(urlStr, "https://") ||
+		strings.HasPrefix(urlStr, "ws://") || strings.HasPrefix(urlStr, "wss://") {
 		// Parse full URL
 		parsedURL, err := url.Parse(urlStr)
 		if err != nil {
@@ -16,10 +16,26 @@ type BaseMCPServerConfig struct {
 	// HTTP-specific fields
 	URL     string            `json:"url,omitempty" yaml:"url,omitempty"`         // URL for HTTP mode MCP servers
 	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"` // HTTP headers for HTTP mode
+	Auth    *MCPAuthConfig    `json:"auth,omitempty" yaml:"auth,omitempty"`       // OAuth client credentials used to obtain a bearer token for HTTP mode
 
 	// Container-specific fields
 	Container      string   `json:"container,omitempty" yaml:"container,omitempty"`           // Container image for the MCP server
 	Entrypoint     string   `json:"entrypoint,omitempty" yaml:"entrypoint,omitempty"`         // Optional entrypoint override for container
 	EntrypointArgs []string `json:"entrypointArgs,omitempty" yaml:"entrypointArgs,omitempty"` // Arguments passed to container entrypoint
 	Mounts         []string `json:"mounts,omitempty" yaml:"mounts,omitempty"`                 // Volume mounts for container (format: "source:dest:mode")
+	Volumes        []string `json:"volumes,omitempty" yaml:"volumes,omitempty"`               // Docker volumes for container (format: "host:container[:ro|rw]")
+
+	// Startup fields
+	StartupTimeout int  `json:"startup-timeout,omitempty" yaml:"startup-timeout,omitempty"` // Seconds the server has to become ready (0 means the default)
+	HealthCheck    bool `json:"health-check,omitempty" yaml:"health-check,omitempty"`       // Ping the server with tools/list at startup and fail fast if it is not ready
+}
+
+// MCPAuthConfig describes an OAuth 2.0 client credentials grant used to obtain a fresh bearer
+// token for an HTTP MCP server. The token is sent in the Authorization header.
+type MCPAuthConfig struct {
+	TokenURL     string `json:"token-url" yaml:"token-url"`                   // OAuth token endpoint
+	ClientID     string `json:"client-id" yaml:"client-id"`                   // Client id, usually a secrets expression
+	ClientSecret string `json:"client-secret" yaml:"client-secret"`           // Client secret, must be a secrets expression
+	Scope        string `json:"scope,omitempty" yaml:"scope,omitempty"`       // Optional space-separated scopes to request
+	Audience     string `json:"audience,omitempty" yaml:"audience,omitempty"` // Optional audience (resource) to request
 }
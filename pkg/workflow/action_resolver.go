@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -11,10 +12,20 @@ import (
 
 var resolverLog = logger.New("workflow:action_resolver")
 
+// ErrActionNotFound is returned by an ActionResolverFunc that has no pin for an action, so
+// resolution falls back to the action cache and the GitHub API
+var ErrActionNotFound = errors.New("action not found")
+
+// ActionResolverFunc resolves an action reference ("owner/repo@version", or
+// "owner/repo/path@version" for actions in subdirectories) to a full commit SHA.
+// It returns ErrActionNotFound (possibly wrapped) when the action is unknown to it.
+type ActionResolverFunc func(action string) (sha string, err error)
+
 // ActionResolver handles resolving action SHAs using GitHub CLI
 type ActionResolver struct {
 	cache             *ActionCache
-	failedResolutions map[string]bool // tracks failed resolution attempts in current run (key: "repo@version")
+	custom            ActionResolverFunc // optional resolver consulted before the cache and the GitHub API
+	failedResolutions map[string]bool    // tracks failed resolution attempts in current run (key: "repo@version")
 }
 
 // NewActionResolver creates a new action resolver
@@ -39,6 +50,22 @@ func (r *ActionResolver) ResolveSHA(repo, version string) (string, error) {
 		return "", fmt.Errorf("previously failed to resolve %s@%s in this compilation run", repo, version)
 	}
 
+	// A custom resolver is the source of truth for the actions it knows about, so it is
+	// consulted before the cache. Its results are not cached in actions-lock.json.
+	if r.custom != nil {
+		sha, err := r.resolveWithCustom(repo, version)
+		if err == nil {
+			resolverLog.Printf("Custom resolver resolved %s@%s to SHA: %s", repo, version, sha)
+			return sha, nil
+		}
+		if !errors.Is(err, ErrActionNotFound) {
+			resolverLog.Printf("Custom resolver failed for %s@%s: %v", repo, version, err)
+			r.failedResolutions[cacheKey] = true
+			return "", err
+		}
+		resolverLog.Printf("Custom resolver has no pin for %s@%s, falling back to default resolution", repo, version)
+	}
+
 	// Check cache first
 	if sha, found := r.cache.Get(repo, version); found {
 		resolverLog.Printf("Cache hit for %s@%s: %s", repo, version, sha)
@@ -67,6 +94,19 @@ func (r *ActionResolver) ResolveSHA(repo, version string) (string, error) {
 	return sha, nil
 }
 
+// resolveWithCustom resolves an action with the custom resolver and validates the returned SHA
+func (r *ActionResolver) resolveWithCustom(repo, version string) (string, error) {
+	sha, err := r.custom(formatActionCacheKey(repo, version))
+	if err != nil {
+		return "", err
+	}
+	sha = strings.TrimSpace(sha)
+	if !isValidFullSHA(sha) {
+		return "", fmt.Errorf("custom action resolver returned an invalid SHA for %s@%s: %q", repo, version, sha)
+	}
+	return sha, nil
+}
+
 // resolveFromGitHub uses gh CLI to resolve the SHA for an action@version
 func (r *ActionResolver) resolveFromGitHub(repo, version string) (string, error) {
 	// Extract base repository (for actions like "github/codeql-action/upload-sarif")
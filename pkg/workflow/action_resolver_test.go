@@ -3,9 +3,13 @@
 package workflow
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/testutil"
 )
 
@@ -102,5 +106,108 @@ func TestActionResolverFailedResolutionCache(t *testing.T) {
 	}
 }
 
+func TestActionResolverCustomResolver(t *testing.T) {
+	const mirrorSHA = "0123456789abcdef0123456789abcdef01234567"
+
+	tmpDir := testutil.TempDir(t, "test-*")
+	cache := NewActionCache(tmpDir)
+	cache.Set("actions/checkout", "v5", "cached-checkout-sha")
+	cache.Set("actions/setup-node", "v4", "cached-setup-node-sha")
+
+	var requested []string
+	resolver := NewActionResolver(cache)
+	resolver.custom = func(action string) (string, error) {
+		requested = append(requested, action)
+		switch action {
+		case "actions/checkout@v5":
+			return mirrorSHA, nil
+		case "actions/upload-artifact@v4":
+			return "not-a-sha", nil
+		case "actions/cache@v4":
+			return "", errors.New("mirror unavailable")
+		}
+		return "", ErrActionNotFound
+	}
+
+	// The custom resolver takes precedence over the cache
+	sha, err := resolver.ResolveSHA("actions/checkout", "v5")
+	if err != nil {
+		t.Fatalf("Expected custom resolver to resolve actions/checkout@v5, got: %v", err)
+	}
+	if sha != mirrorSHA {
+		t.Errorf("Expected SHA from custom resolver %q, got %q", mirrorSHA, sha)
+	}
+	if cached, _ := cache.Get("actions/checkout", "v5"); cached != "cached-checkout-sha" {
+		t.Errorf("Expected custom resolver results not to be cached, got %q", cached)
+	}
+
+	// Not found falls back to the cache
+	sha, err = resolver.ResolveSHA("actions/setup-node", "v4")
+	if err != nil {
+		t.Fatalf("Expected fallback to the cache, got: %v", err)
+	}
+	if sha != "cached-setup-node-sha" {
+		t.Errorf("Expected cached SHA, got %q", sha)
+	}
+
+	// Invalid SHAs and resolver errors fail the resolution
+	if _, err := resolver.ResolveSHA("actions/upload-artifact", "v4"); err == nil || !strings.Contains(err.Error(), "invalid SHA") {
+		t.Errorf("Expected invalid SHA error, got: %v", err)
+	}
+	if _, err := resolver.ResolveSHA("actions/cache", "v4"); err == nil || !strings.Contains(err.Error(), "mirror unavailable") {
+		t.Errorf("Expected resolver error to be returned, got: %v", err)
+	}
+
+	expected := []string{"actions/checkout@v5", "actions/setup-node@v4", "actions/upload-artifact@v4", "actions/cache@v4"}
+	if strings.Join(requested, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected custom resolver to be called with %v, got %v", expected, requested)
+	}
+}
+
+func TestCompileWithCustomActionResolver(t *testing.T) {
+	const mirrorSHA = "fedcba9876543210fedcba9876543210fedcba98"
+
+	tmpDir := testutil.TempDir(t, "custom-action-resolver-test")
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+steps:
+  - name: Set up internal tooling
+    uses: my-org/setup-tooling@v2
+---
+
+# Mirrored Actions
+
+Summarize the repository.
+`
+	testFile := filepath.Join(tmpDir, "mirrored.md")
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler(
+		WithGitRoot(tmpDir),
+		WithActionResolver(func(action string) (string, error) {
+			if action == "my-org/setup-tooling@v2" {
+				return mirrorSHA, nil
+			}
+			return "", ErrActionNotFound
+		}),
+	)
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Expected workflow to compile, got: %v", err)
+	}
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(lockContent), "my-org/setup-tooling@"+mirrorSHA+" # v2") {
+		t.Errorf("Expected my-org/setup-tooling to be pinned to the SHA from the custom resolver")
+	}
+}
+
 // Note: Testing the actual GitHub API resolution requires network access
 // and is tested in integration tests or with network-dependent test tags
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 var addCommentLog = logger.New("workflow:add_comment")
@@ -18,7 +21,7 @@ type AddCommentConfig struct {
 // AddCommentsConfig holds configuration for creating GitHub issue/PR comments from agent output
 type AddCommentsConfig struct {
 	BaseSafeOutputConfig `yaml:",inline"`
-	Target               string   `yaml:"target,omitempty"`              // Target for comments: "triggering" (default), "*" (any issue), or explicit issue number
+	Target               string   `yaml:"target,omitempty"`              // Target for comments: "triggering" (default), "*" (any issue), or explicit issue number (42 or "#42")
 	TargetRepoSlug       string   `yaml:"target-repo,omitempty"`         // Target repository in format "owner/repo" for cross-repository comments
 	AllowedRepos         []string `yaml:"allowed-repos,omitempty"`       // List of additional repositories that comments can be added to (additionally to the target-repo)
 	Discussion           *bool    `yaml:"discussion,omitempty"`          // Target discussion comments instead of issue/PR comments. Must be true if present.
@@ -159,6 +162,9 @@ func (c *Compiler) parseCommentsConfig(outputMap map[string]any) *AddCommentsCon
 		return nil
 	}
 
+	// Normalize a fixed target number (42 or "#42") to the "42" form used by target validation
+	normalizeCommentTarget(configData)
+
 	// Pre-process templatable int fields
 	if err := preprocessIntFieldAsString(configData, "max", addCommentLog); err != nil {
 		addCommentLog.Printf("Invalid max value: %v", err)
@@ -191,3 +197,30 @@ func (c *Compiler) parseCommentsConfig(outputMap map[string]any) *AddCommentsCon
 
 	return &config
 }
+
+// normalizeCommentTarget rewrites a fixed add-comment target given as a YAML number (42) or in
+// issue reference form ("#42") to the plain "42" string. Other values are left for target validation.
+func normalizeCommentTarget(configData map[string]any) {
+	if configData == nil {
+		return
+	}
+	switch v := configData["target"].(type) {
+	case int:
+		configData["target"] = strconv.Itoa(v)
+	case int64:
+		configData["target"] = strconv.FormatInt(v, 10)
+	case uint64:
+		configData["target"] = strconv.FormatUint(v, 10)
+	case float64:
+		if v == float64(int64(v)) {
+			configData["target"] = strconv.FormatInt(int64(v), 10)
+		}
+	case string:
+		if number, ok := strings.CutPrefix(v, "#"); ok && stringutil.IsPositiveInteger(number) {
+			configData["target"] = number
+		}
+	default:
+		return
+	}
+	addCommentLog.Printf("Normalized add-comment target: %v", configData["target"])
+}
@@ -0,0 +1,141 @@
+//go:build integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+)
+
+func TestAddCommentFixedTargetIntegration(t *testing.T) {
+	tests := []struct {
+		name           string
+		target         string
+		expectedConfig string
+	}{
+		{
+			name:           "quoted issue number",
+			target:         `"42"`,
+			expectedConfig: `\"add_comment\":{\"max\":1,\"target\":\"42\"}`,
+		},
+		{
+			name:           "bare YAML number",
+			target:         "42",
+			expectedConfig: `\"add_comment\":{\"max\":1,\"target\":\"42\"}`,
+		},
+		{
+			name:           "issue reference form",
+			target:         `"#42"`,
+			expectedConfig: `\"add_comment\":{\"max\":1,\"target\":\"42\"}`,
+		},
+		{
+			name:           "default targets the triggering entity",
+			expectedConfig: `\"add_comment\":{\"max\":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "add-comment-target-test")
+			addComment := "  add-comment:\n    max: 1\n"
+			if tt.target != "" {
+				addComment += "    target: " + tt.target + "\n"
+			}
+			content := `---
+on:
+  schedule:
+    - cron: "0 9 * * *"
+engine: copilot
+permissions:
+  contents: read
+safe-outputs:
+` + addComment + `---
+
+# Daily report
+`
+			testFile := filepath.Join(tmpDir, "daily-report.md")
+			if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := NewCompiler().CompileWorkflow(testFile); err != nil {
+				t.Fatalf("Unexpected compile error: %v", err)
+			}
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			lockStr := string(lockContent)
+
+			handlerConfigLine := ""
+			for line := range strings.SplitSeq(lockStr, "\n") {
+				if strings.Contains(line, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG:") {
+					handlerConfigLine = line
+					break
+				}
+			}
+			if handlerConfigLine == "" {
+				t.Fatal("Expected GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG in the lock file")
+			}
+			if !strings.Contains(handlerConfigLine, tt.expectedConfig) {
+				t.Errorf("Expected handler config to contain %s, got:\n%s", tt.expectedConfig, handlerConfigLine)
+			}
+		})
+	}
+}
+
+func TestAddCommentFixedTargetEnvIntegration(t *testing.T) {
+	tests := []struct {
+		name           string
+		target         any
+		expectedEnv    string
+		expectTrigger  bool
+		unexpectedEnvs []string
+	}{
+		{name: "fixed number", target: 42, expectedEnv: `GH_AW_COMMENT_TARGET: "42"`},
+		{name: "issue reference", target: "#42", expectedEnv: `GH_AW_COMMENT_TARGET: "42"`},
+		{name: "default", expectTrigger: true, unexpectedEnvs: []string{"GH_AW_COMMENT_TARGET"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addComment := map[string]any{}
+			if tt.target != nil {
+				addComment["target"] = tt.target
+			}
+			compiler := NewCompiler()
+			data := &WorkflowData{
+				Name:        "Test",
+				SafeOutputs: compiler.extractSafeOutputsConfig(map[string]any{"safe-outputs": map[string]any{"add-comment": addComment}}),
+			}
+			if data.SafeOutputs == nil || data.SafeOutputs.AddComments == nil {
+				t.Fatal("Expected AddComments configuration to be parsed")
+			}
+
+			job, err := compiler.buildCreateOutputAddCommentJob(data, "main_job", "", "", "")
+			if err != nil {
+				t.Fatalf("Error building add_comment job: %v", err)
+			}
+			jobYAML := strings.Join(job.Steps, "")
+
+			if tt.expectedEnv != "" && !strings.Contains(jobYAML, tt.expectedEnv) {
+				t.Errorf("Expected %s in job steps, got:\n%s", tt.expectedEnv, jobYAML)
+			}
+			for _, env := range tt.unexpectedEnvs {
+				if strings.Contains(jobYAML, env) {
+					t.Errorf("Expected no %s in job steps, got:\n%s", env, jobYAML)
+				}
+			}
+			// Without a fixed target the job only runs for issue, pull request or discussion events
+			if hasEventFilter := strings.Contains(job.If, "github.event.issue.number"); hasEventFilter != tt.expectTrigger {
+				t.Errorf("Expected event filter in job condition = %v, got: %s", tt.expectTrigger, job.If)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCommentTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		expected any
+	}{
+		{name: "int", target: 42, expected: "42"},
+		{name: "uint64", target: uint64(42), expected: "42"},
+		{name: "float64", target: float64(42), expected: "42"},
+		{name: "issue reference", target: "#42", expected: "42"},
+		{name: "plain number string", target: "42", expected: "42"},
+		{name: "triggering", target: "triggering", expected: "triggering"},
+		{name: "wildcard", target: "*", expected: "*"},
+		{name: "expression", target: "${{ inputs.issue }}", expected: "${{ inputs.issue }}"},
+		{name: "invalid reference left for validation", target: "#abc", expected: "#abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configData := map[string]any{"target": tt.target}
+			normalizeCommentTarget(configData)
+			assert.Equal(t, tt.expected, configData["target"])
+		})
+	}
+}
+
+func TestParseCommentsConfigFixedTarget(t *testing.T) {
+	compiler := NewCompiler()
+
+	config := compiler.parseCommentsConfig(map[string]any{"add-comment": map[string]any{"target": "#7"}})
+	require.NotNil(t, config)
+	assert.Equal(t, "7", config.Target)
+	require.NoError(t, validateTargetValue("add-comment", config.Target))
+
+	config = compiler.parseCommentsConfig(map[string]any{"add-comment": map[string]any{"target": "#abc"}})
+	require.NotNil(t, config)
+	require.Error(t, validateTargetValue("add-comment", config.Target), "an invalid issue reference should be rejected")
+}
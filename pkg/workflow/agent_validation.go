@@ -5,13 +5,14 @@
 // This file validates agent-specific configuration and feature compatibility
 // for agentic workflows. It ensures that:
 //   - Custom agent files exist when specified
-//   - Engine features are supported (HTTP transport, max-turns, web-search)
+//   - Engine features are supported (HTTP transport, max-turns, model parameters, web-search)
 //   - Workflow triggers have appropriate security constraints
 //
 // # Validation Functions
 //
 //   - validateAgentFile() - Validates custom agent file exists
 //   - validateMaxTurnsSupport() - Validates max-turns feature support
+//   - validateModelParametersSupport() - Validates max-tokens and temperature support
 //   - validateWebSearchSupport() - Validates web-search feature support (warning)
 //   - validateWorkflowRunBranches() - Validates workflow_run has branch restrictions
 //
@@ -122,6 +123,24 @@ func (c *Compiler) validateMaxTurnsSupport(frontmatter map[string]any, engine Co
 	return nil
 }
 
+// validateModelParametersSupport validates that max-tokens and temperature are only used with engines that support them
+func (c *Compiler) validateModelParametersSupport(frontmatter map[string]any, engine CodingAgentEngine) error {
+	_, engineConfig := c.ExtractEngineConfig(frontmatter)
+	if engineConfig == nil {
+		return nil
+	}
+
+	if engineConfig.MaxTokens != 0 && !engine.SupportsMaxTokens() {
+		return fmt.Errorf("max-tokens not supported: engine '%s' does not support the max-tokens parameter. Use engine: claude or codex, or remove max-tokens from your configuration. Example:\nengine:\n  id: claude\n  max-tokens: 8192", engine.GetID())
+	}
+
+	if engineConfig.Temperature != nil && !engine.SupportsTemperature() {
+		return fmt.Errorf("temperature not supported: engine '%s' does not support temperature. Remove temperature from your engine configuration", engine.GetID())
+	}
+
+	return nil
+}
+
 // validateWebSearchSupport validates that web-search tool is only used with engines that support this feature
 func (c *Compiler) validateWebSearchSupport(tools map[string]any, engine CodingAgentEngine) {
 	// Check if web-search tool is requested
@@ -134,8 +153,7 @@ func (c *Compiler) validateWebSearchSupport(tools map[string]any, engine CodingA
 
 	// web-search is specified, check if the engine supports it
 	if !engine.SupportsWebSearch() {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Engine '%s' does not support the web-search tool. See https://github.github.com/gh-aw/guides/web-search/ for alternatives.", engine.GetID())))
-		c.IncrementWarningCount()
+		c.addWarning(fmt.Sprintf("Engine '%s' does not support the web-search tool. See https://github.github.com/gh-aw/guides/web-search/ for alternatives.", engine.GetID()))
 	}
 }
 
@@ -214,7 +232,7 @@ func (c *Compiler) validateWorkflowRunBranches(workflowData *WorkflowData, markd
 	// In normal mode, this is a warning
 	formattedWarning := formatCompilerMessage(markdownPath, "warning", message)
 	fmt.Fprintln(os.Stderr, formattedWarning)
-	c.IncrementWarningCount()
+	c.recordWarning(message)
 
 	return nil
 }
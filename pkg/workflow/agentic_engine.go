@@ -38,6 +38,8 @@ type GitHubActionStep []string
 //   CapabilityProvider (feature detection - optional)
 //   ├── SupportsToolsAllowlist()
 //   ├── SupportsMaxTurns()
+//   ├── SupportsMaxTokens()
+//   ├── SupportsTemperature()
 //   ├── SupportsWebFetch()
 //   ├── SupportsWebSearch()
 //   └── SupportsFirewall()
@@ -112,6 +114,12 @@ type CapabilityProvider interface {
 	// SupportsMaxTurns returns true if this engine supports the max-turns feature
 	SupportsMaxTurns() bool
 
+	// SupportsMaxTokens returns true if this engine supports limiting output tokens via engine.max-tokens
+	SupportsMaxTokens() bool
+
+	// SupportsTemperature returns true if this engine supports setting the sampling temperature via engine.temperature
+	SupportsTemperature() bool
+
 	// SupportsWebFetch returns true if this engine has built-in support for the web-fetch tool
 	SupportsWebFetch() bool
 
@@ -213,6 +221,8 @@ type BaseEngine struct {
 	experimental           bool
 	supportsToolsAllowlist bool
 	supportsMaxTurns       bool
+	supportsMaxTokens      bool
+	supportsTemperature    bool
 	supportsWebFetch       bool
 	supportsWebSearch      bool
 	supportsFirewall       bool
@@ -244,6 +254,14 @@ func (e *BaseEngine) SupportsMaxTurns() bool {
 	return e.supportsMaxTurns
 }
 
+func (e *BaseEngine) SupportsMaxTokens() bool {
+	return e.supportsMaxTokens
+}
+
+func (e *BaseEngine) SupportsTemperature() bool {
+	return e.supportsTemperature
+}
+
 func (e *BaseEngine) SupportsWebFetch() bool {
 	return e.supportsWebFetch
 }
@@ -300,6 +300,8 @@ func TestEngineCapabilityVariety(t *testing.T) {
 	t.Run("copilot capabilities", func(t *testing.T) {
 		assert.True(t, copilot.SupportsToolsAllowlist())
 		assert.False(t, copilot.SupportsMaxTurns())
+		assert.False(t, copilot.SupportsMaxTokens())
+		assert.False(t, copilot.SupportsTemperature())
 		assert.True(t, copilot.SupportsWebFetch())
 		assert.False(t, copilot.SupportsWebSearch())
 		assert.True(t, copilot.SupportsFirewall())
@@ -309,6 +311,8 @@ func TestEngineCapabilityVariety(t *testing.T) {
 	t.Run("claude capabilities", func(t *testing.T) {
 		assert.True(t, claude.SupportsToolsAllowlist())
 		assert.True(t, claude.SupportsMaxTurns())
+		assert.True(t, claude.SupportsMaxTokens())
+		assert.False(t, claude.SupportsTemperature())
 		assert.True(t, claude.SupportsWebFetch())
 		assert.True(t, claude.SupportsWebSearch())
 		assert.True(t, claude.SupportsFirewall())
@@ -318,6 +322,8 @@ func TestEngineCapabilityVariety(t *testing.T) {
 	t.Run("codex capabilities", func(t *testing.T) {
 		assert.True(t, codex.SupportsToolsAllowlist())
 		assert.False(t, codex.SupportsMaxTurns())
+		assert.True(t, codex.SupportsMaxTokens())
+		assert.False(t, codex.SupportsTemperature())
 		assert.False(t, codex.SupportsWebFetch())
 		assert.True(t, codex.SupportsWebSearch())
 		assert.True(t, codex.SupportsFirewall())
@@ -1,6 +1,9 @@
 package workflow
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -57,3 +60,47 @@ func (c *Compiler) parseAssignToAgentConfig(outputMap map[string]any) *AssignToA
 
 	return &config
 }
+
+// copilotAgentName is the agent that assign-to-copilot assigns by default
+const copilotAgentName = "copilot"
+
+// parseAssignToCopilotConfig reports whether the assign-to-copilot shorthand is enabled
+func (c *Compiler) parseAssignToCopilotConfig(outputMap map[string]any) bool {
+	enabled, _ := outputMap["assign-to-copilot"].(bool)
+	if enabled {
+		assignToAgentLog.Print("assign-to-copilot enabled")
+	}
+	return enabled
+}
+
+// expandAssignToCopilot expands the assign-to-copilot shorthand into the equivalent assign-to-agent
+// configuration. An explicit assign-to-agent block is kept, with copilot filled in as its default agent
+// when it does not name one; conflicting names are reported by validateAssignToCopilot.
+func expandAssignToCopilot(config *AssignToAgentConfig) *AssignToAgentConfig {
+	if config == nil {
+		return &AssignToAgentConfig{
+			BaseSafeOutputConfig: BaseSafeOutputConfig{Max: defaultIntStr(1)},
+			DefaultAgent:         copilotAgentName,
+		}
+	}
+	if config.DefaultAgent == "" {
+		config.DefaultAgent = copilotAgentName
+	}
+	return config
+}
+
+// validateAssignToCopilot checks that assign-to-copilot is not combined with an assign-to-agent
+// block that selects a different agent
+func validateAssignToCopilot(config *SafeOutputsConfig) error {
+	if config == nil || !config.AssignToCopilot || config.AssignToAgent == nil {
+		return nil
+	}
+	agent := config.AssignToAgent
+	if agent.DefaultAgent != copilotAgentName {
+		return fmt.Errorf("safe-outputs.assign-to-copilot conflicts with safe-outputs.assign-to-agent.name '%s'. Remove assign-to-copilot or set assign-to-agent.name to '%s'", agent.DefaultAgent, copilotAgentName)
+	}
+	if len(agent.Allowed) > 0 && !slices.Contains(agent.Allowed, copilotAgentName) {
+		return fmt.Errorf("safe-outputs.assign-to-copilot conflicts with safe-outputs.assign-to-agent.allowed, which does not include '%s'", copilotAgentName)
+	}
+	return nil
+}
@@ -5,8 +5,10 @@ package workflow
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/stringutil"
 	"github.com/github/gh-aw/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -42,3 +44,110 @@ This workflow tests canonical 'name' key.
 	require.NotNil(t, workflowData.SafeOutputs.AssignToAgent, "AssignToAgent should not be nil")
 	assert.Equal(t, "copilot", workflowData.SafeOutputs.AssignToAgent.DefaultAgent, "Should parse 'name' key as DefaultAgent")
 }
+
+// compileAssignToAgentWorkflow compiles a workflow with the given safe-outputs block and returns the
+// lock file without the frontmatter hash metadata line
+func compileAssignToAgentWorkflow(t *testing.T, safeOutputs string) string {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "assign-to-copilot-test")
+
+	workflow := `---
+on: issues
+engine: copilot
+permissions:
+  contents: read
+safe-outputs:
+` + safeOutputs + `
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "test-assign.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(workflow), 0644), "Failed to write test workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Failed to compile workflow")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+
+	var lines []string
+	for line := range strings.SplitSeq(string(lockContent), "\n") {
+		if !strings.HasPrefix(line, "# gh-aw-metadata:") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TestAssignToCopilotMatchesAssignToAgent tests that the assign-to-copilot shorthand compiles
+// exactly like assign-to-agent with copilot as the default agent
+func TestAssignToCopilotMatchesAssignToAgent(t *testing.T) {
+	shorthand := compileAssignToAgentWorkflow(t, "  assign-to-copilot: true")
+	explicit := compileAssignToAgentWorkflow(t, "  assign-to-agent:\n    name: copilot")
+
+	assert.Contains(t, shorthand, "id: assign_to_agent", "assign-to-copilot should generate the assign_to_agent step")
+	assert.Contains(t, shorthand, `GH_AW_AGENT_DEFAULT: "copilot"`, "assign-to-copilot should default to the copilot agent")
+	assert.Equal(t, explicit, shorthand, "both forms should produce the same workflow")
+}
+
+func TestAssignToCopilotWithAssignToAgent(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]any
+		expectError string
+		expected    *AssignToAgentConfig
+	}{
+		{
+			name:     "shorthand alone",
+			config:   map[string]any{"assign-to-copilot": true},
+			expected: &AssignToAgentConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Max: defaultIntStr(1)}, DefaultAgent: "copilot"},
+		},
+		{
+			name: "fills in the agent of an explicit block",
+			config: map[string]any{
+				"assign-to-copilot": true,
+				"assign-to-agent":   map[string]any{"max": 3},
+			},
+			expected: &AssignToAgentConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("3")}, DefaultAgent: "copilot"},
+		},
+		{
+			name:     "disabled shorthand is ignored",
+			config:   map[string]any{"assign-to-copilot": false},
+			expected: nil,
+		},
+		{
+			name: "conflicting agent name",
+			config: map[string]any{
+				"assign-to-copilot": true,
+				"assign-to-agent":   map[string]any{"name": "other-agent"},
+			},
+			expectError: "conflicts with safe-outputs.assign-to-agent.name 'other-agent'",
+		},
+		{
+			name: "allowed list without copilot",
+			config: map[string]any{
+				"assign-to-copilot": true,
+				"assign-to-agent":   map[string]any{"allowed": []any{"other-agent"}},
+			},
+			expectError: "does not include 'copilot'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			config := compiler.extractSafeOutputsConfig(map[string]any{"safe-outputs": tt.config})
+			require.NotNil(t, config, "safe-outputs config should be extracted")
+
+			err := validateAssignToCopilot(config)
+			if tt.expectError != "" {
+				require.Error(t, err, "conflicting configuration should be rejected")
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config.AssignToAgent)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package workflow
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -10,7 +14,7 @@ var assignToUserLog = logger.New("workflow:assign_to_user")
 type AssignToUserConfig struct {
 	BaseSafeOutputConfig   `yaml:",inline"`
 	SafeOutputTargetConfig `yaml:",inline"`
-	Allowed                []string `yaml:"allowed,omitempty"`        // Optional list of allowed usernames. If omitted, any users are allowed.
+	Allowed                []string `yaml:"allowed,omitempty"`        // Optional list of allowed usernames or "@org/team" entries. If omitted, any users are allowed.
 	Blocked                []string `yaml:"blocked,omitempty"`        // Optional list of blocked usernames or patterns (e.g., "copilot", "*[bot]")
 	UnassignFirst          *string  `yaml:"unassign-first,omitempty"` // If true, unassign all current assignees before assigning new ones
 }
@@ -59,3 +63,48 @@ func (c *Compiler) parseAssignToUserConfig(outputMap map[string]any) *AssignToUs
 
 	return &config
 }
+
+// assignToUserTeamPattern matches "@org/team-slug" entries in assign-to-user.allowed
+var assignToUserTeamPattern = regexp.MustCompile(`^@([A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)/([A-Za-z0-9][A-Za-z0-9_-]*)$`)
+
+// splitAssignToUserAllowed separates literal usernames from "@org/team" entries.
+// Teams are returned as "org/team-slug" so the runtime script can pass them
+// straight to the org team members API.
+func splitAssignToUserAllowed(allowed []string) (users []string, teams []string) {
+	for _, entry := range allowed {
+		if strings.HasPrefix(entry, "@") {
+			teams = append(teams, strings.TrimPrefix(entry, "@"))
+			continue
+		}
+		users = append(users, entry)
+	}
+	return users, teams
+}
+
+// validateAssignToUserAllowed checks the team entries of assign-to-user.allowed.
+// Team members are resolved at runtime through the org API, which the default
+// GITHUB_TOKEN cannot read, so a GitHub App or a custom token is required.
+func validateAssignToUserAllowed(config *SafeOutputsConfig) error {
+	if config == nil || config.AssignToUser == nil {
+		return nil
+	}
+
+	hasTeams := false
+	for _, entry := range config.AssignToUser.Allowed {
+		if !strings.HasPrefix(entry, "@") && !strings.Contains(entry, "/") {
+			continue
+		}
+		if !assignToUserTeamPattern.MatchString(entry) {
+			return fmt.Errorf("safe-outputs.assign-to-user.allowed entry '%s' is not a valid team reference. Use '@org/team-slug' for teams or a plain username for users", entry)
+		}
+		hasTeams = true
+	}
+
+	hasToken := config.App != nil || config.GitHubToken != "" || config.AssignToUser.GitHubToken != ""
+	if hasTeams && !hasToken {
+		return fmt.Errorf("safe-outputs.assign-to-user.allowed contains team entries, which require reading team membership. Configure safe-outputs.app (granted 'members: read'), or set safe-outputs.github-token or safe-outputs.assign-to-user.github-token to a token that has the 'read:org' scope")
+	}
+
+	assignToUserLog.Printf("Validated assign-to-user allowed list: has_teams=%t", hasTeams)
+	return nil
+}
@@ -0,0 +1,199 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitAssignToUserAllowed(t *testing.T) {
+	users, teams := splitAssignToUserAllowed([]string{"user1", "@my-org/triage", "user2", "@other-org/on-call"})
+	assert.Equal(t, []string{"user1", "user2"}, users, "Literal users should be kept")
+	assert.Equal(t, []string{"my-org/triage", "other-org/on-call"}, teams, "Teams should be returned without the @ prefix")
+
+	users, teams = splitAssignToUserAllowed(nil)
+	assert.Empty(t, users)
+	assert.Empty(t, teams)
+}
+
+func TestValidateAssignToUserAllowed(t *testing.T) {
+	app := &GitHubAppConfig{AppID: "${{ vars.APP_ID }}", PrivateKey: "${{ secrets.APP_PRIVATE_KEY }}"}
+
+	tests := []struct {
+		name     string
+		config   *SafeOutputsConfig
+		errorMsg string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+		},
+		{
+			name:   "literal users only",
+			config: &SafeOutputsConfig{AssignToUser: &AssignToUserConfig{Allowed: []string{"user1", "dependabot[bot]"}}},
+		},
+		{
+			name: "team with app",
+			config: &SafeOutputsConfig{
+				App:          app,
+				AssignToUser: &AssignToUserConfig{Allowed: []string{"user1", "@my-org/triage-team"}},
+			},
+		},
+		{
+			name: "team with github-token",
+			config: &SafeOutputsConfig{
+				GitHubToken:  "${{ secrets.ORG_READ_TOKEN }}",
+				AssignToUser: &AssignToUserConfig{Allowed: []string{"@my-org/triage_team"}},
+			},
+		},
+		{
+			name: "team with assign-to-user github-token",
+			config: &SafeOutputsConfig{
+				AssignToUser: &AssignToUserConfig{
+					BaseSafeOutputConfig: BaseSafeOutputConfig{GitHubToken: "${{ secrets.ORG_READ_TOKEN }}"},
+					Allowed:              []string{"@my-org/triage"},
+				},
+			},
+		},
+		{
+			name:     "team without token",
+			config:   &SafeOutputsConfig{AssignToUser: &AssignToUserConfig{Allowed: []string{"@my-org/triage"}}},
+			errorMsg: "contains team entries, which require reading team membership",
+		},
+		{
+			name:     "team without slug",
+			config:   &SafeOutputsConfig{App: app, AssignToUser: &AssignToUserConfig{Allowed: []string{"@my-org"}}},
+			errorMsg: "entry '@my-org' is not a valid team reference",
+		},
+		{
+			name:     "team without @ prefix",
+			config:   &SafeOutputsConfig{App: app, AssignToUser: &AssignToUserConfig{Allowed: []string{"my-org/triage"}}},
+			errorMsg: "entry 'my-org/triage' is not a valid team reference",
+		},
+		{
+			name:     "invalid org name",
+			config:   &SafeOutputsConfig{App: app, AssignToUser: &AssignToUserConfig{Allowed: []string{"@-my-org/triage"}}},
+			errorMsg: "entry '@-my-org/triage' is not a valid team reference",
+		},
+		{
+			name:     "nested team path",
+			config:   &SafeOutputsConfig{App: app, AssignToUser: &AssignToUserConfig{Allowed: []string{"@my-org/triage/leads"}}},
+			errorMsg: "entry '@my-org/triage/leads' is not a valid team reference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAssignToUserAllowed(tt.config)
+			if tt.errorMsg == "" {
+				assert.NoError(t, err, "Expected no error")
+				return
+			}
+			require.Error(t, err, "Expected validation error")
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestCompileAssignToUserWithTeams(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "assign-to-user-teams-test")
+
+	testContent := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  app:
+    app-id: ${{ vars.APP_ID }}
+    private-key: ${{ secrets.APP_PRIVATE_KEY }}
+  assign-to-user:
+    allowed: [octocat, "@my-org/triage"]
+---
+
+# Triage
+
+Assign the opened issue to someone on the triage team.
+`
+
+	testFile := filepath.Join(tmpDir, "assign-teams.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with team entries should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+
+	assert.Contains(t, safeOutputsJob, `\"allowed_teams\":[\"my-org/triage\"]`, "Teams should be passed to the handler")
+	assert.Contains(t, safeOutputsJob, `\"allowed\":[\"octocat\"]`, "Only literal users should be in the allowed list")
+	assert.Contains(t, safeOutputsJob, "permission-members: read", "App token should be able to read team members")
+}
+
+func TestCompileAssignToUserWithTeamsAndOutputToken(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "assign-to-user-teams-token-test")
+
+	testContent := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  assign-to-user:
+    allowed: ["@my-org/triage"]
+    github-token: ${{ secrets.ORG_READ_TOKEN }}
+---
+
+# Triage
+
+Assign the opened issue to someone on the triage team.
+`
+
+	testFile := filepath.Join(tmpDir, "assign-teams-token.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "A github-token on assign-to-user should satisfy the team check")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+
+	assert.Contains(t, safeOutputsJob, "GH_AW_ASSIGN_TO_USER_GITHUB_TOKEN: ${{ secrets.ORG_READ_TOKEN }}", "Team lookup should use the assign-to-user token")
+}
+
+func TestCompileAssignToUserRejectsInvalidTeam(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "assign-to-user-invalid-team-test")
+
+	testContent := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  github-token: ${{ secrets.ORG_READ_TOKEN }}
+  assign-to-user:
+    allowed: ["@my-org/triage team"]
+---
+
+# Triage
+
+Assign the opened issue.
+`
+
+	testFile := filepath.Join(tmpDir, "assign-invalid-team.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Malformed team entries should be rejected")
+	assert.Contains(t, err.Error(), "is not a valid team reference")
+}
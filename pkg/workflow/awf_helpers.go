@@ -155,7 +155,13 @@ func BuildAWFArgs(config AWFCommandConfig) []string {
 	// Use double-quoted form (via shellDoubleQuoteArg) so wildcards like *.domain.com are
 	// treated as plain arguments rather than shell globs, fixing ShellCheck SC1003, while
 	// still escaping $, `, \, and " to prevent unintended shell expansion.
-	awfArgs = append(awfArgs, "--allow-domains", shellDoubleQuoteArg(config.AllowedDomains))
+	// In audit mode localhost entries are always allowed (see network_log.go).
+	allowedDomains := config.AllowedDomains
+	if isNetworkAuditMode(config.WorkflowData.NetworkPermissions) {
+		allowedDomains = getNetworkAuditAllowedDomains(allowedDomains)
+		awfHelpersLog.Print("Network audit mode: adding localhost to allowed domains")
+	}
+	awfArgs = append(awfArgs, "--allow-domains", shellDoubleQuoteArg(allowedDomains))
 
 	// Add blocked domains if specified
 	blockedDomains := formatBlockedDomains(config.WorkflowData.NetworkPermissions)
@@ -0,0 +1,105 @@
+// This file provides parsing and rendering of bash tool command patterns.
+//
+// # Bash Command Patterns
+//
+// Each entry of the bash tool allowlist (tools.bash: [...]) is one of:
+//
+//   - "*" or ":*": any command
+//   - "git log:*" (or the equivalent "git log *" / "git log*"): prefix match on word boundaries.
+//     Allows "git log" and "git log --oneline -5", but not "git logs" or "git push"
+//   - "npm run test": exact match. Allows only "npm run test" with no further arguments.
+//     Any other "*" in an exact entry is literal (e.g. "find . -name '*.go'")
+//
+// Whitespace between words is not significant. gh-aw does not match commands itself: entries
+// are rendered in canonical form ("git log:*", "npm run test") as Bash(...) for Claude and
+// shell(...) for Copilot, and the engine's permission system matches them at runtime. Gemini
+// only supports prefix matching, so exact entries also allow additional arguments there.
+
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var bashCommandPatternLog = logger.New("workflow:bash_command_pattern")
+
+// bashCommandPattern is a parsed bash tool allowlist entry
+type bashCommandPattern struct {
+	command string // Command words, whitespace-normalized (empty for the all-commands wildcard)
+	prefix  bool   // Whether additional arguments are allowed after command
+}
+
+// parseBashCommandPattern parses a bash tool allowlist entry
+func parseBashCommandPattern(entry string) (bashCommandPattern, error) {
+	trimmed := strings.TrimSpace(entry)
+	if trimmed == "" {
+		return bashCommandPattern{}, fmt.Errorf("invalid bash command '%s': command must not be empty", entry)
+	}
+	if strings.ContainsAny(trimmed, "\n\r") {
+		return bashCommandPattern{}, fmt.Errorf("invalid bash command '%s': command must be a single line", entry)
+	}
+	if trimmed == "*" || trimmed == ":*" {
+		return bashCommandPattern{prefix: true}, nil
+	}
+
+	command, prefix := trimmed, false
+	switch {
+	case strings.HasSuffix(command, ":*"):
+		command, prefix = strings.TrimSuffix(command, ":*"), true
+	case strings.HasSuffix(command, "*") && !strings.HasSuffix(command, "**"):
+		command, prefix = strings.TrimSuffix(command, "*"), true
+	}
+
+	command = strings.Join(strings.Fields(command), " ")
+	if command == "" {
+		return bashCommandPattern{}, fmt.Errorf("invalid bash command '%s': a command is required before the ':*' wildcard", entry)
+	}
+	if strings.Contains(command, ":*") {
+		return bashCommandPattern{}, fmt.Errorf("invalid bash command '%s': the ':*' wildcard is only supported at the end of an entry (e.g. 'git log:*')", entry)
+	}
+
+	return bashCommandPattern{command: command, prefix: prefix}, nil
+}
+
+// isAll reports whether the pattern allows any command
+func (p bashCommandPattern) isAll() bool {
+	return p.command == "" && p.prefix
+}
+
+// String returns the canonical form of the pattern passed to engine permission systems
+func (p bashCommandPattern) String() string {
+	if p.isAll() {
+		return "*"
+	}
+	if p.prefix {
+		return p.command + ":*"
+	}
+	return p.command
+}
+
+// canonicalBashCommand returns the canonical form of a bash allowlist entry for rendering.
+// Entries that fail to parse are returned unchanged; they are rejected by validateBashToolConfig.
+func canonicalBashCommand(entry string) string {
+	pattern, err := parseBashCommandPattern(entry)
+	if err != nil {
+		return entry
+	}
+	if canonical := pattern.String(); canonical != entry {
+		bashCommandPatternLog.Printf("Normalized bash command %q to %q", entry, canonical)
+		return canonical
+	}
+	return entry
+}
+
+// validateBashCommandPatterns checks that every bash allowlist entry is a valid pattern
+func validateBashCommandPatterns(commands []string) error {
+	for _, command := range commands {
+		if _, err := parseBashCommandPattern(command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
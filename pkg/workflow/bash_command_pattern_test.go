@@ -0,0 +1,100 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBashCommandPatternRendering checks the permission entries passed to each engine.
+// The engines' permission systems do the matching, so the rendered form is what decides
+// which commands run.
+func TestBashCommandPatternRendering(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   string
+		claude  string
+		copilot string
+		gemini  string
+	}{
+		{name: "exact command", entry: "npm run test", claude: "Bash(npm run test)", copilot: "shell(npm run test)", gemini: "run_shell_command(npm run test)"},
+		{name: "exact command whitespace is normalized", entry: "npm  run   test", claude: "Bash(npm run test)", copilot: "shell(npm run test)", gemini: "run_shell_command(npm run test)"},
+		{name: "prefix pattern", entry: "git log:*", claude: "Bash(git log:*)", copilot: "shell(git log:*)", gemini: "run_shell_command(git log)"},
+		{name: "space wildcard is a prefix pattern", entry: "jq *", claude: "Bash(jq:*)", copilot: "shell(jq:*)", gemini: "run_shell_command(jq)"},
+		{name: "trailing wildcard is a prefix pattern", entry: "ls*", claude: "Bash(ls:*)", copilot: "shell(ls:*)", gemini: "run_shell_command(ls)"},
+		{name: "literal wildcard in exact command", entry: "find . -name '*.go'", claude: "Bash(find . -name '*.go')", copilot: "shell(find . -name '*.go')", gemini: "run_shell_command(find . -name '*.go')"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools := map[string]any{"bash": []any{tt.entry}}
+
+			allowed := NewClaudeEngine().computeAllowedClaudeToolsString(tools, nil, nil)
+			assert.Contains(t, strings.Split(allowed, ","), tt.claude, "Claude allowed tools")
+
+			args := NewCopilotEngine().computeCopilotToolArguments(tools, nil, nil, nil)
+			assert.Contains(t, args, tt.copilot, "Copilot tool arguments")
+
+			assert.Contains(t, computeGeminiToolsCore(tools), tt.gemini, "Gemini core tools")
+		})
+	}
+
+	t.Run("all commands", func(t *testing.T) {
+		for _, entry := range []string{"*", ":*"} {
+			tools := map[string]any{"bash": []any{entry}}
+			assert.Contains(t, strings.Split(NewClaudeEngine().computeAllowedClaudeToolsString(tools, nil, nil), ","), "Bash", "Claude allows all commands for %q", entry)
+			assert.Equal(t, []string{"--allow-all-tools"}, NewCopilotEngine().computeCopilotToolArguments(tools, nil, nil, nil), "Copilot allows all tools for %q", entry)
+			assert.Contains(t, computeGeminiToolsCore(tools), "run_shell_command", "Gemini allows all commands for %q", entry)
+		}
+	})
+}
+
+func TestCanonicalBashCommand(t *testing.T) {
+	tests := map[string]string{
+		"npm run test":        "npm run test",
+		"git log:*":           "git log:*",
+		"git  log *":          "git log:*",
+		"jq *":                "jq:*",
+		"ls*":                 "ls:*",
+		"*":                   "*",
+		":*":                  "*",
+		"find . -name '*.go'": "find . -name '*.go'",
+	}
+
+	for entry, expected := range tests {
+		assert.Equal(t, expected, canonicalBashCommand(entry), "Canonical form of %q", entry)
+	}
+}
+
+func TestParseBashCommandPatternErrors(t *testing.T) {
+	tests := []struct {
+		entry    string
+		errorMsg string
+	}{
+		{entry: "", errorMsg: "command must not be empty"},
+		{entry: "   ", errorMsg: "command must not be empty"},
+		{entry: " :*x", errorMsg: "only supported at the end"},
+		{entry: "git:* push", errorMsg: "only supported at the end"},
+		{entry: "echo a\nrm -rf /", errorMsg: "must be a single line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			_, err := parseBashCommandPattern(tt.entry)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestValidateBashToolConfigPatterns(t *testing.T) {
+	err := validateBashToolConfig(NewTools(map[string]any{"bash": []any{"git log:*", "git:* push"}}), "test")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid bash tool configuration: invalid bash command 'git:* push'")
+
+	assert.NoError(t, validateBashToolConfig(NewTools(map[string]any{"bash": []any{"git log:*", "npm run test"}}), "test"))
+}
@@ -839,12 +839,13 @@ func (c *Compiler) buildUpdateCacheMemoryJob(data *WorkflowData, threatDetection
 	job := &Job{
 		Name:        "update_cache_memory",
 		DisplayName: "", // No display name - job ID is sufficient
-		RunsOn:      "runs-on: ubuntu-latest",
+		RunsOn:      c.formatWorkflowRunsOn(data, "runs-on: ubuntu-latest"),
 		If:          jobCondition,
 		Permissions: permissions,
 		Needs:       []string{"agent", "detection"},
 		Env:         jobEnv,
 		Steps:       steps,
+		Provenance:  "tools.cache-memory",
 	}
 
 	return job, nil
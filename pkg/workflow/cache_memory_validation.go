@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var cacheMemoryValidationLog = logger.New("workflow:cache_memory_validation")
+
+// validCacheMemoryScopes are the accepted values of the cache-memory scope field
+var validCacheMemoryScopes = []string{"workflow", "repo"}
+
+// validateCacheMemory validates the tools.cache-memory configuration independently of strict mode.
+// Array entries must have unique, non-empty ids and non-empty keys, and every scope must be
+// "workflow" or "repo". The strict mode restriction on scope: repo is enforced by validateStrictTools.
+func validateCacheMemory(frontmatter map[string]any) error {
+	toolsMap, ok := frontmatter["tools"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	cacheMemoryValue, exists := toolsMap["cache-memory"]
+	if !exists {
+		return nil
+	}
+
+	switch value := cacheMemoryValue.(type) {
+	case map[string]any:
+		cacheMemoryValidationLog.Print("Validating cache-memory object configuration")
+		return validateCacheMemoryEntry(value, "tools.cache-memory", false)
+	case []any:
+		cacheMemoryValidationLog.Printf("Validating cache-memory array configuration with %d entries", len(value))
+		seenIDs := make(map[string]int)
+		for i, item := range value {
+			field := fmt.Sprintf("tools.cache-memory[%d]", i)
+			entry, ok := item.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s must be an object with 'id' and 'key' fields, got %T", field, item)
+			}
+			if err := validateCacheMemoryEntry(entry, field, true); err != nil {
+				return err
+			}
+			id := entry["id"].(string)
+			if previous, duplicate := seenIDs[id]; duplicate {
+				return fmt.Errorf("%s.id '%s' duplicates tools.cache-memory[%d].id. Each cache-memory entry must have a unique id", field, id, previous)
+			}
+			seenIDs[id] = i
+		}
+	}
+	return nil
+}
+
+// validateCacheMemoryEntry validates the id, key and scope of a single cache-memory entry.
+// The id and key are required for entries of the array form.
+func validateCacheMemoryEntry(entry map[string]any, field string, requireIDAndKey bool) error {
+	for _, name := range []string{"id", "key"} {
+		raw, exists := entry[name]
+		if !exists {
+			if requireIDAndKey {
+				return fmt.Errorf("%s is missing the required '%s' field", field, name)
+			}
+			continue
+		}
+		if str, ok := raw.(string); !ok || strings.TrimSpace(str) == "" {
+			return fmt.Errorf("%s.%s must be a non-empty string", field, name)
+		}
+	}
+
+	if raw, exists := entry["scope"]; exists {
+		scope, ok := raw.(string)
+		if !ok || !slices.Contains(validCacheMemoryScopes, scope) {
+			return fmt.Errorf("%s.scope has invalid value '%v'. Must be one of: %s", field, raw, strings.Join(validCacheMemoryScopes, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCacheMemory(t *testing.T) {
+	tests := []struct {
+		name        string
+		cacheMemory any
+		expectError string
+	}{
+		{
+			name:        "boolean form",
+			cacheMemory: true,
+		},
+		{
+			name:        "object form with repo scope",
+			cacheMemory: map[string]any{"key": "memory-audit", "scope": "repo"},
+		},
+		{
+			name: "array form with distinct ids",
+			cacheMemory: []any{
+				map[string]any{"id": "default", "key": "memory-default"},
+				map[string]any{"id": "session", "key": "memory-session", "scope": "workflow"},
+			},
+		},
+		{
+			name: "duplicate ids",
+			cacheMemory: []any{
+				map[string]any{"id": "notes", "key": "memory-notes"},
+				map[string]any{"id": "session", "key": "memory-session"},
+				map[string]any{"id": "notes", "key": "memory-other"},
+			},
+			expectError: "tools.cache-memory[2].id 'notes' duplicates tools.cache-memory[0].id",
+		},
+		{
+			name: "invalid scope in array entry",
+			cacheMemory: []any{
+				map[string]any{"id": "default", "key": "memory-default", "scope": "organization"},
+			},
+			expectError: "tools.cache-memory[0].scope has invalid value 'organization'. Must be one of: workflow, repo",
+		},
+		{
+			name:        "invalid scope in object form",
+			cacheMemory: map[string]any{"scope": "global"},
+			expectError: "tools.cache-memory.scope has invalid value 'global'",
+		},
+		{
+			name: "empty key",
+			cacheMemory: []any{
+				map[string]any{"id": "default", "key": "  "},
+			},
+			expectError: "tools.cache-memory[0].key must be a non-empty string",
+		},
+		{
+			name: "missing id",
+			cacheMemory: []any{
+				map[string]any{"key": "memory-default"},
+			},
+			expectError: "tools.cache-memory[0] is missing the required 'id' field",
+		},
+		{
+			name:        "entry that is not an object",
+			cacheMemory: []any{"default"},
+			expectError: "tools.cache-memory[0] must be an object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"tools": map[string]any{"cache-memory": tt.cacheMemory},
+			}
+			err := validateCacheMemory(frontmatter)
+			if tt.expectError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectError)
+		})
+	}
+}
+
+func TestCacheMemoryDuplicateIDsNonStrictCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "cache-memory-validation-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+strict: false
+engine: copilot
+permissions:
+  contents: read
+tools:
+  cache-memory:
+    - id: notes
+      key: memory-notes
+    - id: notes
+      key: memory-other
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	err := compiler.CompileWorkflow(testFile)
+	require.Error(t, err, "duplicate cache-memory ids should fail outside strict mode")
+	assert.Contains(t, err.Error(), "tools.cache-memory[1].id 'notes' duplicates tools.cache-memory[0].id")
+}
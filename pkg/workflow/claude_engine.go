@@ -27,6 +27,8 @@ func NewClaudeEngine() *ClaudeEngine {
 			experimental:           false,
 			supportsToolsAllowlist: true,
 			supportsMaxTurns:       true,  // Claude supports max-turns feature
+			supportsMaxTokens:      true,  // Claude supports max-tokens via CLAUDE_CODE_MAX_OUTPUT_TOKENS
+			supportsTemperature:    false, // Claude Code CLI does not expose sampling temperature
 			supportsWebFetch:       true,  // Claude has built-in WebFetch support
 			supportsWebSearch:      true,  // Claude has built-in WebSearch support
 			supportsFirewall:       true,  // Claude supports network firewalling via AWF
@@ -366,6 +368,10 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 		env["GH_AW_MAX_TURNS"] = workflowData.EngineConfig.MaxTurns
 	}
 
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.MaxTokens > 0 {
+		env[constants.ClaudeCLIMaxOutputTokensEnvVar] = strconv.Itoa(workflowData.EngineConfig.MaxTokens)
+	}
+
 	// Set the model environment variable.
 	// When model is configured, use the native ANTHROPIC_MODEL env var - the Claude CLI reads it
 	// directly, avoiding the need to embed the value in the shell command (which would fail
@@ -434,7 +440,7 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 
 	// Filter environment variables to only include allowed secrets
 	// This is a security measure to prevent exposing unnecessary secrets to the AWF container
-	allowedSecrets := e.GetRequiredSecretNames(workflowData)
+	allowedSecrets := append(e.GetRequiredSecretNames(workflowData), getEngineEnvKeys(workflowData)...)
 	filteredEnv := FilterEnvForSecrets(env, allowedSecrets)
 
 	// Format step with command and filtered environment variables using shared helper
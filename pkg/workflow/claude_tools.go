@@ -77,8 +77,15 @@ func (e *ClaudeEngine) expandNeutralToolsToClaudeTools(tools map[string]any) map
 	}
 
 	if _, hasWebFetch := tools["web-fetch"]; hasWebFetch {
-		// web-fetch -> WebFetch
-		claudeAllowed["WebFetch"] = nil
+		if domains := extractWebFetchDomains(tools); len(domains) > 0 {
+			// web-fetch with allowlist -> WebFetch(domain:example.com) for each domain
+			for _, domain := range domains {
+				claudeAllowed[fmt.Sprintf("WebFetch(domain:%s)", domain)] = nil
+			}
+		} else {
+			// web-fetch -> WebFetch
+			claudeAllowed["WebFetch"] = nil
+		}
 	}
 
 	if _, hasWebSearch := tools["web-search"]; hasWebSearch {
@@ -240,7 +247,7 @@ func (e *ClaudeEngine) computeAllowedClaudeToolsString(tools map[string]any, saf
 								// Add individual bash commands with Bash() prefix
 								for _, cmd := range bashCommands {
 									if cmdStr, ok := cmd.(string); ok {
-										allowedTools = append(allowedTools, fmt.Sprintf("Bash(%s)", cmdStr))
+										allowedTools = append(allowedTools, fmt.Sprintf("Bash(%s)", canonicalBashCommand(cmdStr)))
 									}
 								}
 							} else {
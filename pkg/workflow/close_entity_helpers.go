@@ -69,6 +69,14 @@ type CloseEntityConfig struct {
 	SafeOutputTargetConfig           `yaml:",inline"`
 	SafeOutputFilterConfig           `yaml:",inline"`
 	SafeOutputDiscussionFilterConfig `yaml:",inline"` // Only used for discussions
+	// RequireComment controls whether closing must post an explanatory comment (default: true).
+	// Only used for issues and pull requests.
+	RequireComment *bool `yaml:"require-comment,omitempty"`
+}
+
+// closeRequiresComment reports whether closing with this config must post a comment
+func closeRequiresComment(config *CloseEntityConfig) bool {
+	return config == nil || config.RequireComment == nil || *config.RequireComment
 }
 
 // CloseEntityJobParams holds the parameters needed to build a close entity job
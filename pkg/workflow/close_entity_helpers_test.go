@@ -0,0 +1,130 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCloseEntityConfigRequireComment(t *testing.T) {
+	compiler := NewCompiler()
+
+	config := compiler.parseCloseIssuesConfig(map[string]any{
+		"close-issue": map[string]any{"require-comment": false},
+	})
+	require.NotNil(t, config, "close-issue config should be parsed")
+	require.NotNil(t, config.RequireComment, "require-comment should be set")
+	assert.False(t, *config.RequireComment)
+	assert.False(t, closeRequiresComment(config))
+
+	config = compiler.parseClosePullRequestsConfig(map[string]any{
+		"close-pull-request": nil,
+	})
+	require.NotNil(t, config, "close-pull-request config should be parsed")
+	assert.Nil(t, config.RequireComment, "require-comment should be unset by default")
+	assert.True(t, closeRequiresComment(config), "a closing comment should be required by default")
+}
+
+func TestGenerateFilteredToolsJSONCloseRequireComment(t *testing.T) {
+	tests := []struct {
+		name           string
+		requireComment *bool
+		expectRequired bool
+	}{
+		{name: "default", requireComment: nil, expectRequired: true},
+		{name: "required", requireComment: boolPtr(true), expectRequired: true},
+		{name: "optional", requireComment: boolPtr(false), expectRequired: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &WorkflowData{
+				SafeOutputs: &SafeOutputsConfig{
+					CloseIssues:       &CloseEntityConfig{RequireComment: tt.requireComment},
+					ClosePullRequests: &CloseEntityConfig{RequireComment: tt.requireComment},
+				},
+			}
+
+			result, err := generateFilteredToolsJSON(data, ".github/workflows/test.md")
+			require.NoError(t, err, "generateFilteredToolsJSON should not error")
+
+			var tools []map[string]any
+			require.NoError(t, json.Unmarshal([]byte(result), &tools), "Result should be valid JSON")
+
+			for _, toolName := range []string{"close_issue", "close_pull_request"} {
+				var tool map[string]any
+				for _, candidate := range tools {
+					if candidate["name"] == toolName {
+						tool = candidate
+						break
+					}
+				}
+				require.NotNil(t, tool, "%s tool should be present", toolName)
+
+				inputSchema, ok := tool["inputSchema"].(map[string]any)
+				require.True(t, ok, "inputSchema should be present")
+				required, _ := inputSchema["required"].([]any)
+				if tt.expectRequired {
+					assert.Contains(t, required, "body", "%s should require a closing comment", toolName)
+					assert.NotContains(t, tool["description"], "is optional")
+				} else {
+					assert.NotContains(t, required, "body", "%s should not require a closing comment", toolName)
+					assert.Contains(t, tool["description"], "The closing comment (body) is optional.")
+				}
+			}
+		})
+	}
+}
+
+func TestGetWorkflowValidationConfigJSONCloseRequireComment(t *testing.T) {
+	safeOutputs := &SafeOutputsConfig{
+		CloseIssues:       &CloseEntityConfig{RequireComment: boolPtr(false)},
+		ClosePullRequests: &CloseEntityConfig{},
+	}
+
+	result, err := getWorkflowValidationConfigJSON([]string{"close_issue", "close_pull_request"}, safeOutputs)
+	require.NoError(t, err, "validation config should be generated")
+
+	var configs map[string]TypeValidationConfig
+	require.NoError(t, json.Unmarshal([]byte(result), &configs), "Result should be valid JSON")
+	assert.False(t, configs["close_issue"].Fields["body"].Required, "body should be optional with require-comment: false")
+	assert.True(t, configs["close_pull_request"].Fields["body"].Required, "body should be required by default")
+	assert.True(t, ValidationConfig["close_issue"].Fields["body"].Required, "shared validation config should not be modified")
+}
+
+func TestCompileWorkflowCloseIssueRequireComment(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "close-require-comment-test")
+
+	testContent := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  close-issue:
+    require-comment: false
+---
+
+# Close Issue Without Comment
+
+Close the issue if it is spam.
+`
+
+	testFile := filepath.Join(tmpDir, "close-require-comment.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	assert.Contains(t, string(lockContent), `\"require_comment\":false`, "Handler config should pass require_comment to the close_issue handler")
+}
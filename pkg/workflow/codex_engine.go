@@ -39,6 +39,8 @@ func NewCodexEngine() *CodexEngine {
 			experimental:           false,
 			supportsToolsAllowlist: true,
 			supportsMaxTurns:       false, // Codex does not support max-turns feature
+			supportsMaxTokens:      true,  // Codex supports max-tokens via -c model_max_output_tokens
+			supportsTemperature:    false, // Codex CLI does not expose sampling temperature
 			supportsWebFetch:       false, // Codex does not have built-in web-fetch support
 			supportsWebSearch:      true,  // Codex has built-in web-search support
 			supportsFirewall:       true,  // Codex supports network firewalling via AWF
@@ -149,6 +151,11 @@ func (e *CodexEngine) GetExecutionSteps(workflowData *WorkflowData, logFile stri
 	}
 	modelParam := fmt.Sprintf(`${%s:+-c model="$%s" }`, modelEnvVar, modelEnvVar)
 
+	// Limit output tokens per response if max-tokens is configured
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.MaxTokens > 0 {
+		modelParam += fmt.Sprintf("-c model_max_output_tokens=%d ", workflowData.EngineConfig.MaxTokens)
+	}
+
 	// Build search parameter if web-search tool is present
 	webSearchParam := ""
 	if workflowData.ParsedTools != nil && workflowData.ParsedTools.WebSearch != nil {
@@ -315,7 +322,7 @@ mkdir -p "$CODEX_HOME/logs"
 
 	// Filter environment variables to only include allowed secrets
 	// This is a security measure to prevent exposing unnecessary secrets to the AWF container
-	allowedSecrets := e.GetRequiredSecretNames(workflowData)
+	allowedSecrets := append(e.GetRequiredSecretNames(workflowData), getEngineEnvKeys(workflowData)...)
 	filteredEnv := FilterEnvForSecrets(env, allowedSecrets)
 
 	// Format step with command and filtered environment variables using shared helper
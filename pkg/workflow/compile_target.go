@@ -0,0 +1,43 @@
+package workflow
+
+import (
+	"strings"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+// CompileTarget identifies the CI system a workflow is compiled for
+type CompileTarget string
+
+const (
+	// CompileTargetGitHub compiles workflows to GitHub Actions .lock.yml files (default)
+	CompileTargetGitHub CompileTarget = "github"
+
+	// CompileTargetGitLab compiles workflows to GitLab CI .gitlab-ci.yml files (experimental)
+	CompileTargetGitLab CompileTarget = "gitlab"
+)
+
+// String returns the string representation of the compile target
+func (t CompileTarget) String() string {
+	return string(t)
+}
+
+// IsValid checks if the compile target is valid
+func (t CompileTarget) IsValid() bool {
+	return t == CompileTargetGitHub || t == CompileTargetGitLab
+}
+
+// GitLabCIFile returns the path of the GitLab CI file generated for a workflow markdown file
+// (e.g. "daily-report.md" -> "daily-report.gitlab-ci.yml")
+func GitLabCIFile(markdownPath string) string {
+	return strings.TrimSuffix(markdownPath, ".md") + ".gitlab-ci.yml"
+}
+
+// OutputFile returns the path of the file the compiler generates for a workflow markdown file:
+// the .lock.yml file, or the .gitlab-ci.yml file for the GitLab target
+func (c *Compiler) OutputFile(markdownPath string) string {
+	if c.target == CompileTargetGitLab {
+		return GitLabCIFile(markdownPath)
+	}
+	return stringutil.MarkdownToLockFile(markdownPath)
+}
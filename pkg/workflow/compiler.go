@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/stringutil"
 )
@@ -86,12 +87,55 @@ func (c *Compiler) CompileWorkflow(markdownPath string) error {
 	return c.CompileWorkflowData(workflowData, markdownPath)
 }
 
+// CompileWorkflowToYAML compiles a markdown workflow file and returns the generated
+// lock file content without writing anything to disk.
+func (c *Compiler) CompileWorkflowToYAML(markdownPath string) (string, error) {
+	c.markdownPath = markdownPath
+
+	log.Printf("Compiling workflow in memory: %s", markdownPath)
+	workflowData, err := c.ParseWorkflowFile(markdownPath)
+	if err != nil {
+		// Check if this is already a formatted console error
+		if strings.Contains(err.Error(), ":") && (strings.Contains(err.Error(), "error:") || strings.Contains(err.Error(), "warning:")) {
+			return "", err
+		}
+		return "", formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	return c.compileParsedWorkflowToYAML(workflowData, markdownPath)
+}
+
+// compileParsedWorkflowToYAML validates parsed workflow data and returns the generated lock file
+// content without writing anything to disk
+func (c *Compiler) compileParsedWorkflowToYAML(workflowData *WorkflowData, markdownPath string) (string, error) {
+	c.resetCompilationState()
+	if err := c.validateWorkflowData(workflowData, markdownPath); err != nil {
+		return "", err
+	}
+
+	yamlContent, err := c.generateYAML(workflowData, markdownPath)
+	if err != nil {
+		return "", formatCompilerError(markdownPath, "error", fmt.Sprintf("failed to generate YAML: %v", err), err)
+	}
+	return yamlContent, nil
+}
+
 // validateWorkflowData performs comprehensive validation of workflow configuration
 // including expressions, features, permissions, and configurations.
 func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath string) error {
 	// Validate expression safety - check that all GitHub Actions expressions are in the allowed list
 	log.Printf("Validating expression safety")
-	if err := validateExpressionSafety(workflowData.MarkdownContent); err != nil {
+	if err := validateMatrixStrategy(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	markdownContent, err := c.validateMatrixExpressions(workflowData)
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	if err := validateExpressionSafety(markdownContent); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	if err := validateWorkflowInputReferences(markdownContent, workflowData.RawFrontmatter); err != nil {
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
@@ -143,12 +187,48 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate pinned MCP log directories and the log rotation policy
+	log.Printf("Validating MCP log configuration")
+	if err := validateMCPLogConfig(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate that dry-run is not combined with custom safe jobs
+	log.Printf("Validating safe-outputs dry-run")
+	if err := validateSafeOutputsDryRun(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate safe-outputs target configuration
 	log.Printf("Validating safe-outputs target fields")
 	if err := validateSafeOutputsTarget(workflowData.SafeOutputs); err != nil {
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate assign-to-copilot is not combined with a conflicting assign-to-agent block
+	log.Printf("Validating safe-outputs assign-to-copilot")
+	if err := validateAssignToCopilot(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate create-pull-request-review does not approve unless allowed
+	log.Printf("Validating safe-outputs create-pull-request-review event")
+	if err := validateCreatePullRequestReview(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate team entries in assign-to-user.allowed
+	log.Printf("Validating safe-outputs assign-to-user allowed list")
+	if err := validateAssignToUserAllowed(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate safe-outputs title and body templates
+	log.Printf("Validating safe-outputs templates")
+	if err := validateSafeOutputsTemplates(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate safe-outputs allowed-domains configuration
 	log.Printf("Validating safe-outputs allowed-domains")
 	if err := c.validateSafeOutputsAllowedDomains(workflowData.SafeOutputs); err != nil {
@@ -161,12 +241,24 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Lowercase and deduplicate network allowed domains before the firewall rules are generated
+	log.Printf("Normalizing network allowed domains")
+	if err := c.normalizeNetworkAllowedDomains(workflowData.NetworkPermissions); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate network firewall configuration
 	log.Printf("Validating network firewall configuration")
 	if err := validateNetworkFirewallConfig(workflowData.NetworkPermissions); err != nil {
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate network log and audit mode configuration
+	log.Printf("Validating network log configuration")
+	if err := validateNetworkLogConfig(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate labels configuration
 	log.Printf("Validating labels")
 	if err := validateLabels(workflowData); err != nil {
@@ -199,28 +291,40 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		}
 	}
 
+	// Validate ${{ }} expression syntax in fields that are passed through to the lock file
+	log.Printf("Validating expression syntax in custom steps, jobs, env and concurrency")
+	if err := validateUserExpressionSyntax(workflowData.RawFrontmatter); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Emit warning for sandbox.agent: false (disables agent sandbox firewall)
 	if isAgentSandboxDisabled(workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("⚠️  WARNING: Agent sandbox disabled (sandbox.agent: false). This removes firewall protection. The AI agent will have direct network access without firewall filtering. The MCP gateway remains enabled. Only use this for testing or in controlled environments where you trust the AI agent completely."))
-		c.IncrementWarningCount()
+		c.addWarning("⚠️  WARNING: Agent sandbox disabled (sandbox.agent: false). This removes firewall protection. The AI agent will have direct network access without firewall filtering. The MCP gateway remains enabled. Only use this for testing or in controlled environments where you trust the AI agent completely.")
 	}
 
 	// Emit experimental warning for safe-inputs feature
 	if IsSafeInputsEnabled(workflowData.SafeInputs, workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: safe-inputs"))
-		c.IncrementWarningCount()
+		c.addWarning("Using experimental feature: safe-inputs")
 	}
 
 	// Emit experimental warning for plugins feature
 	if workflowData.PluginInfo != nil && len(workflowData.PluginInfo.Plugins) > 0 {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: plugins"))
-		c.IncrementWarningCount()
+		c.addWarning("Using experimental feature: plugins")
 	}
 
 	// Emit experimental warning for rate-limit feature
 	if workflowData.RateLimit != nil {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: rate-limit"))
-		c.IncrementWarningCount()
+		c.addWarning("Using experimental feature: rate-limit")
+	}
+
+	// Warn about required secrets that the workflow never references
+	for _, name := range c.findUnreferencedRequiredSecrets(workflowData) {
+		c.addWarning(fmt.Sprintf("Secret %s is declared as required but never referenced as secrets.%s in the workflow", name, name))
+	}
+
+	// Warn about safe outputs the prompt asks for that are not configured
+	for _, outputType := range findUnconfiguredPromptSafeOutputs(workflowData) {
+		c.addWarning(fmt.Sprintf("The prompt appears to instruct the agent to use %s, but safe-outputs.%s is not configured, so the agent cannot do it. Enable it under safe-outputs, or set features.%s: true to silence this check.", outputType, outputType, constants.DisableSafeOutputsPromptCheckFeatureFlag))
 	}
 
 	// Validate workflow_run triggers have branch restrictions
@@ -262,7 +366,7 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 					} else {
 						// In non-strict mode, missing permissions are warnings
 						fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", message))
-						c.IncrementWarningCount()
+						c.recordWarning(message)
 					}
 				}
 			}
@@ -280,7 +384,7 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 OIDC tokens can authenticate to cloud providers (AWS, Azure, GCP).
 Ensure proper audience validation and trust policies are configured.`
 				fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", warningMsg))
-				c.IncrementWarningCount()
+				c.recordWarning(warningMsg)
 			}
 		}
 	}
@@ -292,6 +396,17 @@ Ensure proper audience validation and trust policies are configured.`
 		allowedTools := workflowData.ParsedTools.GitHub.Allowed.ToStringSlice()
 		enabledToolsets := ParseGitHubToolsets(strings.Join(workflowData.ParsedTools.GitHub.Toolset.ToStringSlice(), ","))
 
+		// Tool names of unreleased or newer server versions cannot be verified against the
+		// tool catalog: warn about unknown tools and validate the known ones only
+		if serverVersion := workflowData.ParsedTools.GitHub.Version; !githubToolCatalogCovers(serverVersion) {
+			if unknown := unknownGitHubTools(allowedTools); len(unknown) > 0 {
+				warningMsg := fmt.Sprintf("Unknown GitHub tool(s) %s cannot be verified: github-mcp-server %s is not covered by the tool catalog (%s). Check the tool names against that server version", formatList(unknown), serverVersion, GitHubToolCatalogVersion)
+				fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", warningMsg))
+				c.recordWarning(warningMsg)
+				allowedTools = slices.DeleteFunc(allowedTools, func(tool string) bool { return slices.Contains(unknown, tool) })
+			}
+		}
+
 		// Validate that all allowed tools have their toolsets enabled
 		if err := ValidateGitHubToolsAgainstToolsets(allowedTools, enabledToolsets); err != nil {
 			return formatCompilerError(markdownPath, "error", err.Error(), err)
@@ -345,76 +460,32 @@ func (c *Compiler) generateAndValidateYAML(workflowData *WorkflowData, markdownP
 		return "", formatCompilerError(markdownPath, "error", fmt.Sprintf("failed to generate YAML: %v", err), err)
 	}
 
-	// Always validate expression sizes - this is a hard limit from GitHub Actions (21KB)
-	// that cannot be bypassed, so we validate it unconditionally
-	log.Print("Validating expression sizes")
-	if err := c.validateExpressionSizes(yamlContent); err != nil {
-		// Store error first so we can write invalid YAML before returning
-		formattedErr := formatCompilerError(markdownPath, "error", fmt.Sprintf("expression size validation failed: %v", err), err)
-		// Write the invalid YAML to a .invalid.yml file for inspection
-		invalidFile := strings.TrimSuffix(lockFile, ".lock.yml") + ".invalid.yml"
-		if writeErr := os.WriteFile(invalidFile, []byte(yamlContent), 0644); writeErr == nil {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Invalid workflow YAML written to: "+console.ToRelativePath(invalidFile)))
+	for _, check := range c.generatedYAMLChecks(workflowData, yamlContent) {
+		log.Print(check.description)
+		err := check.validate()
+		if err == nil {
+			continue
 		}
-		return "", formattedErr
-	}
-
-	// Validate for template injection vulnerabilities - detect unsafe expression usage in run: commands
-	log.Print("Validating for template injection vulnerabilities")
-	if err := validateNoTemplateInjection(yamlContent); err != nil {
-		// Store error first so we can write invalid YAML before returning
-		formattedErr := formatCompilerError(markdownPath, "error", err.Error(), err)
-		// Write the invalid YAML to a .invalid.yml file for inspection
-		invalidFile := strings.TrimSuffix(lockFile, ".lock.yml") + ".invalid.yml"
-		if writeErr := os.WriteFile(invalidFile, []byte(yamlContent), 0644); writeErr == nil {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Workflow with template injection risks written to: "+console.ToRelativePath(invalidFile)))
+		if check.warningOnly {
+			warningMsg := check.prefix + err.Error()
+			fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", warningMsg))
+			c.recordWarning(warningMsg)
+			continue
 		}
-		return "", formattedErr
-	}
-
-	// Validate against GitHub Actions schema (unless skipped)
-	if !c.skipValidation {
-		log.Print("Validating workflow against GitHub Actions schema")
-		if err := c.validateGitHubActionsSchema(yamlContent); err != nil {
-			// Store error first so we can write invalid YAML before returning
-			formattedErr := formatCompilerError(markdownPath, "error", fmt.Sprintf("workflow schema validation failed: %v", err), err)
+		// Store error first so we can write invalid YAML before returning
+		formattedErr := formatCompilerError(markdownPath, "error", check.prefix+err.Error(), err)
+		if check.invalidYAMLNotice != "" {
 			// Write the invalid YAML to a .invalid.yml file for inspection
 			invalidFile := strings.TrimSuffix(lockFile, ".lock.yml") + ".invalid.yml"
 			if writeErr := os.WriteFile(invalidFile, []byte(yamlContent), 0644); writeErr == nil {
-				fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Invalid workflow YAML written to: "+console.ToRelativePath(invalidFile)))
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(check.invalidYAMLNotice+console.ToRelativePath(invalidFile)))
 			}
-			return "", formattedErr
-		}
-
-		// Validate container images used in MCP configurations
-		log.Print("Validating container images")
-		if err := c.validateContainerImages(workflowData); err != nil {
-			// Treat container image validation failures as warnings, not errors
-			// This is because validation may fail due to auth issues locally (e.g., private registries)
-			fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", fmt.Sprintf("container image validation failed: %v", err)))
-			c.IncrementWarningCount()
-		}
-
-		// Validate runtime packages (npx, uv)
-		log.Print("Validating runtime packages")
-		if err := c.validateRuntimePackages(workflowData); err != nil {
-			return "", formatCompilerError(markdownPath, "error", fmt.Sprintf("runtime package validation failed: %v", err), err)
-		}
-
-		// Validate firewall configuration (log-level enum)
-		log.Print("Validating firewall configuration")
-		if err := c.validateFirewallConfig(workflowData); err != nil {
-			return "", formatCompilerError(markdownPath, "error", fmt.Sprintf("firewall configuration validation failed: %v", err), err)
 		}
+		return "", formattedErr
+	}
 
-		// Validate repository features (discussions, issues)
-		log.Print("Validating repository features")
-		if err := c.validateRepositoryFeatures(workflowData); err != nil {
-			return "", formatCompilerError(markdownPath, "error", fmt.Sprintf("repository feature validation failed: %v", err), err)
-		}
-	} else if c.verbose {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Schema validation available but skipped (use SetSkipValidation(false) to enable)"))
-		c.IncrementWarningCount()
+	if c.skipValidation && c.verbose {
+		c.addWarning("Schema validation available but skipped (use SetSkipValidation(false) to enable)")
 	}
 
 	return yamlContent, nil
@@ -499,18 +570,7 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 		log.Printf("Compilation completed in %v", time.Since(startTime))
 	}()
 
-	// Reset the step order tracker for this compilation
-	c.stepOrderTracker = NewStepOrderTracker()
-
-	// Reset schedule friendly formats for this compilation
-	c.scheduleFriendlyFormats = nil
-
-	// Reset the artifact manager for this compilation
-	if c.artifactManager == nil {
-		c.artifactManager = NewArtifactManager()
-	} else {
-		c.artifactManager.Reset()
-	}
+	c.resetCompilationState()
 
 	// Generate lock file name
 	lockFile := stringutil.MarkdownToLockFile(markdownPath)
@@ -520,6 +580,19 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 
 	log.Printf("Starting compilation: %s -> %s", markdownPath, lockFile)
 
+	// The experimental GitLab target maps the workflow onto a GitLab CI file instead
+	if c.target == CompileTargetGitLab {
+		return c.compileGitLabTarget(workflowData, markdownPath)
+	}
+
+	// Skip regeneration when the sources and lock file are unchanged (incremental mode)
+	incremental := c.incrementalEnabled()
+	if incremental && c.isWorkflowUpToDate(workflowData, markdownPath, lockFile) {
+		log.Printf("Workflow is up to date, skipping compilation: %s", markdownPath)
+		c.reportUpToDate(markdownPath)
+		return nil
+	}
+
 	// Validate workflow data
 	if err := c.validateWorkflowData(workflowData, markdownPath); err != nil {
 		return err
@@ -538,7 +611,47 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	}
 
 	// Write output
-	return c.writeWorkflowOutput(lockFile, yamlContent, markdownPath)
+	if err := c.writeWorkflowOutput(lockFile, yamlContent, markdownPath); err != nil {
+		return err
+	}
+
+	// Record the sidecar hash so that the next incremental compilation can skip this workflow
+	if incremental {
+		if err := c.recordIncrementalCache(workflowData, markdownPath, lockFile, yamlContent); err != nil {
+			log.Printf("Failed to record incremental cache: %v", err)
+			c.addWarning(err.Error())
+		}
+	}
+	return nil
+}
+
+// compileGitLabTarget validates a workflow and writes its GitLab CI file next to the markdown file
+func (c *Compiler) compileGitLabTarget(workflowData *WorkflowData, markdownPath string) error {
+	if err := c.validateWorkflowData(workflowData, markdownPath); err != nil {
+		return err
+	}
+
+	yamlContent, err := c.generateGitLabCI(workflowData, markdownPath)
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+	return c.writeWorkflowOutput(filepath.Clean(GitLabCIFile(markdownPath)), yamlContent, markdownPath)
+}
+
+// resetCompilationState clears the per-workflow trackers before generating a workflow
+func (c *Compiler) resetCompilationState() {
+	// Reset the step order tracker for this compilation
+	c.stepOrderTracker = NewStepOrderTracker()
+
+	// Reset schedule friendly formats for this compilation
+	c.scheduleFriendlyFormats = nil
+
+	// Reset the artifact manager for this compilation
+	if c.artifactManager == nil {
+		c.artifactManager = NewArtifactManager()
+	} else {
+		c.artifactManager.Reset()
+	}
 }
 
 // ParseWorkflowFile parses a markdown workflow file and extracts all necessary data
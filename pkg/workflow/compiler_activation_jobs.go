@@ -69,6 +69,15 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		perms.Set(PermissionActions, PermissionRead)
 	}
 
+	// Add read permissions to list the changed files of pushes and pull requests for path conditions
+	if len(data.ChangedFilesPatterns) > 0 {
+		if perms == nil {
+			perms = NewPermissions()
+		}
+		perms.Set(PermissionContents, PermissionRead)
+		perms.Set(PermissionPullRequests, PermissionRead)
+	}
+
 	// Set permissions if any were configured
 	if perms != nil {
 		permissions = perms.RenderToYAML()
@@ -107,6 +116,16 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		steps = c.generateRateLimitCheck(data, steps)
 	}
 
+	// Add required secrets check if any secrets are declared as required
+	if len(data.RequiredSecrets) > 0 {
+		steps = c.generateRequiredSecretsCheck(data, steps)
+	}
+
+	// Add changed files check if the condition has path terms
+	if len(data.ChangedFilesPatterns) > 0 {
+		steps = c.generateChangedFilesCheck(data, steps)
+	}
+
 	// Add stop-time check if configured
 	if data.StopTime != "" {
 		// Extract workflow name for the stop-time check
@@ -288,6 +307,26 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		conditions = append(conditions, rateLimitCheck)
 	}
 
+	if len(data.RequiredSecrets) > 0 {
+		// Add required secrets check condition
+		requiredSecretsCheck := BuildComparison(
+			BuildPropertyAccess(fmt.Sprintf("steps.%s.outputs.%s", constants.CheckRequiredSecretsStepID, constants.RequiredSecretsOkOutput)),
+			"==",
+			BuildStringLiteral("true"),
+		)
+		conditions = append(conditions, requiredSecretsCheck)
+	}
+
+	if len(data.ChangedFilesPatterns) > 0 {
+		// Add changed files check condition
+		changedFilesCheck := BuildComparison(
+			BuildPropertyAccess(fmt.Sprintf("steps.%s.outputs.%s", constants.CheckChangedFilesStepID, constants.ChangedFilesOkOutput)),
+			"==",
+			BuildStringLiteral("true"),
+		)
+		conditions = append(conditions, changedFilesCheck)
+	}
+
 	if len(data.Command) > 0 {
 		// Add command position check condition
 		commandPositionCheck := BuildComparison(
@@ -330,6 +369,11 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		outputs[constants.MatchedCommandOutput] = "''"
 	}
 
+	// Expose the reaction id so the reaction can be removed once the run completes
+	if data.ReactionCleanup != nil && data.AIReaction != "" && data.AIReaction != "none" {
+		outputs[constants.ReactionIDOutput] = "${{ steps.react.outputs.reaction-id }}"
+	}
+
 	// Merge custom outputs from jobs.pre-activation if present
 	if len(customOutputs) > 0 {
 		compilerActivationJobsLog.Printf("Adding %d custom outputs to pre-activation job", len(customOutputs))
@@ -347,10 +391,11 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 	job := &Job{
 		Name:        string(constants.PreActivationJobName),
 		If:          jobIfCondition,
-		RunsOn:      c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:      c.formatGeneratedJobRunsOn(data),
 		Permissions: permissions,
 		Steps:       steps,
 		Outputs:     outputs,
+		Provenance:  "on (roles, stop-after, skip-if-match and reaction), jobs.pre-activation",
 	}
 
 	return job, nil
@@ -727,12 +772,13 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 		Name:                       string(constants.ActivationJobName),
 		If:                         activationCondition,
 		HasWorkflowRunSafetyChecks: workflowRunRepoSafety != "", // Mark job as having workflow_run safety checks
-		RunsOn:                     c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:                     c.formatGeneratedJobRunsOn(data),
 		Permissions:                permissions,
 		Environment:                environment,
 		Steps:                      steps,
 		Outputs:                    outputs,
 		Needs:                      activationNeeds, // Depend on pre-activation job if it exists
+		Provenance:                 "on (trigger handling and prompt preparation)",
 	}
 
 	return job, nil
@@ -917,6 +963,22 @@ func (c *Compiler) buildMainJob(data *WorkflowData, activationJobCreated bool) (
 		// DEFAULT_BRANCH is used by safeoutputs MCP server
 		// Use repository default branch from GitHub context
 		env["DEFAULT_BRANCH"] = "${{ github.event.repository.default_branch }}"
+
+		// GH_AW_MAX_PATCH_BYTES aborts patch generation for oversized create_pull_request patches;
+		// push_to_pull_request_branch patches are not limited by it
+		if maxPatchBytes := getMaxPatchBytesEnv(data.SafeOutputs); maxPatchBytes > 0 {
+			env["GH_AW_MAX_PATCH_BYTES"] = strconv.Itoa(maxPatchBytes)
+		}
+
+		// GH_AW_PATCH_BASE forces the base commit of create_pull_request patches only
+		if patchBase := getPatchBaseEnv(data.SafeOutputs); patchBase != "" {
+			env["GH_AW_PATCH_BASE"] = fmt.Sprintf("%q", patchBase)
+		}
+
+		// GH_AW_PATCH_EXCLUDE_PATHS leaves matching paths out of create_pull_request patches only
+		if excludePaths := getPatchExcludePathsEnv(data.SafeOutputs); excludePaths != "" {
+			env["GH_AW_PATCH_EXCLUDE_PATHS"] = fmt.Sprintf("%q", excludePaths)
+		}
 	}
 
 	// Set GH_AW_WORKFLOW_ID_SANITIZED for cache-memory keys
@@ -959,12 +1021,14 @@ func (c *Compiler) buildMainJob(data *WorkflowData, activationJobCreated bool) (
 		Environment: c.indentYAMLLines(data.Environment, "    "),
 		Container:   c.indentYAMLLines(data.Container, "    "),
 		Services:    c.indentYAMLLines(data.Services, "    "),
+		Strategy:    c.indentYAMLLines(data.Strategy, "    "),
 		Permissions: c.indentYAMLLines(permissions, "    "),
 		Concurrency: c.indentYAMLLines(agentConcurrency, "    "),
 		Env:         env,
 		Steps:       steps,
 		Needs:       depends,
 		Outputs:     outputs,
+		Provenance:  "engine, tools and the workflow markdown",
 	}
 
 	return job, nil
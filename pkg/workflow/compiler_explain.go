@@ -0,0 +1,52 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var compilerExplainLog = logger.New("workflow:compiler_explain")
+
+// This file contains the helpers for explain mode (gh aw compile --explain).
+//
+// In explain mode the generated lock file is annotated with "# Source: ..." comments that
+// name the frontmatter field or feature that produced each job and each major section of
+// the agent job. Job provenance is attached by the job builders through Job.Provenance and
+// rendered by the JobManager; step sections are annotated with writeExplainComment.
+
+// writeExplainComment writes a provenance comment for the steps that follow it in the agent job.
+// It does nothing unless explain mode is enabled.
+func (c *Compiler) writeExplainComment(yaml *strings.Builder, source string) {
+	if !c.explain {
+		return
+	}
+	compilerExplainLog.Printf("Annotating steps with source: %s", source)
+	fmt.Fprintf(yaml, "      # Source: %s\n", source)
+}
+
+// safeOutputsJobProvenance lists the safe-outputs keys handled by the consolidated safe_outputs job.
+// Custom safe-output jobs are excluded since they are rendered as separate jobs.
+func safeOutputsJobProvenance(safeOutputs *SafeOutputsConfig) string {
+	var sources []string
+	for _, toolName := range GetEnabledSafeOutputToolNames(safeOutputs) {
+		if _, isCustomJob := safeOutputs.Jobs[toolName]; isCustomJob {
+			continue
+		}
+		sources = append(sources, "safe-outputs."+strings.ReplaceAll(toolName, "_", "-"))
+	}
+	if len(sources) == 0 {
+		return "safe-outputs"
+	}
+	return strings.Join(sources, ", ")
+}
+
+// engineExecutionProvenance describes the frontmatter that shapes the agent execution step
+func engineExecutionProvenance(data *WorkflowData, engine CodingAgentEngine) string {
+	source := "engine: " + engine.GetID()
+	if isFirewallEnabled(data) {
+		source += ", network.allowed (firewall)"
+	}
+	return source
+}
@@ -0,0 +1,73 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobManagerRenderProvenance(t *testing.T) {
+	jm := NewJobManager()
+	require.NoError(t, jm.AddJob(&Job{
+		Name:       "notify",
+		RunsOn:     "runs-on: ubuntu-latest",
+		Steps:      []string{"      - run: echo hi\n"},
+		Provenance: "jobs.notify",
+	}))
+
+	assert.NotContains(t, jm.RenderToYAML(), "# Source:", "provenance should only be rendered in explain mode")
+
+	jm.SetExplain(true)
+	assert.Contains(t, jm.RenderToYAML(), "  # Source: jobs.notify\n  notify:\n", "provenance should be rendered above the job")
+}
+
+func TestCompileExplainCreateIssue(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-explain-test")
+	testFile := filepath.Join(tmpDir, "explain.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+---
+
+# Triage
+
+Open a follow-up issue.
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+	assert.NotContains(t, string(lockContent), "# Source:", "lock files should not be annotated by default")
+
+	compiler = NewCompiler()
+	compiler.SetExplain(true)
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+	lockContent, err = os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+	lockStr := string(lockContent)
+
+	safeOutputsIdx := strings.Index(lockStr, "\n  safe_outputs:\n")
+	require.Greater(t, safeOutputsIdx, -1, "lock file should contain the safe_outputs job")
+	lines := strings.Split(lockStr[:safeOutputsIdx], "\n")
+	commentLine := lines[len(lines)-1]
+	assert.True(t, strings.HasPrefix(commentLine, "  # Source: "), "the safe_outputs job should be preceded by its provenance, got %q", commentLine)
+	assert.Contains(t, commentLine, "safe-outputs.create-issue")
+
+	assert.Contains(t, lockStr, "  # Source: safe-outputs.threat-detection\n  detection:\n")
+	assert.Contains(t, lockStr, "      # Source: engine: copilot, network.allowed (firewall)\n", "the agent execution step should name the firewall configuration")
+	assert.Contains(t, lockStr, "      # Source: tools, mcp-servers\n")
+}
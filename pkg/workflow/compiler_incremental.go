@@ -0,0 +1,302 @@
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var incrementalLog = logger.New("workflow:compiler_incremental")
+
+// Incremental compilation
+//
+// When incremental mode is enabled, the compiler records a sidecar file for each lock
+// file holding a hash of everything that feeds into the generated YAML: the compiler
+// version and output-affecting options, the workflow markdown, every imported and
+// included file, and the action pin cache. A later compilation skips YAML generation
+// when the recomputed hash matches the sidecar and the existing lock file still has the
+// recorded content. Sidecars are kept in the user cache directory rather than next to
+// the lock files, so they never show up in the repository.
+
+// incrementalCacheDirName is the directory under the gh-aw user cache that holds sidecars
+const incrementalCacheDirName = "incremental"
+
+// incrementalCacheEntry is the JSON content of an incremental compilation sidecar.
+// Dependencies lists the imported and included files hashed into SourceHash, so that the
+// hash can be recomputed without parsing the workflow. It is nil in sidecars recorded
+// before it was added.
+type incrementalCacheEntry struct {
+	Version      string                       `json:"version"`
+	SourceHash   string                       `json:"source-hash"`
+	LockHash     string                       `json:"lock-hash"`
+	Dependencies []incrementalCacheDependency `json:"dependencies"`
+}
+
+// incrementalCacheDependency is an imported or included file recorded in a sidecar
+type incrementalCacheDependency struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+var (
+	// getIncrementalCacheDirFunc allows overriding in tests
+	getIncrementalCacheDirFunc = getIncrementalCacheDirImpl
+)
+
+// getIncrementalCacheDirImpl returns the directory holding incremental compilation sidecars,
+// falling back to the OS temp directory when no user cache directory is available
+func getIncrementalCacheDirImpl() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil || cacheDir == "" {
+		incrementalLog.Printf("No user cache directory, using temp directory: %v", err)
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, "gh-aw", incrementalCacheDirName)
+}
+
+// IncrementalCachePath returns the incremental compilation sidecar path for a lock file.
+// Sidecars are keyed by the absolute lock file path so that workflows with the same name
+// in different repositories or checkouts do not share an entry.
+func IncrementalCachePath(lockFile string) string {
+	absLockFile, err := filepath.Abs(lockFile)
+	if err != nil {
+		absLockFile = lockFile
+	}
+	key := hashBytes([]byte(filepath.ToSlash(absLockFile)))[:16]
+	return filepath.Join(getIncrementalCacheDirFunc(), filepath.Base(lockFile)+"-"+key+".json")
+}
+
+// incrementalEnabled reports whether the up-to-date check applies to this compilation.
+// Modes that intentionally change the output of an unchanged workflow always regenerate.
+func (c *Compiler) incrementalEnabled() bool {
+	return c.incremental && !c.noEmit && !c.explain && !c.refreshStopTime && !c.forceRefreshActionPins && c.contentOverride == ""
+}
+
+// isWorkflowUpToDate reports whether the lock file can be reused because neither the
+// workflow sources nor the lock file changed since the sidecar was recorded
+func (c *Compiler) isWorkflowUpToDate(workflowData *WorkflowData, markdownPath, lockFile string) bool {
+	entry, err := readIncrementalCache(IncrementalCachePath(lockFile))
+	if err != nil {
+		incrementalLog.Printf("No usable incremental cache for %s: %v", lockFile, err)
+		return false
+	}
+
+	lockContent, err := os.ReadFile(lockFile)
+	if err != nil {
+		incrementalLog.Printf("Lock file unreadable, recompiling: %v", err)
+		return false
+	}
+	if hashBytes(lockContent) != entry.LockHash {
+		incrementalLog.Printf("Lock file changed since last compilation: %s", lockFile)
+		return false
+	}
+
+	sourceHash, err := c.computeIncrementalSourceHash(workflowData, markdownPath)
+	if err != nil {
+		incrementalLog.Printf("Failed to hash workflow sources, recompiling: %v", err)
+		return false
+	}
+	if sourceHash != entry.SourceHash {
+		incrementalLog.Printf("Workflow sources changed since last compilation: %s", markdownPath)
+		return false
+	}
+
+	return true
+}
+
+// IsLockFileUpToDate reports whether the lock file of a workflow was generated from its
+// current sources, using the incremental compilation sidecar. The workflow is not parsed:
+// the hash is recomputed from the dependencies recorded in the sidecar, which is enough
+// because adding or removing an import changes the hashed workflow or shared file. ok is
+// false when the lock file has no usable sidecar, so staleness cannot be determined from hashes.
+func (c *Compiler) IsLockFileUpToDate(markdownPath string) (upToDate bool, ok bool) {
+	lockFile := filepath.Clean(stringutil.MarkdownToLockFile(markdownPath))
+	entry, err := readIncrementalCache(IncrementalCachePath(lockFile))
+	if err != nil || entry.Dependencies == nil {
+		return false, false
+	}
+
+	lockContent, err := os.ReadFile(lockFile)
+	if err != nil || hashBytes(lockContent) != entry.LockHash {
+		return false, true
+	}
+
+	dependencies := make([]incrementalDependency, 0, len(entry.Dependencies))
+	for _, dependency := range entry.Dependencies {
+		dependencies = append(dependencies, incrementalDependency{name: dependency.Name, path: dependency.Path})
+	}
+	sourceHash, err := c.hashIncrementalSources(markdownPath, dependencies)
+	if err != nil {
+		incrementalLog.Printf("Failed to hash workflow sources of %s: %v", markdownPath, err)
+		return false, true
+	}
+	return sourceHash == entry.SourceHash, true
+}
+
+// recordIncrementalCache writes the sidecar for a freshly generated lock file
+func (c *Compiler) recordIncrementalCache(workflowData *WorkflowData, markdownPath, lockFile, yamlContent string) error {
+	dependencies := c.collectIncrementalDependencies(workflowData, markdownPath)
+	sourceHash, err := c.hashIncrementalSources(markdownPath, dependencies)
+	if err != nil {
+		return err
+	}
+
+	entry := incrementalCacheEntry{
+		Version:      c.version,
+		SourceHash:   sourceHash,
+		LockHash:     hashBytes([]byte(yamlContent)),
+		Dependencies: make([]incrementalCacheDependency, 0, len(dependencies)),
+	}
+	for _, dependency := range dependencies {
+		entry.Dependencies = append(entry.Dependencies, incrementalCacheDependency{Name: dependency.name, Path: dependency.path})
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode incremental cache: %w", err)
+	}
+
+	cachePath := IncrementalCachePath(lockFile)
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return fmt.Errorf("failed to create incremental cache directory: %w", err)
+	}
+	if err := os.WriteFile(cachePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write incremental cache %s: %w", cachePath, err)
+	}
+	incrementalLog.Printf("Recorded incremental cache: %s", cachePath)
+	return nil
+}
+
+// readIncrementalCache loads a sidecar file
+func readIncrementalCache(cachePath string) (*incrementalCacheEntry, error) {
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, err
+	}
+	var entry incrementalCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("invalid incremental cache %s: %w", cachePath, err)
+	}
+	if entry.SourceHash == "" || entry.LockHash == "" {
+		return nil, errors.New("incremental cache is missing hashes")
+	}
+	return &entry, nil
+}
+
+// computeIncrementalSourceHash hashes every input that affects the generated lock file
+func (c *Compiler) computeIncrementalSourceHash(workflowData *WorkflowData, markdownPath string) (string, error) {
+	return c.hashIncrementalSources(markdownPath, c.collectIncrementalDependencies(workflowData, markdownPath))
+}
+
+// hashIncrementalSources hashes the compiler options, the workflow, the given dependencies
+// and the action pin cache
+func (c *Compiler) hashIncrementalSources(markdownPath string, dependencies []incrementalDependency) (string, error) {
+	h := sha256.New()
+
+	// Compiler version and options that change the generated YAML
+	writeHashField(h, "version", []byte(c.version))
+	writeHashField(h, "action-mode", []byte(c.actionMode))
+	writeHashField(h, "action-tag", []byte(c.actionTag))
+	writeHashField(h, "engine-override", []byte(c.engineOverride))
+	writeHashField(h, "strict", fmt.Appendf(nil, "%t", c.strictMode))
+	writeHashField(h, "trial", fmt.Appendf(nil, "%t:%s", c.trialMode, c.trialLogicalRepoSlug))
+	writeHashField(h, "inline-prompt", fmt.Appendf(nil, "%t", c.inlinePrompt))
+	writeHashField(h, "skip-header", fmt.Appendf(nil, "%t", c.skipHeader))
+
+	markdownContent, err := os.ReadFile(markdownPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read workflow %s: %w", markdownPath, err)
+	}
+	writeHashField(h, "workflow", markdownContent)
+
+	// Imported and included files, so that edits to shared fragments trigger recompilation
+	for _, dependency := range dependencies {
+		content, err := os.ReadFile(dependency.path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read dependency %s: %w", dependency.name, err)
+		}
+		writeHashField(h, "dependency:"+dependency.name, content)
+	}
+
+	// Action pins are resolved from the repository cache, so pin updates change the output
+	actionCache, _ := c.getSharedActionResolver()
+	if pinContent, err := os.ReadFile(actionCache.GetCachePath()); err == nil {
+		writeHashField(h, "action-pins", pinContent)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// incrementalDependency is a resolved imported or included file
+type incrementalDependency struct {
+	name string // Path as recorded in the workflow data
+	path string // Resolved filesystem path
+}
+
+// collectIncrementalDependencies resolves imported and included files to filesystem paths
+func (c *Compiler) collectIncrementalDependencies(workflowData *WorkflowData, markdownPath string) []incrementalDependency {
+	markdownDir := filepath.Dir(markdownPath)
+	seen := make(map[string]bool)
+	var dependencies []incrementalDependency
+
+	add := func(name, path string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		dependencies = append(dependencies, incrementalDependency{name: name, path: path})
+	}
+
+	for _, importedFile := range workflowData.ImportedFiles {
+		// Strip section references (e.g., "shared/foo.md#Section")
+		importPath, _, _ := strings.Cut(importedFile, "#")
+		fullPath, err := parser.ResolveIncludePath(importPath, markdownDir, c.getSharedImportCache())
+		if err != nil {
+			// Unresolvable imports fail the read below, which forces a recompile
+			fullPath = filepath.Join(markdownDir, importPath)
+		}
+		add(importPath, fullPath)
+	}
+
+	for _, includedFile := range workflowData.IncludedFiles {
+		includePath := filepath.FromSlash(includedFile)
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(markdownDir, includePath)
+		}
+		add(includedFile, includePath)
+	}
+
+	sort.Slice(dependencies, func(i, j int) bool { return dependencies[i].name < dependencies[j].name })
+	return dependencies
+}
+
+// writeHashField writes a length-prefixed field so that adjacent fields cannot collide
+func writeHashField(h hash.Hash, name string, content []byte) {
+	fmt.Fprintf(h, "%s\x00%d\x00", name, len(content))
+	h.Write(content)
+}
+
+// hashBytes returns the hex-encoded SHA-256 of content
+func hashBytes(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// reportUpToDate prints the skip message for an up-to-date workflow
+func (c *Compiler) reportUpToDate(markdownPath string) {
+	c.skippedCount++
+	if !c.quiet {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(console.ToRelativePath(markdownPath)+" is up to date"))
+	}
+}
@@ -0,0 +1,124 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const incrementalTestWorkflow = `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+imports:
+  - shared/common.md
+---
+
+# Incremental
+
+Summarize the repository.
+`
+
+const incrementalTestShared = `---
+tools:
+  bash: ["echo"]
+---
+
+Shared instructions.
+`
+
+func setupIncrementalWorkflow(t *testing.T) (workflowPath, sharedPath string) {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "incremental-test")
+
+	cacheDir := t.TempDir()
+	original := getIncrementalCacheDirFunc
+	getIncrementalCacheDirFunc = func() string { return cacheDir }
+	t.Cleanup(func() { getIncrementalCacheDirFunc = original })
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "shared"), 0755), "Should create shared directory")
+
+	workflowPath = filepath.Join(tmpDir, "incremental.md")
+	sharedPath = filepath.Join(tmpDir, "shared", "common.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(incrementalTestWorkflow), 0644), "Should write workflow")
+	require.NoError(t, os.WriteFile(sharedPath, []byte(incrementalTestShared), 0644), "Should write shared import")
+	return workflowPath, sharedPath
+}
+
+func TestIncrementalCompileSkipsUnchangedWorkflow(t *testing.T) {
+	workflowPath, _ := setupIncrementalWorkflow(t)
+	lockFile := stringutil.MarkdownToLockFile(workflowPath)
+
+	first := NewCompiler(WithIncremental(true))
+	require.NoError(t, first.CompileWorkflow(workflowPath), "First compile should succeed")
+	assert.Equal(t, 0, first.GetSkippedCount(), "First compile should not be skipped")
+	assert.FileExists(t, IncrementalCachePath(lockFile), "Sidecar should be recorded")
+	assert.NoFileExists(t, lockFile+".hash", "Sidecar should not be written next to the lock file")
+
+	second := NewCompiler(WithIncremental(true))
+	require.NoError(t, second.CompileWorkflow(workflowPath), "Second compile should succeed")
+	assert.Equal(t, 1, second.GetSkippedCount(), "Second compile should be a no-op")
+}
+
+func TestIncrementalCompileRecompilesWhenImportChanges(t *testing.T) {
+	workflowPath, sharedPath := setupIncrementalWorkflow(t)
+	lockFile := stringutil.MarkdownToLockFile(workflowPath)
+
+	require.NoError(t, NewCompiler(WithIncremental(true)).CompileWorkflow(workflowPath), "First compile should succeed")
+	before, err := readIncrementalCache(IncrementalCachePath(lockFile))
+	require.NoError(t, err, "Should read sidecar")
+
+	updatedShared := `---
+tools:
+  bash: ["echo", "ls"]
+---
+
+Shared instructions.
+`
+	require.NoError(t, os.WriteFile(sharedPath, []byte(updatedShared), 0644), "Should update shared import")
+
+	compiler := NewCompiler(WithIncremental(true))
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "Recompile should succeed")
+	assert.Equal(t, 0, compiler.GetSkippedCount(), "Changing an import should force a recompile")
+
+	after, err := readIncrementalCache(IncrementalCachePath(lockFile))
+	require.NoError(t, err, "Should read sidecar")
+	assert.NotEqual(t, before.SourceHash, after.SourceHash, "Source hash should include imported files")
+}
+
+func TestIncrementalCompileRecompilesWhenLockFileEdited(t *testing.T) {
+	workflowPath, _ := setupIncrementalWorkflow(t)
+	lockFile := stringutil.MarkdownToLockFile(workflowPath)
+
+	require.NoError(t, NewCompiler(WithIncremental(true)).CompileWorkflow(workflowPath), "First compile should succeed")
+	original, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "Should read lock file")
+
+	require.NoError(t, os.WriteFile(lockFile, []byte("# edited\n"), 0644), "Should edit lock file")
+
+	compiler := NewCompiler(WithIncremental(true))
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "Recompile should succeed")
+	assert.Equal(t, 0, compiler.GetSkippedCount(), "An edited lock file should be regenerated")
+
+	restored, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "Should read lock file")
+	assert.Equal(t, string(original), string(restored), "Lock file should be regenerated")
+}
+
+func TestIncrementalCompileDisabledByDefault(t *testing.T) {
+	workflowPath, _ := setupIncrementalWorkflow(t)
+	lockFile := stringutil.MarkdownToLockFile(workflowPath)
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "Compile should succeed")
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "Compile should succeed")
+	assert.Equal(t, 0, compiler.GetSkippedCount(), "Workflows should not be skipped without incremental mode")
+	assert.NoFileExists(t, IncrementalCachePath(lockFile), "No sidecar should be written without incremental mode")
+}
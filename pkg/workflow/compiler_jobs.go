@@ -211,6 +211,17 @@ func (c *Compiler) buildJobs(data *WorkflowData, markdownPath string) error {
 		return fmt.Errorf("failed to build safe outputs jobs: %w", err)
 	}
 
+	// Build the job that removes or replaces the activation reaction once the run completes
+	reactionJob, err := c.buildReactionCleanupJob(data)
+	if err != nil {
+		return fmt.Errorf("failed to build reaction cleanup job: %w", err)
+	}
+	if reactionJob != nil {
+		if err := c.jobManager.AddJob(reactionJob); err != nil {
+			return fmt.Errorf("failed to add reaction cleanup job: %w", err)
+		}
+	}
+
 	// Build additional custom jobs from frontmatter jobs section
 	if len(data.Jobs) > 0 {
 		compilerJobsLog.Printf("Building %d custom jobs from frontmatter", len(data.Jobs))
@@ -224,6 +235,9 @@ func (c *Compiler) buildJobs(data *WorkflowData, markdownPath string) error {
 		return err
 	}
 
+	// Run jobs that process the agent job's artifacts once per matrix leg
+	c.applyMatrixStrategyToJobs(data)
+
 	compilerJobsLog.Print("Successfully built all jobs for workflow")
 	return nil
 }
@@ -240,10 +254,12 @@ func (c *Compiler) buildPreActivationAndActivationJobs(data *WorkflowData, front
 	hasSkipBots := len(data.SkipBots) > 0
 	hasCommandTrigger := len(data.Command) > 0
 	hasRateLimit := data.RateLimit != nil
-	compilerJobsLog.Printf("Job configuration: needsPermissionCheck=%v, hasStopTime=%v, hasSkipIfMatch=%v, hasSkipIfNoMatch=%v, hasSkipRoles=%v, hasSkipBots=%v, hasCommand=%v, hasRateLimit=%v", needsPermissionCheck, hasStopTime, hasSkipIfMatch, hasSkipIfNoMatch, hasSkipRoles, hasSkipBots, hasCommandTrigger, hasRateLimit)
+	hasRequiredSecrets := len(data.RequiredSecrets) > 0
+	hasChangedFilesCheck := len(data.ChangedFilesPatterns) > 0
+	compilerJobsLog.Printf("Job configuration: needsPermissionCheck=%v, hasStopTime=%v, hasSkipIfMatch=%v, hasSkipIfNoMatch=%v, hasSkipRoles=%v, hasSkipBots=%v, hasCommand=%v, hasRateLimit=%v, hasRequiredSecrets=%v, hasChangedFilesCheck=%v", needsPermissionCheck, hasStopTime, hasSkipIfMatch, hasSkipIfNoMatch, hasSkipRoles, hasSkipBots, hasCommandTrigger, hasRateLimit, hasRequiredSecrets, hasChangedFilesCheck)
 
-	// Build pre-activation job if needed (combines membership checks, stop-time validation, skip-if-match check, skip-if-no-match check, skip-roles check, skip-bots check, rate limit check, and command position check)
-	if needsPermissionCheck || hasStopTime || hasSkipIfMatch || hasSkipIfNoMatch || hasSkipRoles || hasSkipBots || hasCommandTrigger || hasRateLimit {
+	// Build pre-activation job if needed (combines membership checks, stop-time validation, skip-if-match check, skip-if-no-match check, skip-roles check, skip-bots check, rate limit check, required secrets check, changed files check, and command position check)
+	if needsPermissionCheck || hasStopTime || hasSkipIfMatch || hasSkipIfNoMatch || hasSkipRoles || hasSkipBots || hasCommandTrigger || hasRateLimit || hasRequiredSecrets || hasChangedFilesCheck {
 		compilerJobsLog.Print("Building pre-activation job")
 		preActivationJob, err := c.buildPreActivationJob(data, needsPermissionCheck)
 		if err != nil {
@@ -429,7 +445,8 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 
 		if configMap, ok := jobConfig.(map[string]any); ok {
 			job := &Job{
-				Name: jobName,
+				Name:       jobName,
+				Provenance: "jobs." + jobName,
 			}
 
 			// Extract job dependencies
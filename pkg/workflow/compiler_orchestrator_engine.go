@@ -3,10 +3,10 @@ package workflow
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"strings"
 
-	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 )
@@ -21,6 +21,7 @@ type engineSetupResult struct {
 	networkPermissions *NetworkPermissions
 	sandboxConfig      *SandboxConfig
 	importsResult      *parser.ImportsResult
+	includedFiles      []string // Files read while setting up the engine, relative to the markdown directory
 }
 
 // setupEngineAndImports configures the AI engine, processes imports, and validates network/sandbox settings.
@@ -39,6 +40,16 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 	// Extract network permissions from frontmatter
 	networkPermissions := c.extractNetworkPermissions(result.Frontmatter)
 
+	// Merge the shared allowlist file referenced by network.allowed-from
+	var includedFiles []string
+	allowlistFile, err := mergeNetworkAllowedFrom(networkPermissions, markdownDir)
+	if err != nil {
+		return nil, err
+	}
+	if allowlistFile != "" {
+		includedFiles = append(includedFiles, allowlistFile)
+	}
+
 	// Default to 'defaults' ecosystem if no network permissions specified
 	if networkPermissions == nil {
 		networkPermissions = &NetworkPermissions{
@@ -68,15 +79,33 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 		}
 	}
 
+	// Strict mode checks shared MCP server definitions ($ref) as they will be inlined
+	strictFrontmatter := result.Frontmatter
+	if len(parser.ExtractMCPServerRefs(result.Frontmatter)) > 0 {
+		strictFrontmatter = maps.Clone(result.Frontmatter)
+		if err := parser.ResolveFrontmatterMCPServerRefs(strictFrontmatter, markdownDir); err != nil {
+			c.strictMode = initialStrictMode
+			return nil, err
+		}
+	}
+
 	// Perform strict mode validations
 	orchestratorEngineLog.Printf("Performing strict mode validation (strict=%v)", c.strictMode)
-	if err := c.validateStrictMode(result.Frontmatter, networkPermissions); err != nil {
+	if err := c.validateStrictMode(strictFrontmatter, networkPermissions); err != nil {
 		orchestratorEngineLog.Printf("Strict mode validation failed: %v", err)
 		// Restore strict mode before returning error
 		c.strictMode = initialStrictMode
 		return nil, err
 	}
 
+	// Validate cache-memory configuration regardless of strict mode
+	if err := validateCacheMemory(result.Frontmatter); err != nil {
+		orchestratorEngineLog.Printf("Cache-memory validation failed: %v", err)
+		// Restore strict mode before returning error
+		c.strictMode = initialStrictMode
+		return nil, err
+	}
+
 	// Validate env secrets regardless of strict mode (error in strict, warning in non-strict)
 	if err := c.validateEnvSecrets(result.Frontmatter); err != nil {
 		orchestratorEngineLog.Printf("Env secrets validation failed: %v", err)
@@ -93,10 +122,14 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 	if c.engineOverride != "" {
 		originalEngineSetting := engineSetting
 		if originalEngineSetting != "" && originalEngineSetting != c.engineOverride {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Command line --engine %s overrides markdown file engine: %s", c.engineOverride, originalEngineSetting)))
-			c.IncrementWarningCount()
+			c.addWarning(fmt.Sprintf("Command line --engine %s overrides markdown file engine: %s", c.engineOverride, originalEngineSetting))
 		}
 		engineSetting = c.engineOverride
+		// An explicit --engine selects a single engine, so the frontmatter fallback chain no longer applies
+		if engineConfig != nil && len(engineConfig.Fallbacks) > 0 {
+			orchestratorEngineLog.Printf("Ignoring engine fallbacks due to --engine override")
+			engineConfig.Fallbacks = nil
+		}
 	}
 
 	// Process imports from frontmatter first (before @include directives)
@@ -218,6 +251,18 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 		return nil, err
 	}
 
+	// Validate the engine fallback chain (engine: [primary, fallback...])
+	if err := c.validateEngineFallbacks(engineConfig); err != nil {
+		orchestratorEngineLog.Printf("Engine fallback validation failed: %v", err)
+		return nil, err
+	}
+
+	// Validate the variables set on the agent execution step (engine.env)
+	if err := c.validateEngineEnv(engineConfig); err != nil {
+		orchestratorEngineLog.Printf("Engine env validation failed: %v", err)
+		return nil, err
+	}
+
 	// Get the agentic engine instance
 	agenticEngine, err := c.getAgenticEngine(engineSetting)
 	if err != nil {
@@ -227,8 +272,7 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 
 	log.Printf("AI engine: %s (%s)", agenticEngine.GetDisplayName(), engineSetting)
 	if agenticEngine.IsExperimental() && c.verbose {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental engine: "+agenticEngine.GetDisplayName()))
-		c.IncrementWarningCount()
+		c.addWarning("Using experimental engine: " + agenticEngine.GetDisplayName())
 	}
 
 	// Enable firewall by default for copilot engine when network restrictions are present
@@ -296,6 +340,7 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 		engineConfig:       engineConfig,
 		agenticEngine:      agenticEngine,
 		networkPermissions: networkPermissions,
+		includedFiles:      includedFiles,
 		sandboxConfig:      sandboxConfig,
 		importsResult:      importsResult,
 	}, nil
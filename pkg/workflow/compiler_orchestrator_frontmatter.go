@@ -22,6 +22,27 @@ type frontmatterParseResult struct {
 	isSharedWorkflow         bool
 }
 
+// ParseFrontmatterOnly reads a workflow file and returns its decoded frontmatter and markdown body
+// without compiling it. Imports, tools, MCP servers, and safe-outputs are not resolved and the
+// frontmatter is not validated against the schema, so this also works for shared workflows and
+// for workflows that do not compile. Files without frontmatter return an empty map and the whole
+// content as the markdown body.
+func ParseFrontmatterOnly(path string) (map[string]any, string, error) {
+	orchestratorFrontmatterLog.Printf("Parsing frontmatter only: %s", path)
+
+	cleanPath := filepath.Clean(path)
+	content, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	result, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", cleanPath, err)
+	}
+	return result.Frontmatter, result.Markdown, nil
+}
+
 // parseFrontmatterSection reads the workflow file and parses its frontmatter.
 // It returns a frontmatterParseResult containing the parsed data and validation information.
 // If the workflow is detected as a shared workflow (no 'on' field), isSharedWorkflow is set to true.
@@ -43,6 +64,12 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 
 	log.Printf("File size: %d bytes", len(content))
 
+	return c.parseFrontmatterContent(cleanPath, content)
+}
+
+// parseFrontmatterContent parses and validates the frontmatter of workflow content that has already
+// been read. cleanPath is used for error messages and as the base for resolving imports.
+func (c *Compiler) parseFrontmatterContent(cleanPath string, content []byte) (*frontmatterParseResult, error) {
 	// Parse frontmatter and markdown
 	orchestratorFrontmatterLog.Printf("Parsing frontmatter from file: %s", cleanPath)
 	result, err := parser.ExtractFrontmatterFromContent(string(content))
@@ -67,6 +94,11 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 		return nil, err
 	}
 
+	// Compile the typed inputs section into workflow_dispatch inputs
+	if err := c.preprocessWorkflowInputs(result.Frontmatter); err != nil {
+		return nil, formatCompilerError(cleanPath, "error", err.Error(), err)
+	}
+
 	// Create a copy of frontmatter without internal markers for schema validation
 	// Keep the original frontmatter with markers for YAML generation
 	frontmatterForValidation := c.copyFrontmatterWithoutInternalMarkers(result.Frontmatter)
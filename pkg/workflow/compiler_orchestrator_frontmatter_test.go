@@ -445,3 +445,82 @@ engine: copilot
 
 	assert.Equal(t, subDir, result.markdownDir, "Should extract correct directory")
 }
+
+// TestParseFrontmatterOnly tests decoding frontmatter with nested maps and arrays without compiling
+func TestParseFrontmatterOnly(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "frontmatter-only")
+
+	testContent := `---
+on:
+  issues:
+    types: [opened, labeled]
+  workflow_dispatch:
+engine:
+  id: copilot
+  model: gpt-5
+tools:
+  github:
+    toolsets: [default, actions]
+  bash:
+    - "make test"
+    - "go vet ./..."
+safe-outputs:
+  create-issue:
+    labels: [triage]
+    max: 2
+unknown-field: still decoded
+---
+
+# Nested Workflow
+
+Body text
+`
+	testFile := filepath.Join(tmpDir, "nested.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	frontmatter, markdown, err := ParseFrontmatterOnly(testFile)
+	require.NoError(t, err)
+
+	on, ok := frontmatter["on"].(map[string]any)
+	require.True(t, ok, "on should decode to a map")
+	issues, ok := on["issues"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, []any{"opened", "labeled"}, issues["types"])
+	assert.Contains(t, on, "workflow_dispatch")
+	assert.Nil(t, on["workflow_dispatch"])
+
+	tools, ok := frontmatter["tools"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, []any{"make test", "go vet ./..."}, tools["bash"])
+	assert.Equal(t, map[string]any{"toolsets": []any{"default", "actions"}}, tools["github"])
+
+	safeOutputs, ok := frontmatter["safe-outputs"].(map[string]any)
+	require.True(t, ok)
+	createIssue, ok := safeOutputs["create-issue"].(map[string]any)
+	require.True(t, ok)
+	assert.EqualValues(t, 2, createIssue["max"])
+
+	assert.Equal(t, "still decoded", frontmatter["unknown-field"], "frontmatter should not be validated against the schema")
+	assert.Equal(t, "# Nested Workflow\n\nBody text", markdown)
+}
+
+// TestParseFrontmatterOnly_NoFrontmatter tests files without frontmatter and invalid YAML
+func TestParseFrontmatterOnly_NoFrontmatter(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "frontmatter-only-none")
+
+	plainFile := filepath.Join(tmpDir, "plain.md")
+	require.NoError(t, os.WriteFile(plainFile, []byte("# Just markdown\n"), 0644))
+	frontmatter, markdown, err := ParseFrontmatterOnly(plainFile)
+	require.NoError(t, err)
+	assert.Empty(t, frontmatter)
+	assert.Equal(t, "# Just markdown\n", markdown)
+
+	invalidFile := filepath.Join(tmpDir, "invalid.md")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("---\non: [unclosed\n---\n"), 0644))
+	_, _, err = ParseFrontmatterOnly(invalidFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid.md")
+
+	_, _, err = ParseFrontmatterOnly(filepath.Join(tmpDir, "missing.md"))
+	require.Error(t, err)
+}
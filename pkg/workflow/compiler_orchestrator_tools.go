@@ -1,12 +1,12 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
-	"os"
 	"sort"
 	"strings"
+	"time"
 
-	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/goccy/go-yaml"
@@ -33,7 +33,8 @@ type toolsProcessingResult struct {
 	safeOutputs           *SafeOutputsConfig
 	secretMasking         *SecretMaskingConfig
 	parsedFrontmatter     *FrontmatterConfig
-	hasExplicitGitHubTool bool // true if tools.github was explicitly configured in frontmatter
+	hasExplicitGitHubTool bool          // true if tools.github was explicitly configured in frontmatter
+	markdownDuration      time.Duration // time spent processing the markdown, reported as its own compilation phase
 }
 
 // processToolsAndMarkdown processes tools configuration, runtimes, and markdown content.
@@ -75,6 +76,13 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 	// Extract mcp-servers from the main file and merge them into tools
 	mcpServers := extractMCPServersFromFrontmatter(result.Frontmatter)
 
+	// Inline shared MCP server definitions referenced with $ref
+	mcpServers, mcpServerRefFiles, err := parser.ResolveMCPServerRefs(mcpServers, markdownDir)
+	if err != nil {
+		orchestratorToolsLog.Printf("Failed to resolve shared MCP server definitions: %v", err)
+		return nil, err
+	}
+
 	// Process @include directives to extract additional tools
 	orchestratorToolsLog.Printf("Expanding includes for tools")
 	includedTools, includedToolFiles, err := parser.ExpandIncludesWithManifest(result.Markdown, markdownDir, true)
@@ -217,11 +225,9 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 
 	if !agenticEngine.SupportsToolsAllowlist() {
 		// For engines that don't support tool allowlists (like custom engine), ignore tools section and provide warnings
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Using experimental %s support (engine: %s)", agenticEngine.GetDisplayName(), agenticEngine.GetID())))
-		c.IncrementWarningCount()
+		c.addWarning(fmt.Sprintf("Using experimental %s support (engine: %s)", agenticEngine.GetDisplayName(), agenticEngine.GetID()))
 		if _, hasTools := result.Frontmatter["tools"]; hasTools {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("'tools' section ignored when using engine: %s (%s doesn't support MCP tool allow-listing)", agenticEngine.GetID(), agenticEngine.GetDisplayName())))
-			c.IncrementWarningCount()
+			c.addWarning(fmt.Sprintf("'tools' section ignored when using engine: %s (%s doesn't support MCP tool allow-listing)", agenticEngine.GetID(), agenticEngine.GetDisplayName()))
 		}
 		tools = map[string]any{}
 		// For now, we'll add a basic github tool (always uses docker MCP)
@@ -234,12 +240,22 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		return nil, err
 	}
 
+	// Validate max-tokens and temperature support for the current engine
+	if err := c.validateModelParametersSupport(result.Frontmatter, agenticEngine); err != nil {
+		return nil, err
+	}
+
 	// Validate web-search support for the current engine (warning only)
 	c.validateWebSearchSupport(tools, agenticEngine)
 
 	// Process @include directives in markdown content
+	markdownStart := time.Now()
 	markdownContent, includedMarkdownFiles, err := parser.ExpandIncludesWithManifest(result.Markdown, markdownDir, false)
 	if err != nil {
+		var cycleErr *parser.ImportCycleError
+		if errors.As(err, &cycleErr) {
+			return nil, parser.FormatImportCycleError(cycleErr)
+		}
 		return nil, fmt.Errorf("failed to expand includes in markdown: %w", err)
 	}
 
@@ -276,6 +292,9 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 	for _, file := range includedMarkdownFiles {
 		allIncludedFilesMap[file] = true
 	}
+	for _, file := range mcpServerRefFiles {
+		allIncludedFilesMap[file] = true
+	}
 	var allIncludedFiles []string
 	for file := range allIncludedFilesMap {
 		allIncludedFiles = append(allIncludedFiles, file)
@@ -311,6 +330,7 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 
 	orchestratorToolsLog.Printf("Text output needed: explicit=%v, context=%v, final=%v",
 		explicitUsage, hasContext, needsTextOutput)
+	markdownDuration := time.Since(markdownStart)
 
 	// Extract and validate tracker-id
 	trackerID, err := c.extractTrackerID(result.Frontmatter)
@@ -345,6 +365,7 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		secretMasking:         secretMasking,
 		parsedFrontmatter:     parsedFrontmatter,
 		hasExplicitGitHubTool: hasExplicitGitHubTool,
+		markdownDuration:      markdownDuration,
 	}, nil
 }
 
@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 	"github.com/goccy/go-yaml"
@@ -19,11 +23,25 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	orchestratorWorkflowLog.Printf("Starting workflow file parsing: %s", markdownPath)
 
 	// Parse frontmatter section
+	frontmatterStart := time.Now()
 	parseResult, err := c.parseFrontmatterSection(markdownPath)
 	if err != nil {
 		return nil, err
 	}
+	c.tracePhase(PhaseFrontmatter, time.Since(frontmatterStart))
 
+	workflowData, err := c.parseWorkflowContent(parseResult)
+	if err != nil {
+		return nil, err
+	}
+
+	orchestratorWorkflowLog.Printf("Workflow file parsing completed successfully: %s", markdownPath)
+	return workflowData, nil
+}
+
+// parseWorkflowContent runs the compilation phases that follow frontmatter parsing. It is shared by
+// ParseWorkflowFile and CompileFromReader so files and piped content go through the same pipeline.
+func (c *Compiler) parseWorkflowContent(parseResult *frontmatterParseResult) (*WorkflowData, error) {
 	// Handle shared workflows
 	if parseResult.isSharedWorkflow {
 		return nil, &SharedWorkflowError{Path: parseResult.cleanPath}
@@ -34,6 +52,7 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	content := parseResult.content
 	result := parseResult.frontmatterResult
 	markdownDir := parseResult.markdownDir
+	toolsStart := time.Now()
 
 	// Setup engine and process imports
 	engineSetup, err := c.setupEngineAndImports(result, cleanPath, content, markdownDir)
@@ -79,6 +98,11 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	workflowData.ActionResolver = actionResolver
 	workflowData.ActionPinWarnings = c.actionPinWarnings
 
+	// Validate the concurrency group template before it replaces the generated group
+	if err := validateConcurrencyGroupTemplateConfig(result.Frontmatter); err != nil {
+		return nil, formatCompilerError(cleanPath, "error", err.Error(), err)
+	}
+
 	// Extract YAML configuration sections from frontmatter
 	c.extractYAMLSections(result.Frontmatter, workflowData)
 
@@ -95,7 +119,9 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	c.processAndMergeSteps(result.Frontmatter, workflowData, engineSetup.importsResult)
 
 	// Process and merge post-steps
-	c.processAndMergePostSteps(result.Frontmatter, workflowData)
+	if err := c.processAndMergePostSteps(result.Frontmatter, workflowData); err != nil {
+		return nil, formatCompilerError(cleanPath, "error", err.Error(), err)
+	}
 
 	// Process and merge services
 	c.processAndMergeServices(result.Frontmatter, workflowData, engineSetup.importsResult)
@@ -121,7 +147,10 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 		return nil, err
 	}
 
-	orchestratorWorkflowLog.Printf("Workflow file parsing completed successfully: %s", markdownPath)
+	// Markdown processing runs in the middle of tool configuration, so its time is reported separately
+	c.tracePhase(PhaseMarkdown, toolsResult.markdownDuration)
+	c.tracePhase(PhaseTools, time.Since(toolsStart)-toolsResult.markdownDuration)
+
 	return workflowData, nil
 }
 
@@ -145,6 +174,18 @@ func (c *Compiler) buildInitialWorkflowData(
 		agentImportSpec = ""
 	}
 
+	// Files read during engine setup (such as the network.allowed-from file) are tracked like includes
+	includedFiles := toolsResult.allIncludedFiles
+	if len(engineSetup.includedFiles) > 0 {
+		includedFiles = slices.Clone(includedFiles)
+		for _, file := range engineSetup.includedFiles {
+			if !slices.Contains(includedFiles, file) {
+				includedFiles = append(includedFiles, file)
+			}
+		}
+		sort.Strings(includedFiles)
+	}
+
 	return &WorkflowData{
 		Name:                  toolsResult.workflowName,
 		FrontmatterName:       toolsResult.frontmatterName,
@@ -156,7 +197,7 @@ func (c *Compiler) buildInitialWorkflowData(
 		ImportedMarkdown:      toolsResult.importedMarkdown, // Only imports WITH inputs
 		ImportPaths:           toolsResult.importPaths,      // Import paths for runtime-import macros (imports without inputs)
 		MainWorkflowMarkdown:  toolsResult.mainWorkflowMarkdown,
-		IncludedFiles:         toolsResult.allIncludedFiles,
+		IncludedFiles:         includedFiles,
 		ImportInputs:          importsResult.ImportInputs,
 		Tools:                 toolsResult.tools,
 		ParsedTools:           NewTools(toolsResult.tools),
@@ -199,7 +240,16 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 	workflowData.On = c.extractTopLevelYAMLSection(frontmatter, "on")
 	workflowData.Permissions = c.extractPermissions(frontmatter)
 	workflowData.Network = c.extractTopLevelYAMLSection(frontmatter, "network")
-	workflowData.Concurrency = c.extractTopLevelYAMLSection(frontmatter, "concurrency")
+	if template, cancelInProgress, ok := extractConcurrencyGroupTemplate(frontmatter); ok {
+		// The group is rendered from the template by GenerateConcurrencyConfig
+		workflowData.ConcurrencyTemplate = template
+		workflowData.ConcurrencyCancel = cancelInProgress
+	} else if cancelInProgress, ok := extractConcurrencyCancelOverride(frontmatter); ok {
+		// Only cancellation is overridden; the group is generated from the triggers
+		workflowData.ConcurrencyCancel = cancelInProgress
+	} else {
+		workflowData.Concurrency = c.extractTopLevelYAMLSection(frontmatter, "concurrency")
+	}
 	workflowData.RunName = c.extractTopLevelYAMLSection(frontmatter, "run-name")
 	workflowData.Env = c.extractTopLevelYAMLSection(frontmatter, "env")
 	workflowData.Features = c.extractFeatures(frontmatter)
@@ -211,6 +261,7 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 	workflowData.RunsOn = c.extractTopLevelYAMLSection(frontmatter, "runs-on")
 	workflowData.Environment = c.extractTopLevelYAMLSection(frontmatter, "environment")
 	workflowData.Container = c.extractTopLevelYAMLSection(frontmatter, "container")
+	workflowData.Strategy = c.extractTopLevelYAMLSection(frontmatter, "strategy")
 	workflowData.Cache = c.extractTopLevelYAMLSection(frontmatter, "cache")
 }
 
@@ -299,40 +350,41 @@ func (c *Compiler) processAndMergeSteps(frontmatter map[string]any, workflowData
 	}
 }
 
-// processAndMergePostSteps handles the processing of post-steps with action pinning
-func (c *Compiler) processAndMergePostSteps(frontmatter map[string]any, workflowData *WorkflowData) {
+// processAndMergePostSteps validates post-steps, makes them run regardless of the agent's
+// outcome, and applies action pinning
+func (c *Compiler) processAndMergePostSteps(frontmatter map[string]any, workflowData *WorkflowData) error {
 	orchestratorWorkflowLog.Print("Processing post-steps")
 
-	workflowData.PostSteps = c.extractTopLevelYAMLSection(frontmatter, "post-steps")
+	postStepsValue, hasPostSteps := frontmatter["post-steps"]
+	if !hasPostSteps || postStepsValue == nil {
+		return nil
+	}
+	if err := validatePostSteps(postStepsValue); err != nil {
+		return err
+	}
 
-	// Apply action pinning to post-steps if any
-	if workflowData.PostSteps != "" {
-		var postStepsWrapper map[string]any
-		if err := yaml.Unmarshal([]byte(workflowData.PostSteps), &postStepsWrapper); err == nil {
-			if postStepsVal, hasPostSteps := postStepsWrapper["post-steps"]; hasPostSteps {
-				if postSteps, ok := postStepsVal.([]any); ok {
-					// Convert to typed steps for action pinning
-					typedPostSteps, err := SliceToSteps(postSteps)
-					if err != nil {
-						orchestratorWorkflowLog.Printf("Failed to convert post-steps to typed steps: %v", err)
-					} else {
-						// Apply action pinning to post steps using type-safe version
-						typedPostSteps = ApplyActionPinsToTypedSteps(typedPostSteps, workflowData)
-						// Convert back to []any for YAML marshaling
-						postSteps = StepsToSlice(typedPostSteps)
-					}
+	typedPostSteps, err := SliceToSteps(postStepsValue.([]any))
+	if err != nil {
+		return fmt.Errorf("failed to parse post-steps: %w", err)
+	}
+	for _, step := range typedPostSteps {
+		step.If = postStepCondition(step.If)
+	}
+	// Apply action pinning to post steps using type-safe version
+	typedPostSteps = ApplyActionPinsToTypedSteps(typedPostSteps, workflowData)
 
-					// Convert back to YAML with "post-steps:" wrapper
-					stepsWrapper := map[string]any{"post-steps": postSteps}
-					stepsYAML, err := yaml.Marshal(stepsWrapper)
-					if err == nil {
-						// Remove quotes from uses values with version comments
-						workflowData.PostSteps = unquoteUsesWithComments(string(stepsYAML))
-					}
-				}
-			}
-		}
+	// Convert back to YAML with "post-steps:" wrapper, keeping the conventional step field order
+	orderedPostSteps := make([]yaml.MapSlice, 0, len(typedPostSteps))
+	for _, step := range typedPostSteps {
+		orderedPostSteps = append(orderedPostSteps, OrderMapFields(step.ToMap(), constants.PriorityStepFields))
 	}
+	stepsYAML, err := yaml.Marshal(map[string]any{"post-steps": orderedPostSteps})
+	if err != nil {
+		return fmt.Errorf("failed to marshal post-steps: %w", err)
+	}
+	// Remove quotes from uses values with version comments
+	workflowData.PostSteps = unquoteUsesWithComments(string(stepsYAML))
+	return nil
 }
 
 // processAndMergeServices handles the merging of imported services with main workflow services
@@ -466,6 +518,14 @@ func (c *Compiler) extractAdditionalConfigurations(
 	workflowData.Roles = c.extractRoles(frontmatter)
 	workflowData.Bots = c.extractBots(frontmatter)
 	workflowData.RateLimit = c.extractRateLimitConfig(frontmatter)
+	requiredSecrets, err := extractRequiredSecrets(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.RequiredSecrets = requiredSecrets
+	if err := c.processPhaseTimeoutsConfiguration(frontmatter, workflowData); err != nil {
+		return err
+	}
 	workflowData.SkipRoles = c.mergeSkipRoles(c.extractSkipRoles(frontmatter), importsResult.MergedSkipRoles)
 	workflowData.SkipBots = c.mergeSkipBots(c.extractSkipBots(frontmatter), importsResult.MergedSkipBots)
 
@@ -585,5 +645,10 @@ func (c *Compiler) processOnSectionAndFilters(
 	// Apply label filter if specified
 	c.applyLabelFilter(workflowData, frontmatter)
 
+	// Compile the condition DSL into the job-level if condition and changed-files check
+	if err := c.processConditionConfiguration(frontmatter, workflowData); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var compilerReactionJobLog = logger.New("workflow:compiler_reaction_job")
+
+// buildReactionCleanupJob creates a dedicated job that updates the activation reaction once the
+// workflow run completes. Depending on on.reaction it removes the reaction added by the
+// pre-activation job and/or adds +1 or -1 based on the agent job result.
+// The job runs when:
+// 1. always() - runs even if agent or other jobs fail
+// 2. pre_activation.outputs.reaction_id is set - only if the reaction was actually added
+// The job depends on pre_activation (for the reaction id), agent (for its result) and the jobs
+// that act on the agent output, so the reaction only changes once the run has finished.
+func (c *Compiler) buildReactionCleanupJob(data *WorkflowData) (*Job, error) {
+	if data.ReactionCleanup == nil || data.AIReaction == "" || data.AIReaction == "none" {
+		return nil, nil
+	}
+	if _, exists := c.jobManager.GetJob(string(constants.PreActivationJobName)); !exists {
+		compilerReactionJobLog.Print("No pre-activation job, so no reaction is added and none needs cleanup")
+		return nil, nil
+	}
+	compilerReactionJobLog.Printf("Building reaction cleanup job: remove=%v, outcome=%v", data.ReactionCleanup.RemoveOnComplete, data.ReactionCleanup.OutcomeReaction)
+
+	var steps []string
+
+	// Add setup step to copy scripts
+	setupActionRef := c.resolveActionReference("./actions/setup", data)
+	if setupActionRef == "" && !c.actionMode.IsScript() {
+		return nil, errors.New("setup action reference is required but could not be resolved")
+	}
+
+	// For dev mode (local action path), checkout the actions folder first
+	steps = append(steps, c.generateCheckoutActionsFolder(data)...)
+
+	// Reaction cleanup job doesn't need project support
+	steps = append(steps, c.generateSetupStep(setupActionRef, SetupActionDestination, false)...)
+
+	reactionIDExpr := fmt.Sprintf("needs.%s.outputs.%s", constants.PreActivationJobName, constants.ReactionIDOutput)
+	reactionAdded := BuildNotEquals(BuildPropertyAccess(reactionIDExpr), BuildStringLiteral(""))
+
+	steps = append(steps, "      - name: Update reaction after workflow completion\n")
+	steps = append(steps, "        id: update-reaction\n")
+	steps = append(steps, fmt.Sprintf("        if: %s\n", reactionAdded.Render()))
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
+	steps = append(steps, "        env:\n")
+	// Quote the reaction value to prevent YAML interpreting +1/-1 as integers
+	steps = append(steps, fmt.Sprintf("          GH_AW_REACTION: %q\n", data.AIReaction))
+	steps = append(steps, fmt.Sprintf("          GH_AW_REACTION_ID: ${{ %s }}\n", reactionIDExpr))
+	steps = append(steps, fmt.Sprintf("          GH_AW_REACTION_REMOVE: \"%t\"\n", data.ReactionCleanup.RemoveOnComplete))
+	steps = append(steps, fmt.Sprintf("          GH_AW_REACTION_OUTCOME: \"%t\"\n", data.ReactionCleanup.OutcomeReaction))
+	steps = append(steps, fmt.Sprintf("          GH_AW_AGENT_CONCLUSION: ${{ needs.%s.result }}\n", constants.AgentJobName))
+	steps = append(steps, "        with:\n")
+	// Use the same token as the pre-activation job so the reaction it added can be removed
+	steps = append(steps, "          github-token: ${{ secrets.GITHUB_TOKEN }}\n")
+	steps = append(steps, "          script: |\n")
+	steps = append(steps, generateGitHubScriptWithRequire("update_reaction.cjs"))
+
+	// Run after the agent and every job that acts on its output
+	needs := []string{string(constants.PreActivationJobName), string(constants.AgentJobName)}
+	for _, jobName := range []string{string(constants.DetectionJobName), "safe_outputs", "conclusion"} {
+		if _, exists := c.jobManager.GetJob(jobName); exists {
+			needs = append(needs, jobName)
+		}
+	}
+
+	// Same permissions as the pre-activation reaction step, plus contents: read for dev mode checkout
+	perms := NewPermissions()
+	if (c.actionMode.IsDev() || c.actionMode.IsScript()) && len(c.generateCheckoutActionsFolder(data)) > 0 {
+		perms = NewPermissionsContentsRead()
+	}
+	perms.Set(PermissionIssues, PermissionWrite)
+	perms.Set(PermissionPullRequests, PermissionWrite)
+	perms.Set(PermissionDiscussions, PermissionWrite)
+
+	compilerReactionJobLog.Printf("Job built successfully: dependencies=%v", needs)
+
+	job := &Job{
+		Name:           "update_reaction",
+		Needs:          needs,
+		If:             BuildFunctionCall("always").Render(),
+		RunsOn:         c.formatGeneratedJobRunsOn(data),
+		Permissions:    perms.RenderToYAML(),
+		Steps:          steps,
+		TimeoutMinutes: 5, // Short timeout - updating a reaction is a quick operation
+		Provenance:     "on.reaction",
+	}
+
+	return job, nil
+}
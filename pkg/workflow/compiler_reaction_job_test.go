@@ -0,0 +1,121 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compileReactionWorkflow compiles a workflow with the given on.reaction value and returns the lock file jobs
+func compileReactionWorkflow(t *testing.T, reaction string) map[string]any {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "reaction-cleanup-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+  reaction:` + reaction + `
+engine: copilot
+permissions:
+  contents: read
+safe-outputs:
+  add-comment:
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile))
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+	var workflow map[string]any
+	require.NoError(t, yaml.Unmarshal(lockContent, &workflow))
+	jobs, ok := workflow["jobs"].(map[string]any)
+	require.True(t, ok, "lock file should have jobs")
+	return jobs
+}
+
+func TestReactionCleanupJob(t *testing.T) {
+	jobs := compileReactionWorkflow(t, `
+    type: rocket
+    remove-on-complete: true
+    outcome-reaction: true`)
+
+	preActivation, ok := jobs["pre_activation"].(map[string]any)
+	require.True(t, ok, "pre_activation job should exist")
+	outputs, _ := preActivation["outputs"].(map[string]any)
+	assert.Equal(t, "${{ steps.react.outputs.reaction-id }}", outputs["reaction_id"], "pre_activation should expose the reaction id")
+
+	job, ok := jobs["update_reaction"].(map[string]any)
+	require.True(t, ok, "update_reaction job should exist")
+	assert.Equal(t, "always()", job["if"], "the job should run once the run completes, whatever its outcome")
+	assert.ElementsMatch(t, []any{"pre_activation", "agent", "detection", "safe_outputs", "conclusion"}, job["needs"])
+
+	steps, _ := job["steps"].([]any)
+	var updateStep map[string]any
+	for _, step := range steps {
+		if stepMap, ok := step.(map[string]any); ok && stepMap["id"] == "update-reaction" {
+			updateStep = stepMap
+		}
+	}
+	require.NotNil(t, updateStep, "update_reaction job should have the update-reaction step")
+	assert.Equal(t, "needs.pre_activation.outputs.reaction_id != ''", updateStep["if"])
+
+	env, _ := updateStep["env"].(map[string]any)
+	assert.Equal(t, "rocket", env["GH_AW_REACTION"])
+	assert.Equal(t, "${{ needs.pre_activation.outputs.reaction_id }}", env["GH_AW_REACTION_ID"])
+	assert.Equal(t, "true", env["GH_AW_REACTION_REMOVE"])
+	assert.Equal(t, "true", env["GH_AW_REACTION_OUTCOME"])
+	assert.Equal(t, "${{ needs.agent.result }}", env["GH_AW_AGENT_CONCLUSION"])
+
+	with, _ := updateStep["with"].(map[string]any)
+	script, _ := with["script"].(string)
+	assert.Contains(t, script, "update_reaction.cjs")
+}
+
+func TestReactionCleanupJobNotGeneratedByDefault(t *testing.T) {
+	for _, reaction := range []string{" eyes", `
+    type: eyes`} {
+		jobs := compileReactionWorkflow(t, reaction)
+		assert.NotContains(t, jobs, "update_reaction", "plain reactions should be left in place")
+
+		preActivation, _ := jobs["pre_activation"].(map[string]any)
+		outputs, _ := preActivation["outputs"].(map[string]any)
+		assert.NotContains(t, outputs, "reaction_id")
+	}
+}
+
+func TestReactionCleanupWithNoneReaction(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "reaction-cleanup-none-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+  reaction:
+    type: none
+    remove-on-complete: true
+engine: copilot
+permissions:
+  contents: read
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "none") || strings.Contains(err.Error(), "reaction"), "error should mention the reaction: %v", err)
+}
@@ -0,0 +1,182 @@
+package workflow
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/goccy/go-yaml"
+)
+
+var compilerReportLog = logger.New("workflow:compiler_report")
+
+// CompileReport is a machine-readable summary of what a compilation produced.
+// It is serializable to JSON for CI tooling.
+type CompileReport struct {
+	Workflow             string            `json:"workflow"`
+	LockFile             string            `json:"lock_file"`
+	Name                 string            `json:"name"`
+	Engine               string            `json:"engine"`
+	Triggers             []string          `json:"triggers"`
+	Jobs                 []string          `json:"jobs"`
+	MCPServers           []string          `json:"mcp_servers"`
+	SafeOutputs          []string          `json:"safe_outputs"`
+	Permissions          map[string]string `json:"permissions,omitempty"`
+	PermissionsShorthand string            `json:"permissions_shorthand,omitempty"`
+	FirewallEnabled      bool              `json:"firewall_enabled"`
+	FirewallAllowlist    []string          `json:"firewall_allowlist"`
+	WarningCount         int               `json:"warning_count"`
+	Warnings             []string          `json:"warnings"`
+	UpToDate             bool              `json:"up_to_date,omitempty"`
+}
+
+// CompileWorkflowWithReport compiles a workflow like CompileWorkflow and returns a report
+// describing the generated jobs, MCP servers, safe outputs, permissions, triggers,
+// engine, firewall allowlist and warnings.
+//
+// When incremental mode skips an up-to-date workflow, UpToDate is set and Jobs is empty
+// because no jobs were generated.
+func (c *Compiler) CompileWorkflowWithReport(markdownPath string) (*CompileReport, error) {
+	compilerReportLog.Printf("Compiling workflow with report: %s", markdownPath)
+
+	// Warnings emitted while parsing belong to this workflow too
+	warningsBefore := len(c.warnings)
+	skippedBefore := c.skippedCount
+
+	c.markdownPath = markdownPath
+	workflowData, err := c.ParseWorkflowFile(markdownPath)
+	if err != nil {
+		// Keep already formatted console errors as-is, matching CompileWorkflow
+		if strings.Contains(err.Error(), ":") && (strings.Contains(err.Error(), "error:") || strings.Contains(err.Error(), "warning:")) {
+			return nil, err
+		}
+		return nil, formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	if err := c.CompileWorkflowData(workflowData, markdownPath); err != nil {
+		return nil, err
+	}
+
+	report := buildCompileReport(workflowData, markdownPath)
+	report.UpToDate = c.skippedCount > skippedBefore
+	if !report.UpToDate {
+		report.Jobs = c.generatedJobNames()
+	}
+	report.Warnings = append(report.Warnings, c.warnings[warningsBefore:]...)
+	report.WarningCount = len(report.Warnings)
+
+	compilerReportLog.Printf("Report built: jobs=%d, mcp_servers=%d, safe_outputs=%d", len(report.Jobs), len(report.MCPServers), len(report.SafeOutputs))
+	return report, nil
+}
+
+// buildCompileReport collects the report fields derived from the parsed workflow data
+func buildCompileReport(workflowData *WorkflowData, markdownPath string) *CompileReport {
+	report := &CompileReport{
+		Workflow:          markdownPath,
+		LockFile:          stringutil.MarkdownToLockFile(markdownPath),
+		Name:              workflowData.Name,
+		Engine:            getReportEngineID(workflowData),
+		Triggers:          extractReportTriggers(workflowData.On),
+		Jobs:              []string{},
+		MCPServers:        collectMCPServerNames(workflowData),
+		SafeOutputs:       GetEnabledSafeOutputToolNames(workflowData.SafeOutputs),
+		FirewallEnabled:   isFirewallEnabled(workflowData),
+		FirewallAllowlist: []string{},
+		Warnings:          []string{},
+	}
+	if report.MCPServers == nil {
+		report.MCPServers = []string{}
+	}
+	if report.SafeOutputs == nil {
+		report.SafeOutputs = []string{}
+	}
+
+	permissionsParser := NewPermissionsParser(workflowData.Permissions)
+	if permissionsParser.isShorthand {
+		report.PermissionsShorthand = permissionsParser.shorthandValue
+	} else if len(permissionsParser.parsedPerms) > 0 {
+		report.Permissions = permissionsParser.parsedPerms
+	}
+
+	if report.FirewallEnabled {
+		allowed := GetAllowedDomainsForEngine(constants.EngineName(report.Engine), workflowData.NetworkPermissions, workflowData.Tools, workflowData.Runtimes)
+		if allowed != "" {
+			report.FirewallAllowlist = strings.Split(allowed, ",")
+		}
+	}
+
+	return report
+}
+
+// getReportEngineID returns the resolved engine ID for the workflow
+func getReportEngineID(workflowData *WorkflowData) string {
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.ID != "" {
+		return workflowData.EngineConfig.ID
+	}
+	return workflowData.AI
+}
+
+// FrontmatterTriggers returns the sorted event names in the "on" field of a workflow's
+// frontmatter, with shorthands such as "daily" or "/command" expanded as in compilation.
+// Only the frontmatter is read, so no imports are resolved and no warnings are printed.
+func FrontmatterTriggers(frontmatter map[string]any) []string {
+	onOnly := map[string]any{"on": frontmatter["on"]}
+	// Fuzzy schedules are only expanded once scattered; the identifier seeds the scattered
+	// times, which do not affect the trigger names
+	c := &Compiler{workflowIdentifier: "frontmatter-triggers"}
+	if err := c.preprocessScheduleFields(onOnly, "", ""); err != nil {
+		compilerReportLog.Printf("Failed to expand on field shorthand: %v", err)
+		onOnly["on"] = frontmatter["on"]
+	}
+	return triggerNames(onOnly["on"])
+}
+
+// extractReportTriggers returns the sorted event names from the rendered "on:" section
+func extractReportTriggers(onSection string) []string {
+	triggers := []string{}
+	if onSection == "" {
+		return triggers
+	}
+
+	var parsed map[string]any
+	if err := yaml.Unmarshal([]byte(onSection), &parsed); err != nil {
+		compilerReportLog.Printf("Failed to parse on section for report: %v", err)
+		return triggers
+	}
+
+	return triggerNames(parsed["on"])
+}
+
+// triggerNames returns the sorted event names of an "on" value
+func triggerNames(onValue any) []string {
+	triggers := []string{}
+	switch on := onValue.(type) {
+	case string:
+		triggers = append(triggers, on)
+	case []any:
+		for _, event := range on {
+			if name, ok := event.(string); ok {
+				triggers = append(triggers, name)
+			}
+		}
+	case map[string]any:
+		for name := range on {
+			triggers = append(triggers, name)
+		}
+	}
+
+	sort.Strings(triggers)
+	return triggers
+}
+
+// generatedJobNames returns the sorted names of the jobs produced by the last compilation
+func (c *Compiler) generatedJobNames() []string {
+	names := make([]string, 0, len(c.jobManager.GetAllJobs()))
+	for name := range c.jobManager.GetAllJobs() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,136 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWorkflowWithReport(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-report-test")
+
+	testContent := `---
+on:
+  issues:
+    types: [opened]
+  workflow_dispatch:
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+network:
+  allowed:
+    - defaults
+    - example.com
+tools:
+  github:
+    toolsets: [issues]
+safe-outputs:
+  add-comment:
+---
+
+# Triage
+
+Comment on new issues.
+`
+
+	testFile := filepath.Join(tmpDir, "triage.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	report, err := NewCompiler().CompileWorkflowWithReport(testFile)
+	require.NoError(t, err, "Workflow should compile")
+	require.NotNil(t, report, "Report should be returned")
+
+	assert.Equal(t, testFile, report.Workflow, "Report should name the workflow")
+	assert.Equal(t, stringutil.MarkdownToLockFile(testFile), report.LockFile, "Report should name the lock file")
+	assert.FileExists(t, report.LockFile, "Lock file should still be written")
+	assert.Equal(t, "Triage", report.Name, "Report should include the workflow name")
+	assert.Equal(t, "copilot", report.Engine, "Report should include the resolved engine")
+	assert.Equal(t, []string{"issues", "workflow_dispatch"}, report.Triggers, "Report should list trigger types")
+	assert.Contains(t, report.Jobs, "activation", "Report should list the activation job")
+	assert.Contains(t, report.Jobs, "agent", "Report should list the agent job")
+	assert.Contains(t, report.Jobs, "safe_outputs", "Report should list the safe outputs job")
+	assert.Equal(t, []string{"github", "safe-outputs"}, report.MCPServers, "Report should list MCP servers")
+	assert.Contains(t, report.SafeOutputs, "add_comment", "Report should list enabled safe outputs")
+	assert.Equal(t, map[string]string{"contents": "read", "issues": "read"}, report.Permissions, "Report should include permissions")
+	assert.True(t, report.FirewallEnabled, "Firewall should be enabled by default")
+	assert.Contains(t, report.FirewallAllowlist, "example.com", "Allowlist should include workflow domains")
+	assert.Contains(t, report.FirewallAllowlist, "api.githubcopilot.com", "Allowlist should include engine defaults")
+	assert.False(t, report.UpToDate, "A fresh compile is not up to date")
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err, "Report should serialize to JSON")
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded), "Report JSON should be valid")
+	assert.Equal(t, "copilot", decoded["engine"], "JSON should use snake_case keys")
+	assert.Contains(t, decoded, "firewall_allowlist", "JSON should include the allowlist")
+}
+
+func TestCompileWorkflowWithReportReturnsCompileErrors(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-report-error-test")
+
+	testFile := filepath.Join(tmpDir, "broken.md")
+	require.NoError(t, os.WriteFile(testFile, []byte("---\non: push\nengine: not-an-engine\n---\n\n# Broken\n"), 0644), "Should write test file")
+
+	report, err := NewCompiler().CompileWorkflowWithReport(testFile)
+	require.Error(t, err, "Invalid workflows should fail")
+	assert.Nil(t, report, "No report should be returned on failure")
+}
+
+func TestCompileWorkflowWithReportIncludesWarnings(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-report-warning-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+rate-limit:
+  max: 5
+  window: 60
+---
+
+# Rate limited
+
+Summarize the repository.
+`
+
+	testFile := filepath.Join(tmpDir, "rate-limited.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	compiler := NewCompiler()
+	report, err := compiler.CompileWorkflowWithReport(testFile)
+	require.NoError(t, err, "Workflow should compile")
+	require.NotNil(t, report, "Report should be returned")
+
+	assert.Contains(t, report.Warnings, "Using experimental feature: rate-limit", "Report should include non-schedule warnings")
+	assert.Len(t, report.Warnings, report.WarningCount, "Every counted warning should have a message")
+	assert.Equal(t, compiler.GetWarningCount(), report.WarningCount, "Report should match the compiler warning count")
+}
+
+func TestExtractReportTriggers(t *testing.T) {
+	tests := []struct {
+		name     string
+		on       string
+		expected []string
+	}{
+		{name: "empty", on: "", expected: []string{}},
+		{name: "string", on: "on: push", expected: []string{"push"}},
+		{name: "list", on: "on: [push, pull_request]", expected: []string{"pull_request", "push"}},
+		{name: "map", on: "on:\n  schedule:\n    - cron: '0 0 * * *'\n  issues:\n    types: [opened]", expected: []string{"issues", "schedule"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, extractReportTriggers(tt.on), "Triggers should be extracted from the on section")
+		})
+	}
+}
@@ -0,0 +1,104 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reuseScheduledWorkflow = `---
+on:
+  schedule:
+    - cron: "0 9 * * *"
+permissions:
+  contents: read
+engine: claude
+safe-outputs:
+  create-issue:
+---
+
+# Daily Report
+
+Write a daily report issue.
+`
+
+const reuseIssueWorkflow = `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Issue Summary
+
+Summarize the opened issue.
+`
+
+// writeReuseWorkflows writes the two workflows used by the compiler reuse tests
+func writeReuseWorkflows(t *testing.T) (string, string) {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "compiler-reuse-test")
+	scheduledFile := filepath.Join(tmpDir, "daily-report.md")
+	issueFile := filepath.Join(tmpDir, "issue-summary.md")
+	require.NoError(t, os.WriteFile(scheduledFile, []byte(reuseScheduledWorkflow), 0644), "Should write scheduled workflow")
+	require.NoError(t, os.WriteFile(issueFile, []byte(reuseIssueWorkflow), 0644), "Should write issue workflow")
+	return scheduledFile, issueFile
+}
+
+func TestCompilerReuseAcrossWorkflows(t *testing.T) {
+	scheduledFile, issueFile := writeReuseWorkflows(t)
+
+	// Compile the issue workflow with a fresh compiler as the reference output
+	require.NoError(t, NewCompiler().CompileWorkflow(issueFile), "Issue workflow should compile")
+	freshLock, err := os.ReadFile(stringutil.MarkdownToLockFile(issueFile))
+	require.NoError(t, err, "Should read lock file")
+
+	compiler := NewCompiler()
+
+	scheduledReport, err := compiler.CompileWorkflowWithReport(scheduledFile)
+	require.NoError(t, err, "Scheduled workflow should compile")
+	assert.Positive(t, scheduledReport.WarningCount, "Fixed daily cron should produce a warning")
+	require.NotEmpty(t, scheduledReport.Warnings, "Fixed daily cron should produce a schedule warning")
+	assert.Contains(t, scheduledReport.Warnings[0], "fixed daily time")
+	assert.Contains(t, scheduledReport.Jobs, "safe_outputs")
+
+	issueReport, err := compiler.CompileWorkflowWithReport(issueFile)
+	require.NoError(t, err, "Issue workflow should compile with a reused compiler")
+	assert.Zero(t, issueReport.WarningCount, "Warnings of the previous workflow should not be reported")
+	assert.Empty(t, issueReport.Warnings, "Schedule warnings of the previous workflow should not be reported")
+	assert.NotContains(t, issueReport.Jobs, "safe_outputs", "Jobs of the previous workflow should not leak")
+	assert.Equal(t, "copilot", issueReport.Engine)
+
+	reusedLock, err := os.ReadFile(stringutil.MarkdownToLockFile(issueFile))
+	require.NoError(t, err, "Should read lock file")
+	assert.Equal(t, string(freshLock), string(reusedLock), "A reused compiler should produce the same output as a fresh one")
+}
+
+func TestCompilerReset(t *testing.T) {
+	scheduledFile, _ := writeReuseWorkflows(t)
+
+	compiler := NewCompiler()
+	compiler.SetStrictMode(true)
+	require.NoError(t, compiler.CompileWorkflow(scheduledFile), "Scheduled workflow should compile")
+	require.Positive(t, compiler.GetWarningCount(), "Fixed daily cron should produce a warning")
+	require.NotEmpty(t, compiler.GetScheduleWarnings(), "Fixed daily cron should produce a schedule warning")
+	actionCache := compiler.GetSharedActionCache()
+
+	compiler.Reset()
+
+	assert.Zero(t, compiler.GetWarningCount(), "Reset should clear the warning count")
+	assert.Empty(t, compiler.GetScheduleWarnings(), "Reset should clear schedule warnings")
+	assert.Zero(t, compiler.GetSkippedCount(), "Reset should clear the incremental skip count")
+	assert.Empty(t, compiler.generatedJobNames(), "Reset should clear the jobs of the last workflow")
+	assert.True(t, compiler.strictMode, "Reset should keep the compiler configuration")
+	assert.Same(t, actionCache, compiler.GetSharedActionCache(), "Reset should keep the shared action cache")
+}
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"path/filepath"
@@ -48,7 +49,7 @@ func (c *Compiler) parseOnSection(frontmatter map[string]any, workflowData *Work
 			// Extract reaction from on section
 			if reactionValue, hasReactionField := onMap["reaction"]; hasReactionField {
 				hasReaction = true
-				reactionStr, err := parseReactionValue(reactionValue)
+				reactionStr, cleanup, err := parseReactionConfig(reactionValue)
 				if err != nil {
 					return err
 				}
@@ -56,8 +57,12 @@ func (c *Compiler) parseOnSection(frontmatter map[string]any, workflowData *Work
 				if !isValidReaction(reactionStr) {
 					return fmt.Errorf("invalid reaction value '%s': must be one of %v", reactionStr, getValidReactions())
 				}
+				if cleanup != nil && reactionStr == "none" {
+					return errors.New("reaction.remove-on-complete and reaction.outcome-reaction cannot be used with reaction 'none'")
+				}
 				// Set AIReaction even if it's "none" - "none" explicitly disables reactions
 				workflowData.AIReaction = reactionStr
+				workflowData.ReactionCleanup = cleanup
 			}
 
 			// Extract status-comment from on section
@@ -315,10 +320,12 @@ func (c *Compiler) applyDefaultTools(tools map[string]any, safeOutputs *SafeOutp
 		// Only set allowed tools if explicitly configured
 		// Don't add default tools - let the MCP server use all available tools
 		if len(existingToolsSet) > 0 {
+			// Expand patterns like "issue_*" against the known GitHub tool catalog
+			expandedAllowed := ExpandGitHubToolPatterns(parsedConfig.Allowed.ToStringSlice())
 			// Convert back to []any for the map
-			existingAllowed := make([]any, 0, len(parsedConfig.Allowed))
-			for _, tool := range parsedConfig.Allowed {
-				existingAllowed = append(existingAllowed, string(tool))
+			existingAllowed := make([]any, 0, len(expandedAllowed))
+			for _, tool := range expandedAllowed {
+				existingAllowed = append(existingAllowed, tool)
 			}
 			githubConfig["allowed"] = existingAllowed
 		}
@@ -145,6 +145,8 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfPositive("expires", c.Expires).
 			AddStringSlice("labels", c.Labels).
 			AddIfNotEmpty("title_prefix", c.TitlePrefix).
+			AddIfNotEmpty("title_template", normalizeSafeOutputTemplate(c.TitleTemplate)).
+			AddIfNotEmpty("body_template", normalizeSafeOutputTemplate(c.BodyTemplate)).
 			AddStringSlice("assignees", c.Assignees).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddTemplatableBool("group", c.Group).
@@ -197,6 +199,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddBoolPtr("require_comment", c.RequireComment).
 			Build()
 	},
 	"close_discussion": func(cfg *SafeOutputsConfig) map[string]any {
@@ -319,7 +322,8 @@ var handlerRegistry = map[string]handlerBuilder{
 		builder := newHandlerConfigBuilder().
 			AddTemplatableInt("max", c.Max).
 			AddIfNotEmpty("target", c.Target).
-			AddIfNotEmpty("title_prefix", c.TitlePrefix)
+			AddIfNotEmpty("title_prefix", c.TitlePrefix).
+			AddStringSlice("allowed_fields", c.AllowedFields)
 		// Boolean pointer fields indicate which fields can be updated
 		if c.Status != nil {
 			builder.AddDefault("allow_status", true)
@@ -436,13 +440,12 @@ var handlerRegistry = map[string]handlerBuilder{
 			return nil
 		}
 		c := cfg.CreatePullRequests
-		maxPatchSize := 1024 // default 1024 KB
-		if cfg.MaximumPatchSize > 0 {
-			maxPatchSize = cfg.MaximumPatchSize
-		}
+		maxPatchSize := getCreatePullRequestMaxPatchSize(cfg)
 		builder := newHandlerConfigBuilder().
 			AddTemplatableInt("max", c.Max).
 			AddIfNotEmpty("title_prefix", c.TitlePrefix).
+			AddIfNotEmpty("title_template", normalizeSafeOutputTemplate(c.TitleTemplate)).
+			AddIfNotEmpty("body_template", normalizeSafeOutputTemplate(c.BodyTemplate)).
 			AddStringSlice("labels", c.Labels).
 			AddStringSlice("reviewers", c.Reviewers).
 			AddTemplatableBool("draft", c.Draft).
@@ -512,6 +515,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddBoolPtr("require_comment", c.RequireComment).
 			Build()
 	},
 	"hide_comment": func(cfg *SafeOutputsConfig) map[string]any {
@@ -615,9 +619,11 @@ var handlerRegistry = map[string]handlerBuilder{
 			return nil
 		}
 		c := cfg.AssignToUser
+		users, teams := splitAssignToUserAllowed(c.Allowed)
 		return newHandlerConfigBuilder().
 			AddTemplatableInt("max", c.Max).
-			AddStringSlice("allowed", c.Allowed).
+			AddStringSlice("allowed", users).
+			AddStringSlice("allowed_teams", teams).
 			AddIfNotEmpty("target", c.Target).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
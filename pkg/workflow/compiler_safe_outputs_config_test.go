@@ -464,6 +464,28 @@ func TestHandlerConfigBooleanFields(t *testing.T) {
 			checkKey:   "draft",
 			expected:   true, // AddTemplatableBool converts "true" string to JSON boolean
 		},
+		{
+			name: "close issue without required comment",
+			safeOutputs: &SafeOutputsConfig{
+				CloseIssues: &CloseEntityConfig{
+					RequireComment: testBoolPtr(false),
+				},
+			},
+			checkField: "close_issue",
+			checkKey:   "require_comment",
+			expected:   false,
+		},
+		{
+			name: "close pull request with required comment",
+			safeOutputs: &SafeOutputsConfig{
+				ClosePullRequests: &CloseEntityConfig{
+					RequireComment: testBoolPtr(true),
+				},
+			},
+			checkField: "close_pull_request",
+			checkKey:   "require_comment",
+			expected:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1010,6 +1032,50 @@ func TestHandlerConfigAssignToUserWithUnassignFirst(t *testing.T) {
 	}
 }
 
+// TestHandlerConfigAssignToUserWithTeams tests that team entries are passed separately from literal users
+func TestHandlerConfigAssignToUserWithTeams(t *testing.T) {
+	compiler := NewCompiler()
+
+	workflowData := &WorkflowData{
+		Name: "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			AssignToUser: &AssignToUserConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{
+					Max: strPtr("2"),
+				},
+				Allowed: []string{"user1", "@my-org/triage", "@my-org/on-call"},
+			},
+		},
+	}
+
+	var steps []string
+	compiler.addHandlerManagerConfigEnvVar(&steps, workflowData)
+
+	found := false
+	for _, step := range steps {
+		if strings.Contains(step, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG") {
+			parts := strings.Split(step, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG: ")
+			if len(parts) == 2 {
+				jsonStr := strings.TrimSpace(parts[1])
+				jsonStr = strings.Trim(jsonStr, "\"")
+				jsonStr = strings.ReplaceAll(jsonStr, "\\\"", "\"")
+
+				var config map[string]map[string]any
+				err := json.Unmarshal([]byte(jsonStr), &config)
+				require.NoError(t, err, "Handler config JSON should be valid")
+
+				assignConfig, ok := config["assign_to_user"]
+				require.True(t, ok, "Should have assign_to_user handler")
+				found = true
+
+				assert.Equal(t, []any{"user1"}, assignConfig["allowed"], "Allowed should only contain literal users")
+				assert.Equal(t, []any{"my-org/triage", "my-org/on-call"}, assignConfig["allowed_teams"], "Teams should be passed as allowed_teams")
+			}
+		}
+	}
+	assert.True(t, found, "Should emit handler config")
+}
+
 // TestHandlerConfigUnassignFromUser tests unassign_from_user configuration
 func TestHandlerConfigUnassignFromUser(t *testing.T) {
 	compiler := NewCompiler()
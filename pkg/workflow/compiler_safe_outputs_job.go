@@ -9,6 +9,58 @@ import (
 
 var consolidatedSafeOutputsJobLog = logger.New("workflow:compiler_safe_outputs_job")
 
+// dedicatedSafeOutputStep describes a safe output that runs in its own step of the consolidated
+// safe_outputs job instead of in the handler manager
+type dedicatedSafeOutputStep struct {
+	id      string
+	enabled func(*SafeOutputsConfig) bool
+	build   func(c *Compiler, data *WorkflowData, mainJobName string, threatDetectionEnabled bool) SafeOutputStepConfig
+	outputs []string // Step outputs exposed as job outputs named <id>_<output>
+}
+
+// dedicatedSafeOutputSteps lists the dedicated safe output steps in their canonical order.
+// They run after the handler manager step, in this order:
+//   - assign_to_agent assigns issues (including ones created by the handler manager) to an agent
+//   - create_agent_session creates agent sessions after assignment
+//   - create_gist publishes standalone reports as gists
+//   - create_release creates draft releases with sanitized notes
+//   - create_pull_request_review submits complete pull request reviews
+//
+// New dedicated steps must be appended here rather than emitted ad hoc, so the step order of
+// the compiled job stays stable.
+var dedicatedSafeOutputSteps = []dedicatedSafeOutputStep{
+	{
+		id:      "assign_to_agent",
+		enabled: func(so *SafeOutputsConfig) bool { return so.AssignToAgent != nil },
+		build:   (*Compiler).buildAssignToAgentStepConfig,
+		outputs: []string{"assigned", "assignment_errors", "assignment_error_count"},
+	},
+	{
+		id:      "create_agent_session",
+		enabled: func(so *SafeOutputsConfig) bool { return so.CreateAgentSessions != nil },
+		build:   (*Compiler).buildCreateAgentSessionStepConfig,
+		outputs: []string{"session_number", "session_url"},
+	},
+	{
+		id:      "create_gist",
+		enabled: func(so *SafeOutputsConfig) bool { return so.CreateGists != nil },
+		build:   (*Compiler).buildCreateGistStepConfig,
+		outputs: []string{"gist_id", "gist_url"},
+	},
+	{
+		id:      "create_release",
+		enabled: func(so *SafeOutputsConfig) bool { return so.CreateReleases != nil },
+		build:   (*Compiler).buildCreateReleaseStepConfig,
+		outputs: []string{"release_id", "release_url", "release_tag"},
+	},
+	{
+		id:      "create_pull_request_review",
+		enabled: func(so *SafeOutputsConfig) bool { return so.CreatePullRequestReviews != nil },
+		build:   (*Compiler).buildCreatePullRequestReviewStepConfig,
+		outputs: []string{"review_id", "review_url"},
+	},
+}
+
 // buildConsolidatedSafeOutputsJob builds a single job containing all safe output operations
 // as separate steps within that job. This reduces the number of jobs in the workflow
 // while maintaining observability through distinct step names, IDs, and outputs.
@@ -66,6 +118,19 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		steps = append(steps, patchDownloadSteps...)
 	}
 
+	// In dry-run mode, every safe output step is replaced by a single step that logs the
+	// sanitized payloads to the step summary and uploads them as an artifact.
+	// No GitHub API writes happen, so the job only needs read access.
+	if isSafeOutputsDryRun(data.SafeOutputs) {
+		consolidatedSafeOutputsJobLog.Print("Dry-run mode enabled, logging safe outputs instead of executing them")
+		steps = append(steps, c.buildConsolidatedSafeOutputsDryRunSteps(data)...)
+		safeOutputStepNames = append(safeOutputStepNames, "dry_run")
+		outputs["dry_run_item_count"] = "${{ steps.dry_run.outputs.item_count }}"
+		permissions = NewPermissionsContentsRead()
+		steps = applySafeOutputsPhaseTimeout(data, steps, safeOutputStepNames)
+		return c.newConsolidatedSafeOutputsJob(data, mainJobName, markdownPath, steps, outputs, permissions, threatDetectionEnabled), safeOutputStepNames, nil
+	}
+
 	// Add shared checkout and git config steps for PR operations
 	// Both create-pull-request and push-to-pull-request-branch need these steps,
 	// so we add them once with a combined condition to avoid duplication
@@ -81,11 +146,12 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 
 	// === Build safe output steps ===
 	//
-	// IMPORTANT: Step order matters for safe outputs that depend on each other.
-	// The execution order ensures dependencies are satisfied:
+	// IMPORTANT: Step order matters for safe outputs that depend on each other, and it must
+	// not depend on how the configuration was written so that recompiling an unchanged
+	// workflow yields a byte-identical lock file. The canonical order is:
 	// 1. Handler Manager - processes create_issue, update_issue, add_comment, etc.
-	// 2. Assign To Agent - assigns issue to agent (after handler managers complete)
-	// 3. Create Agent Session - creates agent session (after assignment)
+	// 2. Dedicated steps in the order of dedicatedSafeOutputSteps (assign_to_agent,
+	//    create_agent_session, create_gist, create_release, create_pull_request_review)
 	//
 	// Note: All project-related operations (create_project, update_project, create_project_status_update)
 	// are now handled by the unified handler in the handler manager step.
@@ -138,6 +204,16 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		outputs["code_push_failure_errors"] = "${{ steps.process_safe_outputs.outputs.code_push_failure_errors }}"
 		outputs["code_push_failure_count"] = "${{ steps.process_safe_outputs.outputs.code_push_failure_count }}"
 
+		// Expose the created issue and pull request so downstream jobs can reference them
+		if data.SafeOutputs.CreateIssues != nil || data.SafeOutputs.CreatePullRequests != nil {
+			outputs["issue_number"] = "${{ steps.process_safe_outputs.outputs.issue_number }}"
+			outputs["issue_url"] = "${{ steps.process_safe_outputs.outputs.issue_url }}"
+		}
+		if data.SafeOutputs.CreatePullRequests != nil {
+			outputs["pr_number"] = "${{ steps.process_safe_outputs.outputs.pr_number }}"
+			outputs["pr_url"] = "${{ steps.process_safe_outputs.outputs.pr_url }}"
+		}
+
 		// Note: Permissions are now computed centrally by ComputePermissionsForSafeOutputs()
 		// at the start of this function to ensure consistent permission calculation
 
@@ -158,29 +234,19 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		}
 	}
 
-	// 3. Assign To Agent step (runs after handler managers)
-	if data.SafeOutputs.AssignToAgent != nil {
-		stepConfig := c.buildAssignToAgentStepConfig(data, mainJobName, threatDetectionEnabled)
-		stepYAML := c.buildConsolidatedSafeOutputStep(data, stepConfig)
-		steps = append(steps, stepYAML...)
-		safeOutputStepNames = append(safeOutputStepNames, stepConfig.StepID)
-
-		outputs["assign_to_agent_assigned"] = "${{ steps.assign_to_agent.outputs.assigned }}"
-		outputs["assign_to_agent_assignment_errors"] = "${{ steps.assign_to_agent.outputs.assignment_errors }}"
-		outputs["assign_to_agent_assignment_error_count"] = "${{ steps.assign_to_agent.outputs.assignment_error_count }}"
-
-		// Note: Permissions are computed centrally by ComputePermissionsForSafeOutputs()
-	}
-
-	// 4. Create Agent Session step
-	if data.SafeOutputs.CreateAgentSessions != nil {
-		stepConfig := c.buildCreateAgentSessionStepConfig(data, mainJobName, threatDetectionEnabled)
+	// 2. Dedicated steps, in the canonical order defined by dedicatedSafeOutputSteps
+	for _, dedicated := range dedicatedSafeOutputSteps {
+		if !dedicated.enabled(data.SafeOutputs) {
+			continue
+		}
+		stepConfig := dedicated.build(c, data, mainJobName, threatDetectionEnabled)
 		stepYAML := c.buildConsolidatedSafeOutputStep(data, stepConfig)
 		steps = append(steps, stepYAML...)
 		safeOutputStepNames = append(safeOutputStepNames, stepConfig.StepID)
 
-		outputs["create_agent_session_session_number"] = "${{ steps.create_agent_session.outputs.session_number }}"
-		outputs["create_agent_session_session_url"] = "${{ steps.create_agent_session.outputs.session_url }}"
+		for _, output := range dedicated.outputs {
+			outputs[dedicated.id+"_"+output] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", dedicated.id, output)
+		}
 
 		// Note: Permissions are computed centrally by ComputePermissionsForSafeOutputs()
 	}
@@ -241,6 +307,12 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	// Add GitHub App token minting step at the beginning if app is configured
 	if data.SafeOutputs.App != nil {
 		appTokenSteps := c.buildGitHubAppTokenMintStep(data.SafeOutputs.App, permissions)
+		// Team entries in assign-to-user.allowed are expanded through the org team members API
+		if data.SafeOutputs.AssignToUser != nil {
+			if _, teams := splitAssignToUserAllowed(data.SafeOutputs.AssignToUser.Allowed); len(teams) > 0 {
+				appTokenSteps = append(appTokenSteps, "          permission-members: read\n")
+			}
+		}
 		// Calculate insertion index: after setup action (if present) and artifact downloads, but before checkout and safe output steps
 		insertIndex := 0
 
@@ -293,6 +365,17 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		steps = append(steps, buildSafeOutputItemsManifestUploadStep()...)
 	}
 
+	steps = applySafeOutputsPhaseTimeout(data, steps, safeOutputStepNames)
+	job := c.newConsolidatedSafeOutputsJob(data, mainJobName, markdownPath, steps, outputs, permissions, threatDetectionEnabled)
+
+	consolidatedSafeOutputsJobLog.Printf("Built consolidated safe outputs job with %d steps", len(safeOutputStepNames))
+
+	return job, safeOutputStepNames, nil
+}
+
+// newConsolidatedSafeOutputsJob wraps the given steps in the consolidated safe_outputs job,
+// adding the job condition, dependencies and job-level environment variables
+func (c *Compiler) newConsolidatedSafeOutputsJob(data *WorkflowData, mainJobName, markdownPath string, steps []string, outputs map[string]string, permissions *Permissions, threatDetectionEnabled bool) *Job {
 	// Build the job condition
 	// The job should run if agent job completed (not skipped) AND detection passed (if enabled)
 	agentNotSkipped := BuildAnd(
@@ -330,21 +413,18 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	// Build job-level environment variables that are common to all safe output steps
 	jobEnv := c.buildJobLevelSafeOutputEnvVars(data, workflowID)
 
-	job := &Job{
+	return &Job{
 		Name:           "safe_outputs",
 		If:             jobCondition.Render(),
-		RunsOn:         c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:         c.formatGeneratedJobRunsOn(data),
 		Permissions:    permissions.RenderToYAML(),
 		TimeoutMinutes: 15, // Slightly longer timeout for consolidated job with multiple steps
 		Env:            jobEnv,
 		Steps:          steps,
 		Outputs:        outputs,
 		Needs:          needs,
+		Provenance:     safeOutputsJobProvenance(data.SafeOutputs),
 	}
-
-	consolidatedSafeOutputsJobLog.Printf("Built consolidated safe outputs job with %d steps", len(safeOutputStepNames))
-
-	return job, safeOutputStepNames, nil
 }
 
 // buildJobLevelSafeOutputEnvVars builds environment variables that should be set at the job level
@@ -389,6 +469,16 @@ func (c *Compiler) buildJobLevelSafeOutputEnvVars(data *WorkflowData, workflowID
 		envVars["GH_AW_SAFE_OUTPUTS_STAGED"] = "\"true\""
 	}
 
+	// Add the global cap on safe output operations (shared by the handler manager and the dedicated steps)
+	if data.SafeOutputs != nil && data.SafeOutputs.MaxTotal > 0 {
+		envVars["GH_AW_SAFE_OUTPUTS_MAX_TOTAL"] = fmt.Sprintf("\"%d\"", data.SafeOutputs.MaxTotal)
+	}
+
+	// Add the delay between successive write API calls (rate limit backoff is always on)
+	if data.SafeOutputs != nil && data.SafeOutputs.ThrottleMs > 0 {
+		envVars["GH_AW_SAFE_OUTPUTS_THROTTLE_MS"] = fmt.Sprintf("\"%d\"", data.SafeOutputs.ThrottleMs)
+	}
+
 	// Set GH_AW_TARGET_REPO_SLUG - prefer trial target repo (applies to all steps)
 	// Note: Individual steps with target-repo config will override this in their step-level env
 	if c.trialMode && c.trialLogicalRepoSlug != "" {
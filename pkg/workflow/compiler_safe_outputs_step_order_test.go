@@ -0,0 +1,119 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedicatedSafeOutputStepsMatchStepIDs(t *testing.T) {
+	compiler := NewCompiler()
+	data := &WorkflowData{
+		Name: "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			AssignToAgent:            &AssignToAgentConfig{},
+			CreateAgentSessions:      &CreateAgentSessionConfig{},
+			CreateGists:              &CreateGistConfig{},
+			CreateReleases:           &CreateReleaseConfig{},
+			CreatePullRequestReviews: &CreatePullRequestReviewConfig{Event: "COMMENT"},
+		},
+	}
+
+	for _, dedicated := range dedicatedSafeOutputSteps {
+		assert.True(t, dedicated.enabled(data.SafeOutputs), "%s should be enabled", dedicated.id)
+		assert.Equal(t, dedicated.id, dedicated.build(compiler, data, string(constants.AgentJobName), false).StepID,
+			"Step ID should match the canonical order entry")
+	}
+}
+
+func TestBuildConsolidatedSafeOutputsJobCanonicalStepOrder(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.jobManager = NewJobManager()
+
+	workflowData := &WorkflowData{
+		Name: "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			CreatePullRequestReviews: &CreatePullRequestReviewConfig{Event: "COMMENT"},
+			CreateReleases:           &CreateReleaseConfig{},
+			CreateGists:              &CreateGistConfig{},
+			CreateAgentSessions:      &CreateAgentSessionConfig{},
+			AssignToAgent:            &AssignToAgentConfig{},
+			CreateIssues:             &CreateIssuesConfig{},
+		},
+	}
+
+	job, stepNames, err := compiler.buildConsolidatedSafeOutputsJob(workflowData, string(constants.AgentJobName), "test.md")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, []string{"process_safe_outputs", "assign_to_agent", "create_agent_session", "create_gist", "create_release", "create_pull_request_review"}, stepNames,
+		"Steps should follow the canonical order")
+	assert.Equal(t, "${{ steps.create_gist.outputs.gist_url }}", job.Outputs["create_gist_gist_url"])
+	assert.Equal(t, "${{ steps.assign_to_agent.outputs.assignment_error_count }}", job.Outputs["assign_to_agent_assignment_error_count"])
+}
+
+func TestCompileSafeOutputsStepOrderIsStable(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-outputs-step-order-test")
+
+	frontmatter := func(safeOutputs string) string {
+		return `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+` + safeOutputs + `---
+
+# Release Notes
+
+Publish the release notes.
+`
+	}
+	// The same configuration with safe output types listed in different orders
+	variants := []string{
+		"  create-issue:\n  add-comment:\n  create-gist:\n  create-release:\n  create-agent-session:\n",
+		"  create-release:\n  create-agent-session:\n  create-gist:\n  add-comment:\n  create-issue:\n",
+	}
+
+	stepIDPattern := regexp.MustCompile(`(?m)^\s+id: (\S+)$`)
+	var lockContents []string
+	var stepOrders [][]string
+	for i, variant := range append(variants, variants[0]) {
+		workflowFile := filepath.Join(tmpDir, "release-notes.md")
+		require.NoError(t, os.WriteFile(workflowFile, []byte(frontmatter(variant)), 0644), "Should write workflow %d", i)
+		require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "Workflow %d should compile", i)
+
+		lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+		require.NoError(t, err, "Should read lock file %d", i)
+		safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+		require.NotEmpty(t, safeOutputsJob, "Lock file %d should have a safe_outputs job", i)
+
+		var stepIDs []string
+		for _, match := range stepIDPattern.FindAllStringSubmatch(safeOutputsJob, -1) {
+			stepIDs = append(stepIDs, match[1])
+		}
+		lockContents = append(lockContents, string(lockContent))
+		stepOrders = append(stepOrders, stepIDs)
+	}
+
+	assert.Equal(t, lockContents[0], lockContents[2], "Compiling the same workflow twice should yield identical lock files")
+	assert.Equal(t, stepOrders[0], stepOrders[1], "Step order should not depend on the order of safe output types")
+
+	canonical := []string{"process_safe_outputs", "create_agent_session", "create_gist", "create_release"}
+	var safeOutputSteps []string
+	for _, stepID := range stepOrders[0] {
+		if slices.Contains(canonical, stepID) {
+			safeOutputSteps = append(safeOutputSteps, stepID)
+		}
+	}
+	assert.Equal(t, canonical, safeOutputSteps, "Steps should follow the canonical order")
+}
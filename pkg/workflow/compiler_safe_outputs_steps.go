@@ -294,6 +294,14 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) []string {
 		steps = append(steps, fmt.Sprintf("          GH_AW_PROJECT_GITHUB_TOKEN: %s\n", projectToken))
 	}
 
+	// Team entries in assign-to-user.allowed are resolved with the assign-to-user token when one is set,
+	// since the step's github-token may not be able to read org team membership
+	if data.SafeOutputs.AssignToUser != nil && data.SafeOutputs.AssignToUser.GitHubToken != "" {
+		if _, teams := splitAssignToUserAllowed(data.SafeOutputs.AssignToUser.Allowed); len(teams) > 0 {
+			steps = append(steps, fmt.Sprintf("          GH_AW_ASSIGN_TO_USER_GITHUB_TOKEN: %s\n", data.SafeOutputs.AssignToUser.GitHubToken))
+		}
+	}
+
 	// With section for github-token
 	// Use the standard safe outputs token for all operations.
 	// If project operations are configured, prefer the project token for the github-script client.
@@ -1349,24 +1349,28 @@ func TestApplyDefaultToolsComplexScenarios(t *testing.T) {
 
 // TestParseOnSectionReactionMapFormat tests reaction with map format
 func TestParseOnSectionReactionMapFormat(t *testing.T) {
-	// This test covers the case where reaction might be provided as a map
-	// though the current implementation expects string or int
 	c := &Compiler{}
 	workflowData := &WorkflowData{}
 
-	// Test that invalid type (map) is handled
 	frontmatter := map[string]any{
 		"on": map[string]any{
 			"reaction": map[string]any{
-				"type": "heart",
+				"type":               "heart",
+				"remove-on-complete": true,
 			},
 		},
 	}
 
 	err := c.parseOnSection(frontmatter, workflowData, "/path/to/test.md")
-
-	// The parseReactionValue function should return an error for map type
-	assert.Error(t, err, "Should error on map type reaction")
+	require.NoError(t, err, "Map format reaction should be accepted")
+	assert.Equal(t, "heart", workflowData.AIReaction)
+	require.NotNil(t, workflowData.ReactionCleanup)
+	assert.True(t, workflowData.ReactionCleanup.RemoveOnComplete)
+
+	// Invalid reaction type inside the map is still rejected
+	frontmatter["on"] = map[string]any{"reaction": map[string]any{"type": "thumbsup"}}
+	err = c.parseOnSection(frontmatter, &WorkflowData{}, "/path/to/test.md")
+	assert.Error(t, err, "Should error on invalid reaction type in map format")
 }
 
 // TestCompilerNeedsGitCommandsAllOutputTypes tests all safe output types for git command requirements
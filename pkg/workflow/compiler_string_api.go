@@ -3,7 +3,9 @@ package workflow
 import (
 	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/github/gh-aw/pkg/parser"
@@ -81,6 +83,11 @@ func (c *Compiler) ParseWorkflowString(content string, virtualPath string) (*Wor
 		return nil, err
 	}
 
+	// Compile the typed inputs section into workflow_dispatch inputs
+	if err := c.preprocessWorkflowInputs(result.Frontmatter); err != nil {
+		return nil, formatCompilerError(cleanPath, "error", err.Error(), err)
+	}
+
 	frontmatterForValidation := c.copyFrontmatterWithoutInternalMarkers(result.Frontmatter)
 
 	// Check if shared workflow (no 'on' field)
@@ -158,3 +165,79 @@ func (c *Compiler) ParseWorkflowString(content string, virtualPath string) (*Wor
 
 	return workflowData, nil
 }
+
+// CompileFromReader compiles workflow markdown read from r and returns the generated lock file
+// content without reading the workflow from disk or writing the lock file. This lets CI pipelines
+// compile generated markdown from a pipe. name is the workflow file name (e.g. "triage.md") and
+// determines the workflow ID and lock file references.
+//
+// Imports and @include directives are resolved relative to the directory configured with
+// WithSourceBaseDir; without a base directory they are rejected. The prompt is always inlined
+// because the markdown does not exist in the repository for runtime-import at run time.
+func (c *Compiler) CompileFromReader(r io.Reader, name string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", errors.New("workflow name is required when compiling from a reader")
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read workflow content: %w", err)
+	}
+
+	markdownPath := filepath.Clean(name)
+	if c.sourceBaseDir != "" {
+		markdownPath = filepath.Join(c.sourceBaseDir, filepath.Base(name))
+	}
+	log.Printf("CompileFromReader: compiling %d bytes as %s", len(content), markdownPath)
+
+	c.markdownPath = markdownPath
+	c.contentOverride = string(content)
+	previousInlinePrompt := c.inlinePrompt
+	c.inlinePrompt = true
+	defer func() {
+		c.contentOverride = ""
+		c.inlinePrompt = previousInlinePrompt
+	}()
+
+	workflowData, err := c.parseReaderContent(markdownPath, content)
+	if err != nil {
+		// Check if this is already a formatted console error
+		if strings.Contains(err.Error(), ":") && (strings.Contains(err.Error(), "error:") || strings.Contains(err.Error(), "warning:")) {
+			return "", err
+		}
+		return "", formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	return c.compileParsedWorkflowToYAML(workflowData, markdownPath)
+}
+
+// parseReaderContent parses workflow content passed to CompileFromReader through the same
+// pipeline as ParseWorkflowFile
+func (c *Compiler) parseReaderContent(markdownPath string, content []byte) (*WorkflowData, error) {
+	frontmatterStart := time.Now()
+	parseResult, err := c.parseFrontmatterContent(markdownPath, content)
+	if err != nil {
+		return nil, err
+	}
+	c.tracePhase(PhaseFrontmatter, time.Since(frontmatterStart))
+	if c.sourceBaseDir == "" {
+		if err := validateNoImportsWithoutBaseDir(parseResult.frontmatterResult); err != nil {
+			return nil, err
+		}
+	}
+	return c.parseWorkflowContent(parseResult)
+}
+
+// validateNoImportsWithoutBaseDir rejects imports and include directives in content that has no
+// directory to resolve them against
+func validateNoImportsWithoutBaseDir(result *parser.FrontmatterResult) error {
+	const hint = "imports cannot be resolved when compiling from stdin without a base directory; set a base directory or inline the imported content"
+	if _, hasImports := result.Frontmatter["imports"]; hasImports {
+		return fmt.Errorf("frontmatter 'imports' found: %s", hint)
+	}
+	for line := range strings.SplitSeq(result.Markdown, "\n") {
+		if directive := parser.ParseImportDirective(line); directive != nil {
+			return fmt.Errorf("%q found: %s", strings.TrimSpace(line), hint)
+		}
+	}
+	return nil
+}
@@ -3,8 +3,13 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/testutil"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -295,3 +300,78 @@ Handle issue ${{ github.event.issue.number }} in repo ${{ github.repository }}.
 	assert.Contains(t, yamlOutput, "Handle issue", "compiled YAML should contain the prompt text")
 	assert.NotContains(t, yamlOutput, "{{#runtime-import", "compiled YAML from string API should not contain runtime-import macros")
 }
+
+func TestCompileFromReader_SelfContainedWorkflow(t *testing.T) {
+	markdown := `---
+on:
+  issues:
+    types: [opened]
+engine: copilot
+permissions:
+  contents: read
+safe-outputs:
+  add-comment:
+---
+
+# Triage
+
+Triage issue #${{ github.event.issue.number }} and leave a comment.
+`
+	tmpDir := testutil.TempDir(t, "reader-compile")
+	t.Chdir(tmpDir)
+
+	compiler := NewCompiler()
+	yamlContent, err := compiler.CompileFromReader(strings.NewReader(markdown), "triage.md")
+	require.NoError(t, err)
+
+	assert.Contains(t, yamlContent, "name: \"Triage\"")
+	assert.Contains(t, yamlContent, "Triage issue #", "prompt should be inlined since the markdown is not on disk")
+	assert.NotContains(t, yamlContent, "{{#runtime-import", "prompt should not be runtime-imported")
+	assert.Contains(t, yamlContent, `"frontmatter_hash":"`, "hash should be computed from the in-memory content")
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "compiling from a reader should not write files")
+}
+
+func TestCompileFromReader_Imports(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "reader-imports")
+	sharedDir := filepath.Join(tmpDir, "shared")
+	require.NoError(t, os.MkdirAll(sharedDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "guidelines.md"), []byte("---\n---\n\nFollow the team guidelines.\n"), 0644))
+
+	markdown := `---
+on: workflow_dispatch
+engine: copilot
+imports:
+  - shared/guidelines.md
+---
+
+# Review
+
+Review the repository.
+`
+
+	_, err := NewCompiler().CompileFromReader(strings.NewReader(markdown), "review.md")
+	require.Error(t, err, "imports should be rejected without a base directory")
+	assert.Contains(t, err.Error(), "without a base directory")
+
+	includeMarkdown := "---\non: workflow_dispatch\nengine: copilot\n---\n\n# Review\n\n@include shared/guidelines.md\n"
+	_, err = NewCompiler().CompileFromReader(strings.NewReader(includeMarkdown), "review.md")
+	require.Error(t, err, "include directives should be rejected without a base directory")
+	assert.Contains(t, err.Error(), "@include shared/guidelines.md")
+
+	yamlContent, err := NewCompiler(WithSourceBaseDir(tmpDir)).CompileFromReader(strings.NewReader(markdown), "review.md")
+	require.NoError(t, err)
+	assert.Contains(t, yamlContent, "{{#runtime-import shared/guidelines.md}}", "imports should resolve relative to the base directory")
+}
+
+func TestCompileFromReader_Errors(t *testing.T) {
+	_, err := NewCompiler().CompileFromReader(strings.NewReader("---\non: push\n---\n# Test\n"), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "name is required")
+
+	_, err = NewCompiler().CompileFromReader(strings.NewReader("# No frontmatter\n"), "plain.md")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "frontmatter")
+}
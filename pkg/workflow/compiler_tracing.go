@@ -0,0 +1,20 @@
+package workflow
+
+import "time"
+
+// Compilation phases reported to the tracer registered with WithTracer. They follow the five
+// phases of the compilation process described in the package documentation.
+const (
+	PhaseFrontmatter = "frontmatter" // Reading the file and parsing its frontmatter
+	PhaseMarkdown    = "markdown"    // Expanding includes and imports into the prompt markdown
+	PhaseTools       = "tools"       // Engine, imports, tools, MCP servers and safe outputs configuration
+	PhaseJobs        = "jobs"        // Building the jobs and validating their dependencies
+	PhaseYAML        = "yaml"        // Rendering the lock file YAML
+)
+
+// tracePhase reports the duration of a completed compilation phase to the registered tracer
+func (c *Compiler) tracePhase(phase string, dur time.Duration) {
+	if c.tracer != nil {
+		c.tracer(phase, dur)
+	}
+}
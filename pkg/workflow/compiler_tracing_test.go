@@ -0,0 +1,44 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWorkflowWithTracer(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compiler-tracer-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+---
+
+# Test Workflow
+
+Summarize the repository activity.
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	durations := make(map[string][]time.Duration)
+	compiler := NewCompiler(WithTracer(func(phase string, dur time.Duration) {
+		durations[phase] = append(durations[phase], dur)
+	}))
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+
+	phases := []string{PhaseFrontmatter, PhaseMarkdown, PhaseTools, PhaseJobs, PhaseYAML}
+	assert.Len(t, durations, len(phases), "only the five compilation phases should be reported")
+	for _, phase := range phases {
+		require.Len(t, durations[phase], 1, "phase %s should be reported exactly once", phase)
+		assert.GreaterOrEqual(t, durations[phase][0], time.Duration(0), "phase %s should report a non-negative duration", phase)
+	}
+}
@@ -1,8 +1,11 @@
 package workflow
 
 import (
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
 )
@@ -52,6 +55,12 @@ func WithStrictMode(strict bool) CompilerOption {
 	return func(c *Compiler) { c.strictMode = strict }
 }
 
+// WithStrictNetworkExplicit configures whether strict mode also rejects the broad "defaults"
+// network profile and requires an explicit allowlist. It has no effect unless strict mode is on.
+func WithStrictNetworkExplicit(explicit bool) CompilerOption {
+	return func(c *Compiler) { c.strictNetworkExplicit = explicit }
+}
+
 // WithFailFast configures whether to stop at first validation error
 func WithFailFast(failFast bool) CompilerOption {
 	return func(c *Compiler) { c.failFast = failFast }
@@ -77,6 +86,23 @@ func WithGitRoot(gitRoot string) CompilerOption {
 	return func(c *Compiler) { c.gitRoot = gitRoot }
 }
 
+// WithActionResolver registers a custom source for the SHA pins of actions referenced by
+// workflows, such as an internal mirror. The resolver is consulted before the actions-lock.json
+// cache and the GitHub API; when it returns ErrActionNotFound, the default resolution is used.
+func WithActionResolver(resolver ActionResolverFunc) CompilerOption {
+	return func(c *Compiler) { c.customActionResolver = resolver }
+}
+
+// WithTarget sets the CI system workflows are compiled for (see CompileTarget)
+func WithTarget(target CompileTarget) CompilerOption {
+	return func(c *Compiler) { c.target = target }
+}
+
+// WithIncremental configures whether to skip regenerating workflows whose sources are unchanged
+func WithIncremental(incremental bool) CompilerOption {
+	return func(c *Compiler) { c.incremental = incremental }
+}
+
 // WithInlinePrompt configures whether to inline markdown content directly in the compiled YAML
 // instead of using runtime-import macros. This is required for Wasm/browser builds where
 // the filesystem is unavailable at runtime.
@@ -84,6 +110,18 @@ func WithInlinePrompt(inline bool) CompilerOption {
 	return func(c *Compiler) { c.inlinePrompt = inline }
 }
 
+// WithSourceBaseDir sets the directory used to resolve imports and @include directives when
+// compiling workflow content that does not come from a file (see CompileFromReader)
+func WithSourceBaseDir(dir string) CompilerOption {
+	return func(c *Compiler) { c.sourceBaseDir = dir }
+}
+
+// WithTracer registers a callback that receives the duration of each compilation phase
+// (see the Phase* constants), so callers can wire compile timings into their own telemetry
+func WithTracer(tracer func(phase string, dur time.Duration)) CompilerOption {
+	return func(c *Compiler) { c.tracer = tracer }
+}
+
 // FileTracker interface for tracking files created during compilation
 type FileTracker interface {
 	TrackCreated(filePath string)
@@ -104,43 +142,58 @@ func GetDefaultVersion() string {
 	return defaultVersion
 }
 
-// Compiler handles converting markdown workflows to GitHub Actions YAML
+// Compiler handles converting markdown workflows to GitHub Actions YAML.
+//
+// A single Compiler can compile many workflows sequentially. Per-workflow state (jobs, step
+// order, artifacts, schedule formats) is reset at the start of every compilation, while the
+// action pin cache, the import cache and the configuration set through options are shared
+// across workflows. Warning counts and schedule warnings accumulate across compilations until
+// Reset or ResetWarningCount is called. A Compiler is not safe for concurrent use.
 type Compiler struct {
 	verbose                 bool
 	quiet                   bool // If true, suppress success messages (for interactive mode)
 	engineOverride          string
-	customOutput            string              // If set, output will be written to this path instead of default location
-	version                 string              // Version of the extension
-	skipValidation          bool                // If true, skip schema validation
-	noEmit                  bool                // If true, validate without generating lock files
-	strictMode              bool                // If true, enforce strict validation requirements
-	trialMode               bool                // If true, suppress safe outputs for trial mode execution
-	trialLogicalRepoSlug    string              // If set in trial mode, the logical repository to checkout
-	refreshStopTime         bool                // If true, regenerate stop-after times instead of preserving existing ones
-	forceRefreshActionPins  bool                // If true, clear action cache and resolve all actions from GitHub API
-	failFast                bool                // If true, stop at first validation error instead of collecting all errors
-	actionCacheCleared      bool                // Tracks if action cache has already been cleared (for forceRefreshActionPins)
-	markdownPath            string              // Path to the markdown file being compiled (for context in dynamic tool generation)
-	actionMode              ActionMode          // Mode for generating JavaScript steps (inline vs custom actions)
-	actionTag               string              // Override action SHA or tag for actions/setup (when set, overrides actionMode to release)
-	jobManager              *JobManager         // Manages jobs and dependencies
-	engineRegistry          *EngineRegistry     // Registry of available agentic engines
-	fileTracker             FileTracker         // Optional file tracker for tracking created files
-	warningCount            int                 // Number of warnings encountered during compilation
-	stepOrderTracker        *StepOrderTracker   // Tracks step ordering for validation
-	actionCache             *ActionCache        // Shared cache for action pin resolutions across all workflows
-	actionResolver          *ActionResolver     // Shared resolver for action pins across all workflows
-	actionPinWarnings       map[string]bool     // Shared cache of already-warned action pin failures (key: "repo@version")
-	importCache             *parser.ImportCache // Shared cache for imported workflow files
-	workflowIdentifier      string              // Identifier for the current workflow being compiled (for schedule scattering)
-	scheduleWarnings        []string            // Accumulated schedule warnings for this compiler instance
-	repositorySlug          string              // Repository slug (owner/repo) used as seed for scattering
-	artifactManager         *ArtifactManager    // Tracks artifact uploads/downloads for validation
-	scheduleFriendlyFormats map[int]string      // Maps schedule item index to friendly format string for current workflow
-	gitRoot                 string              // Git repository root directory (if set, used for action cache path)
-	contentOverride         string              // If set, use this content instead of reading from disk (for Wasm/in-memory compilation)
-	skipHeader              bool                // If true, skip ASCII art header in generated YAML (for Wasm/editor mode)
-	inlinePrompt            bool                // If true, inline markdown content in YAML instead of using runtime-import macros (for Wasm builds)
+	customOutput            string                                // If set, output will be written to this path instead of default location
+	version                 string                                // Version of the extension
+	skipValidation          bool                                  // If true, skip schema validation
+	noEmit                  bool                                  // If true, validate without generating lock files
+	strictMode              bool                                  // If true, enforce strict validation requirements
+	strictNetworkExplicit   bool                                  // If true, strict mode also rejects the "defaults" network profile
+	trialMode               bool                                  // If true, suppress safe outputs for trial mode execution
+	trialLogicalRepoSlug    string                                // If set in trial mode, the logical repository to checkout
+	refreshStopTime         bool                                  // If true, regenerate stop-after times instead of preserving existing ones
+	forceRefreshActionPins  bool                                  // If true, clear action cache and resolve all actions from GitHub API
+	failFast                bool                                  // If true, stop at first validation error instead of collecting all errors
+	actionCacheCleared      bool                                  // Tracks if action cache has already been cleared (for forceRefreshActionPins)
+	markdownPath            string                                // Path to the markdown file being compiled (for context in dynamic tool generation)
+	actionMode              ActionMode                            // Mode for generating JavaScript steps (inline vs custom actions)
+	actionTag               string                                // Override action SHA or tag for actions/setup (when set, overrides actionMode to release)
+	jobManager              *JobManager                           // Manages jobs and dependencies
+	engineRegistry          *EngineRegistry                       // Registry of available agentic engines
+	fileTracker             FileTracker                           // Optional file tracker for tracking created files
+	warningCount            int                                   // Number of warnings encountered during compilation
+	warnings                []string                              // Messages of the warnings counted in warningCount
+	stepOrderTracker        *StepOrderTracker                     // Tracks step ordering for validation
+	actionCache             *ActionCache                          // Shared cache for action pin resolutions across all workflows
+	actionResolver          *ActionResolver                       // Shared resolver for action pins across all workflows
+	customActionResolver    ActionResolverFunc                    // Optional caller-supplied source of action pins
+	actionPinWarnings       map[string]bool                       // Shared cache of already-warned action pin failures (key: "repo@version")
+	importCache             *parser.ImportCache                   // Shared cache for imported workflow files
+	workflowIdentifier      string                                // Identifier for the current workflow being compiled (for schedule scattering)
+	scheduleWarnings        []string                              // Accumulated schedule warnings for this compiler instance
+	repositorySlug          string                                // Repository slug (owner/repo) used as seed for scattering
+	artifactManager         *ArtifactManager                      // Tracks artifact uploads/downloads for validation
+	scheduleFriendlyFormats map[int]string                        // Maps schedule item index to friendly format string for current workflow
+	gitRoot                 string                                // Git repository root directory (if set, used for action cache path)
+	contentOverride         string                                // If set, use this content instead of reading from disk (for Wasm/in-memory compilation)
+	skipHeader              bool                                  // If true, skip ASCII art header in generated YAML (for Wasm/editor mode)
+	inlinePrompt            bool                                  // If true, inline markdown content in YAML instead of using runtime-import macros (for Wasm builds)
+	incremental             bool                                  // If true, skip regeneration when the source hash matches the recorded sidecar
+	explain                 bool                                  // If true, annotate the generated YAML with comments naming the frontmatter that produced each section
+	skippedCount            int                                   // Number of workflows skipped because they were up to date (incremental mode)
+	sourceBaseDir           string                                // Directory for resolving imports of content compiled from a reader
+	tracer                  func(phase string, dur time.Duration) // Optional callback receiving compilation phase timings
+	target                  CompileTarget                         // CI system to compile for (empty means GitHub Actions)
 }
 
 // NewCompiler creates a new workflow compiler with functional options.
@@ -203,6 +256,21 @@ func (c *Compiler) SetNoEmit(noEmit bool) {
 	c.noEmit = noEmit
 }
 
+// SetIncremental configures whether to skip regenerating workflows whose sources are unchanged
+func (c *Compiler) SetIncremental(incremental bool) {
+	c.incremental = incremental
+}
+
+// SetExplain configures whether to annotate the generated YAML with source provenance comments
+func (c *Compiler) SetExplain(explain bool) {
+	c.explain = explain
+}
+
+// GetSkippedCount returns the number of workflows skipped as up to date in incremental mode
+func (c *Compiler) GetSkippedCount() int {
+	return c.skippedCount
+}
+
 // SetFileTracker sets the file tracker for tracking created files
 func (c *Compiler) SetFileTracker(tracker FileTracker) {
 	c.fileTracker = tracker
@@ -218,6 +286,11 @@ func (c *Compiler) SetTrialLogicalRepoSlug(repo string) {
 	c.trialLogicalRepoSlug = repo
 }
 
+// SetTarget configures the CI system workflows are compiled for
+func (c *Compiler) SetTarget(target CompileTarget) {
+	c.target = target
+}
+
 // SetStrictMode configures whether to enable strict validation mode
 func (c *Compiler) SetStrictMode(strict bool) {
 	c.strictMode = strict
@@ -258,8 +331,16 @@ func (c *Compiler) GetVersion() string {
 	return c.version
 }
 
-// IncrementWarningCount increments the warning counter
-func (c *Compiler) IncrementWarningCount() {
+// addWarning prints a warning to stderr and records it for the compile report
+func (c *Compiler) addWarning(message string) {
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(message))
+	c.recordWarning(message)
+}
+
+// recordWarning counts a warning and keeps its message for the compile report without printing
+// it, for warnings printed with file context or displayed later by the caller
+func (c *Compiler) recordWarning(message string) {
+	c.warnings = append(c.warnings, message)
 	c.warningCount++
 }
 
@@ -268,9 +349,27 @@ func (c *Compiler) GetWarningCount() int {
 	return c.warningCount
 }
 
-// ResetWarningCount resets the warning counter to zero
+// ResetWarningCount resets the warning counter to zero and clears the recorded warnings
 func (c *Compiler) ResetWarningCount() {
 	c.warningCount = 0
+	c.warnings = nil
+}
+
+// Reset clears the state accumulated by previous compilations so the compiler can be reused
+// for an unrelated batch of workflows: warning counts, schedule warnings, the incremental skip
+// count and the state of the last compiled workflow. Configuration set through options and the
+// shared action pin and import caches are kept, so reusing a compiler avoids reloading them.
+func (c *Compiler) Reset() {
+	logTypes.Print("Resetting compiler state")
+	c.warningCount = 0
+	c.warnings = nil
+	c.scheduleWarnings = nil
+	c.skippedCount = 0
+	c.markdownPath = ""
+	c.contentOverride = ""
+	c.jobManager = NewJobManager()
+	c.jobManager.SetExplain(c.explain)
+	c.resetCompilationState()
 }
 
 // SetWorkflowIdentifier sets the identifier for the current workflow being compiled
@@ -325,6 +424,7 @@ func (c *Compiler) getSharedActionResolver() (*ActionCache, *ActionResolver) {
 		}
 
 		c.actionResolver = NewActionResolver(c.actionCache)
+		c.actionResolver.custom = c.customActionResolver
 		logTypes.Print("Initialized shared action cache and resolver for compiler")
 	} else if c.forceRefreshActionPins && !c.actionCacheCleared {
 		// If cache already exists but force refresh is set and we haven't cleared it yet, clear it once
@@ -405,6 +505,8 @@ type WorkflowData struct {
 	Permissions           string
 	Network               string // top-level network permissions configuration
 	Concurrency           string // workflow-level concurrency configuration
+	ConcurrencyTemplate   string // concurrency.group-template, rendered with the gh-aw- prefix
+	ConcurrencyCancel     *bool  // explicit concurrency.cancel-in-progress for generated groups
 	RunName               string
 	Env                   string
 	If                    string
@@ -415,6 +517,7 @@ type WorkflowData struct {
 	Environment           string // environment setting for the main job
 	Container             string // container setting for the main job
 	Services              string // services setting for the main job
+	Strategy              string // strategy (matrix) setting for the main job
 	Tools                 map[string]any
 	ParsedTools           *Tools // Structured tools configuration (NEW: parsed from Tools map)
 	MarkdownContent       string
@@ -433,6 +536,7 @@ type WorkflowData struct {
 	CommandEvents         []string             // events where command should be active (nil = all events)
 	CommandOtherEvents    map[string]any       // for merging command with other events
 	AIReaction            string               // AI reaction type like "eyes", "heart", etc.
+	ReactionCleanup       *ReactionCleanup     // what to do with the reaction once the run completes (nil = leave it in place)
 	StatusComment         *bool                // whether to post status comments (default: true when ai-reaction is set, false otherwise)
 	LockForAgent          bool                 // whether to lock the issue during agent workflow execution
 	Jobs                  map[string]any       // custom job configurations with dependencies
@@ -445,6 +549,9 @@ type WorkflowData struct {
 	Roles                 []string             // permission levels required to trigger workflow
 	Bots                  []string             // allow list of bot identifiers that can trigger workflow
 	RateLimit             *RateLimitConfig     // rate limiting configuration for workflow triggers
+	RequiredSecrets       []RequiredSecret     // secrets declared with required: true, checked in the pre-activation job
+	ChangedFilesPatterns  [][]string           // path globs from the condition field; each group must match a changed file
+	PhaseTimeouts         *PhaseTimeouts       // per-phase step timeouts from the timeouts field
 	CacheMemoryConfig     *CacheMemoryConfig   // parsed cache-memory configuration
 	RepoMemoryConfig      *RepoMemoryConfig    // parsed repo-memory configuration
 	Runtimes              map[string]any       // runtime version overrides from frontmatter
@@ -484,6 +591,7 @@ type SafeOutputsConfig struct {
 	CreatePullRequests              *CreatePullRequestsConfig              `yaml:"create-pull-requests,omitempty"`
 	CreatePullRequestReviewComments *CreatePullRequestReviewCommentsConfig `yaml:"create-pull-request-review-comments,omitempty"`
 	SubmitPullRequestReview         *SubmitPullRequestReviewConfig         `yaml:"submit-pull-request-review,omitempty"`           // Submit a PR review with status (APPROVE, REQUEST_CHANGES, COMMENT)
+	CreatePullRequestReviews        *CreatePullRequestReviewConfig         `yaml:"create-pull-request-review,omitempty"`           // Submit complete PR reviews with an event, body and inline comments
 	ReplyToPullRequestReviewComment *ReplyToPullRequestReviewCommentConfig `yaml:"reply-to-pull-request-review-comment,omitempty"` // Reply to existing review comments on PRs
 	ResolvePullRequestReviewThread  *ResolvePullRequestReviewThreadConfig  `yaml:"resolve-pull-request-review-thread,omitempty"`   // Resolve a review thread on a pull request
 	CreateCodeScanningAlerts        *CreateCodeScanningAlertsConfig        `yaml:"create-code-scanning-alerts,omitempty"`
@@ -493,6 +601,7 @@ type SafeOutputsConfig struct {
 	AddReviewer                     *AddReviewerConfig                     `yaml:"add-reviewer,omitempty"`
 	AssignMilestone                 *AssignMilestoneConfig                 `yaml:"assign-milestone,omitempty"`
 	AssignToAgent                   *AssignToAgentConfig                   `yaml:"assign-to-agent,omitempty"`
+	AssignToCopilot                 bool                                   `yaml:"assign-to-copilot,omitempty"`  // Shorthand expanded into AssignToAgent with copilot as the default agent
 	AssignToUser                    *AssignToUserConfig                    `yaml:"assign-to-user,omitempty"`     // Assign users to issues
 	UnassignFromUser                *UnassignFromUserConfig                `yaml:"unassign-from-user,omitempty"` // Remove assignees from issues
 	UpdateIssues                    *UpdateIssuesConfig                    `yaml:"update-issues,omitempty"`
@@ -501,6 +610,8 @@ type SafeOutputsConfig struct {
 	UploadAssets                    *UploadAssetsConfig                    `yaml:"upload-asset,omitempty"`
 	UpdateRelease                   *UpdateReleaseConfig                   `yaml:"update-release,omitempty"`               // Update GitHub release descriptions
 	CreateAgentSessions             *CreateAgentSessionConfig              `yaml:"create-agent-session,omitempty"`         // Create GitHub Copilot coding agent sessions
+	CreateGists                     *CreateGistConfig                      `yaml:"create-gist,omitempty"`                  // Publish standalone reports as GitHub Gists
+	CreateReleases                  *CreateReleaseConfig                   `yaml:"create-release,omitempty"`               // Create GitHub releases (draft by default)
 	UpdateProjects                  *UpdateProjectConfig                   `yaml:"update-project,omitempty"`               // Smart project board management (create/add/update)
 	CreateProjects                  *CreateProjectsConfig                  `yaml:"create-project,omitempty"`               // Create GitHub Projects V2
 	CreateProjectStatusUpdates      *CreateProjectStatusUpdateConfig       `yaml:"create-project-status-update,omitempty"` // Create GitHub project status updates
@@ -516,6 +627,9 @@ type SafeOutputsConfig struct {
 	AllowedDomains                  []string                               `yaml:"allowed-domains,omitempty"`
 	AllowGitHubReferences           []string                               `yaml:"allowed-github-references,omitempty"` // Allowed repositories for GitHub references (e.g., ["repo", "org/repo2"])
 	Staged                          bool                                   `yaml:"staged,omitempty"`                    // If true, emit step summary messages instead of making GitHub API calls
+	DryRun                          bool                                   `yaml:"dry-run,omitempty"`                   // If true, log sanitized payloads to the step summary and an artifact instead of executing safe outputs
+	MaxTotal                        int                                    `yaml:"max-total,omitempty"`                 // Maximum number of safe output operations across all types (0 = unlimited)
+	ThrottleMs                      int                                    `yaml:"throttle-ms,omitempty"`               // Delay in milliseconds between successive write API calls (0 = no delay)
 	Env                             map[string]string                      `yaml:"env,omitempty"`                       // Environment variables to pass to safe output jobs
 	GitHubToken                     string                                 `yaml:"github-token,omitempty"`              // GitHub token for safe output jobs
 	MaximumPatchSize                int                                    `yaml:"max-patch-size,omitempty"`            // Maximum allowed patch size in KB (defaults to 1024)
@@ -0,0 +1,144 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var validateOnlyLog = logger.New("workflow:compiler_validate_only")
+
+// ValidateWorkflow reports whether a workflow would compile without writing any files.
+//
+// It runs the same pipeline as CompileWorkflow: parsing and frontmatter schema validation,
+// strict-mode checks (validateStrictMode), MCP configuration checks (ValidateMCPConfigs),
+// safe-outputs and permission validation, YAML generation, and the checks applied to the
+// generated YAML. Errors from the post-parse stages are aggregated instead of stopping at
+// the first one, unless fail-fast is enabled. Neither the .lock.yml file nor the
+// .invalid.yml debugging artifact is written.
+//
+// Validation that needs network access (container images, runtime packages and
+// repository features) is skipped.
+func (c *Compiler) ValidateWorkflow(markdownPath string) error {
+	validateOnlyLog.Printf("Validating workflow without emitting: %s", markdownPath)
+	c.markdownPath = markdownPath
+
+	// Parsing runs frontmatter, strict-mode, MCP and safe-outputs validation; later
+	// stages depend on the parsed data, so a parse failure is returned directly
+	workflowData, err := c.ParseWorkflowFile(markdownPath)
+	if err != nil {
+		if strings.Contains(err.Error(), ":") && (strings.Contains(err.Error(), "error:") || strings.Contains(err.Error(), "warning:")) {
+			return err
+		}
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	c.resetCompilationState()
+	collector := NewErrorCollector(c.failFast)
+
+	if err := c.validateWorkflowData(workflowData, markdownPath); err != nil {
+		if returnErr := collector.Add(err); returnErr != nil {
+			return returnErr
+		}
+	}
+
+	yamlContent, err := c.generateYAML(workflowData, markdownPath)
+	if err != nil {
+		if returnErr := collector.Add(formatCompilerError(markdownPath, "error", fmt.Sprintf("failed to generate YAML: %v", err), err)); returnErr != nil {
+			return returnErr
+		}
+		// The generated YAML checks below cannot run without YAML
+		return collector.FormattedError("validation")
+	}
+
+	for _, check := range c.generatedYAMLChecks(workflowData, yamlContent) {
+		if check.requiresNetwork {
+			continue
+		}
+		validateOnlyLog.Print(check.description)
+		if err := check.validate(); err != nil {
+			if returnErr := collector.Add(formatCompilerError(markdownPath, "error", check.prefix+err.Error(), err)); returnErr != nil {
+				return returnErr
+			}
+		}
+	}
+
+	if collector.HasErrors() {
+		validateOnlyLog.Printf("Validation found %d errors: %s", collector.Count(), markdownPath)
+		return collector.FormattedError("validation")
+	}
+
+	validateOnlyLog.Printf("Workflow is valid: %s", markdownPath)
+	return nil
+}
+
+// generatedYAMLCheck is a validation applied to the generated workflow YAML
+type generatedYAMLCheck struct {
+	description string // logged before the check runs
+	prefix      string // prepended to the error message
+	// invalidYAMLNotice, when set, makes compilation write the rejected YAML to a
+	// .invalid.yml file and print this notice with its path
+	invalidYAMLNotice string
+	requiresNetwork   bool // skipped by ValidateWorkflow
+	warningOnly       bool // failures are reported as warnings instead of errors
+	validate          func() error
+}
+
+// generatedYAMLChecks returns the checks applied to the generated YAML, in the order
+// generateAndValidateYAML runs them
+func (c *Compiler) generatedYAMLChecks(workflowData *WorkflowData, yamlContent string) []generatedYAMLCheck {
+	checks := []generatedYAMLCheck{
+		// Expression sizes are a hard limit from GitHub Actions (21KB) that cannot be
+		// bypassed, so they are validated unconditionally
+		{
+			description:       "Validating expression sizes",
+			prefix:            "expression size validation failed: ",
+			invalidYAMLNotice: "Invalid workflow YAML written to: ",
+			validate:          func() error { return c.validateExpressionSizes(yamlContent) },
+		},
+		// Detect unsafe expression usage in run: commands
+		{
+			description:       "Validating for template injection vulnerabilities",
+			invalidYAMLNotice: "Workflow with template injection risks written to: ",
+			validate:          func() error { return validateNoTemplateInjection(yamlContent) },
+		},
+	}
+	if c.skipValidation {
+		return checks
+	}
+	return append(checks,
+		generatedYAMLCheck{
+			description:       "Validating workflow against GitHub Actions schema",
+			prefix:            "workflow schema validation failed: ",
+			invalidYAMLNotice: "Invalid workflow YAML written to: ",
+			validate:          func() error { return c.validateGitHubActionsSchema(yamlContent) },
+		},
+		// Container image failures are warnings because they may be caused by local
+		// auth issues (e.g., private registries)
+		generatedYAMLCheck{
+			description:     "Validating container images",
+			prefix:          "container image validation failed: ",
+			requiresNetwork: true,
+			warningOnly:     true,
+			validate:        func() error { return c.validateContainerImages(workflowData) },
+		},
+		generatedYAMLCheck{
+			description:     "Validating runtime packages",
+			prefix:          "runtime package validation failed: ",
+			requiresNetwork: true,
+			validate:        func() error { return c.validateRuntimePackages(workflowData) },
+		},
+		generatedYAMLCheck{
+			description: "Validating firewall configuration",
+			prefix:      "firewall configuration validation failed: ",
+			validate:    func() error { return c.validateFirewallConfig(workflowData) },
+		},
+		generatedYAMLCheck{
+			description:     "Validating repository features",
+			prefix:          "repository feature validation failed: ",
+			requiresNetwork: true,
+			validate:        func() error { return c.validateRepositoryFeatures(workflowData) },
+		},
+	)
+}
@@ -0,0 +1,110 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWorkflowPassesWithoutWritingFiles(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "validate-only-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+---
+
+# Valid
+
+Create an issue.
+`
+	testFile := filepath.Join(tmpDir, "valid.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	require.NoError(t, NewCompiler().ValidateWorkflow(testFile), "Valid workflow should pass validation")
+
+	lockFile := stringutil.MarkdownToLockFile(testFile)
+	assert.NoFileExists(t, lockFile, "Validation should not write a lock file")
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err, "Should list temp dir")
+	assert.Len(t, entries, 1, "Validation should not create any files")
+}
+
+func TestValidateWorkflowFailsForBrokenWorkflow(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		errContains string
+	}{
+		{
+			name: "strict mode write permissions",
+			content: `---
+on: workflow_dispatch
+permissions:
+  contents: write
+engine: copilot
+---
+
+# Broken
+`,
+			errContains: "write",
+		},
+		{
+			name: "invalid MCP configuration",
+			content: `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+tools:
+  custom:
+    type: stdio
+---
+
+# Broken
+`,
+			errContains: "custom",
+		},
+		{
+			name: "unknown frontmatter field",
+			content: `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+not-a-field: true
+---
+
+# Broken
+`,
+			errContains: "not-a-field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "validate-only-broken-test")
+			testFile := filepath.Join(tmpDir, "broken.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(tt.content), 0644), "Should write test file")
+
+			err := NewCompiler().ValidateWorkflow(testFile)
+			require.Error(t, err, "Broken workflow should fail validation")
+			assert.Contains(t, err.Error(), tt.errContains, "Error should describe the problem")
+
+			entries, err := os.ReadDir(tmpDir)
+			require.NoError(t, err, "Should list temp dir")
+			assert.Len(t, entries, 1, "Failed validation should not create any files")
+		})
+	}
+}
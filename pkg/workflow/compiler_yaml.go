@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
@@ -24,6 +25,7 @@ func (c *Compiler) buildJobsAndValidate(data *WorkflowData, markdownPath string)
 
 	// Reset job manager for this compilation
 	c.jobManager = NewJobManager()
+	c.jobManager.SetExplain(c.explain)
 
 	// Build all jobs
 	if err := c.buildJobs(data, markdownPath); err != nil {
@@ -180,16 +182,29 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 	compilerYamlLog.Printf("Generating YAML for workflow: %s", data.Name)
 
 	// Build all jobs and validate dependencies
+	jobsStart := time.Now()
 	if err := c.buildJobsAndValidate(data, markdownPath); err != nil {
 		return "", fmt.Errorf("failed to build and validate jobs: %w", err)
 	}
+	c.tracePhase(PhaseJobs, time.Since(jobsStart))
+	yamlStart := time.Now()
 
 	// Compute frontmatter hash before generating YAML
 	var frontmatterHash string
 	if markdownPath != "" {
 		baseDir := filepath.Dir(markdownPath)
 		cache := parser.NewImportCache(baseDir)
-		hash, err := parser.ComputeFrontmatterHashFromFileWithParsedFrontmatter(markdownPath, data.RawFrontmatter, cache, parser.DefaultFileReader)
+		fileReader := parser.DefaultFileReader
+		if c.contentOverride != "" {
+			// The workflow content was not read from disk; serve it from memory so the hash still covers it
+			fileReader = func(filePath string) ([]byte, error) {
+				if filePath == markdownPath {
+					return []byte(c.contentOverride), nil
+				}
+				return parser.DefaultFileReader(filePath)
+			}
+		}
+		hash, err := parser.ComputeFrontmatterHashFromFileWithParsedFrontmatter(markdownPath, data.RawFrontmatter, cache, fileReader)
 		if err != nil {
 			compilerYamlLog.Printf("Warning: failed to compute frontmatter hash: %v", err)
 			// Continue without hash - non-fatal error
@@ -219,6 +234,7 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 		yamlContent = c.replaceIssueNumberReferences(yamlContent)
 	}
 
+	c.tracePhase(PhaseYAML, time.Since(yamlStart))
 	compilerYamlLog.Printf("Successfully generated YAML for workflow: %s (%d bytes)", data.Name, len(yamlContent))
 	return yamlContent, nil
 }
@@ -350,6 +366,9 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 	// referenced in activation's step env vars.
 	expressionMappings = filterExpressionsForActivation(expressionMappings, data.Jobs, beforeActivationJobs)
 
+	// Matrix values are only available in the agent job legs, which substitute them themselves
+	expressionMappings = filterMatrixExpressions(expressionMappings)
+
 	// Step 2: Add main workflow markdown content to the prompt
 	if c.inlinePrompt || data.InlinedImports {
 		// Inline mode (Wasm/browser): embed the markdown content directly in the YAML
@@ -471,6 +490,7 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData, pre
 }
 func (c *Compiler) generatePostSteps(yaml *strings.Builder, data *WorkflowData) {
 	if data.PostSteps != "" {
+		c.writeExplainComment(yaml, "post-steps")
 		// Remove "post-steps:" line and adjust indentation, similar to CustomSteps processing
 		lines := strings.Split(data.PostSteps, "\n")
 		if len(lines) > 1 {
@@ -536,6 +556,8 @@ func (c *Compiler) generateCreateAwInfo(yaml *strings.Builder, data *WorkflowDat
 			modelEnvVar = constants.EnvVarModelAgentClaude
 		case "codex":
 			modelEnvVar = constants.EnvVarModelAgentCodex
+		case "gemini":
+			modelEnvVar = constants.EnvVarModelAgentGemini
 		case "custom":
 			modelEnvVar = constants.EnvVarModelAgentCustom
 		default:
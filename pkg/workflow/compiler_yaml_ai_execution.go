@@ -3,18 +3,25 @@ package workflow
 import (
 	"fmt"
 	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
 )
 
-// generateEngineExecutionSteps generates the GitHub Actions steps for executing the AI engine
-func (c *Compiler) generateEngineExecutionSteps(yaml *strings.Builder, data *WorkflowData, engine CodingAgentEngine, logFile string) {
+// generateEngineExecutionSteps generates the GitHub Actions steps for executing the AI engine.
+// When the workflow lists fallback engines, the steps for the whole fallback chain are generated.
+func (c *Compiler) generateEngineExecutionSteps(yaml *strings.Builder, data *WorkflowData, engine CodingAgentEngine, logFile string) error {
+	if hasEngineFallbacks(data) {
+		return c.generateEngineExecutionStepsWithFallback(yaml, data, engine, logFile)
+	}
 
-	steps := engine.GetExecutionSteps(data, logFile)
+	steps := applyAgentPhaseTimeout(data, engine.GetExecutionSteps(data, logFile))
 
 	for _, step := range steps {
 		for _, line := range step {
 			yaml.WriteString(line + "\n")
 		}
 	}
+	return nil
 }
 
 // generateLogParsing generates a step that parses the agent's logs and adds them to the step summary
@@ -106,9 +113,14 @@ func (c *Compiler) generateStopMCPGateway(yaml *strings.Builder, data *WorkflowD
 	yaml.WriteString("          MCP_GATEWAY_PORT: ${{ steps.start-mcp-gateway.outputs.gateway-port }}\n")
 	yaml.WriteString("          MCP_GATEWAY_API_KEY: ${{ steps.start-mcp-gateway.outputs.gateway-api-key }}\n")
 	yaml.WriteString("          GATEWAY_PID: ${{ steps.start-mcp-gateway.outputs.gateway-pid }}\n")
+	rotateLogs := generateMCPLogRotationEnv(yaml, data)
 
 	yaml.WriteString("        run: |\n")
 	yaml.WriteString("          bash /opt/gh-aw/actions/stop_mcp_gateway.sh \"$GATEWAY_PID\"\n")
+	if rotateLogs {
+		// Apply the rotation policy once more so the uploaded logs follow it
+		yaml.WriteString("          bash /opt/gh-aw/actions/rotate_mcp_logs.sh " + constants.DefaultMCPLogsDir + "\n")
+	}
 }
 
 // convertGoPatternToJavaScript converts a Go regex pattern to JavaScript-compatible format
@@ -154,6 +154,7 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 
 	// Add custom steps if present
 	if data.CustomSteps != "" {
+		c.writeExplainComment(yaml, "steps")
 		if customStepsContainCheckout && len(runtimeSetupSteps) > 0 {
 			// Custom steps contain checkout and we have runtime steps to insert
 			// Insert runtime steps after the first checkout step
@@ -206,6 +207,9 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	// Add engine-specific installation steps (includes Node.js setup and secret validation for npm-based engines)
 	installSteps := engine.GetInstallationSteps(data)
 	compilerYamlLog.Printf("Adding %d engine installation steps for %s", len(installSteps), engine.GetID())
+	if len(installSteps) > 0 {
+		c.writeExplainComment(yaml, "engine: "+engine.GetID())
+	}
 	for _, step := range installSteps {
 		for _, line := range step {
 			yaml.WriteString(line + "\n")
@@ -224,9 +228,18 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	c.generateGitHubMCPAppTokenMintingStep(yaml, data)
 
 	// Add MCP setup
-	if err := c.generateMCPSetup(yaml, data.Tools, engine, data); err != nil {
+	var mcpSetup strings.Builder
+	if err := c.generateMCPSetup(&mcpSetup, data.Tools, engine, data); err != nil {
 		return fmt.Errorf("failed to generate MCP setup: %w", err)
 	}
+	if mcpSetup.Len() > 0 {
+		c.writeExplainComment(yaml, "tools, mcp-servers")
+	}
+	if data.PhaseTimeouts != nil && data.PhaseTimeouts.MCPSetup > 0 {
+		yaml.WriteString(applyStepTimeoutByID(mcpSetup.String(), mcpSetupStepIDs, data.PhaseTimeouts.MCPSetup))
+	} else {
+		yaml.WriteString(mcpSetup.String())
+	}
 
 	// Stop-time safety checks are now handled by a dedicated job (stop_time_check)
 	// No longer generated in the main job steps
@@ -243,6 +256,9 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	yaml.WriteString("          name: prompt\n")
 	yaml.WriteString("          path: /tmp/gh-aw/aw-prompts\n")
 
+	// Substitute the values of this matrix leg into the prompt
+	c.generateMatrixSubstitutionStep(yaml, data)
+
 	// Collect artifact paths for unified upload at the end
 	var artifactPaths []string
 	artifactPaths = append(artifactPaths, "/tmp/gh-aw/aw-prompts/prompt.txt")
@@ -260,7 +276,10 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 
 	// Add AI execution step using the agentic engine
 	compilerYamlLog.Printf("Generating engine execution steps for %s", engine.GetID())
-	c.generateEngineExecutionSteps(yaml, data, engine, logFileFull)
+	c.writeExplainComment(yaml, engineExecutionProvenance(data, engine))
+	if err := c.generateEngineExecutionSteps(yaml, data, engine, logFileFull); err != nil {
+		return err
+	}
 
 	// Mark that we've completed agent execution - step order validation starts from here
 	compilerYamlLog.Print("Marking agent execution as complete for step order tracking")
@@ -384,6 +403,14 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 		}
 	}
 
+	// Export the firewall proxy logs to the network log when network.log or audit mode is set
+	if isNetworkLogEnabled(data.NetworkPermissions) && isFirewallEnabled(data) {
+		for _, line := range generateNetworkLogStep(data) {
+			yaml.WriteString(line + "\n")
+		}
+		artifactPaths = append(artifactPaths, networkLogPath)
+	}
+
 	// Collect agent stdio logs path for unified upload
 	artifactPaths = append(artifactPaths, logFileFull)
 
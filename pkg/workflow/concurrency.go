@@ -20,10 +20,17 @@ func GenerateConcurrencyConfig(workflowData *WorkflowData, isCommandTrigger bool
 		return workflowData.Concurrency
 	}
 
-	// Build concurrency group keys using the original workflow-specific logic
-	keys := buildConcurrencyGroupKeys(workflowData, isCommandTrigger)
-	groupValue := strings.Join(keys, "-")
-	concurrencyLog.Printf("Built concurrency group: %s", groupValue)
+	var groupValue string
+	if workflowData.ConcurrencyTemplate != "" {
+		// Use the validated group template from concurrency.group-template
+		groupValue = applyConcurrencyGroupPrefix(workflowData.ConcurrencyTemplate)
+		concurrencyLog.Printf("Using concurrency group template: %s", groupValue)
+	} else {
+		// Build concurrency group keys using the original workflow-specific logic
+		keys := buildConcurrencyGroupKeys(workflowData, isCommandTrigger)
+		groupValue = strings.Join(keys, "-")
+		concurrencyLog.Printf("Built concurrency group: %s", groupValue)
+	}
 
 	// Build the concurrency configuration
 	concurrencyConfig := fmt.Sprintf("concurrency:\n  group: \"%s\"", groupValue)
@@ -68,7 +75,12 @@ func GenerateJobConcurrencyConfig(workflowData *WorkflowData) string {
 	}
 
 	// Build the default concurrency configuration
-	groupValue := fmt.Sprintf("gh-aw-%s-${{ github.workflow }}", engineID)
+	groupValue := fmt.Sprintf("gh-aw-%s-%s", engineID, workflowConcurrencyKey(workflowData))
+	if workflowData.Strategy != "" {
+		// Matrix legs share the same workflow key, so include the leg index to keep them
+		// from queueing behind (and cancelling) each other
+		groupValue += "-${{ strategy.job-index }}"
+	}
 	concurrencyConfig := fmt.Sprintf("concurrency:\n  group: \"%s\"", groupValue)
 
 	return concurrencyConfig
@@ -125,9 +137,26 @@ func isPushWorkflow(on string) bool {
 	return strings.Contains(on, "push")
 }
 
+// isWorkflowCallWorkflow checks if a workflow's "on" section contains a workflow_call trigger
+func isWorkflowCallWorkflow(on string) bool {
+	return strings.Contains(on, "workflow_call")
+}
+
+// workflowConcurrencyKey returns the concurrency group key identifying the workflow.
+// In a reusable workflow, github.workflow evaluates to the caller's name, so a group built
+// from it alone would be shared with the caller and deadlock. Reusable workflows prefix the
+// caller's name with the workflow ID, which keeps them apart from the caller while runs from
+// different callers still get their own groups.
+func workflowConcurrencyKey(workflowData *WorkflowData) string {
+	if isWorkflowCallWorkflow(workflowData.On) && workflowData.WorkflowID != "" {
+		return workflowData.WorkflowID + "-${{ github.workflow }}"
+	}
+	return "${{ github.workflow }}"
+}
+
 // buildConcurrencyGroupKeys builds an array of keys for the concurrency group
 func buildConcurrencyGroupKeys(workflowData *WorkflowData, isCommandTrigger bool) []string {
-	keys := []string{"gh-aw", "${{ github.workflow }}"}
+	keys := []string{"gh-aw", workflowConcurrencyKey(workflowData)}
 
 	if isCommandTrigger {
 		// For command workflows: use issue/PR number
@@ -160,6 +189,12 @@ func buildConcurrencyGroupKeys(workflowData *WorkflowData, isCommandTrigger bool
 
 // shouldEnableCancelInProgress determines if cancel-in-progress should be enabled
 func shouldEnableCancelInProgress(workflowData *WorkflowData, isCommandTrigger bool) bool {
+	// An explicit concurrency.cancel-in-progress overrides the trigger-based defaults
+	if workflowData.ConcurrencyCancel != nil {
+		concurrencyLog.Printf("Using explicit cancel-in-progress: %v", *workflowData.ConcurrencyCancel)
+		return *workflowData.ConcurrencyCancel
+	}
+
 	// Never enable cancellation for command workflows
 	if isCommandTrigger {
 		return false
@@ -168,3 +203,21 @@ func shouldEnableCancelInProgress(workflowData *WorkflowData, isCommandTrigger b
 	// Enable cancellation for pull request workflows (including mixed workflows)
 	return isPullRequestWorkflow(workflowData.On)
 }
+
+// extractConcurrencyCancelOverride returns the concurrency.cancel-in-progress value when the
+// frontmatter sets it without a group. The group is then generated from the triggers and only
+// the cancellation behavior is overridden.
+func extractConcurrencyCancelOverride(frontmatter map[string]any) (*bool, bool) {
+	concurrencyMap, ok := frontmatter["concurrency"].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	if _, hasGroup := concurrencyMap["group"]; hasGroup {
+		return nil, false
+	}
+	cancel, ok := concurrencyMap["cancel-in-progress"].(bool)
+	if !ok {
+		return nil, false
+	}
+	return &cancel, true
+}
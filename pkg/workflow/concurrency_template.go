@@ -0,0 +1,179 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var concurrencyTemplateLog = logger.New("workflow:concurrency_template")
+
+// Concurrency group templates
+//
+// concurrency.group-template lets a workflow key its concurrency group on a custom
+// dimension. The template is emitted verbatim with the gh-aw- prefix applied, but only
+// a fixed set of ${{ }} placeholders is accepted so that arbitrary expressions cannot be
+// injected into the group key:
+//
+//	concurrency:
+//	  group-template: "deploy-${{ inputs.environment }}-${{ github.ref }}"
+
+// concurrencyGroupPrefix is prepended to every generated concurrency group
+const concurrencyGroupPrefix = "gh-aw-"
+
+// allowedConcurrencyTemplatePlaceholders lists the context expressions accepted in a group template
+var allowedConcurrencyTemplatePlaceholders = []string{
+	"github.workflow",
+	"github.ref",
+	"github.event.issue.number",
+}
+
+var concurrencyTemplatePlaceholderPattern = regexp.MustCompile(`\$\{\{(.*?)\}\}`)
+
+// extractConcurrencyGroupTemplate returns the concurrency.group-template value and the
+// optional cancel-in-progress override. ok is false when no template is configured.
+func extractConcurrencyGroupTemplate(frontmatter map[string]any) (template string, cancelInProgress *bool, ok bool) {
+	concurrencyMap, isMap := frontmatter["concurrency"].(map[string]any)
+	if !isMap {
+		return "", nil, false
+	}
+	rawTemplate, hasTemplate := concurrencyMap["group-template"]
+	if !hasTemplate {
+		return "", nil, false
+	}
+	template, _ = rawTemplate.(string)
+	if cancel, isBool := concurrencyMap["cancel-in-progress"].(bool); isBool {
+		cancelInProgress = &cancel
+	}
+	concurrencyTemplateLog.Printf("Found concurrency group template: %s", template)
+	return template, cancelInProgress, true
+}
+
+// getWorkflowInputNames returns the input names declared under on.workflow_dispatch.inputs
+// and on.workflow_call.inputs
+func getWorkflowInputNames(frontmatter map[string]any) map[string]bool {
+	names := make(map[string]bool)
+	onMap, ok := frontmatter["on"].(map[string]any)
+	if !ok {
+		return names
+	}
+	for _, trigger := range []string{"workflow_dispatch", "workflow_call"} {
+		triggerMap, ok := onMap[trigger].(map[string]any)
+		if !ok {
+			continue
+		}
+		inputs, ok := triggerMap["inputs"].(map[string]any)
+		if !ok {
+			continue
+		}
+		for name := range inputs {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// validateConcurrencyGroupTemplateConfig validates concurrency.group-template when it is configured
+func validateConcurrencyGroupTemplateConfig(frontmatter map[string]any) error {
+	template, _, ok := extractConcurrencyGroupTemplate(frontmatter)
+	if !ok {
+		return nil
+	}
+	if _, hasGroup := frontmatter["concurrency"].(map[string]any)["group"]; hasGroup {
+		return NewValidationError(
+			"concurrency",
+			template,
+			"'group' and 'group-template' cannot be used together",
+			"Remove 'group' and keep 'group-template', or use a plain 'group' without the template",
+		)
+	}
+	return validateConcurrencyGroupTemplate(template, getWorkflowInputNames(frontmatter))
+}
+
+// validateConcurrencyGroupTemplate checks that a group template only uses whitelisted
+// placeholders: github.workflow, github.ref, github.event.issue.number and inputs.<name>
+// for inputs declared by the workflow.
+func validateConcurrencyGroupTemplate(template string, inputNames map[string]bool) error {
+	if strings.TrimSpace(template) == "" {
+		return NewValidationError(
+			"concurrency.group-template",
+			template,
+			"the concurrency group template is empty",
+			"Provide a group template. Example: 'deploy-${{ inputs.environment }}'",
+		)
+	}
+
+	if strings.ContainsAny(template, "\"\n\r") {
+		return NewValidationError(
+			"concurrency.group-template",
+			template,
+			"the concurrency group template must not contain quotes or line breaks",
+			"Use a single-line template without double quotes. Example: 'deploy-${{ github.ref }}'",
+		)
+	}
+
+	// Placeholders are removed as they are checked so that unbalanced braces remain visible
+	remainder := template
+	for _, match := range concurrencyTemplatePlaceholderPattern.FindAllStringSubmatch(template, -1) {
+		expression := strings.TrimSpace(match[1])
+		if !isAllowedConcurrencyTemplatePlaceholder(expression, inputNames) {
+			concurrencyTemplateLog.Printf("Rejected concurrency template placeholder: %s", expression)
+			return NewValidationError(
+				"concurrency.group-template",
+				match[0],
+				"placeholder is not allowed in a concurrency group template",
+				fmt.Sprintf("Use one of the supported placeholders: %s", strings.Join(allowedConcurrencyTemplatePlaceholderList(inputNames), ", ")),
+			)
+		}
+		remainder = strings.Replace(remainder, match[0], "", 1)
+	}
+
+	if strings.Contains(remainder, "${{") || strings.Contains(remainder, "}}") {
+		return NewValidationError(
+			"concurrency.group-template",
+			template,
+			"unbalanced ${{ }} placeholder in concurrency group template",
+			"Close every placeholder with '}}'. Example: 'deploy-${{ github.ref }}'",
+		)
+	}
+
+	return nil
+}
+
+// isAllowedConcurrencyTemplatePlaceholder reports whether an expression may be used in a group template
+func isAllowedConcurrencyTemplatePlaceholder(expression string, inputNames map[string]bool) bool {
+	for _, allowed := range allowedConcurrencyTemplatePlaceholders {
+		if expression == allowed {
+			return true
+		}
+	}
+	if name, isInput := strings.CutPrefix(expression, "inputs."); isInput {
+		return inputNames[name]
+	}
+	return false
+}
+
+// allowedConcurrencyTemplatePlaceholderList returns the supported placeholders for error messages
+func allowedConcurrencyTemplatePlaceholderList(inputNames map[string]bool) []string {
+	placeholders := make([]string, 0, len(allowedConcurrencyTemplatePlaceholders)+len(inputNames))
+	for _, allowed := range allowedConcurrencyTemplatePlaceholders {
+		placeholders = append(placeholders, "${{ "+allowed+" }}")
+	}
+	inputs := make([]string, 0, len(inputNames))
+	for name := range inputNames {
+		inputs = append(inputs, "${{ inputs."+name+" }}")
+	}
+	sort.Strings(inputs)
+	return append(placeholders, inputs...)
+}
+
+// applyConcurrencyGroupPrefix prepends the gh-aw- prefix unless the template already has it
+func applyConcurrencyGroupPrefix(template string) string {
+	if strings.HasPrefix(template, concurrencyGroupPrefix) {
+		return template
+	}
+	return concurrencyGroupPrefix + template
+}
@@ -0,0 +1,161 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConcurrencyGroupTemplate(t *testing.T) {
+	inputNames := map[string]bool{"environment": true}
+
+	tests := []struct {
+		name        string
+		template    string
+		errContains string
+	}{
+		{name: "workflow and ref", template: "${{ github.workflow }}-${{ github.ref }}"},
+		{name: "issue number", template: "triage-${{ github.event.issue.number }}"},
+		{name: "declared input", template: "deploy-${{ inputs.environment }}"},
+		{name: "no placeholders", template: "nightly"},
+		{name: "undeclared input", template: "deploy-${{ inputs.region }}", errContains: "placeholder is not allowed"},
+		{name: "arbitrary context", template: "${{ github.event.pull_request.title }}", errContains: "placeholder is not allowed"},
+		{name: "expression with operators", template: "${{ github.ref || github.sha }}", errContains: "placeholder is not allowed"},
+		{name: "function call", template: "${{ toJSON(github) }}", errContains: "placeholder is not allowed"},
+		{name: "unclosed placeholder", template: "deploy-${{ github.ref", errContains: "unbalanced"},
+		{name: "quotes", template: `deploy-"prod"`, errContains: "must not contain quotes"},
+		{name: "empty", template: "  ", errContains: "template is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConcurrencyGroupTemplate(tt.template, inputNames)
+			if tt.errContains == "" {
+				assert.NoError(t, err, "Template %q should be accepted", tt.template)
+				return
+			}
+			require.Error(t, err, "Template %q should be rejected", tt.template)
+			assert.Contains(t, err.Error(), tt.errContains, "Error should explain the problem")
+		})
+	}
+}
+
+func TestValidateConcurrencyGroupTemplateConfigRejectsGroup(t *testing.T) {
+	err := validateConcurrencyGroupTemplateConfig(map[string]any{
+		"concurrency": map[string]any{
+			"group":          "static",
+			"group-template": "${{ github.ref }}",
+		},
+	})
+	require.Error(t, err, "group and group-template together should be rejected")
+	assert.Contains(t, err.Error(), "cannot be used together", "Error should explain the conflict")
+}
+
+func TestGetWorkflowInputNames(t *testing.T) {
+	names := getWorkflowInputNames(map[string]any{
+		"on": map[string]any{
+			"workflow_dispatch": map[string]any{
+				"inputs": map[string]any{"environment": map[string]any{"type": "string"}},
+			},
+			"workflow_call": map[string]any{
+				"inputs": map[string]any{"region": map[string]any{"type": "string"}},
+			},
+		},
+	})
+	assert.Equal(t, map[string]bool{"environment": true, "region": true}, names, "Inputs from both triggers should be collected")
+	assert.Empty(t, getWorkflowInputNames(map[string]any{"on": "push"}), "String triggers have no inputs")
+}
+
+func TestGenerateConcurrencyConfigWithTemplate(t *testing.T) {
+	cancel := false
+
+	tests := []struct {
+		name     string
+		data     *WorkflowData
+		expected string
+	}{
+		{
+			name:     "template gets the gh-aw prefix",
+			data:     &WorkflowData{On: "on:\n  workflow_dispatch:", ConcurrencyTemplate: "deploy-${{ inputs.environment }}"},
+			expected: "concurrency:\n  group: \"gh-aw-deploy-${{ inputs.environment }}\"",
+		},
+		{
+			name:     "existing prefix is not repeated",
+			data:     &WorkflowData{On: "on:\n  workflow_dispatch:", ConcurrencyTemplate: "gh-aw-${{ github.ref }}"},
+			expected: "concurrency:\n  group: \"gh-aw-${{ github.ref }}\"",
+		},
+		{
+			name:     "PR workflows keep the cancel default",
+			data:     &WorkflowData{On: "on:\n  pull_request:", ConcurrencyTemplate: "${{ github.ref }}"},
+			expected: "concurrency:\n  group: \"gh-aw-${{ github.ref }}\"\n  cancel-in-progress: true",
+		},
+		{
+			name:     "explicit cancel-in-progress overrides the default",
+			data:     &WorkflowData{On: "on:\n  pull_request:", ConcurrencyTemplate: "${{ github.ref }}", ConcurrencyCancel: &cancel},
+			expected: "concurrency:\n  group: \"gh-aw-${{ github.ref }}\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GenerateConcurrencyConfig(tt.data, false), "Concurrency config should use the template")
+		})
+	}
+}
+
+func TestCompileWorkflowWithConcurrencyGroupTemplate(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "concurrency-template-test")
+
+	testContent := `---
+on:
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Target environment
+        required: true
+        type: string
+permissions:
+  contents: read
+engine: copilot
+concurrency:
+  group-template: "deploy-${{ inputs.environment }}"
+---
+
+# Deploy
+
+Deploy to the selected environment.
+`
+
+	testFile := filepath.Join(tmpDir, "deploy.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with a group template should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	assert.Contains(t, string(lockContent), "group: \"gh-aw-deploy-${{ inputs.environment }}\"", "Lock file should use the rendered template")
+	assert.NotContains(t, string(lockContent), "group-template", "Template key should not leak into the lock file")
+
+	badContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+concurrency:
+  group-template: "deploy-${{ github.event.pull_request.head.ref }}"
+---
+
+# Deploy
+`
+	badFile := filepath.Join(tmpDir, "bad-deploy.md")
+	require.NoError(t, os.WriteFile(badFile, []byte(badContent), 0644), "Should write test file")
+	err = NewCompiler().CompileWorkflow(badFile)
+	require.Error(t, err, "Arbitrary expressions should be rejected")
+	assert.Contains(t, err.Error(), "placeholder is not allowed", "Error should explain the rejection")
+}
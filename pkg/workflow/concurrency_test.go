@@ -149,6 +149,97 @@ This is a test workflow for concurrency behavior.
 	}
 }
 
+func TestWorkflowCallConcurrency(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "workflow-call-test")
+
+	testContent := `---
+on:
+  workflow_call:
+    inputs:
+      target:
+        description: Path to analyze
+        required: true
+        type: string
+    secrets:
+      API_TOKEN:
+        required: false
+    outputs:
+      model:
+        description: Model used by the agent
+        value: ${{ jobs.agent.outputs.model }}
+engine: copilot
+---
+
+# Analyze ${{ inputs.target }}
+`
+	testFile := filepath.Join(tmpDir, "reusable-analysis.md")
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Failed to compile workflow: %v", err)
+	}
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "reusable-analysis.lock.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lockStr := string(lockContent)
+
+	for _, expected := range []string{
+		"  workflow_call:\n    inputs:\n      target:\n",
+		"        type: string\n",
+		"    outputs:\n      model:\n",
+		"        value: ${{ jobs.agent.outputs.model }}\n",
+		"    secrets:\n      API_TOKEN:\n",
+		"concurrency:\n  group: \"gh-aw-reusable-analysis-${{ github.workflow }}\"\n",
+		"      group: \"gh-aw-copilot-reusable-analysis-${{ github.workflow }}\"\n",
+	} {
+		if !strings.Contains(lockStr, expected) {
+			t.Errorf("Expected lock file to contain %q", expected)
+		}
+	}
+	if strings.Contains(lockStr, "group: \"gh-aw-${{ github.workflow }}") {
+		t.Error("Reusable workflows should not share the caller's concurrency group")
+	}
+}
+
+func TestWorkflowCallConcurrencyWithTwoCallers(t *testing.T) {
+	reusable := &WorkflowData{
+		On:           "on:\n  workflow_call:",
+		WorkflowID:   "reusable-analysis",
+		EngineConfig: &EngineConfig{ID: "copilot"},
+	}
+	caller := &WorkflowData{
+		On:           "on:\n  workflow_dispatch:",
+		EngineConfig: &EngineConfig{ID: "copilot"},
+	}
+
+	// Inside the called workflow, github.workflow evaluates to the caller's name
+	evaluate := func(config, callerName string) string {
+		return strings.ReplaceAll(config, "${{ github.workflow }}", callerName)
+	}
+
+	for _, generate := range []func(*WorkflowData) string{
+		func(data *WorkflowData) string { return GenerateConcurrencyConfig(data, false) },
+		GenerateJobConcurrencyConfig,
+	} {
+		nightly := evaluate(generate(reusable), "Nightly")
+		release := evaluate(generate(reusable), "Release")
+
+		if nightly == release {
+			t.Errorf("Runs called from different workflows should not share a group: %s", nightly)
+		}
+		for name, group := range map[string]string{"Nightly": nightly, "Release": release} {
+			if group == evaluate(generate(caller), name) {
+				t.Errorf("Called workflow should not share the group of its caller %s: %s", name, group)
+			}
+		}
+	}
+}
+
 func TestGenerateConcurrencyConfig(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -280,6 +371,33 @@ func TestGenerateConcurrencyConfig(t *testing.T) {
   group: "gh-aw-${{ github.workflow }}-${{ github.event.issue.number || github.event.discussion.number }}"`,
 			description: "Mixed issue and discussion workflows should use issue/discussion number without cancellation",
 		},
+		{
+			name: "Explicit cancel-in-progress true should cancel scheduled workflows",
+			workflowData: &WorkflowData{
+				On: `on:
+  schedule:
+    - cron: "0 9 * * 1"`,
+				ConcurrencyCancel: boolPtr(true),
+			},
+			isAliasTrigger: false,
+			expected: `concurrency:
+  group: "gh-aw-${{ github.workflow }}"
+  cancel-in-progress: true`,
+			description: "Explicit cancel-in-progress: true should force cancellation regardless of trigger type",
+		},
+		{
+			name: "Explicit cancel-in-progress false should suppress cancellation for PR workflows",
+			workflowData: &WorkflowData{
+				On: `on:
+  pull_request:
+    types: [opened, synchronize]`,
+				ConcurrencyCancel: boolPtr(false),
+			},
+			isAliasTrigger: false,
+			expected: `concurrency:
+  group: "gh-aw-${{ github.workflow }}-${{ github.event.pull_request.number || github.ref }}"`,
+			description: "Explicit cancel-in-progress: false should suppress the PR cancellation default",
+		},
 		{
 			name: "Existing concurrency should not be overridden",
 			workflowData: &WorkflowData{
@@ -335,6 +453,17 @@ func TestGenerateJobConcurrencyConfig(t *testing.T) {
   group: "gh-aw-claude-${{ github.workflow }}"`,
 			description: "Claude with workflow_dispatch should get default concurrency",
 		},
+		{
+			name: "Matrix strategy includes the leg index",
+			workflowData: &WorkflowData{
+				On:           "on:\n  workflow_dispatch:",
+				EngineConfig: &EngineConfig{ID: "copilot"},
+				Strategy:     "strategy:\n  matrix:\n    language: [go, python]",
+			},
+			expected: `concurrency:
+  group: "gh-aw-copilot-${{ github.workflow }}-${{ strategy.job-index }}"`,
+			description: "Matrix legs should not share a concurrency group",
+		},
 		{
 			name: "No default concurrency for push workflows",
 			workflowData: &WorkflowData{
@@ -391,6 +520,27 @@ func TestGenerateJobConcurrencyConfig(t *testing.T) {
   group: "gh-aw-codex-${{ github.workflow }}"`,
 			description: "Codex with schedule should get default concurrency",
 		},
+		{
+			name: "Default concurrency for workflow_dispatch with gemini engine",
+			workflowData: &WorkflowData{
+				On:           "on:\n  workflow_dispatch:",
+				EngineConfig: &EngineConfig{ID: "gemini"},
+			},
+			expected: `concurrency:
+  group: "gh-aw-gemini-${{ github.workflow }}"`,
+			description: "Gemini with workflow_dispatch should get default concurrency",
+		},
+		{
+			name: "Default concurrency for workflow_call",
+			workflowData: &WorkflowData{
+				On:           "on:\n  workflow_call:\n    inputs:\n      target:\n        type: string",
+				WorkflowID:   "reusable-analysis",
+				EngineConfig: &EngineConfig{ID: "copilot"},
+			},
+			expected: `concurrency:
+  group: "gh-aw-copilot-reusable-analysis-${{ github.workflow }}"`,
+			description: "Reusable workflows should not share the caller's group",
+		},
 	}
 
 	for _, tt := range tests {
@@ -619,6 +769,55 @@ func TestIsDiscussionWorkflow(t *testing.T) {
 	}
 }
 
+func TestIsWorkflowCallWorkflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		on       string
+		expected bool
+	}{
+		{
+			name: "Workflow call workflow should be identified",
+			on: `on:
+  workflow_call:
+    inputs:
+      target:
+        type: string
+        required: true`,
+			expected: true,
+		},
+		{
+			name: "Workflow dispatch workflow should not be identified as workflow call workflow",
+			on: `on:
+  workflow_dispatch:`,
+			expected: false,
+		},
+		{
+			name: "Workflow run workflow should not be identified as workflow call workflow",
+			on: `on:
+  workflow_run:
+    workflows: ["CI"]
+    types: [completed]`,
+			expected: false,
+		},
+		{
+			name: "Mixed workflow with workflow call should be identified",
+			on: `on:
+  workflow_call:
+  workflow_dispatch:`,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := isWorkflowCallWorkflow(tt.on)
+			if result != tt.expected {
+				t.Errorf("isWorkflowCallWorkflow() for %s = %v, expected %v", tt.name, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestBuildConcurrencyGroupKeys(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -732,6 +931,33 @@ func TestBuildConcurrencyGroupKeys(t *testing.T) {
 			expected:       []string{"gh-aw", "${{ github.workflow }}"},
 			description:    "Other workflows should use just workflow name",
 		},
+		{
+			name: "Workflow call workflow should prefix the caller with the workflow ID",
+			workflowData: &WorkflowData{
+				On: `on:
+  workflow_call:
+    inputs:
+      target:
+        type: string`,
+				WorkflowID: "reusable-analysis",
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "reusable-analysis-${{ github.workflow }}"},
+			description:    "Workflow call workflows should include the workflow ID and the caller's workflow name",
+		},
+		{
+			name: "Workflow call workflow with push should keep the ref key",
+			workflowData: &WorkflowData{
+				On: `on:
+  push:
+    branches: [main]
+  workflow_call:`,
+				WorkflowID: "reusable-analysis",
+			},
+			isAliasTrigger: false,
+			expected:       []string{"gh-aw", "reusable-analysis-${{ github.workflow }}", "${{ github.ref }}"},
+			description:    "Mixed workflow call workflows should keep event-specific keys",
+		},
 	}
 
 	for _, tt := range tests {
@@ -828,3 +1054,19 @@ func TestShouldEnableCancelInProgress(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractConcurrencyCancelOverride(t *testing.T) {
+	cancel, ok := extractConcurrencyCancelOverride(map[string]any{
+		"concurrency": map[string]any{"cancel-in-progress": true},
+	})
+	if !ok || cancel == nil || !*cancel {
+		t.Errorf("extractConcurrencyCancelOverride() = %v, %v, want true override", cancel, ok)
+	}
+
+	// A custom group is emitted verbatim, so its cancel-in-progress is not an override
+	if _, ok := extractConcurrencyCancelOverride(map[string]any{
+		"concurrency": map[string]any{"group": "custom", "cancel-in-progress": true},
+	}); ok {
+		t.Error("extractConcurrencyCancelOverride() should ignore concurrency with a custom group")
+	}
+}
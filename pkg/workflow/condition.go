@@ -0,0 +1,346 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var conditionLog = logger.New("workflow:condition")
+
+// conditionActorPattern matches GitHub logins, optionally with the [bot] suffix used by apps
+var conditionActorPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?(\[bot\])?$`)
+
+// conditionTermKinds are the term prefixes supported by the condition DSL
+var conditionTermKinds = map[string]bool{
+	"label": true,
+	"actor": true,
+	"path":  true,
+}
+
+// conditionExpr is a node of a parsed condition DSL expression
+type conditionExpr struct {
+	op          string // "and", "or", "not", or "term"
+	left, right *conditionExpr
+	kind, value string // term kind (label, actor, path) and value
+}
+
+// processConditionConfiguration compiles the frontmatter condition DSL. Label and actor terms are
+// combined into the workflow's job-level if condition, and path terms are collected for the
+// changed-files check in the pre-activation job.
+func (c *Compiler) processConditionConfiguration(frontmatter map[string]any, workflowData *WorkflowData) error {
+	value, exists := frontmatter["condition"]
+	if !exists {
+		return nil
+	}
+	source, ok := value.(string)
+	if !ok {
+		return errors.New("condition must be a string, e.g. \"label:bug and not actor:dependabot[bot]\"")
+	}
+
+	expr, err := parseConditionDSL(source)
+	if err != nil {
+		return fmt.Errorf("invalid condition %q: %w", source, err)
+	}
+
+	node, pathGroups, err := compileCondition(expr)
+	if err != nil {
+		return fmt.Errorf("invalid condition %q: %w", source, err)
+	}
+
+	if node != nil {
+		conditionTree := BuildConditionTree(workflowData.If, node.Render())
+		workflowData.If = conditionTree.Render()
+	}
+	workflowData.ChangedFilesPatterns = pathGroups
+	conditionLog.Printf("Compiled condition: if=%q, pathGroups=%d", workflowData.If, len(pathGroups))
+	return nil
+}
+
+// parseConditionDSL parses a condition such as "(label:bug or label:regression) and not actor:octocat".
+// Terms are label:<name>, actor:<login>, and path:<glob>; values containing spaces must be quoted.
+// Terms can be combined with and/&&, or/||, not/!, and parentheses.
+func parseConditionDSL(source string) (*conditionExpr, error) {
+	if strings.Contains(source, "${{") || strings.Contains(source, "}}") {
+		return nil, errors.New("GitHub Actions expressions (${{ }}) are not allowed; use label:, actor:, and path: terms")
+	}
+
+	tokens, err := tokenizeCondition(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("condition is empty")
+	}
+
+	p := &conditionParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+// tokenizeCondition splits a condition into operators, parentheses, and kind:value terms
+func tokenizeCondition(source string) ([]string, error) {
+	var tokens []string
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+			i++
+		case r == '!':
+			tokens = append(tokens, "not")
+			i++
+		case strings.HasPrefix(string(runes[i:]), "&&"):
+			tokens = append(tokens, "and")
+			i += 2
+		case strings.HasPrefix(string(runes[i:]), "||"):
+			tokens = append(tokens, "or")
+			i += 2
+		default:
+			var token strings.Builder
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				rest := string(runes[i:])
+				if strings.HasPrefix(rest, "&&") || strings.HasPrefix(rest, "||") {
+					break
+				}
+				if runes[i] == '"' || runes[i] == '\'' {
+					quote := runes[i]
+					end := i + 1
+					for end < len(runes) && runes[end] != quote {
+						end++
+					}
+					if end == len(runes) {
+						return nil, errors.New("unterminated quoted value")
+					}
+					// Keep the quotes so the parser can tell quoted values apart from operators
+					token.WriteString(string(runes[i : end+1]))
+					i = end + 1
+					continue
+				}
+				token.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, token.String())
+		}
+	}
+	return tokens, nil
+}
+
+// conditionParser is a recursive descent parser over condition tokens
+type conditionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *conditionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *conditionParser) parseOr() (*conditionExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "or" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &conditionExpr{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (*conditionExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "and" {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &conditionExpr{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseUnary() (*conditionExpr, error) {
+	token := p.peek()
+	switch token {
+	case "":
+		return nil, errors.New("unexpected end of condition")
+	case "not":
+		p.pos++
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &conditionExpr{op: "not", left: child}, nil
+	case "(":
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	case ")", "and", "or":
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+
+	p.pos++
+	return parseConditionTerm(token)
+}
+
+// parseConditionTerm parses and validates a single kind:value term
+func parseConditionTerm(token string) (*conditionExpr, error) {
+	kind, value, found := strings.Cut(token, ":")
+	if !found || !conditionTermKinds[kind] {
+		return nil, fmt.Errorf("unknown term %q; expected label:<name>, actor:<login>, or path:<glob>", token)
+	}
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	if strings.ContainsAny(value, "\"'\n") {
+		return nil, fmt.Errorf("invalid %s value %q", kind, value)
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("%s: requires a value", kind)
+	}
+	if kind == "actor" && !conditionActorPattern.MatchString(value) {
+		return nil, fmt.Errorf("invalid actor %q; expected a GitHub login", value)
+	}
+	return &conditionExpr{op: "term", kind: kind, value: value}, nil
+}
+
+// compileCondition converts a parsed condition into an expression tree for the job-level if
+// condition and a list of path groups. Path terms can only be evaluated by the changed-files check,
+// so they must appear as top-level and-ed groups of or-ed path terms.
+func compileCondition(expr *conditionExpr) (ConditionNode, [][]string, error) {
+	var conjuncts []*conditionExpr
+	var collect func(e *conditionExpr)
+	collect = func(e *conditionExpr) {
+		if e.op == "and" {
+			collect(e.left)
+			collect(e.right)
+			return
+		}
+		conjuncts = append(conjuncts, e)
+	}
+	collect(expr)
+
+	var node ConditionNode
+	var pathGroups [][]string
+	for _, conjunct := range conjuncts {
+		if !containsPathTerm(conjunct) {
+			term := buildConditionNode(conjunct)
+			if node == nil {
+				node = term
+			} else {
+				node = BuildAnd(node, term)
+			}
+			continue
+		}
+		globs, ok := collectPathGroup(conjunct)
+		if !ok {
+			return nil, nil, errors.New("path: terms can only be combined with other path: terms using 'or' and cannot be negated")
+		}
+		pathGroups = append(pathGroups, globs)
+	}
+	return node, pathGroups, nil
+}
+
+// containsPathTerm reports whether the expression references changed file paths
+func containsPathTerm(e *conditionExpr) bool {
+	if e == nil {
+		return false
+	}
+	if e.op == "term" {
+		return e.kind == "path"
+	}
+	return containsPathTerm(e.left) || containsPathTerm(e.right)
+}
+
+// collectPathGroup returns the globs of an expression made only of or-ed path terms
+func collectPathGroup(e *conditionExpr) ([]string, bool) {
+	switch e.op {
+	case "term":
+		if e.kind != "path" {
+			return nil, false
+		}
+		return []string{e.value}, true
+	case "or":
+		left, ok := collectPathGroup(e.left)
+		if !ok {
+			return nil, false
+		}
+		right, ok := collectPathGroup(e.right)
+		if !ok {
+			return nil, false
+		}
+		return append(left, right...), true
+	}
+	return nil, false
+}
+
+// buildConditionNode converts a condition expression without path terms into an expression tree
+func buildConditionNode(e *conditionExpr) ConditionNode {
+	switch e.op {
+	case "and":
+		return BuildAnd(buildConditionNode(e.left), buildConditionNode(e.right))
+	case "or":
+		return BuildOr(buildConditionNode(e.left), buildConditionNode(e.right))
+	case "not":
+		return &NotNode{Child: buildConditionNode(e.left)}
+	}
+
+	if e.kind == "actor" {
+		return BuildEquals(BuildPropertyAccess("github.actor"), BuildStringLiteral(e.value))
+	}
+	// Labels can come from the triggering issue or pull request
+	return BuildOr(
+		BuildLabelContains(e.value),
+		BuildContains(BuildPropertyAccess("github.event.pull_request.labels.*.name"), BuildStringLiteral(e.value)),
+	)
+}
+
+// generateChangedFilesCheck adds the pre-activation step that checks the changed files of the
+// triggering push or pull request against the condition's path globs
+func (c *Compiler) generateChangedFilesCheck(data *WorkflowData, steps []string) []string {
+	patternsJSON, _ := json.Marshal(data.ChangedFilesPatterns)
+
+	steps = append(steps, "      - name: Check changed files\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckChangedFilesStepID))
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
+	steps = append(steps, "        env:\n")
+	steps = append(steps, fmt.Sprintf("          GH_AW_CHANGED_FILES_PATTERNS: %q\n", string(patternsJSON)))
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          script: |\n")
+	steps = append(steps, generateGitHubScriptWithRequire("check_changed_files.cjs"))
+	return steps
+}
@@ -0,0 +1,158 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileConditionDSL(t *testing.T) {
+	tests := []struct {
+		name           string
+		condition      string
+		expectedIf     string
+		expectedGroups [][]string
+	}{
+		{
+			name:       "label membership",
+			condition:  "label:bug",
+			expectedIf: "(contains(github.event.issue.labels.*.name, 'bug')) || (contains(github.event.pull_request.labels.*.name, 'bug'))",
+		},
+		{
+			name:       "actor check",
+			condition:  "actor:octocat",
+			expectedIf: "github.actor == 'octocat'",
+		},
+		{
+			name:       "negated bot actor with symbolic operators",
+			condition:  "label:bug && !actor:dependabot[bot]",
+			expectedIf: "((contains(github.event.issue.labels.*.name, 'bug')) || (contains(github.event.pull_request.labels.*.name, 'bug'))) && (!(github.actor == 'dependabot[bot]'))",
+		},
+		{
+			name:       "quoted label with spaces",
+			condition:  `actor:octocat or label:"needs triage"`,
+			expectedIf: "(github.actor == 'octocat') || ((contains(github.event.issue.labels.*.name, 'needs triage')) || (contains(github.event.pull_request.labels.*.name, 'needs triage')))",
+		},
+		{
+			name:           "path groups are checked separately",
+			condition:      "actor:octocat and (path:src/** or path:lib/**) and path:go.mod",
+			expectedIf:     "github.actor == 'octocat'",
+			expectedGroups: [][]string{{"src/**", "lib/**"}, {"go.mod"}},
+		},
+		{
+			name:           "path only",
+			condition:      "path:docs/**",
+			expectedGroups: [][]string{{"docs/**"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parseConditionDSL(tt.condition)
+			require.NoError(t, err)
+			node, groups, err := compileCondition(expr)
+			require.NoError(t, err)
+
+			if tt.expectedIf == "" {
+				assert.Nil(t, node)
+			} else {
+				require.NotNil(t, node)
+				assert.Equal(t, tt.expectedIf, node.Render())
+			}
+			assert.Equal(t, tt.expectedGroups, groups)
+		})
+	}
+}
+
+func TestConditionDSLErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		errorText string
+	}{
+		{name: "raw expression", condition: "${{ github.actor == 'octocat' }}", errorText: "are not allowed"},
+		{name: "expression in value", condition: "label:${{ github.event.issue.title }}", errorText: "are not allowed"},
+		{name: "unknown term", condition: "branch:main", errorText: "unknown term"},
+		{name: "invalid actor", condition: "actor:octo.cat", errorText: "invalid actor"},
+		{name: "quote in label", condition: "label:it's", errorText: "unterminated quoted value"},
+		{name: "missing value", condition: "label:", errorText: "requires a value"},
+		{name: "dangling operator", condition: "label:bug and", errorText: "unexpected end"},
+		{name: "unbalanced parenthesis", condition: "(label:bug or label:docs", errorText: "missing closing parenthesis"},
+		{name: "negated path", condition: "not path:docs/**", errorText: "path: terms"},
+		{name: "path mixed with label", condition: "label:docs or path:docs/**", errorText: "path: terms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parseConditionDSL(tt.condition)
+			if err == nil {
+				_, _, err = compileCondition(expr)
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestConditionCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "condition-test")
+
+	testContent := `---
+on:
+  pull_request:
+    types: [opened, labeled]
+engine: copilot
+condition: label:triage and not actor:dependabot[bot] and path:src/**
+---
+
+# Triage pull requests
+`
+	testFile := filepath.Join(tmpDir, "conditional.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "conditional.lock.yml"))
+	require.NoError(t, err)
+	lockStr := string(lockContent)
+
+	preActivation := extractJobSection(lockStr, "pre_activation")
+	require.NotEmpty(t, preActivation, "path terms should add a pre-activation job")
+	assert.Contains(t, preActivation, "contains(github.event.pull_request.labels.*.name, 'triage')")
+	assert.Contains(t, preActivation, "github.actor == 'dependabot[bot]'")
+	assert.Contains(t, preActivation, "id: check_changed_files")
+	assert.Contains(t, preActivation, `GH_AW_CHANGED_FILES_PATTERNS: "[[\"src/**\"]]"`)
+	assert.Contains(t, preActivation, "steps.check_changed_files.outputs.changed_files_ok == 'true'")
+	assert.Contains(t, preActivation, "pull-requests: read")
+
+	activation := extractJobSection(lockStr, "activation")
+	require.NotEmpty(t, activation)
+	assert.True(t, strings.Contains(activation, "needs.pre_activation.outputs.activated == 'true'"), "activation should be gated on the pre-activation checks")
+}
+
+func TestConditionCompilationRejectsExpressions(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "condition-expression-test")
+
+	testContent := `---
+on: issues
+engine: copilot
+condition: "label:${{ github.event.issue.title }}"
+---
+
+# Triage
+`
+	testFile := filepath.Join(tmpDir, "conditional.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "are not allowed")
+}
@@ -40,6 +40,8 @@ func NewCopilotEngine() *CopilotEngine {
 			experimental:           false,
 			supportsToolsAllowlist: true,
 			supportsMaxTurns:       false, // Copilot CLI does not support max-turns feature yet
+			supportsMaxTokens:      false, // Copilot CLI does not expose an output token limit
+			supportsTemperature:    false, // Copilot CLI does not expose sampling temperature
 			supportsWebFetch:       true,  // Copilot CLI has built-in web-fetch support
 			supportsWebSearch:      false, // Copilot CLI does not have built-in web-search support
 			supportsFirewall:       true,  // Copilot supports network firewalling via AWF
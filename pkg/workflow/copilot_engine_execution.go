@@ -355,7 +355,7 @@ COPILOT_CLI_INSTRUCTION="$(cat /tmp/gh-aw/aw-prompts/prompt.txt)"
 
 	// Filter environment variables to only include allowed secrets
 	// This is a security measure to prevent exposing unnecessary secrets to the AWF container
-	allowedSecrets := e.GetRequiredSecretNames(workflowData)
+	allowedSecrets := append(e.GetRequiredSecretNames(workflowData), getEngineEnvKeys(workflowData)...)
 	filteredEnv := FilterEnvForSecrets(env, allowedSecrets)
 
 	// Format step with command and filtered environment variables using shared helper
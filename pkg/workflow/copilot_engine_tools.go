@@ -65,7 +65,7 @@ func (e *CopilotEngine) computeCopilotToolArguments(tools map[string]any, safeOu
 			// Add specific shell commands
 			for _, cmd := range bashCommands {
 				if cmdStr, ok := cmd.(string); ok {
-					args = append(args, "--allow-tool", fmt.Sprintf("shell(%s)", cmdStr))
+					args = append(args, "--allow-tool", fmt.Sprintf("shell(%s)", canonicalBashCommand(cmdStr)))
 				}
 			}
 		} else {
@@ -0,0 +1,150 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var createGistLog = logger.New("workflow:create_gist")
+
+// CreateGistConfig holds configuration for publishing agent output as GitHub Gists
+type CreateGistConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	Public               bool   `yaml:"public,omitempty"`          // If true, gists are public; otherwise they are secret (default)
+	Description          string `yaml:"description,omitempty"`     // Default gist description when the agent does not provide one
+	FilenamePrefix       string `yaml:"filename-prefix,omitempty"` // Prefix prepended to every gist filename
+}
+
+// parseCreateGistConfig handles create-gist configuration
+func (c *Compiler) parseCreateGistConfig(outputMap map[string]any) *CreateGistConfig {
+	configData, exists := outputMap["create-gist"]
+	if !exists {
+		return nil
+	}
+
+	createGistLog.Print("Parsing create-gist configuration")
+	gistConfig := &CreateGistConfig{}
+
+	if configMap, ok := configData.(map[string]any); ok {
+		if public, exists := configMap["public"]; exists {
+			if publicBool, ok := public.(bool); ok {
+				gistConfig.Public = publicBool
+			}
+		}
+
+		if description, exists := configMap["description"]; exists {
+			if descriptionStr, ok := description.(string); ok {
+				gistConfig.Description = descriptionStr
+			}
+		}
+
+		if prefix, exists := configMap["filename-prefix"]; exists {
+			if prefixStr, ok := prefix.(string); ok {
+				gistConfig.FilenamePrefix = prefixStr
+			}
+		}
+
+		// Parse common base fields with default max of 1
+		c.parseBaseSafeOutputConfig(configMap, &gistConfig.BaseSafeOutputConfig, 1)
+	} else {
+		// If configData is nil or not a map (e.g., "create-gist:" with no value),
+		// still set the default max
+		gistConfig.Max = defaultIntStr(1)
+	}
+
+	createGistLog.Printf("Parsed create-gist config: public=%t, filename_prefix=%q", gistConfig.Public, gistConfig.FilenamePrefix)
+	return gistConfig
+}
+
+// buildCreateGistEnvVars builds the environment variables specific to create-gist
+func buildCreateGistEnvVars(cfg *CreateGistConfig) []string {
+	var customEnvVars []string
+
+	if cfg.Public {
+		customEnvVars = append(customEnvVars, "          GH_AW_GIST_PUBLIC: \"true\"\n")
+	}
+	if cfg.Description != "" {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_GIST_DESCRIPTION: %q\n", cfg.Description))
+	}
+	if cfg.FilenamePrefix != "" {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_GIST_FILENAME_PREFIX: %q\n", cfg.FilenamePrefix))
+	}
+
+	// Add max count environment variable for JavaScript to validate against
+	if maxVal := templatableIntValue(cfg.Max); maxVal > 0 {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_GIST_MAX_COUNT: %d\n", maxVal))
+	} else if cfg.Max != nil {
+		customEnvVars = append(customEnvVars, buildTemplatableIntEnvVar("GH_AW_GIST_MAX_COUNT", cfg.Max)...)
+	}
+
+	return customEnvVars
+}
+
+// buildCreateGistStepConfig builds the configuration for creating gists in the consolidated safe-outputs job
+func (c *Compiler) buildCreateGistStepConfig(data *WorkflowData, mainJobName string, threatDetectionEnabled bool) SafeOutputStepConfig {
+	cfg := data.SafeOutputs.CreateGists
+	createGistLog.Print("Building create-gist step config")
+
+	var customEnvVars []string
+	customEnvVars = append(customEnvVars, c.buildStepLevelSafeOutputEnvVars(data, "")...)
+	customEnvVars = append(customEnvVars, buildCreateGistEnvVars(cfg)...)
+
+	condition := BuildSafeOutputType("create_gist")
+
+	return SafeOutputStepConfig{
+		StepName:      "Create Gist",
+		StepID:        "create_gist",
+		ScriptName:    "create_gist",
+		CustomEnvVars: customEnvVars,
+		Condition:     condition,
+		Token:         resolveGistToken(data.SafeOutputs),
+	}
+}
+
+// buildCreateOutputGistJob creates the create_gist job
+func (c *Compiler) buildCreateOutputGistJob(data *WorkflowData, mainJobName string) (*Job, error) {
+	if data.SafeOutputs == nil || data.SafeOutputs.CreateGists == nil {
+		return nil, errors.New("safe-outputs.create-gist configuration is required")
+	}
+
+	cfg := data.SafeOutputs.CreateGists
+	createGistLog.Printf("Building create-gist job: workflow=%s, main_job=%s, public=%t", data.Name, mainJobName, cfg.Public)
+
+	customEnvVars := []string{
+		fmt.Sprintf("          GH_AW_WORKFLOW_ID: %q\n", data.WorkflowID),
+	}
+	customEnvVars = append(customEnvVars, buildCreateGistEnvVars(cfg)...)
+
+	// Add standard environment variables (metadata + staged)
+	customEnvVars = append(customEnvVars, c.buildStandardSafeOutputEnvVars(data, "")...)
+
+	outputs := map[string]string{
+		"gist_id":  "${{ steps.create_gist.outputs.gist_id }}",
+		"gist_url": "${{ steps.create_gist.outputs.gist_url }}",
+	}
+
+	return c.buildSafeOutputJob(data, SafeOutputJobConfig{
+		JobName:       "create_gist",
+		StepName:      "Create Gist",
+		StepID:        "create_gist",
+		MainJobName:   mainJobName,
+		CustomEnvVars: customEnvVars,
+		Script:        "const { main } = require('/opt/gh-aw/actions/create_gist.cjs'); await main();",
+		Permissions:   NewPermissionsContentsRead(),
+		Outputs:       outputs,
+		Condition:     BuildSafeOutputType("create_gist"),
+		Token:         resolveGistToken(data.SafeOutputs),
+	})
+}
+
+// resolveGistToken returns the token used to create gists.
+// Precedence: per-config token > safe-outputs level token > GH_AW_GIST_TOKEN
+func resolveGistToken(safeOutputs *SafeOutputsConfig) string {
+	configToken := safeOutputs.CreateGists.GitHubToken
+	if configToken == "" {
+		configToken = safeOutputs.GitHubToken
+	}
+	return getEffectiveGistGitHubToken(configToken)
+}
@@ -0,0 +1,187 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreateGistConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		outputMap  map[string]any
+		wantConfig bool
+		wantPublic bool
+		wantDesc   string
+		wantPrefix string
+		wantMax    int
+	}{
+		{
+			name:       "no create-gist config",
+			outputMap:  map[string]any{},
+			wantConfig: false,
+		},
+		{
+			name:       "null config uses defaults",
+			outputMap:  map[string]any{"create-gist": nil},
+			wantConfig: true,
+			wantMax:    1,
+		},
+		{
+			name: "all fields",
+			outputMap: map[string]any{
+				"create-gist": map[string]any{
+					"public":          true,
+					"description":     "Weekly report",
+					"filename-prefix": "weekly-",
+					"max":             3,
+				},
+			},
+			wantConfig: true,
+			wantPublic: true,
+			wantDesc:   "Weekly report",
+			wantPrefix: "weekly-",
+			wantMax:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewCompiler().parseCreateGistConfig(tt.outputMap)
+			if !tt.wantConfig {
+				assert.Nil(t, config, "Config should be nil when create-gist is absent")
+				return
+			}
+
+			require.NotNil(t, config, "Config should be parsed")
+			assert.Equal(t, tt.wantPublic, config.Public, "Public should match")
+			assert.Equal(t, tt.wantDesc, config.Description, "Description should match")
+			assert.Equal(t, tt.wantPrefix, config.FilenamePrefix, "FilenamePrefix should match")
+			assert.Equal(t, tt.wantMax, templatableIntValue(config.Max), "Max should match")
+		})
+	}
+}
+
+func TestBuildCreateOutputGistJob(t *testing.T) {
+	compiler := NewCompiler()
+	workflowData := &WorkflowData{
+		Name:       "Test Workflow",
+		WorkflowID: "weekly-report",
+		SafeOutputs: &SafeOutputsConfig{
+			CreateGists: &CreateGistConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("2")},
+				Public:               true,
+				Description:          "Weekly report",
+				FilenamePrefix:       "weekly-",
+			},
+		},
+	}
+
+	job, err := compiler.buildCreateOutputGistJob(workflowData, "main_job")
+	require.NoError(t, err, "buildCreateOutputGistJob should succeed")
+	require.NotNil(t, job, "Job should not be nil")
+
+	assert.Equal(t, "create_gist", job.Name, "Job name should be create_gist")
+	assert.Equal(t, []string{"main_job"}, job.Needs, "Job should depend on the main job")
+	assert.Contains(t, job.Outputs, "gist_id", "Job should expose gist_id")
+	assert.Contains(t, job.Outputs, "gist_url", "Job should expose gist_url")
+	assert.Contains(t, job.Permissions, "contents: read", "Job should only need contents: read")
+	assert.NotContains(t, job.Permissions, "write", "Job should not request write permissions")
+
+	steps := strings.Join(job.Steps, "")
+	assert.Contains(t, steps, `GH_AW_WORKFLOW_ID: "weekly-report"`, "Steps should include the workflow ID")
+	assert.Contains(t, steps, `GH_AW_GIST_PUBLIC: "true"`, "Steps should pass the visibility")
+	assert.Contains(t, steps, `GH_AW_GIST_DESCRIPTION: "Weekly report"`, "Steps should pass the default description")
+	assert.Contains(t, steps, `GH_AW_GIST_FILENAME_PREFIX: "weekly-"`, "Steps should pass the filename prefix")
+	assert.Contains(t, steps, "GH_AW_GIST_MAX_COUNT: 2", "Steps should pass the max count")
+	assert.Contains(t, steps, "github-token: ${{ secrets.GH_AW_GIST_TOKEN }}", "Steps should use the gist token by default")
+}
+
+func TestBuildCreateOutputGistJobRequiresConfig(t *testing.T) {
+	_, err := NewCompiler().buildCreateOutputGistJob(&WorkflowData{SafeOutputs: &SafeOutputsConfig{}}, "main_job")
+	require.Error(t, err, "Job builder should fail without create-gist config")
+	assert.Contains(t, err.Error(), "safe-outputs.create-gist", "Error should name the missing configuration")
+}
+
+func TestResolveGistToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		safeOutputs *SafeOutputsConfig
+		expected    string
+	}{
+		{
+			name:        "defaults to GH_AW_GIST_TOKEN",
+			safeOutputs: &SafeOutputsConfig{CreateGists: &CreateGistConfig{}},
+			expected:    "${{ secrets.GH_AW_GIST_TOKEN }}",
+		},
+		{
+			name: "safe-outputs token overrides default",
+			safeOutputs: &SafeOutputsConfig{
+				GitHubToken: "${{ secrets.SAFE_OUTPUTS_TOKEN }}",
+				CreateGists: &CreateGistConfig{},
+			},
+			expected: "${{ secrets.SAFE_OUTPUTS_TOKEN }}",
+		},
+		{
+			name: "per-output token takes precedence",
+			safeOutputs: &SafeOutputsConfig{
+				GitHubToken: "${{ secrets.SAFE_OUTPUTS_TOKEN }}",
+				CreateGists: &CreateGistConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{GitHubToken: "${{ secrets.GIST_PAT }}"}},
+			},
+			expected: "${{ secrets.GIST_PAT }}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolveGistToken(tt.safeOutputs), "Resolved token should match")
+		})
+	}
+}
+
+func TestCompileWorkflowWithCreateGist(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "create-gist-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-gist:
+    description: "Nightly report"
+    filename-prefix: "nightly-"
+    max: 2
+---
+
+# Nightly Report
+
+Publish the nightly report as a gist.
+`
+
+	testFile := filepath.Join(tmpDir, "nightly-report.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow with create-gist should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+
+	safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+	require.NotEmpty(t, safeOutputsJob, "Lock file should contain the safe_outputs job")
+	assert.Contains(t, safeOutputsJob, "id: create_gist", "safe_outputs job should include the create_gist step")
+	assert.Contains(t, safeOutputsJob, `GH_AW_GIST_FILENAME_PREFIX: "nightly-"`, "create_gist step should pass the filename prefix")
+	assert.Contains(t, safeOutputsJob, "GH_AW_GIST_MAX_COUNT: 2", "create_gist step should pass the max count")
+	assert.Contains(t, safeOutputsJob, "create_gist.cjs", "create_gist step should run the gist script")
+	assert.Contains(t, safeOutputsJob, "secrets.GH_AW_GIST_TOKEN", "create_gist step should use the gist token")
+	assert.Contains(t, string(lockContent), `{"create_gist":{"max":2}`, "Safe outputs config should enable the create_gist tool")
+}
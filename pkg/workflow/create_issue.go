@@ -14,6 +14,8 @@ var createIssueLog = logger.New("workflow:create_issue")
 type CreateIssuesConfig struct {
 	BaseSafeOutputConfig `yaml:",inline"`
 	TitlePrefix          string   `yaml:"title-prefix,omitempty"`
+	TitleTemplate        string   `yaml:"title-template,omitempty"` // Title template with run context placeholders (e.g. "${{ event }}: ${{ title }}")
+	BodyTemplate         string   `yaml:"body-template,omitempty"`  // Body template with run context placeholders (e.g. "Run ${{ run_id }}\n\n${{ body }}")
 	Labels               []string `yaml:"labels,omitempty"`
 	AllowedLabels        []string `yaml:"allowed-labels,omitempty"`     // Optional list of allowed labels. If omitted, any labels are allowed (including creating new ones).
 	Assignees            []string `yaml:"assignees,omitempty"`          // List of users/bots to assign the issue to
@@ -0,0 +1,190 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var createPRReviewLog = logger.New("workflow:create_pr_review")
+
+// pullRequestReviewEvents lists the review events accepted by the GitHub API
+var pullRequestReviewEvents = []string{"COMMENT", "REQUEST_CHANGES", "APPROVE"}
+
+// CreatePullRequestReviewConfig holds configuration for submitting a complete GitHub pull request
+// review from agent output: a single review with an event, a body and optional inline comments.
+// Unlike create-pull-request-review-comment, each message is submitted as its own review.
+type CreatePullRequestReviewConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	Event                string `yaml:"event,omitempty"`         // Review event used when the agent does not choose one: "COMMENT" (default), "REQUEST_CHANGES", or "APPROVE"
+	Body                 string `yaml:"body,omitempty"`          // Review body used when the agent does not provide one
+	AllowApprove         bool   `yaml:"allow-approve,omitempty"` // Allow reviews with the APPROVE event (default: false)
+	Target               string `yaml:"target,omitempty"`        // Target PR: "triggering" (default), "*" (use message.pull_request_number), or explicit number
+}
+
+// parseCreatePullRequestReviewConfig handles create-pull-request-review configuration
+func (c *Compiler) parseCreatePullRequestReviewConfig(outputMap map[string]any) *CreatePullRequestReviewConfig {
+	configData, exists := outputMap["create-pull-request-review"]
+	if !exists {
+		return nil
+	}
+
+	createPRReviewLog.Print("Parsing create-pull-request-review configuration")
+
+	// Reviews are plain comments unless the workflow chooses another event
+	reviewConfig := &CreatePullRequestReviewConfig{Event: "COMMENT"}
+
+	if configMap, ok := configData.(map[string]any); ok {
+		if event, exists := configMap["event"]; exists {
+			if eventStr, ok := event.(string); ok {
+				reviewConfig.Event = strings.ToUpper(eventStr)
+			}
+		}
+
+		if body, exists := configMap["body"]; exists {
+			if bodyStr, ok := body.(string); ok {
+				reviewConfig.Body = bodyStr
+			}
+		}
+
+		if allowApprove, exists := configMap["allow-approve"]; exists {
+			if allowApproveBool, ok := allowApprove.(bool); ok {
+				reviewConfig.AllowApprove = allowApproveBool
+			}
+		}
+
+		if target, exists := configMap["target"]; exists {
+			if targetStr, ok := target.(string); ok {
+				reviewConfig.Target = targetStr
+			}
+		}
+
+		// Parse common base fields with default max of 1
+		c.parseBaseSafeOutputConfig(configMap, &reviewConfig.BaseSafeOutputConfig, 1)
+	} else {
+		// If configData is nil or not a map (e.g., "create-pull-request-review:" with no value),
+		// still set the default max
+		reviewConfig.Max = defaultIntStr(1)
+	}
+
+	createPRReviewLog.Printf("Parsed create-pull-request-review config: event=%s, allow_approve=%t, target=%q", reviewConfig.Event, reviewConfig.AllowApprove, reviewConfig.Target)
+	return reviewConfig
+}
+
+// validateCreatePullRequestReview validates the default review event. APPROVE must be opted
+// into with allow-approve, so an agent can never approve a pull request by default.
+func validateCreatePullRequestReview(config *SafeOutputsConfig) error {
+	if config == nil || config.CreatePullRequestReviews == nil {
+		return nil
+	}
+	review := config.CreatePullRequestReviews
+	if !slices.Contains(pullRequestReviewEvents, review.Event) {
+		return fmt.Errorf("safe-outputs.create-pull-request-review.event must be one of %s, got '%s'", strings.Join(pullRequestReviewEvents, ", "), review.Event)
+	}
+	if review.Event == "APPROVE" && !review.AllowApprove {
+		return errors.New("safe-outputs.create-pull-request-review.event is 'APPROVE' but approvals are not allowed. Set allow-approve: true to let the agent approve pull requests")
+	}
+	return nil
+}
+
+// buildCreatePullRequestReviewEnvVars builds the environment variables specific to create-pull-request-review
+func buildCreatePullRequestReviewEnvVars(cfg *CreatePullRequestReviewConfig) []string {
+	var customEnvVars []string
+
+	// Always pass the default event so the script never falls back to an approval
+	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_PULL_REQUEST_REVIEW_EVENT: %q\n", cfg.Event))
+	if cfg.AllowApprove {
+		customEnvVars = append(customEnvVars, "          GH_AW_PULL_REQUEST_REVIEW_ALLOW_APPROVE: \"true\"\n")
+	}
+	if cfg.Body != "" {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_PULL_REQUEST_REVIEW_BODY: %q\n", cfg.Body))
+	}
+	if cfg.Target != "" {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_PULL_REQUEST_REVIEW_TARGET: %q\n", cfg.Target))
+	}
+
+	// Add max count environment variable for JavaScript to validate against
+	if maxVal := templatableIntValue(cfg.Max); maxVal > 0 {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_PULL_REQUEST_REVIEW_MAX_COUNT: %d\n", maxVal))
+	} else if cfg.Max != nil {
+		customEnvVars = append(customEnvVars, buildTemplatableIntEnvVar("GH_AW_PULL_REQUEST_REVIEW_MAX_COUNT", cfg.Max)...)
+	}
+
+	return customEnvVars
+}
+
+// buildCreatePullRequestReviewCondition builds the condition for the create_pull_request_review step or job.
+// With the default "triggering" target, the workflow must run in a pull request context.
+func buildCreatePullRequestReviewCondition(cfg *CreatePullRequestReviewConfig) ConditionNode {
+	condition := BuildSafeOutputType("create_pull_request_review")
+	if cfg.Target != "" {
+		return condition
+	}
+	issueWithPR := &AndNode{
+		Left:  &ExpressionNode{Expression: "github.event.issue.number"},
+		Right: &ExpressionNode{Expression: "github.event.issue.pull_request"},
+	}
+	eventCondition := BuildOr(
+		issueWithPR,
+		BuildPropertyAccess("github.event.pull_request"),
+	)
+	return BuildAnd(condition, eventCondition)
+}
+
+// buildCreatePullRequestReviewStepConfig builds the configuration for submitting pull request reviews in the consolidated safe-outputs job
+func (c *Compiler) buildCreatePullRequestReviewStepConfig(data *WorkflowData, mainJobName string, threatDetectionEnabled bool) SafeOutputStepConfig {
+	cfg := data.SafeOutputs.CreatePullRequestReviews
+	createPRReviewLog.Print("Building create-pull-request-review step config")
+
+	var customEnvVars []string
+	customEnvVars = append(customEnvVars, c.buildStepLevelSafeOutputEnvVars(data, "")...)
+	customEnvVars = append(customEnvVars, buildCreatePullRequestReviewEnvVars(cfg)...)
+
+	return SafeOutputStepConfig{
+		StepName:      "Create Pull Request Review",
+		StepID:        "create_pull_request_review",
+		ScriptName:    "create_pull_request_review",
+		CustomEnvVars: customEnvVars,
+		Condition:     buildCreatePullRequestReviewCondition(cfg),
+		Token:         cfg.GitHubToken,
+	}
+}
+
+// buildCreateOutputPullRequestReviewJob creates the create_pull_request_review job
+func (c *Compiler) buildCreateOutputPullRequestReviewJob(data *WorkflowData, mainJobName string) (*Job, error) {
+	if data.SafeOutputs == nil || data.SafeOutputs.CreatePullRequestReviews == nil {
+		return nil, errors.New("safe-outputs.create-pull-request-review configuration is required")
+	}
+
+	cfg := data.SafeOutputs.CreatePullRequestReviews
+	createPRReviewLog.Printf("Building create-pull-request-review job: workflow=%s, main_job=%s, event=%s", data.Name, mainJobName, cfg.Event)
+
+	customEnvVars := []string{
+		fmt.Sprintf("          GH_AW_WORKFLOW_ID: %q\n", data.WorkflowID),
+	}
+	customEnvVars = append(customEnvVars, buildCreatePullRequestReviewEnvVars(cfg)...)
+
+	// Add standard environment variables (metadata + staged)
+	customEnvVars = append(customEnvVars, c.buildStandardSafeOutputEnvVars(data, "")...)
+
+	outputs := map[string]string{
+		"review_id":  "${{ steps.create_pull_request_review.outputs.review_id }}",
+		"review_url": "${{ steps.create_pull_request_review.outputs.review_url }}",
+	}
+
+	return c.buildSafeOutputJob(data, SafeOutputJobConfig{
+		JobName:       "create_pull_request_review",
+		StepName:      "Create Pull Request Review",
+		StepID:        "create_pull_request_review",
+		MainJobName:   mainJobName,
+		CustomEnvVars: customEnvVars,
+		Script:        "const { main } = require('/opt/gh-aw/actions/create_pull_request_review.cjs'); await main();",
+		Permissions:   NewPermissionsContentsReadPRWrite(),
+		Outputs:       outputs,
+		Condition:     buildCreatePullRequestReviewCondition(cfg),
+		Token:         cfg.GitHubToken,
+	})
+}
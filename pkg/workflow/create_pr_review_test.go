@@ -0,0 +1,225 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreatePullRequestReviewConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		outputMap        map[string]any
+		wantConfig       bool
+		wantEvent        string
+		wantBody         string
+		wantAllowApprove bool
+		wantTarget       string
+		wantMax          int
+	}{
+		{
+			name:       "no create-pull-request-review config",
+			outputMap:  map[string]any{},
+			wantConfig: false,
+		},
+		{
+			name:       "null config defaults to COMMENT",
+			outputMap:  map[string]any{"create-pull-request-review": nil},
+			wantConfig: true,
+			wantEvent:  "COMMENT",
+			wantMax:    1,
+		},
+		{
+			name: "full config",
+			outputMap: map[string]any{
+				"create-pull-request-review": map[string]any{
+					"event":         "request_changes",
+					"body":          "Automated review",
+					"allow-approve": true,
+					"target":        "*",
+					"max":           3,
+				},
+			},
+			wantConfig:       true,
+			wantEvent:        "REQUEST_CHANGES",
+			wantBody:         "Automated review",
+			wantAllowApprove: true,
+			wantTarget:       "*",
+			wantMax:          3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewCompiler().parseCreatePullRequestReviewConfig(tt.outputMap)
+			if !tt.wantConfig {
+				assert.Nil(t, config, "Config should be nil when create-pull-request-review is absent")
+				return
+			}
+
+			require.NotNil(t, config, "Config should be parsed")
+			assert.Equal(t, tt.wantEvent, config.Event, "Event should match")
+			assert.Equal(t, tt.wantBody, config.Body, "Body should match")
+			assert.Equal(t, tt.wantAllowApprove, config.AllowApprove, "AllowApprove should match")
+			assert.Equal(t, tt.wantTarget, config.Target, "Target should match")
+			assert.Equal(t, tt.wantMax, templatableIntValue(config.Max), "Max should match")
+		})
+	}
+}
+
+func TestValidateCreatePullRequestReview(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *CreatePullRequestReviewConfig
+		wantErr string
+	}{
+		{
+			name:   "COMMENT is valid",
+			config: &CreatePullRequestReviewConfig{Event: "COMMENT"},
+		},
+		{
+			name:   "REQUEST_CHANGES is valid",
+			config: &CreatePullRequestReviewConfig{Event: "REQUEST_CHANGES"},
+		},
+		{
+			name:    "APPROVE requires allow-approve",
+			config:  &CreatePullRequestReviewConfig{Event: "APPROVE"},
+			wantErr: "allow-approve: true",
+		},
+		{
+			name:   "APPROVE with allow-approve is valid",
+			config: &CreatePullRequestReviewConfig{Event: "APPROVE", AllowApprove: true},
+		},
+		{
+			name:    "unknown event is rejected",
+			config:  &CreatePullRequestReviewConfig{Event: "DISMISS"},
+			wantErr: "must be one of COMMENT, REQUEST_CHANGES, APPROVE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCreatePullRequestReview(&SafeOutputsConfig{CreatePullRequestReviews: tt.config})
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Config should be valid")
+				return
+			}
+			require.Error(t, err, "Config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the problem")
+		})
+	}
+}
+
+func TestBuildCreateOutputPullRequestReviewJob(t *testing.T) {
+	compiler := NewCompiler()
+	workflowData := &WorkflowData{
+		Name:       "Test Workflow",
+		WorkflowID: "pr-reviewer",
+		SafeOutputs: &SafeOutputsConfig{
+			CreatePullRequestReviews: &CreatePullRequestReviewConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("2")},
+				Event:                "REQUEST_CHANGES",
+				Body:                 "Automated review",
+			},
+		},
+	}
+
+	job, err := compiler.buildCreateOutputPullRequestReviewJob(workflowData, "main_job")
+	require.NoError(t, err, "buildCreateOutputPullRequestReviewJob should succeed")
+	require.NotNil(t, job, "Job should not be nil")
+
+	assert.Equal(t, "create_pull_request_review", job.Name, "Job name should be create_pull_request_review")
+	assert.Equal(t, []string{"main_job"}, job.Needs, "Job should depend on the main job")
+	assert.Contains(t, job.Outputs, "review_id", "Job should expose review_id")
+	assert.Contains(t, job.Outputs, "review_url", "Job should expose review_url")
+	assert.Contains(t, job.Permissions, "pull-requests: write", "Job should require pull-requests: write")
+	assert.Contains(t, job.If, "github.event.pull_request", "Default target should require a pull request context")
+
+	steps := strings.Join(job.Steps, "")
+	assert.Contains(t, steps, `GH_AW_WORKFLOW_ID: "pr-reviewer"`, "Steps should include the workflow ID")
+	assert.Contains(t, steps, `GH_AW_PULL_REQUEST_REVIEW_EVENT: "REQUEST_CHANGES"`, "Steps should pass the default event")
+	assert.Contains(t, steps, `GH_AW_PULL_REQUEST_REVIEW_BODY: "Automated review"`, "Steps should pass the default body")
+	assert.Contains(t, steps, "GH_AW_PULL_REQUEST_REVIEW_MAX_COUNT: 2", "Steps should pass the max count")
+	assert.NotContains(t, steps, "GH_AW_PULL_REQUEST_REVIEW_ALLOW_APPROVE", "Approvals should not be allowed by default")
+}
+
+func TestBuildCreateOutputPullRequestReviewJobRequiresConfig(t *testing.T) {
+	_, err := NewCompiler().buildCreateOutputPullRequestReviewJob(&WorkflowData{SafeOutputs: &SafeOutputsConfig{}}, "main_job")
+	require.Error(t, err, "Job builder should fail without create-pull-request-review config")
+	assert.Contains(t, err.Error(), "safe-outputs.create-pull-request-review", "Error should name the missing configuration")
+}
+
+func TestCompileWorkflowWithCreatePullRequestReview(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "create-pr-review-test")
+
+	testContent := `---
+on:
+  pull_request:
+    types: [opened]
+permissions:
+  contents: read
+  pull-requests: read
+engine: copilot
+safe-outputs:
+  create-pull-request-review:
+---
+
+# PR Reviewer
+
+Review the pull request.
+`
+
+	testFile := filepath.Join(tmpDir, "pr-reviewer.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow with create-pull-request-review should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+
+	safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+	require.NotEmpty(t, safeOutputsJob, "Lock file should contain the safe_outputs job")
+	assert.Contains(t, safeOutputsJob, "id: create_pull_request_review", "safe_outputs job should include the create_pull_request_review step")
+	assert.Contains(t, safeOutputsJob, `GH_AW_PULL_REQUEST_REVIEW_EVENT: "COMMENT"`, "create_pull_request_review step should default to COMMENT")
+	assert.Contains(t, safeOutputsJob, "create_pull_request_review.cjs", "create_pull_request_review step should run the review script")
+	assert.Contains(t, safeOutputsJob, "pull-requests: write", "safe_outputs job should request pull-requests: write")
+	assert.Contains(t, string(lockContent), `"create_pull_request_review":{"max":1}`, "Safe outputs config should enable the create_pull_request_review tool")
+}
+
+func TestCompileWorkflowRejectsUnallowedApproval(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "create-pr-review-approve-test")
+
+	testContent := `---
+on:
+  pull_request:
+    types: [opened]
+permissions:
+  contents: read
+  pull-requests: read
+engine: copilot
+safe-outputs:
+  create-pull-request-review:
+    event: APPROVE
+---
+
+# PR Approver
+
+Approve the pull request.
+`
+
+	testFile := filepath.Join(tmpDir, "pr-approver.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "APPROVE without allow-approve should not compile")
+	assert.Contains(t, err.Error(), "allow-approve", "Error should point to allow-approve")
+}
@@ -3,6 +3,7 @@ package workflow
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
@@ -22,6 +23,8 @@ func getFallbackAsIssue(config *CreatePullRequestsConfig) bool {
 type CreatePullRequestsConfig struct {
 	BaseSafeOutputConfig           `yaml:",inline"`
 	TitlePrefix                    string   `yaml:"title-prefix,omitempty"`
+	TitleTemplate                  string   `yaml:"title-template,omitempty"` // Title template with run context placeholders (e.g. "${{ event }}: ${{ title }}")
+	BodyTemplate                   string   `yaml:"body-template,omitempty"`  // Body template with run context placeholders (e.g. "Run ${{ run_id }}\n\n${{ body }}")
 	Labels                         []string `yaml:"labels,omitempty"`
 	AllowedLabels                  []string `yaml:"allowed-labels,omitempty"`                      // Optional list of allowed labels. If omitted, any labels are allowed (including creating new ones).
 	Reviewers                      []string `yaml:"reviewers,omitempty"`                           // List of users/bots to assign as reviewers to the pull request
@@ -36,6 +39,52 @@ type CreatePullRequestsConfig struct {
 	Footer                         *string  `yaml:"footer,omitempty"`                              // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
 	FallbackAsIssue                *bool    `yaml:"fallback-as-issue,omitempty"`                   // When true (default), creates an issue if PR creation fails. When false, no fallback occurs and issues: write permission is not requested.
 	GithubTokenForExtraEmptyCommit string   `yaml:"github-token-for-extra-empty-commit,omitempty"` // Token used to push an empty commit to trigger CI events. Use a PAT or "app" for GitHub App auth.
+	MaxPatchSize                   int      `yaml:"max-patch-size,omitempty"`                      // Maximum patch size in KB for this output; overrides safe-outputs.max-patch-size
+	PatchBase                      string   `yaml:"patch-base,omitempty"`                          // Explicit commit or ref to generate the patch from; overrides the GITHUB_SHA and branch strategies
+	ExcludePaths                   []string `yaml:"exclude-paths,omitempty"`                       // Glob patterns of paths to leave out of the generated patch (e.g. lock files, build output)
+}
+
+// getCreatePullRequestMaxPatchSize returns the maximum patch size in KB for create-pull-request.
+// create-pull-request.max-patch-size takes precedence over safe-outputs.max-patch-size.
+func getCreatePullRequestMaxPatchSize(cfg *SafeOutputsConfig) int {
+	maxPatchSize := 1024 // default 1024 KB
+	if cfg == nil {
+		return maxPatchSize
+	}
+	if cfg.CreatePullRequests != nil && cfg.CreatePullRequests.MaxPatchSize > 0 {
+		return cfg.CreatePullRequests.MaxPatchSize
+	}
+	if cfg.MaximumPatchSize > 0 {
+		maxPatchSize = cfg.MaximumPatchSize
+	}
+	return maxPatchSize
+}
+
+// getMaxPatchBytesEnv returns the GH_AW_MAX_PATCH_BYTES value for patch generation, or 0
+// when create-pull-request.max-patch-size is not configured
+func getMaxPatchBytesEnv(cfg *SafeOutputsConfig) int {
+	if cfg == nil || cfg.CreatePullRequests == nil || cfg.CreatePullRequests.MaxPatchSize <= 0 {
+		return 0
+	}
+	return cfg.CreatePullRequests.MaxPatchSize * 1024
+}
+
+// getPatchBaseEnv returns the GH_AW_PATCH_BASE value for patch generation, or "" when
+// create-pull-request.patch-base is not configured
+func getPatchBaseEnv(cfg *SafeOutputsConfig) string {
+	if cfg == nil || cfg.CreatePullRequests == nil {
+		return ""
+	}
+	return cfg.CreatePullRequests.PatchBase
+}
+
+// getPatchExcludePathsEnv returns the GH_AW_PATCH_EXCLUDE_PATHS value for patch generation, or
+// "" when create-pull-request.exclude-paths is not configured
+func getPatchExcludePathsEnv(cfg *SafeOutputsConfig) string {
+	if cfg == nil || cfg.CreatePullRequests == nil {
+		return ""
+	}
+	return strings.Join(cfg.CreatePullRequests.ExcludePaths, ",")
 }
 
 // buildCreateOutputPullRequestJob creates the create_pull_request job
@@ -141,10 +190,7 @@ func (c *Compiler) buildCreateOutputPullRequestJob(data *WorkflowData, mainJobNa
 	}
 
 	// Pass the maximum patch size configuration
-	maxPatchSize := 1024 // Default value
-	if data.SafeOutputs != nil && data.SafeOutputs.MaximumPatchSize > 0 {
-		maxPatchSize = data.SafeOutputs.MaximumPatchSize
-	}
+	maxPatchSize := getCreatePullRequestMaxPatchSize(data.SafeOutputs)
 	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_MAX_PATCH_SIZE: %d\n", maxPatchSize))
 
 	// Pass activation comment information if available (for updating the comment with PR link)
@@ -203,6 +249,8 @@ func (c *Compiler) buildCreateOutputPullRequestJob(data *WorkflowData, mainJobNa
 	outputs := map[string]string{
 		"pull_request_number": "${{ steps.create_pull_request.outputs.pull_request_number }}",
 		"pull_request_url":    "${{ steps.create_pull_request.outputs.pull_request_url }}",
+		"pr_number":           "${{ steps.create_pull_request.outputs.pull_request_number }}",
+		"pr_url":              "${{ steps.create_pull_request.outputs.pull_request_url }}",
 		"issue_number":        "${{ steps.create_pull_request.outputs.issue_number }}",
 		"issue_url":           "${{ steps.create_pull_request.outputs.issue_url }}",
 		"branch_name":         "${{ steps.create_pull_request.outputs.branch_name }}",
@@ -0,0 +1,54 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPatchExcludePathsEnv(t *testing.T) {
+	assert.Empty(t, getPatchExcludePathsEnv(nil), "No safe outputs should not exclude paths")
+	assert.Empty(t, getPatchExcludePathsEnv(&SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{}}),
+		"Unset exclude-paths should not set GH_AW_PATCH_EXCLUDE_PATHS")
+	assert.Equal(t, "dist/**,**/package-lock.json", getPatchExcludePathsEnv(&SafeOutputsConfig{
+		CreatePullRequests: &CreatePullRequestsConfig{ExcludePaths: []string{"dist/**", "**/package-lock.json"}},
+	}))
+}
+
+func TestCompileWorkflowWithCreatePullRequestExcludePaths(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "exclude-paths-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-pull-request:
+    exclude-paths:
+      - "dist/**"
+      - "**/package-lock.json"
+---
+
+# Update Dependencies
+
+Update the dependencies.
+`
+
+	testFile := filepath.Join(tmpDir, "exclude-paths.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with create-pull-request.exclude-paths should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	agentJob := extractJobSection(string(lockContent), "agent")
+	assert.Contains(t, agentJob, `GH_AW_PATCH_EXCLUDE_PATHS: "dist/**,**/package-lock.json"`, "Agent job should export the excluded paths")
+	assert.Contains(t, agentJob, "-e GH_AW_PATCH_EXCLUDE_PATHS", "Excluded paths should be passed to the MCP gateway container")
+}
@@ -0,0 +1,64 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCreatePullRequestMaxPatchSize(t *testing.T) {
+	assert.Equal(t, 1024, getCreatePullRequestMaxPatchSize(nil), "Default should be 1024 KB")
+	assert.Equal(t, 2048, getCreatePullRequestMaxPatchSize(&SafeOutputsConfig{
+		MaximumPatchSize:   2048,
+		CreatePullRequests: &CreatePullRequestsConfig{},
+	}), "Top-level max-patch-size should apply when the output does not set one")
+	assert.Equal(t, 256, getCreatePullRequestMaxPatchSize(&SafeOutputsConfig{
+		MaximumPatchSize:   2048,
+		CreatePullRequests: &CreatePullRequestsConfig{MaxPatchSize: 256},
+	}), "create-pull-request.max-patch-size should take precedence")
+}
+
+func TestGetMaxPatchBytesEnv(t *testing.T) {
+	assert.Zero(t, getMaxPatchBytesEnv(nil), "No safe outputs should not set a limit")
+	assert.Zero(t, getMaxPatchBytesEnv(&SafeOutputsConfig{MaximumPatchSize: 512, CreatePullRequests: &CreatePullRequestsConfig{}}),
+		"Top-level max-patch-size should not set GH_AW_MAX_PATCH_BYTES")
+	assert.Equal(t, 256*1024, getMaxPatchBytesEnv(&SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{MaxPatchSize: 256}}),
+		"KB should be converted to bytes")
+}
+
+func TestCompileWorkflowWithCreatePullRequestMaxPatchSize(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "max-patch-bytes-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-pull-request:
+    max-patch-size: 256
+---
+
+# Large Refactor
+
+Refactor the code base.
+`
+
+	testFile := filepath.Join(tmpDir, "max-patch.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with create-pull-request.max-patch-size should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	agentJob := extractJobSection(string(lockContent), "agent")
+	assert.Contains(t, agentJob, "GH_AW_MAX_PATCH_BYTES: 262144", "Agent job should export the patch byte limit")
+	assert.Contains(t, agentJob, "-e GH_AW_MAX_PATCH_BYTES", "Limit should be passed to the MCP gateway container")
+	assert.Contains(t, string(lockContent), `\"max_patch_size\":256`, "Handler config should use the per-output limit")
+}
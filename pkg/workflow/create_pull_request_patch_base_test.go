@@ -0,0 +1,52 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPatchBaseEnv(t *testing.T) {
+	assert.Empty(t, getPatchBaseEnv(nil), "No safe outputs should not set a patch base")
+	assert.Empty(t, getPatchBaseEnv(&SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{}}),
+		"Unset patch-base should not set GH_AW_PATCH_BASE")
+	assert.Equal(t, "abc123", getPatchBaseEnv(&SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{PatchBase: "abc123"}}))
+}
+
+func TestCompileWorkflowWithCreatePullRequestPatchBase(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "patch-base-test")
+
+	testContent := `---
+on:
+  pull_request:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-pull-request:
+    patch-base: ${{ github.event.pull_request.base.sha }}
+---
+
+# Rebase Pull Request
+
+Rebase the pull request onto its base branch.
+`
+
+	testFile := filepath.Join(tmpDir, "patch-base.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with create-pull-request.patch-base should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	agentJob := extractJobSection(string(lockContent), "agent")
+	assert.Contains(t, agentJob, `GH_AW_PATCH_BASE: "${{ github.event.pull_request.base.sha }}"`, "Agent job should export the patch base")
+	assert.Contains(t, agentJob, "-e GH_AW_PATCH_BASE", "Patch base should be passed to the MCP gateway container")
+}
@@ -0,0 +1,152 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var createReleaseLog = logger.New("workflow:create_release")
+
+// CreateReleaseConfig holds configuration for creating GitHub releases from agent output
+type CreateReleaseConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	Draft                bool   `yaml:"draft"`                      // Create draft releases (default: true). Releases are only published when explicitly set to false.
+	Prerelease           bool   `yaml:"prerelease,omitempty"`       // Mark created releases as prereleases
+	TagPrefix            string `yaml:"tag-prefix,omitempty"`       // Prefix prepended to tag names that do not already start with it (e.g., "v")
+	TargetCommitish      string `yaml:"target-commitish,omitempty"` // Branch or commit SHA the tag is created from when it does not exist yet
+}
+
+// parseCreateReleaseConfig handles create-release configuration
+func (c *Compiler) parseCreateReleaseConfig(outputMap map[string]any) *CreateReleaseConfig {
+	configData, exists := outputMap["create-release"]
+	if !exists {
+		return nil
+	}
+
+	createReleaseLog.Print("Parsing create-release configuration")
+
+	// Releases are created as drafts unless draft: false is set explicitly
+	releaseConfig := &CreateReleaseConfig{Draft: true}
+
+	if configMap, ok := configData.(map[string]any); ok {
+		if draft, exists := configMap["draft"]; exists {
+			if draftBool, ok := draft.(bool); ok {
+				releaseConfig.Draft = draftBool
+			}
+		}
+
+		if prerelease, exists := configMap["prerelease"]; exists {
+			if prereleaseBool, ok := prerelease.(bool); ok {
+				releaseConfig.Prerelease = prereleaseBool
+			}
+		}
+
+		if tagPrefix, exists := configMap["tag-prefix"]; exists {
+			if tagPrefixStr, ok := tagPrefix.(string); ok {
+				releaseConfig.TagPrefix = tagPrefixStr
+			}
+		}
+
+		if targetCommitish, exists := configMap["target-commitish"]; exists {
+			if targetCommitishStr, ok := targetCommitish.(string); ok {
+				releaseConfig.TargetCommitish = targetCommitishStr
+			}
+		}
+
+		// Parse common base fields with default max of 1
+		c.parseBaseSafeOutputConfig(configMap, &releaseConfig.BaseSafeOutputConfig, 1)
+	} else {
+		// If configData is nil or not a map (e.g., "create-release:" with no value),
+		// still set the default max
+		releaseConfig.Max = defaultIntStr(1)
+	}
+
+	createReleaseLog.Printf("Parsed create-release config: draft=%t, prerelease=%t, tag_prefix=%q", releaseConfig.Draft, releaseConfig.Prerelease, releaseConfig.TagPrefix)
+	return releaseConfig
+}
+
+// buildCreateReleaseEnvVars builds the environment variables specific to create-release
+func buildCreateReleaseEnvVars(cfg *CreateReleaseConfig) []string {
+	var customEnvVars []string
+
+	// Always pass the draft setting so the script never publishes by default
+	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_RELEASE_DRAFT: \"%t\"\n", cfg.Draft))
+	if cfg.Prerelease {
+		customEnvVars = append(customEnvVars, "          GH_AW_RELEASE_PRERELEASE: \"true\"\n")
+	}
+	if cfg.TagPrefix != "" {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_RELEASE_TAG_PREFIX: %q\n", cfg.TagPrefix))
+	}
+	if cfg.TargetCommitish != "" {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_RELEASE_TARGET_COMMITISH: %q\n", cfg.TargetCommitish))
+	}
+
+	// Add max count environment variable for JavaScript to validate against
+	if maxVal := templatableIntValue(cfg.Max); maxVal > 0 {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_RELEASE_MAX_COUNT: %d\n", maxVal))
+	} else if cfg.Max != nil {
+		customEnvVars = append(customEnvVars, buildTemplatableIntEnvVar("GH_AW_RELEASE_MAX_COUNT", cfg.Max)...)
+	}
+
+	return customEnvVars
+}
+
+// buildCreateReleaseStepConfig builds the configuration for creating releases in the consolidated safe-outputs job
+func (c *Compiler) buildCreateReleaseStepConfig(data *WorkflowData, mainJobName string, threatDetectionEnabled bool) SafeOutputStepConfig {
+	cfg := data.SafeOutputs.CreateReleases
+	createReleaseLog.Print("Building create-release step config")
+
+	var customEnvVars []string
+	customEnvVars = append(customEnvVars, c.buildStepLevelSafeOutputEnvVars(data, "")...)
+	customEnvVars = append(customEnvVars, buildCreateReleaseEnvVars(cfg)...)
+
+	condition := BuildSafeOutputType("create_release")
+
+	return SafeOutputStepConfig{
+		StepName:      "Create Release",
+		StepID:        "create_release",
+		ScriptName:    "create_release",
+		CustomEnvVars: customEnvVars,
+		Condition:     condition,
+		Token:         cfg.GitHubToken,
+	}
+}
+
+// buildCreateOutputReleaseJob creates the create_release job
+func (c *Compiler) buildCreateOutputReleaseJob(data *WorkflowData, mainJobName string) (*Job, error) {
+	if data.SafeOutputs == nil || data.SafeOutputs.CreateReleases == nil {
+		return nil, errors.New("safe-outputs.create-release configuration is required")
+	}
+
+	cfg := data.SafeOutputs.CreateReleases
+	createReleaseLog.Printf("Building create-release job: workflow=%s, main_job=%s, draft=%t", data.Name, mainJobName, cfg.Draft)
+
+	customEnvVars := []string{
+		fmt.Sprintf("          GH_AW_WORKFLOW_ID: %q\n", data.WorkflowID),
+	}
+	customEnvVars = append(customEnvVars, buildCreateReleaseEnvVars(cfg)...)
+
+	// Add standard environment variables (metadata + staged)
+	customEnvVars = append(customEnvVars, c.buildStandardSafeOutputEnvVars(data, "")...)
+
+	outputs := map[string]string{
+		"release_id":  "${{ steps.create_release.outputs.release_id }}",
+		"release_url": "${{ steps.create_release.outputs.release_url }}",
+		"release_tag": "${{ steps.create_release.outputs.release_tag }}",
+	}
+
+	return c.buildSafeOutputJob(data, SafeOutputJobConfig{
+		JobName:       "create_release",
+		StepName:      "Create Release",
+		StepID:        "create_release",
+		MainJobName:   mainJobName,
+		CustomEnvVars: customEnvVars,
+		Script:        "const { main } = require('/opt/gh-aw/actions/create_release.cjs'); await main();",
+		Permissions:   NewPermissionsContentsWrite(),
+		Outputs:       outputs,
+		Condition:     BuildSafeOutputType("create_release"),
+		Token:         cfg.GitHubToken,
+	})
+}
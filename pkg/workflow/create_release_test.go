@@ -0,0 +1,172 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreateReleaseConfig(t *testing.T) {
+	tests := []struct {
+		name                string
+		outputMap           map[string]any
+		wantConfig          bool
+		wantDraft           bool
+		wantPrerelease      bool
+		wantTagPrefix       string
+		wantTargetCommitish string
+		wantMax             int
+	}{
+		{
+			name:       "no create-release config",
+			outputMap:  map[string]any{},
+			wantConfig: false,
+		},
+		{
+			name:       "null config defaults to draft",
+			outputMap:  map[string]any{"create-release": nil},
+			wantConfig: true,
+			wantDraft:  true,
+			wantMax:    1,
+		},
+		{
+			name:          "omitted draft defaults to draft",
+			outputMap:     map[string]any{"create-release": map[string]any{"tag-prefix": "v"}},
+			wantConfig:    true,
+			wantDraft:     true,
+			wantMax:       1,
+			wantTagPrefix: "v",
+		},
+		{
+			name: "explicit draft false publishes",
+			outputMap: map[string]any{
+				"create-release": map[string]any{
+					"draft":            false,
+					"prerelease":       true,
+					"tag-prefix":       "release-",
+					"target-commitish": "main",
+					"max":              2,
+				},
+			},
+			wantConfig:          true,
+			wantDraft:           false,
+			wantPrerelease:      true,
+			wantTagPrefix:       "release-",
+			wantTargetCommitish: "main",
+			wantMax:             2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewCompiler().parseCreateReleaseConfig(tt.outputMap)
+			if !tt.wantConfig {
+				assert.Nil(t, config, "Config should be nil when create-release is absent")
+				return
+			}
+
+			require.NotNil(t, config, "Config should be parsed")
+			assert.Equal(t, tt.wantDraft, config.Draft, "Draft should match")
+			assert.Equal(t, tt.wantPrerelease, config.Prerelease, "Prerelease should match")
+			assert.Equal(t, tt.wantTagPrefix, config.TagPrefix, "TagPrefix should match")
+			assert.Equal(t, tt.wantTargetCommitish, config.TargetCommitish, "TargetCommitish should match")
+			assert.Equal(t, tt.wantMax, templatableIntValue(config.Max), "Max should match")
+		})
+	}
+}
+
+func TestBuildCreateOutputReleaseJob(t *testing.T) {
+	compiler := NewCompiler()
+	workflowData := &WorkflowData{
+		Name:       "Test Workflow",
+		WorkflowID: "release-notes",
+		SafeOutputs: &SafeOutputsConfig{
+			CreateReleases: &CreateReleaseConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("2")},
+				Draft:                true,
+				Prerelease:           true,
+				TagPrefix:            "v",
+				TargetCommitish:      "main",
+			},
+		},
+	}
+
+	job, err := compiler.buildCreateOutputReleaseJob(workflowData, "main_job")
+	require.NoError(t, err, "buildCreateOutputReleaseJob should succeed")
+	require.NotNil(t, job, "Job should not be nil")
+
+	assert.Equal(t, "create_release", job.Name, "Job name should be create_release")
+	assert.Equal(t, []string{"main_job"}, job.Needs, "Job should depend on the main job")
+	assert.Contains(t, job.Outputs, "release_id", "Job should expose release_id")
+	assert.Contains(t, job.Outputs, "release_url", "Job should expose release_url")
+	assert.Contains(t, job.Outputs, "release_tag", "Job should expose release_tag")
+	assert.Contains(t, job.Permissions, "contents: write", "Job should require contents: write")
+
+	steps := strings.Join(job.Steps, "")
+	assert.Contains(t, steps, `GH_AW_WORKFLOW_ID: "release-notes"`, "Steps should include the workflow ID")
+	assert.Contains(t, steps, `GH_AW_RELEASE_DRAFT: "true"`, "Steps should pass the draft setting")
+	assert.Contains(t, steps, `GH_AW_RELEASE_PRERELEASE: "true"`, "Steps should pass the prerelease setting")
+	assert.Contains(t, steps, `GH_AW_RELEASE_TAG_PREFIX: "v"`, "Steps should pass the tag prefix")
+	assert.Contains(t, steps, `GH_AW_RELEASE_TARGET_COMMITISH: "main"`, "Steps should pass the target commitish")
+	assert.Contains(t, steps, "GH_AW_RELEASE_MAX_COUNT: 2", "Steps should pass the max count")
+}
+
+func TestBuildCreateOutputReleaseJobRequiresConfig(t *testing.T) {
+	_, err := NewCompiler().buildCreateOutputReleaseJob(&WorkflowData{SafeOutputs: &SafeOutputsConfig{}}, "main_job")
+	require.Error(t, err, "Job builder should fail without create-release config")
+	assert.Contains(t, err.Error(), "safe-outputs.create-release", "Error should name the missing configuration")
+}
+
+func TestCreateReleasePermissions(t *testing.T) {
+	permissions := ComputePermissionsForSafeOutputs(&SafeOutputsConfig{CreateReleases: &CreateReleaseConfig{Draft: true}})
+	require.NotNil(t, permissions, "Permissions should be computed")
+
+	level, ok := permissions.Get(PermissionContents)
+	require.True(t, ok, "contents permission should be set")
+	assert.Equal(t, PermissionWrite, level, "create-release should require contents: write")
+}
+
+func TestCompileWorkflowWithCreateRelease(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "create-release-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-release:
+    tag-prefix: "v"
+---
+
+# Release Notes
+
+Draft the release notes for the next version.
+`
+
+	testFile := filepath.Join(tmpDir, "release-notes.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow with create-release should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+
+	safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+	require.NotEmpty(t, safeOutputsJob, "Lock file should contain the safe_outputs job")
+	assert.Contains(t, safeOutputsJob, "id: create_release", "safe_outputs job should include the create_release step")
+	assert.Contains(t, safeOutputsJob, `GH_AW_RELEASE_DRAFT: "true"`, "create_release step should default to drafts")
+	assert.Contains(t, safeOutputsJob, `GH_AW_RELEASE_TAG_PREFIX: "v"`, "create_release step should pass the tag prefix")
+	assert.Contains(t, safeOutputsJob, "create_release.cjs", "create_release step should run the release script")
+	assert.Contains(t, safeOutputsJob, "contents: write", "safe_outputs job should request contents: write")
+	assert.Contains(t, string(lockContent), `"create_release":{"max":1}`, "Safe outputs config should enable the create_release tool")
+}
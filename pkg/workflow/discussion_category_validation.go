@@ -0,0 +1,78 @@
+// This file provides validation of discussion categories against a repository.
+//
+// # Discussion Category Validation
+//
+// safe-outputs.create-discussion.category is a free-form string: a category ID, name or slug.
+// A category that does not exist in the target repository is only noticed at runtime, where the
+// create_discussion handler falls back to another category. When repository feature validation
+// is enabled (compile --validate), the configured category is checked against the categories
+// returned by the GraphQL API (see validateRepositoryFeatures).
+//
+// Matching mirrors resolveCategoryId in create_discussion.cjs: exact ID first, then the name and
+// slug compared case-insensitively.
+
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var discussionCategoryLog = logger.New("workflow:discussion_category_validation")
+
+// DiscussionCategory is a discussion category of a repository
+type DiscussionCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// findDiscussionCategory returns the category matching the configured value by ID, name or slug
+func findDiscussionCategory(categories []DiscussionCategory, category string) *DiscussionCategory {
+	for i := range categories {
+		if categories[i].ID == category {
+			return &categories[i]
+		}
+	}
+	for i := range categories {
+		if strings.EqualFold(categories[i].Name, category) {
+			return &categories[i]
+		}
+	}
+	for i := range categories {
+		if strings.EqualFold(categories[i].Slug, category) {
+			return &categories[i]
+		}
+	}
+	return nil
+}
+
+// validateDiscussionCategory checks that the configured category exists in the repository,
+// returning an error listing the valid categories when it does not
+func validateDiscussionCategory(repo, category string, categories []DiscussionCategory) error {
+	if match := findDiscussionCategory(categories, category); match != nil {
+		discussionCategoryLog.Printf("Category %q matches %q (%s) in %s", category, match.Name, match.ID, repo)
+		return nil
+	}
+
+	names := make([]string, 0, len(categories))
+	for _, cat := range categories {
+		names = append(names, cat.Name)
+	}
+	return fmt.Errorf("safe-outputs.create-discussion.category '%s' does not exist in repository %s. Valid categories: %s",
+		category, repo, strings.Join(names, ", "))
+}
+
+// discussionCategoryTargetRepo returns the repository whose categories the create-discussion
+// category should be checked against, or an empty string when it cannot be known at compile time
+func discussionCategoryTargetRepo(config *CreateDiscussionsConfig, currentRepo string) string {
+	if config.TargetRepoSlug == "" {
+		return currentRepo
+	}
+	if config.TargetRepoSlug == "*" || isGitHubExpression(config.TargetRepoSlug) {
+		return ""
+	}
+	return config.TargetRepoSlug
+}
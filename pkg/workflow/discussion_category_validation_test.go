@@ -0,0 +1,87 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDiscussionCategories = []DiscussionCategory{
+	{ID: "DIC_kwDOGFsHUM4BsUn1", Name: "Announcements", Slug: "announcements"},
+	{ID: "DIC_kwDOGFsHUM4BsUn2", Name: "General", Slug: "general"},
+	{ID: "DIC_kwDOGFsHUM4BsUn3", Name: "Q&A", Slug: "q-a"},
+}
+
+func TestValidateDiscussionCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		errorMsg string
+	}{
+		{name: "matching ID", category: "DIC_kwDOGFsHUM4BsUn2"},
+		{name: "matching name", category: "General"},
+		{name: "matching name case insensitive", category: "q&a"},
+		{name: "matching slug", category: "q-a"},
+		{
+			name:     "non-matching category",
+			category: "Reports",
+			errorMsg: "safe-outputs.create-discussion.category 'Reports' does not exist in repository octo/repo. Valid categories: Announcements, General, Q&A",
+		},
+		{
+			name:     "ID is case sensitive",
+			category: "dic_kwdogfshum4bsun2",
+			errorMsg: "does not exist in repository octo/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDiscussionCategory("octo/repo", tt.category, testDiscussionCategories)
+			if tt.errorMsg == "" {
+				assert.NoError(t, err, "Expected category to match")
+				return
+			}
+			require.Error(t, err, "Expected category to be rejected")
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestDiscussionCategoryTargetRepo(t *testing.T) {
+	assert.Equal(t, "octo/repo", discussionCategoryTargetRepo(&CreateDiscussionsConfig{}, "octo/repo"))
+	assert.Equal(t, "octo/other", discussionCategoryTargetRepo(&CreateDiscussionsConfig{TargetRepoSlug: "octo/other"}, "octo/repo"))
+	assert.Empty(t, discussionCategoryTargetRepo(&CreateDiscussionsConfig{TargetRepoSlug: "*"}, "octo/repo"))
+	assert.Empty(t, discussionCategoryTargetRepo(&CreateDiscussionsConfig{TargetRepoSlug: "${{ vars.TARGET_REPO }}"}, "octo/repo"))
+}
+
+func TestValidateCreateDiscussionCategoryWithCachedCategories(t *testing.T) {
+	ClearRepositoryFeaturesCache()
+	t.Cleanup(ClearRepositoryFeaturesCache)
+	discussionCategoriesCache.Store("octo/repo", testDiscussionCategories)
+
+	compiler := NewCompiler()
+
+	t.Run("matching category", func(t *testing.T) {
+		err := compiler.validateCreateDiscussionCategory(&CreateDiscussionsConfig{Category: "general"}, "octo/repo")
+		assert.NoError(t, err)
+	})
+
+	t.Run("non-matching category", func(t *testing.T) {
+		err := compiler.validateCreateDiscussionCategory(&CreateDiscussionsConfig{Category: "Reports"}, "octo/repo")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Valid categories: Announcements, General, Q&A")
+	})
+
+	t.Run("expression category is skipped", func(t *testing.T) {
+		err := compiler.validateCreateDiscussionCategory(&CreateDiscussionsConfig{Category: "${{ inputs.category }}"}, "octo/repo")
+		assert.NoError(t, err)
+	})
+
+	t.Run("no category configured", func(t *testing.T) {
+		assert.NoError(t, compiler.validateCreateDiscussionCategory(&CreateDiscussionsConfig{}, "octo/repo"))
+		assert.NoError(t, compiler.validateCreateDiscussionCategory(nil, "octo/repo"))
+	})
+}
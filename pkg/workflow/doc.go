@@ -29,6 +29,8 @@
 //  4. Job generation - Creates main agent job, activation jobs, and safe output jobs
 //  5. YAML generation - Produces final GitHub Actions workflow with security features
 //
+// Callers can observe how long each phase takes by registering a callback with WithTracer.
+//
 // # Key Components
 //
 // Compiler: The main orchestrator that coordinates all compilation phases.
@@ -7,6 +7,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 )
 
 var dockerLog = logger.New("workflow:docker")
@@ -22,7 +23,7 @@ func collectDockerImages(tools map[string]any, workflowData *WorkflowData, actio
 		// Only add if using local (Docker) mode
 		if githubType == "local" {
 			githubDockerImageVersion := getGitHubDockerImageVersion(githubTool)
-			image := "ghcr.io/github/github-mcp-server:" + githubDockerImageVersion
+			image := parser.DockerImageReference("ghcr.io/github/github-mcp-server", githubDockerImageVersion)
 			if !imageSet[image] {
 				images = append(images, image)
 				imageSet[image] = true
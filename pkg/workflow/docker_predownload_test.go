@@ -38,6 +38,23 @@ Test workflow.`,
 			},
 			expectStep: true,
 		},
+		{
+			name: "GitHub tool pinned by digest downloads the digest reference",
+			frontmatter: `---
+on: issues
+engine: claude
+tools:
+  github:
+    version: sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+---
+
+# Test
+Test workflow.`,
+			expectedImages: []string{
+				"ghcr.io/github/github-mcp-server@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			},
+			expectStep: true,
+		},
 		{
 			name: "GitHub remote mode does not generate GitHub MCP docker image but still downloads MCP gateway",
 			frontmatter: `---
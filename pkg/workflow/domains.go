@@ -388,6 +388,20 @@ func matchesDomain(domain, pattern string) bool {
 	return false
 }
 
+// IsDomainCoveredByAllowedEntry reports whether domain is allowed by a network.allowed entry.
+// The entry may be a plain domain, a *.wildcard pattern, or an ecosystem identifier such as "python".
+func IsDomainCoveredByAllowedEntry(domain, entry string) bool {
+	if isEcosystemIdentifier(entry) {
+		for _, ecosystemDomain := range getEcosystemDomains(entry) {
+			if matchesDomain(domain, ecosystemDomain) {
+				return true
+			}
+		}
+		return false
+	}
+	return matchesDomain(domain, entry)
+}
+
 // extractHTTPMCPDomains extracts domain names from HTTP MCP server URLs in tools configuration
 // Returns a slice of domain names (e.g., ["mcp.tavily.com", "api.example.com"])
 func extractHTTPMCPDomains(tools map[string]any) []string {
@@ -437,6 +451,53 @@ func extractHTTPMCPDomains(tools map[string]any) []string {
 	return domains
 }
 
+// getMCPServerNetwork returns the per-server network.allowed entries and network.proxy-args
+// of a custom MCP server configuration
+func getMCPServerNetwork(serverConfig map[string]any) ([]string, []string) {
+	networkMap, ok := serverConfig["network"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	network := MapToolConfig(networkMap)
+	allowed, _ := network.GetStringArray("allowed")
+	proxyArgs, _ := network.GetStringArray("proxy-args")
+	return allowed, proxyArgs
+}
+
+// extractMCPNetworkDomains collects the network.allowed entries of custom stdio MCP servers
+// so they can be merged into the firewall allowlist. Ecosystem identifiers are expanded.
+// Returns a slice of domain names (e.g., ["registry.npmjs.org", "api.example.com"])
+func extractMCPNetworkDomains(tools map[string]any) []string {
+	if tools == nil {
+		return []string{}
+	}
+
+	domains := []string{}
+
+	for toolName, toolConfig := range tools {
+		configMap, ok := toolConfig.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		// Only stdio servers run inside the sandbox; HTTP server domains come from their URL
+		if hasMCP, mcpType := hasMCPConfig(configMap); !hasMCP || mcpType != "stdio" {
+			continue
+		}
+
+		allowed, _ := getMCPServerNetwork(configMap)
+		if len(allowed) == 0 {
+			continue
+		}
+
+		expanded := GetAllowedDomains(&NetworkPermissions{Allowed: allowed})
+		domainsLog.Printf("Extracted %d network domains from MCP server '%s'", len(expanded), toolName)
+		domains = append(domains, expanded...)
+	}
+
+	return domains
+}
+
 // extractPlaywrightDomains returns Playwright domains when Playwright tool is configured
 // Returns a slice of domain names required for Playwright browser downloads
 // These domains are needed when Playwright MCP server initializes in the Docker container
@@ -454,6 +515,31 @@ func extractPlaywrightDomains(tools map[string]any) []string {
 	return []string{}
 }
 
+// getWebFetchAllowed returns the allowed entries of a web-fetch tool configuration map
+func getWebFetchAllowed(configMap map[string]any) []string {
+	allowed, _ := MapToolConfig(configMap).GetStringArray("allowed")
+	return allowed
+}
+
+// extractWebFetchDomains returns the web-fetch allowlist domains when the web-fetch tool is
+// configured with an allowed list, expanding ecosystem identifiers.
+// These domains are added to the firewall so they do not have to be repeated in network.allowed.
+func extractWebFetchDomains(tools map[string]any) []string {
+	configMap, ok := tools["web-fetch"].(map[string]any)
+	if !ok {
+		return []string{}
+	}
+
+	allowed := getWebFetchAllowed(configMap)
+	if len(allowed) == 0 {
+		return []string{}
+	}
+
+	domains := GetAllowedDomains(&NetworkPermissions{Allowed: allowed})
+	domainsLog.Printf("Detected web-fetch allowlist, adding %d domains", len(domains))
+	return domains
+}
+
 // mergeDomainsWithNetwork combines default domains with NetworkPermissions allowed domains
 // Returns a deduplicated, sorted, comma-separated string suitable for AWF's --allow-domains flag
 func mergeDomainsWithNetwork(defaultDomains []string, network *NetworkPermissions) string {
@@ -466,7 +552,7 @@ func mergeDomainsWithNetworkAndTools(defaultDomains []string, network *NetworkPe
 	return mergeDomainsWithNetworkToolsAndRuntimes(defaultDomains, network, tools, nil)
 }
 
-// mergeDomainsWithNetworkToolsAndRuntimes combines default domains with NetworkPermissions, HTTP MCP server domains, and runtime ecosystem domains
+// mergeDomainsWithNetworkToolsAndRuntimes combines default domains with NetworkPermissions, MCP server domains, and runtime ecosystem domains
 // Returns a deduplicated, sorted, comma-separated string suitable for AWF's --allow-domains flag
 func mergeDomainsWithNetworkToolsAndRuntimes(defaultDomains []string, network *NetworkPermissions, tools map[string]any, runtimes map[string]any) string {
 	domainMap := make(map[string]bool)
@@ -493,6 +579,13 @@ func mergeDomainsWithNetworkToolsAndRuntimes(defaultDomains []string, network *N
 		}
 	}
 
+	// Add per-server network domains of custom stdio MCP servers (if tools are specified)
+	if tools != nil {
+		for _, domain := range extractMCPNetworkDomains(tools) {
+			domainMap[domain] = true
+		}
+	}
+
 	// Add Playwright ecosystem domains (if Playwright tool is specified)
 	// This ensures browser binaries can be downloaded when Playwright initializes
 	if tools != nil {
@@ -502,6 +595,13 @@ func mergeDomainsWithNetworkToolsAndRuntimes(defaultDomains []string, network *N
 		}
 	}
 
+	// Add web-fetch allowlist domains (if web-fetch is restricted to specific domains)
+	if tools != nil {
+		for _, domain := range extractWebFetchDomains(tools) {
+			domainMap[domain] = true
+		}
+	}
+
 	// Add runtime ecosystem domains (if runtimes are specified)
 	if runtimes != nil {
 		runtimeDomains := getDomainsFromRuntimes(runtimes)
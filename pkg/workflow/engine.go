@@ -17,7 +17,9 @@ type EngineConfig struct {
 	Version     string
 	Model       string
 	MaxTurns    string
-	Concurrency string // Agent job-level concurrency configuration (YAML format)
+	MaxTokens   int      // Maximum output tokens per model response (0 = engine default)
+	Temperature *float64 // Sampling temperature (nil = engine default)
+	Concurrency string   // Agent job-level concurrency configuration (YAML format)
 	UserAgent   string
 	Command     string // Custom executable path (when set, skip installation steps)
 	Env         map[string]string
@@ -25,6 +27,7 @@ type EngineConfig struct {
 	Args        []string
 	Firewall    *FirewallConfig // AWF firewall configuration
 	Agent       string          // Agent identifier for copilot --agent flag (copilot engine only)
+	Fallbacks   []string        // Ordered engine IDs to try when the primary engine fails with a provider error
 }
 
 // NetworkPermissions represents network access permissions for workflow execution
@@ -58,10 +61,13 @@ type EngineConfig struct {
 // Ecosystem identifiers in the Allowed list are expanded to their corresponding domain lists.
 // See GetAllowedDomains() for the list of supported ecosystem identifiers.
 type NetworkPermissions struct {
-	Allowed           []string        `yaml:"allowed,omitempty"`  // List of allowed domains or ecosystem identifiers (e.g., "defaults", "github", "python")
-	Blocked           []string        `yaml:"blocked,omitempty"`  // List of blocked domains (takes precedence over allowed)
-	Firewall          *FirewallConfig `yaml:"firewall,omitempty"` // AWF firewall configuration (see firewall.go)
-	ExplicitlyDefined bool            `yaml:"-"`                  // Internal flag: true if network field was explicitly set in frontmatter
+	Allowed           []string        `yaml:"allowed,omitempty"`      // List of allowed domains or ecosystem identifiers (e.g., "defaults", "github", "python")
+	AllowedFrom       string          `yaml:"allowed-from,omitempty"` // Repository-relative path of a shared allowlist file merged into Allowed (see network_allowlist_file.go)
+	Blocked           []string        `yaml:"blocked,omitempty"`      // List of blocked domains (takes precedence over allowed)
+	Firewall          *FirewallConfig `yaml:"firewall,omitempty"`     // AWF firewall configuration (see firewall.go)
+	Log               bool            `yaml:"log,omitempty"`          // Log every allowed and denied connection to /tmp/gh-aw/network.log (see network_log.go)
+	Mode              string          `yaml:"mode,omitempty"`         // Enforcement mode: "enforce" (default) or "audit" (report blocked hosts)
+	ExplicitlyDefined bool            `yaml:"-"`                      // Internal flag: true if network field was explicitly set in frontmatter
 }
 
 // EngineNetworkConfig combines engine configuration with top-level network permissions
@@ -81,6 +87,22 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 			return engineStr, &EngineConfig{ID: engineStr}
 		}
 
+		// Handle array format (ordered fallback chain: first entry is the primary engine)
+		if engineList, ok := engine.([]any); ok {
+			var engineIDs []string
+			for _, item := range engineList {
+				if idStr, ok := item.(string); ok && idStr != "" {
+					engineIDs = append(engineIDs, idStr)
+				}
+			}
+			if len(engineIDs) == 0 {
+				engineLog.Print("Engine list is empty, ignoring")
+				return "", nil
+			}
+			engineLog.Printf("Found engine fallback chain: %v", engineIDs)
+			return engineIDs[0], &EngineConfig{ID: engineIDs[0], Fallbacks: engineIDs[1:]}
+		}
+
 		// Handle object format
 		if engineObj, ok := engine.(map[string]any); ok {
 			engineLog.Print("Found engine in object format, parsing configuration")
@@ -116,6 +138,37 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 				}
 			}
 
+			// Extract optional 'max-tokens' field
+			if maxTokens, hasMaxTokens := engineObj["max-tokens"]; hasMaxTokens {
+				switch v := maxTokens.(type) {
+				case int:
+					config.MaxTokens = v
+				case int64:
+					config.MaxTokens = int(v)
+				case uint64:
+					config.MaxTokens = int(v)
+				case float64:
+					config.MaxTokens = int(v)
+				}
+			}
+
+			// Extract optional 'temperature' field
+			if temperature, hasTemperature := engineObj["temperature"]; hasTemperature {
+				switch v := temperature.(type) {
+				case float64:
+					config.Temperature = &v
+				case int:
+					f := float64(v)
+					config.Temperature = &f
+				case int64:
+					f := float64(v)
+					config.Temperature = &f
+				case uint64:
+					f := float64(v)
+					config.Temperature = &f
+				}
+			}
+
 			// Extract optional 'concurrency' field (string or object format)
 			if concurrency, hasConcurrency := engineObj["concurrency"]; hasConcurrency {
 				if concurrencyStr, ok := concurrency.(string); ok {
@@ -0,0 +1,141 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEngineEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		expectError string
+	}{
+		{
+			name: "no env",
+		},
+		{
+			name: "valid names",
+			env:  map[string]string{"FEATURE_FLAG": "enabled", "_private": "1", "Api2_Key": "${{ secrets.API_KEY }}"},
+		},
+		{
+			name:        "name starting with a digit",
+			env:         map[string]string{"2FA_MODE": "on"},
+			expectError: `invalid engine.env variable name "2FA_MODE"`,
+		},
+		{
+			name:        "name with a dash",
+			env:         map[string]string{"FEATURE-FLAG": "on"},
+			expectError: `invalid engine.env variable name "FEATURE-FLAG"`,
+		},
+		{
+			name:        "empty name",
+			env:         map[string]string{"": "on"},
+			expectError: `invalid engine.env variable name ""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewCompiler().validateEngineEnv(&EngineConfig{ID: "copilot", Env: tt.env})
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetEngineEnvKeys(t *testing.T) {
+	assert.Nil(t, getEngineEnvKeys(nil), "No workflow data should have no keys")
+	assert.Nil(t, getEngineEnvKeys(&WorkflowData{}), "No engine config should have no keys")
+	assert.Equal(t, []string{"A_VAR", "B_VAR"}, getEngineEnvKeys(&WorkflowData{
+		EngineConfig: &EngineConfig{Env: map[string]string{"B_VAR": "b", "A_VAR": "a"}},
+	}), "Keys should be sorted")
+}
+
+func TestCompileWorkflowEngineEnvOnlyOnAgentStep(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "engine-env-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+strict: false
+engine:
+  id: copilot
+  env:
+    AGENT_FEATURE_FLAG: "enabled"
+    AGENT_FEATURE_TOKEN: ${{ secrets.AGENT_FEATURE_TOKEN }}
+safe-outputs:
+  create-issue:
+---
+
+# Engine Env
+
+Read the feature flag and open an issue.
+`
+
+	testFile := filepath.Join(tmpDir, "engine-env.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with engine.env should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	agentJob := extractJobSection(string(lockContent), "agent")
+
+	stepStart := strings.Index(agentJob, "- name: Execute GitHub Copilot CLI")
+	require.NotEqual(t, -1, stepStart, "Agent job should run the agent")
+	stepEnd := strings.Index(agentJob[stepStart+1:], "\n      - name: ")
+	require.NotEqual(t, -1, stepEnd, "Agent step should be followed by other steps")
+	agentStep := agentJob[stepStart : stepStart+1+stepEnd]
+
+	assert.Contains(t, agentStep, "AGENT_FEATURE_FLAG: enabled", "Agent step should set the engine.env variable")
+	assert.Contains(t, agentStep, "AGENT_FEATURE_TOKEN: ${{ secrets.AGENT_FEATURE_TOKEN }}",
+		"Agent step should keep secrets explicitly passed through engine.env")
+	assert.Equal(t, 1, strings.Count(agentJob, " AGENT_FEATURE_FLAG:"), "Only the agent step should set the engine.env variable")
+	assert.Equal(t, 1, strings.Count(agentJob, " AGENT_FEATURE_TOKEN:"), "Only the agent step should set the engine.env secret")
+	assert.Contains(t, agentJob, "SECRET_AGENT_FEATURE_TOKEN: ${{ secrets.AGENT_FEATURE_TOKEN }}",
+		"Secrets referenced in engine.env should be redacted from the agent logs")
+
+	safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+	require.NotEmpty(t, safeOutputsJob, "Workflow should have a safe outputs job")
+	assert.NotContains(t, safeOutputsJob, "AGENT_FEATURE_FLAG", "Safe output steps should not see engine.env variables")
+	assert.NotContains(t, safeOutputsJob, "AGENT_FEATURE_TOKEN", "Safe output steps should not see engine.env secrets")
+}
+
+func TestCompileWorkflowRejectsInvalidEngineEnvName(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "engine-env-invalid-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: copilot
+  env:
+    FEATURE-FLAG: "enabled"
+---
+
+# Engine Env
+
+Do the work.
+`
+
+	testFile := filepath.Join(tmpDir, "engine-env-invalid.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Invalid engine.env names should be rejected")
+	assert.Contains(t, err.Error(), `invalid engine.env variable name "FEATURE-FLAG"`)
+}
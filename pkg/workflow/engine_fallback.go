@@ -0,0 +1,225 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var engineFallbackLog = logger.New("workflow:engine_fallback")
+
+// Engine fallback chains
+//
+// A workflow may list several engines (engine: [copilot, claude]). The main job runs the
+// first engine and, when it fails with a provider-level error, runs the next engine in the
+// list. Each attempt runs with continue-on-error so that a successful fallback leaves the
+// job green. After a failed attempt, the engine_fallback.cjs classifier inspects the agent
+// log written by that attempt:
+//
+//   - Fallback-eligible failures are provider errors: authentication (invalid API key, 401),
+//     quota and rate limits (429, quota exhausted) and server errors (5xx, overloaded).
+//     The classifier sets the "fallback" output and the next engine runs.
+//   - Every other failure (tool errors, agent errors, timeouts) is a hard failure. The
+//     classifier fails the job and no further engines run.
+//
+// When the last engine in the chain fails, the classifier always fails the job.
+
+// engineFallbackScriptName is the name of the JavaScript classifier run after a failed attempt
+const engineFallbackScriptName = "engine_fallback"
+
+// hasEngineFallbacks reports whether the workflow configures an engine fallback chain
+func hasEngineFallbacks(data *WorkflowData) bool {
+	return data.EngineConfig != nil && len(data.EngineConfig.Fallbacks) > 0
+}
+
+// engineAttemptStepID returns the step ID of the execution step for the given attempt.
+// Attempt 0 is the primary engine and keeps the standard agentic_execution ID.
+func engineAttemptStepID(attempt int) string {
+	if attempt == 0 {
+		return "agentic_execution"
+	}
+	return fmt.Sprintf("agentic_execution_fallback_%d", attempt)
+}
+
+// engineFallbackStepID returns the step ID of the classifier that runs after the given attempt
+func engineFallbackStepID(attempt int) string {
+	return fmt.Sprintf("engine_fallback_%d", attempt)
+}
+
+// generateEngineExecutionStepsWithFallback generates the execution steps for the primary engine
+// followed by the conditional installation, MCP configuration and execution steps of each
+// fallback engine, with a classifier step after every attempt.
+func (c *Compiler) generateEngineExecutionStepsWithFallback(yaml *strings.Builder, data *WorkflowData, engine CodingAgentEngine, logFile string) error {
+	fallbacks := data.EngineConfig.Fallbacks
+	engineFallbackLog.Printf("Generating engine fallback chain: %s -> %s", engine.GetID(), strings.Join(fallbacks, " -> "))
+
+	// Primary engine attempt
+	writeGitHubActionSteps(yaml, markEngineAttemptSteps(applyAgentPhaseTimeout(data, engine.GetExecutionSteps(data, logFile)), 0, ""))
+	c.generateEngineFallbackClassifierStep(yaml, engine, fallbacks[0], logFile, 0)
+
+	for i, fallbackID := range fallbacks {
+		attempt := i + 1
+		fallbackEngine, err := c.getAgenticEngine(fallbackID)
+		if err != nil {
+			return fmt.Errorf("failed to get fallback engine %s: %w", fallbackID, err)
+		}
+
+		condition := fmt.Sprintf("steps.%s.outputs.fallback == 'true'", engineFallbackStepID(attempt-1))
+
+		// Install the fallback engine (the primary engine was installed earlier in the job)
+		for _, step := range fallbackEngine.GetInstallationSteps(data) {
+			step = suffixStepID(step, fmt.Sprintf("-fallback-%d", attempt))
+			writeGitHubActionSteps(yaml, []GitHubActionStep{addStepCondition(step, condition)})
+		}
+
+		// Point the fallback engine at the MCP gateway that is already running
+		if HasMCPServers(data) {
+			writeGitHubActionSteps(yaml, []GitHubActionStep{buildEngineFallbackMCPConfigStep(data, fallbackEngine, condition)})
+		}
+
+		writeGitHubActionSteps(yaml, markEngineAttemptSteps(applyAgentPhaseTimeout(data, fallbackEngine.GetExecutionSteps(data, logFile)), attempt, condition))
+
+		nextEngine := ""
+		if attempt < len(fallbacks) {
+			nextEngine = fallbacks[attempt]
+		}
+		c.generateEngineFallbackClassifierStep(yaml, fallbackEngine, nextEngine, logFile, attempt)
+	}
+
+	return nil
+}
+
+// generateEngineFallbackClassifierStep generates the step that classifies a failed engine attempt.
+// nextEngine is empty for the last engine in the chain, in which case any failure fails the job.
+func (c *Compiler) generateEngineFallbackClassifierStep(yaml *strings.Builder, engine CodingAgentEngine, nextEngine string, logFile string, attempt int) {
+	fmt.Fprintf(yaml, "      - name: Check %s failure for engine fallback\n", engine.GetDisplayName())
+	fmt.Fprintf(yaml, "        id: %s\n", engineFallbackStepID(attempt))
+	fmt.Fprintf(yaml, "        if: steps.%s.outcome == 'failure'\n", engineAttemptStepID(attempt))
+	fmt.Fprintf(yaml, "        uses: %s\n", GetActionPin("actions/github-script"))
+	yaml.WriteString("        env:\n")
+	fmt.Fprintf(yaml, "          GH_AW_AGENT_LOG: %s\n", logFile)
+	fmt.Fprintf(yaml, "          GH_AW_ENGINE_ID: %s\n", engine.GetID())
+	if nextEngine != "" {
+		fmt.Fprintf(yaml, "          GH_AW_FALLBACK_ENGINE: %s\n", nextEngine)
+	}
+	yaml.WriteString("        with:\n")
+	yaml.WriteString("          script: |\n")
+	yaml.WriteString("            const { setupGlobals } = require('" + SetupActionDestination + "/setup_globals.cjs');\n")
+	yaml.WriteString("            setupGlobals(core, github, context, exec, io);\n")
+	yaml.WriteString("            const { main } = require('/opt/gh-aw/actions/" + engineFallbackScriptName + ".cjs');\n")
+	yaml.WriteString("            await main();\n")
+}
+
+// buildEngineFallbackMCPConfigStep builds a step that converts the running MCP gateway's output
+// into the client configuration format expected by the fallback engine
+func buildEngineFallbackMCPConfigStep(data *WorkflowData, engine CodingAgentEngine, condition string) GitHubActionStep {
+	return GitHubActionStep{
+		fmt.Sprintf("      - name: Configure MCP servers for %s", engine.GetDisplayName()),
+		"        if: " + condition,
+		"        env:",
+		"          MCP_GATEWAY_OUTPUT: /tmp/gh-aw/mcp-config/gateway-output.json",
+		"          MCP_GATEWAY_DOMAIN: " + mcpGatewayDomain(data),
+		"          MCP_GATEWAY_PORT: ${{ steps.start-mcp-gateway.outputs.gateway-port }}",
+		"        run: |",
+		fmt.Sprintf("          bash /opt/gh-aw/actions/convert_gateway_config_%s.sh", engine.GetID()),
+	}
+}
+
+// markEngineAttemptSteps prepares the execution steps of one engine attempt. The last step is the
+// agent execution step: it gets the attempt's step ID and continue-on-error so that a failure can
+// be classified. A non-empty condition is added to every step.
+func markEngineAttemptSteps(steps []GitHubActionStep, attempt int, condition string) []GitHubActionStep {
+	marked := make([]GitHubActionStep, 0, len(steps))
+	for i, step := range steps {
+		if attempt > 0 {
+			step = suffixStepID(step, fmt.Sprintf("-fallback-%d", attempt))
+		}
+		isExecutionStep := i == len(steps)-1
+		if isExecutionStep {
+			step = insertStepKey(step, "        continue-on-error: true")
+		}
+		if condition != "" {
+			step = addStepCondition(step, condition)
+		}
+		if isExecutionStep {
+			step = setStepID(step, engineAttemptStepID(attempt))
+		}
+		marked = append(marked, step)
+	}
+	return marked
+}
+
+// writeGitHubActionSteps writes steps to the YAML builder, one line at a time
+func writeGitHubActionSteps(yaml *strings.Builder, steps []GitHubActionStep) {
+	for _, step := range steps {
+		for _, line := range step {
+			yaml.WriteString(line + "\n")
+		}
+	}
+}
+
+// stepKeyIndex returns the index of the step-level key line (e.g. "        id: ") or -1
+func stepKeyIndex(step GitHubActionStep, key string) int {
+	prefix := "        " + key + ":"
+	for i, line := range step {
+		if line == prefix || strings.HasPrefix(line, prefix+" ") {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertStepKey inserts a step-level key line directly after the first line of the step
+func insertStepKey(step GitHubActionStep, line string) GitHubActionStep {
+	result := make(GitHubActionStep, 0, len(step)+1)
+	result = append(result, step[0], line)
+	return append(result, step[1:]...)
+}
+
+// setStepID sets the ID of a step, replacing any existing ID
+func setStepID(step GitHubActionStep, id string) GitHubActionStep {
+	result := append(GitHubActionStep{}, step...)
+	if idx := stepKeyIndex(result, "id"); idx >= 0 {
+		result[idx] = "        id: " + id
+		return result
+	}
+	return insertStepKey(result, "        id: "+id)
+}
+
+// suffixStepID appends a suffix to the ID of a step so that repeated steps keep unique IDs
+func suffixStepID(step GitHubActionStep, suffix string) GitHubActionStep {
+	idx := stepKeyIndex(step, "id")
+	if idx < 0 {
+		return step
+	}
+	return setStepID(step, strings.TrimSpace(strings.TrimPrefix(step[idx], "        id:"))+suffix)
+}
+
+// addStepCondition adds a condition to a step, combining it with any existing if: expression
+func addStepCondition(step GitHubActionStep, condition string) GitHubActionStep {
+	idx := stepKeyIndex(step, "if")
+	if idx < 0 {
+		return insertStepKey(step, "        if: "+condition)
+	}
+
+	// Collect the existing expression, including block scalar (if: |) continuation lines
+	existing := strings.TrimSpace(strings.TrimPrefix(step[idx], "        if:"))
+	end := idx + 1
+	if existing == "|" || existing == ">" {
+		var parts []string
+		for end < len(step) && strings.HasPrefix(step[end], "          ") {
+			parts = append(parts, strings.TrimSpace(step[end]))
+			end++
+		}
+		existing = strings.Join(parts, " ")
+	}
+	if strings.HasPrefix(existing, "${{") && strings.HasSuffix(existing, "}}") {
+		existing = strings.TrimSpace(existing[3 : len(existing)-2])
+	}
+
+	result := make(GitHubActionStep, 0, len(step))
+	result = append(result, step[:idx]...)
+	result = append(result, fmt.Sprintf("        if: (%s) && (%s)", existing, condition))
+	return append(result, step[end:]...)
+}
@@ -0,0 +1,219 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractEngineConfigFallbackList(t *testing.T) {
+	compiler := NewCompiler()
+
+	engineSetting, config := compiler.ExtractEngineConfig(map[string]any{
+		"engine": []any{"copilot", "claude", "codex"},
+	})
+	require.NotNil(t, config, "Engine list should produce a config")
+	assert.Equal(t, "copilot", engineSetting, "First engine in the list should be the primary engine")
+	assert.Equal(t, "copilot", config.ID, "Config ID should be the primary engine")
+	assert.Equal(t, []string{"claude", "codex"}, config.Fallbacks, "Remaining engines should be fallbacks in order")
+
+	engineSetting, config = compiler.ExtractEngineConfig(map[string]any{
+		"engine": []any{"claude"},
+	})
+	require.NotNil(t, config, "Single-entry list should produce a config")
+	assert.Equal(t, "claude", engineSetting, "Single-entry list should select that engine")
+	assert.Empty(t, config.Fallbacks, "Single-entry list should have no fallbacks")
+
+	engineSetting, config = compiler.ExtractEngineConfig(map[string]any{
+		"engine": []any{},
+	})
+	assert.Empty(t, engineSetting, "Empty list should not select an engine")
+	assert.Nil(t, config, "Empty list should not produce a config")
+}
+
+func TestValidateEngineFallbacks(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *EngineConfig
+		errContains string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+		},
+		{
+			name:   "no fallbacks",
+			config: &EngineConfig{ID: "copilot"},
+		},
+		{
+			name:   "valid chain",
+			config: &EngineConfig{ID: "copilot", Fallbacks: []string{"claude", "codex"}},
+		},
+		{
+			name:        "unknown fallback engine",
+			config:      &EngineConfig{ID: "copilot", Fallbacks: []string{"claud"}},
+			errContains: "invalid fallback engine",
+		},
+		{
+			name:        "fallback repeats primary",
+			config:      &EngineConfig{ID: "copilot", Fallbacks: []string{"copilot"}},
+			errContains: `engine "copilot" appears more than once`,
+		},
+		{
+			name:        "duplicate fallback",
+			config:      &EngineConfig{ID: "copilot", Fallbacks: []string{"claude", "claude"}},
+			errContains: `engine "claude" appears more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewCompiler().validateEngineFallbacks(tt.config)
+			if tt.errContains == "" {
+				assert.NoError(t, err, "Fallback chain should be valid")
+				return
+			}
+			require.Error(t, err, "Fallback chain should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "Error should explain the rejection")
+		})
+	}
+}
+
+func TestAddStepCondition(t *testing.T) {
+	tests := []struct {
+		name     string
+		step     GitHubActionStep
+		expected GitHubActionStep
+	}{
+		{
+			name:     "step without condition",
+			step:     GitHubActionStep{"      - name: Install", "        run: npm install"},
+			expected: GitHubActionStep{"      - name: Install", "        if: cond", "        run: npm install"},
+		},
+		{
+			name:     "step with existing condition",
+			step:     GitHubActionStep{"      - name: Install", "        if: always()", "        run: npm install"},
+			expected: GitHubActionStep{"      - name: Install", "        if: (always()) && (cond)", "        run: npm install"},
+		},
+		{
+			name:     "step with expression condition",
+			step:     GitHubActionStep{"      - name: Install", "        if: ${{ env.X == 'y' }}", "        run: npm install"},
+			expected: GitHubActionStep{"      - name: Install", "        if: (env.X == 'y') && (cond)", "        run: npm install"},
+		},
+		{
+			name:     "step with block condition",
+			step:     GitHubActionStep{"      - name: Install", "        if: |", "          a ||", "          b", "        run: npm install"},
+			expected: GitHubActionStep{"      - name: Install", "        if: (a || b) && (cond)", "        run: npm install"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, addStepCondition(tt.step, "cond"), "Condition should be combined")
+		})
+	}
+}
+
+func TestMarkEngineAttemptSteps(t *testing.T) {
+	steps := []GitHubActionStep{
+		{"      - name: Write settings", "        id: settings", "        run: echo settings"},
+		{"      - name: Execute CLI", "        id: agentic_execution", "        run: cli"},
+	}
+
+	marked := markEngineAttemptSteps(steps, 2, "steps.engine_fallback_1.outputs.fallback == 'true'")
+	require.Len(t, marked, 2, "All steps should be kept")
+
+	setup := strings.Join(marked[0], "\n")
+	assert.Contains(t, setup, "id: settings-fallback-2", "Setup step IDs should stay unique")
+	assert.Contains(t, setup, "if: steps.engine_fallback_1.outputs.fallback == 'true'", "Setup step should only run on fallback")
+	assert.NotContains(t, setup, "continue-on-error", "Only the execution step should continue on error")
+
+	execution := strings.Join(marked[1], "\n")
+	assert.Contains(t, execution, "id: agentic_execution_fallback_2", "Execution step should use the attempt ID")
+	assert.Contains(t, execution, "continue-on-error: true", "Execution step should continue on error")
+	assert.Contains(t, execution, "if: steps.engine_fallback_1.outputs.fallback == 'true'", "Execution step should only run on fallback")
+
+	primary := markEngineAttemptSteps(steps, 0, "")
+	primaryExecution := strings.Join(primary[1], "\n")
+	assert.Contains(t, primaryExecution, "id: agentic_execution\n", "Primary execution step should keep the standard ID")
+	assert.NotContains(t, primaryExecution, "if:", "Primary execution step should run unconditionally")
+}
+
+func TestCompileWorkflowWithEngineFallback(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "engine-fallback-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: [copilot, claude]
+safe-outputs:
+  add-comment:
+---
+
+# Engine Fallback
+
+Summarize the repository.
+`
+
+	testFile := filepath.Join(tmpDir, "fallback.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with an engine list should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+
+	agentJob := extractJobSection(string(lockContent), "agent")
+	require.NotEmpty(t, agentJob, "Lock file should contain the agent job")
+
+	// Primary attempt
+	assert.Contains(t, agentJob, "- name: Execute GitHub Copilot CLI\n        continue-on-error: true\n        id: agentic_execution\n", "Primary engine should continue on error")
+	assert.Contains(t, agentJob, "id: engine_fallback_0\n        if: steps.agentic_execution.outcome == 'failure'", "Primary failure should be classified")
+	assert.Contains(t, agentJob, "GH_AW_FALLBACK_ENGINE: claude", "Classifier should name the next engine")
+
+	// Fallback attempt
+	fallbackCondition := "if: steps.engine_fallback_0.outputs.fallback == 'true'"
+	assert.Contains(t, agentJob, "- name: Install Claude Code CLI\n        "+fallbackCondition, "Fallback engine should only be installed on fallback")
+	assert.Contains(t, agentJob, "id: validate-secret-fallback-1", "Fallback install step IDs should be unique")
+	assert.Contains(t, agentJob, "bash /opt/gh-aw/actions/convert_gateway_config_claude.sh", "Fallback engine should get its MCP client config")
+	assert.Contains(t, agentJob, "- name: Execute Claude Code CLI\n        "+fallbackCondition, "Fallback engine should only run on fallback")
+	assert.Contains(t, agentJob, "id: agentic_execution_fallback_1", "Fallback execution step should have its own ID")
+
+	// Last attempt: any failure fails the job
+	assert.Contains(t, agentJob, "id: engine_fallback_1\n        if: steps.agentic_execution_fallback_1.outcome == 'failure'", "Last engine failure should be classified")
+	assert.Equal(t, 1, strings.Count(agentJob, "GH_AW_FALLBACK_ENGINE:"), "Last classifier should have no next engine")
+}
+
+func TestCompileWorkflowWithoutEngineFallback(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "engine-no-fallback-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+---
+
+# No Fallback
+
+Summarize the repository.
+`
+
+	testFile := filepath.Join(tmpDir, "no-fallback.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	assert.NotContains(t, string(lockContent), "engine_fallback", "Single engine workflows should not get fallback steps")
+}
@@ -295,6 +295,21 @@ func FilterEnvForSecrets(env map[string]string, allowedNamesAndKeys []string) ma
 	return filtered
 }
 
+// getEngineEnvKeys returns the sorted names of the variables set under engine.env.
+// Engines add them to the names allowed by FilterEnvForSecrets so that secrets the
+// workflow author explicitly passed to the agent step are kept.
+func getEngineEnvKeys(workflowData *WorkflowData) []string {
+	if workflowData == nil || workflowData.EngineConfig == nil || len(workflowData.EngineConfig.Env) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(workflowData.EngineConfig.Env))
+	for key := range workflowData.EngineConfig.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // GetHostedToolcachePathSetup returns a shell command that adds all runtime binaries
 // from /opt/hostedtoolcache to PATH. This includes Node.js, Python, Go, Ruby, and other
 // runtimes installed via actions/setup-* steps.
@@ -0,0 +1,190 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractEngineConfigModelParameters(t *testing.T) {
+	compiler := NewCompiler()
+
+	t.Run("scalar form has no model parameters", func(t *testing.T) {
+		engineSetting, config := compiler.ExtractEngineConfig(map[string]any{"engine": "claude"})
+		require.NotNil(t, config, "Scalar engine should produce a config")
+		assert.Equal(t, "claude", engineSetting, "Scalar engine should select the engine")
+		assert.Empty(t, config.Model, "Scalar engine should not set a model")
+		assert.Zero(t, config.MaxTokens, "Scalar engine should not set max-tokens")
+		assert.Nil(t, config.Temperature, "Scalar engine should not set temperature")
+	})
+
+	t.Run("map form with all model parameters", func(t *testing.T) {
+		engineSetting, config := compiler.ExtractEngineConfig(map[string]any{
+			"engine": map[string]any{
+				"id":          "claude",
+				"model":       "claude-sonnet-4",
+				"max-tokens":  uint64(8192),
+				"temperature": 0.2,
+			},
+		})
+		require.NotNil(t, config, "Map engine should produce a config")
+		assert.Equal(t, "claude", engineSetting, "Map engine should select the engine")
+		assert.Equal(t, "claude-sonnet-4", config.Model, "Model should be parsed")
+		assert.Equal(t, 8192, config.MaxTokens, "max-tokens should be parsed")
+		require.NotNil(t, config.Temperature, "temperature should be parsed")
+		assert.InDelta(t, 0.2, *config.Temperature, 1e-9, "temperature should keep its value")
+	})
+
+	t.Run("map form with integer temperature and float max-tokens", func(t *testing.T) {
+		_, config := compiler.ExtractEngineConfig(map[string]any{
+			"engine": map[string]any{
+				"id":          "codex",
+				"max-tokens":  float64(4096),
+				"temperature": 0,
+			},
+		})
+		require.NotNil(t, config, "Map engine should produce a config")
+		assert.Equal(t, 4096, config.MaxTokens, "Float max-tokens should be converted")
+		require.NotNil(t, config.Temperature, "Zero temperature should be distinguishable from unset")
+		assert.Zero(t, *config.Temperature, "Integer temperature should be converted")
+	})
+}
+
+func TestValidateModelParametersSupport(t *testing.T) {
+	registry := GetGlobalEngineRegistry()
+
+	tests := []struct {
+		name        string
+		engineID    string
+		engine      map[string]any
+		errContains string
+	}{
+		{
+			name:     "claude supports max-tokens",
+			engineID: "claude",
+			engine:   map[string]any{"id": "claude", "max-tokens": 8192},
+		},
+		{
+			name:     "codex supports max-tokens",
+			engineID: "codex",
+			engine:   map[string]any{"id": "codex", "max-tokens": 8192},
+		},
+		{
+			name:        "copilot rejects max-tokens",
+			engineID:    "copilot",
+			engine:      map[string]any{"id": "copilot", "max-tokens": 8192},
+			errContains: "max-tokens not supported: engine 'copilot'",
+		},
+		{
+			name:        "gemini rejects max-tokens",
+			engineID:    "gemini",
+			engine:      map[string]any{"id": "gemini", "max-tokens": 8192},
+			errContains: "max-tokens not supported: engine 'gemini'",
+		},
+		{
+			name:        "claude rejects temperature",
+			engineID:    "claude",
+			engine:      map[string]any{"id": "claude", "temperature": 0.5},
+			errContains: "temperature not supported: engine 'claude'",
+		},
+		{
+			name:     "model alone is accepted by every engine",
+			engineID: "copilot",
+			engine:   map[string]any{"id": "copilot", "model": "gpt-5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := registry.GetEngine(tt.engineID)
+			require.NoError(t, err, "Engine should be registered")
+
+			err = NewCompiler().validateModelParametersSupport(map[string]any{"engine": tt.engine}, engine)
+			if tt.errContains == "" {
+				assert.NoError(t, err, "Model parameters should be accepted")
+				return
+			}
+			require.Error(t, err, "Model parameters should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "Error should name the unsupported parameter and engine")
+		})
+	}
+}
+
+func TestCompileWorkflowWithEngineMaxTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		engineID string
+		expected string
+	}{
+		{
+			name:     "claude uses native env var",
+			engineID: "claude",
+			expected: "CLAUDE_CODE_MAX_OUTPUT_TOKENS: 8192",
+		},
+		{
+			name:     "codex uses config override",
+			engineID: "codex",
+			expected: "-c model_max_output_tokens=8192 exec",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "engine-max-tokens-test")
+
+			testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: ` + tt.engineID + `
+  max-tokens: 8192
+---
+
+# Max Tokens
+
+Summarize the repository.
+`
+
+			testFile := filepath.Join(tmpDir, "max-tokens.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+			require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with max-tokens should compile")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			require.NoError(t, err, "Should read lock file")
+			assert.Contains(t, extractJobSection(string(lockContent), "agent"), tt.expected, "Agent invocation should limit output tokens")
+		})
+	}
+}
+
+func TestCompileWorkflowWithUnsupportedModelParameter(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "engine-unsupported-param-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: copilot
+  temperature: 0.2
+---
+
+# Temperature
+
+Summarize the repository.
+`
+
+	testFile := filepath.Join(tmpDir, "temperature.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Unsupported temperature should fail compilation")
+	assert.Contains(t, err.Error(), "temperature not supported", "Error should explain the unsupported parameter")
+}
@@ -10,6 +10,8 @@
 //
 //   - validateEngine() - Validates that a given engine ID is supported
 //   - validateSingleEngineSpecification() - Validates that only one engine field exists across all files
+//   - validateEngineFallbacks() - Validates the engine fallback chain of an `engine: [...]` list
+//   - validateEngineEnv() - Validates that engine.env only sets valid environment variable names
 //
 // # Validation Pattern: Engine Registry
 //
@@ -36,6 +38,8 @@ package workflow
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
@@ -45,6 +49,9 @@ import (
 
 var engineValidationLog = logger.New("workflow:engine_validation")
 
+// engineEnvNamePattern matches valid environment variable names for engine.env
+var engineEnvNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // validateEngine validates that the given engine ID is supported
 func (c *Compiler) validateEngine(engineID string) error {
 	if engineID == "" {
@@ -96,6 +103,55 @@ func (c *Compiler) validateEngine(engineID string) error {
 	return fmt.Errorf("%s", errMsg)
 }
 
+// validateEngineFallbacks validates the fallback engines of an `engine: [primary, fallback...]` list.
+// Every fallback must be a supported engine and may appear only once in the chain.
+func (c *Compiler) validateEngineFallbacks(engineConfig *EngineConfig) error {
+	if engineConfig == nil || len(engineConfig.Fallbacks) == 0 {
+		return nil
+	}
+
+	engineValidationLog.Printf("Validating %d fallback engines for %s", len(engineConfig.Fallbacks), engineConfig.ID)
+
+	seen := map[string]bool{engineConfig.ID: true}
+	for _, fallbackID := range engineConfig.Fallbacks {
+		if err := c.validateEngine(fallbackID); err != nil {
+			return fmt.Errorf("invalid fallback engine: %w", err)
+		}
+		if seen[fallbackID] {
+			return fmt.Errorf("engine %q appears more than once in the engine fallback list. Each engine may be listed only once.\n\nExample:\nengine: [copilot, claude]\n\nSee: %s",
+				fallbackID, constants.DocsEnginesURL)
+		}
+		seen[fallbackID] = true
+	}
+
+	return nil
+}
+
+// validateEngineEnv validates that every variable set under engine.env has a valid
+// environment variable name. The variables are set on the agent execution step only.
+func (c *Compiler) validateEngineEnv(engineConfig *EngineConfig) error {
+	if engineConfig == nil || len(engineConfig.Env) == 0 {
+		return nil
+	}
+
+	engineValidationLog.Printf("Validating %d engine.env variables", len(engineConfig.Env))
+
+	names := make([]string, 0, len(engineConfig.Env))
+	for name := range engineConfig.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !engineEnvNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid engine.env variable name %q. Names must start with a letter or underscore and contain only letters, digits and underscores.\n\nExample:\nengine:\n  id: copilot\n  env:\n    FEATURE_FLAG: \"enabled\"\n\nSee: %s",
+				name, constants.DocsEnginesURL)
+		}
+	}
+
+	return nil
+}
+
 // validateSingleEngineSpecification validates that only one engine field exists across all files
 func (c *Compiler) validateSingleEngineSpecification(mainEngineSetting string, includedEnginesJSON []string) (string, error) {
 	var allEngines []string
@@ -366,7 +366,7 @@ func TestMCPValidationErrorQuality(t *testing.T) {
 			name: "invalid type value",
 			tools: map[string]any{
 				"bad-type-value": map[string]any{
-					"type":    "websocket",
+					"type":    "grpc",
 					"command": "test",
 				},
 			},
@@ -376,7 +376,7 @@ func TestMCPValidationErrorQuality(t *testing.T) {
 				"stdio",
 				"http",
 				"local",
-				"websocket",
+				"grpc",
 				"Example:",
 				"tools:",
 			},
@@ -0,0 +1,274 @@
+// This file provides syntax validation for GitHub Actions expressions in user-supplied fields.
+//
+// # Expression Syntax Validation
+//
+// Custom steps, jobs, env values and concurrency groups are copied into the lock file
+// unchanged, so a malformed ${{ }} expression in them only fails once the workflow runs.
+// This file catches the common mistakes at compile time instead.
+//
+// # Validation Functions
+//
+//   - validateUserExpressionSyntax() - Validates expressions in the pass-through frontmatter fields
+//   - validateExpressionSyntaxInValue() - Validates the expressions in a single string value
+//
+// # Validation Coverage
+//
+// The checks are deliberately conservative so that valid but unusual expressions are not rejected:
+//   - Every ${{ has a matching }} and no expression is empty
+//   - String literals inside expressions are closed
+//   - Top-level identifiers are known contexts (github, env, steps, ...) or literals
+//   - Called functions are known expression functions (contains, format, toJSON, ...)
+//
+// Property names, index expressions and operators are not checked.
+
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var expressionSyntaxLog = logger.New("workflow:expression_syntax_validation")
+
+// expressionSyntaxFields lists the frontmatter fields whose values are passed through to the
+// compiled workflow and may contain ${{ }} expressions
+var expressionSyntaxFields = []string{"run-name", "concurrency", "env", "steps", "post-steps", "jobs"}
+
+// knownExpressionContexts lists the contexts available in GitHub Actions expressions
+var knownExpressionContexts = map[string]bool{
+	"github":   true,
+	"env":      true,
+	"vars":     true,
+	"job":      true,
+	"jobs":     true,
+	"steps":    true,
+	"runner":   true,
+	"secrets":  true,
+	"strategy": true,
+	"matrix":   true,
+	"needs":    true,
+	"inputs":   true,
+}
+
+// knownExpressionFunctions lists the functions available in GitHub Actions expressions (lowercase,
+// since function names are case-insensitive)
+var knownExpressionFunctions = map[string]bool{
+	"contains":   true,
+	"startswith": true,
+	"endswith":   true,
+	"format":     true,
+	"join":       true,
+	"tojson":     true,
+	"fromjson":   true,
+	"hashfiles":  true,
+	"success":    true,
+	"always":     true,
+	"cancelled":  true,
+	"failure":    true,
+	"case":       true,
+}
+
+// expressionLiterals lists the keyword literals of the expression language (lowercase)
+var expressionLiterals = map[string]bool{
+	"true":     true,
+	"false":    true,
+	"null":     true,
+	"nan":      true,
+	"infinity": true,
+}
+
+// validateUserExpressionSyntax validates the ${{ }} expressions found in the frontmatter fields that
+// the compiler passes through to the lock file
+func validateUserExpressionSyntax(frontmatter map[string]any) error {
+	for _, field := range expressionSyntaxFields {
+		value, ok := frontmatter[field]
+		if !ok {
+			continue
+		}
+		if err := validateExpressionSyntaxInTree(field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateExpressionSyntaxInTree walks a frontmatter value and validates every string in it.
+// path is the location of value, e.g. steps[0].run, and is used as the error field.
+func validateExpressionSyntaxInTree(path string, value any) error {
+	switch v := value.(type) {
+	case string:
+		return validateExpressionSyntaxInValue(path, v)
+	case []any:
+		for i, item := range v {
+			if err := validateExpressionSyntaxInTree(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		keys := sliceutil.MapToSlice(v)
+		slices.Sort(keys)
+		for _, key := range keys {
+			if err := validateExpressionSyntaxInTree(path+"."+key, v[key]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateExpressionSyntaxInValue validates every ${{ }} expression in a single string value
+func validateExpressionSyntaxInValue(field, value string) error {
+	rest := value
+	for {
+		start := strings.Index(rest, "${{")
+		if start == -1 {
+			return nil
+		}
+		rest = rest[start:]
+
+		end := findExpressionEnd(rest)
+		if end == -1 {
+			expressionSyntaxLog.Printf("Unclosed expression in %s", field)
+			return NewValidationError(
+				field,
+				firstLine(rest),
+				"expression '${{' is not closed with '}}'",
+				"Close the expression with '}}'. Example: '${{ github.ref }}'",
+			)
+		}
+
+		snippet := rest[:end+2]
+		if err := validateExpressionBody(field, snippet, rest[3:end]); err != nil {
+			return err
+		}
+		rest = rest[end+2:]
+	}
+}
+
+// findExpressionEnd returns the index of the '}}' closing the expression that starts at the
+// beginning of s, skipping string literals, or -1 when the expression is not closed. An expression
+// that runs into another '${{' is treated as unclosed.
+func findExpressionEnd(s string) int {
+	inString := false
+	for i := 3; i < len(s); i++ {
+		switch {
+		case inString:
+			if s[i] == '\'' {
+				inString = false
+			}
+		case s[i] == '\'':
+			inString = true
+		case strings.HasPrefix(s[i:], "}}"):
+			return i
+		case strings.HasPrefix(s[i:], "${{"):
+			return -1
+		}
+	}
+	return -1
+}
+
+// validateExpressionBody checks the content of a single expression for empty bodies, unclosed
+// string literals, unknown contexts and unknown functions
+func validateExpressionBody(field, snippet, body string) error {
+	if strings.TrimSpace(body) == "" {
+		return NewValidationError(field, snippet, "expression is empty", "Put an expression inside '${{ }}' or remove it")
+	}
+
+	for i := 0; i < len(body); {
+		ch := body[i]
+		switch {
+		case ch == '\'':
+			// String literal; '' is an escaped quote
+			closed := false
+			for i++; i < len(body); i++ {
+				if body[i] == '\'' {
+					if i+1 < len(body) && body[i+1] == '\'' {
+						i++
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+			}
+			if !closed {
+				return NewValidationError(field, snippet, "string literal is not closed", "Close the string with a single quote (')")
+			}
+		case ch >= '0' && ch <= '9':
+			// Number literals, including hex and exponent forms
+			for i < len(body) && isExpressionNumberChar(body[i]) {
+				i++
+			}
+		case isExpressionIdentStart(ch):
+			identStart := i
+			for i < len(body) && isExpressionIdentChar(body[i]) {
+				i++
+			}
+			if err := validateExpressionIdentifier(field, snippet, body, identStart, i); err != nil {
+				return err
+			}
+		default:
+			i++
+		}
+	}
+	return nil
+}
+
+// validateExpressionIdentifier checks the identifier body[start:end]. Property names (after a '.')
+// are accepted as-is; function calls must use known functions and other identifiers must be
+// known contexts or literals.
+func validateExpressionIdentifier(field, snippet, body string, start, end int) error {
+	if prev := strings.TrimRight(body[:start], " \t\r\n"); strings.HasSuffix(prev, ".") {
+		return nil
+	}
+
+	ident := body[start:end]
+	name := strings.ToLower(ident)
+	if next := strings.TrimLeft(body[end:], " \t\r\n"); strings.HasPrefix(next, "(") {
+		if knownExpressionFunctions[name] {
+			return nil
+		}
+		expressionSyntaxLog.Printf("Unknown function %s in %s", ident, field)
+		return NewValidationError(
+			field,
+			snippet,
+			fmt.Sprintf("unknown function '%s'", ident),
+			"Use one of the expression functions: contains, startsWith, endsWith, format, join, toJSON, fromJSON, hashFiles, success, always, cancelled, failure",
+		)
+	}
+
+	if knownExpressionContexts[name] || expressionLiterals[name] {
+		return nil
+	}
+	expressionSyntaxLog.Printf("Unknown context %s in %s", ident, field)
+	contexts := sliceutil.MapToSlice(knownExpressionContexts)
+	slices.Sort(contexts)
+	return NewValidationError(
+		field,
+		snippet,
+		fmt.Sprintf("unknown context '%s'", ident),
+		"Use one of the expression contexts: "+strings.Join(contexts, ", ")+". Quote literal text with single quotes",
+	)
+}
+
+func isExpressionIdentStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isExpressionIdentChar(ch byte) bool {
+	return isExpressionIdentStart(ch) || ch == '-' || (ch >= '0' && ch <= '9')
+}
+
+func isExpressionNumberChar(ch byte) bool {
+	return ch == '.' || isExpressionIdentChar(ch)
+}
+
+// firstLine returns the first line of s, used to keep error snippets short
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
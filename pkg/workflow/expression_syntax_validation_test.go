@@ -0,0 +1,128 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExpressionSyntaxInValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expectError string
+	}{
+		{name: "no expressions", value: "echo hello"},
+		{name: "simple context", value: "ref=${{ github.ref }}"},
+		{name: "several expressions", value: "${{ steps.build.outputs.path }}/${{ matrix.os }}-${{ env.SUFFIX }}"},
+		{name: "functions are case-insensitive", value: "${{ toJson(github.event) }} ${{ HASHFILES('**/go.sum') }}"},
+		{name: "operators and literals", value: "${{ github.event_name == 'push' && !cancelled() || null != true }}"},
+		{name: "number literals", value: "${{ 0xff == 255 && 1.5e3 > -2 }}"},
+		{name: "index and object filter", value: "${{ github.event['pull_request'].labels.*.name }}"},
+		{name: "closing braces inside string literal", value: "${{ format('{{0}}', github.ref) }}"},
+		{name: "escaped quote in string literal", value: "${{ contains(github.ref, 'it''s') }}"},
+		{name: "hyphenated property", value: "${{ needs.pre-activation.outputs.activated }}"},
+		{name: "stray closing braces outside expressions", value: "jq '{a: 1}}'"},
+		{
+			name:        "unclosed expression",
+			value:       "echo ${{ github.ref }",
+			expectError: "expression '${{' is not closed with '}}'",
+		},
+		{
+			name:        "expression runs into the next one",
+			value:       "${{ github.ref ${{ github.sha }}",
+			expectError: "expression '${{' is not closed with '}}'",
+		},
+		{
+			name:        "unknown context",
+			value:       "${{ gihtub.ref }}",
+			expectError: "unknown context 'gihtub'",
+		},
+		{
+			name:        "bare word",
+			value:       "${{ production }}",
+			expectError: "unknown context 'production'",
+		},
+		{
+			name:        "unknown function",
+			value:       "${{ toUpper(github.ref) }}",
+			expectError: "unknown function 'toUpper'",
+		},
+		{
+			name:        "empty expression",
+			value:       "${{ }}",
+			expectError: "expression is empty",
+		},
+		{
+			name:        "unclosed string literal",
+			value:       "${{ contains(github.ref, 'main) }}",
+			expectError: "expression '${{' is not closed with '}}'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExpressionSyntaxInValue("steps[0].run", tt.value)
+			if tt.expectError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectError)
+			assert.Contains(t, err.Error(), "'steps[0].run'", "error should name the field")
+		})
+	}
+}
+
+func TestValidateUserExpressionSyntaxReportsSnippet(t *testing.T) {
+	frontmatter := map[string]any{
+		"env": map[string]any{"OK": "${{ vars.OK }}"},
+		"jobs": map[string]any{
+			"build": map[string]any{
+				"steps": []any{
+					map[string]any{"run": "echo ok"},
+					map[string]any{"with": map[string]any{"ref": "prefix-${{ inptus.ref }}-suffix"}},
+				},
+			},
+		},
+		// Fields that are not passed through are not checked
+		"safe-outputs": map[string]any{"title": "${{ whatever }}"},
+	}
+
+	err := validateUserExpressionSyntax(frontmatter)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'jobs.build.steps[1].with.ref'")
+	assert.Contains(t, err.Error(), "Value: ${{ inptus.ref }}", "error should show the offending expression")
+	assert.Contains(t, err.Error(), "unknown context 'inptus'")
+
+	require.NoError(t, validateUserExpressionSyntax(map[string]any{"env": map[string]any{"A": "${{ secrets.A }}"}}))
+}
+
+func TestCompileRejectsMalformedStepExpression(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "expression-syntax-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+steps:
+  - name: Checkout ref
+    run: echo "${{ github.ref }"
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "a malformed expression in a custom step should fail compilation")
+	assert.Contains(t, err.Error(), "steps[0].run")
+	assert.Contains(t, err.Error(), "is not closed")
+}
@@ -41,6 +41,14 @@ func AddMCPFetchServerIfNeeded(tools map[string]any, engine CodingAgentEngine) (
 		"container": "mcp/fetch",
 	}
 
+	// Keep the web-fetch domain allowlist as the server's network allowlist so the domains
+	// still reach the firewall
+	if originalConfig, ok := tools["web-fetch"].(map[string]any); ok {
+		if allowed := getWebFetchAllowed(originalConfig); len(allowed) > 0 {
+			webFetchConfig["network"] = map[string]any{"allowed": allowed}
+		}
+	}
+
 	// Add the web-fetch server to the tools
 	updatedTools["web-fetch"] = webFetchConfig
 
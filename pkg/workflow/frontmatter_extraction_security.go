@@ -42,6 +42,12 @@ func (c *Compiler) extractNetworkPermissions(frontmatter map[string]any) *Networ
 				}
 			}
 
+			// Extract the shared allowlist file path if present
+			if allowedFrom, ok := networkObj["allowed-from"].(string); ok {
+				permissions.AllowedFrom = allowedFrom
+				frontmatterExtractionSecurityLog.Printf("Network allowlist file: %s", allowedFrom)
+			}
+
 			// Extract blocked domains if present
 			if blocked, hasBlocked := networkObj["blocked"]; hasBlocked {
 				if blockedSlice, ok := blocked.([]any); ok {
@@ -60,6 +66,21 @@ func (c *Compiler) extractNetworkPermissions(frontmatter map[string]any) *Networ
 				permissions.Firewall = c.extractFirewallConfig(firewall)
 			}
 
+			// Extract connection logging flag if present
+			if logValue, hasLog := networkObj["log"]; hasLog {
+				if logBool, ok := logValue.(bool); ok {
+					permissions.Log = logBool
+				}
+			}
+
+			// Extract enforcement mode if present
+			if mode, hasMode := networkObj["mode"]; hasMode {
+				if modeStr, ok := mode.(string); ok {
+					permissions.Mode = modeStr
+					frontmatterExtractionSecurityLog.Printf("Network mode: %s", modeStr)
+				}
+			}
+
 			// Empty object {} means no network access (empty allowed list)
 			return permissions
 		}
@@ -438,6 +459,29 @@ func (c *Compiler) extractMCPGatewayConfig(mcpVal any) *MCPGatewayRuntimeConfig
 		}
 	}
 
+	// Extract log-rotation (rotation policy for MCP server logs)
+	if rotationVal, hasRotation := mcpObj["log-rotation"]; hasRotation {
+		if rotationObj, ok := rotationVal.(map[string]any); ok {
+			mcpConfig.LogRotation = &MCPLogRotationConfig{}
+			if maxSize, ok := rotationObj["max-size"].(string); ok {
+				mcpConfig.LogRotation.MaxSize = maxSize
+			}
+			if maxAge, ok := rotationObj["max-age"].(string); ok {
+				mcpConfig.LogRotation.MaxAge = maxAge
+			}
+			switch v := rotationObj["max-files"].(type) {
+			case int:
+				mcpConfig.LogRotation.MaxFiles = v
+			case int64:
+				mcpConfig.LogRotation.MaxFiles = int(v)
+			case uint64:
+				mcpConfig.LogRotation.MaxFiles = int(v)
+			case float64:
+				mcpConfig.LogRotation.MaxFiles = int(v)
+			}
+		}
+	}
+
 	return mcpConfig
 }
 
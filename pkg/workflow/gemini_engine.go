@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
@@ -23,6 +24,8 @@ func NewGeminiEngine() *GeminiEngine {
 			experimental:           false,
 			supportsToolsAllowlist: true,
 			supportsMaxTurns:       false,
+			supportsMaxTokens:      false,
+			supportsTemperature:    false,
 			supportsWebFetch:       false,
 			supportsWebSearch:      false,
 			supportsFirewall:       true, // Gemini supports network firewalling via AWF
@@ -183,10 +186,11 @@ func (e *GeminiEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 	// Build gemini CLI arguments based on configuration
 	var geminiArgs []string
 
-	// Model is passed via the native GEMINI_MODEL environment variable only when explicitly
-	// configured. When not configured, the Gemini CLI uses its built-in default model.
+	// Model is passed via the native GEMINI_MODEL environment variable when explicitly configured.
 	// This avoids embedding the value directly in the shell command (which fails template injection
 	// validation for GitHub Actions expressions like ${{ inputs.model }}).
+	// When not configured, GH_AW_MODEL_AGENT/DETECTION_GEMINI selects the default model, and the
+	// Gemini CLI falls back to its built-in default when that variable is unset.
 	modelConfigured := workflowData.EngineConfig != nil && workflowData.EngineConfig.Model != ""
 
 	// Gemini CLI reads MCP config from .gemini/settings.json (project-level)
@@ -211,6 +215,13 @@ func (e *GeminiEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 
 	geminiCommand := fmt.Sprintf("%s %s", commandName, shellJoinArgs(geminiArgs))
 
+	// When model is not configured, use the GH_AW_MODEL_AGENT_GEMINI fallback env var
+	// via shell expansion so users can set a default via GitHub Actions variables.
+	modelEnvVar := geminiDefaultModelEnvVar(workflowData)
+	if !modelConfigured {
+		geminiCommand = fmt.Sprintf(`%s${%s:+ --model "$%s"}`, geminiCommand, modelEnvVar, modelEnvVar)
+	}
+
 	// Build the full command with AWF wrapping if enabled
 	var command string
 	firewallEnabled := isFirewallEnabled(workflowData)
@@ -268,14 +279,21 @@ func (e *GeminiEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 	// Add safe outputs env
 	applySafeOutputEnvToMap(env, workflowData)
 
-	// Set the model environment variable only when explicitly configured.
 	// When model is configured, use the native GEMINI_MODEL env var - the Gemini CLI reads it
 	// directly, avoiding the need to embed the value in the shell command (which would fail
 	// template injection validation for GitHub Actions expressions like ${{ inputs.model }}).
-	// When model is not configured, let the Gemini CLI use its built-in default model.
+	// When model is not configured, fall back to GH_AW_MODEL_AGENT/DETECTION_GEMINI so users
+	// can set a default via GitHub Actions variables.
 	if modelConfigured {
 		geminiLog.Printf("Setting %s env var for model: %s", constants.GeminiCLIModelEnvVar, workflowData.EngineConfig.Model)
 		env[constants.GeminiCLIModelEnvVar] = workflowData.EngineConfig.Model
+	} else {
+		env[modelEnvVar] = fmt.Sprintf("${{ vars.%s || '' }}", modelEnvVar)
+	}
+
+	// Add custom environment variables from engine config
+	if workflowData.EngineConfig != nil && len(workflowData.EngineConfig.Env) > 0 {
+		maps.Copy(env, workflowData.EngineConfig.Env)
 	}
 
 	// Generate the execution step
@@ -285,7 +303,7 @@ func (e *GeminiEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 	}
 
 	// Filter environment variables for security
-	allowedSecrets := e.GetRequiredSecretNames(workflowData)
+	allowedSecrets := append(e.GetRequiredSecretNames(workflowData), getEngineEnvKeys(workflowData)...)
 	filteredEnv := FilterEnvForSecrets(env, allowedSecrets)
 
 	// Format step with command and env
@@ -294,3 +312,12 @@ func (e *GeminiEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 	steps = append(steps, GitHubActionStep(stepLines))
 	return steps
 }
+
+// geminiDefaultModelEnvVar returns the GitHub Actions variable that selects the default Gemini
+// model when none is configured. The detection job has no safe outputs and uses its own variable.
+func geminiDefaultModelEnvVar(workflowData *WorkflowData) string {
+	if workflowData.SafeOutputs == nil {
+		return constants.EnvVarModelDetectionGemini
+	}
+	return constants.EnvVarModelAgentGemini
+}
@@ -3,9 +3,14 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -232,7 +237,7 @@ func TestGeminiEngineExecution(t *testing.T) {
 	})
 
 	t.Run("model environment variables", func(t *testing.T) {
-		// When model is not configured, no model env var should be set (let Gemini CLI use its default)
+		// When model is not configured, the default model comes from the GH_AW_MODEL_AGENT_GEMINI variable
 		noModelWorkflow := &WorkflowData{
 			Name:        "no-model",
 			SafeOutputs: &SafeOutputsConfig{},
@@ -241,9 +246,21 @@ func TestGeminiEngineExecution(t *testing.T) {
 		steps := engine.GetExecutionSteps(noModelWorkflow, "/tmp/test.log")
 		require.Len(t, steps, 2, "Should generate settings step and execution step")
 		stepContent := strings.Join(steps[1], "\n")
-		assert.NotContains(t, stepContent, "GH_AW_MODEL_DETECTION_GEMINI", "Should not include detection model env var when model is unconfigured")
-		assert.NotContains(t, stepContent, "GH_AW_MODEL_AGENT_GEMINI", "Should not include agent model env var when model is unconfigured")
-		assert.NotContains(t, stepContent, "GEMINI_MODEL", "Should not include GEMINI_MODEL when model is unconfigured")
+		assert.Contains(t, stepContent, "GH_AW_MODEL_AGENT_GEMINI: ${{ vars.GH_AW_MODEL_AGENT_GEMINI || '' }}", "Should include agent model fallback env var when model is unconfigured")
+		assert.Contains(t, stepContent, `${GH_AW_MODEL_AGENT_GEMINI:+ --model "$GH_AW_MODEL_AGENT_GEMINI"}`, "Should pass the fallback model only when the variable is set")
+		assert.NotContains(t, stepContent, "GH_AW_MODEL_DETECTION_GEMINI", "Should not include detection model env var in the agent job")
+		assert.NotContains(t, stepContent, "GEMINI_MODEL:", "Should not include GEMINI_MODEL when model is unconfigured")
+
+		// The detection job (no safe outputs) uses the detection model variable
+		detectionWorkflow := &WorkflowData{
+			Name: "detection",
+		}
+
+		steps = engine.GetExecutionSteps(detectionWorkflow, "/tmp/test.log")
+		require.Len(t, steps, 2, "Should generate settings step and execution step")
+		stepContent = strings.Join(steps[1], "\n")
+		assert.Contains(t, stepContent, "GH_AW_MODEL_DETECTION_GEMINI: ${{ vars.GH_AW_MODEL_DETECTION_GEMINI || '' }}", "Should include detection model fallback env var")
+		assert.NotContains(t, stepContent, "GH_AW_MODEL_AGENT_GEMINI", "Should not include agent model env var in the detection job")
 
 		// When model is configured, use the native GEMINI_MODEL env var
 		modelWorkflow := &WorkflowData{
@@ -257,6 +274,7 @@ func TestGeminiEngineExecution(t *testing.T) {
 		require.Len(t, steps, 2, "Should generate settings step and execution step")
 		stepContent = strings.Join(steps[1], "\n")
 		assert.Contains(t, stepContent, "GEMINI_MODEL: gemini-2.0-flash", "Should set GEMINI_MODEL when model is explicitly configured")
+		assert.NotContains(t, stepContent, "GH_AW_MODEL_DETECTION_GEMINI", "Should not include fallback env var when model is configured")
 	})
 
 	t.Run("settings step is first", func(t *testing.T) {
@@ -473,3 +491,45 @@ func TestGenerateGeminiSettingsStep(t *testing.T) {
 		assert.Contains(t, content, "replace", "Should include replace for edit tool")
 	})
 }
+
+func TestCompileWorkflowWithGeminiEngine(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "gemini-engine-compile-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+  issues: read
+engine: gemini
+tools:
+  github:
+    toolsets: [issues]
+safe-outputs:
+  add-comment:
+---
+
+# Gemini Workflow
+
+Summarize the open issues.
+`
+
+	testFile := filepath.Join(tmpDir, "gemini.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with engine: gemini should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+
+	var workflow map[string]any
+	require.NoError(t, yaml.Unmarshal(lockContent, &workflow), "Lock file should be valid YAML")
+	jobs, ok := workflow["jobs"].(map[string]any)
+	require.True(t, ok, "Lock file should define jobs")
+	assert.Contains(t, jobs, "agent", "Lock file should contain the agent job")
+
+	lock := string(lockContent)
+	assert.Contains(t, lock, "Execute Gemini CLI", "Agent job should run the Gemini CLI")
+	assert.Contains(t, lock, "GEMINI_API_KEY: ${{ secrets.GEMINI_API_KEY }}", "Agent job should use the GEMINI_API_KEY secret")
+	assert.Contains(t, lock, `group: "gh-aw-gemini-${{ github.workflow }}"`, "Agent job should use the gemini concurrency group")
+	assert.Contains(t, lock, "GH_AW_MODEL_AGENT_GEMINI", "Agent job should support a default model variable")
+}
@@ -79,7 +79,8 @@ func computeGeminiToolsCore(tools map[string]any) []string {
 				// Add an entry for each specific command: run_shell_command(cmd)
 				for _, cmd := range bashCommands {
 					if cmdStr, ok := cmd.(string); ok {
-						entry := fmt.Sprintf("run_shell_command(%s)", cmdStr)
+						// Gemini matches run_shell_command entries as command prefixes
+						entry := fmt.Sprintf("run_shell_command(%s)", strings.TrimSuffix(canonicalBashCommand(cmdStr), ":*"))
 						geminiToolsLog.Printf("bash %q → %s", cmdStr, entry)
 						toolsCore = append(toolsCore, entry)
 					}
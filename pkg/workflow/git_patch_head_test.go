@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestGitPatchFromHEADCommits tests that the patch generation script can detect
@@ -438,3 +440,356 @@ func TestGitPatchNoCommits(t *testing.T) {
 		t.Error("Script should log that no commits were made")
 	}
 }
+
+// TestGitPatchExceedsMaxPatchBytes tests that patch generation is aborted when the
+// patch is larger than GH_AW_MAX_PATCH_BYTES
+func TestGitPatchExceedsMaxPatchBytes(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-patch-max-bytes-*")
+
+	// Initialize git repo
+	cmd := exec.Command("git", "init")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to init git: %v\nOutput: %s", err, output)
+	}
+
+	// Configure git
+	cmd = exec.Command("git", "config", "user.email", "test@example.com")
+	cmd.Dir = tmpDir
+	cmd.Run()
+
+	cmd = exec.Command("git", "config", "user.name", "Test User")
+	cmd.Dir = tmpDir
+	cmd.Run()
+
+	// Create the initial commit (this will be our GITHUB_SHA)
+	os.WriteFile(filepath.Join(tmpDir, "initial.txt"), []byte("initial content\n"), 0644)
+
+	cmd = exec.Command("git", "add", ".")
+	cmd.Dir = tmpDir
+	cmd.Run()
+
+	cmd = exec.Command("git", "commit", "-m", "Initial commit")
+	cmd.Dir = tmpDir
+	cmd.Run()
+
+	cmd = exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = tmpDir
+	output, _ := cmd.CombinedOutput()
+	initialSHA := strings.TrimSpace(string(output))
+
+	// Commit a large file directly to HEAD (about 64 KB of text)
+	largeContent := strings.Repeat("generated line of content for the oversized patch\n", 1300)
+	if err := os.WriteFile(filepath.Join(tmpDir, "large.txt"), []byte(largeContent), 0644); err != nil {
+		t.Fatalf("Failed to write large file: %v", err)
+	}
+
+	cmd = exec.Command("git", "add", "large.txt")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to add large file: %v\nOutput: %s", err, output)
+	}
+
+	cmd = exec.Command("git", "commit", "-m", "Add large generated file")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to commit large file: %v\nOutput: %s", err, output)
+	}
+
+	// The size limit is a create-pull-request option; the branch does not exist, so the HEAD strategy is used
+	safeOutputsFile := filepath.Join(tmpDir, "safe-outputs.jsonl")
+	os.WriteFile(safeOutputsFile, []byte(createPullRequestSafeOutput), 0644)
+
+	scriptPath := filepath.Join("..", "..", "actions", "setup", "sh", "generate_git_patch.sh")
+	scriptContent, err := os.ReadFile(scriptPath)
+	if err != nil {
+		t.Fatalf("Failed to read script file: %v", err)
+	}
+	scriptFile := filepath.Join(tmpDir, "generate_patch.sh")
+	os.WriteFile(scriptFile, scriptContent, 0755)
+
+	// Ensure /tmp/gh-aw exists and is clean
+	os.MkdirAll("/tmp/gh-aw", 0755)
+	if entries, err := os.ReadDir("/tmp/gh-aw"); err == nil {
+		for _, entry := range entries {
+			if matched, _ := filepath.Match("aw-*.patch", entry.Name()); matched {
+				os.Remove(filepath.Join("/tmp/gh-aw", entry.Name()))
+			}
+		}
+	}
+
+	stepSummary := filepath.Join(tmpDir, "step-summary.md")
+
+	cmd = exec.Command("bash", scriptFile)
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(),
+		"GH_AW_SAFE_OUTPUTS="+safeOutputsFile,
+		"GITHUB_SHA="+initialSHA,
+		"DEFAULT_BRANCH=main",
+		"GITHUB_STEP_SUMMARY="+stepSummary,
+		"GH_AW_MAX_PATCH_BYTES=10240",
+	)
+
+	scriptOutput, err := cmd.CombinedOutput()
+	t.Logf("Script output:\n%s", scriptOutput)
+
+	if err == nil {
+		t.Fatal("Script should fail when the patch exceeds GH_AW_MAX_PATCH_BYTES")
+	}
+
+	if !strings.Contains(string(scriptOutput), "exceeds the maximum of 10240 bytes") {
+		t.Error("Script should log that the patch exceeds the maximum size")
+	}
+
+	// Verify the oversized patch was removed
+	if entries, err := os.ReadDir("/tmp/gh-aw"); err == nil {
+		for _, entry := range entries {
+			if matched, _ := filepath.Match("aw-*.patch", entry.Name()); matched {
+				t.Errorf("Oversized patch file should be removed, found: %s", entry.Name())
+			}
+		}
+	}
+
+	// Verify the step summary explains the failure
+	summary, err := os.ReadFile(stepSummary)
+	if err != nil {
+		t.Fatalf("Failed to read step summary: %v", err)
+	}
+	if !strings.Contains(string(summary), "size limit exceeded") {
+		t.Errorf("Step summary should report the size limit, got:\n%s", summary)
+	}
+}
+
+// TestGitPatchWithBinaryFiles tests that binary files committed to HEAD are included as
+// binary diffs that re-apply cleanly, and that the script warns about them
+func TestGitPatchWithBinaryFiles(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-patch-binary-*")
+
+	// Initialize git repo
+	cmd := exec.Command("git", "init")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to init git: %v\nOutput: %s", err, output)
+	}
+
+	// Configure git
+	cmd = exec.Command("git", "config", "user.email", "test@example.com")
+	cmd.Dir = tmpDir
+	cmd.Run()
+
+	cmd = exec.Command("git", "config", "user.name", "Test User")
+	cmd.Dir = tmpDir
+	cmd.Run()
+
+	// Create the initial commit (this will be our GITHUB_SHA)
+	os.WriteFile(filepath.Join(tmpDir, "initial.txt"), []byte("initial content\n"), 0644)
+
+	cmd = exec.Command("git", "add", ".")
+	cmd.Dir = tmpDir
+	cmd.Run()
+
+	cmd = exec.Command("git", "commit", "-m", "Initial commit")
+	cmd.Dir = tmpDir
+	cmd.Run()
+
+	cmd = exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = tmpDir
+	output, _ := cmd.CombinedOutput()
+	initialSHA := strings.TrimSpace(string(output))
+
+	// Commit a binary file directly to HEAD (PNG header followed by NUL bytes and a byte ramp)
+	binaryContent := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0x00, 0x00, 0x0d}
+	for i := range 512 {
+		binaryContent = append(binaryContent, byte(i%256))
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "logo.png"), binaryContent, 0644); err != nil {
+		t.Fatalf("Failed to write binary file: %v", err)
+	}
+
+	cmd = exec.Command("git", "add", "logo.png")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to add binary file: %v\nOutput: %s", err, output)
+	}
+
+	cmd = exec.Command("git", "commit", "-m", "Add logo")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to commit binary file: %v\nOutput: %s", err, output)
+	}
+
+	// Create empty safe-outputs (no branch name, so the HEAD strategy is used)
+	safeOutputsFile := filepath.Join(tmpDir, "safe-outputs.jsonl")
+	os.WriteFile(safeOutputsFile, []byte(""), 0644)
+
+	scriptPath := filepath.Join("..", "..", "actions", "setup", "sh", "generate_git_patch.sh")
+	scriptContent, err := os.ReadFile(scriptPath)
+	if err != nil {
+		t.Fatalf("Failed to read script file: %v", err)
+	}
+	scriptFile := filepath.Join(tmpDir, "generate_patch.sh")
+	os.WriteFile(scriptFile, scriptContent, 0755)
+
+	// Ensure /tmp/gh-aw exists and is clean
+	os.MkdirAll("/tmp/gh-aw", 0755)
+	if entries, err := os.ReadDir("/tmp/gh-aw"); err == nil {
+		for _, entry := range entries {
+			if matched, _ := filepath.Match("aw-*.patch", entry.Name()); matched {
+				os.Remove(filepath.Join("/tmp/gh-aw", entry.Name()))
+			}
+		}
+	}
+
+	cmd = exec.Command("bash", scriptFile)
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(),
+		"GH_AW_SAFE_OUTPUTS="+safeOutputsFile,
+		"GITHUB_SHA="+initialSHA,
+		"DEFAULT_BRANCH=main",
+		"GITHUB_STEP_SUMMARY=/dev/null",
+	)
+
+	scriptOutput, err := cmd.CombinedOutput()
+	t.Logf("Script output:\n%s", scriptOutput)
+	if err != nil {
+		t.Fatalf("Failed to run patch generation script: %v\nOutput: %s", err, scriptOutput)
+	}
+
+	if !strings.Contains(string(scriptOutput), "WARNING: Patch includes binary files") {
+		t.Error("Script should warn that the patch includes binary files")
+	}
+
+	// Find the generated patch file (aw-{branch}.patch)
+	var patchFile string
+	if entries, err := os.ReadDir("/tmp/gh-aw"); err == nil {
+		for _, entry := range entries {
+			if matched, _ := filepath.Match("aw-*.patch", entry.Name()); matched {
+				patchFile = filepath.Join("/tmp/gh-aw", entry.Name())
+				break
+			}
+		}
+	}
+	if patchFile == "" {
+		t.Fatal("No aw-*.patch file was created")
+	}
+
+	patchContent, err := os.ReadFile(patchFile)
+	if err != nil {
+		t.Fatalf("Failed to read patch file: %v", err)
+	}
+
+	// Verify the patch contains a real binary diff rather than "Binary files differ"
+	if !strings.Contains(string(patchContent), "GIT binary patch") {
+		t.Errorf("Patch should contain a binary diff, got:\n%s", patchContent)
+	}
+	if strings.Contains(string(patchContent), "Binary files /dev/null and b/logo.png differ") {
+		t.Error("Patch should not contain a non-applicable binary placeholder")
+	}
+
+	// Verify the patch re-applies on the original commit
+	cmd = exec.Command("git", "checkout", "--quiet", initialSHA)
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to check out initial commit: %v\nOutput: %s", err, output)
+	}
+
+	cmd = exec.Command("git", "apply", patchFile)
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Patch with binary file should apply cleanly: %v\nOutput: %s", err, output)
+	}
+
+	appliedContent, err := os.ReadFile(filepath.Join(tmpDir, "logo.png"))
+	if err != nil {
+		t.Fatalf("Failed to read applied binary file: %v", err)
+	}
+	if string(appliedContent) != string(binaryContent) {
+		t.Error("Applied binary file should match the committed content")
+	}
+}
+
+// patchScriptTestRepo creates a git repository with three commits on HEAD and returns the
+// directory and the commit SHAs keyed by commit message
+func patchScriptTestRepo(t *testing.T) (string, map[string]string) {
+	t.Helper()
+	repoDir := testutil.TempDir(t, "test-patch-script-*")
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, output)
+		return strings.TrimSpace(string(output))
+	}
+
+	git("init", "-q", "-b", "main")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test User")
+
+	shas := make(map[string]string)
+	commit := func(file, message string) {
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, file), []byte(message+"\n"), 0644))
+		git("add", file)
+		git("commit", "-q", "-m", message)
+		shas[message] = git("rev-parse", "HEAD")
+	}
+
+	commit("initial.txt", "Initial commit")
+	commit("first.txt", "First change")
+	commit("second.txt", "Second change")
+
+	return repoDir, shas
+}
+
+// createPullRequestSafeOutput is a create_pull_request entry whose branch does not exist locally,
+// so the patch script falls back to the commits on HEAD with the create-pull-request options
+const createPullRequestSafeOutput = `{"type":"create_pull_request","branch":"agent/new-feature","title":"New feature"}` + "\n"
+
+// runPatchScript runs generate_git_patch.sh in repoDir with the given safe outputs and extra
+// environment, and returns the script output and the generated patch for the main branch
+func runPatchScript(t *testing.T, repoDir, safeOutputs string, env ...string) (string, string, error) {
+	t.Helper()
+
+	safeOutputsFile := filepath.Join(repoDir, "safe-outputs.jsonl")
+	require.NoError(t, os.WriteFile(safeOutputsFile, []byte(safeOutputs), 0644))
+
+	scriptContent, err := os.ReadFile(filepath.Join("..", "..", "actions", "setup", "sh", "generate_git_patch.sh"))
+	require.NoError(t, err, "Should read patch script")
+	scriptFile := filepath.Join(repoDir, "generate_patch.sh")
+	require.NoError(t, os.WriteFile(scriptFile, scriptContent, 0755))
+
+	// Ensure /tmp/gh-aw exists and is clean
+	require.NoError(t, os.MkdirAll("/tmp/gh-aw", 0755))
+	patchFile := filepath.Join("/tmp/gh-aw", "aw-main.patch")
+	os.Remove(patchFile)
+
+	cmd := exec.Command("bash", scriptFile)
+	cmd.Dir = repoDir
+	cmd.Env = append(os.Environ(),
+		"GH_AW_SAFE_OUTPUTS="+safeOutputsFile,
+		"DEFAULT_BRANCH=main",
+		"GITHUB_STEP_SUMMARY="+filepath.Join(repoDir, "step-summary.md"),
+	)
+	cmd.Env = append(cmd.Env, env...)
+	output, runErr := cmd.CombinedOutput()
+	t.Logf("Script output:\n%s", output)
+
+	patchContent, _ := os.ReadFile(patchFile)
+	return string(output), string(patchContent), runErr
+}
+
+func TestGitPatchIgnoresCreatePullRequestOptionsForPushToPullRequestBranch(t *testing.T) {
+	repoDir, shas := patchScriptTestRepo(t)
+
+	// The create-pull-request size limit would abort this patch if it were applied
+	pushSafeOutput := `{"type":"push_to_pull_request_branch","branch":"agent/update"}` + "\n"
+	output, patch, err := runPatchScript(t, repoDir, pushSafeOutput,
+		"GITHUB_SHA="+shas["Initial commit"],
+		"GH_AW_MAX_PATCH_BYTES=10",
+	)
+	require.NoError(t, err, "Patch generation should not use the create-pull-request size limit")
+
+	assert.Contains(t, output, "Number of commits: 2", "All commits since checkout should be counted")
+	assert.Contains(t, patch, "first.txt")
+	assert.Contains(t, patch, "second.txt")
+}
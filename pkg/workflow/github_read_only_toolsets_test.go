@@ -0,0 +1,267 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repoReadOnlyTools are the read-only tools of the repos toolset
+const repoReadOnlyTools = "get_commit,get_file_contents,get_latest_release,get_release_by_tag,get_repository_tree,get_tag,list_branches,list_commits,list_releases,list_tags"
+
+func TestGetGitHubToolsetAccess(t *testing.T) {
+	tests := []struct {
+		name       string
+		githubTool any
+		expected   gitHubToolsetAccess
+	}{
+		{
+			name:       "defaults to read-only",
+			githubTool: map[string]any{},
+			expected:   gitHubToolsetAccess{ReadOnly: true, Toolsets: "context,repos,issues,pull_requests"},
+		},
+		{
+			name: "full read-only ignores read-only toolsets",
+			githubTool: map[string]any{
+				"read-only":          true,
+				"toolsets":           []any{"repos", "issues"},
+				"read-only-toolsets": []any{"repos"},
+			},
+			expected: gitHubToolsetAccess{ReadOnly: true, Toolsets: "repos,issues"},
+		},
+		{
+			name: "writable without read-only toolsets",
+			githubTool: map[string]any{
+				"read-only": false,
+				"toolsets":  []any{"repos", "issues"},
+			},
+			expected: gitHubToolsetAccess{Toolsets: "repos,issues"},
+		},
+		{
+			name: "partial read-only",
+			githubTool: map[string]any{
+				"read-only":          false,
+				"toolsets":           []any{"repos", "issues"},
+				"read-only-toolsets": []any{"repos"},
+			},
+			expected: gitHubToolsetAccess{Toolsets: "issues", Tools: repoReadOnlyTools},
+		},
+		{
+			name: "partial read-only with default toolsets",
+			githubTool: map[string]any{
+				"read-only":          false,
+				"read-only-toolsets": []any{"repos", "context"},
+			},
+			expected: gitHubToolsetAccess{
+				Toolsets: "issues,pull_requests",
+				Tools:    "get_commit,get_copilot_space,get_file_contents,get_latest_release,get_release_by_tag,get_repository_tree,get_tag,github_support_docs_search,list_branches,list_commits,list_copilot_spaces,list_releases,list_tags",
+			},
+		},
+		{
+			name: "overlapping read-only tools are deduplicated",
+			githubTool: map[string]any{
+				"read-only":          false,
+				"toolsets":           []any{"issues", "pull_requests", "search", "actions"},
+				"read-only-toolsets": []any{"issues", "search"},
+			},
+			expected: gitHubToolsetAccess{
+				Toolsets: "actions,pull_requests",
+				Tools:    "issue_read,list_issue_types,list_issues,search_code,search_issues,search_orgs,search_pull_requests,search_repositories,search_users",
+			},
+		},
+		{
+			name: "all toolsets read-only falls back to read-only mode",
+			githubTool: map[string]any{
+				"read-only":          false,
+				"toolsets":           []any{"repos", "issues"},
+				"read-only-toolsets": []any{"issues", "repos"},
+			},
+			expected: gitHubToolsetAccess{ReadOnly: true, Toolsets: "repos,issues"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getGitHubToolsetAccess(tt.githubTool))
+		})
+	}
+}
+
+func TestRenderGitHubMCPReadOnlyToolsets(t *testing.T) {
+	partialReadOnly := map[string]any{
+		"read-only":          false,
+		"toolsets":           []any{"repos", "issues"},
+		"read-only-toolsets": []any{"repos"},
+	}
+	fullReadOnly := map[string]any{
+		"read-only": true,
+		"toolsets":  []any{"repos", "issues"},
+	}
+	withMode := func(githubTool map[string]any, mode string) map[string]any {
+		result := map[string]any{"mode": mode}
+		for key, value := range githubTool {
+			result[key] = value
+		}
+		return result
+	}
+
+	tests := []struct {
+		name        string
+		format      string
+		githubTool  map[string]any
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:       "local partial read-only",
+			format:     "json",
+			githubTool: withMode(partialReadOnly, "local"),
+			contains: []string{
+				`"GITHUB_TOOLSETS": "issues"`,
+				`"GITHUB_TOOLS": "` + repoReadOnlyTools + `"`,
+			},
+			notContains: []string{"GITHUB_READ_ONLY"},
+		},
+		{
+			name:        "local full read-only",
+			format:      "json",
+			githubTool:  withMode(fullReadOnly, "local"),
+			contains:    []string{`"GITHUB_READ_ONLY": "1"`, `"GITHUB_TOOLSETS": "repos,issues"`},
+			notContains: []string{"GITHUB_TOOLS\""},
+		},
+		{
+			name:       "remote partial read-only",
+			format:     "json",
+			githubTool: withMode(partialReadOnly, "remote"),
+			contains: []string{
+				`"X-MCP-Toolsets": "issues"`,
+				`"X-MCP-Tools": "` + repoReadOnlyTools + `"`,
+			},
+			notContains: []string{"X-MCP-Readonly"},
+		},
+		{
+			name:        "remote full read-only",
+			format:      "json",
+			githubTool:  withMode(fullReadOnly, "remote"),
+			contains:    []string{`"X-MCP-Readonly": "true"`, `"X-MCP-Toolsets": "repos,issues"`},
+			notContains: []string{"X-MCP-Tools\""},
+		},
+		{
+			name:       "toml local partial read-only",
+			format:     "toml",
+			githubTool: withMode(partialReadOnly, "local"),
+			contains: []string{
+				`"GITHUB_TOOLSETS" = "issues"`,
+				`"GITHUB_TOOLS" = "` + repoReadOnlyTools + `"`,
+			},
+			notContains: []string{"GITHUB_READ_ONLY"},
+		},
+		{
+			name:       "toml remote partial read-only",
+			format:     "toml",
+			githubTool: withMode(partialReadOnly, "remote"),
+			contains: []string{
+				`url = "https://api.githubcopilot.com/mcp/"`,
+				`http_headers = { "X-MCP-Toolsets" = "issues", "X-MCP-Tools" = "` + repoReadOnlyTools + `" }`,
+			},
+			notContains: []string{"mcp-readonly"},
+		},
+		{
+			name:        "toml remote full read-only",
+			format:      "toml",
+			githubTool:  withMode(fullReadOnly, "remote"),
+			contains:    []string{`url = "https://api.githubcopilot.com/mcp-readonly/"`},
+			notContains: []string{"http_headers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := NewMCPConfigRenderer(MCPRendererOptions{
+				IncludeCopilotFields: true,
+				Format:               tt.format,
+				IsLast:               true,
+			})
+
+			var yaml strings.Builder
+			renderer.RenderGitHubMCP(&yaml, tt.githubTool, &WorkflowData{Name: "test-workflow"})
+			output := yaml.String()
+
+			for _, expected := range tt.contains {
+				assert.Contains(t, output, expected)
+			}
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, output, unexpected)
+			}
+		})
+	}
+}
+
+func TestCompileWorkflowWithGitHubReadOnlyToolsets(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "github-read-only-toolsets-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+tools:
+  github:
+    read-only: false
+    toolsets: [repos, issues]
+    read-only-toolsets: [repos]
+---
+
+# Triage
+
+Read the repository and triage issues.
+`
+
+	testFile := filepath.Join(tmpDir, "read-only-toolsets.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with read-only-toolsets should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	agentJob := extractJobSection(string(lockContent), "agent")
+
+	assert.Contains(t, agentJob, `"GITHUB_TOOLSETS": "issues"`, "Only the writable toolsets should be enabled as toolsets")
+	assert.Contains(t, agentJob, `"GITHUB_TOOLS": "`+repoReadOnlyTools+`"`, "Read-only toolsets should only enable their read-only tools")
+	assert.NotContains(t, agentJob, "GITHUB_READ_ONLY", "The server should not be in read-only mode")
+}
+
+func TestCompileWorkflowRejectsUnknownGitHubReadOnlyToolset(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "github-read-only-toolsets-invalid-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+tools:
+  github:
+    read-only: false
+    toolsets: [repos, issues]
+    read-only-toolsets: [code]
+---
+
+# Triage
+
+Read the repository and triage issues.
+`
+
+	testFile := filepath.Join(tmpDir, "read-only-toolsets-invalid.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Unknown toolsets in read-only-toolsets should be rejected")
+	assert.Contains(t, err.Error(), "read-only-toolsets")
+}
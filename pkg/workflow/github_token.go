@@ -80,3 +80,18 @@ func getEffectiveProjectGitHubToken(customToken string) string {
 	tokenLog.Print("Using GH_AW_PROJECT_GITHUB_TOKEN for project operations")
 	return "${{ secrets.GH_AW_PROJECT_GITHUB_TOKEN }}"
 }
+
+// getEffectiveGistGitHubToken returns the GitHub token to use for creating gists,
+// with precedence:
+// 1. Custom token passed as parameter (e.g., from safe-outputs.create-gist.github-token)
+// 2. secrets.GH_AW_GIST_TOKEN (required token for gist operations)
+// Note: GitHub Actions has no gist permission scope, so the default GITHUB_TOKEN can never
+// create gists. A PAT with the 'gist' scope (classic) or Gists: Read+Write (fine-grained) is required.
+func getEffectiveGistGitHubToken(customToken string) string {
+	if customToken != "" {
+		tokenLog.Print("Using custom gist GitHub token")
+		return customToken
+	}
+	tokenLog.Print("Using GH_AW_GIST_TOKEN for gist operations")
+	return "${{ secrets.GH_AW_GIST_TOKEN }}"
+}
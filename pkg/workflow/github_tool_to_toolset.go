@@ -7,8 +7,10 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
+	"golang.org/x/mod/semver"
 )
 
 var githubToolToToolsetLog = logger.New("workflow:github_tool_to_toolset")
@@ -21,6 +23,11 @@ var githubToolToToolsetJSON []byte
 // in .github/instructions/github-mcp-server.instructions.md
 var GitHubToolToToolsetMap map[string]string
 
+// GitHubToolCatalogVersion is the github-mcp-server release GitHubToolToToolsetMap describes.
+// Tool names can only be verified for this release and older ones; update it together with
+// the JSON catalog when bumping the default server version.
+const GitHubToolCatalogVersion = constants.DefaultGitHubMCPServerVersion
+
 func init() {
 	// Load the mapping from embedded JSON
 	if err := json.Unmarshal(githubToolToToolsetJSON, &GitHubToolToToolsetMap); err != nil {
@@ -28,6 +35,83 @@ func init() {
 	}
 }
 
+// ExpandGitHubToolPatterns expands glob patterns such as "issue_*" in a GitHub allowed list
+// into the matching tools from GitHubToolToToolsetMap, sorted by name. Exact names and the
+// "*" wildcard are kept as-is and duplicates are dropped. Patterns that match no known tool
+// are kept so that ValidateGitHubToolsAgainstToolsets reports them.
+func ExpandGitHubToolPatterns(allowedTools []string) []string {
+	knownTools := make([]string, 0, len(GitHubToolToToolsetMap))
+	for tool := range GitHubToolToToolsetMap {
+		knownTools = append(knownTools, tool)
+	}
+	sort.Strings(knownTools)
+
+	seen := make(map[string]bool, len(allowedTools))
+	expanded := make([]string, 0, len(allowedTools))
+	add := func(tool string) {
+		if !seen[tool] {
+			seen[tool] = true
+			expanded = append(expanded, tool)
+		}
+	}
+
+	for _, tool := range allowedTools {
+		if !parser.IsToolPattern(tool) {
+			add(tool)
+			continue
+		}
+
+		var matches []string
+		for _, known := range knownTools {
+			if parser.MatchToolPattern(tool, known) {
+				matches = append(matches, known)
+			}
+		}
+		githubToolToToolsetLog.Printf("Expanded GitHub tool pattern %s to %d tools", tool, len(matches))
+		if len(matches) == 0 {
+			add(tool)
+			continue
+		}
+		for _, match := range matches {
+			add(match)
+		}
+	}
+
+	return expanded
+}
+
+// githubToolCatalogCovers reports whether the tool catalog can verify tool names for the
+// configured github-mcp-server version. Release tags up to GitHubToolCatalogVersion are covered.
+// Newer releases, prereleases, digests and other references (such as "latest") may ship tools the
+// catalog does not know about yet.
+func githubToolCatalogCovers(version string) bool {
+	if version == "" {
+		return true
+	}
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !semver.IsValid(version) || semver.Prerelease(version) != "" || semver.Build(version) != "" {
+		githubToolToToolsetLog.Printf("GitHub MCP server version %s is not a release tag, tool catalog does not cover it", version)
+		return false
+	}
+	return compareVersions(version, string(GitHubToolCatalogVersion)) <= 0
+}
+
+// unknownGitHubTools returns the allowed tools that are not in the tool catalog
+func unknownGitHubTools(allowedTools []string) []string {
+	var unknown []string
+	for _, tool := range allowedTools {
+		if tool == "*" {
+			continue
+		}
+		if _, exists := GitHubToolToToolsetMap[tool]; !exists {
+			unknown = append(unknown, tool)
+		}
+	}
+	return unknown
+}
+
 // ValidateGitHubToolsAgainstToolsets validates that all allowed GitHub tools have their
 // corresponding toolsets enabled in the configuration
 func ValidateGitHubToolsAgainstToolsets(allowedTools []string, enabledToolsets []string) error {
@@ -59,6 +143,13 @@ func ValidateGitHubToolsAgainstToolsets(allowedTools []string, enabledToolsets [
 			continue
 		}
 
+		// Patterns reaching validation did not match any known tool during expansion
+		if parser.IsToolPattern(tool) {
+			githubToolToToolsetLog.Printf("Tool pattern %s matches no known GitHub tools", tool)
+			unknownTools = append(unknownTools, tool)
+			continue
+		}
+
 		requiredToolset, exists := GitHubToolToToolsetMap[tool]
 		if !exists {
 			githubToolToToolsetLog.Printf("Tool %s not found in mapping, checking for typo", tool)
@@ -71,11 +162,10 @@ func ValidateGitHubToolsAgainstToolsets(allowedTools []string, enabledToolsets [
 			sort.Strings(validTools)
 
 			// Try to find close matches
-			matches := parser.FindClosestMatches(tool, validTools, 1)
-			if len(matches) > 0 {
-				githubToolToToolsetLog.Printf("Found suggestion for unknown tool %s: %s", tool, matches[0])
+			if match := suggestGitHubTool(tool, validTools); match != "" {
+				githubToolToToolsetLog.Printf("Found suggestion for unknown tool %s: %s", tool, match)
 				unknownTools = append(unknownTools, tool)
-				suggestions = append(suggestions, fmt.Sprintf("%s → %s", tool, matches[0]))
+				suggestions = append(suggestions, fmt.Sprintf("%s → %s", tool, match))
 			} else {
 				githubToolToToolsetLog.Printf("No suggestion found for unknown tool: %s", tool)
 				unknownTools = append(unknownTools, tool)
@@ -114,6 +204,7 @@ func ValidateGitHubToolsAgainstToolsets(allowedTools []string, enabledToolsets [
 
 		exampleCount := min(10, len(validTools))
 		errMsg.WriteString(fmt.Sprintf("Valid GitHub tools include: %s\n\n", formatList(validTools[:exampleCount])))
+		errMsg.WriteString(fmt.Sprintf("Tool names are checked against github-mcp-server %s. When 'tools.github.version' pins a newer or unreleased server version, unknown tools are reported as warnings instead.\n\n", GitHubToolCatalogVersion))
 		errMsg.WriteString("See all tools: https://github.com/github/gh-aw/blob/main/pkg/workflow/data/github_tool_to_toolset.json")
 
 		return fmt.Errorf("%s", errMsg.String())
@@ -128,6 +219,29 @@ func ValidateGitHubToolsAgainstToolsets(allowedTools []string, enabledToolsets [
 	return nil
 }
 
+// suggestGitHubTool returns the known tool closest to an unknown tool name, or an empty string.
+// Names are compared as typed and with their words sorted, so that swapped words such as
+// "issue_crate" still suggest "create_issue".
+func suggestGitHubTool(tool string, validTools []string) string {
+	if matches := parser.FindClosestMatches(tool, validTools, 1); len(matches) > 0 {
+		return matches[0]
+	}
+
+	sortedWords := func(name string) string {
+		words := strings.Split(strings.ToLower(name), "_")
+		sort.Strings(words)
+		return strings.Join(words, "_")
+	}
+	target := sortedWords(tool)
+	best, bestDistance := "", 3
+	for _, candidate := range validTools {
+		if distance := parser.LevenshteinDistance(target, sortedWords(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
 // formatList formats a list of strings as a comma-separated list
 func formatList(items []string) string {
 	if len(items) == 0 {
@@ -139,5 +253,5 @@ func formatList(items []string) string {
 	if len(items) == 2 {
 		return items[0] + " and " + items[1]
 	}
-	return fmt.Sprintf("%s, and %s", formatList(items[:len(items)-1]), items[len(items)-1])
+	return fmt.Sprintf("%s, and %s", strings.Join(items[:len(items)-1], ", "), items[len(items)-1])
 }
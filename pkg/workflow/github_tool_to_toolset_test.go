@@ -3,8 +3,12 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
 )
 
 func TestValidateGitHubToolsAgainstToolsets(t *testing.T) {
@@ -72,6 +76,13 @@ func TestValidateGitHubToolsAgainstToolsets(t *testing.T) {
 			expectError:     true,
 			errorContains:   []string{"pull_requests", "search_pull_requests"},
 		},
+		{
+			name:            "Tool pattern matching no known tools is reported",
+			allowedTools:    []string{"get_repository", "nonexistent_*"},
+			enabledToolsets: []string{"repos"},
+			expectError:     true,
+			errorContains:   []string{"Unknown GitHub tool", "nonexistent_*"},
+		},
 		{
 			name:            "Unknown tool is ignored",
 			allowedTools:    []string{"get_repository", "unknown_tool_xyz"},
@@ -435,3 +446,153 @@ func TestValidateGitHubToolsMultipleUnknown(t *testing.T) {
 		t.Errorf("Expected 'Did you mean:' in error message, got: %s", errorMsg)
 	}
 }
+
+// TestExpandGitHubToolPatterns tests expansion of glob patterns against the GitHub tool catalog
+func TestExpandGitHubToolPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		allowed  []string
+		expected []string
+	}{
+		{
+			name:     "Exact names are unchanged",
+			allowed:  []string{"get_repository", "list_issues"},
+			expected: []string{"get_repository", "list_issues"},
+		},
+		{
+			name:     "Wildcard is kept as-is",
+			allowed:  []string{"*"},
+			expected: []string{"*"},
+		},
+		{
+			name:     "Prefix pattern expands to matching tools in sorted order",
+			allowed:  []string{"get_team*"},
+			expected: []string{"get_team_members", "get_teams"},
+		},
+		{
+			name:     "Suffix pattern expands using the catalog",
+			allowed:  []string{"*_issue"},
+			expected: []string{"create_issue", "update_issue"},
+		},
+		{
+			name:     "Duplicates between exact names and patterns are dropped",
+			allowed:  []string{"get_teams", "get_team*"},
+			expected: []string{"get_teams", "get_team_members"},
+		},
+		{
+			name:     "Pattern matching nothing is kept for validation",
+			allowed:  []string{"nonexistent_*"},
+			expected: []string{"nonexistent_*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExpandGitHubToolPatterns(tt.allowed)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("ExpandGitHubToolPatterns(%v) = %v, want %v", tt.allowed, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubToolCatalogCovers(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected bool
+	}{
+		{version: "", expected: true},
+		{version: string(GitHubToolCatalogVersion), expected: true},
+		{version: "v0.20.0", expected: true},
+		{version: "0.20.0", expected: true},
+		{version: "v99.0.0", expected: false},
+		{version: "v0.31.0-rc.1", expected: false},
+		{version: "latest", expected: false},
+		{version: "sha256:" + strings.Repeat("a", 64), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := githubToolCatalogCovers(tt.version); got != tt.expected {
+				t.Errorf("githubToolCatalogCovers(%q) = %v, want %v", tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompileWorkflowGitHubAllowedToolNames(t *testing.T) {
+	tests := []struct {
+		name          string
+		github        string
+		expectError   bool
+		errorContains []string
+	}{
+		{
+			name: "known tools",
+			github: `    toolsets: [repos, issues]
+    allowed: [issue_read, list_issues, get_file_contents]`,
+		},
+		{
+			name: "typo is reported with a suggestion",
+			github: `    toolsets: [issues]
+    allowed: [issue_read, issue_crate]`,
+			expectError:   true,
+			errorContains: []string{"Unknown GitHub tool(s): issue_crate", "issue_crate → create_issue"},
+		},
+		{
+			name: "unreleased server version bypasses unknown tools",
+			github: `    version: v99.0.0
+    toolsets: [issues]
+    allowed: [issue_read, issue_unreleased_tool]`,
+		},
+		{
+			name: "unreleased server version still validates toolsets of known tools",
+			github: `    version: v99.0.0
+    toolsets: [issues]
+    allowed: [issue_unreleased_tool, get_file_contents]`,
+			expectError:   true,
+			errorContains: []string{"repos"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "github-allowed-tools-test")
+			testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+tools:
+  github:
+` + tt.github + `
+---
+
+# Test Workflow
+
+Read the issues.
+`
+			testFile := filepath.Join(tmpDir, "github-tools.md")
+			if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := NewCompiler().CompileWorkflow(testFile)
+			if !tt.expectError {
+				if err != nil {
+					t.Fatalf("Expected workflow to compile, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected compilation error, got nil")
+			}
+			for _, expected := range tt.errorContains {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("Expected error to contain %q, got: %v", expected, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,579 @@
+// This file implements the experimental GitLab CI compile target.
+//
+// # GitLab CI Target
+//
+// With `gh aw compile --target gitlab`, a workflow is compiled to a <name>.gitlab-ci.yml file
+// instead of a GitHub Actions lock file. The internal job model is mapped onto GitLab CI jobs:
+//
+//   - activation: renders the prompt from the workflow markdown and passes it on as an artifact
+//   - agent: installs the engine CLI and runs it with the prompt
+//
+// Jobs are chained with needs instead of stages and carry their own rules, so the generated file
+// can be added to an existing pipeline with include: local. The schedule and workflow_dispatch
+// triggers map to pipeline sources; cron expressions are listed in the header because GitLab
+// pipeline schedules are configured in the project settings rather than in YAML.
+//
+// Features without a GitLab equivalent (other triggers, safe outputs, MCP servers, custom steps,
+// the network firewall, GitHub Actions expressions, ...) are reported as "unsupported on gitlab"
+// errors instead of being dropped from the generated pipeline.
+
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/goccy/go-yaml"
+)
+
+var gitlabTargetLog = logger.New("workflow:gitlab_target")
+
+const (
+	// gitLabActivationImage is the image of the job that renders the prompt
+	gitLabActivationImage = "alpine:3.21"
+
+	// gitLabAgentImage is the image of the job that installs and runs the engine CLI
+	gitLabAgentImage = "node:22"
+
+	// gitLabPromptFile is the path of the rendered prompt, relative to the project directory
+	gitLabPromptFile = ".gh-aw/prompt.md"
+
+	// gitLabDefaultRunsOn is the runs-on value that has an equivalent on GitLab shared runners
+	gitLabDefaultRunsOn = "runs-on: ubuntu-latest"
+)
+
+// gitLabEngine describes how an engine CLI is installed and run in a GitLab job
+type gitLabEngine struct {
+	npmPackage     string
+	defaultVersion string
+	command        string
+	args           []string
+	promptFlag     string // flag preceding the prompt; empty when the prompt is a positional argument
+	modelFlag      string // flag used for engine.model when the CLI has no model environment variable
+}
+
+// gitLabEngines lists the engines supported by the GitLab target
+var gitLabEngines = map[string]gitLabEngine{
+	"copilot": {
+		npmPackage:     "@github/copilot",
+		defaultVersion: string(constants.DefaultCopilotVersion),
+		command:        "copilot",
+		args:           []string{"--add-dir", "$CI_PROJECT_DIR", "--log-level", "all", "--log-dir", ".gh-aw/logs", "--disable-builtin-mcps"},
+		promptFlag:     "--prompt",
+	},
+	"claude": {
+		npmPackage:     "@anthropic-ai/claude-code",
+		defaultVersion: string(constants.DefaultClaudeCodeVersion),
+		command:        "claude",
+		args:           []string{"--print", "--disable-slash-commands", "--permission-mode", "bypassPermissions", "--debug-file", ".gh-aw/logs/agent.log"},
+	},
+	"codex": {
+		npmPackage:     "@openai/codex",
+		defaultVersion: string(constants.DefaultCodexVersion),
+		command:        "codex",
+		args:           []string{"exec", "--dangerously-bypass-approvals-and-sandbox", "--skip-git-repo-check"},
+		modelFlag:      "--model",
+	},
+}
+
+// gitLabSupportedTools are the tools provided by the engine CLIs themselves. Other tools are
+// MCP servers, which need the MCP gateway of the GitHub Actions runtime.
+var gitLabSupportedTools = []string{"bash", "edit", "web-fetch", "web-search"}
+
+// unsupportedOnGitLab returns the error reported for a feature without a GitLab equivalent
+func unsupportedOnGitLab(feature, reason string) error {
+	return fmt.Errorf("%s is unsupported on gitlab: %s", feature, reason)
+}
+
+// gitLabTriggers holds the triggers of a workflow that map to GitLab pipeline sources
+type gitLabTriggers struct {
+	schedules []string
+	manual    bool
+	inputs    []gitLabInput
+}
+
+// gitLabInput is a workflow_dispatch input exposed as a pipeline variable
+type gitLabInput struct {
+	name        string
+	value       string
+	description string
+}
+
+// generateGitLabCI generates the GitLab CI YAML for a workflow, or an error listing every feature
+// of the workflow that is unsupported on gitlab
+func (c *Compiler) generateGitLabCI(data *WorkflowData, markdownPath string) (string, error) {
+	gitlabTargetLog.Printf("Generating GitLab CI for workflow: %s", data.Name)
+	collector := NewErrorCollector(c.failFast)
+
+	triggers, err := parseGitLabTriggers(data.On, collector)
+	if err != nil {
+		return "", err
+	}
+	if err := checkGitLabUnsupportedFeatures(data, collector); err != nil {
+		return "", err
+	}
+
+	engineID := gitLabEngineID(data)
+	engine, engineSupported := gitLabEngines[engineID]
+	if !engineSupported {
+		if err := collector.Add(unsupportedOnGitLab(fmt.Sprintf("engine '%s'", engineID), "supported engines are claude, codex and copilot")); err != nil {
+			return "", err
+		}
+	}
+
+	prompt, err := buildGitLabPrompt(data, markdownPath)
+	if err != nil {
+		if returnErr := collector.Add(err); returnErr != nil {
+			return "", returnErr
+		}
+	}
+
+	if collector.HasErrors() {
+		return "", collector.FormattedError("gitlab target")
+	}
+
+	// The GitHub MCP server added by default is not available on GitLab, so it is left out of
+	// the tool arguments and the required secrets
+	gitLabData := *data
+	gitLabData.Tools = make(map[string]any, len(data.Tools))
+	for name, value := range data.Tools {
+		if name != "github" {
+			gitLabData.Tools[name] = value
+		}
+	}
+	gitLabData.ParsedTools = NewTools(gitLabData.Tools)
+
+	script, err := c.buildGitLabAgentScript(&gitLabData, engineID, engine)
+	if err != nil {
+		return "", err
+	}
+
+	var yaml strings.Builder
+	c.writeGitLabHeader(&yaml, &gitLabData, markdownPath, triggers)
+	writeGitLabVariables(&yaml, triggers.inputs)
+	writeGitLabActivationJob(&yaml, data.WorkflowID, triggers, prompt)
+	writeGitLabAgentJob(&yaml, data, engineID, triggers, script)
+
+	gitlabTargetLog.Printf("Generated GitLab CI: %d bytes", yaml.Len())
+	return yaml.String(), nil
+}
+
+// parseGitLabTriggers maps the on: section onto GitLab pipeline sources
+func parseGitLabTriggers(on string, collector *ErrorCollector) (*gitLabTriggers, error) {
+	var parsed map[string]any
+	if err := yaml.Unmarshal([]byte(on), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse triggers: %w", err)
+	}
+
+	triggers := &gitLabTriggers{}
+	events := map[string]any{}
+	switch on := parsed["on"].(type) {
+	case map[string]any:
+		events = on
+	case string:
+		events[on] = nil
+	case []any:
+		for _, event := range on {
+			if name, ok := event.(string); ok {
+				events[name] = nil
+			}
+		}
+	}
+	eventNames := make([]string, 0, len(events))
+	for name := range events {
+		eventNames = append(eventNames, name)
+	}
+	sort.Strings(eventNames)
+
+	for _, name := range eventNames {
+		switch name {
+		case "schedule":
+			items, _ := events[name].([]any)
+			for _, item := range items {
+				if itemMap, ok := item.(map[string]any); ok {
+					if cron, ok := itemMap["cron"].(string); ok {
+						triggers.schedules = append(triggers.schedules, cron)
+					}
+				}
+			}
+		case "workflow_dispatch":
+			triggers.manual = true
+			triggers.inputs = parseGitLabInputs(events[name])
+		default:
+			if err := collector.Add(unsupportedOnGitLab(fmt.Sprintf("trigger '%s'", name), "only schedule and workflow_dispatch triggers can be mapped to pipeline sources")); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !triggers.manual && len(triggers.schedules) == 0 && len(eventNames) == 0 {
+		if err := collector.Add(fmt.Errorf("workflow has no schedule or workflow_dispatch trigger to map to gitlab pipeline sources")); err != nil {
+			return nil, err
+		}
+	}
+	return triggers, nil
+}
+
+// parseGitLabInputs returns the workflow_dispatch inputs, sorted by name
+func parseGitLabInputs(dispatch any) []gitLabInput {
+	dispatchMap, _ := dispatch.(map[string]any)
+	inputsMap, _ := dispatchMap["inputs"].(map[string]any)
+
+	var inputs []gitLabInput
+	for name, config := range inputsMap {
+		input := gitLabInput{name: name}
+		if configMap, ok := config.(map[string]any); ok {
+			if description, ok := configMap["description"].(string); ok {
+				input.description = description
+			}
+			if value, ok := configMap["default"]; ok && value != nil {
+				input.value = fmt.Sprint(value)
+			}
+		}
+		inputs = append(inputs, input)
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].name < inputs[j].name })
+	return inputs
+}
+
+// checkGitLabUnsupportedFeatures reports the workflow features that rely on GitHub Actions
+func checkGitLabUnsupportedFeatures(data *WorkflowData, collector *ErrorCollector) error {
+	var errs []error
+
+	if data.SafeOutputs != nil {
+		errs = append(errs, unsupportedOnGitLab("safe-outputs", "safe output handlers call the GitHub API"))
+	}
+	if data.SafeInputs != nil {
+		errs = append(errs, unsupportedOnGitLab("safe-inputs", "safe-inputs tools are served through the MCP gateway"))
+	}
+
+	toolNames := make([]string, 0, len(data.Tools))
+	for name := range data.Tools {
+		toolNames = append(toolNames, name)
+	}
+	sort.Strings(toolNames)
+	for _, name := range toolNames {
+		if name == "github" && !data.HasExplicitGitHubTool {
+			// The GitHub MCP server is added by default; it is left out of the GitLab pipeline
+			continue
+		}
+		if !slices.Contains(gitLabSupportedTools, name) {
+			errs = append(errs, unsupportedOnGitLab(fmt.Sprintf("tools.%s", name), "only tools built into the engine CLI (bash, edit, web-fetch, web-search) are available"))
+		}
+	}
+
+	if len(data.Jobs) > 0 {
+		errs = append(errs, unsupportedOnGitLab("jobs", "custom jobs are GitHub Actions jobs"))
+	}
+	if data.CustomSteps != "" {
+		errs = append(errs, unsupportedOnGitLab("steps", "custom steps are GitHub Actions steps"))
+	}
+	if data.PostSteps != "" {
+		errs = append(errs, unsupportedOnGitLab("post-steps", "custom steps are GitHub Actions steps"))
+	}
+	if data.RunsOn != "" && data.RunsOn != gitLabDefaultRunsOn {
+		errs = append(errs, unsupportedOnGitLab("runs-on", "GitHub runner labels do not map to GitLab runner tags"))
+	}
+	for feature, value := range map[string]string{"container": data.Container, "services": data.Services, "environment": data.Environment, "strategy": data.Strategy, "if": data.If} {
+		if value != "" {
+			errs = append(errs, unsupportedOnGitLab(feature, "the job setting has no GitLab equivalent in the generated pipeline"))
+		}
+	}
+	if data.StopTime != "" {
+		errs = append(errs, unsupportedOnGitLab("stop-after", "the stop time check runs in the GitHub Actions pre-activation job"))
+	}
+	if data.SkipIfMatch != nil || data.SkipIfNoMatch != nil {
+		errs = append(errs, unsupportedOnGitLab("skip-if-match/skip-if-no-match", "the checks use GitHub search"))
+	}
+	if data.PluginInfo != nil && len(data.PluginInfo.Plugins) > 0 {
+		errs = append(errs, unsupportedOnGitLab("plugins", "plugins are installed by the GitHub Actions runtime"))
+	}
+	if network := data.NetworkPermissions; network != nil {
+		explicitAllowlist := len(network.Allowed) > 0 && !(len(network.Allowed) == 1 && network.Allowed[0] == "defaults")
+		if explicitAllowlist || len(network.Blocked) > 0 {
+			errs = append(errs, unsupportedOnGitLab("network", "the agent firewall that enforces allowed and blocked domains is not available"))
+		}
+	}
+
+	// Sort for deterministic error output (the map iteration above is unordered)
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	for _, err := range errs {
+		if returnErr := collector.Add(err); returnErr != nil {
+			return returnErr
+		}
+	}
+	return nil
+}
+
+// gitLabEngineID returns the engine of the workflow
+func gitLabEngineID(data *WorkflowData) string {
+	if data.EngineConfig != nil && data.EngineConfig.ID != "" {
+		return data.EngineConfig.ID
+	}
+	if data.AI != "" {
+		return data.AI
+	}
+	return "copilot"
+}
+
+// buildGitLabPrompt inlines the workflow markdown and its imports into a single prompt
+func buildGitLabPrompt(data *WorkflowData, markdownPath string) (string, error) {
+	var parts []string
+
+	if data.ImportedMarkdown != "" {
+		imported := removeXMLComments(data.ImportedMarkdown)
+		if len(data.ImportInputs) > 0 {
+			imported = SubstituteImportInputs(imported, data.ImportInputs)
+		}
+		parts = append(parts, imported)
+	}
+
+	workspaceRoot := resolveWorkspaceRoot(markdownPath)
+	for _, importPath := range data.ImportPaths {
+		content, err := os.ReadFile(filepath.Join(workspaceRoot, filepath.FromSlash(importPath)))
+		if err != nil {
+			return "", fmt.Errorf("failed to read import %s: %w", importPath, err)
+		}
+		body, err := parser.ExtractMarkdownContent(string(content))
+		if err != nil {
+			body = string(content)
+		}
+		parts = append(parts, removeXMLComments(body))
+	}
+
+	markdown := data.MainWorkflowMarkdown
+	if markdown == "" {
+		markdown = data.MarkdownContent
+	}
+	parts = append(parts, removeXMLComments(markdown))
+
+	prompt := strings.TrimSpace(strings.Join(parts, "\n\n"))
+	if start := strings.Index(prompt, "${{"); start >= 0 {
+		expression := prompt[start:]
+		if end := strings.Index(expression, "}}"); end >= 0 {
+			expression = expression[:end+2]
+		}
+		return "", unsupportedOnGitLab(fmt.Sprintf("expression '%s' in the prompt", expression), "GitHub Actions expressions are not evaluated; workflow_dispatch inputs are available to the agent as environment variables instead")
+	}
+	return prompt, nil
+}
+
+// buildGitLabAgentScript returns the script lines that install and run the engine CLI
+func (c *Compiler) buildGitLabAgentScript(data *WorkflowData, engineID string, engine gitLabEngine) ([]string, error) {
+	config := data.EngineConfig
+	if config == nil {
+		config = &EngineConfig{}
+	}
+
+	script := []string{"mkdir -p .gh-aw/logs"}
+	command := engine.command
+	if config.Command != "" {
+		command = config.Command
+	} else {
+		version := engine.defaultVersion
+		if config.Version != "" {
+			version = config.Version
+		}
+		script = append(script, fmt.Sprintf("npm install -g --silent %s@%s", engine.npmPackage, version))
+	}
+
+	args := slices.Clone(engine.args)
+	if config.Model != "" && engine.modelFlag != "" {
+		args = append(args, engine.modelFlag, config.Model)
+	}
+
+	// Restrict the tools like the GitHub Actions pipeline does
+	codingEngine, err := c.engineRegistry.GetEngine(engineID)
+	if err != nil {
+		return nil, err
+	}
+	switch e := codingEngine.(type) {
+	case *CopilotEngine:
+		args = append(args, e.computeCopilotToolArguments(data.Tools, nil, nil, data)...)
+	case *ClaudeEngine:
+		if config.MaxTurns != "" {
+			args = append(args, "--max-turns", config.MaxTurns)
+		}
+		if allowedTools := e.computeAllowedClaudeToolsString(data.Tools, nil, nil); allowedTools != "" {
+			args = append(args, "--allowed-tools", allowedTools)
+		}
+	}
+	args = append(args, config.Args...)
+
+	if engine.promptFlag != "" {
+		args = append(args, engine.promptFlag)
+	}
+	args = append(args, fmt.Sprintf("\"$(cat %s)\"", gitLabPromptFile))
+
+	// $CI_PROJECT_DIR must be expanded by the shell, so it is double-quoted rather than escaped
+	joined := strings.ReplaceAll(shellJoinArgs(args), "'$CI_PROJECT_DIR'", "\"$CI_PROJECT_DIR\"")
+	script = append(script, command+" "+joined)
+	return script, nil
+}
+
+// writeGitLabHeader writes the comment header of the generated pipeline
+func (c *Compiler) writeGitLabHeader(yaml *strings.Builder, data *WorkflowData, markdownPath string, triggers *gitLabTriggers) {
+	workspaceRoot := resolveWorkspaceRoot(markdownPath)
+	relPath, err := filepath.Rel(workspaceRoot, GitLabCIFile(markdownPath))
+	if err != nil {
+		relPath = filepath.Base(GitLabCIFile(markdownPath))
+	}
+	sourcePath, err := filepath.Rel(workspaceRoot, markdownPath)
+	if err != nil {
+		sourcePath = filepath.Base(markdownPath)
+	}
+
+	yaml.WriteString("# This file was automatically generated by gh-aw (experimental GitLab CI target). DO NOT EDIT.\n")
+	yaml.WriteString("#\n")
+	fmt.Fprintf(yaml, "# Source: %s\n", filepath.ToSlash(sourcePath))
+	yaml.WriteString("#\n")
+	yaml.WriteString("# Add it to the project pipeline from .gitlab-ci.yml:\n")
+	yaml.WriteString("#   include:\n")
+	fmt.Fprintf(yaml, "#     - local: %s\n", filepath.ToSlash(relPath))
+
+	engineID := gitLabEngineID(data)
+	if codingEngine, err := c.engineRegistry.GetEngine(engineID); err == nil {
+		if secrets := codingEngine.GetRequiredSecretNames(data); len(secrets) > 0 {
+			yaml.WriteString("#\n")
+			yaml.WriteString("# Required CI/CD variables (masked):\n")
+			for _, secret := range secrets {
+				fmt.Fprintf(yaml, "#   - %s\n", secret)
+			}
+		}
+	}
+
+	if len(triggers.schedules) > 0 {
+		yaml.WriteString("#\n")
+		yaml.WriteString("# Create pipeline schedules in the project settings with these cron expressions (UTC):\n")
+		for _, cron := range triggers.schedules {
+			fmt.Fprintf(yaml, "#   - %s\n", cron)
+		}
+	}
+	yaml.WriteString("\n")
+}
+
+// writeGitLabVariables writes the workflow_dispatch inputs as pipeline variables, which GitLab
+// prefills in the "Run pipeline" form
+func writeGitLabVariables(yaml *strings.Builder, inputs []gitLabInput) {
+	if len(inputs) == 0 {
+		return
+	}
+	yaml.WriteString("variables:\n")
+	for _, input := range inputs {
+		fmt.Fprintf(yaml, "  %s:\n", gitLabQuote(input.name))
+		fmt.Fprintf(yaml, "    value: %s\n", gitLabQuote(input.value))
+		if input.description != "" {
+			fmt.Fprintf(yaml, "    description: %s\n", gitLabQuote(input.description))
+		}
+	}
+	yaml.WriteString("\n")
+}
+
+// writeGitLabRules writes the rules that run a job for the mapped pipeline sources
+func writeGitLabRules(yaml *strings.Builder, triggers *gitLabTriggers) {
+	yaml.WriteString("  rules:\n")
+	if len(triggers.schedules) > 0 {
+		yaml.WriteString("    - if: $CI_PIPELINE_SOURCE == \"schedule\"\n")
+	}
+	if triggers.manual {
+		yaml.WriteString("    - if: $CI_PIPELINE_SOURCE == \"web\"\n")
+		yaml.WriteString("    - if: $CI_PIPELINE_SOURCE == \"api\"\n")
+	}
+}
+
+// gitLabJobName returns the name of a job, prefixed with the workflow ID so several workflows
+// can be included in the same pipeline
+func gitLabJobName(workflowID, job string) string {
+	return workflowID + ":" + job
+}
+
+// writeGitLabActivationJob writes the job that renders the prompt
+func writeGitLabActivationJob(yaml *strings.Builder, workflowID string, triggers *gitLabTriggers, prompt string) {
+	fmt.Fprintf(yaml, "%s:\n", gitLabQuote(gitLabJobName(workflowID, "activation")))
+	fmt.Fprintf(yaml, "  image: %s\n", gitLabActivationImage)
+	writeGitLabRules(yaml, triggers)
+	yaml.WriteString("  script:\n")
+	fmt.Fprintf(yaml, "    - mkdir -p %s\n", filepath.Dir(gitLabPromptFile))
+	yaml.WriteString("    - |\n")
+	fmt.Fprintf(yaml, "      cat > %s << 'GH_AW_PROMPT_EOF'\n", gitLabPromptFile)
+	for line := range strings.SplitSeq(prompt, "\n") {
+		if line == "" {
+			yaml.WriteString("\n")
+			continue
+		}
+		fmt.Fprintf(yaml, "      %s\n", line)
+	}
+	yaml.WriteString("      GH_AW_PROMPT_EOF\n")
+	yaml.WriteString("  artifacts:\n")
+	yaml.WriteString("    paths:\n")
+	fmt.Fprintf(yaml, "      - %s\n", gitLabPromptFile)
+	yaml.WriteString("    expire_in: 1 day\n")
+	yaml.WriteString("\n")
+}
+
+// writeGitLabAgentJob writes the job that runs the engine CLI
+func writeGitLabAgentJob(yaml *strings.Builder, data *WorkflowData, engineID string, triggers *gitLabTriggers, script []string) {
+	fmt.Fprintf(yaml, "%s:\n", gitLabQuote(gitLabJobName(data.WorkflowID, "agent")))
+	fmt.Fprintf(yaml, "  image: %s\n", gitLabAgentImage)
+	yaml.WriteString("  needs:\n")
+	fmt.Fprintf(yaml, "    - %s\n", gitLabQuote(gitLabJobName(data.WorkflowID, "activation")))
+	writeGitLabRules(yaml, triggers)
+	if minutes := strings.TrimSpace(strings.TrimPrefix(data.TimeoutMinutes, "timeout-minutes:")); minutes != "" {
+		fmt.Fprintf(yaml, "  timeout: %s minutes\n", minutes)
+	}
+
+	variables := map[string]string{}
+	if data.EngineConfig != nil {
+		for name, value := range data.EngineConfig.Env {
+			variables[name] = value
+		}
+		if data.EngineConfig.Model != "" && gitLabEngines[engineID].modelFlag == "" {
+			switch engineID {
+			case "copilot":
+				variables[constants.CopilotCLIModelEnvVar] = data.EngineConfig.Model
+			case "claude":
+				variables[constants.ClaudeCLIModelEnvVar] = data.EngineConfig.Model
+			}
+		}
+	}
+	if len(variables) > 0 {
+		names := make([]string, 0, len(variables))
+		for name := range variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		yaml.WriteString("  variables:\n")
+		for _, name := range names {
+			fmt.Fprintf(yaml, "    %s: %s\n", name, gitLabQuote(variables[name]))
+		}
+	}
+
+	yaml.WriteString("  script:\n")
+	for _, line := range script {
+		fmt.Fprintf(yaml, "    - %s\n", gitLabQuote(line))
+	}
+	yaml.WriteString("  artifacts:\n")
+	yaml.WriteString("    when: always\n")
+	yaml.WriteString("    paths:\n")
+	yaml.WriteString("      - .gh-aw/\n")
+	yaml.WriteString("    expire_in: 1 week\n")
+}
+
+// gitLabQuote quotes a string for use as a YAML scalar. JSON strings are valid YAML
+// double-quoted scalars.
+func gitLabQuote(value string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
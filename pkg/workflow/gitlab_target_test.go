@@ -0,0 +1,110 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compileGitLabWorkflow compiles a workflow for the GitLab target and returns the path of the markdown file
+func compileGitLabWorkflow(t *testing.T, name, content string) (string, error) {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "gitlab-target-test")
+	testFile := filepath.Join(tmpDir, name)
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Should write test file")
+
+	compiler := NewCompiler(WithTarget(CompileTargetGitLab), WithWorkflowIdentifier(name))
+	return testFile, compiler.CompileWorkflow(testFile)
+}
+
+func TestCompileTarget(t *testing.T) {
+	assert.True(t, CompileTargetGitHub.IsValid())
+	assert.True(t, CompileTargetGitLab.IsValid())
+	assert.False(t, CompileTarget("jenkins").IsValid())
+	assert.Equal(t, "reports/daily.gitlab-ci.yml", GitLabCIFile("reports/daily.md"))
+
+	assert.Equal(t, "daily.lock.yml", NewCompiler().OutputFile("daily.md"))
+	assert.Equal(t, "daily.gitlab-ci.yml", NewCompiler(WithTarget(CompileTargetGitLab)).OutputFile("daily.md"))
+}
+
+func TestCompileGitLabTarget(t *testing.T) {
+	testFile, err := compileGitLabWorkflow(t, "research.md", `---
+on:
+  schedule: daily
+  workflow_dispatch:
+    inputs:
+      topic:
+        description: Topic to research
+        default: security
+engine: copilot
+permissions:
+  contents: read
+timeout-minutes: 15
+---
+
+# Research
+
+Research the repository.
+`)
+	require.NoError(t, err, "Workflow should compile for the GitLab target")
+
+	_, err = os.Stat(stringutil.MarkdownToLockFile(testFile))
+	assert.True(t, os.IsNotExist(err), "GitHub Actions lock file should not be generated")
+
+	content, err := os.ReadFile(GitLabCIFile(testFile))
+	require.NoError(t, err, "Should read GitLab CI file")
+	assert.Contains(t, string(content), "COPILOT_GITHUB_TOKEN", "Required CI/CD variables should be documented")
+
+	var pipeline map[string]any
+	require.NoError(t, yaml.Unmarshal(content, &pipeline), "GitLab CI file should be valid YAML")
+
+	variables, ok := pipeline["variables"].(map[string]any)
+	require.True(t, ok, "Inputs should become pipeline variables")
+	assert.Contains(t, variables, "topic")
+
+	activation, ok := pipeline["research:activation"].(map[string]any)
+	require.True(t, ok, "Activation job should be generated")
+	assert.Contains(t, activation["script"].([]any)[1], "Research the repository.", "Prompt should be written by the activation job")
+
+	agent, ok := pipeline["research:agent"].(map[string]any)
+	require.True(t, ok, "Agent job should be generated")
+	assert.Equal(t, []any{"research:activation"}, agent["needs"])
+	assert.Equal(t, "15 minutes", agent["timeout"])
+	assert.Len(t, agent["rules"], 3, "Agent should run on schedule, web and api pipelines")
+
+	script := agent["script"].([]any)
+	assert.Contains(t, script[len(script)-1], "copilot ", "Agent job should run the engine CLI")
+	assert.Contains(t, script[len(script)-1], `--prompt "$(cat .gh-aw/prompt.md)"`)
+}
+
+func TestCompileGitLabTargetUnsupportedFeatures(t *testing.T) {
+	_, err := compileGitLabWorkflow(t, "triage.md", `---
+on:
+  issues:
+    types: [opened]
+  workflow_dispatch:
+engine: copilot
+permissions:
+  contents: read
+tools:
+  playwright:
+safe-outputs:
+  create-issue:
+---
+
+# Triage ${{ github.event.issue.number }}
+`)
+	require.Error(t, err, "Unsupported features should be rejected")
+	assert.Contains(t, err.Error(), "trigger 'issues' is unsupported on gitlab")
+	assert.Contains(t, err.Error(), "safe-outputs is unsupported on gitlab")
+	assert.Contains(t, err.Error(), "tools.playwright is unsupported on gitlab")
+	assert.Contains(t, err.Error(), "expression '${{ github.event.issue.number }}' in the prompt is unsupported on gitlab")
+}
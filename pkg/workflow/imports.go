@@ -115,6 +115,8 @@ func (c *Compiler) MergeNetworkPermissions(topNetwork *NetworkPermissions, impor
 		result.Allowed = make([]string, len(topNetwork.Allowed))
 		copy(result.Allowed, topNetwork.Allowed)
 		importsLog.Printf("Starting with %d top-level allowed domains", len(topNetwork.Allowed))
+		result.Log = topNetwork.Log
+		result.Mode = topNetwork.Mode
 	}
 
 	// Track domains to avoid duplicates
@@ -443,6 +445,8 @@ func hasSafeOutputType(config *SafeOutputsConfig, key string) bool {
 		return config.CreatePullRequestReviewComments != nil
 	case "submit-pull-request-review":
 		return config.SubmitPullRequestReview != nil
+	case "create-pull-request-review":
+		return config.CreatePullRequestReviews != nil
 	case "reply-to-pull-request-review-comment":
 		return config.ReplyToPullRequestReviewComment != nil
 	case "resolve-pull-request-review-thread":
@@ -459,6 +463,8 @@ func hasSafeOutputType(config *SafeOutputsConfig, key string) bool {
 		return config.AssignMilestone != nil
 	case "assign-to-agent":
 		return config.AssignToAgent != nil
+	case "assign-to-copilot":
+		return config.AssignToCopilot
 	case "update-issue":
 		return config.UpdateIssues != nil
 	case "update-pull-request":
@@ -473,6 +479,10 @@ func hasSafeOutputType(config *SafeOutputsConfig, key string) bool {
 		return config.CreateAgentSessions != nil
 	case "create-agent-task": // Backward compatibility
 		return config.CreateAgentSessions != nil
+	case "create-gist":
+		return config.CreateGists != nil
+	case "create-release":
+		return config.CreateReleases != nil
 	case "update-project":
 		return config.UpdateProjects != nil
 	case "missing-tool":
@@ -533,6 +543,9 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if result.SubmitPullRequestReview == nil && importedConfig.SubmitPullRequestReview != nil {
 		result.SubmitPullRequestReview = importedConfig.SubmitPullRequestReview
 	}
+	if result.CreatePullRequestReviews == nil && importedConfig.CreatePullRequestReviews != nil {
+		result.CreatePullRequestReviews = importedConfig.CreatePullRequestReviews
+	}
 	if result.ReplyToPullRequestReviewComment == nil && importedConfig.ReplyToPullRequestReviewComment != nil {
 		result.ReplyToPullRequestReviewComment = importedConfig.ReplyToPullRequestReviewComment
 	}
@@ -559,6 +572,7 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	}
 	if result.AssignToAgent == nil && importedConfig.AssignToAgent != nil {
 		result.AssignToAgent = importedConfig.AssignToAgent
+		result.AssignToCopilot = importedConfig.AssignToCopilot
 	}
 	if result.AssignToUser == nil && importedConfig.AssignToUser != nil {
 		result.AssignToUser = importedConfig.AssignToUser
@@ -581,6 +595,12 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if result.CreateAgentSessions == nil && importedConfig.CreateAgentSessions != nil {
 		result.CreateAgentSessions = importedConfig.CreateAgentSessions
 	}
+	if result.CreateGists == nil && importedConfig.CreateGists != nil {
+		result.CreateGists = importedConfig.CreateGists
+	}
+	if result.CreateReleases == nil && importedConfig.CreateReleases != nil {
+		result.CreateReleases = importedConfig.CreateReleases
+	}
 	if result.UpdateProjects == nil && importedConfig.UpdateProjects != nil {
 		result.UpdateProjects = importedConfig.UpdateProjects
 	}
@@ -619,6 +639,15 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if !result.Staged && importedConfig.Staged {
 		result.Staged = importedConfig.Staged
 	}
+	if !result.DryRun && importedConfig.DryRun {
+		result.DryRun = importedConfig.DryRun
+	}
+	if result.MaxTotal == 0 && importedConfig.MaxTotal > 0 {
+		result.MaxTotal = importedConfig.MaxTotal
+	}
+	if result.ThrottleMs == 0 && importedConfig.ThrottleMs > 0 {
+		result.ThrottleMs = importedConfig.ThrottleMs
+	}
 	if len(result.Env) == 0 && len(importedConfig.Env) > 0 {
 		result.Env = importedConfig.Env
 	}
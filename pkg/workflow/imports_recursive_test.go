@@ -60,6 +60,10 @@ permissions:
 engine: copilot
 imports:
   - intermediate-shared.md
+network:
+  allowed:
+    - defaults
+    - example.com
 mcp-servers:
   main-tool:
     url: "https://example.com/main"
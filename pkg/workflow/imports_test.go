@@ -246,3 +246,46 @@ This is a test workflow with imported MCP server.
 		t.Error("Expected tavily to be configured as HTTP MCP server")
 	}
 }
+
+func TestCompileWorkflowWithIncludeCycle(t *testing.T) {
+	tempDir := testutil.TempDir(t, "test-*")
+
+	files := map[string]string{
+		"a.md": "# Shared A\n\n{{#import b.md}}\n",
+		"b.md": "# Shared B\n\n{{#import a.md}}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	workflowPath := filepath.Join(tempDir, "test-workflow.md")
+	workflowContent := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Test Workflow
+
+{{#import a.md}}
+`
+	if err := os.WriteFile(workflowPath, []byte(workflowContent), 0644); err != nil {
+		t.Fatalf("Failed to write workflow file: %v", err)
+	}
+
+	err := workflow.NewCompiler().CompileWorkflow(workflowPath)
+	if err == nil {
+		t.Fatal("Expected include cycle to fail compilation")
+	}
+	if !strings.Contains(err.Error(), "Import cycle detected") {
+		t.Errorf("Expected import cycle error, got: %v", err)
+	}
+	for _, name := range []string{"a.md", "b.md"} {
+		if !strings.Contains(err.Error(), filepath.Join(tempDir, name)) {
+			t.Errorf("Expected cycle error to name %s, got: %v", name, err)
+		}
+	}
+}
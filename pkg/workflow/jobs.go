@@ -26,10 +26,12 @@ type Job struct {
 	Environment                string            // Job environment configuration
 	Container                  string            // Job container configuration
 	Services                   string            // Job services configuration
+	Strategy                   string            // Job strategy (matrix) configuration
 	Env                        map[string]string // Job-level environment variables
 	Steps                      []string
 	Needs                      []string // Job dependencies (needs clause)
 	Outputs                    map[string]string
+	Provenance                 string // Frontmatter feature that produced the job, rendered as a comment in explain mode
 
 	// Reusable workflow call properties
 	Uses    string            // Path to reusable workflow (e.g., ./.github/workflows/reusable.yml)
@@ -41,6 +43,7 @@ type Job struct {
 type JobManager struct {
 	jobs     map[string]*Job
 	jobOrder []string // Job names in sorted alphabetical order
+	explain  bool     // If true, render each job's provenance as a YAML comment
 }
 
 // NewJobManager creates a new JobManager instance
@@ -68,6 +71,11 @@ func (jm *JobManager) AddJob(job *Job) error {
 	return nil
 }
 
+// SetExplain configures whether job provenance is rendered as YAML comments
+func (jm *JobManager) SetExplain(explain bool) {
+	jm.explain = explain
+}
+
 // GetJob retrieves a job by name
 func (jm *JobManager) GetJob(name string) (*Job, bool) {
 	job, exists := jm.jobs[name]
@@ -225,6 +233,11 @@ func (jm *JobManager) RenderToYAML() string {
 func (jm *JobManager) renderJob(job *Job) string {
 	var yaml strings.Builder
 
+	// Explain where the job comes from
+	if jm.explain && job.Provenance != "" {
+		fmt.Fprintf(&yaml, "  # Source: %s\n", job.Provenance)
+	}
+
 	fmt.Fprintf(&yaml, "  %s:\n", job.Name)
 
 	// Add display name if present
@@ -301,6 +314,11 @@ func (jm *JobManager) renderJob(job *Job) string {
 		fmt.Fprintf(&yaml, "    %s\n", job.Services)
 	}
 
+	// Add strategy section
+	if job.Strategy != "" {
+		fmt.Fprintf(&yaml, "    %s\n", job.Strategy)
+	}
+
 	// Add permissions section
 	if job.Permissions != "" {
 		fmt.Fprintf(&yaml, "    %s\n", job.Permissions)
@@ -79,6 +79,7 @@ func getCreatePRReviewCommentScript() string { return "" }
 func getNoOpScript() string                  { return "" }
 func getNotifyCommentErrorScript() string    { return "" }
 func getCreateProjectScript() string         { return "" }
+func getRemoveLabelsScript() string          { return "" }
 func getUploadAssetsScript() string          { return "" }
 
 // Public Get* functions return empty strings since embedded scripts were removed
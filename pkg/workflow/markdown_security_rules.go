@@ -0,0 +1,138 @@
+// This file provides custom, regex-based rules for the markdown security scanner.
+//
+// # Custom Security Rules
+//
+// The built-in checks in markdown_security_scanner.go cover generic attacks. Organizations can
+// add their own policy on top of them, such as forbidden commands or internal hostnames, in
+// .github/aw/security-rules.json:
+//
+//	{
+//	  "rules": [
+//	    {
+//	      "id": "no-internal-hosts",
+//	      "pattern": "\\.corp\\.example\\.com",
+//	      "description": "References an internal hostname"
+//	    }
+//	  ]
+//	}
+//
+// Each line of the workflow file, including the frontmatter, is matched against every rule.
+// A match produces a SecurityFinding in CategoryCustomRule with the rule id set. Custom rules
+// only add findings; the built-in checks always run.
+
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var securityRulesLog = logger.New("workflow:markdown_security_rules")
+
+// SecurityRulesFile is the path, relative to the repository root, of the custom security rules file
+const SecurityRulesFile = ".github/aw/security-rules.json"
+
+// securityRuleIDPattern restricts rule ids to lowercase identifiers such as no-internal-hosts
+var securityRuleIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// SecurityRule is a custom regex-based rule applied by the markdown security scanner
+type SecurityRule struct {
+	ID          string // Rule id reported in findings, e.g. no-internal-hosts
+	Description string // Human-readable explanation of what the rule forbids
+	Pattern     *regexp.Regexp
+}
+
+// securityRulesFileContent is the JSON layout of the custom security rules file
+type securityRulesFileContent struct {
+	Rules []struct {
+		ID          string `json:"id"`
+		Pattern     string `json:"pattern"`
+		Description string `json:"description"`
+	} `json:"rules"`
+}
+
+// LoadSecurityRules reads and compiles the custom security rules in path.
+// A missing file is not an error and yields no rules.
+func LoadSecurityRules(path string) ([]SecurityRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			securityRulesLog.Printf("No custom security rules file at %s", path)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read security rules file %s: %w", path, err)
+	}
+
+	rules, err := ParseSecurityRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid security rules file %s: %w", path, err)
+	}
+	securityRulesLog.Printf("Loaded %d custom security rule(s) from %s", len(rules), path)
+	return rules, nil
+}
+
+// ParseSecurityRules parses and compiles custom security rules from JSON
+func ParseSecurityRules(data []byte) ([]SecurityRule, error) {
+	var content securityRulesFileContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	rules := make([]SecurityRule, 0, len(content.Rules))
+	seen := make(map[string]bool)
+	for i, raw := range content.Rules {
+		if !securityRuleIDPattern.MatchString(raw.ID) {
+			return nil, fmt.Errorf("rule %d: id '%s' must be lowercase letters, digits, '.', '_' or '-'", i+1, raw.ID)
+		}
+		if seen[raw.ID] {
+			return nil, fmt.Errorf("rule %d: duplicate id '%s'", i+1, raw.ID)
+		}
+		seen[raw.ID] = true
+
+		if raw.Pattern == "" {
+			return nil, fmt.Errorf("rule '%s': pattern is required", raw.ID)
+		}
+		pattern, err := regexp.Compile(raw.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule '%s': invalid pattern: %w", raw.ID, err)
+		}
+
+		description := raw.Description
+		if description == "" {
+			description = "matches forbidden pattern " + raw.Pattern
+		}
+		rules = append(rules, SecurityRule{ID: raw.ID, Description: description, Pattern: pattern})
+	}
+	return rules, nil
+}
+
+// scanCustomRules matches every line of content against the custom rules. Unlike the built-in
+// checks it runs on the whole file, since policy rules often target frontmatter such as
+// network or steps configuration.
+func scanCustomRules(content string, rules []SecurityRule) []SecurityFinding {
+	var findings []SecurityFinding
+	lines := strings.Split(content, "\n")
+	for _, rule := range rules {
+		for lineNum, line := range lines {
+			loc := rule.Pattern.FindStringIndex(line)
+			if loc == nil {
+				continue
+			}
+			findings = append(findings, SecurityFinding{
+				Category:    CategoryCustomRule,
+				RuleID:      rule.ID,
+				Description: rule.Description,
+				Line:        lineNum + 1,
+				Column:      loc[0] + 1,
+				Snippet:     truncateSnippet(line[loc[0]:loc[1]], 80),
+			})
+		}
+	}
+	return findings
+}
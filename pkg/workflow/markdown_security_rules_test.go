@@ -0,0 +1,112 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecurityRules(t *testing.T) {
+	rules, err := ParseSecurityRules([]byte(`{"rules": [
+		{"id": "no-internal-hosts", "pattern": "\\.corp\\.example\\.com", "description": "References an internal hostname"},
+		{"id": "no-rm-rf", "pattern": "rm\\s+-rf\\s+/"}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "no-internal-hosts", rules[0].ID)
+	assert.Equal(t, "References an internal hostname", rules[0].Description)
+	assert.Equal(t, `matches forbidden pattern rm\s+-rf\s+/`, rules[1].Description, "a missing description should fall back to the pattern")
+
+	tests := []struct {
+		name    string
+		json    string
+		errText string
+	}{
+		{name: "invalid JSON", json: `{"rules": [`, errText: "failed to parse JSON"},
+		{name: "missing id", json: `{"rules": [{"pattern": "x"}]}`, errText: "rule 1: id ''"},
+		{name: "uppercase id", json: `{"rules": [{"id": "NoHosts", "pattern": "x"}]}`, errText: "must be lowercase"},
+		{name: "duplicate id", json: `{"rules": [{"id": "a", "pattern": "x"}, {"id": "a", "pattern": "y"}]}`, errText: "duplicate id 'a'"},
+		{name: "missing pattern", json: `{"rules": [{"id": "a"}]}`, errText: "pattern is required"},
+		{name: "invalid pattern", json: `{"rules": [{"id": "a", "pattern": "(unclosed"}]}`, errText: "rule 'a': invalid pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSecurityRules([]byte(tt.json))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errText)
+		})
+	}
+}
+
+func TestLoadSecurityRules(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "security-rules-test")
+
+	rules, err := LoadSecurityRules(filepath.Join(tmpDir, "missing.json"))
+	require.NoError(t, err, "a missing rules file should not be an error")
+	assert.Empty(t, rules)
+
+	rulesPath := filepath.Join(tmpDir, "security-rules.json")
+	require.NoError(t, os.WriteFile(rulesPath, []byte(`{"rules": [{"id": "no-curl-pipe", "pattern": "curl[^|]*\\|\\s*sh"}]}`), 0644))
+	rules, err = LoadSecurityRules(rulesPath)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "no-curl-pipe", rules[0].ID)
+
+	require.NoError(t, os.WriteFile(rulesPath, []byte(`{"rules": [{"id": "bad", "pattern": "["}]}`), 0644))
+	_, err = LoadSecurityRules(rulesPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), rulesPath, "the error should name the rules file")
+}
+
+func TestScanMarkdownSecurityWithRules_CustomRuleFires(t *testing.T) {
+	rules, err := ParseSecurityRules([]byte(`{"rules": [
+		{"id": "no-internal-hosts", "pattern": "[a-z]+\\.corp\\.example\\.com", "description": "References an internal hostname"}
+	]}`))
+	require.NoError(t, err)
+
+	content := "---\non: issues\nnetwork:\n  allowed:\n    - build.corp.example.com\n---\n\n# Task\n\nFetch the report from wiki.corp.example.com.\n"
+	findings := ScanMarkdownSecurityWithRules(content, rules)
+	require.Len(t, findings, 2, "custom rules should match in both the frontmatter and the body")
+
+	assert.Equal(t, CategoryCustomRule, findings[0].Category)
+	assert.Equal(t, "no-internal-hosts", findings[0].RuleID)
+	assert.Equal(t, 5, findings[0].Line)
+	assert.Equal(t, "build.corp.example.com", findings[0].Snippet)
+	assert.Equal(t, 10, findings[1].Line)
+	assert.Equal(t, "[custom-rule:no-internal-hosts] line 10: References an internal hostname", findings[1].String())
+
+	assert.Empty(t, ScanMarkdownSecurity(content), "custom rules should only apply when passed in")
+}
+
+func TestScanMarkdownSecurityWithRules_BuiltinsStillFire(t *testing.T) {
+	rules, err := ParseSecurityRules([]byte(`{"rules": [{"id": "no-rm-rf", "pattern": "rm\\s+-rf\\s+/"}]}`))
+	require.NoError(t, err)
+
+	content := "# Task\n\nRun `rm -rf /` to clean up.\n\n<script>alert('x')</script>\n"
+	findings := ScanMarkdownSecurityWithRules(content, rules)
+
+	var builtin, custom []SecurityFinding
+	for _, f := range findings {
+		if f.RuleID != "" {
+			custom = append(custom, f)
+		} else {
+			builtin = append(builtin, f)
+		}
+	}
+	require.Len(t, custom, 1)
+	assert.Equal(t, "no-rm-rf", custom[0].RuleID)
+	assert.Equal(t, 3, custom[0].Line)
+	require.NotEmpty(t, builtin, "built-in checks should run alongside custom rules")
+	assert.Equal(t, CategoryHTMLAbuse, builtin[0].Category)
+	assert.Len(t, builtin, len(ScanMarkdownSecurity(content)), "custom rules should not change the built-in findings")
+
+	formatted := FormatSecurityFindings(findings, "workflow.md")
+	assert.Contains(t, formatted, "[custom-rule:no-rm-rf]")
+	assert.Contains(t, formatted, "[html-abuse]")
+}
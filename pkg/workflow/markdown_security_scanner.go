@@ -13,12 +13,15 @@
 //   - Embedded files: SVG with scripts, data-URI payloads in images
 //   - Social engineering: misleading formatting patterns
 //
+// Organizations can add their own regex rules on top of these; see markdown_security_rules.go.
+//
 // # Usage
 //
 // Call ScanMarkdownSecurity(content) before writing any externally-sourced
 // workflow file to disk. Returns a list of SecurityFinding values, each
 // describing a specific issue found. If the list is non-empty, the workflow
-// should be rejected.
+// should be rejected. Use ScanMarkdownSecurityWithRules to also apply custom rules
+// loaded with LoadSecurityRules.
 
 package workflow
 
@@ -29,6 +32,7 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -50,22 +54,35 @@ const (
 	CategoryEmbeddedFiles SecurityFindingCategory = "embedded-files"
 	// CategorySocialEngineering covers misleading formatting and disguised commands
 	CategorySocialEngineering SecurityFindingCategory = "social-engineering"
+	// CategoryCustomRule covers matches of custom rules loaded from the security rules file
+	CategoryCustomRule SecurityFindingCategory = "custom-rule"
 )
 
 // SecurityFinding represents a single security issue found in markdown content
 type SecurityFinding struct {
 	Category    SecurityFindingCategory
+	RuleID      string // Id of the custom rule that produced the finding, empty for built-in checks
 	Description string
-	Line        int    // 1-based line number where the issue was found, 0 if unknown
-	Snippet     string // Short excerpt of the problematic content
+	Line        int      // 1-based line number where the issue was found, 0 if unknown
+	Column      int      // 1-based column where the snippet starts on Line, 0 if unknown
+	Snippet     string   // Short excerpt of the problematic content
+	Context     []string // Source lines centered on Line, used to render the finding like a compiler diagnostic
 }
 
 // String returns a human-readable description of the finding
 func (f SecurityFinding) String() string {
 	if f.Line > 0 {
-		return fmt.Sprintf("[%s] line %d: %s", f.Category, f.Line, f.Description)
+		return fmt.Sprintf("[%s] line %d: %s", f.label(), f.Line, f.Description)
+	}
+	return fmt.Sprintf("[%s] %s", f.label(), f.Description)
+}
+
+// label returns the category of the finding, qualified with the rule id for custom rules
+func (f SecurityFinding) label() string {
+	if f.RuleID != "" {
+		return fmt.Sprintf("%s:%s", f.Category, f.RuleID)
 	}
-	return fmt.Sprintf("[%s] %s", f.Category, f.Description)
+	return string(f.Category)
 }
 
 // countCategories counts unique security finding categories
@@ -83,6 +100,12 @@ func countCategories(findings []SecurityFinding) int {
 // match the original file. Returns a list of findings. If non-empty, the content
 // should be rejected.
 func ScanMarkdownSecurity(content string) []SecurityFinding {
+	return ScanMarkdownSecurityWithRules(content, nil)
+}
+
+// ScanMarkdownSecurityWithRules runs the built-in checks of ScanMarkdownSecurity and
+// additionally matches the whole content against the given custom rules
+func ScanMarkdownSecurityWithRules(content string, rules []SecurityRule) []SecurityFinding {
 	markdownSecurityLog.Printf("Scanning markdown content (%d bytes) for security issues", len(content))
 
 	// Strip frontmatter and get the line offset for correct line number reporting
@@ -113,6 +136,14 @@ func ScanMarkdownSecurity(content string) []SecurityFinding {
 		}
 	}
 
+	// Custom rules scan the original content, so their line numbers need no adjustment
+	if len(rules) > 0 {
+		markdownSecurityLog.Printf("Running %d custom rule(s)", len(rules))
+		findings = append(findings, scanCustomRules(content, rules)...)
+	}
+
+	addFindingContext(content, findings)
+
 	if len(findings) > 0 {
 		markdownSecurityLog.Printf("Security scan complete: found %d issue(s) across %d categor(ies)", len(findings), countCategories(findings))
 	} else {
@@ -144,6 +175,61 @@ func stripFrontmatter(content string) (string, int) {
 	return "", 0
 }
 
+// findingContextRadius is the number of source lines shown above and below a finding
+const findingContextRadius = 2
+
+// addFindingContext fills in the column and the surrounding source lines of each finding
+// from the original (unstripped) content, after line numbers have been adjusted
+func addFindingContext(content string, findings []SecurityFinding) {
+	if len(findings) == 0 {
+		return
+	}
+	lines := strings.Split(content, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+
+	for i := range findings {
+		f := &findings[i]
+		if f.Line <= 0 || f.Line > len(lines) {
+			continue
+		}
+		if f.Column == 0 {
+			f.Column = snippetColumn(lines[f.Line-1], f.Snippet)
+		}
+		f.Context = findingContextLines(lines, f.Line)
+	}
+}
+
+// snippetColumn returns the 1-based column of snippet in line, or 0 if it cannot be found
+func snippetColumn(line, snippet string) int {
+	snippet = strings.TrimSuffix(snippet, "...")
+	if snippet == "" {
+		return 0
+	}
+	idx := strings.Index(line, snippet)
+	if idx == -1 {
+		return 0
+	}
+	return idx + 1
+}
+
+// findingContextLines returns the lines around lineNum (1-based), centered on it as
+// console.FormatError expects. Lines before the start of the file are returned as empty
+// placeholders, which the renderer skips.
+func findingContextLines(lines []string, lineNum int) []string {
+	radius := min(findingContextRadius, len(lines)-lineNum)
+	context := make([]string, 0, 2*radius+1)
+	for n := lineNum - radius; n <= lineNum+radius; n++ {
+		if n < 1 {
+			context = append(context, "")
+			continue
+		}
+		context = append(context, lines[n-1])
+	}
+	return context
+}
+
 // FormatSecurityFindings formats a list of findings into a human-readable error message
 // filePath: the workflow file path to include in error messages
 func FormatSecurityFindings(findings []SecurityFinding, filePath string) string {
@@ -154,25 +240,27 @@ func FormatSecurityFindings(findings []SecurityFinding, filePath string) string
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "Security scan found %d issue(s) in workflow markdown:\n\n", len(findings))
 
-	// Format each finding using formatCompilerErrorWithPosition for consistency
+	// Format each finding like a compiler diagnostic, with the surrounding source lines
 	for _, f := range findings {
 		line := f.Line
 		if line <= 0 {
 			line = 1 // Default to line 1 if unknown
 		}
+		column := f.Column
+		if column <= 0 {
+			column = 1
+		}
 
-		// Create a formatted error for this finding
-		findingErr := formatCompilerErrorWithPosition(
-			filePath,
-			line,
-			1, // Column 1 (we don't have column info)
-			"error",
-			fmt.Sprintf("[%s] %s", f.Category, f.Description),
-			nil,
-		)
-
-		// Append the formatted error to our output
-		sb.WriteString(findingErr.Error())
+		sb.WriteString(console.FormatError(console.CompilerError{
+			Position: console.ErrorPosition{
+				File:   filePath,
+				Line:   line,
+				Column: column,
+			},
+			Type:    "error",
+			Message: fmt.Sprintf("[%s] %s", f.label(), f.Description),
+			Context: f.Context,
+		}))
 		sb.WriteString("\n")
 	}
 
@@ -545,22 +633,24 @@ func scanHTMLAbuse(content string) []SecurityFinding {
 		}
 
 		for _, check := range htmlChecks {
-			if check.pattern.MatchString(line) {
+			if loc := check.pattern.FindStringIndex(line); loc != nil {
 				findings = append(findings, SecurityFinding{
 					Category:    CategoryHTMLAbuse,
 					Description: check.desc,
 					Line:        lineNo,
+					Column:      loc[0] + 1,
 					Snippet:     truncateSnippet(line, 80),
 				})
 			}
 		}
 
 		// Check for <style> with hiding properties
-		if styleTagPattern.MatchString(line) {
+		if loc := styleTagPattern.FindStringIndex(line); loc != nil {
 			findings = append(findings, SecurityFinding{
 				Category:    CategoryHTMLAbuse,
 				Description: "<style> tag can be used to hide content or mislead users",
 				Line:        lineNo,
+				Column:      loc[0] + 1,
 				Snippet:     truncateSnippet(line, 80),
 			})
 		}
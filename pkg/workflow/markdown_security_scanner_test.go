@@ -756,6 +756,56 @@ func TestFormatSecurityFindings_Multiple(t *testing.T) {
 	assert.Contains(t, result, "cannot be added", "should mention rejection")
 }
 
+func TestScanMarkdownSecurity_FindingLocationAndContext(t *testing.T) {
+	content := strings.Join([]string{
+		"---",                                // 1
+		"on: issues",                         // 2
+		"---",                                // 3
+		"",                                   // 4
+		"# Triage",                           // 5
+		"",                                   // 6
+		"Read the issue and label it.",       // 7
+		"Then run <script>alert(1)</script>", // 8
+		"Finally, post a summary.",           // 9
+		"",                                   // 10
+		"Done.",                              // 11
+	}, "\n")
+
+	findings := ScanMarkdownSecurity(content)
+	require.NotEmpty(t, findings, "should detect the script tag")
+	f := findings[0]
+	assert.Equal(t, CategoryHTMLAbuse, f.Category)
+	assert.Equal(t, 8, f.Line, "should report the line of the script tag in the original file")
+	assert.Equal(t, 10, f.Column, "should report the column where the snippet starts")
+	assert.Equal(t, []string{
+		"",
+		"Read the issue and label it.",
+		"Then run <script>alert(1)</script>",
+		"Finally, post a summary.",
+		"",
+	}, f.Context, "context should be centered on the finding line")
+
+	formatted := FormatSecurityFindings(findings, "workflow.md")
+	assert.Contains(t, formatted, "workflow.md:8:10:", "should render an IDE-parseable location")
+	assert.Contains(t, formatted, "8 | Then run <script>alert(1)</script>", "should render the offending source line")
+	assert.Contains(t, formatted, "7 | Read the issue and label it.", "should render the line above")
+	assert.Contains(t, formatted, "9 | Finally, post a summary.", "should render the line below")
+}
+
+func TestScanMarkdownSecurity_FindingContextAtFileEdges(t *testing.T) {
+	findings := ScanMarkdownSecurity("<script>x</script>\nsecond line")
+	require.NotEmpty(t, findings)
+	assert.Equal(t, 1, findings[0].Line)
+	assert.Equal(t, []string{"", "<script>x</script>", "second line"}, findings[0].Context,
+		"lines before the start of the file should be empty placeholders")
+
+	findings = ScanMarkdownSecurity("first line\nsecond line\n<script>x</script>")
+	require.NotEmpty(t, findings)
+	assert.Equal(t, 3, findings[0].Line)
+	assert.Equal(t, []string{"<script>x</script>"}, findings[0].Context,
+		"context should stay centered when the finding is on the last line")
+}
+
 func TestSecurityFinding_String(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -411,9 +411,19 @@ func TestIsMCPType(t *testing.T) {
 			expected: false,
 		},
 		{
-			name:     "websocket type (not valid)",
+			name:     "websocket type",
 			typeStr:  "websocket",
-			expected: false,
+			expected: true,
+		},
+		{
+			name:     "ws type (alias for websocket)",
+			typeStr:  "ws",
+			expected: true,
+		},
+		{
+			name:     "wss type (alias for websocket)",
+			typeStr:  "wss",
+			expected: true,
 		},
 		{
 			name:     "grpc type (not valid)",
@@ -1059,7 +1069,7 @@ func TestHasMCPConfigComprehensive(t *testing.T) {
 		{
 			name: "not MCP - unknown type",
 			toolConfig: map[string]any{
-				"type": "websocket",
+				"type": "grpc",
 			},
 			wantHasMCP: false,
 			wantType:   "",
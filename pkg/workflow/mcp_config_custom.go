@@ -565,22 +565,27 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 
 	// Validate known properties - fail if unknown properties are found
 	knownProperties := map[string]bool{
-		"type":           true,
-		"mode":           true, // Added for MCPServerConfig struct
-		"command":        true,
-		"container":      true,
-		"version":        true,
-		"args":           true,
-		"entrypoint":     true,
-		"entrypointArgs": true,
-		"mounts":         true,
-		"env":            true,
-		"proxy-args":     true,
-		"url":            true,
-		"headers":        true,
-		"registry":       true,
-		"allowed":        true,
-		"toolsets":       true, // Added for MCPServerConfig struct
+		"type":            true,
+		"mode":            true, // Added for MCPServerConfig struct
+		"command":         true,
+		"container":       true,
+		"version":         true,
+		"args":            true,
+		"entrypoint":      true,
+		"entrypointArgs":  true,
+		"mounts":          true,
+		"volumes":         true,
+		"env":             true,
+		"proxy-args":      true,
+		"network":         true,
+		"url":             true,
+		"headers":         true,
+		"registry":        true,
+		"allowed":         true,
+		"toolsets":        true, // Added for MCPServerConfig struct
+		"startup-timeout": true,
+		"health-check":    true,
+		"auth":            true,
 	}
 
 	for key := range toolConfig {
@@ -606,12 +611,8 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 	// Infer type from fields if not explicitly provided
 	if typeStr, hasType := config.GetString("type"); hasType {
 		mcpCustomLog.Printf("MCP type explicitly set to: %s", typeStr)
-		// Normalize "local" to "stdio"
-		if typeStr == "local" {
-			result.Type = "stdio"
-		} else {
-			result.Type = typeStr
-		}
+		// Normalize aliases ("local" -> "stdio", "ws"/"wss" -> "websocket")
+		result.Type = parser.NormalizeMCPType(typeStr)
 	} else {
 		mcpCustomLog.Print("No explicit MCP type, inferring from fields")
 		// Infer type from presence of fields
@@ -643,6 +644,18 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 	if registry, hasRegistry := config.GetString("registry"); hasRegistry {
 		result.Registry = registry
 	}
+	if startupTimeout, hasStartupTimeout := config.GetAny("startup-timeout"); hasStartupTimeout {
+		seconds, err := parser.ParseMCPStartupTimeout(startupTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid startup-timeout for MCP tool '%s': %w", toolName, err)
+		}
+		result.StartupTimeout = seconds
+	}
+	if healthCheck, hasHealthCheck := config.GetAny("health-check"); hasHealthCheck {
+		if healthCheckBool, ok := healthCheck.(bool); ok {
+			result.HealthCheck = healthCheckBool
+		}
+	}
 
 	// Extract fields based on type
 	mcpCustomLog.Printf("Extracting fields for MCP type: %s", result.Type)
@@ -669,11 +682,23 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 		if mounts, hasMounts := config.GetStringArray("mounts"); hasMounts {
 			result.Mounts = mounts
 		}
+		if volumes, hasVolumes := config.GetStringArray("volumes"); hasVolumes {
+			result.Volumes = volumes
+			// The MCP Gateway requires an explicit mode, so volumes without one are mounted read-write (the docker default)
+			for _, volume := range volumes {
+				if strings.Count(volume, ":") == 1 {
+					volume += ":rw"
+				}
+				result.Mounts = append(result.Mounts, volume)
+			}
+		}
 		if env, hasEnv := config.GetStringMap("env"); hasEnv {
 			result.Env = env
 		}
 		if proxyArgs, hasProxyArgs := config.GetStringArray("proxy-args"); hasProxyArgs {
 			result.ProxyArgs = proxyArgs
+		} else if _, networkProxyArgs := getMCPServerNetwork(toolConfig); len(networkProxyArgs) > 0 {
+			result.ProxyArgs = networkProxyArgs
 		}
 	case "http":
 		if url, hasURL := config.GetString("url"); hasURL {
@@ -695,6 +720,16 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 		if headers, hasHeaders := config.GetStringMap("headers"); hasHeaders {
 			result.Headers = headers
 		}
+		if auth, hasAuth := config.GetAny("auth"); hasAuth {
+			authConfig, err := parser.ParseMCPAuthConfig(toolName, auth, result.Headers)
+			if err != nil {
+				return nil, err
+			}
+			result.Auth = authConfig
+			result.Headers = maps.Clone(result.Headers)
+			// The token fetched by the OAuth step before the gateway starts is sent as the bearer token
+			result.Headers["Authorization"] = "Bearer ${" + mcpOAuthTokenEnvVar(toolName) + "}"
+		}
 	default:
 		mcpCustomLog.Printf("Unsupported MCP type '%s' for tool '%s'", result.Type, toolName)
 		return nil, fmt.Errorf(
@@ -746,11 +781,8 @@ func hasMCPConfig(toolConfig map[string]any) (bool, string) {
 	// Check for direct type field
 	if mcpType, hasType := toolConfig["type"]; hasType {
 		if typeStr, ok := mcpType.(string); ok && parser.IsMCPType(typeStr) {
-			// Normalize "local" to "stdio" for consistency
-			if typeStr == "local" {
-				return true, "stdio"
-			}
-			return true, typeStr
+			// Normalize aliases ("local" -> "stdio", "ws"/"wss" -> "websocket") for consistency
+			return true, parser.NormalizeMCPType(typeStr)
 		}
 	}
 
@@ -132,7 +132,7 @@ func renderPlaywrightMCPConfigWithOptions(yaml *strings.Builder, playwrightConfi
 	// creates a network namespace for renderer processes that cannot reach localhost.
 	// This is required for screenshot workflows that serve docs on localhost.
 	// Note: as of @playwright/mcp v0.0.26+, --no-sandbox is a direct top-level flag.
-	entrypointArgs := []string{"--output-dir", "/tmp/gh-aw/mcp-logs/playwright", "--no-sandbox"}
+	entrypointArgs := []string{"--output-dir", getPlaywrightLogDir(playwrightConfig), "--no-sandbox"}
 	// Append custom args if present
 	if len(customArgs) > 0 {
 		entrypointArgs = append(entrypointArgs, customArgs...)
@@ -160,8 +160,8 @@ func renderPlaywrightMCPConfigWithOptions(yaml *strings.Builder, playwrightConfi
 		yaml.WriteString("                ],\n")
 	}
 
-	// Add volume mounts
-	yaml.WriteString("                \"mounts\": [\"/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs:rw\"]\n")
+	// Add volume mounts (only the pinned log directory when log-dir is set)
+	yaml.WriteString("                \"mounts\": [\"" + getPlaywrightLogsMount(playwrightConfig) + "\"]\n")
 
 	// Note: tools field is NOT included here - the converter script adds it back
 	// for Copilot. This keeps the gateway config compatible with the schema.
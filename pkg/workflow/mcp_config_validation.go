@@ -52,6 +52,7 @@ import (
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/sliceutil"
 )
 
 var mcpValidationLog = logger.New("workflow:mcp_config_validation")
@@ -124,28 +125,33 @@ func getRawMCPConfig(toolConfig map[string]any) (map[string]any, error) {
 
 	// List of all known tool config fields (not just MCP)
 	knownToolFields := map[string]bool{
-		"type":           true,
-		"url":            true,
-		"command":        true,
-		"container":      true,
-		"env":            true,
-		"headers":        true,
-		"version":        true,
-		"args":           true,
-		"entrypoint":     true,
-		"entrypointArgs": true,
-		"mounts":         true,
-		"proxy-args":     true,
-		"registry":       true,
-		"allowed":        true,
-		"mode":           true, // for github tool
-		"github-token":   true, // for github tool
-		"read-only":      true, // for github tool
-		"toolsets":       true, // for github tool
-		"id":             true, // for cache-memory (array notation)
-		"key":            true, // for cache-memory
-		"description":    true, // for cache-memory
-		"retention-days": true, // for cache-memory
+		"type":            true,
+		"url":             true,
+		"command":         true,
+		"container":       true,
+		"env":             true,
+		"headers":         true,
+		"version":         true,
+		"args":            true,
+		"entrypoint":      true,
+		"entrypointArgs":  true,
+		"mounts":          true,
+		"volumes":         true,
+		"proxy-args":      true,
+		"network":         true, // for custom stdio MCP servers
+		"registry":        true,
+		"allowed":         true,
+		"mode":            true, // for github tool
+		"github-token":    true, // for github tool
+		"read-only":       true, // for github tool
+		"toolsets":        true, // for github tool
+		"id":              true, // for cache-memory (array notation)
+		"key":             true, // for cache-memory
+		"description":     true, // for cache-memory
+		"retention-days":  true, // for cache-memory
+		"startup-timeout": true,
+		"health-check":    true,
+		"auth":            true, // for custom HTTP MCP servers
 	}
 
 	// Check new format: direct fields in tool config
@@ -155,7 +161,7 @@ func getRawMCPConfig(toolConfig map[string]any) (map[string]any, error) {
 		}
 	}
 
-	// Check for unknown fields that might be typos or deprecated (like "network")
+	// Check for unknown fields that might be typos or deprecated
 	for field := range toolConfig {
 		if !knownToolFields[field] {
 			// Build list of valid fields for the error message
@@ -210,6 +216,17 @@ func validateStringProperty(toolName, propertyName string, value any, exists boo
 
 // validateMCPRequirements validates the specific requirements for MCP configuration
 func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConfig map[string]any) error {
+	// Custom servers only accept exact tool names or "*": patterns cannot be enforced by the engines
+	if allowed, ok := toolConfig["allowed"].([]any); ok {
+		for _, item := range allowed {
+			if name, ok := item.(string); ok {
+				if err := parser.ValidateCustomServerToolName(name); err != nil {
+					return fmt.Errorf("tool '%s' has an invalid allowed entry: %w.\n\nExample:\ntools:\n  %s:\n    allowed: [\"search_pages\", \"get_page\"]\n\nSee: %s", toolName, err, toolName, constants.DocsToolsURL)
+				}
+			}
+		}
+	}
+
 	// Validate 'type' property - allow inference from other fields
 	mcpType, hasType := mcpConfig["type"]
 	var typeStr string
@@ -233,16 +250,26 @@ func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConf
 		}
 	}
 
-	// Normalize "local" to "stdio" for validation
-	if typeStr == "local" {
-		typeStr = "stdio"
-	}
-
 	// Validate type is one of the supported types
 	if !parser.IsMCPType(typeStr) {
 		return fmt.Errorf("tool '%s' mcp configuration 'type' must be one of: stdio, http (per MCP Gateway Specification). Note: 'local' is accepted for backward compatibility and treated as 'stdio'. Got: %s.\n\nExample:\ntools:\n  %s:\n    type: \"stdio\"\n    command: \"node server.js\"\n\nSee: %s", toolName, typeStr, toolName, constants.DocsToolsURL)
 	}
 
+	// Normalize aliases ("local" -> "stdio", "ws"/"wss" -> "websocket") for validation
+	typeStr = parser.NormalizeMCPType(typeStr)
+
+	// Validate startup health check settings (available for all types)
+	if startupTimeout, hasStartupTimeout := toolConfig["startup-timeout"]; hasStartupTimeout {
+		if _, err := parser.ParseMCPStartupTimeout(startupTimeout); err != nil {
+			return fmt.Errorf("tool '%s' mcp configuration has invalid 'startup-timeout': %w.\n\nExample:\ntools:\n  %s:\n    command: \"node server.js\"\n    startup-timeout: 90\n    health-check: true\n\nSee: %s", toolName, err, toolName, constants.DocsToolsURL)
+		}
+	}
+	if healthCheck, hasHealthCheck := toolConfig["health-check"]; hasHealthCheck {
+		if _, ok := healthCheck.(bool); !ok {
+			return fmt.Errorf("tool '%s' mcp configuration 'health-check' must be a boolean, got %T.\n\nExample:\ntools:\n  %s:\n    command: \"node server.js\"\n    health-check: true\n\nSee: %s", toolName, healthCheck, toolName, constants.DocsToolsURL)
+		}
+	}
+
 	// Validate type-specific requirements
 	switch typeStr {
 	case "http":
@@ -259,7 +286,29 @@ func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConf
 			return fmt.Errorf("tool '%s' mcp configuration with type 'http' cannot use 'mounts' field. Volume mounts are only supported for stdio (containerized) MCP servers.\n\nExample:\ntools:\n  %s:\n    type: http\n    url: \"https://api.example.com/mcp\"\n\nSee: %s", toolName, toolName, constants.DocsToolsURL)
 		}
 
-		return validateStringProperty(toolName, "url", url, hasURL)
+		// HTTP type cannot use volumes field
+		if _, hasVolumes := toolConfig["volumes"]; hasVolumes {
+			return fmt.Errorf("tool '%s' mcp configuration with type 'http' cannot use 'volumes' field. Volumes are only supported for stdio (containerized) MCP servers.\n\nExample:\ntools:\n  %s:\n    type: http\n    url: \"https://api.example.com/mcp\"\n\nSee: %s", toolName, toolName, constants.DocsToolsURL)
+		}
+
+		if err := validateStringProperty(toolName, "url", url, hasURL); err != nil {
+			return err
+		}
+
+		// Validate the OAuth client credentials used to obtain a bearer token
+		if auth, hasAuth := toolConfig["auth"]; hasAuth {
+			headers, _ := MapToolConfig(toolConfig).GetStringMap("headers")
+			if _, err := parser.ParseMCPAuthConfig(toolName, auth, headers); err != nil {
+				return fmt.Errorf("tool '%s' mcp configuration has invalid 'auth': %w.\n\nExample:\ntools:\n  %s:\n    type: http\n    url: \"https://api.example.com/mcp\"\n    auth:\n      token-url: \"https://auth.example.com/oauth/token\"\n      client-id: \"${{ secrets.MCP_CLIENT_ID }}\"\n      client-secret: \"${{ secrets.MCP_CLIENT_SECRET }}\"\n\nSee: %s", toolName, err, toolName, constants.DocsToolsURL)
+			}
+		}
+
+		return nil
+
+	case "websocket":
+		// The MCP gateway only connects to stdio and http servers, so a WebSocket server
+		// would be rejected when the gateway starts. Fail at compile time instead.
+		return fmt.Errorf("tool '%s' mcp configuration with type 'websocket' is not supported: the MCP gateway only accepts stdio and http servers. Expose the server over streamable HTTP, or run an HTTP bridge in front of it, and use type: http.\n\nExample:\ntools:\n  %s:\n    type: http\n    url: \"https://api.example.com/mcp\"\n\nSee: %s", toolName, toolName, constants.DocsToolsURL)
 
 	case "stdio":
 		// stdio type requires either 'command' or 'container' property (but not both)
@@ -288,6 +337,47 @@ func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConf
 				return err
 			}
 		}
+
+		// Validate docker volume syntax (host:container[:ro|rw]) at compile time
+		if volumesRaw, hasVolumes := toolConfig["volumes"]; hasVolumes {
+			if err := validateMCPVolumesSyntax(toolName, volumesRaw); err != nil {
+				return err
+			}
+		}
+
+		// Validate ${VAR:-default} interpolations in env values at compile time
+		if envMap, hasEnv := toolConfig["env"].(map[string]any); hasEnv {
+			envKeys := sliceutil.MapToSlice(envMap)
+			sort.Strings(envKeys)
+			for _, key := range envKeys {
+				if value, ok := envMap[key].(string); ok {
+					if err := parser.ValidateEnvInterpolation(value); err != nil {
+						return fmt.Errorf("tool '%s' mcp configuration env '%s' is invalid: %w.\n\nExample:\ntools:\n  %s:\n    container: \"my-registry/my-tool\"\n    env:\n      API_URL: \"${API_URL:-https://api.example.com}\"\n\nSee: %s", toolName, key, err, toolName, constants.DocsToolsURL)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateMCPVolumesSyntax validates that docker volume strings in a custom MCP server config
+// follow the "host:container[:ro|rw]" syntax.
+func validateMCPVolumesSyntax(toolName string, volumesRaw any) error {
+	volumes, ok := volumesRaw.([]any)
+	if !ok {
+		return fmt.Errorf("tool '%s' mcp configuration 'volumes' must be an array of strings.\n\nExample:\ntools:\n  %s:\n    container: \"my-registry/my-tool\"\n    volumes:\n      - \"/host/path:/container/path:ro\"\n\nSee: %s", toolName, toolName, constants.DocsToolsURL)
+	}
+
+	for i, item := range volumes {
+		volume, ok := item.(string)
+		if !ok {
+			return fmt.Errorf("tool '%s' mcp configuration volumes[%d] must be a string, got %T.\n\nSee: %s", toolName, i, item, constants.DocsToolsURL)
+		}
+		if err := parser.ValidateVolumeSyntax(volume); err != nil {
+			return fmt.Errorf("tool '%s' mcp configuration volumes[%d] is invalid: %w.\n\nExample:\ntools:\n  %s:\n    container: \"my-registry/my-tool\"\n    volumes:\n      - \"/host/path:/container/path\"     # read-write\n      - \"/host/path:/container/path:ro\"  # read-only\n\nSee: %s", toolName, i, err, toolName, constants.DocsToolsURL)
+		}
 	}
 
 	return nil
@@ -124,3 +124,101 @@ func TestValidateMCPMountsSyntax(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateMCPVolumesSyntax tests the docker volume syntax validation for container MCP servers.
+func TestValidateMCPVolumesSyntax(t *testing.T) {
+	tests := []struct {
+		name       string
+		volumesRaw any
+		wantErr    bool
+		errMsg     string
+	}{
+		{
+			name:       "valid volumes with and without mode",
+			volumesRaw: []any{"/host/data:/data:ro", "/host/cache:/cache"},
+			wantErr:    false,
+		},
+		{
+			name:       "invalid type — not an array",
+			volumesRaw: "/host/data:/data",
+			wantErr:    true,
+			errMsg:     "must be an array of strings",
+		},
+		{
+			name:       "missing container path",
+			volumesRaw: []any{"/host/data"},
+			wantErr:    true,
+			errMsg:     "volumes[0]",
+		},
+		{
+			name:       "relative container path",
+			volumesRaw: []any{"/host/data:/data", "/host/cache:cache"},
+			wantErr:    true,
+			errMsg:     "volumes[1]",
+		},
+		{
+			name:       "invalid mode",
+			volumesRaw: []any{"/host/data:/data:readonly"},
+			wantErr:    true,
+			errMsg:     "mode must be 'ro' or 'rw'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMCPVolumesSyntax("my-tool", tt.volumesRaw)
+
+			if tt.wantErr {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.errMsg, "error message should contain %q", tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestGetMCPConfigVolumesBecomeGatewayMounts tests that docker volumes are passed to the gateway with an explicit mode.
+func TestGetMCPConfigVolumesBecomeGatewayMounts(t *testing.T) {
+	result, err := getMCPConfig(map[string]any{
+		"container": "myregistry/server:latest",
+		"volumes":   []any{"/host/data:/data:ro", "/host/cache:/cache"},
+	}, "volume-server")
+	require.NoError(t, err, "container MCP config with volumes should parse")
+
+	assert.Equal(t, []string{"/host/data:/data:ro", "/host/cache:/cache"}, result.Volumes, "volumes should be preserved")
+	assert.Equal(t, []string{"/host/data:/data:ro", "/host/cache:/cache:rw"}, result.Mounts, "volumes should default to read-write gateway mounts")
+}
+
+// TestValidateMCPConfigsRejectsToolPatterns tests that custom MCP servers only accept exact tool names or "*".
+func TestValidateMCPConfigsRejectsToolPatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []any
+		wantErr bool
+	}{
+		{name: "exact names", allowed: []any{"search_pages", "get_page"}},
+		{name: "wildcard", allowed: []any{"*"}},
+		{name: "prefix pattern", allowed: []any{"search_*"}, wantErr: true},
+		{name: "character class", allowed: []any{"get_[a-z]*"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools := map[string]any{
+				"notion": map[string]any{
+					"container": "mcp/notion",
+					"allowed":   tt.allowed,
+				},
+			}
+
+			err := ValidateMCPConfigs(tools)
+			if tt.wantErr {
+				require.Error(t, err, "Tool patterns on a custom MCP server should be rejected")
+				assert.Contains(t, err.Error(), "custom MCP servers do not support tool patterns", "Error should explain that patterns are unsupported")
+				return
+			}
+			assert.NoError(t, err, "Exact tool names and the wildcard should be accepted")
+		})
+	}
+}
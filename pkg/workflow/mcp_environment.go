@@ -176,6 +176,11 @@ func collectMCPEnvironmentVariables(tools map[string]any, mcpTools []string, wor
 				maps.Copy(envVars, headerSecrets)
 			}
 
+			// Pass the token fetched by the OAuth step of HTTP MCP servers that use auth
+			if mcpConfig.Auth != nil {
+				envVars[mcpOAuthTokenEnvVar(toolName)] = mcpOAuthTokenExpression(toolName)
+			}
+
 			// Also extract secrets from env section if present
 			if len(mcpConfig.Env) > 0 {
 				envSecrets := ExtractSecretsFromMap(mcpConfig.Env)
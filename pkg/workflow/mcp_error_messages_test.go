@@ -81,13 +81,13 @@ func TestMCPErrorMessageQuality(t *testing.T) {
 			name:     "unsupported MCP type",
 			toolName: "weird-tool",
 			toolConfig: map[string]any{
-				"type":    "websocket",
+				"type":    "grpc",
 				"command": "node",
 			},
 			expectError: true,
 			errorContains: []string{
 				"unsupported MCP type",
-				"websocket",
+				"grpc",
 				"weird-tool",
 				"Valid types are: stdio, http",
 				"Example:",
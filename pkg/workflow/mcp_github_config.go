@@ -23,6 +23,7 @@
 //
 // Security features:
 //   - Read-only mode: Prevents write operations (default: true)
+//   - Read-only toolsets: Limits selected toolsets to their read-only tools when read-only is false
 //   - GitHub lockdown mode: Restricts access to current repository only
 //   - Automatic lockdown: Enables lockdown for public repositories with GH_AW_GITHUB_TOKEN
 //   - Allowed tools: Restricts available GitHub API operations
@@ -64,6 +65,7 @@ package workflow
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -208,6 +210,91 @@ func expandDefaultToolset(toolsetsStr string) string {
 	return strings.Join(result, ",")
 }
 
+// getGitHubReadOnlyToolsets extracts the read-only-toolsets configuration from GitHub tool
+func getGitHubReadOnlyToolsets(githubTool any) []string {
+	if toolConfig, ok := githubTool.(map[string]any); ok {
+		switch v := toolConfig["read-only-toolsets"].(type) {
+		case []any:
+			toolsets := make([]string, 0, len(v))
+			for _, item := range v {
+				if str, ok := item.(string); ok {
+					toolsets = append(toolsets, str)
+				}
+			}
+			return toolsets
+		case []string:
+			return v
+		}
+	}
+	return nil
+}
+
+// gitHubToolsetAccess describes how the enabled GitHub toolsets are exposed by the GitHub MCP server
+type gitHubToolsetAccess struct {
+	// ReadOnly puts the whole server in read-only mode
+	ReadOnly bool
+	// Toolsets is the comma-separated list of toolsets enabled with all their tools
+	Toolsets string
+	// Tools is the comma-separated list of individual tools enabled in addition to Toolsets.
+	// It holds the read-only tools of the toolsets listed in read-only-toolsets.
+	Tools string
+}
+
+// getGitHubToolsetAccess resolves read-only, toolsets and read-only-toolsets into the toolsets
+// and tools to enable on the GitHub MCP server.
+//
+// Without read-only-toolsets, or when the whole server is read-only, the configured toolsets are
+// enabled as-is. Otherwise the read-only toolsets are removed from the enabled toolsets and only
+// their read-only tools are enabled individually, so the remaining toolsets stay writable. If no
+// writable toolset remains, the server falls back to read-only mode.
+func getGitHubToolsetAccess(githubTool any) gitHubToolsetAccess {
+	readOnly := getGitHubReadOnly(githubTool)
+	toolsets := getGitHubToolsets(githubTool)
+	readOnlyToolsets := getGitHubReadOnlyToolsets(githubTool)
+
+	if readOnly || len(readOnlyToolsets) == 0 {
+		return gitHubToolsetAccess{ReadOnly: readOnly, Toolsets: toolsets}
+	}
+
+	readOnlySet := make(map[string]bool, len(readOnlyToolsets))
+	for _, toolset := range readOnlyToolsets {
+		readOnlySet[toolset] = true
+	}
+
+	var writable []string
+	for _, toolset := range ParseGitHubToolsets(toolsets) {
+		if !readOnlySet[toolset] {
+			writable = append(writable, toolset)
+		}
+	}
+
+	if len(writable) == 0 {
+		githubConfigLog.Print("All enabled toolsets are read-only, using read-only mode")
+		return gitHubToolsetAccess{ReadOnly: true, Toolsets: toolsets}
+	}
+
+	seenTools := make(map[string]bool)
+	var tools []string
+	for _, toolset := range readOnlyToolsets {
+		for _, tool := range toolsetPermissionsMap[toolset].Tools {
+			if !seenTools[tool] {
+				tools = append(tools, tool)
+				seenTools[tool] = true
+			}
+		}
+	}
+
+	// "all" expands in map order, so sort for deterministic output
+	sort.Strings(writable)
+	sort.Strings(tools)
+
+	githubConfigLog.Printf("Resolved read-only toolsets %v: writable=%v, read-only tools=%d", readOnlyToolsets, writable, len(tools))
+	return gitHubToolsetAccess{
+		Toolsets: strings.Join(writable, ","),
+		Tools:    strings.Join(tools, ","),
+	}
+}
+
 // getGitHubAllowedTools extracts the allowed tools list from GitHub tool configuration
 // Returns the list of allowed tools, or nil if no allowed list is specified (which means all tools are allowed)
 func getGitHubAllowedTools(githubTool any) []string {
@@ -0,0 +1,64 @@
+// # MCP Startup Health Checks
+//
+// This file emits the per-server startup health check configuration for the MCP gateway step.
+//
+// Custom MCP servers can opt into a startup health check with `health-check: true` and
+// bound how long they may take to become ready with `startup-timeout` (seconds, default
+// constants.DefaultMCPServerStartupTimeout). The compiler exports the enabled servers as a
+// JSON object in GH_AW_MCP_HEALTH_CHECKS, e.g. {"datadog":90}. check_mcp_servers.sh then
+// keeps sending tools/list to each listed server until it answers or its startup timeout
+// elapses, and fails the step with a message naming the server that never became ready.
+package workflow
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var mcpHealthCheckLog = logger.New("workflow:mcp_health_check")
+
+// collectMCPHealthChecks returns the startup timeout in seconds for every custom MCP server
+// that enables health-check, keyed by server name
+func collectMCPHealthChecks(tools map[string]any) map[string]int {
+	healthChecks := make(map[string]int)
+	for toolName, toolValue := range tools {
+		toolConfig, ok := toolValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, _ := hasMCPConfig(toolConfig); !hasMcp {
+			continue
+		}
+		mcpConfig, err := getMCPConfig(toolConfig, toolName)
+		if err != nil {
+			mcpHealthCheckLog.Printf("Skipping health check for tool %s: %v", toolName, err)
+			continue
+		}
+		if mcpConfig.HealthCheck {
+			healthChecks[toolName] = mcpConfig.StartupTimeoutSeconds()
+		}
+	}
+
+	mcpHealthCheckLog.Printf("Collected %d MCP server health checks", len(healthChecks))
+	return healthChecks
+}
+
+// generateMCPHealthChecks exports GH_AW_MCP_HEALTH_CHECKS for check_mcp_servers.sh when
+// any custom MCP server enables its startup health check
+func generateMCPHealthChecks(yaml *strings.Builder, tools map[string]any) {
+	healthChecks := collectMCPHealthChecks(tools)
+	if len(healthChecks) == 0 {
+		return
+	}
+	// json.Marshal sorts map keys, keeping the generated lock file stable
+	healthChecksJSON, err := json.Marshal(healthChecks)
+	if err != nil {
+		mcpHealthCheckLog.Printf("Failed to marshal MCP health checks: %v", err)
+		return
+	}
+	yaml.WriteString("          # Fail fast when an MCP server does not become ready within its startup timeout\n")
+	yaml.WriteString("          export " + constants.EnvVarMCPHealthChecks + "=" + shellEscapeArg(string(healthChecksJSON)) + "\n")
+}
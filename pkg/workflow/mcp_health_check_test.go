@@ -0,0 +1,109 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectMCPHealthChecks(t *testing.T) {
+	tools := map[string]any{
+		"slow": map[string]any{
+			"command":         "node",
+			"args":            []any{"server.js"},
+			"startup-timeout": 90,
+			"health-check":    true,
+		},
+		"defaulted": map[string]any{
+			"type":         "http",
+			"url":          "https://example.com/mcp",
+			"health-check": true,
+		},
+		"unchecked": map[string]any{
+			"command":         "node",
+			"startup-timeout": 30,
+		},
+		"github": map[string]any{},
+	}
+
+	assert.Equal(t, map[string]int{"slow": 90, "defaulted": 60}, collectMCPHealthChecks(tools),
+		"only servers with health-check enabled should be collected, using the default startup timeout when unset")
+}
+
+func TestGenerateMCPHealthChecks(t *testing.T) {
+	var yaml strings.Builder
+	generateMCPHealthChecks(&yaml, map[string]any{"github": map[string]any{}})
+	assert.Empty(t, yaml.String(), "nothing should be emitted without health checks")
+
+	generateMCPHealthChecks(&yaml, map[string]any{
+		"b-server": map[string]any{"command": "node", "health-check": true},
+		"a-server": map[string]any{"command": "node", "health-check": true, "startup-timeout": 15},
+	})
+	assert.Contains(t, yaml.String(), `export GH_AW_MCP_HEALTH_CHECKS='{"a-server":15,"b-server":60}'`,
+		"health checks should be exported as sorted JSON")
+}
+
+func TestCompileMCPHealthCheck(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-health-check-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+mcp-servers:
+  flaky:
+    container: "myorg/flaky-mcp:latest"
+    startup-timeout: 45
+    health-check: true
+    allowed: ["*"]
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+	lockStr := string(lockContent)
+	exportIdx := strings.Index(lockStr, `export GH_AW_MCP_HEALTH_CHECKS='{"flaky":45}'`)
+	require.Greater(t, exportIdx, -1, "lock file should export the health check configuration")
+	gatewayIdx := strings.Index(lockStr, "start_mcp_gateway.sh")
+	require.Greater(t, gatewayIdx, -1, "lock file should start the MCP gateway")
+	assert.Less(t, exportIdx, gatewayIdx, "health checks should be exported before the gateway starts")
+}
+
+func TestCompileMCPHealthCheckInvalidStartupTimeout(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-health-check-invalid-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+mcp-servers:
+  flaky:
+    container: "myorg/flaky-mcp:latest"
+    startup-timeout: 0
+    health-check: true
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "a zero startup-timeout should be rejected")
+	assert.Contains(t, err.Error(), "startup-timeout", "error should name the invalid field")
+}
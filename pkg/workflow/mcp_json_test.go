@@ -211,7 +211,7 @@ func TestValidateMCPConfigs(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "new format: stdio with container and network config should fail",
+			name: "new format: stdio with container and network config",
 			tools: map[string]any{
 				"network-server": map[string]any{
 					"type":      "stdio",
@@ -223,8 +223,7 @@ func TestValidateMCPConfigs(t *testing.T) {
 					"allowed": []any{"fetch", "post"},
 				},
 			},
-			wantErr: true,
-			errMsg:  "unknown property 'network'",
+			wantErr: false,
 		},
 		{
 			name: "new format: missing type and no inferrable fields",
@@ -415,7 +414,7 @@ func TestValidateMCPConfigs(t *testing.T) {
 			errMsg:  "missing required property 'url'",
 		},
 		{
-			name: "network field in tool config should fail (no longer supported)",
+			name: "network field in tool config",
 			tools: map[string]any{
 				"toolWithNetworkField": map[string]any{
 					"type":      "stdio",
@@ -426,8 +425,7 @@ func TestValidateMCPConfigs(t *testing.T) {
 					"allowed": []any{"tool1"},
 				},
 			},
-			wantErr: true,
-			errMsg:  "unknown property 'network'",
+			wantErr: false,
 		},
 	}
 
@@ -0,0 +1,230 @@
+// # MCP Server Logs
+//
+// This file handles where MCP servers write their logs and how those logs are rotated.
+//
+// All MCP logs live under constants.DefaultMCPLogsDir, which is uploaded with the agent
+// artifacts. Playwright writes to its own subdirectory, "playwright" by default, and a
+// workflow can pin a different one with `tools.playwright.log-dir` so several browser
+// servers never collide. A pinned directory is created in the MCP setup step and is the
+// only part of the logs directory mounted into the Playwright container.
+//
+// `sandbox.mcp.log-rotation` bounds how large the logs grow during long runs:
+//
+//	sandbox:
+//	  mcp:
+//	    log-rotation:
+//	      max-size: 10m   # rotate a log file once it exceeds this size
+//	      max-age: 2h     # remove rotated copies older than this
+//	      max-files: 3    # rotated copies kept per log file (default: 3)
+//
+// The compiler exports the policy in the MCP setup step, where rotate_mcp_logs.sh watches the
+// logs directory in the background, and the gateway stop step runs a final pass so the
+// uploaded artifact follows the same policy.
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var mcpLogsLog = logger.New("workflow:mcp_logs")
+
+// defaultMCPLogMaxFiles is the number of rotated copies kept per log file when max-files is not set
+const defaultMCPLogMaxFiles = 3
+
+// mcpLogSizePattern matches a log size such as 512k, 10m or 1gb
+var mcpLogSizePattern = regexp.MustCompile(`(?i)^([0-9]+)(b|k|kb|m|mb|g|gb)?$`)
+
+// getPlaywrightLogDir returns the absolute directory Playwright writes its output and logs to
+func getPlaywrightLogDir(playwrightConfig *PlaywrightToolConfig) string {
+	logDir := parser.DefaultPlaywrightLogDir
+	if playwrightConfig != nil && playwrightConfig.LogDir != "" {
+		logDir = playwrightConfig.LogDir
+	}
+	path, err := parser.ResolveMCPServerLogDir(logDir)
+	if err != nil {
+		// validateMCPLogConfig rejects invalid directories before rendering
+		mcpLogsLog.Printf("Ignoring invalid Playwright log-dir: %v", err)
+		return constants.DefaultMCPLogsDir + "/" + parser.DefaultPlaywrightLogDir
+	}
+	return path
+}
+
+// getPlaywrightLogsMount returns the volume mount for the Playwright container. Without a pinned
+// log-dir the whole MCP logs directory is mounted, as before.
+func getPlaywrightLogsMount(playwrightConfig *PlaywrightToolConfig) string {
+	logsDir := constants.DefaultMCPLogsDir
+	if playwrightConfig != nil && playwrightConfig.LogDir != "" {
+		logsDir = getPlaywrightLogDir(playwrightConfig)
+	}
+	return logsDir + ":" + logsDir + ":rw"
+}
+
+// parseMCPLogSize converts a log size such as "10m" to bytes
+func parseMCPLogSize(size string) (int64, error) {
+	matches := mcpLogSizePattern.FindStringSubmatch(strings.TrimSpace(size))
+	if matches == nil {
+		return 0, fmt.Errorf("invalid size '%s': expected a size such as 512k, 10m or 1g", size)
+	}
+	value, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid size '%s': must be greater than zero", size)
+	}
+	switch strings.TrimSuffix(strings.ToLower(matches[2]), "b") {
+	case "k":
+		value *= 1024
+	case "m":
+		value *= 1024 * 1024
+	case "g":
+		value *= 1024 * 1024 * 1024
+	}
+	return value, nil
+}
+
+// mcpLogRotationEnv converts a rotation policy to the environment variables read by
+// rotate_mcp_logs.sh, in a stable order
+func mcpLogRotationEnv(rotation *MCPLogRotationConfig) ([][2]string, error) {
+	var env [][2]string
+	if rotation.MaxSize != "" {
+		size, err := parseMCPLogSize(rotation.MaxSize)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, [2]string{constants.EnvVarMCPLogMaxSizeBytes, strconv.FormatInt(size, 10)})
+	}
+	if rotation.MaxAge != "" {
+		age, err := time.ParseDuration(rotation.MaxAge)
+		if err != nil || age < time.Second {
+			return nil, fmt.Errorf("invalid age '%s': expected a duration of at least one second such as 30m or 2h", rotation.MaxAge)
+		}
+		env = append(env, [2]string{constants.EnvVarMCPLogMaxAgeSeconds, strconv.Itoa(int(age.Seconds()))})
+	}
+	maxFiles := rotation.MaxFiles
+	if maxFiles == 0 {
+		maxFiles = defaultMCPLogMaxFiles
+	}
+	env = append(env, [2]string{constants.EnvVarMCPLogMaxFiles, strconv.Itoa(maxFiles)})
+	return env, nil
+}
+
+// getMCPLogRotation returns the configured rotation policy, or nil when logs are not rotated
+func getMCPLogRotation(workflowData *WorkflowData) *MCPLogRotationConfig {
+	if workflowData == nil || workflowData.SandboxConfig == nil || workflowData.SandboxConfig.MCP == nil {
+		return nil
+	}
+	return workflowData.SandboxConfig.MCP.LogRotation
+}
+
+// mcpLogRotationEnvNames returns the names of the rotation environment variables exported in the
+// MCP setup step, or nil when logs are not rotated
+func mcpLogRotationEnvNames(workflowData *WorkflowData) []string {
+	rotation := getMCPLogRotation(workflowData)
+	if rotation == nil {
+		return nil
+	}
+	env, err := mcpLogRotationEnv(rotation)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(env))
+	for _, kv := range env {
+		names = append(names, kv[0])
+	}
+	return names
+}
+
+// validateMCPLogConfig validates the Playwright log-dir and the MCP log rotation policy
+func validateMCPLogConfig(workflowData *WorkflowData) error {
+	if playwrightTool, ok := workflowData.Tools["playwright"]; ok {
+		if playwrightConfig := parsePlaywrightTool(playwrightTool); playwrightConfig.LogDir != "" {
+			if _, err := parser.ResolveMCPServerLogDir(playwrightConfig.LogDir); err != nil {
+				return NewValidationError(
+					"tools.playwright.log-dir",
+					playwrightConfig.LogDir,
+					err.Error(),
+					"Use a folder name such as:\n\ntools:\n  playwright:\n    log-dir: playwright-checkout",
+				)
+			}
+		}
+	}
+
+	rotation := getMCPLogRotation(workflowData)
+	if rotation == nil {
+		return nil
+	}
+	if rotation.MaxSize == "" && rotation.MaxAge == "" {
+		return NewValidationError(
+			"sandbox.mcp.log-rotation",
+			"",
+			"log rotation needs max-size, max-age, or both",
+			"Rotate logs by size and remove old copies:\n\nsandbox:\n  mcp:\n    log-rotation:\n      max-size: 10m\n      max-age: 2h",
+		)
+	}
+	if rotation.MaxFiles < 0 {
+		return NewValidationError(
+			"sandbox.mcp.log-rotation.max-files",
+			strconv.Itoa(rotation.MaxFiles),
+			"max-files must be at least 1",
+			"Omit max-files to keep 3 rotated copies per log file.",
+		)
+	}
+	if _, err := mcpLogRotationEnv(rotation); err != nil {
+		return NewValidationError(
+			"sandbox.mcp.log-rotation",
+			rotation.MaxSize+" "+rotation.MaxAge,
+			err.Error(),
+			"Sizes use k, m or g suffixes (e.g., 10m) and ages use Go durations (e.g., 30m, 2h).",
+		)
+	}
+	return nil
+}
+
+// generateMCPLogSetup creates pinned MCP log directories and starts log rotation in the MCP setup step
+func generateMCPLogSetup(yaml *strings.Builder, workflowData *WorkflowData, tools map[string]any) {
+	if playwrightTool, ok := tools["playwright"]; ok {
+		if playwrightConfig := parsePlaywrightTool(playwrightTool); playwrightConfig.LogDir != "" {
+			// Create the pinned directory on the host so docker does not create it as root
+			yaml.WriteString("          mkdir -p \"" + getPlaywrightLogDir(playwrightConfig) + "\"\n")
+		}
+	}
+
+	rotation := getMCPLogRotation(workflowData)
+	if rotation == nil {
+		return
+	}
+	env, err := mcpLogRotationEnv(rotation)
+	if err != nil {
+		mcpLogsLog.Printf("Skipping MCP log rotation: %v", err)
+		return
+	}
+	mcpLogsLog.Printf("Enabling MCP log rotation: max_size=%s, max_age=%s", rotation.MaxSize, rotation.MaxAge)
+	yaml.WriteString("          # Rotate MCP server logs in the background while the agent runs\n")
+	for _, kv := range env {
+		yaml.WriteString("          export " + kv[0] + "=\"" + kv[1] + "\"\n")
+	}
+	yaml.WriteString("          nohup bash /opt/gh-aw/actions/rotate_mcp_logs.sh --watch " + constants.DefaultMCPLogsDir + " > /tmp/gh-aw/mcp-config/log-rotation.log 2>&1 &\n")
+}
+
+// generateMCPLogRotationEnv writes the rotation policy as step env entries and reports whether
+// rotation is enabled, so the gateway stop step can run a final rotation pass before upload
+func generateMCPLogRotationEnv(yaml *strings.Builder, workflowData *WorkflowData) bool {
+	rotation := getMCPLogRotation(workflowData)
+	if rotation == nil {
+		return false
+	}
+	env, err := mcpLogRotationEnv(rotation)
+	if err != nil {
+		return false
+	}
+	for _, kv := range env {
+		yaml.WriteString("          " + kv[0] + ": \"" + kv[1] + "\"\n")
+	}
+	return true
+}
@@ -0,0 +1,264 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPlaywrightLogDir(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *PlaywrightToolConfig
+		expectedDir   string
+		expectedMount string
+	}{
+		{
+			name:          "nil config uses the shared logs mount",
+			config:        nil,
+			expectedDir:   "/tmp/gh-aw/mcp-logs/playwright",
+			expectedMount: "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs:rw",
+		},
+		{
+			name:          "default log-dir uses the shared logs mount",
+			config:        &PlaywrightToolConfig{},
+			expectedDir:   "/tmp/gh-aw/mcp-logs/playwright",
+			expectedMount: "/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs:rw",
+		},
+		{
+			name:          "pinned log-dir mounts only that directory",
+			config:        &PlaywrightToolConfig{LogDir: "browser/checkout"},
+			expectedDir:   "/tmp/gh-aw/mcp-logs/browser/checkout",
+			expectedMount: "/tmp/gh-aw/mcp-logs/browser/checkout:/tmp/gh-aw/mcp-logs/browser/checkout:rw",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedDir, getPlaywrightLogDir(tt.config), "log directory should match")
+			assert.Equal(t, tt.expectedMount, getPlaywrightLogsMount(tt.config), "logs mount should match")
+		})
+	}
+}
+
+func TestParseMCPLogSize(t *testing.T) {
+	tests := []struct {
+		size     string
+		expected int64
+		wantErr  bool
+	}{
+		{size: "100", expected: 100},
+		{size: "512k", expected: 512 * 1024},
+		{size: "10m", expected: 10 * 1024 * 1024},
+		{size: "10MB", expected: 10 * 1024 * 1024},
+		{size: "1g", expected: 1024 * 1024 * 1024},
+		{size: "0m", wantErr: true},
+		{size: "ten", wantErr: true},
+		{size: "10t", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			size, err := parseMCPLogSize(tt.size)
+			if tt.wantErr {
+				assert.Error(t, err, "size should be rejected")
+				return
+			}
+			require.NoError(t, err, "size should be accepted")
+			assert.Equal(t, tt.expected, size, "size in bytes should match")
+		})
+	}
+}
+
+func TestMCPLogRotationEnv(t *testing.T) {
+	env, err := mcpLogRotationEnv(&MCPLogRotationConfig{MaxSize: "10m", MaxAge: "2h"})
+	require.NoError(t, err, "valid rotation policy should convert")
+	assert.Equal(t, [][2]string{
+		{"GH_AW_MCP_LOG_MAX_SIZE_BYTES", "10485760"},
+		{"GH_AW_MCP_LOG_MAX_AGE_SECONDS", "7200"},
+		{"GH_AW_MCP_LOG_MAX_FILES", "3"},
+	}, env, "rotation env should use bytes, seconds and the default max-files")
+
+	env, err = mcpLogRotationEnv(&MCPLogRotationConfig{MaxAge: "30m", MaxFiles: 5})
+	require.NoError(t, err, "age-only rotation policy should convert")
+	assert.Equal(t, [][2]string{
+		{"GH_AW_MCP_LOG_MAX_AGE_SECONDS", "1800"},
+		{"GH_AW_MCP_LOG_MAX_FILES", "5"},
+	}, env, "rotation env should omit the size limit when max-size is not set")
+
+	_, err = mcpLogRotationEnv(&MCPLogRotationConfig{MaxAge: "two hours"})
+	assert.Error(t, err, "invalid max-age should be rejected")
+}
+
+func TestValidateMCPLogConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    *WorkflowData
+		wantErr string
+	}{
+		{
+			name: "no log configuration",
+			data: &WorkflowData{Tools: map[string]any{"playwright": nil}},
+		},
+		{
+			name: "valid log-dir and rotation",
+			data: &WorkflowData{
+				Tools:         map[string]any{"playwright": map[string]any{"log-dir": "browser"}},
+				SandboxConfig: &SandboxConfig{MCP: &MCPGatewayRuntimeConfig{LogRotation: &MCPLogRotationConfig{MaxSize: "10m"}}},
+			},
+		},
+		{
+			name:    "log-dir outside the logs directory",
+			data:    &WorkflowData{Tools: map[string]any{"playwright": map[string]any{"log-dir": "/var/log"}}},
+			wantErr: "tools.playwright.log-dir",
+		},
+		{
+			name: "rotation without limits",
+			data: &WorkflowData{
+				SandboxConfig: &SandboxConfig{MCP: &MCPGatewayRuntimeConfig{LogRotation: &MCPLogRotationConfig{MaxFiles: 2}}},
+			},
+			wantErr: "max-size, max-age, or both",
+		},
+		{
+			name: "rotation with invalid size",
+			data: &WorkflowData{
+				SandboxConfig: &SandboxConfig{MCP: &MCPGatewayRuntimeConfig{LogRotation: &MCPLogRotationConfig{MaxSize: "big"}}},
+			},
+			wantErr: "invalid size 'big'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMCPLogConfig(tt.data)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "log configuration should be valid")
+				return
+			}
+			require.Error(t, err, "log configuration should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error should explain the problem")
+		})
+	}
+}
+
+func TestRenderPlaywrightMCPConfigWithPinnedLogDir(t *testing.T) {
+	config := &PlaywrightToolConfig{LogDir: "checkout"}
+
+	var jsonYAML strings.Builder
+	renderPlaywrightMCPConfigWithOptions(&jsonYAML, config, true, true, true)
+	assert.Contains(t, jsonYAML.String(), `"--output-dir", "/tmp/gh-aw/mcp-logs/checkout"`, "JSON config should write output to the pinned directory")
+	assert.Contains(t, jsonYAML.String(), `"mounts": ["/tmp/gh-aw/mcp-logs/checkout:/tmp/gh-aw/mcp-logs/checkout:rw"]`, "JSON config should mount only the pinned directory")
+
+	var tomlYAML strings.Builder
+	renderer := NewMCPConfigRenderer(MCPRendererOptions{Format: "toml"})
+	renderer.renderPlaywrightTOML(&tomlYAML, config)
+	assert.Contains(t, tomlYAML.String(), `"/tmp/gh-aw/mcp-logs/checkout"`, "TOML config should write output to the pinned directory")
+	assert.Contains(t, tomlYAML.String(), `mounts = ["/tmp/gh-aw/mcp-logs/checkout:/tmp/gh-aw/mcp-logs/checkout:rw"]`, "TOML config should mount only the pinned directory")
+}
+
+func TestCompileMCPLogDirAndRotation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-logs-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+tools:
+  playwright:
+    log-dir: browser/checkout
+sandbox:
+  mcp:
+    log-rotation:
+      max-size: 10m
+      max-age: 2h
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+	lockStr := string(lockContent)
+
+	assert.Contains(t, lockStr, `mkdir -p "/tmp/gh-aw/mcp-logs/browser/checkout"`, "setup step should create the pinned log directory")
+	assert.Contains(t, lockStr, `"mounts": ["/tmp/gh-aw/mcp-logs/browser/checkout:/tmp/gh-aw/mcp-logs/browser/checkout:rw"]`, "Playwright should mount only the pinned log directory")
+	assert.Contains(t, lockStr, `"--output-dir", "/tmp/gh-aw/mcp-logs/browser/checkout"`, "Playwright should write output to the pinned log directory")
+
+	exportIdx := strings.Index(lockStr, `export GH_AW_MCP_LOG_MAX_SIZE_BYTES="10485760"`)
+	require.Greater(t, exportIdx, -1, "setup step should export the rotation size limit")
+	assert.Contains(t, lockStr, `export GH_AW_MCP_LOG_MAX_AGE_SECONDS="7200"`, "setup step should export the rotation age limit")
+	assert.Contains(t, lockStr, `export GH_AW_MCP_LOG_MAX_FILES="3"`, "setup step should export the default max-files")
+	assert.Contains(t, lockStr, "rotate_mcp_logs.sh --watch /tmp/gh-aw/mcp-logs", "setup step should start the rotation watcher")
+	assert.Contains(t, lockStr, "-e GH_AW_MCP_LOG_MAX_SIZE_BYTES -e GH_AW_MCP_LOG_MAX_AGE_SECONDS -e GH_AW_MCP_LOG_MAX_FILES", "rotation env should be passed to the gateway container")
+	gatewayIdx := strings.Index(lockStr, "start_mcp_gateway.sh")
+	require.Greater(t, gatewayIdx, -1, "lock file should start the MCP gateway")
+	assert.Less(t, exportIdx, gatewayIdx, "rotation should be configured before the gateway starts")
+
+	stopStep := lockStr[strings.Index(lockStr, "- name: Stop MCP Gateway"):]
+	assert.Contains(t, stopStep, `GH_AW_MCP_LOG_MAX_SIZE_BYTES: "10485760"`, "stop step should receive the rotation policy")
+	assert.Contains(t, stopStep, "bash /opt/gh-aw/actions/rotate_mcp_logs.sh /tmp/gh-aw/mcp-logs", "stop step should run a final rotation pass")
+	assert.Contains(t, lockStr, "/tmp/gh-aw/mcp-logs/", "MCP logs should still be uploaded")
+}
+
+func TestCompileMCPLogsDefaultsUnchanged(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-logs-default-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+tools:
+  playwright:
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+	lockStr := string(lockContent)
+
+	assert.Contains(t, lockStr, `"mounts": ["/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs:rw"]`, "Playwright should mount the shared logs directory by default")
+	assert.NotContains(t, lockStr, "rotate_mcp_logs.sh", "logs should not be rotated without a rotation policy")
+	assert.NotContains(t, lockStr, "GH_AW_MCP_LOG_MAX_FILES", "rotation env should not be emitted without a rotation policy")
+}
+
+func TestCompileMCPLogDirRejectsEscape(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-logs-invalid-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+tools:
+  playwright:
+    log-dir: ../../etc
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "a log-dir outside the MCP logs directory should be rejected")
+	assert.Contains(t, err.Error(), "log-dir", "error should name the invalid field")
+}
@@ -0,0 +1,144 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractMCPNetworkDomains(t *testing.T) {
+	tools := map[string]any{
+		"fetcher": map[string]any{
+			"container": "mcp/fetch",
+			"network": map[string]any{
+				"allowed": []any{"example.com", "api.example.com"},
+			},
+		},
+		"installer": map[string]any{
+			"command": "npx",
+			"args":    []any{"@my/installer"},
+			"network": map[string]any{
+				"allowed": []any{"node"},
+			},
+		},
+		"remote": map[string]any{
+			"type": "http",
+			"url":  "https://mcp.remote.com/mcp",
+			"network": map[string]any{
+				"allowed": []any{"ignored.example.org"},
+			},
+		},
+		"plain": map[string]any{
+			"container": "mcp/plain",
+		},
+		"github": nil,
+	}
+
+	domains := extractMCPNetworkDomains(tools)
+
+	assert.Contains(t, domains, "example.com", "Server domains should be collected")
+	assert.Contains(t, domains, "api.example.com", "Server domains should be collected")
+	assert.Contains(t, domains, "registry.npmjs.org", "Ecosystem identifiers should be expanded")
+	assert.NotContains(t, domains, "ignored.example.org", "HTTP servers should not contribute per-server network domains")
+	assert.NotContains(t, domains, "node", "Ecosystem identifiers should not be passed through verbatim")
+	assert.Empty(t, extractMCPNetworkDomains(nil), "Nil tools should yield no domains")
+}
+
+func TestGetAllowedDomainsForEngineWithMCPNetwork(t *testing.T) {
+	network := &NetworkPermissions{Allowed: []string{"defaults", "github.com"}}
+	tools := map[string]any{
+		"server-a": map[string]any{
+			"container": "mcp/a",
+			"network":   map[string]any{"allowed": []any{"a.example.com", "shared.example.com"}},
+		},
+		"server-b": map[string]any{
+			"container": "mcp/b",
+			"network":   map[string]any{"allowed": []any{"b.example.com", "shared.example.com"}},
+		},
+	}
+
+	domains := GetAllowedDomainsForEngine(constants.CopilotEngine, network, tools, nil)
+
+	assert.Contains(t, domains, "a.example.com", "First server domains should be merged")
+	assert.Contains(t, domains, "b.example.com", "Second server domains should be merged")
+	assert.Contains(t, domains, "github.com", "Top-level domains should be kept")
+	assert.Equal(t, 1, strings.Count(","+domains+",", ",shared.example.com,"), "Shared domains should be deduplicated")
+}
+
+func TestGetMCPConfigNetworkProxyArgs(t *testing.T) {
+	config, err := getMCPConfig(map[string]any{
+		"container": "mcp/fetch",
+		"network": map[string]any{
+			"allowed":    []any{"example.com"},
+			"proxy-args": []any{"--proxy-test"},
+		},
+	}, "fetcher")
+	require.NoError(t, err, "Per-server network block should be accepted")
+	assert.Equal(t, []string{"--proxy-test"}, config.ProxyArgs, "Per-server proxy args should be used")
+
+	config, err = getMCPConfig(map[string]any{
+		"container":  "mcp/fetch",
+		"proxy-args": []any{"--top-level"},
+		"network": map[string]any{
+			"proxy-args": []any{"--proxy-test"},
+		},
+	}, "fetcher")
+	require.NoError(t, err, "Both proxy-args forms should be accepted")
+	assert.Equal(t, []string{"--top-level"}, config.ProxyArgs, "Server-level proxy-args should take precedence")
+}
+
+func TestValidateStrictMCPNetworkWithServerNetwork(t *testing.T) {
+	compiler := NewCompiler()
+	frontmatter := map[string]any{
+		"mcp-servers": map[string]any{
+			"fetcher": map[string]any{
+				"container": "mcp/fetch",
+				"network":   map[string]any{"allowed": []any{"example.com"}},
+			},
+		},
+	}
+
+	assert.NoError(t, compiler.validateStrictMCPNetwork(frontmatter, nil),
+		"Per-server network block should satisfy strict mode without top-level network")
+}
+
+func TestCompileWorkflowWithMCPServerNetwork(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-network-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+mcp-servers:
+  fetcher:
+    container: mcp/fetch
+    network:
+      allowed:
+        - fetch.example.com
+    allowed: ["fetch"]
+---
+
+# MCP Server Network
+
+Fetch the page.
+`
+
+	testFile := filepath.Join(tmpDir, "mcp-network.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with per-server network should compile in strict mode")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	agentJob := extractJobSection(string(lockContent), "agent")
+	assert.Contains(t, agentJob, "fetch.example.com", "Server domains should be merged into the firewall allowlist")
+}
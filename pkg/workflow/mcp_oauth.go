@@ -0,0 +1,102 @@
+// # MCP OAuth Client Credentials
+//
+// This file emits the token steps for HTTP MCP servers that authenticate with an `auth` block.
+//
+// Static `Authorization: Bearer <token>` headers expire, so an HTTP MCP server can instead
+// declare OAuth client credentials:
+//
+//	mcp-servers:
+//	  my-server:
+//	    url: "https://api.example.com/mcp"
+//	    auth:
+//	      token-url: "https://auth.example.com/oauth/token"
+//	      client-id: "${{ secrets.MCP_CLIENT_ID }}"
+//	      client-secret: "${{ secrets.MCP_CLIENT_SECRET }}"
+//
+// Before the MCP gateway starts, the compiler emits one step per server that runs
+// fetch_mcp_oauth_token.sh to request a token with the client_credentials grant. The masked
+// token is passed to the gateway step through the GH_AW_MCP_OAUTH_TOKEN_<SERVER> environment
+// variable, and the server's Authorization header is rendered as "Bearer ${GH_AW_MCP_OAUTH_TOKEN_<SERVER>}".
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/github/gh-aw/pkg/types"
+)
+
+var mcpOAuthLog = logger.New("workflow:mcp_oauth")
+
+// mcpOAuthNameSanitizer replaces characters that are not valid in step ids and environment variable names
+var mcpOAuthNameSanitizer = strings.NewReplacer(".", "_", "-", "_", " ", "_")
+
+// mcpOAuthTokenEnvVar returns the environment variable that carries the OAuth token of an MCP server
+// to the MCP gateway step, e.g. GH_AW_MCP_OAUTH_TOKEN_MY_SERVER for my-server
+func mcpOAuthTokenEnvVar(toolName string) string {
+	return "GH_AW_MCP_OAUTH_TOKEN_" + strings.ToUpper(mcpOAuthNameSanitizer.Replace(toolName))
+}
+
+// mcpOAuthStepID returns the id of the step that fetches the OAuth token of an MCP server
+func mcpOAuthStepID(toolName string) string {
+	return "mcp-oauth-token-" + strings.ReplaceAll(strings.ToLower(mcpOAuthNameSanitizer.Replace(toolName)), "_", "-")
+}
+
+// collectMCPOAuthConfigs returns the auth configuration of every custom HTTP MCP server that
+// authenticates with OAuth client credentials, keyed by server name
+func collectMCPOAuthConfigs(tools map[string]any) map[string]*types.MCPAuthConfig {
+	authConfigs := make(map[string]*types.MCPAuthConfig)
+	for toolName, toolValue := range tools {
+		toolConfig, ok := toolValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, mcpType := hasMCPConfig(toolConfig); !hasMcp || mcpType != "http" {
+			continue
+		}
+		mcpConfig, err := getMCPConfig(toolConfig, toolName)
+		if err != nil {
+			mcpOAuthLog.Printf("Skipping OAuth token step for tool %s: %v", toolName, err)
+			continue
+		}
+		if mcpConfig.Auth != nil {
+			authConfigs[toolName] = mcpConfig.Auth
+		}
+	}
+
+	mcpOAuthLog.Printf("Collected %d MCP servers using OAuth client credentials", len(authConfigs))
+	return authConfigs
+}
+
+// generateMCPOAuthTokenSteps writes a step per OAuth-authenticated MCP server that fetches a
+// fresh bearer token before the MCP gateway starts
+func generateMCPOAuthTokenSteps(yaml *strings.Builder, tools map[string]any) {
+	authConfigs := collectMCPOAuthConfigs(tools)
+	toolNames := sliceutil.MapToSlice(authConfigs)
+	sort.Strings(toolNames)
+
+	for _, toolName := range toolNames {
+		auth := authConfigs[toolName]
+		fmt.Fprintf(yaml, "      - name: Fetch OAuth token for MCP server %s\n", toolName)
+		fmt.Fprintf(yaml, "        id: %s\n", mcpOAuthStepID(toolName))
+		yaml.WriteString("        env:\n")
+		fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_TOKEN_URL: %q\n", auth.TokenURL)
+		fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_CLIENT_ID: %q\n", auth.ClientID)
+		fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_CLIENT_SECRET: %q\n", auth.ClientSecret)
+		if auth.Scope != "" {
+			fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_SCOPE: %q\n", auth.Scope)
+		}
+		if auth.Audience != "" {
+			fmt.Fprintf(yaml, "          GH_AW_MCP_OAUTH_AUDIENCE: %q\n", auth.Audience)
+		}
+		fmt.Fprintf(yaml, "        run: bash /opt/gh-aw/actions/fetch_mcp_oauth_token.sh %s\n", shellEscapeArg(toolName))
+	}
+}
+
+// mcpOAuthTokenExpression returns the expression that reads the token fetched by the OAuth step of an MCP server
+func mcpOAuthTokenExpression(toolName string) string {
+	return fmt.Sprintf("${{ steps.%s.outputs.token }}", mcpOAuthStepID(toolName))
+}
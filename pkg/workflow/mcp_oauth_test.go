@@ -0,0 +1,164 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPOAuthNames(t *testing.T) {
+	assert.Equal(t, "GH_AW_MCP_OAUTH_TOKEN_MY_SERVER", mcpOAuthTokenEnvVar("my-server"))
+	assert.Equal(t, "GH_AW_MCP_OAUTH_TOKEN_API_V2", mcpOAuthTokenEnvVar("api.v2"))
+	assert.Equal(t, "mcp-oauth-token-my-server", mcpOAuthStepID("my-server"))
+	assert.Equal(t, "mcp-oauth-token-my-server", mcpOAuthStepID("My_Server"))
+	assert.Equal(t, "${{ steps.mcp-oauth-token-my-server.outputs.token }}", mcpOAuthTokenExpression("my-server"))
+}
+
+func TestGetMCPConfigOAuthHeader(t *testing.T) {
+	toolConfig := map[string]any{
+		"url":     "https://api.example.com/mcp",
+		"headers": map[string]any{"X-Tenant": "acme"},
+		"auth": map[string]any{
+			"token-url":     "https://auth.example.com/oauth/token",
+			"client-id":     "${{ secrets.MCP_CLIENT_ID }}",
+			"client-secret": "${{ secrets.MCP_CLIENT_SECRET }}",
+		},
+	}
+
+	mcpConfig, err := getMCPConfig(toolConfig, "my-server")
+	require.NoError(t, err)
+	require.NotNil(t, mcpConfig.Auth)
+	assert.Equal(t, map[string]string{
+		"X-Tenant":      "acme",
+		"Authorization": "Bearer ${GH_AW_MCP_OAUTH_TOKEN_MY_SERVER}",
+	}, mcpConfig.Headers, "the OAuth token should be sent as the Authorization header")
+
+	staticConfig, err := getMCPConfig(map[string]any{
+		"url":     "https://api.example.com/mcp",
+		"headers": map[string]any{"Authorization": "Bearer ${{ secrets.API_TOKEN }}"},
+	}, "static-server")
+	require.NoError(t, err)
+	assert.Nil(t, staticConfig.Auth)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer ${{ secrets.API_TOKEN }}"}, staticConfig.Headers,
+		"static headers should be rendered unchanged")
+}
+
+func TestGenerateMCPOAuthTokenSteps(t *testing.T) {
+	var yaml strings.Builder
+	generateMCPOAuthTokenSteps(&yaml, map[string]any{
+		"static": map[string]any{"url": "https://api.example.com/mcp"},
+		"github": map[string]any{},
+	})
+	assert.Empty(t, yaml.String(), "nothing should be emitted without an auth block")
+
+	generateMCPOAuthTokenSteps(&yaml, map[string]any{
+		"b-server": map[string]any{
+			"url": "https://b.example.com/mcp",
+			"auth": map[string]any{
+				"token-url":     "https://auth.example.com/token",
+				"client-id":     "b-client",
+				"client-secret": "${{ secrets.B_SECRET }}",
+				"audience":      "*",
+			},
+		},
+		"a-server": map[string]any{
+			"url": "https://a.example.com/mcp",
+			"auth": map[string]any{
+				"token-url":     "https://auth.example.com/token",
+				"client-id":     "${{ secrets.A_CLIENT_ID }}",
+				"client-secret": "${{ secrets.A_SECRET }}",
+				"scope":         "mcp.read #admin",
+			},
+		},
+	})
+	steps := yaml.String()
+	assert.Contains(t, steps, "id: mcp-oauth-token-a-server")
+	assert.Contains(t, steps, `GH_AW_MCP_OAUTH_CLIENT_SECRET: "${{ secrets.A_SECRET }}"`)
+	assert.Contains(t, steps, `GH_AW_MCP_OAUTH_SCOPE: "mcp.read #admin"`)
+	assert.Contains(t, steps, `GH_AW_MCP_OAUTH_AUDIENCE: "*"`)
+	assert.Contains(t, steps, "run: bash /opt/gh-aw/actions/fetch_mcp_oauth_token.sh b-server")
+	assert.Less(t, strings.Index(steps, "a-server"), strings.Index(steps, "b-server"), "steps should be sorted by server name")
+}
+
+func TestCompileMCPOAuth(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-oauth-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+network:
+  allowed:
+    - defaults
+    - api.example.com
+mcp-servers:
+  my-server:
+    url: "https://api.example.com/mcp"
+    auth:
+      token-url: "https://auth.example.com/oauth/token"
+      client-id: "${{ secrets.MCP_CLIENT_ID }}"
+      client-secret: "${{ secrets.MCP_CLIENT_SECRET }}"
+    allowed: ["*"]
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+	lockStr := string(lockContent)
+
+	fetchIdx := strings.Index(lockStr, "id: mcp-oauth-token-my-server")
+	require.Greater(t, fetchIdx, -1, "lock file should fetch the OAuth token")
+	gatewayIdx := strings.Index(lockStr, "name: Start MCP Gateway")
+	require.Greater(t, gatewayIdx, -1, "lock file should start the MCP gateway")
+	assert.Less(t, fetchIdx, gatewayIdx, "the token should be fetched before the gateway starts")
+	assert.Contains(t, lockStr, "GH_AW_MCP_OAUTH_TOKEN_MY_SERVER: ${{ steps.mcp-oauth-token-my-server.outputs.token }}",
+		"the token should be passed to the gateway step through env")
+	assert.Contains(t, lockStr, `"Authorization": "Bearer ${GH_AW_MCP_OAUTH_TOKEN_MY_SERVER}"`)
+}
+
+func TestCompileMCPOAuthInvalid(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-oauth-invalid-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+network:
+  allowed:
+    - defaults
+    - api.example.com
+mcp-servers:
+  my-server:
+    url: "https://api.example.com/mcp"
+    headers:
+      Authorization: "Bearer ${{ secrets.API_TOKEN }}"
+    auth:
+      token-url: "https://auth.example.com/oauth/token"
+      client-id: "my-client"
+      client-secret: "${{ secrets.MCP_CLIENT_SECRET }}"
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "auth combined with a static Authorization header should be rejected")
+	assert.Contains(t, err.Error(), "cannot set both auth and an 'Authorization' header")
+}
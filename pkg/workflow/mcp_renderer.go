@@ -84,6 +84,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 )
 
 var mcpRendererLog = logger.New("workflow:mcp_renderer")
@@ -121,7 +122,8 @@ func NewMCPConfigRenderer(opts MCPRendererOptions) *MCPConfigRendererUnified {
 // Supports both local (Docker) and remote (hosted) modes
 func (r *MCPConfigRendererUnified) RenderGitHubMCP(yaml *strings.Builder, githubTool any, workflowData *WorkflowData) {
 	githubType := getGitHubType(githubTool)
-	readOnly := getGitHubReadOnly(githubTool)
+	access := getGitHubToolsetAccess(githubTool)
+	readOnly := access.ReadOnly
 
 	// Get lockdown value - use detected value if lockdown wasn't explicitly set
 	lockdown := getGitHubLockdown(githubTool)
@@ -136,10 +138,10 @@ func (r *MCPConfigRendererUnified) RenderGitHubMCP(yaml *strings.Builder, github
 		lockdown = true // This is a placeholder - actual value comes from step output
 	}
 
-	toolsets := getGitHubToolsets(githubTool)
+	toolsets := access.Toolsets
 
-	mcpRendererLog.Printf("Rendering GitHub MCP: type=%s, read_only=%t, lockdown=%t (explicit=%t, use_step=%t), toolsets=%v, format=%s",
-		githubType, readOnly, lockdown, hasGitHubLockdownExplicitlySet(githubTool), shouldUseStepOutput, toolsets, r.options.Format)
+	mcpRendererLog.Printf("Rendering GitHub MCP: type=%s, read_only=%t, lockdown=%t (explicit=%t, use_step=%t), toolsets=%v, read_only_tools=%q, format=%s",
+		githubType, readOnly, lockdown, hasGitHubLockdownExplicitlySet(githubTool), shouldUseStepOutput, toolsets, access.Tools, r.options.Format)
 
 	if r.options.Format == "toml" {
 		r.renderGitHubTOML(yaml, githubTool, workflowData)
@@ -163,6 +165,7 @@ func (r *MCPConfigRendererUnified) RenderGitHubMCP(yaml *strings.Builder, github
 			Lockdown:           lockdown,
 			LockdownFromStep:   shouldUseStepOutput,
 			Toolsets:           toolsets,
+			Tools:              access.Tools,
 			AuthorizationValue: authValue,
 			IncludeToolsField:  r.options.IncludeCopilotFields,
 			AllowedTools:       getGitHubAllowedTools(githubTool),
@@ -179,6 +182,7 @@ func (r *MCPConfigRendererUnified) RenderGitHubMCP(yaml *strings.Builder, github
 			Lockdown:           lockdown,
 			LockdownFromStep:   shouldUseStepOutput,
 			Toolsets:           toolsets,
+			Tools:              access.Tools,
 			DockerImageVersion: githubDockerImageVersion,
 			CustomArgs:         customArgs,
 			Mounts:             mounts,
@@ -240,7 +244,7 @@ func (r *MCPConfigRendererUnified) renderPlaywrightTOML(yaml *strings.Builder, p
 	// Entrypoint args for Playwright MCP server (goes after container image)
 	yaml.WriteString("          entrypointArgs = [\n")
 	yaml.WriteString("            \"--output-dir\",\n")
-	yaml.WriteString("            \"/tmp/gh-aw/mcp-logs/playwright\"")
+	yaml.WriteString("            \"" + getPlaywrightLogDir(playwrightConfig) + "\"")
 
 	// Append custom args if present
 	writeArgsToYAML(yaml, customArgs, "            ")
@@ -248,8 +252,8 @@ func (r *MCPConfigRendererUnified) renderPlaywrightTOML(yaml *strings.Builder, p
 	yaml.WriteString("\n")
 	yaml.WriteString("          ]\n")
 
-	// Add volume mounts
-	yaml.WriteString("          mounts = [\"/tmp/gh-aw/mcp-logs:/tmp/gh-aw/mcp-logs:rw\"]\n")
+	// Add volume mounts (only the pinned log directory when log-dir is set)
+	yaml.WriteString("          mounts = [\"" + getPlaywrightLogsMount(playwrightConfig) + "\"]\n")
 }
 
 // RenderSerenaMCP generates Serena MCP server configuration
@@ -472,9 +476,10 @@ func (r *MCPConfigRendererUnified) renderAgenticWorkflowsTOML(yaml *strings.Buil
 // renderGitHubTOML generates GitHub MCP configuration in TOML format (for Codex engine)
 func (r *MCPConfigRendererUnified) renderGitHubTOML(yaml *strings.Builder, githubTool any, workflowData *WorkflowData) {
 	githubType := getGitHubType(githubTool)
-	readOnly := getGitHubReadOnly(githubTool)
+	access := getGitHubToolsetAccess(githubTool)
+	readOnly := access.ReadOnly
 	lockdown := getGitHubLockdown(githubTool)
-	toolsets := getGitHubToolsets(githubTool)
+	toolsets := access.Toolsets
 
 	yaml.WriteString("          \n")
 	yaml.WriteString("          [mcp_servers.github]\n")
@@ -518,6 +523,12 @@ func (r *MCPConfigRendererUnified) renderGitHubTOML(yaml *strings.Builder, githu
 
 		// Use bearer_token_env_var for authentication
 		yaml.WriteString("          bearer_token_env_var = \"GH_AW_GITHUB_TOKEN\"\n")
+
+		// With read-only-toolsets, pass the writable toolsets and the read-only tools as headers,
+		// matching the X-MCP-Toolsets/X-MCP-Tools headers of the JSON remote configuration
+		if access.Tools != "" {
+			fmt.Fprintf(yaml, "          http_headers = { \"X-MCP-Toolsets\" = \"%s\", \"X-MCP-Tools\" = \"%s\" }\n", toolsets, access.Tools)
+		}
 	} else {
 		// Local mode - use Docker-based GitHub MCP server with MCP Gateway spec format
 		githubDockerImageVersion := getGitHubDockerImageVersion(githubTool)
@@ -525,7 +536,7 @@ func (r *MCPConfigRendererUnified) renderGitHubTOML(yaml *strings.Builder, githu
 		mounts := getGitHubMounts(githubTool)
 
 		// MCP Gateway spec fields for containerized stdio servers
-		yaml.WriteString("          container = \"ghcr.io/github/github-mcp-server:" + githubDockerImageVersion + "\"\n")
+		yaml.WriteString("          container = \"" + parser.DockerImageReference("ghcr.io/github/github-mcp-server", githubDockerImageVersion) + "\"\n")
 
 		// Append custom args if present (these are Docker runtime args, go before container image)
 		if len(customArgs) > 0 {
@@ -562,6 +573,10 @@ func (r *MCPConfigRendererUnified) renderGitHubTOML(yaml *strings.Builder, githu
 
 		envVars["GITHUB_TOOLSETS"] = toolsets
 
+		if access.Tools != "" {
+			envVars["GITHUB_TOOLS"] = access.Tools
+		}
+
 		// Write environment variables in sorted order for deterministic output
 		envKeys := make([]string, 0, len(envVars))
 		for key := range envVars {
@@ -663,6 +678,8 @@ type GitHubMCPDockerOptions struct {
 	LockdownFromStep bool
 	// Toolsets specifies the GitHub toolsets to enable
 	Toolsets string
+	// Tools specifies individual tools to enable in addition to Toolsets (used for read-only toolsets)
+	Tools string
 	// DockerImageVersion specifies the GitHub MCP server Docker image version
 	DockerImageVersion string
 	// CustomArgs are additional arguments to append to the Docker command
@@ -692,7 +709,7 @@ func RenderGitHubMCPDockerConfig(yaml *strings.Builder, options GitHubMCPDockerO
 	}
 
 	// MCP Gateway spec fields for containerized stdio servers
-	yaml.WriteString("                \"container\": \"ghcr.io/github/github-mcp-server:" + options.DockerImageVersion + "\",\n")
+	yaml.WriteString("                \"container\": \"" + parser.DockerImageReference("ghcr.io/github/github-mcp-server", options.DockerImageVersion) + "\",\n")
 
 	// Append custom args if present (these are Docker runtime args, go before container image)
 	if len(options.CustomArgs) > 0 {
@@ -754,6 +771,11 @@ func RenderGitHubMCPDockerConfig(yaml *strings.Builder, options GitHubMCPDockerO
 	// Toolsets (always configured, defaults to "default")
 	envVars["GITHUB_TOOLSETS"] = options.Toolsets
 
+	// Individual tools (read-only tools of the read-only toolsets)
+	if options.Tools != "" {
+		envVars["GITHUB_TOOLS"] = options.Tools
+	}
+
 	// Write environment variables in sorted order for deterministic output
 	envKeys := make([]string, 0, len(envVars))
 	for key := range envVars {
@@ -783,6 +805,8 @@ type GitHubMCPRemoteOptions struct {
 	LockdownFromStep bool
 	// Toolsets specifies the GitHub toolsets to enable
 	Toolsets string
+	// Tools specifies individual tools to enable in addition to Toolsets (used for read-only toolsets)
+	Tools string
 	// AuthorizationValue is the value for the Authorization header
 	// For Claude: "Bearer {effectiveToken}"
 	// For Copilot: "Bearer \\${GITHUB_PERSONAL_ACCESS_TOKEN}"
@@ -831,6 +855,11 @@ func RenderGitHubMCPRemoteConfig(yaml *strings.Builder, options GitHubMCPRemoteO
 		headers["X-MCP-Toolsets"] = options.Toolsets
 	}
 
+	// Add X-MCP-Tools header for the read-only tools of the read-only toolsets
+	if options.Tools != "" {
+		headers["X-MCP-Tools"] = options.Tools
+	}
+
 	// Write headers using helper
 	writeHeadersToYAML(yaml, headers, "                  ")
 
@@ -0,0 +1,171 @@
+// # MCP Secret Redaction
+//
+// This file provides redaction of secret-looking MCP server header and env values.
+//
+// MCP server configurations frequently carry tokens in headers (e.g. "Authorization")
+// or env entries (e.g. "DD_API_KEY"). Two safeguards keep them out of logs:
+//
+//   - Compiler output: RedactMCPSecretValues replaces secret-looking values with "***"
+//     before any MCP configuration is printed (verbose compile, mcp inspect).
+//   - Generated workflow: generateMCPSecretMasks emits ::add-mask:: commands for literal
+//     credential-shaped values before the MCP gateway echoes its configuration.
+//
+// For compiler output a value is considered secret-looking when it references secrets.*,
+// when its key names a credential (token, secret, password, api key, auth, ...), or when
+// the value itself has a credential shape (Bearer/Basic/token schemes, well-known token
+// prefixes). Masks are only emitted for credential-shaped values: masking by key name
+// would register ordinary values such as "/tmp" or "none" and hide them everywhere in
+// the job log.
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var mcpSecretRedactionLog = logger.New("workflow:mcp_secret_redaction")
+
+// redactedMCPValue replaces secret-looking values in compiler output
+const redactedMCPValue = "***"
+
+// secretLikeKeyPattern matches header or env names that usually carry credentials
+var secretLikeKeyPattern = regexp.MustCompile(`(?i)(token|secret|password|passwd|api[-_]?key|auth|credential|private[-_]?key|cookie|session)`)
+
+// secretLikeValuePattern matches values shaped like credentials regardless of their key
+var secretLikeValuePattern = regexp.MustCompile(`(?i)^((bearer|basic|token)\s+\S+|ghp_|gho_|ghs_|ghu_|github_pat_|sk-|xox[abpr]-)`)
+
+// isSecretLikeMCPValue reports whether an MCP header or env value should be treated as a secret
+func isSecretLikeMCPValue(key, value string) bool {
+	if value == "" {
+		return false
+	}
+	if strings.Contains(value, "secrets.") {
+		return true
+	}
+	return secretLikeKeyPattern.MatchString(key) || isCredentialShapedMCPValue(value)
+}
+
+// isCredentialShapedMCPValue reports whether a literal MCP header or env value has the
+// shape of a credential, independently of the key it is stored under
+func isCredentialShapedMCPValue(value string) bool {
+	return secretLikeValuePattern.MatchString(value)
+}
+
+// RedactMCPSecretValues returns a copy of an MCP header or env map in which every
+// secret-looking value is replaced with "***", for use wherever configuration is printed.
+func RedactMCPSecretValues(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(values))
+	for key, value := range values {
+		if isSecretLikeMCPValue(key, value) {
+			redacted[key] = redactedMCPValue
+		} else {
+			redacted[key] = value
+		}
+	}
+	return redacted
+}
+
+// collectMCPSecretMaskValues returns the sorted, de-duplicated literal credential-shaped
+// header and env values of custom MCP servers. Values containing ${{ }} expressions or
+// ${VAR} references are skipped: they are resolved at runtime from step env, where GitHub
+// Actions already masks secrets, and the expression text itself is not sensitive.
+func collectMCPSecretMaskValues(tools map[string]any) []string {
+	seen := make(map[string]bool)
+	for toolName, toolValue := range tools {
+		toolConfig, ok := toolValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, _ := hasMCPConfig(toolConfig); !hasMcp {
+			continue
+		}
+		mcpConfig, err := getMCPConfig(toolConfig, toolName)
+		if err != nil {
+			mcpSecretRedactionLog.Printf("Skipping masks for tool %s: %v", toolName, err)
+			continue
+		}
+		for _, values := range []map[string]string{mcpConfig.Headers, mcpConfig.Env} {
+			for _, value := range values {
+				if strings.Contains(value, "${") || !isCredentialShapedMCPValue(value) {
+					continue
+				}
+				seen[value] = true
+			}
+		}
+	}
+
+	maskValues := sliceutil.MapToSlice(seen)
+	sort.Strings(maskValues)
+	mcpSecretRedactionLog.Printf("Collected %d literal MCP secret values to mask", len(maskValues))
+	return maskValues
+}
+
+// generateMCPSecretMasks writes ::add-mask:: commands for literal credential-shaped MCP header
+// and env values so they are masked before the gateway prints its configuration
+func generateMCPSecretMasks(yaml *strings.Builder, tools map[string]any) {
+	maskValues := collectMCPSecretMaskValues(tools)
+	if len(maskValues) == 0 {
+		return
+	}
+	yaml.WriteString("          # Mask literal MCP server credentials before the configuration is logged\n")
+	for _, value := range maskValues {
+		yaml.WriteString("          echo " + shellDoubleQuoteArg("::add-mask::"+value) + "\n")
+	}
+}
+
+// printMCPServerConfigs prints the custom MCP server configurations in verbose mode
+// with secret-looking header and env values redacted
+func printMCPServerConfigs(tools map[string]any, mcpTools []string) {
+	for _, toolName := range mcpTools {
+		toolConfig, ok := tools[toolName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, _ := hasMCPConfig(toolConfig); !hasMcp {
+			continue
+		}
+		mcpConfig, err := getMCPConfig(toolConfig, toolName)
+		if err != nil {
+			continue
+		}
+
+		details := []string{"type=" + mcpConfig.Type}
+		if mcpConfig.URL != "" {
+			details = append(details, "url="+mcpConfig.URL)
+		}
+		if mcpConfig.Container != "" {
+			details = append(details, "container="+mcpConfig.Container)
+		}
+		if mcpConfig.Command != "" {
+			details = append(details, "command="+mcpConfig.Command)
+		}
+		if len(mcpConfig.Headers) > 0 {
+			details = append(details, "headers="+formatMCPSecretValues(RedactMCPSecretValues(mcpConfig.Headers)))
+		}
+		if len(mcpConfig.Env) > 0 {
+			details = append(details, "env="+formatMCPSecretValues(RedactMCPSecretValues(mcpConfig.Env)))
+		}
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("MCP server '%s': %s", toolName, strings.Join(details, ", "))))
+	}
+}
+
+// formatMCPSecretValues renders a header or env map as sorted KEY=VALUE pairs
+func formatMCPSecretValues(values map[string]string) string {
+	keys := sliceutil.MapToSlice(values)
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+values[key])
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
@@ -0,0 +1,152 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactMCPSecretValues(t *testing.T) {
+	input := map[string]string{
+		"Authorization": "Bearer token123",
+		"X-Custom":      "Token abcdef",
+		"DD_API_KEY":    "${{ secrets.DD_API_KEY }}",
+		"DD_SITE":       "datadoghq.com",
+		"API_URL":       "${API_URL:-https://default.example.com}",
+		"GITHUB_PAT":    "ghp_abcdefghijklmnop",
+		"SERVICE_NAME":  "sk-not-a-name",
+		"LOG_LEVEL":     "debug",
+		"EMPTY_TOKEN":   "",
+	}
+
+	expected := map[string]string{
+		"Authorization": "***",
+		"X-Custom":      "***",
+		"DD_API_KEY":    "***",
+		"DD_SITE":       "datadoghq.com",
+		"API_URL":       "${API_URL:-https://default.example.com}",
+		"GITHUB_PAT":    "***",
+		"SERVICE_NAME":  "***",
+		"LOG_LEVEL":     "debug",
+		"EMPTY_TOKEN":   "",
+	}
+
+	assert.Equal(t, expected, RedactMCPSecretValues(input), "secret-looking values should be redacted")
+	assert.Equal(t, "Bearer token123", input["Authorization"], "input map should not be modified")
+	assert.Nil(t, RedactMCPSecretValues(nil), "nil map should stay nil")
+}
+
+func TestCollectMCPSecretMaskValues(t *testing.T) {
+	tools := map[string]any{
+		"datadog": map[string]any{
+			"type": "http",
+			"url":  "https://mcp.datadoghq.com/api/unstable/mcp-server/mcp",
+			"headers": map[string]any{
+				"Authorization": "Bearer token123",
+				"DD_API_KEY":    "${{ secrets.DD_API_KEY }}",
+				"DD_SITE":       "datadoghq.com",
+			},
+		},
+		"local": map[string]any{
+			"command": "node",
+			"args":    []any{"server.js"},
+			"env": map[string]any{
+				"SERVICE_TOKEN": "ghp_abcdefghijklmnop",
+				"SESSION_DIR":   "/tmp",
+				"AUTH_MODE":     "none",
+				"API_URL":       "${API_URL:-https://default.example.com}",
+			},
+		},
+		"github": map[string]any{},
+	}
+
+	assert.Equal(t, []string{"Bearer token123", "ghp_abcdefghijklmnop"}, collectMCPSecretMaskValues(tools),
+		"only literal credential-shaped values should be masked, regardless of key names")
+}
+
+func TestGenerateMCPSecretMasksEscapesValues(t *testing.T) {
+	tools := map[string]any{
+		"custom": map[string]any{
+			"type":    "http",
+			"url":     "https://example.com/mcp",
+			"headers": map[string]any{"Authorization": "Bearer a$b\"c"},
+		},
+	}
+
+	var yaml strings.Builder
+	generateMCPSecretMasks(&yaml, tools)
+
+	assert.Contains(t, yaml.String(), `echo "::add-mask::Bearer a\$b\"c"`, "mask value should be shell-escaped")
+}
+
+func TestVerboseCompileRedactsMCPHeaders(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "mcp-redaction-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+network:
+  allowed:
+    - defaults
+    - mcp.datadoghq.com
+mcp-servers:
+  datadog:
+    type: http
+    url: "https://mcp.datadoghq.com/api/unstable/mcp-server/mcp"
+    headers:
+      Authorization: "Bearer token123"
+      DD_API_KEY: "${{ secrets.DD_API_KEY }}"
+    allowed: ["*"]
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	oldStderr := os.Stderr
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stderr = w
+
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(&buf, r)
+		close(done)
+	}()
+
+	compiler := NewCompiler(WithVerbose(true))
+	compileErr := compiler.CompileWorkflow(testFile)
+
+	w.Close()
+	os.Stderr = oldStderr
+	<-done
+	require.NoError(t, compileErr)
+
+	stderrOutput := buf.String()
+	assert.Contains(t, stderrOutput, "MCP server 'datadog'", "verbose output should describe the MCP server")
+	assert.Contains(t, stderrOutput, "Authorization=***", "Authorization header should be redacted")
+	assert.Contains(t, stderrOutput, "DD_API_KEY=***", "secret references should be redacted")
+	assert.NotContains(t, stderrOutput, "token123", "header value must never appear unredacted in verbose output")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+	lockStr := string(lockContent)
+	maskIdx := strings.Index(lockStr, `echo "::add-mask::Bearer token123"`)
+	require.Greater(t, maskIdx, -1, "lock file should mask the literal header value")
+	gatewayIdx := strings.Index(lockStr, "start_mcp_gateway.sh")
+	require.Greater(t, gatewayIdx, -1, "lock file should start the MCP gateway")
+	assert.Less(t, maskIdx, gatewayIdx, "mask should be emitted before the gateway logs its configuration")
+}
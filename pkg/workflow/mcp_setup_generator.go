@@ -77,41 +77,13 @@ var mcpSetupGeneratorLog = logger.New("workflow:mcp_setup_generator")
 // generateMCPSetup generates the MCP server configuration setup
 func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any, engine CodingAgentEngine, workflowData *WorkflowData) error {
 	mcpSetupGeneratorLog.Print("Generating MCP server configuration setup")
-	// Collect tools that need MCP server configuration
-	var mcpTools []string
-
 	// Check if workflowData is valid before accessing its fields
 	if workflowData == nil {
 		return nil
 	}
 
-	workflowTools := workflowData.Tools
-
-	for toolName, toolValue := range workflowTools {
-		// Skip if the tool is explicitly disabled (set to false)
-		if toolValue == false {
-			continue
-		}
-		// Standard MCP tools
-		if toolName == "github" || toolName == "playwright" || toolName == "serena" || toolName == "cache-memory" || toolName == "agentic-workflows" {
-			mcpTools = append(mcpTools, toolName)
-		} else if mcpConfig, ok := toolValue.(map[string]any); ok {
-			// Check if it's explicitly marked as MCP type in the new format
-			if hasMcp, _ := hasMCPConfig(mcpConfig); hasMcp {
-				mcpTools = append(mcpTools, toolName)
-			}
-		}
-	}
-
-	// Check if safe-outputs is enabled and add to MCP tools
-	if HasSafeOutputsEnabled(workflowData.SafeOutputs) {
-		mcpTools = append(mcpTools, "safe-outputs")
-	}
-
-	// Check if safe-inputs is configured and feature flag is enabled, add to MCP tools
-	if IsSafeInputsEnabled(workflowData.SafeInputs, workflowData) {
-		mcpTools = append(mcpTools, "safe-inputs")
-	}
+	// Collect tools that need MCP server configuration
+	mcpTools := collectMCPServerNames(workflowData)
 
 	// Populate dispatch-workflow file mappings before generating config
 	// This ensures workflow_files is available in the config.json
@@ -123,13 +95,14 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 		safeOutputConfig = generateSafeOutputsConfig(workflowData)
 	}
 
-	// Sort tools to ensure stable code generation
-	sort.Strings(mcpTools)
-
 	if mcpSetupGeneratorLog.Enabled() {
 		mcpSetupGeneratorLog.Printf("Collected %d MCP tools: %v", len(mcpTools), mcpTools)
 	}
 
+	if c.verbose {
+		printMCPServerConfigs(tools, mcpTools)
+	}
+
 	// Ensure MCP gateway config has defaults set before collecting Docker images
 	ensureDefaultMCPGatewayConfig(workflowData)
 
@@ -233,7 +206,7 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 				}
 			}
 		}
-		validationConfigJSON, err := GetValidationConfigJSON(enabledTypes)
+		validationConfigJSON, err := getWorkflowValidationConfigJSON(enabledTypes, workflowData.SafeOutputs)
 		if err != nil {
 			// Log error prominently - validation config is critical for safe output processing
 			// The error will be caught at compile time if this ever fails
@@ -439,6 +412,9 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 		generateSerenaLocalModeSteps(yaml)
 	}
 
+	// Fetch fresh bearer tokens for HTTP MCP servers that authenticate with OAuth client credentials
+	generateMCPOAuthTokenSteps(yaml, tools)
+
 	// The MCP gateway is always enabled, even when agent sandbox is disabled
 	// Use the engine's RenderMCPConfig method
 	yaml.WriteString("      - name: Start MCP Gateway\n")
@@ -478,14 +454,7 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 		port = int(DefaultMCPGatewayPort)
 	}
 
-	domain := gatewayConfig.Domain
-	if domain == "" {
-		if workflowData.SandboxConfig.Agent != nil && workflowData.SandboxConfig.Agent.Disabled {
-			domain = "localhost"
-		} else {
-			domain = "host.docker.internal"
-		}
-	}
+	domain := mcpGatewayDomain(workflowData)
 
 	apiKey := gatewayConfig.APIKey
 
@@ -505,6 +474,15 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 		yaml.WriteString("          echo \"::add-mask::${MCP_GATEWAY_API_KEY}\"\n")
 	}
 
+	// Mask literal MCP credentials before start_mcp_gateway.sh echoes the configuration
+	generateMCPSecretMasks(yaml, tools)
+
+	// Export per-server startup health checks for check_mcp_servers.sh
+	generateMCPHealthChecks(yaml, tools)
+
+	// Create pinned MCP log directories and start log rotation
+	generateMCPLogSetup(yaml, workflowData, tools)
+
 	// Export payload directory and ensure it exists
 	payloadDir := gatewayConfig.PayloadDir
 	if payloadDir == "" {
@@ -560,6 +538,11 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 	// These are needed because awmg v0.0.12+ validates and resolves ${VAR} patterns at config load time
 	// Environment variables used by MCP gateway
 	containerCmd.WriteString(" -e MCP_GATEWAY_LOG_DIR")
+	// Log rotation policy, so the gateway and its servers can size their own logs
+	rotationEnv := mcpLogRotationEnvNames(workflowData)
+	for _, envVarName := range rotationEnv {
+		containerCmd.WriteString(" -e " + envVarName)
+	}
 	// Environment variables used by safeoutputs MCP server
 	containerCmd.WriteString(" -e GH_AW_MCP_LOG_DIR")
 	containerCmd.WriteString(" -e GH_AW_SAFE_OUTPUTS")
@@ -569,6 +552,15 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 	containerCmd.WriteString(" -e GH_AW_ASSETS_MAX_SIZE_KB")
 	containerCmd.WriteString(" -e GH_AW_ASSETS_ALLOWED_EXTS")
 	containerCmd.WriteString(" -e DEFAULT_BRANCH")
+	if getMaxPatchBytesEnv(workflowData.SafeOutputs) > 0 {
+		containerCmd.WriteString(" -e GH_AW_MAX_PATCH_BYTES")
+	}
+	if getPatchBaseEnv(workflowData.SafeOutputs) != "" {
+		containerCmd.WriteString(" -e GH_AW_PATCH_BASE")
+	}
+	if getPatchExcludePathsEnv(workflowData.SafeOutputs) != "" {
+		containerCmd.WriteString(" -e GH_AW_PATCH_EXCLUDE_PATHS")
+	}
 	// Environment variables used by GitHub MCP server
 	containerCmd.WriteString(" -e GITHUB_MCP_SERVER_TOKEN")
 	// For Copilot engine with GitHub remote MCP, also pass GITHUB_PERSONAL_ACCESS_TOKEN
@@ -639,7 +631,8 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 			"MCP_GATEWAY_LOG_DIR", "GH_AW_MCP_LOG_DIR", "GH_AW_SAFE_OUTPUTS",
 			"GH_AW_SAFE_OUTPUTS_CONFIG_PATH", "GH_AW_SAFE_OUTPUTS_TOOLS_PATH",
 			"GH_AW_ASSETS_BRANCH", "GH_AW_ASSETS_MAX_SIZE_KB", "GH_AW_ASSETS_ALLOWED_EXTS",
-			"DEFAULT_BRANCH", "GITHUB_MCP_SERVER_TOKEN", "GITHUB_MCP_LOCKDOWN",
+			"DEFAULT_BRANCH", "GH_AW_MAX_PATCH_BYTES", "GH_AW_PATCH_BASE", "GH_AW_PATCH_EXCLUDE_PATHS",
+			"GITHUB_MCP_SERVER_TOKEN", "GITHUB_MCP_LOCKDOWN",
 			"GITHUB_REPOSITORY", "GITHUB_SERVER_URL", "GITHUB_SHA", "GITHUB_WORKSPACE",
 			"GITHUB_TOKEN", "GITHUB_RUN_ID", "GITHUB_RUN_NUMBER", "GITHUB_RUN_ATTEMPT",
 			"GITHUB_JOB", "GITHUB_ACTION", "GITHUB_EVENT_NAME", "GITHUB_EVENT_PATH",
@@ -663,6 +656,9 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 			addedEnvVars["GH_AW_SAFE_OUTPUTS_PORT"] = true
 			addedEnvVars["GH_AW_SAFE_OUTPUTS_API_KEY"] = true
 		}
+		for _, envVarName := range rotationEnv {
+			addedEnvVars[envVarName] = true
+		}
 
 		// Mark gateway config environment variables as added
 		if len(gatewayConfig.Env) > 0 {
@@ -731,3 +727,51 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 	// The MCP gateway is always enabled, even when agent sandbox is disabled
 	return engine.RenderMCPConfig(yaml, tools, mcpTools, workflowData)
 }
+
+// mcpGatewayDomain returns the domain agents use to reach the MCP gateway: the configured
+// domain, localhost when the agent sandbox is disabled, or host.docker.internal otherwise.
+func mcpGatewayDomain(workflowData *WorkflowData) string {
+	if workflowData.SandboxConfig != nil && workflowData.SandboxConfig.MCP != nil && workflowData.SandboxConfig.MCP.Domain != "" {
+		return workflowData.SandboxConfig.MCP.Domain
+	}
+	if workflowData.SandboxConfig != nil && workflowData.SandboxConfig.Agent != nil && workflowData.SandboxConfig.Agent.Disabled {
+		return "localhost"
+	}
+	return "host.docker.internal"
+}
+
+// collectMCPServerNames returns the sorted names of the MCP servers configured for the agent,
+// including the built-in safe-outputs and safe-inputs servers when they are enabled
+func collectMCPServerNames(workflowData *WorkflowData) []string {
+	var mcpTools []string
+
+	for toolName, toolValue := range workflowData.Tools {
+		// Skip if the tool is explicitly disabled (set to false)
+		if toolValue == false {
+			continue
+		}
+		// Standard MCP tools
+		if toolName == "github" || toolName == "playwright" || toolName == "serena" || toolName == "cache-memory" || toolName == "agentic-workflows" {
+			mcpTools = append(mcpTools, toolName)
+		} else if mcpConfig, ok := toolValue.(map[string]any); ok {
+			// Check if it's explicitly marked as MCP type in the new format
+			if hasMcp, _ := hasMCPConfig(mcpConfig); hasMcp {
+				mcpTools = append(mcpTools, toolName)
+			}
+		}
+	}
+
+	// Check if safe-outputs is enabled and add to MCP tools
+	if HasSafeOutputsEnabled(workflowData.SafeOutputs) {
+		mcpTools = append(mcpTools, "safe-outputs")
+	}
+
+	// Check if safe-inputs is configured and feature flag is enabled, add to MCP tools
+	if IsSafeInputsEnabled(workflowData.SafeInputs, workflowData) {
+		mcpTools = append(mcpTools, "safe-inputs")
+	}
+
+	// Sort tools to ensure stable code generation
+	sort.Strings(mcpTools)
+	return mcpTools
+}
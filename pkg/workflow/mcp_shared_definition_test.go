@@ -0,0 +1,71 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSharedMCPWorkflow writes a workflow using a shared MCP server definition and returns its path
+func writeSharedMCPWorkflow(t *testing.T, definition string) string {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "mcp-shared-definition-test")
+	if definition != "" {
+		sharedDir := filepath.Join(tmpDir, "shared")
+		require.NoError(t, os.MkdirAll(sharedDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "myserver.mcp.json"), []byte(definition), 0644))
+	}
+
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+mcp-servers:
+  myserver:
+    $ref: shared/myserver.mcp.json
+    allowed: ["search"]
+---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+	return testFile
+}
+
+func TestCompileSharedMCPServerDefinition(t *testing.T) {
+	testFile := writeSharedMCPWorkflow(t, `{"command": "npx", "args": ["-y", "@myorg/myserver"], "allowed": ["*"]}`)
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "workflow with a valid $ref should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+	lockStr := string(lockContent)
+	assert.Contains(t, lockStr, "@myorg/myserver", "shared definition should be inlined into the MCP config")
+	assert.Contains(t, lockStr, "#     - shared/myserver.mcp.json", "shared definition should be listed in the lock file manifest")
+	assert.NotContains(t, lockStr, "$ref", "the $ref key should not leak into the generated workflow")
+}
+
+func TestCompileSharedMCPServerDefinitionMissingFile(t *testing.T) {
+	testFile := writeSharedMCPWorkflow(t, "")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "a $ref to a missing file should fail compilation")
+	assert.Contains(t, err.Error(), "shared MCP server definition 'shared/myserver.mcp.json' not found")
+}
+
+func TestCompileSharedMCPServerDefinitionMalformedJSON(t *testing.T) {
+	testFile := writeSharedMCPWorkflow(t, `{"command": "npx",`)
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "a $ref to malformed JSON should fail compilation")
+	assert.Contains(t, err.Error(), "is not valid JSON")
+}
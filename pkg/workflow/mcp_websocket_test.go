@@ -0,0 +1,93 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateMCPRequirementsWebSocket tests that WebSocket MCP servers are rejected, since the
+// MCP gateway only accepts stdio and http servers
+func TestValidateMCPRequirementsWebSocket(t *testing.T) {
+	tests := []struct {
+		name      string
+		mcpConfig map[string]any
+	}{
+		{
+			name:      "websocket type",
+			mcpConfig: map[string]any{"type": "websocket", "url": "wss://mcp.example.com/ws"},
+		},
+		{
+			name:      "ws alias",
+			mcpConfig: map[string]any{"type": "ws", "url": "ws://localhost:9000"},
+		},
+		{
+			name:      "wss alias",
+			mcpConfig: map[string]any{"type": "wss", "url": "wss://mcp.example.com/ws"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMCPRequirements("ws-tool", tt.mcpConfig, tt.mcpConfig)
+			require.Error(t, err, "WebSocket MCP server should be rejected")
+			assert.Contains(t, err.Error(), "type 'websocket' is not supported", "Error should name the unsupported transport")
+			assert.Contains(t, err.Error(), "only accepts stdio and http servers", "Error should explain the gateway limitation")
+			assert.Contains(t, err.Error(), "type: http", "Error should suggest the HTTP transport")
+		})
+	}
+}
+
+// TestExtractHTTPMCPDomainsIgnoresWebSocket tests that WebSocket MCP servers are not treated as HTTP servers
+func TestExtractHTTPMCPDomainsIgnoresWebSocket(t *testing.T) {
+	tools := map[string]any{
+		"ws-tool": map[string]any{
+			"type": "wss",
+			"url":  "wss://mcp.example.com:8443/ws",
+		},
+	}
+
+	assert.Empty(t, extractHTTPMCPDomains(tools), "WebSocket MCP host should not be allowed as an HTTP MCP server")
+}
+
+// TestCompileWorkflowWithWebSocketMCP tests that compiling a workflow with a WebSocket MCP server fails
+func TestCompileWorkflowWithWebSocketMCP(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "websocket-mcp-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: claude
+mcp-servers:
+  realtime:
+    type: websocket
+    url: "wss://mcp.example.com/ws"
+    headers:
+      Authorization: "Bearer ${{ secrets.REALTIME_TOKEN }}"
+    allowed: ["*"]
+---
+
+# WebSocket MCP
+
+Use the realtime MCP server.
+`
+
+	testFile := filepath.Join(tmpDir, "websocket-mcp.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	compiler := NewCompiler()
+	err := compiler.CompileWorkflow(testFile)
+	require.Error(t, err, "Workflow with a websocket MCP server should not compile")
+	assert.Contains(t, err.Error(), "type 'websocket' is not supported", "Error should name the unsupported transport")
+
+	_, statErr := os.Stat(stringutil.MarkdownToLockFile(testFile))
+	assert.True(t, os.IsNotExist(statErr), "No lock file should be written")
+}
@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var networkAllowlistFileLog = logger.New("workflow:network_allowlist_file")
+
+// Shared network allowlist files
+//
+// With network.allowed-from, a workflow loads allowlist entries from a file shared by the
+// workflows of a repository, such as .github/aw/network-allowlist.txt. The path is resolved
+// relative to the repository root. The file holds one domain or ecosystem identifier per line;
+// blank lines and lines starting with '#' are ignored. Its entries are merged with the inline
+// network.allowed list before strict mode validation, so the same rules apply to both.
+
+// mergeNetworkAllowedFrom merges the entries of the network.allowed-from file into the allowed
+// list, deduplicated. It returns the path of the file relative to
+// markdownDir so it can be tracked as an include dependency.
+func mergeNetworkAllowedFrom(permissions *NetworkPermissions, markdownDir string) (string, error) {
+	if permissions == nil || permissions.AllowedFrom == "" {
+		return "", nil
+	}
+
+	relPath := filepath.FromSlash(permissions.AllowedFrom)
+	if !filepath.IsLocal(relPath) {
+		return "", fmt.Errorf("network.allowed-from must be a path relative to the repository root, got '%s'", permissions.AllowedFrom)
+	}
+
+	repoRoot := repositoryRootForMarkdownDir(markdownDir)
+	fullPath := filepath.Join(repoRoot, relPath)
+	entries, err := readNetworkAllowlistFile(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("network.allowed-from file '%s' not found (looked in %s)", permissions.AllowedFrom, fullPath)
+		}
+		return "", fmt.Errorf("failed to read network.allowed-from file '%s': %w", permissions.AllowedFrom, err)
+	}
+
+	// Localhost entries are added when the firewall configuration is rendered, so only
+	// deduplicate here to keep the merged list valid for domain validation
+	permissions.Allowed = sliceutil.Deduplicate(append(permissions.Allowed, entries...))
+	networkAllowlistFileLog.Printf("Merged %d entries from %s, %d allowed entries in total", len(entries), fullPath, len(permissions.Allowed))
+
+	includePath, err := filepath.Rel(markdownDir, fullPath)
+	if err != nil {
+		includePath = fullPath
+	}
+	return filepath.ToSlash(includePath), nil
+}
+
+// readNetworkAllowlistFile returns the entries of an allowlist file, skipping blank lines and comments
+func readNetworkAllowlistFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, strings.ToLower(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// repositoryRootForMarkdownDir returns the directory containing the .github folder that
+// markdownDir is in, or markdownDir itself when it is not inside a .github folder
+func repositoryRootForMarkdownDir(markdownDir string) string {
+	dir := filepath.Clean(markdownDir)
+	for {
+		if filepath.Base(dir) == ".github" {
+			return filepath.Dir(dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return markdownDir
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,142 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupAllowlistRepo creates a repository layout with a shared network allowlist file
+// and returns the repository root and the workflows directory
+func setupAllowlistRepo(t *testing.T, allowlist string) (string, string) {
+	t.Helper()
+	repoRoot := testutil.TempDir(t, "network-allowlist-test")
+	workflowsDir := filepath.Join(repoRoot, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Should create workflows directory")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoRoot, ".github", "aw"), 0755), "Should create aw directory")
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".github", "aw", "network-allowlist.txt"), []byte(allowlist), 0644), "Should write allowlist")
+	return repoRoot, workflowsDir
+}
+
+func TestMergeNetworkAllowedFrom(t *testing.T) {
+	_, workflowsDir := setupAllowlistRepo(t, `# Corporate allowlist
+corp.example.com
+Artifacts.Example.com
+
+python
+  github
+`)
+
+	permissions := &NetworkPermissions{
+		Allowed:     []string{"github", "corp.example.com", "api.example.org"},
+		AllowedFrom: ".github/aw/network-allowlist.txt",
+	}
+	includePath, err := mergeNetworkAllowedFrom(permissions, workflowsDir)
+	require.NoError(t, err, "Allowlist file should be merged")
+
+	assert.Equal(t, "../aw/network-allowlist.txt", includePath, "Allowlist should be tracked relative to the workflow directory")
+	assert.Equal(t, []string{
+		"github", "corp.example.com", "api.example.org", "artifacts.example.com", "python",
+	}, permissions.Allowed, "Inline and file entries should be merged and deduplicated")
+}
+
+func TestMergeNetworkAllowedFromErrors(t *testing.T) {
+	_, workflowsDir := setupAllowlistRepo(t, "corp.example.com\n")
+
+	tests := []struct {
+		name        string
+		allowedFrom string
+		errorMsg    string
+	}{
+		{
+			name:        "missing file",
+			allowedFrom: ".github/aw/missing.txt",
+			errorMsg:    "network.allowed-from file '.github/aw/missing.txt' not found",
+		},
+		{
+			name:        "path outside the repository",
+			allowedFrom: "../allowlist.txt",
+			errorMsg:    "network.allowed-from must be a path relative to the repository root",
+		},
+		{
+			name:        "absolute path",
+			allowedFrom: "/etc/allowlist.txt",
+			errorMsg:    "network.allowed-from must be a path relative to the repository root",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissions := &NetworkPermissions{AllowedFrom: tt.allowedFrom}
+			_, err := mergeNetworkAllowedFrom(permissions, workflowsDir)
+			require.Error(t, err, "Expected an error")
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestCompileWorkflowWithNetworkAllowedFrom(t *testing.T) {
+	_, workflowsDir := setupAllowlistRepo(t, "corp.example.com\nartifacts.example.com\n")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+network:
+  allowed:
+    - github
+  allowed-from: .github/aw/network-allowlist.txt
+---
+
+# Corporate Workflow
+
+Use the corporate services.
+`
+	testFile := filepath.Join(workflowsDir, "allowlist.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	compiler := NewCompiler()
+	workflowData, err := compiler.ParseWorkflowFile(testFile)
+	require.NoError(t, err, "Workflow with allowed-from should parse")
+	assert.Contains(t, workflowData.NetworkPermissions.Allowed, "corp.example.com")
+	assert.Contains(t, workflowData.NetworkPermissions.Allowed, "artifacts.example.com")
+	assert.Contains(t, workflowData.NetworkPermissions.Allowed, "github")
+	assert.Contains(t, workflowData.IncludedFiles, "../aw/network-allowlist.txt", "Allowlist should be tracked as an include dependency")
+
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow with allowed-from should compile")
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	assert.Contains(t, string(lockContent), "corp.example.com", "Allowlist domains should reach the firewall configuration")
+}
+
+func TestCompileWorkflowWithNetworkAllowedFromStrictMode(t *testing.T) {
+	_, workflowsDir := setupAllowlistRepo(t, "corp.example.com\n*\n")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+network:
+  allowed-from: .github/aw/network-allowlist.txt
+---
+
+# Corporate Workflow
+
+Use the corporate services.
+`
+	testFile := filepath.Join(workflowsDir, "allowlist-strict.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Strict mode should reject a wildcard loaded from the allowlist file")
+	assert.Contains(t, err.Error(), "wildcard '*' is not allowed")
+}
@@ -0,0 +1,76 @@
+package workflow
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var networkCIDRLog = logger.New("workflow:network_cidr")
+
+// CIDR ranges in network allowlists
+//
+// network.allowed accepts IP ranges in CIDR notation (e.g. 10.0.0.0/8, 192.168.1.0/24,
+// fd00::/8) next to domains and ecosystem identifiers. CIDR entries are validated at
+// compile time and passed to the firewall unchanged, together with the allowed domains.
+
+// isCIDREntry reports whether a network.allowed entry is written in CIDR notation
+func isCIDREntry(entry string) bool {
+	return strings.Contains(entry, "/") && !strings.Contains(entry, "://")
+}
+
+// isAllAddressesCIDR reports whether a CIDR entry covers every address (0.0.0.0/0 or ::/0)
+func isAllAddressesCIDR(entry string) bool {
+	_, ipNet, err := net.ParseCIDR(entry)
+	if err != nil {
+		return false
+	}
+	ones, _ := ipNet.Mask.Size()
+	return ones == 0
+}
+
+// validateCIDR validates a CIDR entry from network.allowed. The address must be the
+// network address of the range so that the allowed range is unambiguous.
+func validateCIDR(entry string) error {
+	ip, ipNet, err := net.ParseCIDR(entry)
+	if err != nil {
+		networkCIDRLog.Printf("Invalid CIDR %q: %v", entry, err)
+		reason, suggestion := describeInvalidCIDR(entry)
+		return NewValidationError("network.allowed", entry, reason, suggestion)
+	}
+
+	if !ip.Equal(ipNet.IP) {
+		networkCIDRLog.Printf("CIDR %q has host bits set, network address is %s", entry, ipNet.String())
+		return NewValidationError(
+			"network.allowed",
+			entry,
+			"IP range has host bits set",
+			fmt.Sprintf("Use the network address of the range: '%s'", ipNet.String()),
+		)
+	}
+
+	return nil
+}
+
+// describeInvalidCIDR explains why an entry containing '/' is not a valid IP range: the
+// part before the slash is a host name, an invalid IP address, or the prefix length is wrong
+func describeInvalidCIDR(entry string) (reason, suggestion string) {
+	const cidrExamples = "Use CIDR notation with a valid address and prefix length. Examples:\n  - '10.0.0.0/8'\n  - '192.168.1.0/24'\n  - 'fd00::/8'"
+
+	address, prefix, _ := strings.Cut(entry, "/")
+	if net.ParseIP(address) == nil {
+		// IPv6 addresses contain hex letters too, but always contain a colon
+		if strings.ContainsAny(address, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") && !strings.Contains(address, ":") {
+			return "entries cannot contain a path; '/' is only allowed in IP ranges",
+				fmt.Sprintf("List the domain without the path: '%s'", address)
+		}
+		return fmt.Sprintf("'%s' is not a valid IP address", address), cidrExamples
+	}
+	maxPrefix := 32
+	if net.ParseIP(address).To4() == nil {
+		maxPrefix = 128
+	}
+	return fmt.Sprintf("prefix length '%s' must be a number from 0 to %d", prefix, maxPrefix), cidrExamples
+}
@@ -0,0 +1,116 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCIDR(t *testing.T) {
+	tests := []struct {
+		name        string
+		entry       string
+		errContains string
+	}{
+		{name: "class A private range", entry: "10.0.0.0/8"},
+		{name: "class C private range", entry: "192.168.1.0/24"},
+		{name: "single host", entry: "172.16.5.4/32"},
+		{name: "IPv6 range", entry: "fd00::/8"},
+		{name: "invalid octet", entry: "10.0.0.300/8", errContains: "'10.0.0.300' is not a valid IP address"},
+		{name: "prefix too long", entry: "10.0.0.0/33", errContains: "prefix length '33' must be a number from 0 to 32"},
+		{name: "IPv6 prefix too long", entry: "fd00::/129", errContains: "prefix length '129' must be a number from 0 to 128"},
+		{name: "missing prefix length", entry: "10.0.0.0/", errContains: "prefix length '' must be a number from 0 to 32"},
+		{name: "hostname with prefix", entry: "internal.example.com/24", errContains: "entries cannot contain a path"},
+		{name: "domain with path", entry: "github.com/org/repo", errContains: "List the domain without the path: 'github.com'"},
+		{name: "host bits set", entry: "192.168.1.7/24", errContains: "Use the network address of the range: '192.168.1.0/24'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCIDR(tt.entry)
+			if tt.errContains == "" {
+				assert.NoError(t, err, "CIDR %s should be valid", tt.entry)
+				return
+			}
+			require.Error(t, err, "CIDR %s should be rejected", tt.entry)
+			assert.Contains(t, err.Error(), tt.errContains, "Error should explain the problem")
+		})
+	}
+}
+
+func TestIsCIDREntry(t *testing.T) {
+	assert.True(t, isCIDREntry("10.0.0.0/8"), "IPv4 range should be a CIDR entry")
+	assert.True(t, isCIDREntry("fd00::/8"), "IPv6 range should be a CIDR entry")
+	assert.False(t, isCIDREntry("github.com"), "Domain should not be a CIDR entry")
+	assert.False(t, isCIDREntry("defaults"), "Ecosystem should not be a CIDR entry")
+	assert.False(t, isCIDREntry("https://api.github.com/"), "URL should not be a CIDR entry")
+}
+
+func TestValidateNetworkAllowedDomainsWithCIDR(t *testing.T) {
+	compiler := NewCompiler()
+
+	err := compiler.validateNetworkAllowedDomains(&NetworkPermissions{
+		Allowed: []string{"defaults", "github.com", "10.0.0.0/8", "192.168.1.0/24"},
+	})
+	require.NoError(t, err, "CIDR ranges should be accepted next to domains and ecosystems")
+
+	err = compiler.validateNetworkAllowedDomains(&NetworkPermissions{
+		Allowed: []string{"defaults", "10.0.0.0/40"},
+	})
+	require.Error(t, err, "Malformed CIDR should be rejected")
+	assert.Contains(t, err.Error(), "network.allowed[1]", "Error should point at the offending entry")
+	assert.Contains(t, err.Error(), "prefix length '40' must be a number from 0 to 32", "Error should explain the problem")
+}
+
+func TestValidateStrictNetworkWithCIDR(t *testing.T) {
+	compiler := NewCompiler()
+
+	assert.NoError(t, compiler.validateStrictNetwork(&NetworkPermissions{Allowed: []string{"10.0.0.0/8", "192.168.1.0/24"}}),
+		"Strict mode should permit explicit CIDR ranges")
+
+	err := compiler.validateStrictNetwork(&NetworkPermissions{Allowed: []string{"*"}})
+	require.Error(t, err, "Strict mode should still reject a bare wildcard")
+	assert.Contains(t, err.Error(), "wildcard '*' is not allowed", "Error should mention the wildcard")
+
+	for _, entry := range []string{"0.0.0.0/0", "::/0"} {
+		err = compiler.validateStrictNetwork(&NetworkPermissions{Allowed: []string{"defaults", entry}})
+		require.Error(t, err, "Strict mode should reject %s", entry)
+		assert.Contains(t, err.Error(), "matches every address", "Error should explain why %s is rejected", entry)
+	}
+}
+
+func TestCompileWorkflowWithCIDRAllowlist(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "network-cidr-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+network:
+  allowed:
+    - defaults
+    - 10.0.0.0/8
+    - 192.168.1.0/24
+---
+
+# CIDR Allowlist
+
+Call the internal service.
+`
+
+	testFile := filepath.Join(tmpDir, "cidr.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with CIDR ranges should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	assert.Contains(t, string(lockContent), `--allow-domains "10.0.0.0/8,192.168.1.0/24,`, "CIDR ranges should be passed to the firewall")
+}
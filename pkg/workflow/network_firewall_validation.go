@@ -2,6 +2,7 @@
 //
 // This file contains domain-specific validation functions for network firewall configuration:
 //   - validateNetworkFirewallConfig() - Validates firewall configuration dependencies
+//   - validateNetworkLogConfig() - Validates network.log and network.mode dependencies
 //
 // These validation functions are organized in a dedicated file following the validation
 // architecture pattern where domain-specific validation belongs in domain validation files.
@@ -47,3 +48,39 @@ func validateNetworkFirewallConfig(networkPermissions *NetworkPermissions) error
 
 	return nil
 }
+
+// validateNetworkLogConfig validates that network.log and network.mode: audit are only used
+// when the agent runs inside the AWF sandbox, which produces the connection logs
+func validateNetworkLogConfig(workflowData *WorkflowData) error {
+	networkPermissions := workflowData.NetworkPermissions
+	if networkPermissions == nil {
+		return nil
+	}
+
+	switch networkPermissions.Mode {
+	case "", NetworkModeEnforce, NetworkModeAudit:
+	default:
+		return NewValidationError(
+			"network.mode",
+			networkPermissions.Mode,
+			"network mode must be 'enforce' or 'audit'",
+			"Use audit mode to list the hosts the firewall blocks:\n\nnetwork:\n  mode: audit\n\nSee: "+string(constants.DocsNetworkURL),
+		)
+	}
+
+	if !isNetworkLogEnabled(networkPermissions) || isFirewallEnabled(workflowData) {
+		return nil
+	}
+
+	networkFirewallValidationLog.Print("Validation error: network logging requested without the AWF sandbox")
+	field, value := "network.log", "true"
+	if isNetworkAuditMode(networkPermissions) {
+		field, value = "network.mode", NetworkModeAudit
+	}
+	return NewValidationError(
+		field,
+		value,
+		"network logging requires the agent sandbox (AWF), which records every connection",
+		"Remove sandbox.agent: false, or remove "+field+" from your network configuration.\n\nSee: "+string(constants.DocsSandboxURL),
+	)
+}
@@ -0,0 +1,84 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var networkLogLog = logger.New("workflow:network_log")
+
+// Network egress logging and audit mode
+//
+// With network.log: true, every connection that passes through the AWF proxy is exported
+// after the agent runs to /tmp/gh-aw/network.log and uploaded with the agent artifacts.
+//
+// With network.mode: audit, the network log is always enabled and the hosts the firewall
+// blocked are listed in the step summary, to discover the domains a workflow really needs.
+// AWF has no documented setting that allows every domain, so audit mode does not relax the
+// firewall: it enforces the same allowlist, with localhost entries always added so that
+// traffic to local services is never blocked.
+
+const (
+	// NetworkModeEnforce blocks connections to domains outside the allowlist (default)
+	NetworkModeEnforce = "enforce"
+	// NetworkModeAudit also logs every connection and reports the blocked hosts
+	NetworkModeAudit = "audit"
+)
+
+// networkLogPath is the file the network log exporter writes to
+const networkLogPath = "/tmp/gh-aw/network.log"
+
+// isNetworkAuditMode reports whether the network is configured to report blocked hosts
+func isNetworkAuditMode(network *NetworkPermissions) bool {
+	return network != nil && network.Mode == NetworkModeAudit
+}
+
+// isNetworkLogEnabled reports whether connections should be exported to the network log
+func isNetworkLogEnabled(network *NetworkPermissions) bool {
+	return network != nil && (network.Log || isNetworkAuditMode(network))
+}
+
+// getNetworkAuditAllowedDomains returns the allowlist passed to the firewall in audit mode.
+// Localhost entries are always included so that traffic to the MCP gateway and local
+// services is never blocked or reported.
+func getNetworkAuditAllowedDomains(allowedDomains string) string {
+	var domains []string
+	for domain := range strings.SplitSeq(allowedDomains, ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return strings.Join(parser.EnsureLocalhostDomains(domains), ",")
+}
+
+// generateNetworkLogStep generates the step that exports the firewall proxy logs to the
+// network log. In audit mode the exporter also lists the blocked hosts in the step summary.
+func generateNetworkLogStep(data *WorkflowData) GitHubActionStep {
+	mode := NetworkModeEnforce
+	if isNetworkAuditMode(data.NetworkPermissions) {
+		mode = NetworkModeAudit
+	}
+	networkLogLog.Printf("Generating network log export step: mode=%s", mode)
+
+	step := []string{
+		"      - name: Export network log",
+		"        if: always()",
+		"        continue-on-error: true",
+		fmt.Sprintf("        uses: %s", GetActionPin("actions/github-script")),
+		"        env:",
+		"          GH_AW_NETWORK_LOG: " + networkLogPath,
+		"          GH_AW_NETWORK_MODE: " + mode,
+	}
+	step = append(step,
+		"        with:",
+		"          script: |",
+		"            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');",
+		"            setupGlobals(core, github, context, exec, io);",
+		"            const { main } = require('/opt/gh-aw/actions/export_network_log.cjs');",
+		"            await main();",
+	)
+	return GitHubActionStep(step)
+}
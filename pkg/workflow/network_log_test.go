@@ -0,0 +1,183 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractNetworkPermissionsLogAndMode(t *testing.T) {
+	compiler := NewCompiler()
+
+	permissions := compiler.extractNetworkPermissions(map[string]any{
+		"network": map[string]any{
+			"allowed": []any{"defaults"},
+			"log":     true,
+			"mode":    "audit",
+		},
+	})
+	require.NotNil(t, permissions, "Network object should produce permissions")
+	assert.True(t, permissions.Log, "network.log should be parsed")
+	assert.Equal(t, NetworkModeAudit, permissions.Mode, "network.mode should be parsed")
+	assert.True(t, isNetworkAuditMode(permissions), "Audit mode should be detected")
+	assert.True(t, isNetworkLogEnabled(permissions), "Network log should be enabled")
+
+	permissions = compiler.extractNetworkPermissions(map[string]any{
+		"network": map[string]any{"mode": "audit"},
+	})
+	require.NotNil(t, permissions, "Network object should produce permissions")
+	assert.False(t, permissions.Log, "network.log should default to false")
+	assert.True(t, isNetworkLogEnabled(permissions), "Audit mode should always enable the network log")
+
+	permissions = compiler.extractNetworkPermissions(map[string]any{"network": "defaults"})
+	require.NotNil(t, permissions, "Network string should produce permissions")
+	assert.False(t, isNetworkLogEnabled(permissions), "Network log should be disabled by default")
+}
+
+func TestGetNetworkAuditAllowedDomains(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed string
+	}{
+		{name: "no domains", allowed: ""},
+		{name: "engine domains", allowed: "api.github.com,github.com"},
+		{name: "localhost already present", allowed: "github.com,localhost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domains := strings.Split(getNetworkAuditAllowedDomains(tt.allowed), ",")
+			for _, localhost := range []string{"localhost", "localhost:*", "127.0.0.1", "127.0.0.1:*"} {
+				assert.Equal(t, 1, countString(domains, localhost), "Audit allowlist should contain %s exactly once", localhost)
+			}
+			for domain := range strings.SplitSeq(tt.allowed, ",") {
+				if domain != "" {
+					assert.Contains(t, domains, domain, "Audit allowlist should keep configured domains")
+				}
+			}
+		})
+	}
+}
+
+func countString(values []string, target string) int {
+	count := 0
+	for _, value := range values {
+		if value == target {
+			count++
+		}
+	}
+	return count
+}
+
+func TestValidateNetworkLogConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        *WorkflowData
+		errContains string
+	}{
+		{
+			name: "no network permissions",
+			data: &WorkflowData{},
+		},
+		{
+			name: "log with firewall enabled",
+			data: &WorkflowData{NetworkPermissions: &NetworkPermissions{Log: true, Firewall: &FirewallConfig{Enabled: true}}},
+		},
+		{
+			name:        "log with firewall disabled",
+			data:        &WorkflowData{NetworkPermissions: &NetworkPermissions{Log: true, Firewall: &FirewallConfig{Enabled: false}}},
+			errContains: "network.log",
+		},
+		{
+			name:        "audit mode with firewall disabled",
+			data:        &WorkflowData{NetworkPermissions: &NetworkPermissions{Mode: NetworkModeAudit, Firewall: &FirewallConfig{Enabled: false}}},
+			errContains: "network.mode",
+		},
+		{
+			name:        "unknown mode",
+			data:        &WorkflowData{NetworkPermissions: &NetworkPermissions{Mode: "monitor"}},
+			errContains: "network mode must be 'enforce' or 'audit'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNetworkLogConfig(tt.data)
+			if tt.errContains == "" {
+				assert.NoError(t, err, "Network log configuration should be valid")
+				return
+			}
+			require.Error(t, err, "Network log configuration should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "Error should name the offending field")
+		})
+	}
+}
+
+func TestValidateStrictNetworkAllowsAuditMode(t *testing.T) {
+	err := NewCompiler().validateStrictNetwork(&NetworkPermissions{Allowed: []string{"defaults"}, Mode: NetworkModeAudit})
+	assert.NoError(t, err, "Audit mode enforces the allowlist, so strict mode should accept it")
+}
+
+func compileNetworkLogWorkflow(t *testing.T, network string) string {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "network-log-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+strict: false
+network:
+` + network + `
+---
+
+# Network Log
+
+Summarize the repository.
+`
+
+	testFile := filepath.Join(tmpDir, "network-log.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	return extractJobSection(string(lockContent), "agent")
+}
+
+func TestCompileWorkflowWithNetworkLog(t *testing.T) {
+	agentJob := compileNetworkLogWorkflow(t, "  allowed: [defaults, github]\n  log: true")
+
+	assert.Contains(t, agentJob, "- name: Export network log", "Network log should be exported")
+	assert.Contains(t, agentJob, "GH_AW_NETWORK_MODE: enforce", "Network log should default to enforce mode")
+	assert.NotContains(t, agentJob, `--allow-domains "*"`, "Enforce mode should keep the firewall allowlist")
+	assert.NotContains(t, agentJob, "localhost:*", "Enforce mode should not add localhost entries")
+	assert.Contains(t, agentJob, "            /tmp/gh-aw/network.log\n", "Network log should be uploaded as an artifact")
+}
+
+func TestCompileWorkflowWithNetworkAuditMode(t *testing.T) {
+	agentJob := compileNetworkLogWorkflow(t, "  allowed: [defaults, github]\n  blocked: [tracker.example.com]\n  mode: audit")
+
+	assert.NotContains(t, agentJob, `--allow-domains "*"`, "Audit mode should not pass a wildcard to the firewall")
+	assert.Contains(t, agentJob, `--allow-domains "localhost,localhost:*,127.0.0.1,127.0.0.1:*,`, "Audit allowlist should always contain localhost entries")
+	assert.Contains(t, agentJob, "api.github.com", "Audit allowlist should contain the enforced domains")
+	assert.Contains(t, agentJob, `--block-domains "tracker.example.com"`, "Audit mode should still enforce blocked domains")
+	assert.Contains(t, agentJob, "GH_AW_NETWORK_MODE: audit", "Exporter should run in audit mode")
+	assert.Contains(t, agentJob, "            /tmp/gh-aw/network.log\n", "Network log should be uploaded as an artifact")
+}
+
+func TestCompileWorkflowWithoutNetworkLog(t *testing.T) {
+	agentJob := compileNetworkLogWorkflow(t, "  allowed: [defaults]")
+
+	assert.NotContains(t, agentJob, "Export network log", "Network log should not be exported by default")
+	assert.NotContains(t, agentJob, "/tmp/gh-aw/network.log", "Network log should not be uploaded by default")
+}
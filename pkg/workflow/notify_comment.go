@@ -33,6 +33,13 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 		return nil, nil // No safe-outputs configured, no need for conclusion job
 	}
 
+	// In dry-run mode safe outputs are only logged, including noop and missing-tool items,
+	// so the status comments and issue reports written by this job are skipped as well
+	if isSafeOutputsDryRun(data.SafeOutputs) {
+		notifyCommentLog.Printf("Skipping job: safe-outputs dry-run enabled")
+		return nil, nil
+	}
+
 	// Build the job steps
 	var steps []string
 
@@ -385,11 +392,12 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 	job := &Job{
 		Name:        "conclusion",
 		If:          condition.Render(),
-		RunsOn:      c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:      c.formatGeneratedJobRunsOn(data),
 		Permissions: permissions.RenderToYAML(),
 		Steps:       steps,
 		Needs:       needs,
 		Outputs:     outputs,
+		Provenance:  "safe-outputs (noop, missing-tool and run status reporting)",
 	}
 
 	return job, nil
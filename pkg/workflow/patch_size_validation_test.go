@@ -73,6 +73,23 @@ This workflow tests custom 2MB patch size configuration.`,
 			shouldContainPushJob: false,
 			shouldContainPRJob:   true,
 		},
+		{
+			name: "create-pull-request patch size overrides top-level",
+			frontmatterContent: `---
+on: push
+safe-outputs:
+  max-patch-size: 2048
+  create-pull-request:
+    max-patch-size: 256
+---
+
+# Test Workflow
+
+This workflow tests the per-output patch size limit.`,
+			expectedConfigValue:  `\"max_patch_size\":256`,
+			shouldContainPushJob: false,
+			shouldContainPRJob:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,288 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+	"github.com/goccy/go-yaml"
+)
+
+var permissionsBuilderLog = logger.New("workflow:permissions_builder")
+
+// PermissionsBuilder provides a fluent API for assembling GitHub Actions permissions.
+//
+// Example:
+//
+//	yaml := NewPermissionsBuilder().
+//		WithAll(PermissionRead).
+//		WithIssues(PermissionWrite).
+//		Build()
+type PermissionsBuilder struct {
+	scopes map[PermissionScope]PermissionLevel
+}
+
+// NewPermissionsBuilder creates a new PermissionsBuilder with no scopes set
+func NewPermissionsBuilder() *PermissionsBuilder {
+	return &PermissionsBuilder{
+		scopes: make(map[PermissionScope]PermissionLevel),
+	}
+}
+
+// With sets the level for a single scope
+func (b *PermissionsBuilder) With(scope PermissionScope, level PermissionLevel) *PermissionsBuilder {
+	b.scopes[scope] = level
+	return b
+}
+
+// WithAll sets every known scope to the given level.
+// Subsequent WithX calls override individual scopes.
+// id-token does not support read access, so it is left unset when level is read.
+func (b *PermissionsBuilder) WithAll(level PermissionLevel) *PermissionsBuilder {
+	permissionsBuilderLog.Printf("Setting all scopes to level: %s", level)
+	for _, scope := range GetAllPermissionScopes() {
+		if scope == PermissionIdToken && level == PermissionRead {
+			delete(b.scopes, scope)
+			continue
+		}
+		b.scopes[scope] = level
+	}
+	return b
+}
+
+// Clear removes all scopes from the builder
+func (b *PermissionsBuilder) Clear() *PermissionsBuilder {
+	clear(b.scopes)
+	return b
+}
+
+// WithActions sets the actions scope
+func (b *PermissionsBuilder) WithActions(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionActions, level)
+}
+
+// WithAttestations sets the attestations scope
+func (b *PermissionsBuilder) WithAttestations(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionAttestations, level)
+}
+
+// WithChecks sets the checks scope
+func (b *PermissionsBuilder) WithChecks(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionChecks, level)
+}
+
+// WithContents sets the contents scope
+func (b *PermissionsBuilder) WithContents(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionContents, level)
+}
+
+// WithDeployments sets the deployments scope
+func (b *PermissionsBuilder) WithDeployments(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionDeployments, level)
+}
+
+// WithDiscussions sets the discussions scope
+func (b *PermissionsBuilder) WithDiscussions(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionDiscussions, level)
+}
+
+// WithIdToken sets the id-token scope
+func (b *PermissionsBuilder) WithIdToken(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionIdToken, level)
+}
+
+// WithIssues sets the issues scope
+func (b *PermissionsBuilder) WithIssues(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionIssues, level)
+}
+
+// WithMetadata sets the metadata scope
+func (b *PermissionsBuilder) WithMetadata(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionMetadata, level)
+}
+
+// WithModels sets the models scope
+func (b *PermissionsBuilder) WithModels(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionModels, level)
+}
+
+// WithPackages sets the packages scope
+func (b *PermissionsBuilder) WithPackages(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionPackages, level)
+}
+
+// WithPages sets the pages scope
+func (b *PermissionsBuilder) WithPages(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionPages, level)
+}
+
+// WithPullRequests sets the pull-requests scope
+func (b *PermissionsBuilder) WithPullRequests(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionPullRequests, level)
+}
+
+// WithRepositoryProjects sets the repository-projects scope
+func (b *PermissionsBuilder) WithRepositoryProjects(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionRepositoryProj, level)
+}
+
+// WithOrganizationProjects sets the organization-projects scope
+func (b *PermissionsBuilder) WithOrganizationProjects(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionOrganizationProj, level)
+}
+
+// WithSecurityEvents sets the security-events scope
+func (b *PermissionsBuilder) WithSecurityEvents(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionSecurityEvents, level)
+}
+
+// WithStatuses sets the statuses scope
+func (b *PermissionsBuilder) WithStatuses(level PermissionLevel) *PermissionsBuilder {
+	return b.With(PermissionStatuses, level)
+}
+
+// uniformLevel returns the level shared by every known scope, if any.
+// id-token is allowed to be unset when the shared level is read since it does not support read access.
+func (b *PermissionsBuilder) uniformLevel() (PermissionLevel, bool) {
+	var shared PermissionLevel
+	for _, scope := range GetAllPermissionScopes() {
+		level, exists := b.scopes[scope]
+		if !exists {
+			if scope == PermissionIdToken {
+				continue
+			}
+			return "", false
+		}
+		if shared == "" {
+			shared = level
+		} else if level != shared {
+			return "", false
+		}
+	}
+	if shared == "" {
+		return "", false
+	}
+	// An unset id-token only matches read
+	if _, hasIdToken := b.scopes[PermissionIdToken]; !hasIdToken && shared != PermissionRead {
+		return "", false
+	}
+	return shared, true
+}
+
+// Permissions returns the Permissions described by the builder.
+// When every scope shares the same level the result collapses to the
+// read-all/write-all shorthand (or an explicit empty map for none).
+func (b *PermissionsBuilder) Permissions() *Permissions {
+	if level, ok := b.uniformLevel(); ok {
+		permissionsBuilderLog.Printf("All scopes share level %s, using shorthand", level)
+		switch level {
+		case PermissionRead:
+			return NewPermissionsReadAll()
+		case PermissionWrite:
+			return NewPermissionsWriteAll()
+		case PermissionNone:
+			return NewPermissionsEmpty()
+		}
+	}
+
+	// NewPermissionsFromMap copies the map, so later builder calls don't leak into the result
+	return NewPermissionsFromMap(b.scopes)
+}
+
+// Build renders the builder to GitHub Actions YAML format
+func (b *PermissionsBuilder) Build() string {
+	return b.Permissions().RenderToYAML()
+}
+
+// ParsePermissions parses a permissions YAML block into a PermissionsBuilder.
+// It accepts the map form (optionally prefixed with "permissions:"), the
+// read-all/write-all/none shorthands, and the empty map "{}".
+// Unknown scopes or levels produce an error referencing the offending key.
+func ParsePermissions(permissionsYAML string) (*PermissionsBuilder, error) {
+	content := strings.TrimSpace(permissionsYAML)
+	permissionsBuilderLog.Printf("Parsing permissions into builder: length=%d", len(content))
+
+	var raw any
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("invalid permissions YAML: %w", err)
+	}
+
+	// Unwrap a top-level "permissions:" key
+	if wrapper, ok := raw.(map[string]any); ok && len(wrapper) == 1 {
+		if inner, hasPermissions := wrapper["permissions"]; hasPermissions {
+			raw = inner
+		}
+	}
+
+	builder := NewPermissionsBuilder()
+
+	switch v := raw.(type) {
+	case nil:
+		return builder, nil
+	case string:
+		switch v {
+		case "read-all":
+			return builder.WithAll(PermissionRead), nil
+		case "write-all":
+			return builder.WithAll(PermissionWrite), nil
+		case "none":
+			return builder.WithAll(PermissionNone), nil
+		default:
+			return nil, fmt.Errorf("invalid permissions shorthand %q: must be one of read-all, write-all, none, or a map of scopes to levels", v)
+		}
+	case map[string]any:
+		// Apply "all" first so explicit scopes override it regardless of map order
+		if allValue, hasAll := v["all"]; hasAll {
+			level, err := parsePermissionLevelValue("all", allValue)
+			if err != nil {
+				return nil, err
+			}
+			builder.WithAll(level)
+		}
+
+		keys := sliceutil.MapToSlice(v)
+		sort.Strings(keys)
+		for _, key := range keys {
+			if key == "all" {
+				continue
+			}
+			scope := convertStringToPermissionScope(key)
+			if scope == "" {
+				return nil, fmt.Errorf("invalid permission scope %q: valid scopes are %s", key, strings.Join(permissionScopeNames(), ", "))
+			}
+			level, err := parsePermissionLevelValue(key, v[key])
+			if err != nil {
+				return nil, err
+			}
+			builder.With(scope, level)
+		}
+		return builder, nil
+	default:
+		return nil, fmt.Errorf("invalid permissions: expected a shorthand string or a map of scopes to levels, got %T", raw)
+	}
+}
+
+// parsePermissionLevelValue converts a YAML value into a PermissionLevel for the given key
+func parsePermissionLevelValue(key string, value any) (PermissionLevel, error) {
+	levelStr, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid permission level for %q: expected read, write, or none, got %T", key, value)
+	}
+	switch PermissionLevel(levelStr) {
+	case PermissionRead, PermissionWrite, PermissionNone:
+		return PermissionLevel(levelStr), nil
+	default:
+		return "", fmt.Errorf("invalid permission level %q for %q: must be read, write, or none", levelStr, key)
+	}
+}
+
+// permissionScopeNames returns the names of all known permission scopes
+func permissionScopeNames() []string {
+	var names []string
+	for _, scope := range GetAllPermissionScopes() {
+		names = append(names, string(scope))
+	}
+	return names
+}
@@ -0,0 +1,209 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionsBuilderWithAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *PermissionsBuilder
+		expected string
+	}{
+		{
+			name:     "all read collapses to read-all",
+			builder:  NewPermissionsBuilder().WithAll(PermissionRead),
+			expected: "permissions: read-all",
+		},
+		{
+			name:     "all write collapses to write-all",
+			builder:  NewPermissionsBuilder().WithAll(PermissionWrite),
+			expected: "permissions: write-all",
+		},
+		{
+			name:     "all none renders empty map",
+			builder:  NewPermissionsBuilder().WithAll(PermissionNone),
+			expected: "permissions: {}",
+		},
+		{
+			name:     "explicit scopes matching all read still collapse",
+			builder:  NewPermissionsBuilder().WithAll(PermissionWrite).WithAll(PermissionRead),
+			expected: "permissions: read-all",
+		},
+		{
+			name:    "all read with issues write emits explicit map",
+			builder: NewPermissionsBuilder().WithAll(PermissionRead).WithIssues(PermissionWrite),
+			expected: `permissions:
+      actions: read
+      attestations: read
+      checks: read
+      contents: read
+      deployments: read
+      discussions: read
+      issues: write
+      models: read
+      packages: read
+      pages: read
+      pull-requests: read
+      repository-projects: read
+      security-events: read
+      statuses: read`,
+		},
+		{
+			name:    "single scopes without WithAll",
+			builder: NewPermissionsBuilder().WithContents(PermissionRead).WithPullRequests(PermissionWrite),
+			expected: `permissions:
+      contents: read
+      pull-requests: write`,
+		},
+		{
+			name:     "empty builder renders nothing",
+			builder:  NewPermissionsBuilder(),
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.builder.Build(), "Rendered YAML should match")
+		})
+	}
+}
+
+func TestPermissionsBuilderWithAllSkipsIdTokenRead(t *testing.T) {
+	perms := NewPermissionsBuilder().WithAll(PermissionRead).WithContents(PermissionWrite).Permissions()
+
+	_, hasIdToken := perms.Get(PermissionIdToken)
+	assert.False(t, hasIdToken, "id-token should not be set since it does not support read")
+
+	level, ok := perms.Get(PermissionContents)
+	assert.True(t, ok, "contents should be set")
+	assert.Equal(t, PermissionWrite, level, "later WithContents should override WithAll")
+}
+
+func TestPermissionsBuilderClear(t *testing.T) {
+	builder := NewPermissionsBuilder().WithAll(PermissionWrite)
+	assert.Equal(t, "permissions: write-all", builder.Build(), "WithAll(write) should render write-all")
+
+	builder.Clear()
+	assert.Empty(t, builder.Build(), "Clear should remove all scopes")
+
+	builder.WithIssues(PermissionWrite)
+	assert.Equal(t, "permissions:\n      issues: write", builder.Build(), "Builder should be reusable after Clear")
+}
+
+func TestPermissionsBuilderResultIsIndependent(t *testing.T) {
+	builder := NewPermissionsBuilder().WithIssues(PermissionWrite)
+	perms := builder.Permissions()
+
+	builder.WithContents(PermissionWrite)
+
+	_, hasContents := perms.Get(PermissionContents)
+	assert.False(t, hasContents, "Permissions returned earlier should not see later builder changes")
+}
+
+func TestParsePermissions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "read-all shorthand",
+			input:    "read-all",
+			expected: "permissions: read-all",
+		},
+		{
+			name:     "write-all shorthand with permissions prefix",
+			input:    "permissions: write-all",
+			expected: "permissions: write-all",
+		},
+		{
+			name:     "empty map",
+			input:    "{}",
+			expected: "",
+		},
+		{
+			name:     "map form",
+			input:    "contents: read\nissues: write",
+			expected: "permissions:\n      contents: read\n      issues: write",
+		},
+		{
+			name:     "map form with permissions prefix",
+			input:    "permissions:\n  contents: read\n  pull-requests: write",
+			expected: "permissions:\n      contents: read\n      pull-requests: write",
+		},
+		{
+			name:     "all read with override",
+			input:    "all: read\nissues: write",
+			expected: NewPermissionsBuilder().WithAll(PermissionRead).WithIssues(PermissionWrite).Build(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder, err := ParsePermissions(tt.input)
+			require.NoError(t, err, "ParsePermissions should succeed")
+			assert.Equal(t, tt.expected, builder.Build(), "Parsed permissions should render as expected")
+		})
+	}
+}
+
+func TestParsePermissionsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		errContains string
+	}{
+		{
+			name:        "unknown scope",
+			input:       "contents: read\nrepo-admin: write",
+			errContains: `"repo-admin"`,
+		},
+		{
+			name:        "invalid level",
+			input:       "issues: admin",
+			errContains: `invalid permission level "admin" for "issues"`,
+		},
+		{
+			name:        "non-string level",
+			input:       "issues: 1",
+			errContains: `"issues"`,
+		},
+		{
+			name:        "invalid shorthand",
+			input:       "read",
+			errContains: "invalid permissions shorthand",
+		},
+		{
+			name:        "list instead of map",
+			input:       "- contents",
+			errContains: "expected a shorthand string or a map",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePermissions(tt.input)
+			require.Error(t, err, "ParsePermissions should fail")
+			assert.Contains(t, err.Error(), tt.errContains, "Error should reference the offending value")
+		})
+	}
+}
+
+func TestParsePermissionsRoundTrip(t *testing.T) {
+	builder, err := ParsePermissions("permissions:\n  contents: read\n  issues: write")
+	require.NoError(t, err, "ParsePermissions should succeed")
+
+	yaml := builder.WithContents(PermissionWrite).Build()
+	assert.Equal(t, "permissions:\n      contents: write\n      issues: write", yaml, "Tweaked scope should be reflected in output")
+
+	reparsed, err := ParsePermissions(yaml)
+	require.NoError(t, err, "Rendered YAML should parse back")
+	assert.Equal(t, yaml, reparsed.Build(), "Round trip should be stable")
+}
@@ -0,0 +1,144 @@
+package workflow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var phaseTimeoutsLog = logger.New("workflow:phase_timeouts")
+
+// PhaseTimeouts holds the per-phase step timeouts from the timeouts frontmatter field.
+// A zero value means the phase keeps its default timeout.
+type PhaseTimeouts struct {
+	MCPSetup    int // timeout-minutes for the steps that start MCP servers and the MCP gateway
+	Agent       int // timeout-minutes for the agent execution step
+	SafeOutputs int // timeout-minutes for the safe output processing steps
+}
+
+// mcpSetupStepIDs are the IDs of the agent job steps that start MCP servers
+var mcpSetupStepIDs = []string{"safe-outputs-start", "safe-inputs-start", "serena-start", "start-mcp-gateway"}
+
+// extractPhaseTimeouts parses the timeouts frontmatter field
+func extractPhaseTimeouts(frontmatter map[string]any) (*PhaseTimeouts, error) {
+	value, exists := frontmatter["timeouts"]
+	if !exists {
+		return nil, nil
+	}
+	timeoutsMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("timeouts must be an object, e.g. timeouts: { mcp-setup: 5, agent: 30, safe-outputs: 5 }, got %T", value)
+	}
+
+	timeouts := &PhaseTimeouts{}
+	fields := map[string]*int{
+		"mcp-setup":    &timeouts.MCPSetup,
+		"agent":        &timeouts.Agent,
+		"safe-outputs": &timeouts.SafeOutputs,
+	}
+	for key, raw := range timeoutsMap {
+		target, known := fields[key]
+		if !known {
+			return nil, fmt.Errorf("unknown timeouts phase '%s'; expected mcp-setup, agent, or safe-outputs", key)
+		}
+		minutes, ok := parsePositiveMinutes(raw)
+		if !ok {
+			return nil, fmt.Errorf("timeouts.%s must be a positive integer number of minutes, got %v", key, raw)
+		}
+		*target = minutes
+	}
+	return timeouts, nil
+}
+
+// parsePositiveMinutes converts a YAML number to a positive whole number of minutes
+func parsePositiveMinutes(raw any) (int, bool) {
+	switch v := raw.(type) {
+	case int:
+		return v, v > 0
+	case int64:
+		return int(v), v > 0
+	case uint64:
+		return int(v), v > 0
+	case float64:
+		return int(v), v > 0 && v == float64(int(v))
+	}
+	return 0, false
+}
+
+// processPhaseTimeoutsConfiguration extracts the per-phase timeouts and warns when they add up to
+// more than the workflow timeout, since the later phases would then be cut off by it
+func (c *Compiler) processPhaseTimeoutsConfiguration(frontmatter map[string]any, workflowData *WorkflowData) error {
+	timeouts, err := extractPhaseTimeouts(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.PhaseTimeouts = timeouts
+	if timeouts == nil {
+		return nil
+	}
+
+	jobTimeout := int(constants.DefaultAgenticWorkflowTimeout / time.Minute)
+	if workflowData.TimeoutMinutes != "" {
+		parsed, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(workflowData.TimeoutMinutes, "timeout-minutes:")))
+		if err != nil {
+			// Not a plain number of minutes; nothing to compare against
+			return nil
+		}
+		jobTimeout = parsed
+	}
+
+	total := timeouts.MCPSetup + timeouts.Agent + timeouts.SafeOutputs
+	phaseTimeoutsLog.Printf("Phase timeouts: mcp-setup=%d, agent=%d, safe-outputs=%d (total=%d, timeout-minutes=%d)", timeouts.MCPSetup, timeouts.Agent, timeouts.SafeOutputs, total, jobTimeout)
+	if total > jobTimeout {
+		c.addWarning(fmt.Sprintf("Per-phase timeouts add up to %d minutes, which exceeds timeout-minutes (%d). Phases may be cut off by the workflow timeout.", total, jobTimeout))
+	}
+	return nil
+}
+
+// setStepTimeout sets timeout-minutes on a step, replacing any existing value
+func setStepTimeout(step GitHubActionStep, minutes int) GitHubActionStep {
+	line := fmt.Sprintf("        timeout-minutes: %d", minutes)
+	result := append(GitHubActionStep{}, step...)
+	if idx := stepKeyIndex(result, "timeout-minutes"); idx >= 0 {
+		result[idx] = line
+		return result
+	}
+	return insertStepKey(result, line)
+}
+
+// applyStepTimeoutByID adds timeout-minutes to the steps with the given IDs in generated YAML.
+// The timeout is inserted directly after the step's id line.
+func applyStepTimeoutByID(yamlContent string, stepIDs []string, minutes int) string {
+	for _, id := range stepIDs {
+		idLine := fmt.Sprintf("        id: %s\n", id)
+		yamlContent = strings.ReplaceAll(yamlContent, idLine, fmt.Sprintf("%s        timeout-minutes: %d\n", idLine, minutes))
+	}
+	return yamlContent
+}
+
+// applyAgentPhaseTimeout sets the agent phase timeout on the execution step, which engines emit
+// as the last of their execution steps
+func applyAgentPhaseTimeout(data *WorkflowData, steps []GitHubActionStep) []GitHubActionStep {
+	if data.PhaseTimeouts == nil || data.PhaseTimeouts.Agent == 0 || len(steps) == 0 {
+		return steps
+	}
+	last := len(steps) - 1
+	steps[last] = setStepTimeout(steps[last], data.PhaseTimeouts.Agent)
+	return steps
+}
+
+// applySafeOutputsPhaseTimeout sets the safe-outputs phase timeout on the safe output steps of the
+// safe_outputs job
+func applySafeOutputsPhaseTimeout(data *WorkflowData, steps []string, stepIDs []string) []string {
+	if data.PhaseTimeouts == nil || data.PhaseTimeouts.SafeOutputs == 0 {
+		return steps
+	}
+	for i, step := range steps {
+		steps[i] = applyStepTimeoutByID(step, stepIDs, data.PhaseTimeouts.SafeOutputs)
+	}
+	return steps
+}
@@ -0,0 +1,138 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractPhaseTimeouts(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *PhaseTimeouts
+		errorText   string
+	}{
+		{
+			name:        "not configured",
+			frontmatter: map[string]any{},
+		},
+		{
+			name: "all phases",
+			frontmatter: map[string]any{"timeouts": map[string]any{
+				"mcp-setup": uint64(5), "agent": uint64(30), "safe-outputs": uint64(5),
+			}},
+			expected: &PhaseTimeouts{MCPSetup: 5, Agent: 30, SafeOutputs: 5},
+		},
+		{
+			name:        "single phase",
+			frontmatter: map[string]any{"timeouts": map[string]any{"agent": 45}},
+			expected:    &PhaseTimeouts{Agent: 45},
+		},
+		{
+			name:        "zero",
+			frontmatter: map[string]any{"timeouts": map[string]any{"agent": 0}},
+			errorText:   "timeouts.agent must be a positive integer",
+		},
+		{
+			name:        "fractional",
+			frontmatter: map[string]any{"timeouts": map[string]any{"mcp-setup": 2.5}},
+			errorText:   "timeouts.mcp-setup must be a positive integer",
+		},
+		{
+			name:        "string",
+			frontmatter: map[string]any{"timeouts": map[string]any{"safe-outputs": "5"}},
+			errorText:   "timeouts.safe-outputs must be a positive integer",
+		},
+		{
+			name:        "unknown phase",
+			frontmatter: map[string]any{"timeouts": map[string]any{"detection": 5}},
+			errorText:   "unknown timeouts phase 'detection'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeouts, err := extractPhaseTimeouts(tt.frontmatter)
+			if tt.errorText != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorText)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, timeouts)
+		})
+	}
+}
+
+func TestPhaseTimeoutsCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "phase-timeouts-test")
+
+	testContent := `---
+on: workflow_dispatch
+engine: copilot
+timeout-minutes: 60
+timeouts:
+  mcp-setup: 5
+  agent: 30
+  safe-outputs: 7
+safe-outputs:
+  create-issue:
+---
+
+# Phase timeouts
+
+Create an issue.
+`
+	testFile := filepath.Join(tmpDir, "phase-timeouts.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+	assert.Equal(t, 0, compiler.GetWarningCount(), "phase timeouts within timeout-minutes should not warn")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "phase-timeouts.lock.yml"))
+	require.NoError(t, err)
+	lockStr := string(lockContent)
+
+	agentJob := extractJobSection(lockStr, "agent")
+	require.NotEmpty(t, agentJob)
+	assert.Contains(t, agentJob, "        id: start-mcp-gateway\n        timeout-minutes: 5\n")
+	assert.Contains(t, agentJob, "        id: safe-outputs-start\n        timeout-minutes: 5\n")
+	assert.Contains(t, agentJob, "        timeout-minutes: 30\n", "agent phase timeout should replace timeout-minutes on the execution step")
+	assert.NotContains(t, agentJob, "        timeout-minutes: 60\n")
+
+	safeOutputsJob := extractJobSection(lockStr, "safe_outputs")
+	require.NotEmpty(t, safeOutputsJob)
+	assert.Contains(t, safeOutputsJob, "        id: process_safe_outputs\n        timeout-minutes: 7\n")
+}
+
+func TestPhaseTimeoutsExceedWorkflowTimeout(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "phase-timeouts-warning-test")
+
+	testContent := `---
+on: workflow_dispatch
+engine: copilot
+timeout-minutes: 20
+timeouts:
+  mcp-setup: 10
+  agent: 15
+---
+
+# Phase timeouts
+
+Summarize the repository.
+`
+	testFile := filepath.Join(tmpDir, "phase-timeouts.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+	assert.Equal(t, 1, compiler.GetWarningCount(), "phase timeouts exceeding timeout-minutes should warn")
+}
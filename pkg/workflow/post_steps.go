@@ -0,0 +1,117 @@
+// This file validates and prepares post-steps for the agent job.
+//
+// # Post-Steps
+//
+// Steps under the top-level post-steps field are appended to the agent job after the agent
+// has run. They are meant for cleanup and reporting, such as uploading debug logs, so they run
+// regardless of the agent's outcome:
+//
+//	post-steps:
+//	  - name: Upload debug logs
+//	    uses: actions/upload-artifact@v4
+//	    with:
+//	      path: /tmp/gh-aw/
+//
+// A step without an if condition gets if: always(). A step with a condition that does not
+// already use a status check function (always(), success(), failure() or cancelled()) gets
+// always() && (condition).
+//
+// Post-steps are plain steps and run with the permissions of the agent job. Fields that
+// belong to jobs, such as permissions, are rejected so a post-step cannot appear to grant
+// itself additional access.
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var postStepsLog = logger.New("workflow:post_steps")
+
+// allowedPostStepFields lists the GitHub Actions step fields accepted in post-steps
+var allowedPostStepFields = map[string]bool{
+	"name":              true,
+	"id":                true,
+	"if":                true,
+	"uses":              true,
+	"run":               true,
+	"with":              true,
+	"env":               true,
+	"shell":             true,
+	"working-directory": true,
+	"continue-on-error": true,
+	"timeout-minutes":   true,
+}
+
+// jobOnlyPostStepFields lists job-level fields that are commonly misplaced on a step.
+// They are rejected with a dedicated message because they would suggest the step can change
+// the permissions or environment it runs with.
+var jobOnlyPostStepFields = map[string]bool{
+	"permissions": true,
+	"environment": true,
+	"runs-on":     true,
+	"needs":       true,
+	"container":   true,
+	"services":    true,
+	"strategy":    true,
+}
+
+// statusCheckFunctionPattern matches GitHub Actions status check functions in a condition
+var statusCheckFunctionPattern = regexp.MustCompile(`\b(always|success|failure|cancelled)\s*\(\s*\)`)
+
+// validatePostSteps checks that post-steps is a list of step objects that only use step fields
+func validatePostSteps(postSteps any) error {
+	stepsList, ok := postSteps.([]any)
+	if !ok {
+		return fmt.Errorf("post-steps must be an array of steps, got %T", postSteps)
+	}
+
+	for i, step := range stepsList {
+		stepMap, ok := step.(map[string]any)
+		if !ok {
+			return fmt.Errorf("post-steps[%d] must be an object, got %T", i, step)
+		}
+
+		fields := make([]string, 0, len(stepMap))
+		for field := range stepMap {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			if jobOnlyPostStepFields[field] {
+				return fmt.Errorf("post-steps[%d]: '%s' is a job field and cannot be set on a step. Post-steps run in the agent job with its permissions", i, field)
+			}
+			if !allowedPostStepFields[field] {
+				return fmt.Errorf("post-steps[%d]: unsupported field '%s'", i, field)
+			}
+		}
+
+		_, hasUses := stepMap["uses"]
+		_, hasRun := stepMap["run"]
+		if hasUses == hasRun {
+			return fmt.Errorf("post-steps[%d] must specify exactly one of 'uses' or 'run'", i)
+		}
+	}
+
+	postStepsLog.Printf("Validated %d post-steps", len(stepsList))
+	return nil
+}
+
+// postStepCondition returns the if condition of a post-step so that it runs regardless of the
+// outcome of the agent
+func postStepCondition(condition string) string {
+	expr := stripExpressionWrapper(condition)
+	if expr == "" {
+		return "always()"
+	}
+	if statusCheckFunctionPattern.MatchString(expr) {
+		return condition
+	}
+	return fmt.Sprintf("always() && (%s)", strings.TrimSpace(expr))
+}
@@ -0,0 +1,162 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePostSteps(t *testing.T) {
+	tests := []struct {
+		name        string
+		postSteps   any
+		expectError string
+	}{
+		{
+			name: "valid run and uses steps",
+			postSteps: []any{
+				map[string]any{"name": "Cleanup", "run": "echo cleanup"},
+				map[string]any{"uses": "actions/upload-artifact@v4", "with": map[string]any{"path": "/tmp/gh-aw/"}},
+			},
+		},
+		{
+			name:        "not an array",
+			postSteps:   map[string]any{"name": "Cleanup", "run": "echo cleanup"},
+			expectError: "post-steps must be an array of steps",
+		},
+		{
+			name:        "step is not an object",
+			postSteps:   []any{"echo cleanup"},
+			expectError: "post-steps[0] must be an object, got string",
+		},
+		{
+			name: "permissions on a step",
+			postSteps: []any{
+				map[string]any{"run": "echo cleanup", "permissions": map[string]any{"contents": "write"}},
+			},
+			expectError: "post-steps[0]: 'permissions' is a job field and cannot be set on a step",
+		},
+		{
+			name:        "unsupported field",
+			postSteps:   []any{map[string]any{"run": "echo cleanup", "script": "x"}},
+			expectError: "post-steps[0]: unsupported field 'script'",
+		},
+		{
+			name:        "both uses and run",
+			postSteps:   []any{map[string]any{"run": "echo cleanup", "uses": "actions/checkout@v5"}},
+			expectError: "post-steps[0] must specify exactly one of 'uses' or 'run'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePostSteps(tt.postSteps)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPostStepCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		expected  string
+	}{
+		{condition: "", expected: "always()"},
+		{condition: "github.event_name == 'push'", expected: "always() && (github.event_name == 'push')"},
+		{condition: "${{ github.event_name == 'push' }}", expected: "always() && (github.event_name == 'push')"},
+		{condition: "failure()", expected: "failure()"},
+		{condition: "${{ always() && env.DEBUG }}", expected: "${{ always() && env.DEBUG }}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			assert.Equal(t, tt.expected, postStepCondition(tt.condition))
+		})
+	}
+}
+
+func TestCompileWorkflowPostStepsRunAlwaysAfterAgent(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "post-steps-always-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+post-steps:
+  - name: Upload Debug Logs
+    uses: actions/upload-artifact@b7c566a772e6b6bfb58ed0dc250532a479d7789f
+    with:
+      name: debug-logs
+      path: /tmp/gh-aw/
+  - name: Report Push
+    if: github.event_name == 'push'
+    run: echo "pushed"
+---
+
+# Post Steps
+
+Do the work.
+`
+
+	testFile := filepath.Join(tmpDir, "post-steps-always.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with post-steps should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	agentJob := extractJobSection(string(lockContent), "agent")
+
+	agentStepIndex := strings.Index(agentJob, "- name: Execute GitHub Copilot CLI")
+	uploadStepIndex := strings.Index(agentJob, "- name: Upload Debug Logs")
+	reportStepIndex := strings.Index(agentJob, "- name: Report Push")
+	require.NotEqual(t, -1, agentStepIndex, "Agent job should run the agent")
+	require.NotEqual(t, -1, uploadStepIndex, "Post-step should be in the agent job")
+	require.NotEqual(t, -1, reportStepIndex, "Post-step should be in the agent job")
+	assert.Greater(t, uploadStepIndex, agentStepIndex, "Post-steps should run after the agent")
+	assert.Greater(t, reportStepIndex, uploadStepIndex, "Post-steps should keep their order")
+
+	assert.Contains(t, agentJob, "      - name: Upload Debug Logs\n        if: always()\n", "Post-step without a condition should always run")
+	assert.Contains(t, agentJob, "      - name: Report Push\n        if: always() && (github.event_name == 'push')\n",
+		"Post-step condition should be combined with always()")
+}
+
+func TestCompileWorkflowPostStepsRejectsPermissions(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "post-steps-permissions-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+post-steps:
+  - name: Push Changes
+    run: git push
+    permissions:
+      contents: write
+---
+
+# Post Steps
+
+Do the work.
+`
+
+	testFile := filepath.Join(tmpDir, "post-steps-permissions.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Post-steps should not be able to request permissions")
+	assert.Contains(t, err.Error(), "'permissions' is a job field and cannot be set on a step")
+}
@@ -163,6 +163,7 @@ func (c *Compiler) buildUploadAssetsJob(data *WorkflowData, mainJobName string,
 		PreSteps:      preSteps,
 		Token:         data.SafeOutputs.UploadAssets.GitHubToken,
 		Needs:         needs,
+		Provenance:    "safe-outputs.upload-asset",
 	})
 }
 
@@ -21,6 +21,12 @@ var validReactions = map[string]bool{
 	"none":     true,
 }
 
+// ReactionCleanup configures what happens to the reaction once the workflow run completes
+type ReactionCleanup struct {
+	RemoveOnComplete bool // remove the reaction added on activation
+	OutcomeReaction  bool // add +1 when the agent succeeds and -1 when it fails
+}
+
 // isValidReaction checks if a reaction value is valid according to the schema
 func isValidReaction(reaction string) bool {
 	return validReactions[reaction]
@@ -94,3 +100,51 @@ func intToReactionString(v int64) (string, error) {
 		return "", fmt.Errorf("invalid reaction value '%d': must be one of %v", v, getValidReactions())
 	}
 }
+
+// parseReactionConfig parses the on.reaction value. Besides a plain reaction (eyes, +1, ...), it
+// accepts an object that also configures what happens to the reaction once the run completes:
+//
+//	reaction:
+//	  type: eyes                # defaults to eyes
+//	  remove-on-complete: true  # remove the reaction when the run completes
+//	  outcome-reaction: true    # add +1 on success and -1 on failure
+//
+// The returned cleanup is nil when neither completion option is enabled.
+func parseReactionConfig(value any) (string, *ReactionCleanup, error) {
+	configMap, ok := value.(map[string]any)
+	if !ok {
+		reaction, err := parseReactionValue(value)
+		return reaction, nil, err
+	}
+
+	reaction := "eyes"
+	if typeValue, hasType := configMap["type"]; hasType {
+		parsed, err := parseReactionValue(typeValue)
+		if err != nil {
+			return "", nil, err
+		}
+		reaction = parsed
+	}
+
+	var cleanup ReactionCleanup
+	for key, dest := range map[string]*bool{
+		"remove-on-complete": &cleanup.RemoveOnComplete,
+		"outcome-reaction":   &cleanup.OutcomeReaction,
+	} {
+		optionValue, exists := configMap[key]
+		if !exists {
+			continue
+		}
+		optionBool, isBool := optionValue.(bool)
+		if !isBool {
+			return "", nil, fmt.Errorf("reaction.%s must be a boolean value, got %T", key, optionValue)
+		}
+		*dest = optionBool
+	}
+
+	reactionsLog.Printf("Parsed reaction object: type=%s, remove-on-complete=%v, outcome-reaction=%v", reaction, cleanup.RemoveOnComplete, cleanup.OutcomeReaction)
+	if !cleanup.RemoveOnComplete && !cleanup.OutcomeReaction {
+		return reaction, nil, nil
+	}
+	return reaction, &cleanup, nil
+}
@@ -140,6 +140,51 @@ func TestParseReactionValue(t *testing.T) {
 	}
 }
 
+func TestParseReactionConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		value           any
+		expected        string
+		expectedCleanup *ReactionCleanup
+		expectError     bool
+	}{
+		{name: "plain reaction", value: "rocket", expected: "rocket"},
+		{name: "plain numeric reaction", value: int(1), expected: "+1"},
+		{name: "object with type only", value: map[string]any{"type": "heart"}, expected: "heart"},
+		{name: "object defaults to eyes", value: map[string]any{"remove-on-complete": true}, expected: "eyes", expectedCleanup: &ReactionCleanup{RemoveOnComplete: true}},
+		{
+			name:            "object with all options",
+			value:           map[string]any{"type": "rocket", "remove-on-complete": true, "outcome-reaction": true},
+			expected:        "rocket",
+			expectedCleanup: &ReactionCleanup{RemoveOnComplete: true, OutcomeReaction: true},
+		},
+		{name: "disabled options", value: map[string]any{"type": "eyes", "remove-on-complete": false}, expected: "eyes"},
+		{name: "non-boolean option", value: map[string]any{"remove-on-complete": "yes"}, expectError: true},
+		{name: "invalid type", value: map[string]any{"type": int(2)}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, cleanup, err := parseReactionConfig(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("parseReactionConfig(%v) expected error, got result %q", tt.value, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseReactionConfig(%v) unexpected error: %v", tt.value, err)
+			}
+			if result != tt.expected {
+				t.Errorf("parseReactionConfig(%v) = %q, want %q", tt.value, result, tt.expected)
+			}
+			if (cleanup == nil) != (tt.expectedCleanup == nil) || (cleanup != nil && *cleanup != *tt.expectedCleanup) {
+				t.Errorf("parseReactionConfig(%v) cleanup = %+v, want %+v", tt.value, cleanup, tt.expectedCleanup)
+			}
+		})
+	}
+}
+
 func TestIntToReactionString(t *testing.T) {
 	tests := []struct {
 		name        string
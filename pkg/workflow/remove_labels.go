@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"errors"
+
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -36,3 +38,33 @@ func (c *Compiler) parseRemoveLabelsConfig(outputMap map[string]any) *RemoveLabe
 
 	return &config
 }
+
+// buildRemoveLabelsJob creates the remove_labels job
+func (c *Compiler) buildRemoveLabelsJob(data *WorkflowData, mainJobName string) (*Job, error) {
+	removeLabelsLog.Printf("Building remove_labels job for workflow: %s, main_job: %s", data.Name, mainJobName)
+
+	if data.SafeOutputs == nil || data.SafeOutputs.RemoveLabels == nil {
+		return nil, errors.New("safe-outputs configuration is required")
+	}
+
+	cfg := data.SafeOutputs.RemoveLabels
+
+	// Build list job config; the runtime script ignores labels outside the allowlist
+	listJobConfig := ListJobConfig{
+		SafeOutputTargetConfig: cfg.SafeOutputTargetConfig,
+		Allowed:                cfg.Allowed,
+		Blocked:                cfg.Blocked,
+	}
+
+	// Use shared builder for list-based safe-output jobs
+	return c.BuildListSafeOutputJob(data, mainJobName, listJobConfig, cfg.BaseSafeOutputConfig, ListJobBuilderConfig{
+		JobName:     "remove_labels",
+		StepName:    "Remove Labels",
+		StepID:      "remove_labels",
+		EnvPrefix:   "GH_AW_LABELS",
+		OutputName:  "labels_removed",
+		Script:      getRemoveLabelsScript(),
+		Permissions: NewPermissionsContentsReadIssuesWritePRWrite(),
+		DefaultMax:  3,
+	})
+}
@@ -730,12 +730,13 @@ func (c *Compiler) buildPushRepoMemoryJob(data *WorkflowData, threatDetectionEna
 	job := &Job{
 		Name:        "push_repo_memory",
 		DisplayName: "", // No display name - job ID is sufficient
-		RunsOn:      "runs-on: ubuntu-latest",
+		RunsOn:      c.formatWorkflowRunsOn(data, "runs-on: ubuntu-latest"),
 		If:          jobCondition,
 		Permissions: "permissions:\n      contents: write",
 		Needs:       []string{"agent"}, // Detection dependency added by caller if needed
 		Steps:       steps,
 		Outputs:     outputs,
+		Provenance:  "tools.repo-memory",
 	}
 
 	return job, nil
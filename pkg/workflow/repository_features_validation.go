@@ -15,6 +15,7 @@
 //   - getRepositoryFeatures() - Gets repository features with caching (discussions, issues)
 //   - checkRepositoryHasDiscussions() - Checks if discussions are enabled (cached)
 //   - checkRepositoryHasIssues() - Checks if issues are enabled (cached)
+//   - getDiscussionCategories() - Gets the discussion categories of a repository (cached)
 //   - ClearRepositoryFeaturesCache() - Clears all repository feature caches
 //
 // # Validation Pattern: Feature Detection with Caching
@@ -64,6 +65,7 @@ type RepositoryFeatures struct {
 var (
 	repositoryFeaturesCache       = sync.Map{} // sync.Map is thread-safe and efficient for read-heavy workloads
 	repositoryFeaturesLoggedCache = sync.Map{} // Tracks which repositories have had their success messages logged
+	discussionCategoriesCache     = sync.Map{} // Discussion categories per repository
 	getCurrentRepositoryOnce      sync.Once
 	currentRepositoryResult       string
 	currentRepositoryError        error
@@ -84,6 +86,12 @@ func ClearRepositoryFeaturesCache() {
 		return true
 	})
 
+	// Clear the discussion categories cache
+	discussionCategoriesCache.Range(func(key, value any) bool {
+		discussionCategoriesCache.Delete(key)
+		return true
+	})
+
 	// Reset the current repository cache
 	getCurrentRepositoryOnce = sync.Once{}
 	currentRepositoryResult = ""
@@ -153,6 +161,13 @@ func (c *Compiler) validateRepositoryFeatures(workflowData *WorkflowData) error
 		}
 	}
 
+	// Check that the configured discussion category exists in the target repository
+	if err := c.validateCreateDiscussionCategory(workflowData.SafeOutputs.CreateDiscussions, repo); err != nil {
+		if returnErr := collector.Add(err); returnErr != nil {
+			return returnErr // Fail-fast mode
+		}
+	}
+
 	// Check if issues are enabled when create-issue is configured
 	if workflowData.SafeOutputs.CreateIssues != nil {
 		hasIssues, err := checkRepositoryHasIssues(repo, c.verbose)
@@ -350,3 +365,97 @@ func checkRepositoryHasIssuesUncached(repo string) (bool, error) {
 
 	return response.HasIssues, nil
 }
+
+// validateCreateDiscussionCategory checks the create-discussion category against the categories
+// of the target repository. Categories that use expressions and target repositories that are not
+// known at compile time are skipped, as are API failures.
+func (c *Compiler) validateCreateDiscussionCategory(config *CreateDiscussionsConfig, currentRepo string) error {
+	if config == nil || config.Category == "" || isGitHubExpression(config.Category) {
+		return nil
+	}
+
+	repo := discussionCategoryTargetRepo(config, currentRepo)
+	if repo == "" {
+		repositoryFeaturesLog.Printf("Skipping discussion category validation: target repository %q is not known at compile time", config.TargetRepoSlug)
+		return nil
+	}
+
+	categories, err := getDiscussionCategories(repo)
+	if err != nil {
+		repositoryFeaturesLog.Printf("Warning: Could not fetch discussion categories: %v", err)
+		if c.verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(
+				fmt.Sprintf("Could not verify discussion category '%s': %v", config.Category, err)))
+		}
+		return nil
+	}
+	if len(categories) == 0 {
+		// Discussions are disabled or have no categories; the discussions check reports this
+		repositoryFeaturesLog.Printf("Repository %s has no discussion categories", repo)
+		return nil
+	}
+
+	return validateDiscussionCategory(repo, config.Category, categories)
+}
+
+// getDiscussionCategories gets the discussion categories of a repository (with caching)
+func getDiscussionCategories(repo string) ([]DiscussionCategory, error) {
+	if cached, exists := discussionCategoriesCache.Load(repo); exists {
+		repositoryFeaturesLog.Printf("Using cached discussion categories for: %s", repo)
+		return cached.([]DiscussionCategory), nil
+	}
+
+	categories, err := getDiscussionCategoriesUncached(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := discussionCategoriesCache.LoadOrStore(repo, categories)
+	return actual.([]DiscussionCategory), nil
+}
+
+// getDiscussionCategoriesUncached fetches the discussion categories of a repository (no caching)
+func getDiscussionCategoriesUncached(repo string) ([]DiscussionCategory, error) {
+	query := `query($owner: String!, $name: String!) {
+		repository(owner: $owner, name: $name) {
+			discussionCategories(first: 100) {
+				nodes {
+					id
+					name
+					slug
+				}
+			}
+		}
+	}`
+
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid repository format: %s. Expected format: owner/repo. Example: github/gh-aw", repo)
+	}
+	owner, name := parts[0], parts[1]
+
+	type GraphQLResponse struct {
+		Data struct {
+			Repository struct {
+				DiscussionCategories struct {
+					Nodes []DiscussionCategory `json:"nodes"`
+				} `json:"discussionCategories"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	stdOut, _, err := gh.Exec("api", "graphql", "-f", "query="+query,
+		"-f", "owner="+owner, "-f", "name="+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query discussion categories: %w", err)
+	}
+
+	var response GraphQLResponse
+	if err := json.Unmarshal(stdOut.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL response: %w", err)
+	}
+
+	categories := response.Data.Repository.DiscussionCategories.Nodes
+	repositoryFeaturesLog.Printf("Fetched %d discussion categories for: %s", len(categories), repo)
+	return categories, nil
+}
@@ -0,0 +1,127 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var requiredSecretsLog = logger.New("workflow:required_secrets")
+
+// secretNamePattern matches names usable both as GitHub secret names and as environment variable names
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// RequiredSecret is a secret declared with required: true in the frontmatter secrets block
+type RequiredSecret struct {
+	Name        string // secret name, also used as the environment variable name in the guard step
+	Value       string // expression providing the secret value (defaults to ${{ secrets.<Name> }})
+	Description string // optional human description shown when the secret is missing
+}
+
+// extractRequiredSecrets extracts secrets declared with required: true from the frontmatter secrets block.
+// Entries are returned sorted by name for deterministic output.
+func extractRequiredSecrets(frontmatter map[string]any) ([]RequiredSecret, error) {
+	secrets, ok := frontmatter["secrets"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	var required []RequiredSecret
+	for name, value := range secrets {
+		config, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		if isRequired, _ := config["required"].(bool); !isRequired {
+			continue
+		}
+		if !secretNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("secrets.%s: required secret names may only contain letters, digits, and underscores and must not start with a digit", name)
+		}
+
+		secret := RequiredSecret{Name: name, Value: fmt.Sprintf("${{ secrets.%s }}", name)}
+		if v, ok := config["value"].(string); ok && v != "" {
+			secret.Value = v
+		}
+		if d, ok := config["description"].(string); ok {
+			secret.Description = d
+		}
+		required = append(required, secret)
+	}
+
+	sort.Slice(required, func(i, j int) bool {
+		return required[i].Name < required[j].Name
+	})
+	requiredSecretsLog.Printf("Extracted %d required secrets", len(required))
+	return required, nil
+}
+
+// findUnreferencedRequiredSecrets returns the required secrets that the workflow never references
+// as secrets.<NAME>. References are searched for in the frontmatter (excluding the secrets block itself),
+// the merged tools configuration (including imported MCP servers), and the secrets the engine consumes.
+func (c *Compiler) findUnreferencedRequiredSecrets(workflowData *WorkflowData) []string {
+	if len(workflowData.RequiredSecrets) == 0 {
+		return nil
+	}
+
+	var sources []string
+	if workflowData.RawFrontmatter != nil {
+		frontmatter := maps.Clone(workflowData.RawFrontmatter)
+		delete(frontmatter, "secrets")
+		if data, err := json.Marshal(frontmatter); err == nil {
+			sources = append(sources, string(data))
+		}
+	}
+	if data, err := json.Marshal(workflowData.Tools); err == nil {
+		sources = append(sources, string(data))
+	}
+	content := strings.Join(sources, "\n")
+
+	engineSecrets := make(map[string]bool)
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.ID != "" {
+		if engine, err := c.engineRegistry.GetEngine(workflowData.EngineConfig.ID); err == nil {
+			for _, name := range engine.GetRequiredSecretNames(workflowData) {
+				engineSecrets[name] = true
+			}
+		}
+	}
+
+	var unreferenced []string
+	for _, secret := range workflowData.RequiredSecrets {
+		if engineSecrets[secret.Name] {
+			continue
+		}
+		pattern := regexp.MustCompile(`\bsecrets\.` + regexp.QuoteMeta(secret.Name) + `\b`)
+		if !pattern.MatchString(content) {
+			unreferenced = append(unreferenced, secret.Name)
+		}
+	}
+	requiredSecretsLog.Printf("Found %d unreferenced required secrets", len(unreferenced))
+	return unreferenced
+}
+
+// generateRequiredSecretsCheck adds the pre-activation step that fails when a required secret is not set
+func (c *Compiler) generateRequiredSecretsCheck(data *WorkflowData, steps []string) []string {
+	names := make([]string, 0, len(data.RequiredSecrets))
+	for _, secret := range data.RequiredSecrets {
+		names = append(names, secret.Name)
+	}
+
+	steps = append(steps, "      - name: Validate required secrets\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckRequiredSecretsStepID))
+	steps = append(steps, "        run: /opt/gh-aw/actions/validate_required_secrets.sh "+strings.Join(names, " ")+"\n")
+	steps = append(steps, "        env:\n")
+	for _, secret := range data.RequiredSecrets {
+		steps = append(steps, fmt.Sprintf("          %s: %s\n", secret.Name, secret.Value))
+		if secret.Description != "" {
+			steps = append(steps, fmt.Sprintf("          GH_AW_SECRET_DESCRIPTION_%s: %q\n", secret.Name, secret.Description))
+		}
+	}
+	return steps
+}
@@ -0,0 +1,107 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractRequiredSecrets(t *testing.T) {
+	frontmatter := map[string]any{
+		"secrets": map[string]any{
+			"PLAIN": "${{ secrets.PLAIN }}",
+			"OPTIONAL": map[string]any{
+				"value":       "${{ secrets.OPTIONAL }}",
+				"description": "not required",
+			},
+			"SLACK_TOKEN": map[string]any{
+				"required":    true,
+				"description": "Bot token for posting updates",
+			},
+			"API_KEY": map[string]any{
+				"required": true,
+				"value":    "${{ secrets.PROD_API_KEY }}",
+			},
+		},
+	}
+
+	secrets, err := extractRequiredSecrets(frontmatter)
+	require.NoError(t, err)
+	assert.Equal(t, []RequiredSecret{
+		{Name: "API_KEY", Value: "${{ secrets.PROD_API_KEY }}"},
+		{Name: "SLACK_TOKEN", Value: "${{ secrets.SLACK_TOKEN }}", Description: "Bot token for posting updates"},
+	}, secrets, "only required secrets should be extracted, sorted by name, with value defaulting to secrets.<NAME>")
+
+	_, err = extractRequiredSecrets(map[string]any{
+		"secrets": map[string]any{"MY-SECRET": map[string]any{"required": true}},
+	})
+	require.Error(t, err, "names that are not valid environment variable names should be rejected")
+	assert.Contains(t, err.Error(), "secrets.MY-SECRET")
+}
+
+// compileRequiredSecretsWorkflow compiles a workflow with the given frontmatter secrets block and extra frontmatter
+func compileRequiredSecretsWorkflow(t *testing.T, secrets, extra string) (*Compiler, string) {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "required-secrets-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+secrets:
+` + secrets + `
+` + extra + `---
+
+# Test Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+	return compiler, string(lockContent)
+}
+
+func TestRequiredSecretsGuardStep(t *testing.T) {
+	compiler, lockStr := compileRequiredSecretsWorkflow(t, `  SLACK_TOKEN:
+    required: true
+    description: Bot token for posting updates`, `mcp-servers:
+  slack:
+    container: mcp/slack
+    env:
+      SLACK_BOT_TOKEN: ${{ secrets.SLACK_TOKEN }}
+    allowed: ["*"]
+`)
+
+	assert.Contains(t, lockStr, "  pre_activation:", "required secrets should create the pre-activation job")
+	assert.Contains(t, lockStr, "- name: Validate required secrets")
+	assert.Contains(t, lockStr, "run: /opt/gh-aw/actions/validate_required_secrets.sh SLACK_TOKEN")
+	assert.Contains(t, lockStr, "SLACK_TOKEN: ${{ secrets.SLACK_TOKEN }}")
+	assert.Contains(t, lockStr, `GH_AW_SECRET_DESCRIPTION_SLACK_TOKEN: "Bot token for posting updates"`)
+	assert.Contains(t, lockStr, "steps.check_required_secrets.outputs.secrets_ok == 'true'", "activation should depend on the secrets check")
+	assert.Zero(t, compiler.GetWarningCount(), "a referenced required secret should not warn")
+}
+
+func TestRequiredSecretUnreferencedWarns(t *testing.T) {
+	compiler, lockStr := compileRequiredSecretsWorkflow(t, `  UNUSED_TOKEN:
+    required: true`, "")
+
+	assert.Contains(t, lockStr, "validate_required_secrets.sh UNUSED_TOKEN", "unreferenced required secrets are still checked")
+	assert.Equal(t, 1, compiler.GetWarningCount(), "an unreferenced required secret should warn")
+}
+
+func TestRequiredSecretReferencedByEngine(t *testing.T) {
+	compiler, _ := compileRequiredSecretsWorkflow(t, `  COPILOT_GITHUB_TOKEN:
+    required: true`, "")
+
+	assert.Zero(t, compiler.GetWarningCount(), "secrets consumed by the engine count as referenced")
+}
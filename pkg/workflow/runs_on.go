@@ -0,0 +1,33 @@
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var runsOnLog = logger.New("workflow:runs_on")
+
+// hasWorkflowRunsOn reports whether the workflow pins a runner with a top-level runs-on field
+func hasWorkflowRunsOn(data *WorkflowData) bool {
+	_, pinned := data.RawFrontmatter["runs-on"]
+	return pinned && data.RunsOn != ""
+}
+
+// formatWorkflowRunsOn returns the runs-on line for a generated job: the workflow-level
+// runs-on when the workflow pins a runner, otherwise fallback
+func (c *Compiler) formatWorkflowRunsOn(data *WorkflowData, fallback string) string {
+	if !hasWorkflowRunsOn(data) {
+		return fallback
+	}
+	runsOnLog.Print("Using workflow-level runs-on for generated job")
+	return c.indentYAMLLines(data.RunsOn, "    ")
+}
+
+// formatGeneratedJobRunsOn returns the runs-on line for the activation, pre-activation and
+// safe-output jobs. safe-outputs.runs-on takes precedence over the workflow-level runs-on,
+// which takes precedence over the default activation runner image.
+func (c *Compiler) formatGeneratedJobRunsOn(data *WorkflowData) string {
+	if data.SafeOutputs != nil && data.SafeOutputs.RunsOn != "" {
+		return c.formatSafeOutputsRunsOn(data.SafeOutputs)
+	}
+	return c.formatWorkflowRunsOn(data, c.formatSafeOutputsRunsOn(data.SafeOutputs))
+}
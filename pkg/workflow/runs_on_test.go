@@ -0,0 +1,89 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compileRunsOnTestJobs compiles a workflow and returns the jobs of the lock file
+func compileRunsOnTestJobs(t *testing.T, frontmatter string) map[string]any {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "runs-on-test")
+	testFile := filepath.Join(tmpDir, "runner.md")
+	content := "---\n" + frontmatter + "---\n\n# Triage\n\nTriage the issue.\n"
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile))
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+
+	var lock map[string]any
+	require.NoError(t, yaml.Unmarshal(lockContent, &lock))
+	jobs, ok := lock["jobs"].(map[string]any)
+	require.True(t, ok, "lock file should have jobs")
+	return jobs
+}
+
+const runsOnTestFrontmatter = `on:
+  issues:
+    types: [opened]
+  reaction: eyes
+permissions:
+  contents: read
+engine: copilot
+tools:
+  cache-memory: true
+safe-outputs:
+  add-comment:
+  jobs:
+    notify:
+      steps:
+        - run: echo notify
+`
+
+func TestCompileWorkflowRunsOnAppliesToAllJobs(t *testing.T) {
+	jobs := compileRunsOnTestJobs(t, "runs-on: ubuntu-24.04\n"+runsOnTestFrontmatter)
+
+	for _, name := range []string{"pre_activation", "activation", "agent", "detection", "safe_outputs", "conclusion", "update_cache_memory", "notify"} {
+		assert.Contains(t, jobs, name, "expected job %s to be generated", name)
+	}
+	for name, job := range jobs {
+		assert.Equal(t, "ubuntu-24.04", job.(map[string]any)["runs-on"], "job %s should use the pinned runner", name)
+	}
+}
+
+func TestCompileWorkflowRunsOnArray(t *testing.T) {
+	jobs := compileRunsOnTestJobs(t, "runs-on: [self-hosted, linux, x64]\n"+runsOnTestFrontmatter)
+
+	for name, job := range jobs {
+		assert.Equal(t, []any{"self-hosted", "linux", "x64"}, job.(map[string]any)["runs-on"], "job %s should use the pinned runner labels", name)
+	}
+}
+
+func TestCompileWorkflowSafeOutputsRunsOnOverridesWorkflowRunsOn(t *testing.T) {
+	frontmatter := "runs-on: ubuntu-24.04\n" + runsOnTestFrontmatter + "  runs-on: ubuntu-22.04\n"
+	jobs := compileRunsOnTestJobs(t, frontmatter)
+
+	assert.Equal(t, "ubuntu-24.04", jobs["agent"].(map[string]any)["runs-on"])
+	assert.Equal(t, "ubuntu-24.04", jobs["detection"].(map[string]any)["runs-on"])
+	assert.Equal(t, "ubuntu-22.04", jobs["activation"].(map[string]any)["runs-on"])
+	assert.Equal(t, "ubuntu-22.04", jobs["safe_outputs"].(map[string]any)["runs-on"])
+}
+
+func TestCompileWorkflowWithoutRunsOnKeepsDefaultRunners(t *testing.T) {
+	jobs := compileRunsOnTestJobs(t, runsOnTestFrontmatter)
+
+	assert.Equal(t, "ubuntu-latest", jobs["agent"].(map[string]any)["runs-on"])
+	assert.Equal(t, "ubuntu-latest", jobs["detection"].(map[string]any)["runs-on"])
+	assert.Equal(t, "ubuntu-slim", jobs["activation"].(map[string]any)["runs-on"])
+	assert.Equal(t, "ubuntu-slim", jobs["safe_outputs"].(map[string]any)["runs-on"])
+}
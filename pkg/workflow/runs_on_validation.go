@@ -11,6 +11,7 @@
 // # Validation Functions
 //
 //   - validateRunsOn() - Validates the runs-on field for unsupported runner types
+//   - validateRunnerLabel() - Rejects malformed runner labels
 //   - extractRunnerLabels() - Extracts individual runner labels from runs-on value
 //
 // # When to Add Validation Here
@@ -23,6 +24,7 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -34,9 +36,10 @@ var runsOnValidationLog = logger.New("workflow:runs_on_validation")
 // macOSRunnerFAQURL is the URL to the FAQ entry explaining why macOS runners are not supported.
 const macOSRunnerFAQURL = "https://github.github.com/gh-aw/reference/faq/#why-are-macos-runners-not-supported"
 
-// validateRunsOn validates that the runs-on field does not specify macOS runners,
-// which are not supported in agentic workflows because they do not support
-// container jobs required for the Agent Workflow Firewall sandbox.
+// validateRunsOn validates that the runs-on field names at least one well-formed runner
+// label and does not specify macOS runners, which are not supported in agentic workflows
+// because they do not support container jobs required for the Agent Workflow Firewall sandbox.
+// The runner is applied to every generated job, so a malformed value would break all of them.
 //
 // Returns an error with a FAQ link if a macOS runner is detected, nil otherwise.
 func validateRunsOn(frontmatter map[string]any, markdownPath string) error {
@@ -48,7 +51,16 @@ func validateRunsOn(frontmatter map[string]any, markdownPath string) error {
 	runsOnValidationLog.Printf("Validating runs-on configuration")
 
 	labels := extractRunnerLabels(runsOn)
+	if group, _ := runsOnGroup(runsOn); len(labels) == 0 && group == "" {
+		return formatCompilerError(markdownPath, "error",
+			"runs-on must name at least one runner label or runner group.\n\n"+
+				"Example: runs-on: ubuntu-24.04", nil)
+	}
 	for _, label := range labels {
+		if err := validateRunnerLabel(label); err != nil {
+			return formatCompilerError(markdownPath, "error", err.Error(), nil)
+		}
+
 		lower := strings.ToLower(label)
 		if strings.HasPrefix(lower, "macos-") || lower == "macos" {
 			return formatCompilerError(markdownPath, "error",
@@ -66,6 +78,36 @@ func validateRunsOn(frontmatter map[string]any, markdownPath string) error {
 	return nil
 }
 
+// validateRunnerLabel rejects runner labels that cannot match any runner, such as empty labels,
+// labels containing whitespace and values that repeat the runs-on key
+func validateRunnerLabel(label string) error {
+	trimmed := strings.TrimSpace(label)
+	if trimmed == "" {
+		return errors.New("runs-on contains an empty runner label.\n\nExample: runs-on: ubuntu-24.04")
+	}
+	if strings.HasPrefix(trimmed, "${{") {
+		// Expressions are resolved by GitHub Actions at runtime
+		return nil
+	}
+	if strings.HasPrefix(strings.ToLower(trimmed), "runs-on:") {
+		return fmt.Errorf("runner label '%s' repeats the runs-on key.\n\nExample: runs-on: ubuntu-24.04", label)
+	}
+	if strings.ContainsAny(label, " \t\r\n,") {
+		return fmt.Errorf("runner label '%s' contains whitespace or a comma.\n\nUse a list for several labels. Example: runs-on: [self-hosted, linux]", label)
+	}
+	return nil
+}
+
+// runsOnGroup returns the runner group of the object form of runs-on
+func runsOnGroup(runsOn any) (string, bool) {
+	runsOnMap, ok := runsOn.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	group, ok := runsOnMap["group"].(string)
+	return strings.TrimSpace(group), ok
+}
+
 // extractRunnerLabels extracts individual runner label strings from a runs-on value.
 // Handles all supported GitHub Actions runs-on forms:
 //   - string: "ubuntu-latest"
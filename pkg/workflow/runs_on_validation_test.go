@@ -119,6 +119,59 @@ func TestValidateRunsOn(t *testing.T) {
 			errorInMsg:  "containers",
 			description: "Error should explain containers requirement",
 		},
+		{
+			name:        "pinned runner image",
+			frontmatter: map[string]any{"runs-on": "ubuntu-24.04"},
+			wantErr:     false,
+			description: "Pinned runner images should be allowed",
+		},
+		{
+			name:        "expression label",
+			frontmatter: map[string]any{"runs-on": "${{ vars.AGENT_RUNNER || 'ubuntu-latest' }}"},
+			wantErr:     false,
+			description: "Expressions are resolved at runtime and should be allowed",
+		},
+		{
+			name:        "empty string",
+			frontmatter: map[string]any{"runs-on": ""},
+			wantErr:     true,
+			errorInMsg:  "empty runner label",
+			description: "Empty runs-on should be rejected",
+		},
+		{
+			name:        "empty array",
+			frontmatter: map[string]any{"runs-on": []any{}},
+			wantErr:     true,
+			errorInMsg:  "at least one runner label",
+			description: "Empty runs-on array should be rejected",
+		},
+		{
+			name:        "object without labels or group",
+			frontmatter: map[string]any{"runs-on": map[string]any{}},
+			wantErr:     true,
+			errorInMsg:  "at least one runner label",
+			description: "Object form without labels or group should be rejected",
+		},
+		{
+			name:        "object with group only",
+			frontmatter: map[string]any{"runs-on": map[string]any{"group": "larger-runners"}},
+			wantErr:     false,
+			description: "Object form with only a group should be allowed",
+		},
+		{
+			name:        "comma separated labels",
+			frontmatter: map[string]any{"runs-on": "self-hosted, linux"},
+			wantErr:     true,
+			errorInMsg:  "whitespace or a comma",
+			description: "Several labels in one string should be rejected",
+		},
+		{
+			name:        "repeated runs-on key",
+			frontmatter: map[string]any{"runs-on": "runs-on: ubuntu-latest"},
+			wantErr:     true,
+			errorInMsg:  "repeats the runs-on key",
+			description: "A value that repeats the key should be rejected",
+		},
 	}
 
 	for _, tt := range tests {
@@ -165,7 +165,8 @@ func (c *Compiler) buildSafeJobs(data *WorkflowData, threatDetectionEnabled bool
 		normalizedJobName := stringutil.NormalizeSafeOutputIdentifier(jobName)
 
 		job := &Job{
-			Name: normalizedJobName,
+			Name:       normalizedJobName,
+			Provenance: "safe-outputs.jobs." + jobName,
 		}
 
 		// Set custom job name if specified
@@ -199,7 +200,7 @@ func (c *Compiler) buildSafeJobs(data *WorkflowData, threatDetectionEnabled bool
 				}
 			}
 		} else {
-			job.RunsOn = "runs-on: ubuntu-latest" // Default
+			job.RunsOn = c.formatWorkflowRunsOn(data, "runs-on: ubuntu-latest") // Default
 		}
 
 		// Set if condition - combine safe output type check with user-provided condition
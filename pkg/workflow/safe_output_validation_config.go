@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"encoding/json"
+	"maps"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -61,6 +62,22 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"repo": {Type: "string", MaxLength: 256}, // Optional: target repository in format "owner/repo"
 		},
 	},
+	"create_gist": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"content":     {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
+			"filename":    {Type: "string", Sanitize: true, MaxLength: 128},
+			"description": {Type: "string", Sanitize: true, MaxLength: 256},
+		},
+	},
+	"create_release": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"tag":  {Required: true, Type: "string", Sanitize: true, MaxLength: 128},
+			"name": {Type: "string", Sanitize: true, MaxLength: 256},
+			"body": {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
+		},
+	},
 	"add_comment": {
 		DefaultMax: 1,
 		Fields: map[string]FieldValidation{
@@ -178,6 +195,15 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"event": {Type: "string", Enum: []string{"APPROVE", "REQUEST_CHANGES", "COMMENT"}},
 		},
 	},
+	"create_pull_request_review": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"body":                {Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
+			"event":               {Type: "string", Enum: []string{"APPROVE", "REQUEST_CHANGES", "COMMENT"}},
+			"comments":            {Type: "array"},
+			"pull_request_number": {OptionalPositiveInteger: true},
+		},
+	},
 	"reply_to_pull_request_review_comment": {
 		DefaultMax: 10,
 		Fields: map[string]FieldValidation{
@@ -373,22 +399,54 @@ var ValidationConfig = map[string]TypeValidationConfig{
 // If enabledTypes is empty or nil, returns all validation configs
 // If enabledTypes is provided, returns only configs for the specified types
 func GetValidationConfigJSON(enabledTypes []string) (string, error) {
-	safeOutputValidationLog.Printf("Getting validation config JSON for %d types", len(enabledTypes))
+	return marshalValidationConfig(filterValidationConfig(enabledTypes))
+}
 
-	configToMarshal := ValidationConfig
-	if len(enabledTypes) > 0 {
-		safeOutputValidationLog.Printf("Filtering validation configs to enabled types: %v", enabledTypes)
-		configToMarshal = make(map[string]TypeValidationConfig)
-		for _, typeName := range enabledTypes {
-			if config, ok := ValidationConfig[typeName]; ok {
-				configToMarshal[typeName] = config
-			}
+// getWorkflowValidationConfigJSON returns the validation configuration for enabledTypes as
+// indented JSON, adjusted for safe-output settings that relax field requirements
+func getWorkflowValidationConfigJSON(enabledTypes []string, safeOutputs *SafeOutputsConfig) (string, error) {
+	configs := filterValidationConfig(enabledTypes)
+	for _, typeName := range []string{"close_issue", "close_pull_request"} {
+		config, ok := configs[typeName]
+		closeConfig := closeCommentConfig(typeName, safeOutputs)
+		if !ok || closeConfig == nil || closeRequiresComment(closeConfig) {
+			continue
 		}
-	} else {
+		// Copy the fields so the shared ValidationConfig is not modified
+		fields := maps.Clone(config.Fields)
+		body := fields["body"]
+		body.Required = false
+		fields["body"] = body
+		config.Fields = fields
+		configs[typeName] = config
+		safeOutputValidationLog.Printf("Made body optional for %s (require-comment: false)", typeName)
+	}
+	return marshalValidationConfig(configs)
+}
+
+// filterValidationConfig returns the validation configs of enabledTypes, or all configs when
+// enabledTypes is empty. The returned map is a copy that callers may modify.
+func filterValidationConfig(enabledTypes []string) map[string]TypeValidationConfig {
+	safeOutputValidationLog.Printf("Getting validation config JSON for %d types", len(enabledTypes))
+
+	if len(enabledTypes) == 0 {
 		safeOutputValidationLog.Print("Returning all validation configs")
+		return maps.Clone(ValidationConfig)
+	}
+
+	safeOutputValidationLog.Printf("Filtering validation configs to enabled types: %v", enabledTypes)
+	configs := make(map[string]TypeValidationConfig)
+	for _, typeName := range enabledTypes {
+		if config, ok := ValidationConfig[typeName]; ok {
+			configs[typeName] = config
+		}
 	}
+	return configs
+}
 
-	data, err := json.MarshalIndent(configToMarshal, "", "  ")
+// marshalValidationConfig marshals validation configs as indented JSON
+func marshalValidationConfig(configs map[string]TypeValidationConfig) (string, error) {
+	data, err := json.MarshalIndent(configs, "", "  ")
 	if err != nil {
 		safeOutputValidationLog.Printf("Failed to marshal validation config: %v", err)
 		return "", err
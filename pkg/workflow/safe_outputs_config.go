@@ -71,6 +71,18 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.CreateAgentSessions = agentSessionConfig
 			}
 
+			// Handle create-gist
+			createGistConfig := c.parseCreateGistConfig(outputMap)
+			if createGistConfig != nil {
+				config.CreateGists = createGistConfig
+			}
+
+			// Handle create-release
+			createReleaseConfig := c.parseCreateReleaseConfig(outputMap)
+			if createReleaseConfig != nil {
+				config.CreateReleases = createReleaseConfig
+			}
+
 			// Handle update-project (smart project board management)
 			updateProjectConfig := c.parseUpdateProjectConfig(outputMap)
 			if updateProjectConfig != nil {
@@ -144,6 +156,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.SubmitPullRequestReview = submitPRReviewConfig
 			}
 
+			// Handle create-pull-request-review
+			createPRReviewConfig := c.parseCreatePullRequestReviewConfig(outputMap)
+			if createPRReviewConfig != nil {
+				config.CreatePullRequestReviews = createPRReviewConfig
+			}
+
 			// Handle reply-to-pull-request-review-comment
 			replyToPRReviewCommentConfig := c.parseReplyToPullRequestReviewCommentConfig(outputMap)
 			if replyToPRReviewCommentConfig != nil {
@@ -225,6 +243,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.AssignToAgent = assignToAgentConfig
 			}
 
+			// Handle assign-to-copilot, which expands into assign-to-agent
+			if c.parseAssignToCopilotConfig(outputMap) {
+				config.AssignToCopilot = true
+				config.AssignToAgent = expandAssignToCopilot(config.AssignToAgent)
+			}
+
 			// Handle assign-to-user
 			assignToUserConfig := c.parseAssignToUserConfig(outputMap)
 			if assignToUserConfig != nil {
@@ -345,6 +369,48 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				}
 			}
 
+			// Handle dry-run flag
+			if dryRun, exists := outputMap["dry-run"]; exists {
+				if dryRunBool, ok := dryRun.(bool); ok {
+					config.DryRun = dryRunBool
+				}
+			}
+
+			// Handle max-total configuration
+			if maxTotal, exists := outputMap["max-total"]; exists {
+				switch v := maxTotal.(type) {
+				case int:
+					if v >= 1 {
+						config.MaxTotal = v
+					}
+				case int64:
+					if v >= 1 {
+						config.MaxTotal = int(v)
+					}
+				case uint64:
+					if v >= 1 {
+						config.MaxTotal = int(v)
+					}
+				case float64:
+					intVal := int(v)
+					if v != float64(intVal) {
+						safeOutputsConfigLog.Printf("max-total: float value %.2f truncated to integer %d", v, intVal)
+					}
+					if intVal >= 1 {
+						config.MaxTotal = intVal
+					}
+				}
+			}
+
+			// Handle throttle-ms configuration
+			if throttleMs, exists := outputMap["throttle-ms"]; exists {
+				if value, ok := parseIntValue(throttleMs); ok && value >= 1 {
+					config.ThrottleMs = value
+				} else {
+					safeOutputsConfigLog.Printf("throttle-ms: ignoring invalid value %v", throttleMs)
+				}
+			}
+
 			// Handle env configuration
 			if env, exists := outputMap["env"]; exists {
 				if envMap, ok := env.(map[string]any); ok {
@@ -177,6 +177,18 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 				1, // default max
 			)
 		}
+		if data.SafeOutputs.CreateGists != nil {
+			safeOutputsConfig["create_gist"] = generateMaxConfig(
+				data.SafeOutputs.CreateGists.Max,
+				1, // default max
+			)
+		}
+		if data.SafeOutputs.CreateReleases != nil {
+			safeOutputsConfig["create_release"] = generateMaxConfig(
+				data.SafeOutputs.CreateReleases.Max,
+				1, // default max
+			)
+		}
 		if data.SafeOutputs.AddComments != nil {
 			additionalFields := make(map[string]any)
 			// Note: AddCommentsConfig has Target, TargetRepoSlug, AllowedRepos but not embedded SafeOutputTargetConfig
@@ -251,6 +263,12 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 				1, // default max
 			)
 		}
+		if data.SafeOutputs.CreatePullRequestReviews != nil {
+			safeOutputsConfig["create_pull_request_review"] = generateMaxConfig(
+				data.SafeOutputs.CreatePullRequestReviews.Max,
+				1, // default max
+			)
+		}
 		if data.SafeOutputs.ResolvePullRequestReviewThread != nil {
 			safeOutputsConfig["resolve_pull_request_review_thread"] = generateMaxConfig(
 				data.SafeOutputs.ResolvePullRequestReviewThread.Max,
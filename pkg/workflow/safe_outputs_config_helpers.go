@@ -19,6 +19,8 @@ var safeOutputReflectionLog = logger.New("workflow:safe_outputs_config_helpers_r
 var safeOutputFieldMapping = map[string]string{
 	"CreateIssues":                    "create_issue",
 	"CreateAgentSessions":             "create_agent_session",
+	"CreateGists":                     "create_gist",
+	"CreateReleases":                  "create_release",
 	"CreateDiscussions":               "create_discussion",
 	"UpdateDiscussions":               "update_discussion",
 	"CloseDiscussions":                "close_discussion",
@@ -28,6 +30,7 @@ var safeOutputFieldMapping = map[string]string{
 	"CreatePullRequests":              "create_pull_request",
 	"CreatePullRequestReviewComments": "create_pull_request_review_comment",
 	"SubmitPullRequestReview":         "submit_pull_request_review",
+	"CreatePullRequestReviews":        "create_pull_request_review",
 	"ReplyToPullRequestReviewComment": "reply_to_pull_request_review_comment",
 	"ResolvePullRequestReviewThread":  "resolve_pull_request_review_thread",
 	"CreateCodeScanningAlerts":        "create_code_scanning_alert",
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 )
 
 var safeOutputsDomainsValidationLog = logger.New("workflow:safe_outputs_domains_validation")
@@ -26,7 +27,13 @@ func (c *Compiler) validateNetworkAllowedDomains(network *NetworkPermissions) er
 			continue
 		}
 
-		if err := validateDomainPattern(domain); err != nil {
+		// CIDR ranges (e.g. 10.0.0.0/8) are validated as IP ranges, not domain patterns
+		validate := validateDomainPattern
+		if isCIDREntry(domain) {
+			validate = validateCIDR
+		}
+
+		if err := validate(domain); err != nil {
 			wrappedErr := fmt.Errorf("network.allowed[%d]: %w", i, err)
 			if returnErr := collector.Add(wrappedErr); returnErr != nil {
 				return returnErr // Fail-fast mode
@@ -37,6 +44,54 @@ func (c *Compiler) validateNetworkAllowedDomains(network *NetworkPermissions) er
 	return collector.Error()
 }
 
+// normalizeNetworkAllowedDomains lowercases and deduplicates the network allowed domains
+// before the firewall rules are generated, warning about the duplicates that were removed.
+// Protocol-specific entries (http:// or https://) keep their protocol and have their host
+// normalized; ecosystem identifiers and CIDR ranges are only lowercased.
+func (c *Compiler) normalizeNetworkAllowedDomains(network *NetworkPermissions) error {
+	if network == nil || len(network.Allowed) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(network.Allowed))
+	var normalized, duplicates []string
+	for i, entry := range network.Allowed {
+		domain := strings.ToLower(strings.TrimSpace(entry))
+		if !isEcosystemIdentifier(domain) && !isCIDREntry(domain) {
+			protocol, host := splitDomainProtocol(domain)
+			hosts, _, err := parser.NormalizeDomains([]string{host})
+			if err != nil {
+				return fmt.Errorf("network.allowed[%d]: %w", i, err)
+			}
+			domain = protocol + hosts[0]
+		}
+
+		if seen[domain] {
+			duplicates = append(duplicates, domain)
+			continue
+		}
+		seen[domain] = true
+		normalized = append(normalized, domain)
+	}
+
+	if len(duplicates) > 0 {
+		safeOutputsDomainsValidationLog.Printf("Removed %d duplicate network allowed domain(s): %v", len(duplicates), duplicates)
+		c.addWarning("Removed duplicate network.allowed entries: " + strings.Join(duplicates, ", "))
+	}
+	network.Allowed = normalized
+	return nil
+}
+
+// splitDomainProtocol splits the optional http:// or https:// prefix from a domain entry
+func splitDomainProtocol(domain string) (string, string) {
+	for _, protocol := range []string{"https://", "http://"} {
+		if host, ok := strings.CutPrefix(domain, protocol); ok {
+			return protocol, host
+		}
+	}
+	return "", domain
+}
+
 // isEcosystemIdentifier checks if a domain string is actually an ecosystem identifier
 func isEcosystemIdentifier(domain string) bool {
 	// Ecosystem identifiers don't contain dots and don't have protocol prefixes
@@ -3,8 +3,11 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -869,3 +872,48 @@ func TestValidateSafeOutputsAllowedDomainsIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeNetworkAllowedDomains(t *testing.T) {
+	compiler := NewCompiler()
+	network := &NetworkPermissions{
+		Allowed: []string{"github.com", "defaults", "GitHub.com", "https://Secure.Example.com", "https://secure.example.com", "http://secure.example.com", "10.0.0.0/8"},
+	}
+
+	require.NoError(t, compiler.normalizeNetworkAllowedDomains(network))
+	assert.Equal(t, []string{"github.com", "defaults", "https://secure.example.com", "http://secure.example.com", "10.0.0.0/8"}, network.Allowed,
+		"duplicates should be removed while protocol-specific entries stay distinct")
+	assert.Equal(t, 1, compiler.GetWarningCount(), "removed duplicates should produce a warning")
+
+	unique := &NetworkPermissions{Allowed: []string{"github.com", "api.github.com"}}
+	compiler = NewCompiler()
+	require.NoError(t, compiler.normalizeNetworkAllowedDomains(unique))
+	assert.Equal(t, []string{"github.com", "api.github.com"}, unique.Allowed)
+	assert.Equal(t, 0, compiler.GetWarningCount())
+}
+
+func TestNetworkAllowedDomainsDedupCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "network-dedup-test")
+
+	testContent := `---
+on: issues
+engine: copilot
+network:
+  allowed:
+    - defaults
+    - github.com
+    - github.com
+---
+
+# Dedup
+`
+	testFile := filepath.Join(tmpDir, "dedup.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+	assert.Positive(t, compiler.GetWarningCount(), "duplicate github.com should produce a warning")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "dedup.lock.yml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(lockContent), "github.com,github.com", "allow-domains should not list github.com twice")
+}
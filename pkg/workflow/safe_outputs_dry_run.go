@@ -0,0 +1,127 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsDryRunLog = logger.New("workflow:safe_outputs_dry_run")
+
+// SafeOutputsDryRunScriptName is the script that logs safe output payloads in dry-run mode
+const SafeOutputsDryRunScriptName = "safe_outputs_dry_run"
+
+// safeOutputsDryRunPayloadPath is where the dry-run script writes the sanitized payloads
+const safeOutputsDryRunPayloadPath = "/tmp/gh-aw/safe-outputs-dry-run.jsonl"
+
+// dryRunJobOutputTypes maps standalone safe output job names to the agent output item type
+// they process, for jobs whose name differs from the item type
+var dryRunJobOutputTypes = map[string]string{
+	"create_pr_review_comment": "create_pull_request_review_comment",
+	"upload_assets":            "upload_asset",
+}
+
+// isSafeOutputsDryRun returns true when safe-outputs.dry-run is enabled
+func isSafeOutputsDryRun(safeOutputs *SafeOutputsConfig) bool {
+	return safeOutputs != nil && safeOutputs.DryRun
+}
+
+// validateSafeOutputsDryRun rejects dry-run mode combined with custom safe jobs, whose steps
+// are user-defined and cannot be turned into a dry run
+func validateSafeOutputsDryRun(safeOutputs *SafeOutputsConfig) error {
+	if !isSafeOutputsDryRun(safeOutputs) || len(safeOutputs.Jobs) == 0 {
+		return nil
+	}
+
+	jobNames := make([]string, 0, len(safeOutputs.Jobs))
+	for name := range safeOutputs.Jobs {
+		jobNames = append(jobNames, name)
+	}
+	sort.Strings(jobNames)
+	safeOutputsDryRunLog.Printf("Rejecting dry-run with custom safe jobs: %v", jobNames)
+	return NewValidationError(
+		"safe-outputs.dry-run",
+		"true",
+		fmt.Sprintf("dry-run cannot be combined with custom safe jobs (%s), because their steps run with write access", strings.Join(jobNames, ", ")),
+		"Remove the custom safe jobs from safe-outputs.jobs while testing with dry-run, or disable dry-run",
+	)
+}
+
+// buildSafeOutputsDryRunEnvVars builds the environment variables for the dry-run step.
+// types restricts the logged items to the given safe output types; an empty list logs every item.
+// excludedTypes are skipped, because a separate job logs them.
+func buildSafeOutputsDryRunEnvVars(types, excludedTypes []string) []string {
+	customEnvVars := []string{
+		fmt.Sprintf("          GH_AW_DRY_RUN_PAYLOAD_PATH: %q\n", safeOutputsDryRunPayloadPath),
+	}
+	if len(types) > 0 {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_DRY_RUN_TYPES: %q\n", strings.Join(types, ",")))
+	}
+	if len(excludedTypes) > 0 {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_DRY_RUN_EXCLUDE_TYPES: %q\n", strings.Join(excludedTypes, ",")))
+	}
+	return customEnvVars
+}
+
+// buildSafeOutputsDryRunUploadStep builds the step that uploads the dry-run payloads as an artifact.
+// The step always runs so the payloads are available even if the logging step fails.
+func buildSafeOutputsDryRunUploadStep(artifactName string) []string {
+	return []string{
+		"      - name: Upload dry-run safe outputs\n",
+		"        if: always()\n",
+		fmt.Sprintf("        uses: %s\n", GetActionPin("actions/upload-artifact")),
+		"        with:\n",
+		fmt.Sprintf("          name: %s\n", artifactName),
+		fmt.Sprintf("          path: %s\n", safeOutputsDryRunPayloadPath),
+		"          if-no-files-found: ignore\n",
+	}
+}
+
+// buildConsolidatedSafeOutputsDryRunSteps builds the steps that replace every safe output step
+// in the consolidated safe_outputs job when dry-run mode is enabled.
+func (c *Compiler) buildConsolidatedSafeOutputsDryRunSteps(data *WorkflowData) []string {
+	safeOutputsDryRunLog.Print("Building dry-run steps for consolidated safe outputs job")
+
+	// upload_asset items are logged by the separate upload_assets job
+	var excludedTypes []string
+	if data.SafeOutputs.UploadAssets != nil {
+		excludedTypes = append(excludedTypes, "upload_asset")
+	}
+
+	var steps []string
+	steps = append(steps, c.buildConsolidatedSafeOutputStep(data, SafeOutputStepConfig{
+		StepName:      "Log safe outputs (dry run)",
+		StepID:        "dry_run",
+		ScriptName:    SafeOutputsDryRunScriptName,
+		CustomEnvVars: buildSafeOutputsDryRunEnvVars(nil, excludedTypes),
+	})...)
+	steps = append(steps, buildSafeOutputsDryRunUploadStep("safe-outputs-dry-run")...)
+	return steps
+}
+
+// applySafeOutputsDryRun rewrites a standalone safe output job configuration so that the job
+// logs the sanitized payload of its safe output type instead of calling the GitHub API.
+// Pre- and post-steps are dropped since they prepare or follow up on write operations,
+// and the job permissions are reduced to read-only.
+func applySafeOutputsDryRun(config SafeOutputJobConfig) SafeOutputJobConfig {
+	safeOutputsDryRunLog.Printf("Applying dry-run mode to safe output job: %s", config.JobName)
+
+	outputType := config.JobName
+	if mapped, ok := dryRunJobOutputTypes[config.JobName]; ok {
+		outputType = mapped
+	}
+
+	config.StepName += " (dry run)"
+	config.Script = ""
+	config.ScriptName = SafeOutputsDryRunScriptName
+	config.CustomEnvVars = buildSafeOutputsDryRunEnvVars([]string{outputType}, nil)
+	config.Permissions = NewPermissionsContentsRead()
+	config.PreSteps = nil
+	config.PostSteps = buildSafeOutputsDryRunUploadStep("safe-outputs-dry-run-" + strings.ReplaceAll(config.JobName, "_", "-"))
+	config.UseCopilotRequestsToken = false
+	config.UseCopilotCodingAgentToken = false
+	config.Token = ""
+	return config
+}
@@ -0,0 +1,212 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSafeOutputsDryRun(t *testing.T) {
+	compiler := NewCompiler()
+
+	config := compiler.extractSafeOutputsConfig(map[string]any{
+		"safe-outputs": map[string]any{
+			"create-issue": nil,
+			"dry-run":      true,
+		},
+	})
+	require.NotNil(t, config, "Safe outputs config should be parsed")
+	assert.True(t, config.DryRun, "dry-run should be enabled")
+
+	config = compiler.extractSafeOutputsConfig(map[string]any{
+		"safe-outputs": map[string]any{
+			"create-issue": nil,
+		},
+	})
+	require.NotNil(t, config, "Safe outputs config should be parsed")
+	assert.False(t, config.DryRun, "dry-run should default to false")
+}
+
+func TestApplySafeOutputsDryRun(t *testing.T) {
+	config := applySafeOutputsDryRun(SafeOutputJobConfig{
+		JobName:                 "create_pr_review_comment",
+		StepName:                "Create PR Review Comment",
+		StepID:                  "create_pr_review_comment",
+		CustomEnvVars:           []string{"          GH_AW_PR_REVIEW_COMMENT_SIDE: \"RIGHT\"\n"},
+		Script:                  "await main();",
+		ScriptName:              "create_pr_review_comment",
+		Permissions:             NewPermissionsContentsReadPRWrite(),
+		PreSteps:                []string{"      - name: Checkout\n"},
+		PostSteps:               []string{"      - name: Add reviewers\n"},
+		Token:                   "${{ secrets.CUSTOM_TOKEN }}",
+		UseCopilotRequestsToken: true,
+	})
+
+	assert.Equal(t, "Create PR Review Comment (dry run)", config.StepName, "Step name should mark dry-run mode")
+	assert.Equal(t, "create_pr_review_comment", config.StepID, "Step ID should be preserved so job outputs resolve")
+	assert.Equal(t, SafeOutputsDryRunScriptName, config.ScriptName, "Script should be replaced with the dry-run script")
+	assert.Empty(t, config.Script, "Inline script should be cleared")
+	assert.Empty(t, config.PreSteps, "Pre-steps should be dropped")
+	assert.Empty(t, config.Token, "Custom token should be dropped")
+	assert.False(t, config.UseCopilotRequestsToken, "Copilot token chain should be disabled")
+	assert.Equal(t, NewPermissionsContentsRead().RenderToYAML(), config.Permissions.RenderToYAML(), "Permissions should be read-only")
+
+	envVars := strings.Join(config.CustomEnvVars, "")
+	assert.Contains(t, envVars, `GH_AW_DRY_RUN_TYPES: "create_pull_request_review_comment"`, "Dry run should filter on the item type")
+	assert.NotContains(t, envVars, "GH_AW_PR_REVIEW_COMMENT_SIDE", "Type-specific env vars should be dropped")
+
+	postSteps := strings.Join(config.PostSteps, "")
+	assert.Contains(t, postSteps, "name: safe-outputs-dry-run-create-pr-review-comment", "Post-steps should upload the payload artifact")
+	assert.NotContains(t, postSteps, "Add reviewers", "Original post-steps should be dropped")
+}
+
+func TestBuildCreateOutputIssueJobDryRun(t *testing.T) {
+	compiler := NewCompiler()
+	workflowData := &WorkflowData{
+		Name: "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			DryRun:       true,
+			CreateIssues: &CreateIssuesConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Max: strPtr("1")}},
+			App:          &GitHubAppConfig{AppID: "${{ vars.APP_ID }}", PrivateKey: "${{ secrets.APP_PRIVATE_KEY }}"},
+		},
+	}
+
+	job, err := compiler.buildCreateOutputIssueJob(workflowData, "main")
+	require.NoError(t, err, "buildCreateOutputIssueJob should succeed in dry-run mode")
+	require.NotNil(t, job, "Job should not be nil")
+
+	assert.Contains(t, job.Permissions, "contents: read", "Dry-run job should keep read access")
+	assert.NotContains(t, job.Permissions, "write", "Dry-run job should not request write permissions")
+
+	steps := strings.Join(job.Steps, "")
+	assert.Contains(t, steps, "GH_AW_AGENT_OUTPUT", "Dry-run job should still read the agent output")
+	assert.Contains(t, steps, "safe_outputs_dry_run.cjs", "Dry-run job should run the dry-run script")
+	assert.Contains(t, steps, `GH_AW_DRY_RUN_TYPES: "create_issue"`, "Dry-run job should only log create_issue items")
+	assert.Contains(t, steps, "name: safe-outputs-dry-run-create-issue", "Dry-run job should upload the payload artifact")
+	assert.NotContains(t, steps, "create_issue.cjs", "Dry-run job should not run the create_issue script")
+	assert.NotContains(t, steps, "Generate GitHub App token", "Dry-run job should not mint an app token")
+}
+
+func TestCompileWorkflowWithSafeOutputsDryRun(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-outputs-dry-run-test")
+
+	testContent := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  dry-run: true
+  create-issue:
+  add-comment:
+  create-pull-request:
+---
+
+# Dry Run
+
+Triage the issue.
+`
+
+	testFile := filepath.Join(tmpDir, "dry-run.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow with dry-run should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+
+	safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+	require.NotEmpty(t, safeOutputsJob, "Lock file should contain the safe_outputs job")
+
+	assert.Contains(t, safeOutputsJob, "contents: read", "safe_outputs job should keep read access")
+	assert.NotContains(t, safeOutputsJob, ": write", "safe_outputs job should not request write permissions")
+	assert.Contains(t, safeOutputsJob, "id: dry_run", "safe_outputs job should include the dry-run step")
+	assert.Contains(t, safeOutputsJob, "safe_outputs_dry_run.cjs", "safe_outputs job should run the dry-run script")
+	assert.Contains(t, safeOutputsJob, "name: safe-outputs-dry-run", "safe_outputs job should upload the payload artifact")
+	assert.Contains(t, safeOutputsJob, "GH_AW_AGENT_OUTPUT", "safe_outputs job should still download the agent output")
+	assert.NotContains(t, safeOutputsJob, "id: process_safe_outputs", "safe_outputs job should not run the handler manager")
+	assert.NotContains(t, safeOutputsJob, "safe_output_handler_manager.cjs", "safe_outputs job should not call the GitHub API")
+	assert.NotContains(t, safeOutputsJob, "git config", "safe_outputs job should not configure git for pushes")
+	assert.Empty(t, extractJobSection(string(lockContent), "conclusion"), "Lock file should not contain the conclusion job")
+}
+
+func TestValidateSafeOutputsDryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		safeOutputs *SafeOutputsConfig
+		wantErr     bool
+	}{
+		{
+			name:        "nil safe outputs",
+			safeOutputs: nil,
+		},
+		{
+			name:        "custom jobs without dry-run",
+			safeOutputs: &SafeOutputsConfig{Jobs: map[string]*SafeJobConfig{"notify": {}}},
+		},
+		{
+			name:        "dry-run without custom jobs",
+			safeOutputs: &SafeOutputsConfig{DryRun: true, CreateIssues: &CreateIssuesConfig{}},
+		},
+		{
+			name:        "dry-run with custom jobs",
+			safeOutputs: &SafeOutputsConfig{DryRun: true, Jobs: map[string]*SafeJobConfig{"notify": {}, "deploy": {}}},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSafeOutputsDryRun(tt.safeOutputs)
+			if tt.wantErr {
+				require.Error(t, err, "dry-run with custom safe jobs should be rejected")
+				assert.Contains(t, err.Error(), "deploy, notify", "Error should list the custom safe jobs")
+				return
+			}
+			assert.NoError(t, err, "Configuration should be accepted")
+		})
+	}
+}
+
+func TestCompileWorkflowWithSafeOutputsDryRunUploadAssets(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-outputs-dry-run-assets-test")
+
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  dry-run: true
+  add-comment:
+  upload-asset:
+---
+
+# Dry Run Assets
+
+Render a chart.
+`
+
+	testFile := filepath.Join(tmpDir, "dry-run-assets.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with dry-run and upload-asset should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+
+	safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+	assert.Contains(t, safeOutputsJob, `GH_AW_DRY_RUN_EXCLUDE_TYPES: "upload_asset"`, "safe_outputs job should leave upload_asset items to the upload_assets job")
+
+	uploadAssetsJob := extractJobSection(string(lockContent), "upload_assets")
+	assert.Contains(t, uploadAssetsJob, `GH_AW_DRY_RUN_TYPES: "upload_asset"`, "upload_assets job should log the upload_asset items")
+	assert.NotContains(t, uploadAssetsJob, "GH_AW_DRY_RUN_EXCLUDE_TYPES", "upload_assets job should not exclude any type")
+}
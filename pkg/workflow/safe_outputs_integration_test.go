@@ -99,6 +99,21 @@ func TestSafeOutputJobsIntegration(t *testing.T) {
 				return c.buildAddLabelsJob(data, mainJobName)
 			},
 		},
+		{
+			name:           "remove_labels",
+			safeOutputType: "remove-labels",
+			configBuilder: func() *SafeOutputsConfig {
+				return &SafeOutputsConfig{
+					RemoveLabels: &RemoveLabelsConfig{
+						Allowed: []string{"needs-triage", "in-progress"},
+					},
+				}
+			},
+			requiredEnvVar: "GH_AW_LABELS_ALLOWED",
+			jobBuilder: func(c *Compiler, data *WorkflowData, mainJobName string) (*Job, error) {
+				return c.buildRemoveLabelsJob(data, mainJobName)
+			},
+		},
 		{
 			name:           "missing_tool",
 			safeOutputType: "missing-tool",
@@ -167,6 +182,61 @@ func TestSafeOutputJobsIntegration(t *testing.T) {
 				return c.buildCreateOutputAgentSessionJob(data, mainJobName)
 			},
 		},
+		{
+			name:           "create_gist",
+			safeOutputType: "create-gist",
+			configBuilder: func() *SafeOutputsConfig {
+				return &SafeOutputsConfig{
+					CreateGists: &CreateGistConfig{
+						BaseSafeOutputConfig: BaseSafeOutputConfig{
+							Max: strPtr("3"),
+						},
+						FilenamePrefix: "report-",
+					},
+				}
+			},
+			requiredEnvVar: "GH_AW_WORKFLOW_ID",
+			jobBuilder: func(c *Compiler, data *WorkflowData, mainJobName string) (*Job, error) {
+				return c.buildCreateOutputGistJob(data, mainJobName)
+			},
+		},
+		{
+			name:           "create_release",
+			safeOutputType: "create-release",
+			configBuilder: func() *SafeOutputsConfig {
+				return &SafeOutputsConfig{
+					CreateReleases: &CreateReleaseConfig{
+						BaseSafeOutputConfig: BaseSafeOutputConfig{
+							Max: strPtr("1"),
+						},
+						Draft:     true,
+						TagPrefix: "v",
+					},
+				}
+			},
+			requiredEnvVar: "GH_AW_WORKFLOW_ID",
+			jobBuilder: func(c *Compiler, data *WorkflowData, mainJobName string) (*Job, error) {
+				return c.buildCreateOutputReleaseJob(data, mainJobName)
+			},
+		},
+		{
+			name:           "create_pull_request_review",
+			safeOutputType: "create-pull-request-review",
+			configBuilder: func() *SafeOutputsConfig {
+				return &SafeOutputsConfig{
+					CreatePullRequestReviews: &CreatePullRequestReviewConfig{
+						BaseSafeOutputConfig: BaseSafeOutputConfig{
+							Max: strPtr("1"),
+						},
+						Event: "COMMENT",
+					},
+				}
+			},
+			requiredEnvVar: "GH_AW_WORKFLOW_ID",
+			jobBuilder: func(c *Compiler, data *WorkflowData, mainJobName string) (*Job, error) {
+				return c.buildCreateOutputPullRequestReviewJob(data, mainJobName)
+			},
+		},
 		{
 			name:           "upload_assets",
 			safeOutputType: "upload-assets",
@@ -707,6 +777,143 @@ func TestSafeOutputJobsWithCustomEnvVars(t *testing.T) {
 	}
 }
 
+// TestCreateReleaseJobDraftDefaultIntegration tests that releases parsed from frontmatter
+// are drafts unless draft: false is set explicitly, and that the job always carries
+// GH_AW_WORKFLOW_ID and contents: write.
+func TestCreateReleaseJobDraftDefaultIntegration(t *testing.T) {
+	tests := []struct {
+		name          string
+		releaseConfig any
+		expectedDraft string
+	}{
+		{
+			name:          "null config is draft",
+			releaseConfig: nil,
+			expectedDraft: `GH_AW_RELEASE_DRAFT: "true"`,
+		},
+		{
+			name:          "omitted draft is draft",
+			releaseConfig: map[string]any{"prerelease": true},
+			expectedDraft: `GH_AW_RELEASE_DRAFT: "true"`,
+		},
+		{
+			name:          "explicit draft false publishes",
+			releaseConfig: map[string]any{"draft": false},
+			expectedDraft: `GH_AW_RELEASE_DRAFT: "false"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCompiler()
+			safeOutputs := c.extractSafeOutputsConfig(map[string]any{
+				"safe-outputs": map[string]any{"create-release": tt.releaseConfig},
+			})
+			if safeOutputs == nil || safeOutputs.CreateReleases == nil {
+				t.Fatal("Expected create-release configuration to be parsed")
+			}
+
+			workflowData := &WorkflowData{
+				Name:        "test-workflow",
+				WorkflowID:  "release-notes",
+				Source:      "test-source",
+				SafeOutputs: safeOutputs,
+			}
+
+			job, err := c.buildCreateOutputReleaseJob(workflowData, "main_job")
+			if err != nil {
+				t.Fatalf("Failed to build create_release job: %v", err)
+			}
+
+			stepsContent := strings.Join(job.Steps, "")
+			if !strings.Contains(stepsContent, `GH_AW_WORKFLOW_ID: "release-notes"`) {
+				t.Errorf("Expected GH_AW_WORKFLOW_ID in create_release job steps.\nJob steps:\n%s", stepsContent)
+			}
+			if !strings.Contains(stepsContent, tt.expectedDraft) {
+				t.Errorf("Expected %s in create_release job steps.\nJob steps:\n%s", tt.expectedDraft, stepsContent)
+			}
+			if !strings.Contains(job.Permissions, "contents: write") {
+				t.Errorf("Expected contents: write permission, got:\n%s", job.Permissions)
+			}
+		})
+	}
+}
+
+// TestCreatePullRequestReviewJobEventIntegration tests that reviews parsed from frontmatter
+// default to the COMMENT event, only allow approvals when allow-approve is set, and that the
+// job always carries GH_AW_WORKFLOW_ID and pull-requests: write.
+func TestCreatePullRequestReviewJobEventIntegration(t *testing.T) {
+	tests := []struct {
+		name               string
+		reviewConfig       any
+		expectedEvent      string
+		expectAllowApprove bool
+	}{
+		{
+			name:          "null config comments",
+			reviewConfig:  nil,
+			expectedEvent: `GH_AW_PULL_REQUEST_REVIEW_EVENT: "COMMENT"`,
+		},
+		{
+			name:          "omitted event comments",
+			reviewConfig:  map[string]any{"body": "Automated review"},
+			expectedEvent: `GH_AW_PULL_REQUEST_REVIEW_EVENT: "COMMENT"`,
+		},
+		{
+			name:          "explicit request changes",
+			reviewConfig:  map[string]any{"event": "REQUEST_CHANGES"},
+			expectedEvent: `GH_AW_PULL_REQUEST_REVIEW_EVENT: "REQUEST_CHANGES"`,
+		},
+		{
+			name:               "approve when allowed",
+			reviewConfig:       map[string]any{"event": "APPROVE", "allow-approve": true},
+			expectedEvent:      `GH_AW_PULL_REQUEST_REVIEW_EVENT: "APPROVE"`,
+			expectAllowApprove: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCompiler()
+			safeOutputs := c.extractSafeOutputsConfig(map[string]any{
+				"safe-outputs": map[string]any{"create-pull-request-review": tt.reviewConfig},
+			})
+			if safeOutputs == nil || safeOutputs.CreatePullRequestReviews == nil {
+				t.Fatal("Expected create-pull-request-review configuration to be parsed")
+			}
+			if err := validateCreatePullRequestReview(safeOutputs); err != nil {
+				t.Fatalf("Expected valid create-pull-request-review configuration, got: %v", err)
+			}
+
+			workflowData := &WorkflowData{
+				Name:        "test-workflow",
+				WorkflowID:  "pr-reviewer",
+				Source:      "test-source",
+				SafeOutputs: safeOutputs,
+			}
+
+			job, err := c.buildCreateOutputPullRequestReviewJob(workflowData, "main_job")
+			if err != nil {
+				t.Fatalf("Failed to build create_pull_request_review job: %v", err)
+			}
+
+			stepsContent := strings.Join(job.Steps, "")
+			if !strings.Contains(stepsContent, `GH_AW_WORKFLOW_ID: "pr-reviewer"`) {
+				t.Errorf("Expected GH_AW_WORKFLOW_ID in create_pull_request_review job steps.\nJob steps:\n%s", stepsContent)
+			}
+			if !strings.Contains(stepsContent, tt.expectedEvent) {
+				t.Errorf("Expected %s in create_pull_request_review job steps.\nJob steps:\n%s", tt.expectedEvent, stepsContent)
+			}
+			if got := strings.Contains(stepsContent, `GH_AW_PULL_REQUEST_REVIEW_ALLOW_APPROVE: "true"`); got != tt.expectAllowApprove {
+				t.Errorf("Expected allow-approve env var present=%t, got %t.\nJob steps:\n%s", tt.expectAllowApprove, got, stepsContent)
+			}
+			if !strings.Contains(job.Permissions, "pull-requests: write") {
+				t.Errorf("Expected pull-requests: write permission, got:\n%s", job.Permissions)
+			}
+		})
+	}
+}
+
 // TestSafeOutputJobsMissingConfig tests that jobs fail gracefully when required configuration is missing
 func TestSafeOutputJobsMissingConfig(t *testing.T) {
 	tests := []struct {
@@ -741,6 +948,15 @@ func TestSafeOutputJobsMissingConfig(t *testing.T) {
 			},
 			shouldFail: true,
 		},
+		{
+			name: "remove_labels_without_config",
+			jobBuilder: func(c *Compiler, data *WorkflowData, mainJobName string) (*Job, error) {
+				// Set SafeOutputs to nil
+				data.SafeOutputs = nil
+				return c.buildRemoveLabelsJob(data, mainJobName)
+			},
+			shouldFail: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,127 @@
+//go:build integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jobOutputsBlock returns the outputs: block of a compiled job section
+func jobOutputsBlock(t *testing.T, jobSection string) string {
+	t.Helper()
+	start := strings.Index(jobSection, "    outputs:\n")
+	require.NotEqual(t, -1, start, "Job should declare outputs")
+	block := jobSection[start+len("    outputs:\n"):]
+	var lines []string
+	for _, line := range strings.Split(block, "\n") {
+		if !strings.HasPrefix(line, "      ") {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestSafeOutputsJobCreatedItemOutputsIntegration(t *testing.T) {
+	tests := []struct {
+		name        string
+		safeOutputs string
+		expected    []string
+		notExpected []string
+	}{
+		{
+			name: "create-issue",
+			safeOutputs: `safe-outputs:
+  create-issue:`,
+			expected: []string{
+				"      issue_number: ${{ steps.process_safe_outputs.outputs.issue_number }}",
+				"      issue_url: ${{ steps.process_safe_outputs.outputs.issue_url }}",
+			},
+			notExpected: []string{"pr_number:", "pr_url:"},
+		},
+		{
+			name: "create-pull-request",
+			safeOutputs: `safe-outputs:
+  create-pull-request:`,
+			expected: []string{
+				"      issue_number: ${{ steps.process_safe_outputs.outputs.issue_number }}",
+				"      issue_url: ${{ steps.process_safe_outputs.outputs.issue_url }}",
+				"      pr_number: ${{ steps.process_safe_outputs.outputs.pr_number }}",
+				"      pr_url: ${{ steps.process_safe_outputs.outputs.pr_url }}",
+			},
+		},
+		{
+			name: "add-comment only",
+			safeOutputs: `safe-outputs:
+  add-comment:`,
+			notExpected: []string{"issue_number:", "issue_url:", "pr_number:", "pr_url:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "safe-outputs-job-outputs-test")
+			testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+` + tt.safeOutputs + `
+---
+
+# Created Items
+
+Open an issue or pull request.
+`
+			testFile := filepath.Join(tmpDir, "job-outputs.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+			require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			require.NoError(t, err, "Should read lock file")
+			outputs := jobOutputsBlock(t, extractJobSection(string(lockContent), "safe_outputs"))
+
+			for _, expected := range tt.expected {
+				assert.Contains(t, outputs, expected, "safe_outputs job should expose the created item")
+			}
+			for _, unexpected := range tt.notExpected {
+				assert.NotContains(t, outputs, unexpected, "safe_outputs job should only expose configured items")
+			}
+		})
+	}
+}
+
+func TestCreateOutputJobsCreatedItemOutputsIntegration(t *testing.T) {
+	c := NewCompiler()
+
+	issueJob, err := c.buildCreateOutputIssueJob(&WorkflowData{
+		Name:        "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}},
+	}, "agent")
+	require.NoError(t, err, "Should build create_issue job")
+	assert.Equal(t, "${{ steps.create_issue.outputs.issue_number }}", issueJob.Outputs["issue_number"])
+	assert.Equal(t, "${{ steps.create_issue.outputs.issue_url }}", issueJob.Outputs["issue_url"])
+
+	prJob, err := c.buildCreateOutputPullRequestJob(&WorkflowData{
+		Name:        "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{}},
+	}, "agent")
+	require.NoError(t, err, "Should build create_pull_request job")
+	assert.Equal(t, "${{ steps.create_pull_request.outputs.pull_request_number }}", prJob.Outputs["pr_number"])
+	assert.Equal(t, "${{ steps.create_pull_request.outputs.pull_request_url }}", prJob.Outputs["pr_url"])
+	assert.Equal(t, "${{ steps.create_pull_request.outputs.issue_number }}", prJob.Outputs["issue_number"])
+	assert.Equal(t, "${{ steps.create_pull_request.outputs.issue_url }}", prJob.Outputs["issue_url"])
+
+	rendered := NewJobManager().renderJob(prJob)
+	outputs := jobOutputsBlock(t, rendered)
+	assert.Contains(t, outputs, "      pr_number: ${{ steps.create_pull_request.outputs.pull_request_number }}")
+	assert.Contains(t, outputs, "      pr_url: ${{ steps.create_pull_request.outputs.pull_request_url }}")
+}
@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"strings"
+
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -38,6 +40,7 @@ type SafeOutputJobConfig struct {
 	PreSteps                   []string          // Optional steps to run before the GitHub Script step
 	PostSteps                  []string          // Optional steps to run after the GitHub Script step
 	Token                      string            // GitHub token for this output type
+	Provenance                 string            // Frontmatter key that produced the job, defaults to safe-outputs.<job-name>
 	UseCopilotRequestsToken    bool              // Whether to use Copilot token preference chain
 	UseCopilotCodingAgentToken bool              // Whether to use agent token preference chain (config token > GH_AW_AGENT_TOKEN)
 	TargetRepoSlug             string            // Target repository for cross-repo operations
@@ -53,8 +56,14 @@ func (c *Compiler) buildSafeOutputJob(data *WorkflowData, config SafeOutputJobCo
 	safeOutputsJobsLog.Printf("Building safe output job: %s (actionMode=%s)", config.JobName, c.actionMode)
 	var steps []string
 
-	// Add GitHub App token minting step if app is configured
-	if data.SafeOutputs != nil && data.SafeOutputs.App != nil {
+	// In dry-run mode, log the payload instead of executing the safe output
+	dryRun := isSafeOutputsDryRun(data.SafeOutputs)
+	if dryRun {
+		config = applySafeOutputsDryRun(config)
+	}
+
+	// Add GitHub App token minting step if app is configured (not needed in dry-run mode)
+	if data.SafeOutputs != nil && data.SafeOutputs.App != nil && !dryRun {
 		safeOutputsJobsLog.Print("Adding GitHub App token minting step with auto-computed permissions")
 		steps = append(steps, c.buildGitHubAppTokenMintStep(data.SafeOutputs.App, config.Permissions)...)
 	}
@@ -110,7 +119,7 @@ func (c *Compiler) buildSafeOutputJob(data *WorkflowData, config SafeOutputJobCo
 	}
 
 	// Add GitHub App token invalidation step if app is configured
-	if data.SafeOutputs != nil && data.SafeOutputs.App != nil {
+	if data.SafeOutputs != nil && data.SafeOutputs.App != nil && !dryRun {
 		safeOutputsJobsLog.Print("Adding GitHub App token invalidation step")
 		steps = append(steps, c.buildGitHubAppTokenInvalidationStep()...)
 	}
@@ -129,16 +138,22 @@ func (c *Compiler) buildSafeOutputJob(data *WorkflowData, config SafeOutputJobCo
 	}
 	safeOutputsJobsLog.Printf("Job %s needs: %v", config.JobName, needs)
 
+	provenance := config.Provenance
+	if provenance == "" {
+		provenance = "safe-outputs." + strings.ReplaceAll(config.JobName, "_", "-")
+	}
+
 	// Create the job with standard configuration
 	job := &Job{
 		Name:           config.JobName,
 		If:             jobCondition.Render(),
-		RunsOn:         c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		RunsOn:         c.formatGeneratedJobRunsOn(data),
 		Permissions:    config.Permissions.RenderToYAML(),
 		TimeoutMinutes: 10, // 10-minute timeout as required for all safe output jobs
 		Steps:          steps,
 		Outputs:        config.Outputs,
 		Needs:          needs,
+		Provenance:     provenance,
 	}
 
 	return job, nil
@@ -0,0 +1,121 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractSafeOutputsConfigMaxTotal(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected int
+	}{
+		{name: "int value", value: 5, expected: 5},
+		{name: "uint64 value", value: uint64(7), expected: 7},
+		{name: "float value", value: 3.0, expected: 3},
+		{name: "zero is ignored", value: 0, expected: 0},
+		{name: "negative is ignored", value: -1, expected: 0},
+		{name: "string is ignored", value: "5", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"safe-outputs": map[string]any{
+					"create-issue": nil,
+					"max-total":    tt.value,
+				},
+			}
+
+			config := NewCompiler().extractSafeOutputsConfig(frontmatter)
+			require.NotNil(t, config, "Safe outputs config should be parsed")
+			assert.Equal(t, tt.expected, config.MaxTotal, "MaxTotal should match")
+		})
+	}
+}
+
+func TestMergeSafeOutputsMaxTotal(t *testing.T) {
+	compiler := NewCompiler()
+
+	merged, err := compiler.MergeSafeOutputs(&SafeOutputsConfig{}, []string{`{"max-total": 4}`})
+	require.NoError(t, err, "Merging imported max-total should succeed")
+	assert.Equal(t, 4, merged.MaxTotal, "Imported max-total should be used when the main workflow sets none")
+
+	merged, err = compiler.MergeSafeOutputs(&SafeOutputsConfig{MaxTotal: 2}, []string{`{"max-total": 4}`})
+	require.NoError(t, err, "Merging imported max-total should succeed")
+	assert.Equal(t, 2, merged.MaxTotal, "Main workflow max-total should take precedence over imports")
+}
+
+func TestCompileWorkflowWithSafeOutputsMaxTotal(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-outputs-max-total-test")
+
+	testContent := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  max-total: 3
+  create-issue:
+    max: 5
+  add-comment:
+    max: 2
+---
+
+# Max Total
+
+Triage the issue.
+`
+
+	testFile := filepath.Join(tmpDir, "max-total.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow with max-total should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+
+	safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+	require.NotEmpty(t, safeOutputsJob, "Lock file should contain the safe_outputs job")
+	assert.Contains(t, safeOutputsJob, `GH_AW_SAFE_OUTPUTS_MAX_TOTAL: "3"`, "Job should expose the global cap")
+	// Per-type limits are still passed to the handlers and are enforced before the global cap
+	assert.Contains(t, safeOutputsJob, `\"create_issue\":{\"max\":5`, "Handler config should keep the per-type create-issue max")
+	assert.Contains(t, safeOutputsJob, `\"add_comment\":{\"max\":2`, "Handler config should keep the per-type add-comment max")
+}
+
+func TestCompileWorkflowWithoutSafeOutputsMaxTotal(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-outputs-no-max-total-test")
+
+	testContent := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+---
+
+# No Max Total
+
+Triage the issue.
+`
+
+	testFile := filepath.Join(tmpDir, "no-max-total.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+	assert.NotContains(t, string(lockContent), "GH_AW_SAFE_OUTPUTS_MAX_TOTAL", "Global cap should only be emitted when configured")
+}
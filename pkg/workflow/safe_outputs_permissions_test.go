@@ -3,8 +3,12 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -351,3 +355,260 @@ func TestComputePermissionsForSafeOutputs_NoOpAndMissingTool(t *testing.T) {
 	// The conclusion job will handle commenting through add-comment if configured
 	assert.Empty(t, permissions.permissions, "NoOp and MissingTool alone should not add permissions")
 }
+
+func TestDerivePermissions(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []string
+		expected map[PermissionScope]PermissionLevel
+	}{
+		{
+			name:     "no safe outputs",
+			keys:     nil,
+			expected: map[PermissionScope]PermissionLevel{},
+		},
+		{
+			name:     "create-issue",
+			keys:     []string{"create-issue"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite},
+		},
+		{
+			name:     "create-discussion",
+			keys:     []string{"create-discussion"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite, PermissionDiscussions: PermissionWrite},
+		},
+		{
+			name:     "update-discussion",
+			keys:     []string{"update-discussion"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionDiscussions: PermissionWrite},
+		},
+		{
+			name:     "close-discussion",
+			keys:     []string{"close-discussion"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionDiscussions: PermissionWrite},
+		},
+		{
+			name:     "add-comment",
+			keys:     []string{"add-comment"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite, PermissionDiscussions: PermissionWrite},
+		},
+		{
+			name:     "close-issue",
+			keys:     []string{"close-issue"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite},
+		},
+		{
+			name:     "create-pull-request",
+			keys:     []string{"create-pull-request"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionWrite, PermissionIssues: PermissionWrite, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "close-pull-request",
+			keys:     []string{"close-pull-request"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "update-pull-request",
+			keys:     []string{"update-pull-request"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "mark-pull-request-as-ready-for-review",
+			keys:     []string{"mark-pull-request-as-ready-for-review"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "create-pull-request-review-comment",
+			keys:     []string{"create-pull-request-review-comment"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "submit-pull-request-review",
+			keys:     []string{"submit-pull-request-review"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "reply-to-pull-request-review-comment",
+			keys:     []string{"reply-to-pull-request-review-comment"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "resolve-pull-request-review-thread",
+			keys:     []string{"resolve-pull-request-review-thread"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "push-to-pull-request-branch",
+			keys:     []string{"push-to-pull-request-branch"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionWrite, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "add-labels",
+			keys:     []string{"add-labels"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "remove-labels",
+			keys:     []string{"remove-labels"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "add-reviewer",
+			keys:     []string{"add-reviewer"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "update-issue",
+			keys:     []string{"update-issue"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite},
+		},
+		{
+			name:     "link-sub-issue",
+			keys:     []string{"link-sub-issue"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite},
+		},
+		{
+			name:     "assign-milestone",
+			keys:     []string{"assign-milestone"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite},
+		},
+		{
+			name:     "assign-to-agent",
+			keys:     []string{"assign-to-agent"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite},
+		},
+		{
+			name:     "assign-to-user",
+			keys:     []string{"assign-to-user"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite},
+		},
+		{
+			name:     "unassign-from-user",
+			keys:     []string{"unassign-from-user"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite},
+		},
+		{
+			name:     "create-agent-session",
+			keys:     []string{"create-agent-session"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite},
+		},
+		{
+			name:     "hide-comment",
+			keys:     []string{"hide-comment"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite, PermissionDiscussions: PermissionWrite},
+		},
+		{
+			name:     "create-code-scanning-alert",
+			keys:     []string{"create-code-scanning-alert"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionSecurityEvents: PermissionWrite},
+		},
+		{
+			name:     "autofix-code-scanning-alert",
+			keys:     []string{"autofix-code-scanning-alert"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionSecurityEvents: PermissionWrite, PermissionActions: PermissionRead},
+		},
+		{
+			name:     "upload-asset",
+			keys:     []string{"upload-asset"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionWrite},
+		},
+		{
+			name:     "update-release",
+			keys:     []string{"update-release"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionWrite},
+		},
+		{
+			name:     "create-project",
+			keys:     []string{"create-project"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionOrganizationProj: PermissionWrite},
+		},
+		{
+			name:     "create-issue and add-labels combine",
+			keys:     []string{"create-issue", "add-labels"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite, PermissionPullRequests: PermissionWrite},
+		},
+		{
+			name:     "create-pull-request upgrades contents from add-comment",
+			keys:     []string{"add-comment", "create-pull-request"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionWrite, PermissionIssues: PermissionWrite, PermissionPullRequests: PermissionWrite, PermissionDiscussions: PermissionWrite},
+		},
+		{
+			name:     "code scanning and discussions combine",
+			keys:     []string{"create-code-scanning-alert", "create-discussion"},
+			expected: map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead, PermissionIssues: PermissionWrite, PermissionDiscussions: PermissionWrite, PermissionSecurityEvents: PermissionWrite},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := DerivePermissions(SafeOutputsConfigFromKeys(tt.keys))
+			require.NotNil(t, builder, "DerivePermissions should never return nil")
+			assert.Equal(t, tt.expected, builder.scopes, "Derived scopes should match")
+		})
+	}
+}
+
+func TestDerivePermissionsNilConfig(t *testing.T) {
+	builder := DerivePermissions(nil)
+	require.NotNil(t, builder, "DerivePermissions should return an empty builder for nil config")
+	assert.Empty(t, builder.Build(), "Empty builder should render nothing")
+}
+
+func TestCompileWorkflowWithDerivedPermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		features    string
+		permissions string
+		expected    []string
+		notExpected []string
+	}{
+		{
+			name:        "derive-permissions enabled without explicit permissions",
+			features:    "features:\n  derive-permissions: true\n",
+			expected:    []string{"contents: read", "issues: read", "pull-requests: read"},
+			notExpected: []string{"issues: write", "pull-requests: write", "discussions:"},
+		},
+		{
+			name:        "derive-permissions disabled keeps read-only default",
+			expected:    []string{"contents: read"},
+			notExpected: []string{"issues: write"},
+		},
+		{
+			name:        "explicit permissions take precedence over derivation",
+			features:    "features:\n  derive-permissions: true\n",
+			permissions: "permissions:\n  contents: read\n",
+			expected:    []string{"contents: read"},
+			notExpected: []string{"issues: write"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "derive-permissions-test")
+
+			testContent := "---\non: workflow_dispatch\nengine: copilot\n" + tt.features + tt.permissions +
+				"safe-outputs:\n  create-issue:\n  add-labels:\n---\n\n# Derived permissions\n\nTriage the repository.\n"
+
+			testFile := filepath.Join(tmpDir, "derive-permissions.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			require.NoError(t, err, "Should read lock file")
+
+			agentJob := extractJobSection(string(lockContent), "agent")
+			require.NotEmpty(t, agentJob, "Lock file should contain the agent job")
+			for _, expected := range tt.expected {
+				assert.Contains(t, agentJob, expected, "Agent job permissions should contain %q", expected)
+			}
+			for _, notExpected := range tt.notExpected {
+				assert.NotContains(t, agentJob, notExpected, "Agent job permissions should not contain %q", notExpected)
+			}
+
+			safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+			assert.Contains(t, safeOutputsJob, "issues: write", "Safe outputs job should keep its write permissions")
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package workflow
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsPromptValidationLog = logger.New("workflow:safe_outputs_prompt_validation")
+
+// promptSafeOutputPhrase maps instructions in the workflow prompt to the safe output they require
+type promptSafeOutputPhrase struct {
+	outputType string
+	pattern    *regexp.Regexp
+}
+
+// promptImperativePrefix anchors a phrase to the start of an instruction: the start of a line or
+// list item (optionally after a bold lead-in), the start of a sentence or clause, or a verb that
+// follows "and", "then" or "please". Verbs in the middle of a sentence ("keep the tag but add a
+// comment explaining why") are descriptions rather than instructions to the agent.
+const promptImperativePrefix = `(?im)(?:^[ \t]*(?:(?:[-*+]|\d+\.)[ \t]+)?(?:\*\*[^*\n]+\*\*:?[ \t]+)?|[.!?:,][ \t]+|\b(?:and|then|please)[ \t]+)`
+
+// promptSafeOutputPhrases are the imperative phrases that indicate the agent is expected to produce a
+// safe output. They only match the base form of the verb followed by an article ("open a pull
+// request"), so descriptions of the triggering event ("when someone opens an issue") and "open" used
+// as an adjective ("find an open PR") are not reported.
+var promptSafeOutputPhrases = []promptSafeOutputPhrase{
+	{"create-pull-request", regexp.MustCompile(promptImperativePrefix + `(?:create|open|submit|raise|file)\s+(?:a|an)\s+(?:new\s+|draft\s+)?(?:pull\s+request|pr)\b`)},
+	{"create-issue", regexp.MustCompile(promptImperativePrefix + `(?:create|open|file|raise|submit)\s+(?:a|an)\s+(?:new\s+)?issue\b`)},
+	{"create-discussion", regexp.MustCompile(promptImperativePrefix + `(?:create|open|start|post)\s+a\s+(?:new\s+)?discussion\b`)},
+	{"add-comment", regexp.MustCompile(promptImperativePrefix + `(?:add|post|leave|write)\s+a\s+comment\b`)},
+	{"add-labels", regexp.MustCompile(promptImperativePrefix + `(?:add|apply)\s+(?:a\s+|the\s+)?labels?\b`)},
+}
+
+// promptFencePattern matches the opening or closing line of a fenced code block
+var promptFencePattern = regexp.MustCompile("^[ \t]*(?:```|~~~)")
+
+// promptListItemPattern matches a list item line
+var promptListItemPattern = regexp.MustCompile(`^[ \t]*(?:[-*+]|\d+\.)[ \t]+`)
+
+// promptFutureClausePattern matches a clause describing what someone will do ("The Copilot coding
+// agent will:", "the agent will parse the issue and create a PR"), up to the end of the sentence
+var promptFutureClausePattern = regexp.MustCompile(`(?i)\b\w+[ \t]+will\b[^.!?\n]*`)
+
+// promptInstructionText returns the parts of the prompt that address the agent itself. Fenced code
+// blocks hold templates for comments, issue bodies or instructions to other agents, and clauses
+// saying what someone other than "you" will do describe the work of another agent or a human, as
+// do the list items following such a clause when it ends with a colon. All of these are left out.
+func promptInstructionText(markdown string) string {
+	var lines []string
+	inFence := false
+	inDelegatedList := false
+	for line := range strings.SplitSeq(markdown, "\n") {
+		if promptFencePattern.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if inDelegatedList {
+			if strings.TrimSpace(line) == "" || promptListItemPattern.MatchString(line) || line != strings.TrimLeft(line, " \t") {
+				continue
+			}
+			inDelegatedList = false
+		}
+		line = promptFutureClausePattern.ReplaceAllStringFunc(line, func(clause string) string {
+			if strings.EqualFold(strings.Fields(clause)[0], "you") {
+				return clause
+			}
+			if strings.HasSuffix(strings.TrimSpace(clause), ":") {
+				inDelegatedList = true
+			}
+			return ""
+		})
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// detectPromptSafeOutputs returns the safe output types that the prompt instructs the agent to
+// produce, in the order of promptSafeOutputPhrases
+func detectPromptSafeOutputs(markdown string) []string {
+	instructions := promptInstructionText(markdown)
+	var outputTypes []string
+	for _, phrase := range promptSafeOutputPhrases {
+		if phrase.pattern.MatchString(instructions) {
+			outputTypes = append(outputTypes, phrase.outputType)
+		}
+	}
+	return outputTypes
+}
+
+// findUnconfiguredPromptSafeOutputs returns the safe output types the prompt appears to ask for but
+// that are not enabled under safe-outputs. The check is a heuristic, so callers only warn about the
+// result. It is skipped when the disable-safe-outputs-prompt-check feature is enabled.
+func findUnconfiguredPromptSafeOutputs(workflowData *WorkflowData) []string {
+	if isFeatureEnabled(constants.DisableSafeOutputsPromptCheckFeatureFlag, workflowData) {
+		safeOutputsPromptValidationLog.Print("Safe outputs prompt check disabled by feature flag")
+		return nil
+	}
+
+	var missing []string
+	for _, outputType := range detectPromptSafeOutputs(workflowData.MarkdownContent) {
+		if !hasSafeOutputType(workflowData.SafeOutputs, outputType) {
+			missing = append(missing, outputType)
+		}
+	}
+	safeOutputsPromptValidationLog.Printf("Found %d safe output types mentioned in the prompt but not configured", len(missing))
+	return missing
+}
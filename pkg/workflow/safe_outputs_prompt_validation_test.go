@@ -0,0 +1,153 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectPromptSafeOutputs(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected []string
+	}{
+		{
+			name:     "open a pull request",
+			markdown: "Fix the failing test and open a pull request with the change.",
+			expected: []string{"create-pull-request"},
+		},
+		{
+			name:     "create a draft PR",
+			markdown: "Create a draft PR that updates the changelog.",
+			expected: []string{"create-pull-request"},
+		},
+		{
+			name:     "file an issue",
+			markdown: "If the build is broken, file an issue describing the failure.",
+			expected: []string{"create-issue"},
+		},
+		{
+			name:     "multiple outputs in mapping order",
+			markdown: "Post a comment summarizing the findings, then create a new issue and add the label `triaged`.",
+			expected: []string{"create-issue", "add-comment", "add-labels"},
+		},
+		{
+			name:     "start a discussion",
+			markdown: "Start a discussion with the weekly report.",
+			expected: []string{"create-discussion"},
+		},
+		{
+			name:     "describing the trigger is not an instruction",
+			markdown: "This workflow runs when someone opens an issue or creates a pull request.",
+			expected: nil,
+		},
+		{
+			name:     "open used as an adjective is not an instruction",
+			markdown: "The following tests require an open pull request. First, find an open PR in the repository. If a sibling issue already has an open PR, skip it.",
+		},
+		{
+			name:     "code comments in the middle of a sentence are not an instruction",
+			markdown: "For private fields, keep the `json:\"-\"` tag but add a comment explaining why.",
+		},
+		{
+			name:     "instruction in a list item",
+			markdown: "1. Generate the code changes\n2. **Finally**: Create a pull request with the fix",
+			expected: []string{"create-pull-request"},
+		},
+		{
+			name:     "templates in fenced code blocks are not an instruction",
+			markdown: "Append these instructions to the issue body:\n\n```markdown\n4. **Create a pull request** with the workflow file\n```\n\nEnd the report with:\n\n~~~\n*For questions or feedback, create an issue in the gh-aw repository*\n~~~",
+		},
+		{
+			name:     "work delegated to another agent is not an instruction",
+			markdown: "Assign the issue to Copilot.\n\nThe Copilot coding agent will:\n1. Analyze the issue\n2. Create a pull request with the fix\n   and link it to the issue\n\nAfter assigning, add the label `assigned`.",
+			expected: []string{"add-labels"},
+		},
+		{
+			name:     "describing what another agent will do is not an instruction",
+			markdown: "Assign the issue to Copilot. The agent will parse the issue, design the workflow, and create a PR with the workflow file.",
+		},
+		{
+			name:     "work listed for the agent itself is an instruction",
+			markdown: "You will:\n1. Review the failing tests\n2. Open a pull request with the fix",
+			expected: []string{"create-pull-request"},
+		},
+		{
+			name:     "no safe output phrases",
+			markdown: "Summarize the repository activity.",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, detectPromptSafeOutputs(tt.markdown))
+		})
+	}
+}
+
+func TestFindUnconfiguredPromptSafeOutputs(t *testing.T) {
+	markdown := "Fix the bug and open a pull request. Add a comment on the issue when done."
+
+	missing := findUnconfiguredPromptSafeOutputs(&WorkflowData{MarkdownContent: markdown})
+	assert.Equal(t, []string{"create-pull-request", "add-comment"}, missing, "nothing configured should report every mention")
+
+	missing = findUnconfiguredPromptSafeOutputs(&WorkflowData{
+		MarkdownContent: markdown,
+		SafeOutputs:     &SafeOutputsConfig{AddComments: &AddCommentsConfig{}},
+	})
+	assert.Equal(t, []string{"create-pull-request"}, missing, "configured outputs should not be reported")
+
+	missing = findUnconfiguredPromptSafeOutputs(&WorkflowData{
+		MarkdownContent: markdown,
+		Features:        map[string]any{"disable-safe-outputs-prompt-check": true},
+	})
+	assert.Empty(t, missing, "the feature flag should disable the check")
+}
+
+// compilePromptSafeOutputsWorkflow compiles a workflow with the given extra frontmatter and prompt
+func compilePromptSafeOutputsWorkflow(t *testing.T, extra, prompt string) *Compiler {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "prompt-safe-outputs-test")
+	testFile := filepath.Join(tmpDir, "test-workflow.md")
+	content := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+` + extra + `---
+
+# Test Workflow
+
+` + prompt + `
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "the prompt check must never fail compilation")
+	return compiler
+}
+
+func TestPromptSafeOutputsWarning(t *testing.T) {
+	prompt := "Update the dependencies and open a pull request with the changes."
+
+	compiler := compilePromptSafeOutputsWorkflow(t, "", prompt)
+	assert.Equal(t, 1, compiler.GetWarningCount(), "a pull request mentioned without create-pull-request should warn")
+
+	compiler = compilePromptSafeOutputsWorkflow(t, `safe-outputs:
+  create-pull-request:
+`, prompt)
+	assert.Zero(t, compiler.GetWarningCount(), "a configured create-pull-request should not warn")
+
+	compiler = compilePromptSafeOutputsWorkflow(t, `features:
+  disable-safe-outputs-prompt-check: true
+`, prompt)
+	assert.Zero(t, compiler.GetWarningCount(), "the feature flag should suppress the warning")
+}
@@ -0,0 +1,163 @@
+// This file provides the registry of safe-output types.
+//
+// # Safe Output Registry
+//
+// safeOutputTypeDefinitions is the single table that ties each safe-output frontmatter key to
+// its SafeOutputsConfig field and to the permissions its handler needs. The compiler derives the
+// safe-output job permissions from it (ComputePermissionsForSafeOutputs), SafeOutputsConfigFromKeys
+// builds configs from it, and SafeOutputRegistry exposes it to tooling such as lint and docs
+// generation. When adding a new safe-output type, add its config field to SafeOutputsConfig and
+// an entry here; TestSafeOutputRegistryCoversConfig fails if the entry is missing.
+
+package workflow
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputRegistryLog = logger.New("workflow:safe_outputs_registry")
+
+// safeOutputTypeDefinition describes one safe-output type
+type safeOutputTypeDefinition struct {
+	key   string // Frontmatter key under safe-outputs (e.g. "create-issue")
+	field string // Name of the SafeOutputsConfig field holding the type's config
+	// permissions returns the permissions the type needs for the given config, or nil when the
+	// type needs no permissions beyond those of the safe-outputs job
+	permissions func(safeOutputs *SafeOutputsConfig) *Permissions
+}
+
+// SafeOutputTypeInfo is the registry metadata of a safe-output type
+type SafeOutputTypeInfo struct {
+	Key         string       // Frontmatter key under safe-outputs (e.g. "create-issue")
+	ToolName    string       // Name of the MCP tool the agent calls (e.g. "create_issue")
+	Field       string       // Name of the SafeOutputsConfig field holding the type's config
+	ConfigType  reflect.Type // Config struct type (e.g. CreateIssuesConfig)
+	Permissions *Permissions // Permissions required with the default configuration
+	DefaultMax  int          // Default value of max when the workflow does not set it
+}
+
+// constPermissions returns a permissions function that ignores the config
+func constPermissions(newPermissions func() *Permissions) func(*SafeOutputsConfig) *Permissions {
+	return func(*SafeOutputsConfig) *Permissions {
+		return newPermissions()
+	}
+}
+
+// commentPermissions returns the permissions for comment handlers that also act on discussions
+// unless discussions: false is set. PR comments are issue comments, so pull-requests: write is
+// not needed.
+func commentPermissions(discussions *bool) *Permissions {
+	if discussions != nil && !*discussions {
+		return NewPermissionsContentsReadIssuesWrite()
+	}
+	return NewPermissionsContentsReadIssuesWriteDiscussionsWrite()
+}
+
+// safeOutputTypeDefinitions lists every safe-output type in SafeOutputsConfig field order
+var safeOutputTypeDefinitions = []safeOutputTypeDefinition{
+	{key: "create-issue", field: "CreateIssues", permissions: constPermissions(NewPermissionsContentsReadIssuesWrite)},
+	{key: "create-discussion", field: "CreateDiscussions", permissions: constPermissions(NewPermissionsContentsReadIssuesWriteDiscussionsWrite)},
+	{key: "update-discussion", field: "UpdateDiscussions", permissions: constPermissions(NewPermissionsContentsReadDiscussionsWrite)},
+	{key: "close-discussion", field: "CloseDiscussions", permissions: constPermissions(NewPermissionsContentsReadDiscussionsWrite)},
+	{key: "close-issue", field: "CloseIssues", permissions: constPermissions(NewPermissionsContentsReadIssuesWrite)},
+	{key: "close-pull-request", field: "ClosePullRequests", permissions: constPermissions(NewPermissionsContentsReadPRWrite)},
+	{key: "mark-pull-request-as-ready-for-review", field: "MarkPullRequestAsReadyForReview", permissions: constPermissions(NewPermissionsContentsReadPRWrite)},
+	{key: "add-comment", field: "AddComments", permissions: func(safeOutputs *SafeOutputsConfig) *Permissions {
+		return commentPermissions(safeOutputs.AddComments.Discussions)
+	}},
+	{key: "create-pull-request", field: "CreatePullRequests", permissions: func(safeOutputs *SafeOutputsConfig) *Permissions {
+		if getFallbackAsIssue(safeOutputs.CreatePullRequests) {
+			return NewPermissionsContentsWriteIssuesWritePRWrite()
+		}
+		return NewPermissionsContentsWritePRWrite()
+	}},
+	{key: "create-pull-request-review-comment", field: "CreatePullRequestReviewComments", permissions: constPermissions(NewPermissionsContentsReadPRWrite)},
+	{key: "submit-pull-request-review", field: "SubmitPullRequestReview", permissions: constPermissions(NewPermissionsContentsReadPRWrite)},
+	{key: "create-pull-request-review", field: "CreatePullRequestReviews", permissions: constPermissions(NewPermissionsContentsReadPRWrite)},
+	{key: "reply-to-pull-request-review-comment", field: "ReplyToPullRequestReviewComment", permissions: constPermissions(NewPermissionsContentsReadPRWrite)},
+	{key: "resolve-pull-request-review-thread", field: "ResolvePullRequestReviewThread", permissions: constPermissions(NewPermissionsContentsReadPRWrite)},
+	{key: "create-code-scanning-alert", field: "CreateCodeScanningAlerts", permissions: constPermissions(NewPermissionsContentsReadSecurityEventsWrite)},
+	{key: "autofix-code-scanning-alert", field: "AutofixCodeScanningAlert", permissions: constPermissions(NewPermissionsContentsReadSecurityEventsWriteActionsRead)},
+	{key: "add-labels", field: "AddLabels", permissions: constPermissions(NewPermissionsContentsReadIssuesWritePRWrite)},
+	{key: "remove-labels", field: "RemoveLabels", permissions: constPermissions(NewPermissionsContentsReadIssuesWritePRWrite)},
+	{key: "add-reviewer", field: "AddReviewer", permissions: constPermissions(NewPermissionsContentsReadPRWrite)},
+	{key: "assign-milestone", field: "AssignMilestone", permissions: constPermissions(NewPermissionsContentsReadIssuesWrite)},
+	{key: "assign-to-agent", field: "AssignToAgent", permissions: constPermissions(NewPermissionsContentsReadIssuesWrite)},
+	{key: "assign-to-user", field: "AssignToUser", permissions: constPermissions(NewPermissionsContentsReadIssuesWrite)},
+	{key: "unassign-from-user", field: "UnassignFromUser", permissions: constPermissions(NewPermissionsContentsReadIssuesWrite)},
+	{key: "update-issue", field: "UpdateIssues", permissions: constPermissions(NewPermissionsContentsReadIssuesWrite)},
+	{key: "update-pull-request", field: "UpdatePullRequests", permissions: constPermissions(NewPermissionsContentsReadPRWrite)},
+	{key: "push-to-pull-request-branch", field: "PushToPullRequestBranch", permissions: constPermissions(NewPermissionsContentsWritePRWrite)},
+	{key: "upload-asset", field: "UploadAssets", permissions: constPermissions(NewPermissionsContentsWrite)},
+	{key: "update-release", field: "UpdateRelease", permissions: constPermissions(NewPermissionsContentsWrite)},
+	{key: "create-agent-session", field: "CreateAgentSessions", permissions: constPermissions(NewPermissionsContentsReadIssuesWrite)},
+	// GitHub Actions has no gist permission scope; the gist scope is carried by the token
+	// (GH_AW_GIST_TOKEN or github-token), so only contents: read is needed here
+	{key: "create-gist", field: "CreateGists", permissions: constPermissions(NewPermissionsContentsRead)},
+	{key: "create-release", field: "CreateReleases", permissions: constPermissions(NewPermissionsContentsWrite)},
+	{key: "update-project", field: "UpdateProjects", permissions: constPermissions(NewPermissionsContentsReadProjectsWrite)},
+	{key: "create-project", field: "CreateProjects", permissions: constPermissions(NewPermissionsContentsReadProjectsWrite)},
+	{key: "create-project-status-update", field: "CreateProjectStatusUpdates", permissions: constPermissions(NewPermissionsContentsReadProjectsWrite)},
+	{key: "link-sub-issue", field: "LinkSubIssue", permissions: constPermissions(NewPermissionsContentsReadIssuesWrite)},
+	{key: "hide-comment", field: "HideComment", permissions: func(safeOutputs *SafeOutputsConfig) *Permissions {
+		return commentPermissions(safeOutputs.HideComment.Discussions)
+	}},
+	{key: "dispatch-workflow", field: "DispatchWorkflow", permissions: constPermissions(NewPermissionsActionsWrite)},
+	// missing-tool, missing-data and noop only report; they need no write permissions
+	{key: "missing-tool", field: "MissingTool"},
+	{key: "missing-data", field: "MissingData"},
+	{key: "noop", field: "NoOp"},
+}
+
+// safeOutputsConfigType is the reflected type of SafeOutputsConfig
+var safeOutputsConfigType = reflect.TypeFor[SafeOutputsConfig]()
+
+// SafeOutputRegistry returns the metadata of every safe-output type: its frontmatter key, tool
+// name, config struct, the permissions it requires with the default configuration and its
+// default max. The entries come from the same definitions the compiler uses to compute the
+// safe-output job permissions.
+func SafeOutputRegistry() []SafeOutputTypeInfo {
+	registry := make([]SafeOutputTypeInfo, 0, len(safeOutputTypeDefinitions))
+	for _, def := range safeOutputTypeDefinitions {
+		field, ok := safeOutputsConfigType.FieldByName(def.field)
+		if !ok {
+			safeOutputRegistryLog.Printf("Skipping %s: SafeOutputsConfig has no field %s", def.key, def.field)
+			continue
+		}
+		toolName := strings.ReplaceAll(def.key, "-", "_")
+		registry = append(registry, SafeOutputTypeInfo{
+			Key:         def.key,
+			ToolName:    toolName,
+			Field:       def.field,
+			ConfigType:  field.Type.Elem(),
+			Permissions: ComputePermissionsForSafeOutputs(SafeOutputsConfigFromKeys([]string{def.key})),
+			DefaultMax:  GetDefaultMaxForType(toolName),
+		})
+	}
+	return registry
+}
+
+// enabledSafeOutputTypes returns the definitions of the safe-output types configured in safeOutputs
+func enabledSafeOutputTypes(safeOutputs *SafeOutputsConfig) []safeOutputTypeDefinition {
+	value := reflect.ValueOf(safeOutputs).Elem()
+	var enabled []safeOutputTypeDefinition
+	for _, def := range safeOutputTypeDefinitions {
+		if field := value.FieldByName(def.field); field.IsValid() && !field.IsNil() {
+			enabled = append(enabled, def)
+		}
+	}
+	return enabled
+}
+
+// lookupSafeOutputType returns the definition of the safe-output type with the given frontmatter key
+func lookupSafeOutputType(key string) (safeOutputTypeDefinition, bool) {
+	for _, def := range safeOutputTypeDefinitions {
+		if def.key == key {
+			return def, true
+		}
+	}
+	return safeOutputTypeDefinition{}, false
+}
@@ -0,0 +1,89 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// safeOutputsConfigNonTypeFields lists the SafeOutputsConfig pointer and map fields that hold
+// shared settings rather than a safe-output type
+var safeOutputsConfigNonTypeFields = map[string]bool{
+	"ThreatDetection": true,
+	"Jobs":            true,
+	"App":             true,
+	"Env":             true,
+	"Messages":        true,
+	"Mentions":        true,
+	"Footer":          true,
+	"MaxBotMentions":  true,
+}
+
+func TestSafeOutputRegistryCoversConfig(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, info := range SafeOutputRegistry() {
+		registered[info.Field] = true
+	}
+
+	configType := reflect.TypeFor[SafeOutputsConfig]()
+	for i := range configType.NumField() {
+		field := configType.Field(i)
+		if field.Type.Kind() != reflect.Pointer && field.Type.Kind() != reflect.Map {
+			continue
+		}
+		if safeOutputsConfigNonTypeFields[field.Name] {
+			assert.False(t, registered[field.Name], "%s holds shared settings and must not be registered", field.Name)
+			continue
+		}
+		assert.True(t, registered[field.Name], "SafeOutputsConfig.%s has no entry in safeOutputTypeDefinitions", field.Name)
+	}
+}
+
+func TestSafeOutputRegistry(t *testing.T) {
+	registry := SafeOutputRegistry()
+	require.Len(t, registry, len(safeOutputTypeDefinitions), "every definition should name an existing field")
+
+	byKey := make(map[string]SafeOutputTypeInfo, len(registry))
+	for _, info := range registry {
+		_, duplicate := byKey[info.Key]
+		assert.False(t, duplicate, "duplicate registry key %s", info.Key)
+		byKey[info.Key] = info
+	}
+
+	createIssue := byKey["create-issue"]
+	assert.Equal(t, "create_issue", createIssue.ToolName)
+	assert.Equal(t, "CreateIssues", createIssue.Field)
+	assert.Equal(t, reflect.TypeFor[CreateIssuesConfig](), createIssue.ConfigType)
+	assert.Equal(t, GetDefaultMaxForType("create_issue"), createIssue.DefaultMax)
+	assert.Equal(t, NewPermissionsContentsReadIssuesWrite().permissions, createIssue.Permissions.permissions)
+
+	addComment := byKey["add-comment"]
+	assert.Equal(t, NewPermissionsContentsReadIssuesWriteDiscussionsWrite().permissions, addComment.Permissions.permissions,
+		"add-comment requires discussions: write by default")
+
+	assert.Empty(t, byKey["noop"].Permissions.permissions, "noop requires no permissions")
+	assert.Equal(t, 1, byKey["dispatch-workflow"].DefaultMax, "types without validation config default to max 1")
+}
+
+func TestSafeOutputRegistryMatchesDerivedPermissions(t *testing.T) {
+	for _, info := range SafeOutputRegistry() {
+		t.Run(info.Key, func(t *testing.T) {
+			derived := DerivePermissions(SafeOutputsConfigFromKeys([]string{info.Key})).Permissions()
+			assert.Equal(t, info.Permissions.permissions, derived.permissions)
+		})
+	}
+}
+
+func TestSafeOutputsConfigFromKeys(t *testing.T) {
+	config := SafeOutputsConfigFromKeys([]string{"create-issue", "create-release", "dispatch-workflow", "unknown-type"})
+
+	assert.NotNil(t, config.CreateIssues)
+	assert.NotNil(t, config.DispatchWorkflow)
+	require.NotNil(t, config.CreateReleases)
+	assert.True(t, config.CreateReleases.Draft, "releases should be drafts by default")
+	assert.Nil(t, config.AddComments)
+}
@@ -87,6 +87,9 @@ func validateSafeOutputsTarget(config *SafeOutputsConfig) error {
 	if config.SubmitPullRequestReview != nil {
 		configs = append(configs, targetConfig{"submit-pull-request-review", config.SubmitPullRequestReview.Target})
 	}
+	if config.CreatePullRequestReviews != nil {
+		configs = append(configs, targetConfig{"create-pull-request-review", config.CreatePullRequestReviews.Target})
+	}
 	if config.ReplyToPullRequestReviewComment != nil {
 		configs = append(configs, targetConfig{"reply-to-pull-request-review-comment", config.ReplyToPullRequestReviewComment.Target})
 	}
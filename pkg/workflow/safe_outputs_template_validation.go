@@ -0,0 +1,98 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsTemplateValidationLog = logger.New("workflow:safe_outputs_template_validation")
+
+// safeOutputTemplateContextPlaceholders are the run context placeholders that title-template
+// and body-template may reference. They are resolved at runtime by the safe output script.
+var safeOutputTemplateContextPlaceholders = []string{"workflow", "run_id", "actor", "event"}
+
+// safeOutputTemplatePlaceholderPattern matches a ${{ name }} placeholder in a template
+var safeOutputTemplatePlaceholderPattern = regexp.MustCompile(`\$\{\{\s*([^}]*?)\s*\}\}`)
+
+// validateSafeOutputsTemplates validates the title-template and body-template fields of
+// create-issue and create-pull-request. Only whitelisted placeholders are allowed so that
+// templates cannot inject arbitrary GitHub Actions expressions into the compiled workflow.
+func validateSafeOutputsTemplates(config *SafeOutputsConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	type templateConfig struct {
+		name     string
+		field    string
+		template string
+	}
+
+	var templates []templateConfig
+	if config.CreateIssues != nil {
+		templates = append(templates,
+			templateConfig{"create-issue", "title", config.CreateIssues.TitleTemplate},
+			templateConfig{"create-issue", "body", config.CreateIssues.BodyTemplate},
+		)
+	}
+	if config.CreatePullRequests != nil {
+		templates = append(templates,
+			templateConfig{"create-pull-request", "title", config.CreatePullRequests.TitleTemplate},
+			templateConfig{"create-pull-request", "body", config.CreatePullRequests.BodyTemplate},
+		)
+	}
+
+	for _, tmpl := range templates {
+		if err := validateSafeOutputTemplate(tmpl.name, tmpl.field, tmpl.template); err != nil {
+			return err
+		}
+	}
+
+	safeOutputsTemplateValidationLog.Printf("Validated %d safe output templates", len(templates))
+	return nil
+}
+
+// validateSafeOutputTemplate validates a single template. field is "title" or "body" and is
+// also accepted as a placeholder for the agent-provided value.
+func validateSafeOutputTemplate(configName, field, template string) error {
+	if template == "" {
+		return nil
+	}
+
+	allowed := append([]string{field}, safeOutputTemplateContextPlaceholders...)
+
+	for _, match := range safeOutputTemplatePlaceholderPattern.FindAllStringSubmatch(template, -1) {
+		name := match[1]
+		if !slices.Contains(allowed, name) {
+			return fmt.Errorf("invalid placeholder %q in %s.%s-template: only %s are allowed; arbitrary GitHub Actions expressions are not supported",
+				match[0], configName, field, formatSafeOutputTemplatePlaceholders(allowed))
+		}
+	}
+
+	// Reject unterminated or nested expressions that the placeholder pattern does not consume
+	if strings.Contains(safeOutputTemplatePlaceholderPattern.ReplaceAllString(template, ""), "${{") {
+		return fmt.Errorf("invalid %s.%s-template: unterminated '${{' expression", configName, field)
+	}
+
+	return nil
+}
+
+// formatSafeOutputTemplatePlaceholders formats placeholders for error messages (e.g. "${{ title }}, ${{ run_id }}")
+func formatSafeOutputTemplatePlaceholders(placeholders []string) string {
+	formatted := make([]string, len(placeholders))
+	for i, placeholder := range placeholders {
+		formatted[i] = "${{ " + placeholder + " }}"
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// normalizeSafeOutputTemplate converts ${{ name }} placeholders into the {name} form used by the
+// runtime template renderer, so the compiled workflow never contains an expression for
+// GitHub Actions to evaluate. Templates must be validated with validateSafeOutputTemplate first.
+func normalizeSafeOutputTemplate(template string) string {
+	return safeOutputTemplatePlaceholderPattern.ReplaceAllString(template, "{$1}")
+}
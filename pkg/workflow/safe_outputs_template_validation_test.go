@@ -0,0 +1,174 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSafeOutputTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		field       string
+		template    string
+		errContains string
+	}{
+		{
+			name:     "empty template",
+			field:    "title",
+			template: "",
+		},
+		{
+			name:     "plain text",
+			field:    "title",
+			template: "[bot] report",
+		},
+		{
+			name:     "all context placeholders",
+			field:    "body",
+			template: "${{ workflow }} run ${{ run_id }} by ${{actor}} on ${{ event }}\n\n${{ body }}",
+		},
+		{
+			name:     "title placeholder",
+			field:    "title",
+			template: "[${{ event }}] ${{ title }}",
+		},
+		{
+			name:        "body placeholder in title template",
+			field:       "title",
+			template:    "${{ body }}",
+			errContains: `invalid placeholder "${{ body }}" in create-issue.title-template`,
+		},
+		{
+			name:        "arbitrary expression",
+			field:       "title",
+			template:    "${{ github.event.issue.title }}",
+			errContains: "arbitrary GitHub Actions expressions are not supported",
+		},
+		{
+			name:        "secret reference",
+			field:       "body",
+			template:    "${{ secrets.GITHUB_TOKEN }}",
+			errContains: `invalid placeholder "${{ secrets.GITHUB_TOKEN }}"`,
+		},
+		{
+			name:        "unterminated expression",
+			field:       "title",
+			template:    "${{ run_id",
+			errContains: "unterminated '${{' expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSafeOutputTemplate("create-issue", tt.field, tt.template)
+			if tt.errContains == "" {
+				assert.NoError(t, err, "Template should be valid")
+				return
+			}
+			require.Error(t, err, "Template should be rejected")
+			assert.Contains(t, err.Error(), tt.errContains, "Error should explain the rejection")
+		})
+	}
+}
+
+func TestValidateSafeOutputsTemplates(t *testing.T) {
+	assert.NoError(t, validateSafeOutputsTemplates(nil), "Nil config should be valid")
+
+	err := validateSafeOutputsTemplates(&SafeOutputsConfig{
+		CreateIssues:       &CreateIssuesConfig{TitleTemplate: "${{ title }}"},
+		CreatePullRequests: &CreatePullRequestsConfig{BodyTemplate: "${{ env.SECRET }}"},
+	})
+	require.Error(t, err, "Invalid pull request body template should be rejected")
+	assert.Contains(t, err.Error(), "create-pull-request.body-template", "Error should name the offending field")
+}
+
+func TestNormalizeSafeOutputTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{template: "", expected: ""},
+		{template: "plain", expected: "plain"},
+		{template: "[${{ event }}] ${{title}}", expected: "[{event}] {title}"},
+		{template: "Run ${{  run_id  }} by ${{ actor }}", expected: "Run {run_id} by {actor}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeSafeOutputTemplate(tt.template), "Normalized template should match")
+		})
+	}
+}
+
+func TestCompileWorkflowWithSafeOutputTemplates(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-output-templates-test")
+
+	testContent := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+    title-template: "[${{ event }}] ${{ title }}"
+    body-template: "Run ${{ run_id }} by @${{ actor }}"
+  create-pull-request:
+    title-template: "${{ workflow }}: ${{ title }}"
+---
+
+# Templates
+
+Create an issue.
+`
+
+	testFile := filepath.Join(tmpDir, "templates.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow with templates should compile")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Should read lock file")
+
+	safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+	require.NotEmpty(t, safeOutputsJob, "Lock file should contain the safe_outputs job")
+	assert.Contains(t, safeOutputsJob, `\"title_template\":\"[{event}] {title}\"`, "Handler config should contain the normalized title template")
+	assert.Contains(t, safeOutputsJob, `\"body_template\":\"Run {run_id} by @{actor}\"`, "Handler config should contain the normalized body template")
+	assert.Contains(t, safeOutputsJob, `\"title_template\":\"{workflow}: {title}\"`, "Handler config should contain the pull request title template")
+	assert.NotContains(t, safeOutputsJob, "${{ run_id }}", "Placeholders should not be emitted as expressions")
+	assert.NotContains(t, string(lockContent), "_TEMPLATE:", "Templates should only be passed through the handler config")
+}
+
+func TestCompileWorkflowRejectsArbitraryTemplateExpressions(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-output-templates-invalid-test")
+
+	testContent := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-pull-request:
+    title-template: "${{ github.event.issue.title }}"
+---
+
+# Templates
+
+Create a pull request.
+`
+
+	testFile := filepath.Join(tmpDir, "templates.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Arbitrary expressions in templates should be rejected")
+	assert.Contains(t, err.Error(), "create-pull-request.title-template", "Error should name the offending field")
+}
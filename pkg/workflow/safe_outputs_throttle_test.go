@@ -0,0 +1,113 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractSafeOutputsConfigThrottleMs(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected int
+	}{
+		{name: "int value", value: 500, expected: 500},
+		{name: "uint64 value", value: uint64(250), expected: 250},
+		{name: "float value", value: 100.0, expected: 100},
+		{name: "zero is ignored", value: 0, expected: 0},
+		{name: "negative is ignored", value: -10, expected: 0},
+		{name: "string is ignored", value: "500", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"safe-outputs": map[string]any{
+					"add-comment": nil,
+					"throttle-ms": tt.value,
+				},
+			}
+
+			config := NewCompiler().extractSafeOutputsConfig(frontmatter)
+			require.NotNil(t, config, "Safe outputs config should be parsed")
+			assert.Equal(t, tt.expected, config.ThrottleMs, "ThrottleMs should match")
+		})
+	}
+}
+
+func TestMergeSafeOutputsThrottleMs(t *testing.T) {
+	compiler := NewCompiler()
+
+	merged, err := compiler.MergeSafeOutputs(&SafeOutputsConfig{}, []string{`{"throttle-ms": 300}`})
+	require.NoError(t, err, "Merging imported throttle-ms should succeed")
+	assert.Equal(t, 300, merged.ThrottleMs, "Imported throttle-ms should be used when the main workflow sets none")
+
+	merged, err = compiler.MergeSafeOutputs(&SafeOutputsConfig{ThrottleMs: 100}, []string{`{"throttle-ms": 300}`})
+	require.NoError(t, err, "Merging imported throttle-ms should succeed")
+	assert.Equal(t, 100, merged.ThrottleMs, "Main workflow throttle-ms should take precedence over imports")
+}
+
+func TestCompileWorkflowWithSafeOutputsThrottleMs(t *testing.T) {
+	tests := []struct {
+		name        string
+		throttle    string
+		expectEnv   bool
+		expectedEnv string
+	}{
+		{
+			name:        "throttle-ms is plumbed to the job env",
+			throttle:    "  throttle-ms: 750\n",
+			expectEnv:   true,
+			expectedEnv: `GH_AW_SAFE_OUTPUTS_THROTTLE_MS: "750"`,
+		},
+		{
+			name:      "inter-call delay is opt-in",
+			expectEnv: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "safe-outputs-throttle-test")
+
+			testContent := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+` + tt.throttle + `  add-comment:
+    max: 10
+  add-labels:
+---
+
+# Throttle
+
+Triage the issue.
+`
+
+			testFile := filepath.Join(tmpDir, "throttle.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+			require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			require.NoError(t, err, "Should read lock file")
+
+			safeOutputsJob := extractJobSection(string(lockContent), "safe_outputs")
+			require.NotEmpty(t, safeOutputsJob, "Lock file should contain the safe_outputs job")
+			if tt.expectEnv {
+				assert.Contains(t, safeOutputsJob, tt.expectedEnv, "Job should expose the inter-call delay")
+			} else {
+				assert.NotContains(t, safeOutputsJob, "GH_AW_SAFE_OUTPUTS_THROTTLE_MS", "Delay should only be emitted when configured")
+			}
+		})
+	}
+}
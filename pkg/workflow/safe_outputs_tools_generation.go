@@ -72,6 +72,12 @@ func generateFilteredToolsJSON(data *WorkflowData, markdownPath string) (string,
 	if data.SafeOutputs.CreateAgentSessions != nil {
 		enabledTools["create_agent_session"] = true
 	}
+	if data.SafeOutputs.CreateGists != nil {
+		enabledTools["create_gist"] = true
+	}
+	if data.SafeOutputs.CreateReleases != nil {
+		enabledTools["create_release"] = true
+	}
 	if data.SafeOutputs.CreateDiscussions != nil {
 		enabledTools["create_discussion"] = true
 	}
@@ -102,6 +108,9 @@ func generateFilteredToolsJSON(data *WorkflowData, markdownPath string) (string,
 	if data.SafeOutputs.SubmitPullRequestReview != nil {
 		enabledTools["submit_pull_request_review"] = true
 	}
+	if data.SafeOutputs.CreatePullRequestReviews != nil {
+		enabledTools["create_pull_request_review"] = true
+	}
 	if data.SafeOutputs.ReplyToPullRequestReviewComment != nil {
 		enabledTools["reply_to_pull_request_review_comment"] = true
 	}
@@ -197,6 +206,9 @@ func generateFilteredToolsJSON(data *WorkflowData, markdownPath string) (string,
 			// Add repo parameter to inputSchema if allowed-repos has entries
 			addRepoParameterIfNeeded(enhancedTool, toolName, data.SafeOutputs)
 
+			// Make the closing comment optional when require-comment is false
+			makeCloseCommentOptionalIfNeeded(enhancedTool, toolName, data.SafeOutputs)
+
 			filteredTools = append(filteredTools, enhancedTool)
 		}
 	}
@@ -464,6 +476,52 @@ func addRepoParameterIfNeeded(tool map[string]any, toolName string, safeOutputs
 	safeOutputsConfigLog.Printf("Added repo parameter to tool: %s (has allowed-repos or wildcard target-repo)", toolName)
 }
 
+// closeCommentConfig returns the close config of a close_issue or close_pull_request tool
+func closeCommentConfig(toolName string, safeOutputs *SafeOutputsConfig) *CloseEntityConfig {
+	if safeOutputs == nil {
+		return nil
+	}
+	switch toolName {
+	case "close_issue":
+		return safeOutputs.CloseIssues
+	case "close_pull_request":
+		return safeOutputs.ClosePullRequests
+	}
+	return nil
+}
+
+// makeCloseCommentOptionalIfNeeded removes "body" from the required parameters of the
+// close_issue and close_pull_request tools when the workflow sets require-comment: false
+func makeCloseCommentOptionalIfNeeded(tool map[string]any, toolName string, safeOutputs *SafeOutputsConfig) {
+	config := closeCommentConfig(toolName, safeOutputs)
+	if config == nil || closeRequiresComment(config) {
+		return
+	}
+
+	inputSchema, ok := tool["inputSchema"].(map[string]any)
+	if !ok {
+		return
+	}
+	required, ok := inputSchema["required"].([]any)
+	if !ok {
+		return
+	}
+
+	optional := make([]any, 0, len(required))
+	for _, field := range required {
+		if field != "body" {
+			optional = append(optional, field)
+		}
+	}
+	if len(optional) == 0 {
+		delete(inputSchema, "required")
+	} else {
+		inputSchema["required"] = optional
+	}
+
+	safeOutputsConfigLog.Printf("Made closing comment optional for tool: %s (require-comment: false)", toolName)
+}
+
 // generateDispatchWorkflowTool generates an MCP tool definition for a specific workflow
 // The tool will be named after the workflow and accept the workflow's defined inputs
 func generateDispatchWorkflowTool(workflowName string, workflowInputs map[string]any) map[string]any {
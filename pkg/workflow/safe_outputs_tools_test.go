@@ -307,6 +307,8 @@ func TestGetSafeOutputsToolsJSON(t *testing.T) {
 	expectedTools := []string{
 		"create_issue",
 		"create_agent_session",
+		"create_gist",
+		"create_release",
 		"create_discussion",
 		"update_discussion",
 		"close_discussion",
@@ -317,6 +319,7 @@ func TestGetSafeOutputsToolsJSON(t *testing.T) {
 		"create_pull_request",
 		"create_pull_request_review_comment",
 		"submit_pull_request_review",
+		"create_pull_request_review",
 		"reply_to_pull_request_review_comment",
 		"resolve_pull_request_review_thread",
 		"create_code_scanning_alert",
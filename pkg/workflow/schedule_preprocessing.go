@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
@@ -58,7 +59,6 @@ func (c *Compiler) normalizeScheduleString(scheduleStr string, itemIndex int) (p
 			} else {
 				// Warn if repository slug is not available - scattering will not be org-aware
 				schedulePreprocessingLog.Printf("Warning: repository slug not available for fuzzy schedule scattering")
-				c.IncrementWarningCount()
 				c.addScheduleWarning("Fuzzy schedule scattering without repository context. Workflows with the same name in different repositories may collide. Ensure you are in a git repository with a configured remote.")
 			}
 		} else {
@@ -259,8 +259,10 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 		c.scheduleFriendlyFormats = make(map[int]string)
 	}
 
-	// Process each schedule item
+	// Process each schedule item. Items with a time zone may expand into several UTC items,
+	// so the array is rebuilt and friendly formats are keyed by the index in the new array.
 	schedulePreprocessingLog.Printf("Processing %d schedule items", len(scheduleArray))
+	var processedSchedule []any
 	for i, item := range scheduleArray {
 		itemMap, ok := item.(map[string]any)
 		if !ok {
@@ -284,14 +286,32 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 			return err
 		}
 
+		if tzValue, hasTZ := itemMap["tz"]; hasTZ {
+			tz, ok := tzValue.(string)
+			if !ok {
+				return fmt.Errorf("schedule item %d 'tz' field must be a string", i)
+			}
+			items, err := c.convertScheduleTimezone(cronStr, parsedCron, tz, i)
+			if err != nil {
+				return err
+			}
+			for _, tzItem := range items {
+				c.scheduleFriendlyFormats[len(processedSchedule)] = tzItem.friendly
+				processedSchedule = append(processedSchedule, map[string]any{"cron": tzItem.cron})
+			}
+			continue
+		}
+
 		// Update the cron field with the parsed cron expression
 		itemMap["cron"] = parsedCron
 
 		// If there was an original friendly format, store it for later use
 		if original != "" {
-			c.scheduleFriendlyFormats[i] = original
+			c.scheduleFriendlyFormats[len(processedSchedule)] = original
 		}
+		processedSchedule = append(processedSchedule, itemMap)
 	}
+	onMap["schedule"] = processedSchedule
 
 	// Add workflow_dispatch if not already present
 	if _, hasWorkflowDispatch := onMap["workflow_dispatch"]; !hasWorkflowDispatch {
@@ -302,6 +322,35 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 	return nil
 }
 
+// timezoneScheduleItem is a UTC schedule item produced from a schedule item with a time zone
+type timezoneScheduleItem struct {
+	cron     string
+	friendly string
+}
+
+// convertScheduleTimezone converts the normalized cron of a schedule item written in the time
+// zone tz into UTC schedule items, one per UTC offset the zone uses during the current year
+func (c *Compiler) convertScheduleTimezone(cronStr, parsedCron, tz string, itemIndex int) ([]timezoneScheduleItem, error) {
+	if strings.Contains(strings.ToLower(cronStr), "utc") {
+		return nil, fmt.Errorf("schedule item %d cannot combine a UTC offset in 'cron' with 'tz'", itemIndex)
+	}
+
+	converted, err := parser.ConvertCronToUTC(parsedCron, tz, time.Now().Year())
+	if err != nil {
+		return nil, fmt.Errorf("schedule item %d: %w", itemIndex, err)
+	}
+	schedulePreprocessingLog.Printf("Converted schedule item %d (%s in %s) to %d UTC cron expression(s)", itemIndex, parsedCron, tz, len(converted))
+
+	items := make([]timezoneScheduleItem, 0, len(converted))
+	for _, entry := range converted {
+		items = append(items, timezoneScheduleItem{
+			cron:     entry.Cron,
+			friendly: fmt.Sprintf("%s in %s (%s)", cronStr, tz, entry.Offset),
+		})
+	}
+	return items, nil
+}
+
 // createTriggerParseError creates a detailed error for trigger parsing issues with source location
 func (c *Compiler) createTriggerParseError(filePath, content, triggerStr string, err error) error {
 	schedulePreprocessingLog.Printf("Creating trigger parse error for: %s", triggerStr)
@@ -443,11 +492,7 @@ func (c *Compiler) addDailyCronWarning(cronExpr string) {
 			hour, minute,
 		)
 
-		// This warning is added to the warning count
-		// It will be collected and displayed by the compilation process
-		c.IncrementWarningCount()
-
-		// Store the warning for later display
+		// Store the warning for later display; this also adds it to the warning count
 		c.addScheduleWarning(warningMsg)
 	}
 }
@@ -470,10 +515,7 @@ func (c *Compiler) addHourlyCronWarning(cronExpr string) {
 			minute, interval,
 		)
 
-		// This warning is added to the warning count
-		c.IncrementWarningCount()
-
-		// Store the warning for later display
+		// Store the warning for later display; this also adds it to the warning count
 		c.addScheduleWarning(warningMsg)
 	}
 }
@@ -509,16 +551,15 @@ func (c *Compiler) addWeeklyCronWarning(cronExpr string) {
 			weekdayName, hour, minute, strings.ToLower(weekdayName),
 		)
 
-		// This warning is added to the warning count
-		c.IncrementWarningCount()
-
-		// Store the warning for later display
+		// Store the warning for later display; this also adds it to the warning count
 		c.addScheduleWarning(warningMsg)
 	}
 }
 
-// addScheduleWarning adds a warning to the compiler's schedule warnings list
+// addScheduleWarning records a warning and adds it to the compiler's schedule warnings list,
+// which the compilation process displays after compiling
 func (c *Compiler) addScheduleWarning(warning string) {
+	c.recordWarning(warning)
 	if c.scheduleWarnings == nil {
 		c.scheduleWarnings = []string{}
 	}
@@ -1553,3 +1553,96 @@ func TestFuzzyScheduleDevModeDifferentFromReleaseMode(t *testing.T) {
 	t.Logf("Dev mode result: %s", devResult)
 	t.Logf("Release mode result: %s", releaseResult)
 }
+
+func TestSchedulePreprocessingWithTimezone(t *testing.T) {
+	frontmatter := map[string]any{
+		"on": map[string]any{
+			"schedule": []any{
+				map[string]any{"cron": "30 8 * * 1-5", "tz": "Asia/Kolkata"},
+				map[string]any{"cron": "0 12 * * *"},
+			},
+		},
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.preprocessScheduleFields(frontmatter, "", ""); err != nil {
+		t.Fatalf("preprocessing failed: %v", err)
+	}
+
+	schedule := frontmatter["on"].(map[string]any)["schedule"].([]any)
+	if len(schedule) != 2 {
+		t.Fatalf("expected 2 schedule items, got %d: %v", len(schedule), schedule)
+	}
+	first := schedule[0].(map[string]any)
+	if first["cron"] != "0 3 * * 1-5" {
+		t.Errorf("expected Kolkata schedule to convert to '0 3 * * 1-5', got %v", first["cron"])
+	}
+	if _, hasTZ := first["tz"]; hasTZ {
+		t.Errorf("expected 'tz' to be removed from the compiled schedule item, got %v", first)
+	}
+	if got := compiler.scheduleFriendlyFormats[0]; got != "30 8 * * 1-5 in Asia/Kolkata (UTC+05:30)" {
+		t.Errorf("unexpected friendly format for converted item: %q", got)
+	}
+	if second := schedule[1].(map[string]any); second["cron"] != "0 12 * * *" {
+		t.Errorf("expected UTC schedule to be unchanged, got %v", second["cron"])
+	}
+}
+
+func TestSchedulePreprocessingWithTimezoneDaylightSaving(t *testing.T) {
+	frontmatter := map[string]any{
+		"on": map[string]any{
+			"schedule": []any{
+				map[string]any{"cron": "0 9 * * 1", "tz": "America/New_York"},
+			},
+		},
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.preprocessScheduleFields(frontmatter, "", ""); err != nil {
+		t.Fatalf("preprocessing failed: %v", err)
+	}
+
+	schedule := frontmatter["on"].(map[string]any)["schedule"].([]any)
+	if len(schedule) != 2 {
+		t.Fatalf("expected one schedule item per UTC offset, got %d: %v", len(schedule), schedule)
+	}
+	for i, item := range schedule {
+		cron := item.(map[string]any)["cron"].(string)
+		if !strings.HasPrefix(cron, "0 14 ") && !strings.HasPrefix(cron, "0 13 ") {
+			t.Errorf("schedule item %d: expected 9:00 New York time in UTC, got %q", i, cron)
+		}
+		if !strings.Contains(compiler.scheduleFriendlyFormats[i], "in America/New_York (UTC-0") {
+			t.Errorf("schedule item %d: unexpected friendly format %q", i, compiler.scheduleFriendlyFormats[i])
+		}
+	}
+}
+
+func TestSchedulePreprocessingWithInvalidTimezone(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    map[string]any
+		errText string
+	}{
+		{name: "unknown time zone", item: map[string]any{"cron": "0 9 * * 1", "tz": "Europe/Atlantis"}, errText: "unknown time zone 'Europe/Atlantis'"},
+		{name: "non-string time zone", item: map[string]any{"cron": "0 9 * * 1", "tz": 5}, errText: "'tz' field must be a string"},
+		{name: "UTC offset in cron", item: map[string]any{"cron": "daily around 09:00 utc+1", "tz": "Europe/Paris"}, errText: "cannot combine a UTC offset"},
+		{name: "interval schedule", item: map[string]any{"cron": "0 */6 * * *", "tz": "Europe/Paris"}, errText: "fixed minute and hour"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"on": map[string]any{"schedule": []any{tt.item}},
+			}
+			compiler := NewCompiler()
+			compiler.SetWorkflowIdentifier("test-workflow.md")
+			err := compiler.preprocessScheduleFields(frontmatter, "", "")
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("expected error containing %q, got %q", tt.errText, err.Error())
+			}
+		})
+	}
+}
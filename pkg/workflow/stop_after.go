@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -63,6 +65,11 @@ func (c *Compiler) processStopAfterConfiguration(frontmatter map[string]any, wor
 	// Resolve relative stop-after to absolute time if needed
 	if workflowData.StopTime != "" {
 		stopAfterLog.Printf("Stop-after value specified: %s", workflowData.StopTime)
+		// Validate the configured value even when the stop time is preserved from the lock file
+		if _, err := ParseStopAfter(workflowData.StopTime); err != nil {
+			return fmt.Errorf("invalid stop-after format: %w", err)
+		}
+
 		// Check if there's already a lock file with a stop time (recompilation case)
 		lockFile := stringutil.MarkdownToLockFile(markdownPath)
 		existingStopTime := ExtractStopTimeFromLockFile(lockFile)
@@ -111,33 +118,82 @@ func (c *Compiler) processStopAfterConfiguration(frontmatter map[string]any, wor
 	return nil
 }
 
-// resolveStopTime resolves a stop-time value to an absolute timestamp
-// If the stop-time is relative (starts with '+'), it calculates the absolute time
-// from the compilation time. Otherwise, it parses the absolute time using various formats.
-func resolveStopTime(stopTime string, compilationTime time.Time) (string, error) {
-	if stopTime == "" {
-		return "", nil
+// stopTimeFormat is the canonical format of resolved stop times in lock files
+const stopTimeFormat = "2006-01-02 15:04:05"
+
+// ambiguousSlashDatePattern matches numeric dates such as 03/04/2025 whose day and month order
+// cannot be determined
+var ambiguousSlashDatePattern = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})/(\d{4})\b`)
+
+// ParseStopAfter parses a stop-after value and returns the absolute time at which the workflow
+// stops running. It accepts relative deltas from now such as "+48h", "+3d", "+2w", "+1mo", or
+// "+1d12h", absolute "2006-01-02 15:04:05" timestamps, RFC3339 timestamps, and the readable date
+// formats supported by the compiler (e.g. "June 1, 2025"). Numeric dates that could be read as
+// either MM/DD or DD/MM (e.g. "03/04/2025") are rejected as ambiguous.
+func ParseStopAfter(value string) (time.Time, error) {
+	return parseStopAfterAt(value, time.Now())
+}
+
+// parseStopAfterAt parses a stop-after value, resolving relative deltas from now
+func parseStopAfterAt(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New("stop-after value is empty")
 	}
 
-	if isRelativeStopTime(stopTime) {
+	if isRelativeStopTime(value) {
 		// Parse the relative time delta (minutes not allowed for stop-after)
-		delta, err := parseTimeDeltaForStopAfter(stopTime)
+		delta, err := parseTimeDeltaForStopAfter(value)
 		if err != nil {
-			return "", err
+			return time.Time{}, err
 		}
 
 		// Calculate absolute time in UTC using precise calculation
 		// Always use AddDate for months, weeks, and days for maximum precision
-		absoluteTime := compilationTime.UTC()
+		absoluteTime := now.UTC()
 		absoluteTime = absoluteTime.AddDate(0, delta.Months, delta.Weeks*7+delta.Days)
 		absoluteTime = absoluteTime.Add(time.Duration(delta.Hours)*time.Hour + time.Duration(delta.Minutes)*time.Minute)
+		return absoluteTime.Truncate(time.Second), nil
+	}
+
+	for _, format := range []string{stopTimeFormat, time.RFC3339} {
+		if parsed, err := time.Parse(format, value); err == nil {
+			return parsed.UTC(), nil
+		}
+	}
 
-		// Format in the expected format: "YYYY-MM-DD HH:MM:SS"
-		return absoluteTime.Format("2006-01-02 15:04:05"), nil
+	if matches := ambiguousSlashDatePattern.FindStringSubmatch(value); matches != nil {
+		first, _ := strconv.Atoi(matches[1])
+		second, _ := strconv.Atoi(matches[2])
+		if first <= 12 && second <= 12 && first != second {
+			year := matches[3]
+			return time.Time{}, fmt.Errorf("ambiguous stop-after date %q: it could be MM/DD or DD/MM. Use YYYY-MM-DD instead, e.g. \"%s-%02d-%02d\" or \"%s-%02d-%02d\"", value, year, first, second, year, second, first)
+		}
 	}
 
 	// Parse absolute date-time with flexible format support
-	return parseAbsoluteDateTime(stopTime)
+	resolved, err := parseAbsoluteDateTime(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(stopTimeFormat, resolved)
+}
+
+// resolveStopTime resolves a stop-time value to an absolute timestamp
+// If the stop-time is relative (starts with '+'), it calculates the absolute time
+// from the compilation time. Otherwise, it parses the absolute time using various formats.
+func resolveStopTime(stopTime string, compilationTime time.Time) (string, error) {
+	if stopTime == "" {
+		return "", nil
+	}
+
+	stopAt, err := parseStopAfterAt(stopTime, compilationTime)
+	if err != nil {
+		return "", err
+	}
+
+	// Format in the expected format: "YYYY-MM-DD HH:MM:SS"
+	return stopAt.Format(stopTimeFormat), nil
 }
 
 // ExtractStopTimeFromLockFile extracts the STOP_TIME value from a compiled workflow lock file
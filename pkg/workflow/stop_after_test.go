@@ -147,6 +147,74 @@ func TestResolveStopTimeRejectsMinutes(t *testing.T) {
 	}
 }
 
+// TestParseStopAfterFormats tests the stop-after formats accepted by parseStopAfterAt
+func TestParseStopAfterFormats(t *testing.T) {
+	now := time.Date(2025, 8, 15, 12, 0, 30, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+	}{
+		{name: "relative hours", value: "+48h", expected: time.Date(2025, 8, 17, 12, 0, 30, 0, time.UTC)},
+		{name: "relative days", value: "+3d", expected: time.Date(2025, 8, 18, 12, 0, 30, 0, time.UTC)},
+		{name: "relative weeks", value: "+2w", expected: time.Date(2025, 8, 29, 12, 0, 30, 0, time.UTC)},
+		{name: "relative combined", value: "+1w2d6h", expected: time.Date(2025, 8, 24, 18, 0, 30, 0, time.UTC)},
+		{name: "absolute timestamp", value: "2025-12-31 23:59:59", expected: time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{name: "absolute timestamp with whitespace", value: "  2025-12-31 23:59:59 ", expected: time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{name: "RFC3339 UTC", value: "2025-12-31T23:59:59Z", expected: time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{name: "RFC3339 with offset", value: "2025-12-31T20:00:00-05:00", expected: time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{name: "readable date", value: "June 1, 2025", expected: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "unambiguous US date", value: "06/15/2025", expected: time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "same day and month", value: "06/06/2025", expected: time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseStopAfterAt(tt.value, now)
+			if err != nil {
+				t.Fatalf("parseStopAfterAt(%q) unexpected error: %v", tt.value, err)
+			}
+			if !result.Equal(tt.expected) {
+				t.Errorf("parseStopAfterAt(%q) = %v, want %v", tt.value, result, tt.expected)
+			}
+			if result.Location() != time.UTC {
+				t.Errorf("parseStopAfterAt(%q) location = %v, want UTC", tt.value, result.Location())
+			}
+		})
+	}
+}
+
+// TestParseStopAfterRejections tests that invalid and ambiguous stop-after values are rejected
+func TestParseStopAfterRejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		errorMsg string
+	}{
+		{name: "empty", value: "  ", errorMsg: "empty"},
+		{name: "ambiguous numeric date", value: "06/01/2025", errorMsg: "ambiguous stop-after date"},
+		{name: "ambiguous numeric date with time", value: "03/04/2025 15:30", errorMsg: "\"2025-03-04\" or \"2025-04-03\""},
+		{name: "relative without unit", value: "+48", errorMsg: "invalid time delta format"},
+		{name: "relative minutes", value: "+90m", errorMsg: "minute unit 'm' is not allowed"},
+		{name: "relative beyond one year", value: "+400d", errorMsg: "too large"},
+		{name: "negative delta", value: "-1d", errorMsg: "unable to parse date-time"},
+		{name: "unknown format", value: "next tuesday", errorMsg: "unable to parse date-time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseStopAfter(tt.value)
+			if err == nil {
+				t.Fatalf("ParseStopAfter(%q) expected error but got %v", tt.value, result)
+			}
+			if !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("ParseStopAfter(%q) error = %v, want to contain %q", tt.value, err, tt.errorMsg)
+			}
+		})
+	}
+}
+
 // TestRefreshStopTimeBehavior tests that the refreshStopTime flag controls stop time preservation
 func TestRefreshStopTimeBehavior(t *testing.T) {
 	// Create a temporary directory for test files
@@ -0,0 +1,232 @@
+// This file provides matrix strategy support for the main agent job.
+//
+// # Matrix Strategy
+//
+// The frontmatter strategy field is rendered as-is on the agent job, so each matrix
+// combination runs its own agent job leg. Matrix values can be referenced in the prompt
+// as ${{ matrix.<key> }}:
+//
+//   - The activation job (which is not part of the matrix) leaves matrix expressions untouched
+//     when it builds the prompt
+//   - Each agent job leg substitutes its own matrix values into the downloaded prompt
+//
+// # Per-Leg Artifacts
+//
+// Jobs that consume artifacts uploaded by a matrix leg (such as cache-memory updates) are run
+// with the same strategy and artifact names are suffixed with the leg index, so each leg's
+// artifacts are processed by a matching leg of every downstream job.
+//
+// # Safe Outputs
+//
+// A strategy cannot be combined with safe-outputs. Threat detection and safe output jobs are
+// gated on job outputs (needs.agent.outputs.*, needs.detection.outputs.success), and GitHub
+// Actions collapses the outputs of a matrix job to a single leg, so one leg's safe outputs
+// could be applied based on another leg's threat detection verdict.
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var strategyLog = logger.New("workflow:strategy")
+
+// matrixExpressionRegex matches simple ${{ matrix.<key> }} expressions
+var matrixExpressionRegex = regexp.MustCompile(`\$\{\{\s*matrix\.[a-zA-Z0-9_-]+\s*\}\}`)
+
+// matrixArtifactSuffix is appended to artifact names uploaded and downloaded by matrix legs
+const matrixArtifactSuffix = "-${{ strategy.job-index }}"
+
+// sharedArtifactNames are artifacts uploaded by jobs outside the matrix, so matrix legs
+// download them without a leg suffix
+var sharedArtifactNames = map[string]bool{
+	"prompt": true,
+}
+
+// hasMatrixExpressions reports whether the content references matrix values
+func hasMatrixExpressions(content string) bool {
+	return matrixExpressionRegex.MatchString(content)
+}
+
+// stripMatrixExpressions removes matrix expressions from the content so the remaining
+// expressions can be validated against the allowlist
+func stripMatrixExpressions(content string) string {
+	return matrixExpressionRegex.ReplaceAllString(content, "")
+}
+
+// validateMatrixExpressions checks the prompt's matrix expressions against the workflow's strategy
+// and returns the markdown that remains to be validated by validateExpressionSafety
+func (c *Compiler) validateMatrixExpressions(workflowData *WorkflowData) (string, error) {
+	markdown := workflowData.MarkdownContent
+	if workflowData.Strategy == "" || !hasMatrixExpressions(markdown) {
+		return markdown, nil
+	}
+
+	if c.inlinePrompt || workflowData.InlinedImports {
+		return "", fmt.Errorf("matrix expressions (${{ matrix.* }}) cannot be used in the prompt when the prompt is inlined at compile time")
+	}
+
+	strategyLog.Print("Allowing matrix expressions in prompt for matrix strategy")
+	return stripMatrixExpressions(markdown), nil
+}
+
+// validateMatrixStrategy rejects a strategy on workflows with safe-outputs, since the
+// outputs of matrixed agent and detection jobs cannot be attributed to a single leg
+func validateMatrixStrategy(workflowData *WorkflowData) error {
+	if workflowData.Strategy == "" || workflowData.SafeOutputs == nil {
+		return nil
+	}
+
+	strategyLog.Print("Rejecting matrix strategy combined with safe-outputs")
+	return NewValidationError(
+		"strategy",
+		"",
+		"strategy cannot be combined with safe-outputs: matrix job outputs are collapsed to a single leg, so threat detection results could not be matched to each leg's safe outputs",
+		"Remove safe-outputs from the matrix workflow, or split each matrix combination into its own workflow",
+	)
+}
+
+// filterMatrixExpressions removes matrix.* expression mappings, since matrix values are not
+// available in the activation job and are substituted by each agent job leg instead
+func filterMatrixExpressions(mappings []*ExpressionMapping) []*ExpressionMapping {
+	filtered := make([]*ExpressionMapping, 0, len(mappings))
+	for _, m := range mappings {
+		if strings.HasPrefix(m.Content, "matrix.") {
+			strategyLog.Printf("Leaving matrix expression for agent job substitution: %s", m.Content)
+			continue
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered
+}
+
+// generateMatrixSubstitutionStep generates the step that substitutes the current leg's matrix
+// values into the prompt downloaded from the activation job
+func (c *Compiler) generateMatrixSubstitutionStep(yaml *strings.Builder, data *WorkflowData) {
+	if data.Strategy == "" {
+		return
+	}
+
+	strategyLog.Print("Adding matrix value substitution step")
+	yaml.WriteString("      - name: Substitute matrix values in prompt\n")
+	fmt.Fprintf(yaml, "        uses: %s\n", GetActionPin("actions/github-script"))
+	yaml.WriteString("        env:\n")
+	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	yaml.WriteString("          GH_AW_MATRIX: ${{ toJSON(matrix) }}\n")
+	yaml.WriteString("        with:\n")
+	yaml.WriteString("          script: |\n")
+	yaml.WriteString(generateGitHubScriptWithRequire("substitute_matrix_values.cjs"))
+}
+
+// applyMatrixStrategyToJobs runs every generated job that consumes artifacts of a matrix leg
+// with the agent job's strategy and suffixes the artifact names with the leg index.
+// Custom jobs from the frontmatter are left unchanged.
+func (c *Compiler) applyMatrixStrategyToJobs(data *WorkflowData) {
+	if data.Strategy == "" {
+		return
+	}
+
+	agentJob, exists := c.jobManager.GetJob(string(constants.AgentJobName))
+	if !exists {
+		return
+	}
+
+	// Collect the artifacts uploaded by matrix legs, starting with the agent job, and
+	// propagate the strategy until no further job downloads one of them
+	matrixJobs := map[string]bool{agentJob.Name: true}
+	matrixArtifacts := make(map[string]bool)
+	for name := range findJobArtifactNames(agentJob, "actions/upload-artifact") {
+		matrixArtifacts[name] = true
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, jobName := range c.jobManager.jobOrder {
+			job, _ := c.jobManager.GetJob(jobName)
+			if matrixJobs[jobName] || data.Jobs[jobName] != nil || job.Uses != "" {
+				continue
+			}
+			downloadsMatrixArtifact := false
+			for name := range findJobArtifactNames(job, "actions/download-artifact") {
+				if matrixArtifacts[name] {
+					downloadsMatrixArtifact = true
+					break
+				}
+			}
+			if !downloadsMatrixArtifact {
+				continue
+			}
+
+			strategyLog.Printf("Running job %s once per matrix leg", jobName)
+			matrixJobs[jobName] = true
+			job.Strategy = c.indentYAMLLines(data.Strategy, "    ")
+			for name := range findJobArtifactNames(job, "actions/upload-artifact") {
+				matrixArtifacts[name] = true
+			}
+			changed = true
+		}
+	}
+
+	for jobName := range matrixJobs {
+		job, _ := c.jobManager.GetJob(jobName)
+		suffixMatrixArtifactNames(job)
+	}
+}
+
+// findJobArtifactNames returns the artifact names used by the job's steps for the given action
+// (actions/upload-artifact or actions/download-artifact)
+func findJobArtifactNames(job *Job, action string) map[string]bool {
+	names := make(map[string]bool)
+	visitArtifactNameLines(job, action, func(line, name string) string {
+		names[name] = true
+		return line
+	})
+	return names
+}
+
+// suffixMatrixArtifactNames appends the matrix leg index to the names of artifacts uploaded
+// and downloaded by a matrix job, except for artifacts shared by all legs
+func suffixMatrixArtifactNames(job *Job) {
+	suffix := func(line, name string) string {
+		if sharedArtifactNames[name] {
+			return line
+		}
+		return strings.Replace(line, name, name+matrixArtifactSuffix, 1)
+	}
+	visitArtifactNameLines(job, "actions/upload-artifact", suffix)
+	visitArtifactNameLines(job, "actions/download-artifact", suffix)
+}
+
+// visitArtifactNameLines calls visit for each "name:" input of steps using the given action and
+// replaces the line with the returned value. Steps may be stored as a single string or split
+// across several entries, so the enclosing step is tracked across entries.
+func visitArtifactNameLines(job *Job, action string, visit func(line, name string) string) {
+	inArtifactStep := false
+	for i, entry := range job.Steps {
+		lines := strings.SplitAfter(entry, "\n")
+		for j, line := range lines {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "- ") {
+				inArtifactStep = false
+			}
+			if strings.Contains(trimmed, "uses: "+action+"@") {
+				inArtifactStep = true
+				continue
+			}
+			if !inArtifactStep || !strings.HasPrefix(trimmed, "name: ") {
+				continue
+			}
+			name := strings.TrimSpace(strings.TrimPrefix(trimmed, "name: "))
+			if name == "" || strings.HasSuffix(name, matrixArtifactSuffix) {
+				continue
+			}
+			lines[j] = visit(line, name)
+		}
+		job.Steps[i] = strings.Join(lines, "")
+	}
+}
@@ -0,0 +1,155 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMatrixExpressions(t *testing.T) {
+	mappings := []*ExpressionMapping{
+		{Content: "github.actor", EnvVar: "GH_AW_GITHUB_ACTOR"},
+		{Content: "matrix.language", EnvVar: "GH_AW_MATRIX_LANGUAGE"},
+	}
+
+	filtered := filterMatrixExpressions(mappings)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "github.actor", filtered[0].Content)
+}
+
+func TestSuffixMatrixArtifactNames(t *testing.T) {
+	job := &Job{
+		Name: "safe_outputs",
+		Steps: []string{
+			"      - name: Download prompt artifact\n        uses: actions/download-artifact@v6\n        with:\n          name: prompt\n",
+			"      - name: Download agent output artifact\n",
+			"        uses: actions/download-artifact@v6\n",
+			"        with:\n",
+			"          name: agent-output\n",
+			"      - name: Upload items\n        uses: actions/upload-artifact@v6\n        with:\n          name: safe-output-items\n",
+			"      - name: Unrelated\n        run: echo done\n",
+		},
+	}
+
+	assert.Equal(t, map[string]bool{"prompt": true, "agent-output": true}, findJobArtifactNames(job, "actions/download-artifact"))
+
+	suffixMatrixArtifactNames(job)
+	assert.Contains(t, job.Steps[0], "name: prompt\n", "artifacts uploaded outside the matrix should stay shared")
+	assert.Equal(t, "          name: agent-output-${{ strategy.job-index }}\n", job.Steps[4], "split steps should be tracked across entries")
+	assert.Contains(t, job.Steps[5], "name: safe-output-items-${{ strategy.job-index }}\n")
+	assert.Contains(t, job.Steps[6], "- name: Unrelated\n")
+
+	suffixMatrixArtifactNames(job)
+	assert.Equal(t, "          name: agent-output-${{ strategy.job-index }}\n", job.Steps[4], "suffixing should be idempotent")
+}
+
+func TestMatrixStrategyCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "matrix-strategy-test")
+
+	testContent := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+strategy:
+  matrix:
+    language: [go, python]
+  fail-fast: false
+tools:
+  repo-memory: true
+---
+
+# Language review
+
+Review the ${{ matrix.language }} code in ${{ github.repository }}.
+`
+	testFile := filepath.Join(tmpDir, "matrix-review.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile))
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "matrix-review.lock.yml"))
+	require.NoError(t, err)
+	lockStr := string(lockContent)
+
+	var workflow map[string]any
+	require.NoError(t, yaml.Unmarshal(lockContent, &workflow))
+	jobs, ok := workflow["jobs"].(map[string]any)
+	require.True(t, ok, "lock file should have jobs")
+
+	expectedStrategy := map[string]any{
+		"matrix":    map[string]any{"language": []any{"go", "python"}},
+		"fail-fast": false,
+	}
+	for _, jobName := range []string{"agent", "push_repo_memory"} {
+		job, ok := jobs[jobName].(map[string]any)
+		require.True(t, ok, "lock file should have %s job", jobName)
+		assert.Equal(t, expectedStrategy, job["strategy"], "%s job should run once per matrix leg", jobName)
+	}
+	activation, ok := jobs["activation"].(map[string]any)
+	require.True(t, ok)
+	assert.NotContains(t, activation, "strategy", "activation job builds the prompt once for all legs")
+
+	assert.Contains(t, lockStr, "      - name: Substitute matrix values in prompt\n")
+	assert.Contains(t, lockStr, "          GH_AW_MATRIX: ${{ toJSON(matrix) }}\n")
+	assert.Contains(t, lockStr, `group: "gh-aw-copilot-${{ github.workflow }}-${{ strategy.job-index }}"`)
+	assert.Contains(t, lockStr, "          name: prompt\n")
+	assert.Contains(t, lockStr, "          name: repo-memory-default-${{ strategy.job-index }}\n")
+	assert.NotContains(t, lockStr, "          name: repo-memory-default\n", "every repo-memory artifact should be per leg")
+	assert.NotContains(t, lockStr, "GH_AW_MATRIX_LANGUAGE", "matrix values are not available in the activation job")
+}
+
+func TestMatrixStrategyRejectsSafeOutputs(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "matrix-safe-outputs-test")
+
+	testContent := `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+strategy:
+  matrix:
+    language: [go, python]
+safe-outputs:
+  create-issue:
+---
+
+# Language review
+
+Review the ${{ matrix.language }} code.
+`
+	testFile := filepath.Join(tmpDir, "matrix-safe-outputs.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "strategy should be rejected with safe-outputs")
+	assert.Contains(t, err.Error(), "strategy cannot be combined with safe-outputs")
+}
+
+func TestMatrixExpressionsRequireStrategy(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "matrix-expression-test")
+
+	testContent := `---
+on: workflow_dispatch
+engine: copilot
+---
+
+# Language review
+
+Review the ${{ matrix.language }} code.
+`
+	testFile := filepath.Join(tmpDir, "no-matrix.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "matrix expressions should be rejected without a strategy")
+	assert.Contains(t, err.Error(), "matrix.language")
+}
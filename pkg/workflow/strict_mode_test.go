@@ -396,6 +396,50 @@ tools:
 # Test Workflow`,
 			expectError: false,
 		},
+		{
+			name: "HTTP MCP server with a host in network.allowed",
+			content: `---
+on: push
+permissions:
+  contents: read
+timeout-minutes: 10
+engine: copilot
+network:
+  allowed:
+    - defaults
+    - mcp.example.com
+mcp-servers:
+  remote:
+    type: http
+    url: "https://mcp.example.com/mcp"
+    allowed: ["search"]
+---
+
+# Test Workflow`,
+			expectError: false,
+		},
+		{
+			name: "HTTP MCP server with a host outside network.allowed",
+			content: `---
+on: push
+permissions:
+  contents: read
+timeout-minutes: 10
+engine: copilot
+network:
+  allowed:
+    - defaults
+mcp-servers:
+  remote:
+    type: http
+    url: "https://mcp.example.com/mcp"
+    allowed: ["search"]
+---
+
+# Test Workflow`,
+			expectError: true,
+			errorMsg:    "HTTP MCP server 'remote' uses host 'mcp.example.com', which is not covered by network.allowed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -10,7 +10,9 @@
 //   - Write permissions on sensitive scopes
 //   - Network access configuration
 //   - Top-level network configuration required for container-based MCP servers
+//   - HTTP MCP server hosts covered by the network allowlist
 //   - Bash wildcard tool usage
+//   - web-fetch without a domain allowlist
 //
 // # Validation Functions
 //
@@ -19,6 +21,7 @@
 //  2. validateStrictPermissions() - Refuses write permissions on sensitive scopes
 //  3. validateStrictNetwork() - Requires explicit network configuration
 //  4. validateStrictMCPNetwork() - Requires top-level network config for container-based MCP servers
+//  5. validateStrictHTTPMCPHosts() - Requires HTTP MCP server hosts to be in network.allowed
 //
 // # Integration with Security Scanners
 //
@@ -42,13 +45,13 @@ package workflow
 import (
 	"errors"
 	"fmt"
-	"os"
+	"maps"
 	"slices"
 	"strings"
 
-	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 var strictModeValidationLog = logger.New("workflow:strict_mode_validation")
@@ -91,6 +94,20 @@ func (c *Compiler) validateStrictNetwork(networkPermissions *NetworkPermissions)
 		return errors.New("internal error: network permissions not initialized (this should not happen in normal operation)")
 	}
 
+	// CIDR ranges covering every address are equivalent to the wildcard "*"
+	for _, entry := range networkPermissions.Allowed {
+		if isCIDREntry(entry) && isAllAddressesCIDR(entry) {
+			strictModeValidationLog.Printf("Network validation failed: all-addresses CIDR %s", entry)
+			return fmt.Errorf("strict mode: CIDR range '%s' is not allowed in network.allowed because it matches every address. Specify explicit ranges like '10.0.0.0/8'. See: https://github.github.com/gh-aw/reference/network/", entry)
+		}
+	}
+
+	// With explicit strict networking, the broad "defaults" profile must be replaced by an allowlist
+	if c.strictNetworkExplicit && slices.Contains(networkPermissions.Allowed, "defaults") {
+		strictModeValidationLog.Printf("Network validation failed: 'defaults' rejected by explicit strict network")
+		return errors.New("strict mode: the 'defaults' network profile is not allowed; list the required domains explicitly instead, e.g.\n\nnetwork:\n  allowed:\n    - api.github.com\n    - registry.npmjs.org\n\nEcosystem identifiers like 'python' or 'node' can also be used. See: https://github.github.com/gh-aw/reference/network/")
+	}
+
 	// If allowed list contains "defaults", that's acceptable (this is the automatic default)
 	if slices.Contains(networkPermissions.Allowed, "defaults") {
 		strictModeValidationLog.Printf("Network validation passed: allowed list contains 'defaults'")
@@ -107,7 +124,8 @@ func (c *Compiler) validateStrictNetwork(networkPermissions *NetworkPermissions)
 	return nil
 }
 
-// validateStrictMCPNetwork requires top-level network configuration when custom MCP servers use containers
+// validateStrictMCPNetwork requires network configuration when custom MCP servers use containers.
+// A server's own network.allowed block is sufficient; its domains are merged into the firewall allowlist.
 func (c *Compiler) validateStrictMCPNetwork(frontmatter map[string]any, networkPermissions *NetworkPermissions) error {
 	// Check mcp-servers section (new format)
 	mcpServersValue, exists := frontmatter["mcp-servers"]
@@ -139,9 +157,10 @@ func (c *Compiler) validateStrictMCPNetwork(frontmatter map[string]any, networkP
 		// Only stdio servers with containers need network configuration
 		if mcpType == "stdio" {
 			if _, hasContainer := serverConfig["container"]; hasContainer {
-				// Require top-level network configuration
-				if !hasTopLevelNetwork {
-					return fmt.Errorf("strict mode: custom MCP server '%s' with container must have top-level network configuration for security. Add 'network: { allowed: [...] }' to the workflow to restrict network access. See: https://github.github.com/gh-aw/reference/network/", serverName)
+				// Require per-server or top-level network configuration
+				serverAllowed, _ := getMCPServerNetwork(serverConfig)
+				if !hasTopLevelNetwork && len(serverAllowed) == 0 {
+					return fmt.Errorf("strict mode: custom MCP server '%s' with container must have network configuration for security. Add 'network: { allowed: [...] }' to the MCP server or to the workflow to restrict network access. See: https://github.github.com/gh-aw/reference/network/", serverName)
 				}
 			}
 		}
@@ -150,6 +169,57 @@ func (c *Compiler) validateStrictMCPNetwork(frontmatter map[string]any, networkP
 	return nil
 }
 
+// validateStrictHTTPMCPHosts requires the host of every HTTP MCP server URL to be covered by
+// network.allowed, either at the top level or in the server's own network block. HTTP servers are
+// called by the MCP gateway, so listing the host keeps the allowlist a complete record of the
+// endpoints the workflow talks to.
+func (c *Compiler) validateStrictHTTPMCPHosts(frontmatter map[string]any, networkPermissions *NetworkPermissions) error {
+	mcpServersMap, ok := frontmatter["mcp-servers"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var topLevelAllowed []string
+	if networkPermissions != nil {
+		topLevelAllowed = networkPermissions.Allowed
+	}
+
+	// Sort server names so the reported server is deterministic
+	serverNames := slices.Sorted(maps.Keys(mcpServersMap))
+	for _, serverName := range serverNames {
+		serverConfig, ok := mcpServersMap[serverName].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		hasMCP, mcpType := hasMCPConfig(serverConfig)
+		if !hasMCP || mcpType != "http" {
+			continue
+		}
+
+		// URLs built from expressions are only known at runtime
+		serverURL, _ := serverConfig["url"].(string)
+		if serverURL == "" || strings.Contains(serverURL, "${{") {
+			continue
+		}
+		host := stringutil.ExtractDomainFromURL(serverURL)
+		if host == "" {
+			continue
+		}
+
+		serverAllowed, _ := getMCPServerNetwork(serverConfig)
+		covered := slices.ContainsFunc(append(slices.Clone(topLevelAllowed), serverAllowed...), func(entry string) bool {
+			return IsDomainCoveredByAllowedEntry(host, entry)
+		})
+		if !covered {
+			strictModeValidationLog.Printf("HTTP MCP server '%s' host %s is not covered by network.allowed", serverName, host)
+			return fmt.Errorf("strict mode: HTTP MCP server '%s' uses host '%s', which is not covered by network.allowed. Add '%s' to network.allowed or to the server's own 'network: { allowed: [...] }'. See: https://github.github.com/gh-aw/reference/network/", serverName, host, host)
+		}
+	}
+
+	return nil
+}
+
 // validateStrictTools validates tools configuration in strict mode
 func (c *Compiler) validateStrictTools(frontmatter map[string]any) error {
 	// Check tools section
@@ -178,6 +248,15 @@ func (c *Compiler) validateStrictTools(frontmatter map[string]any) error {
 		}
 	}
 
+	// Check that web-fetch is restricted to a domain allowlist
+	if webFetchValue, hasWebFetch := toolsMap["web-fetch"]; hasWebFetch {
+		webFetchConfig, _ := webFetchValue.(map[string]any)
+		if len(getWebFetchAllowed(webFetchConfig)) == 0 {
+			strictModeValidationLog.Printf("web-fetch allowlist validation failed")
+			return errors.New("strict mode: web-fetch tool requires a domain allowlist. Without one, the agent can fetch content from any domain the firewall allows. Use 'web-fetch: { allowed: [docs.example.com] }' to list the domains the agent may fetch from; they are added to the network firewall automatically. See: https://github.github.com/gh-aw/reference/tools/#web-fetch")
+		}
+	}
+
 	// Check if cache-memory is configured with scope: repo
 	cacheMemoryValue, hasCacheMemory := toolsMap["cache-memory"]
 	if hasCacheMemory {
@@ -362,8 +441,7 @@ func (c *Compiler) validateEnvSecretsSection(config map[string]any, sectionName
 
 	// In non-strict mode, emit a warning
 	warningMsg := fmt.Sprintf("Warning: secrets detected in '%s' section will be leaked to the agent container. Found: %s. Consider using engine-specific secret configuration instead.", sectionName, strings.Join(secretRefs, ", "))
-	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
-	c.IncrementWarningCount()
+	c.addWarning(warningMsg)
 
 	return nil
 }
@@ -416,14 +494,21 @@ func (c *Compiler) validateStrictMode(frontmatter map[string]any, networkPermiss
 		}
 	}
 
-	// 4. Validate tools configuration
+	// 4. Require HTTP MCP server hosts to be in the network allowlist
+	if err := c.validateStrictHTTPMCPHosts(frontmatter, networkPermissions); err != nil {
+		if returnErr := collector.Add(err); returnErr != nil {
+			return returnErr // Fail-fast mode
+		}
+	}
+
+	// 5. Validate tools configuration
 	if err := c.validateStrictTools(frontmatter); err != nil {
 		if returnErr := collector.Add(err); returnErr != nil {
 			return returnErr // Fail-fast mode
 		}
 	}
 
-	// 5. Refuse deprecated fields
+	// 6. Refuse deprecated fields
 	if err := c.validateStrictDeprecatedFields(frontmatter); err != nil {
 		if returnErr := collector.Add(err); returnErr != nil {
 			return returnErr // Fail-fast mode
@@ -523,8 +608,7 @@ func (c *Compiler) validateStrictFirewall(engineID string, networkPermissions *N
 			warningMsg := "strict mode: recommend using ecosystem identifiers instead of individual domain names for better maintainability: " + strings.Join(suggestions, ", ")
 
 			// Print warning message and increment warning count
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
-			c.IncrementWarningCount()
+			c.addWarning(warningMsg)
 		}
 	}
 
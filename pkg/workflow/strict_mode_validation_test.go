@@ -208,6 +208,7 @@ func TestValidateStrictNetwork(t *testing.T) {
 	tests := []struct {
 		name               string
 		networkPermissions *NetworkPermissions
+		explicitNetwork    bool
 		expectError        bool
 		errorMsg           string
 	}{
@@ -268,11 +269,46 @@ func TestValidateStrictNetwork(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "explicit network refuses defaults",
+			networkPermissions: &NetworkPermissions{
+				Allowed: []string{"defaults"},
+			},
+			explicitNetwork: true,
+			expectError:     true,
+			errorMsg:        "strict mode: the 'defaults' network profile is not allowed; list the required domains explicitly",
+		},
+		{
+			name: "explicit network refuses defaults among other domains",
+			networkPermissions: &NetworkPermissions{
+				Allowed: []string{"github.com", "defaults"},
+			},
+			explicitNetwork: true,
+			expectError:     true,
+			errorMsg:        "the 'defaults' network profile is not allowed",
+		},
+		{
+			name: "explicit network allows specific domains and ecosystems",
+			networkPermissions: &NetworkPermissions{
+				Allowed: []string{"api.github.com", "python"},
+			},
+			explicitNetwork: true,
+			expectError:     false,
+		},
+		{
+			name: "explicit network still refuses wildcard",
+			networkPermissions: &NetworkPermissions{
+				Allowed: []string{"*"},
+			},
+			explicitNetwork: true,
+			expectError:     true,
+			errorMsg:        "strict mode: wildcard '*' is not allowed",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			compiler := NewCompiler()
+			compiler := NewCompiler(WithStrictNetworkExplicit(tt.explicitNetwork))
 			err := compiler.validateStrictNetwork(tt.networkPermissions)
 
 			if tt.expectError && err == nil {
@@ -385,7 +421,7 @@ func TestValidateStrictMode(t *testing.T) {
 				Allowed: []string{}, // Empty allowed list - no top-level network config
 			},
 			expectError: true,
-			errorMsg:    "strict mode: custom MCP server 'my-server' with container must have top-level network configuration for security",
+			errorMsg:    "strict mode: custom MCP server 'my-server' with container must have network configuration for security",
 		},
 		{
 			name:       "strict mode with container MCP and network config",
@@ -156,6 +156,7 @@ permissions:
 tools:
   edit:
   web-fetch:
+    allowed: [docs.example.com]
 engine: claude
 ---
 
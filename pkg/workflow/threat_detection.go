@@ -141,7 +141,7 @@ func (c *Compiler) buildThreatDetectionJob(data *WorkflowData, mainJobName strin
 	job := &Job{
 		Name:           string(constants.DetectionJobName),
 		If:             condition.Render(),
-		RunsOn:         "runs-on: ubuntu-latest",
+		RunsOn:         c.formatWorkflowRunsOn(data, "runs-on: ubuntu-latest"),
 		Permissions:    permissions,
 		Concurrency:    c.indentYAMLLines(agentConcurrency, "    "),
 		TimeoutMinutes: 10,
@@ -150,6 +150,7 @@ func (c *Compiler) buildThreatDetectionJob(data *WorkflowData, mainJobName strin
 		Outputs: map[string]string{
 			"success": "${{ steps.parse_results.outputs.success }}",
 		},
+		Provenance: "safe-outputs.threat-detection",
 	}
 
 	return job, nil
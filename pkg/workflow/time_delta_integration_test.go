@@ -67,11 +67,11 @@ engine: claude
 on:
   schedule:
     - cron: "0 9 * * 1"
-  stop-after: "06/01/2025 15:30"
+  stop-after: "06/15/2025 15:30"
 ---`,
 			markdown:       "# Test Workflow\n\nThis is a test workflow.",
 			expectStopTime: true,
-			shouldContain:  "2025-06-01 15:30:00",
+			shouldContain:  "2025-06-15 15:30:00",
 		},
 		{
 			name: "relative stop-after gets resolved",
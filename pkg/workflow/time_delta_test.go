@@ -620,9 +620,9 @@ func TestResolveStopTime(t *testing.T) {
 		},
 		{
 			name:        "absolute time US format",
-			stopTime:    "06/01/2025 15:30",
+			stopTime:    "06/15/2025 15:30",
 			compileTime: baseTime,
-			expected:    "2025-06-01 15:30:00",
+			expected:    "2025-06-15 15:30:00",
 		},
 		{
 			name:        "absolute time European style",
@@ -44,6 +44,37 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			}
 		}
 
+	case "create_gist":
+		if config := safeOutputs.CreateGists; config != nil {
+			if templatableIntValue(config.Max) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d gist(s) can be created.", templatableIntValue(config.Max)))
+			}
+			if config.Public {
+				constraints = append(constraints, "Gists will be public.")
+			} else {
+				constraints = append(constraints, "Gists will be secret (unlisted).")
+			}
+			if config.FilenamePrefix != "" {
+				constraints = append(constraints, fmt.Sprintf("Filenames will be prefixed with %q.", config.FilenamePrefix))
+			}
+		}
+
+	case "create_release":
+		if config := safeOutputs.CreateReleases; config != nil {
+			if templatableIntValue(config.Max) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d release(s) can be created.", templatableIntValue(config.Max)))
+			}
+			if config.Draft {
+				constraints = append(constraints, "Releases will be created as drafts.")
+			}
+			if config.Prerelease {
+				constraints = append(constraints, "Releases will be marked as prereleases.")
+			}
+			if config.TagPrefix != "" {
+				constraints = append(constraints, fmt.Sprintf("Tag names will be prefixed with %q.", config.TagPrefix))
+			}
+		}
+
 	case "create_agent_session":
 		if config := safeOutputs.CreateAgentSessions; config != nil {
 			if templatableIntValue(config.Max) > 0 {
@@ -97,6 +128,9 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			if config.Target != "" {
 				constraints = append(constraints, fmt.Sprintf("Target: %s.", config.Target))
 			}
+			if !closeRequiresComment(config) {
+				constraints = append(constraints, "The closing comment (body) is optional.")
+			}
 		}
 
 	case "close_pull_request":
@@ -113,6 +147,9 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			if config.RequiredTitlePrefix != "" {
 				constraints = append(constraints, fmt.Sprintf("Only PRs with title prefix %q can be closed.", config.RequiredTitlePrefix))
 			}
+			if !closeRequiresComment(config) {
+				constraints = append(constraints, "The closing comment (body) is optional.")
+			}
 		}
 
 	case "add_comment":
@@ -168,6 +205,17 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			}
 		}
 
+	case "create_pull_request_review":
+		if config := safeOutputs.CreatePullRequestReviews; config != nil {
+			if templatableIntValue(config.Max) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d review(s) can be submitted.", templatableIntValue(config.Max)))
+			}
+			constraints = append(constraints, fmt.Sprintf("Default event: %s.", config.Event))
+			if !config.AllowApprove {
+				constraints = append(constraints, "The APPROVE event is not allowed.")
+			}
+		}
+
 	case "reply_to_pull_request_review_comment":
 		if config := safeOutputs.ReplyToPullRequestReviewComment; config != nil {
 			if templatableIntValue(config.Max) > 0 {
@@ -239,6 +287,9 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			if config.Status != nil && *config.Status {
 				constraints = append(constraints, "Status updates (open/closed) are allowed.")
 			}
+			if len(config.AllowedFields) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Only these fields can be changed: %s. Updates to other fields are rejected.", strings.Join(config.AllowedFields, ", ")))
+			}
 		}
 
 	case "update_pull_request":
@@ -168,8 +168,28 @@ func (c *Compiler) applyDefaults(data *WorkflowData, markdownPath string) error
 		// When no permissions are specified, set default to contents: read.
 		// This provides minimal access needed for most workflows while following
 		// the principle of least privilege.
+		//
+		// With the derive-permissions feature enabled, read access to the scopes used
+		// by the configured safe-outputs is derived instead. The agent job stays
+		// read-only: the safe output jobs receive the write scopes they need from
+		// ComputePermissionsForSafeOutputs.
 		// ============================================================================
 		perms := NewPermissionsContentsRead()
+		if isFeatureEnabled(constants.DerivePermissionsFeatureFlag, data) && data.SafeOutputs != nil {
+			builder := DerivePermissions(data.SafeOutputs)
+			for scope := range builder.scopes {
+				if scope == PermissionIdToken {
+					// id-token does not support read access
+					delete(builder.scopes, scope)
+					continue
+				}
+				builder.With(scope, PermissionRead)
+			}
+			// The agent job always needs to read the repository
+			builder.WithContents(PermissionRead)
+			perms = builder.Permissions()
+			toolsLog.Print("Derived read-only default permissions from safe-outputs configuration")
+		}
 		yaml := perms.RenderToYAML()
 		// RenderToYAML uses job-friendly indentation (6 spaces). WorkflowData.Permissions
 		// is stored in workflow-level indentation (2 spaces) and later re-indented for jobs.
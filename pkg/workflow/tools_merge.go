@@ -0,0 +1,126 @@
+// This file provides merging of tool configurations.
+//
+// # Tools Merge
+//
+// A main workflow and each of its imports may declare a tools section. Merge combines two
+// configurations with the receiver treated as the main workflow:
+//
+//   - Tools present in only one configuration are kept as-is
+//   - Custom MCP servers are unioned; a server declared in both keeps the main configuration
+//   - github allowed lists and toolsets are unioned and deduplicated, main entries first
+//   - bash command lists are unioned and deduplicated
+//   - Any other conflicting value (scalars, objects, bash: true vs a command list) keeps the
+//     main configuration
+//
+// The merge operates on the raw tool maps and re-parses the result with NewTools, so the
+// strongly-typed fields and ToMap always agree.
+
+package workflow
+
+import (
+	"maps"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var toolsMergeLog = logger.New("workflow:tools_merge")
+
+// Merge returns a new Tools combining t (the main workflow) with other (an import).
+// Neither input is modified.
+func (t *Tools) Merge(other *Tools) *Tools {
+	mainTools := t.ToMap()
+	otherTools := other.ToMap()
+	toolsMergeLog.Printf("Merging tools: main_count=%d, other_count=%d", len(mainTools), len(otherTools))
+
+	merged := make(map[string]any, len(mainTools)+len(otherTools))
+	maps.Copy(merged, otherTools)
+	for name, mainValue := range mainTools {
+		otherValue, exists := otherTools[name]
+		if !exists {
+			merged[name] = mainValue
+			continue
+		}
+
+		switch name {
+		case "github":
+			merged[name] = mergeGitHubToolValues(mainValue, otherValue)
+		case "bash":
+			if commands, ok := mergeToolLists(mainValue, otherValue); ok {
+				merged[name] = commands
+			} else {
+				merged[name] = mainValue
+			}
+		default:
+			toolsMergeLog.Printf("Tool %s declared in both configurations, keeping main", name)
+			merged[name] = mainValue
+		}
+	}
+
+	return NewTools(merged)
+}
+
+// mergeGitHubToolValues merges two raw github tool configurations. Object configurations are
+// combined key by key with main values winning, except allowed and toolsets which are unioned.
+func mergeGitHubToolValues(mainValue, otherValue any) any {
+	mainMap, mainIsMap := mainValue.(map[string]any)
+	otherMap, otherIsMap := otherValue.(map[string]any)
+	if !mainIsMap {
+		if otherIsMap && mainValue == nil {
+			// github: (enabled with defaults) adds nothing to an import's configuration
+			return otherMap
+		}
+		return mainValue
+	}
+	if !otherIsMap {
+		return mainMap
+	}
+
+	result := make(map[string]any, len(mainMap)+len(otherMap))
+	maps.Copy(result, otherMap)
+	maps.Copy(result, mainMap)
+	for _, key := range []string{"allowed", "toolsets"} {
+		if list, ok := mergeToolLists(mainMap[key], otherMap[key]); ok {
+			result[key] = list
+		}
+	}
+	toolsMergeLog.Printf("Merged github tool configuration: allowed=%v", result["allowed"])
+	return result
+}
+
+// mergeToolLists unions two string lists, preserving order and removing duplicates.
+// It reports false when either value is not a list.
+func mergeToolLists(mainValue, otherValue any) ([]any, bool) {
+	mainList, mainOK := toolListStrings(mainValue)
+	otherList, otherOK := toolListStrings(otherValue)
+	if !mainOK || !otherOK {
+		return nil, false
+	}
+
+	seen := make(map[string]bool, len(mainList)+len(otherList))
+	result := make([]any, 0, len(mainList)+len(otherList))
+	for _, item := range append(mainList, otherList...) {
+		if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result, true
+}
+
+// toolListStrings returns the string entries of a []any or []string value
+func toolListStrings(value any) ([]string, bool) {
+	switch list := value.(type) {
+	case []any:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result, true
+	case []string:
+		return append([]string(nil), list...), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,116 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolsMerge(t *testing.T) {
+	mainTools := NewTools(map[string]any{
+		"github": map[string]any{
+			"allowed": []any{"issue_read", "list_issues"},
+			"mode":    "remote",
+		},
+		"bash": []any{"echo", "ls"},
+		"main-server": map[string]any{
+			"command": "node",
+			"args":    []any{"server.js"},
+		},
+		"shared-server": map[string]any{
+			"url": "https://main.example.com/mcp",
+		},
+		"timeout": 300,
+	})
+	imported := NewTools(map[string]any{
+		"github": map[string]any{
+			"allowed": []any{"list_issues", "get_file_contents"},
+			"mode":    "local",
+			"version": "v1.2.3",
+		},
+		"bash": []any{"ls", "cat"},
+		"import-server": map[string]any{
+			"container": "example/mcp",
+		},
+		"shared-server": map[string]any{
+			"url": "https://import.example.com/mcp",
+		},
+		"timeout": 120,
+		"edit":    nil,
+	})
+
+	merged := mainTools.Merge(imported)
+	require.NotNil(t, merged)
+
+	require.NotNil(t, merged.GitHub, "github should be configured")
+	assert.Equal(t, GitHubAllowedTools{"issue_read", "list_issues", "get_file_contents"}, merged.GitHub.Allowed,
+		"github allowed lists should be unioned and deduplicated")
+	assert.Equal(t, "remote", merged.GitHub.Mode, "main github mode should win")
+	assert.Equal(t, "v1.2.3", merged.GitHub.Version, "import github fields should be kept when main does not set them")
+
+	require.NotNil(t, merged.Bash)
+	assert.Equal(t, []string{"echo", "ls", "cat"}, merged.Bash.AllowedCommands, "bash commands should be unioned")
+
+	assert.True(t, merged.HasTool("edit"), "tools only in the import should be kept")
+	require.NotNil(t, merged.Timeout)
+	assert.Equal(t, 300, *merged.Timeout, "main timeout should win")
+
+	assert.Contains(t, merged.Custom, "main-server")
+	assert.Contains(t, merged.Custom, "import-server")
+	require.Contains(t, merged.Custom, "shared-server")
+	assert.Equal(t, "https://main.example.com/mcp", merged.Custom["shared-server"].URL, "main server configuration should win")
+
+	assert.Equal(t, merged.ToMap()["github"].(map[string]any)["allowed"], []any{"issue_read", "list_issues", "get_file_contents"},
+		"raw map should match the typed configuration")
+
+	assert.Equal(t, []any{"issue_read", "list_issues"}, mainTools.ToMap()["github"].(map[string]any)["allowed"], "main tools should not be modified")
+	assert.Equal(t, []any{"list_issues", "get_file_contents"}, imported.ToMap()["github"].(map[string]any)["allowed"], "imported tools should not be modified")
+}
+
+func TestToolsMergeGitHubShapes(t *testing.T) {
+	tests := []struct {
+		name            string
+		main            any
+		other           any
+		expectedAllowed GitHubAllowedTools
+	}{
+		{
+			name:            "main enabled with defaults keeps import allowed list",
+			main:            nil,
+			other:           map[string]any{"allowed": []any{"issue_read"}},
+			expectedAllowed: GitHubAllowedTools{"issue_read"},
+		},
+		{
+			name:            "main allowed list with import enabled with defaults",
+			main:            map[string]any{"allowed": []any{"issue_read"}},
+			other:           nil,
+			expectedAllowed: GitHubAllowedTools{"issue_read"},
+		},
+		{
+			name:            "only import allowed list",
+			main:            map[string]any{"mode": "remote"},
+			other:           map[string]any{"allowed": []any{"list_issues"}},
+			expectedAllowed: GitHubAllowedTools{"list_issues"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := NewTools(map[string]any{"github": tt.main}).Merge(NewTools(map[string]any{"github": tt.other}))
+			require.NotNil(t, merged.GitHub)
+			assert.Equal(t, tt.expectedAllowed, merged.GitHub.Allowed)
+		})
+	}
+}
+
+func TestToolsMergeNil(t *testing.T) {
+	tools := NewTools(map[string]any{"edit": nil})
+
+	assert.True(t, tools.Merge(nil).HasTool("edit"), "merging nil should keep main tools")
+
+	var empty *Tools
+	assert.True(t, empty.Merge(tools).HasTool("edit"), "merging into nil should keep import tools")
+}
@@ -225,6 +225,15 @@ func parseGitHubTool(val any) *GitHubToolConfig {
 			}
 		}
 
+		if readOnlyToolsets, ok := configMap["read-only-toolsets"].([]any); ok {
+			config.ReadOnlyToolsets = make(GitHubToolsets, 0, len(readOnlyToolsets))
+			for _, item := range readOnlyToolsets {
+				if str, ok := item.(string); ok {
+					config.ReadOnlyToolsets = append(config.ReadOnlyToolsets, GitHubToolset(str))
+				}
+			}
+		}
+
 		if lockdown, ok := configMap["lockdown"].(bool); ok {
 			config.Lockdown = lockdown
 		}
@@ -313,6 +322,11 @@ func parsePlaywrightTool(val any) *PlaywrightToolConfig {
 			}
 		}
 
+		// Handle log-dir field
+		if logDir, ok := configMap["log-dir"].(string); ok {
+			config.LogDir = logDir
+		}
+
 		return config
 	}
 
@@ -399,8 +413,12 @@ func parseSerenaTool(val any) *SerenaToolConfig {
 
 // parseWebFetchTool converts raw web-fetch tool configuration
 func parseWebFetchTool(val any) *WebFetchToolConfig {
-	// web-fetch is either nil or an empty object
-	return &WebFetchToolConfig{}
+	// web-fetch is either nil or an object with an optional domain allowlist
+	config := &WebFetchToolConfig{}
+	if configMap, ok := val.(map[string]any); ok {
+		config.Allowed = getWebFetchAllowed(configMap)
+	}
+	return config
 }
 
 // parseWebSearchTool converts raw web-search tool configuration
@@ -569,21 +587,41 @@ func parseMCPServerConfig(val any) MCPServerConfig {
 		}
 	}
 
+	if volumes, ok := configMap["volumes"].([]any); ok {
+		config.Volumes = make([]string, 0, len(volumes))
+		for _, volume := range volumes {
+			if str, ok := volume.(string); ok {
+				config.Volumes = append(config.Volumes, str)
+			}
+		}
+	}
+
+	if startupTimeout, ok := parseIntValue(configMap["startup-timeout"]); ok {
+		config.StartupTimeout = startupTimeout
+	}
+
+	if healthCheck, ok := configMap["health-check"].(bool); ok {
+		config.HealthCheck = healthCheck
+	}
+
 	// Store any unknown fields in CustomFields
 	knownFields := map[string]bool{
-		"command":        true,
-		"args":           true,
-		"env":            true,
-		"mode":           true,
-		"type":           true,
-		"version":        true,
-		"toolsets":       true,
-		"url":            true,
-		"headers":        true,
-		"container":      true,
-		"entrypoint":     true,
-		"entrypointArgs": true,
-		"mounts":         true,
+		"command":         true,
+		"args":            true,
+		"env":             true,
+		"mode":            true,
+		"type":            true,
+		"version":         true,
+		"toolsets":        true,
+		"url":             true,
+		"headers":         true,
+		"container":       true,
+		"entrypoint":      true,
+		"entrypointArgs":  true,
+		"mounts":          true,
+		"volumes":         true,
+		"startup-timeout": true,
+		"health-check":    true,
 	}
 
 	for key, value := range configMap {
@@ -151,6 +151,15 @@ func mcpServerConfigToMap(config MCPServerConfig) map[string]any {
 	if len(config.Mounts) > 0 {
 		result["mounts"] = config.Mounts
 	}
+	if len(config.Volumes) > 0 {
+		result["volumes"] = config.Volumes
+	}
+	if config.StartupTimeout > 0 {
+		result["startup-timeout"] = config.StartupTimeout
+	}
+	if config.HealthCheck {
+		result["health-check"] = config.HealthCheck
+	}
 
 	// Add custom fields (these override standard fields if there are conflicts)
 	maps.Copy(result, config.CustomFields)
@@ -269,12 +278,17 @@ type GitHubToolConfig struct {
 	Toolset     GitHubToolsets     `yaml:"toolsets,omitempty"`
 	Lockdown    bool               `yaml:"lockdown,omitempty"`
 	App         *GitHubAppConfig   `yaml:"app,omitempty"` // GitHub App configuration for token minting
+
+	// ReadOnlyToolsets lists enabled toolsets that only expose their read-only tools while the
+	// other toolsets stay writable. Requires read-only: false.
+	ReadOnlyToolsets GitHubToolsets `yaml:"read-only-toolsets,omitempty"`
 }
 
 // PlaywrightToolConfig represents the configuration for the Playwright tool
 type PlaywrightToolConfig struct {
 	Version string   `yaml:"version,omitempty"`
 	Args    []string `yaml:"args,omitempty"`
+	LogDir  string   `yaml:"log-dir,omitempty"` // Subdirectory of the MCP logs directory for Playwright output (default: "playwright")
 }
 
 // SerenaToolConfig represents the configuration for the Serena MCP tool
@@ -301,8 +315,11 @@ type BashToolConfig struct {
 }
 
 // WebFetchToolConfig represents the configuration for the web-fetch tool
+// Can be nil (fetch any domain the firewall allows) or an object with a domain allowlist
 type WebFetchToolConfig struct {
-	// Currently an empty object or nil
+	// Allowed lists the domains (or ecosystem identifiers) the agent may fetch from.
+	// The domains are also added to the network firewall allowlist.
+	Allowed []string `yaml:"allowed,omitempty"`
 }
 
 // WebSearchToolConfig represents the configuration for the web-search tool
@@ -348,17 +365,25 @@ type MCPServerConfig struct {
 // Per MCP Gateway Specification v1.0.0: All stdio-based MCP servers MUST be containerized.
 // Direct command execution is not supported.
 type MCPGatewayRuntimeConfig struct {
-	Container      string            `yaml:"container,omitempty"`      // Container image for the gateway (required)
-	Version        string            `yaml:"version,omitempty"`        // Optional version/tag for the container
-	Entrypoint     string            `yaml:"entrypoint,omitempty"`     // Optional entrypoint override for the container
-	Args           []string          `yaml:"args,omitempty"`           // Arguments for docker run
-	EntrypointArgs []string          `yaml:"entrypointArgs,omitempty"` // Arguments passed to container entrypoint
-	Env            map[string]string `yaml:"env,omitempty"`            // Environment variables for the gateway
-	Port           int               `yaml:"port,omitempty"`           // Port for the gateway HTTP server (default: 8080)
-	APIKey         string            `yaml:"api-key,omitempty"`        // API key for gateway authentication
-	Domain         string            `yaml:"domain,omitempty"`         // Domain for gateway URL (localhost or host.docker.internal)
-	Mounts         []string          `yaml:"mounts,omitempty"`         // Volume mounts for the gateway container (format: "source:dest:mode")
-	PayloadDir     string            `yaml:"payload-dir,omitempty"`    // Directory path for storing large payload JSON files (must be absolute path)
+	Container      string                `yaml:"container,omitempty"`      // Container image for the gateway (required)
+	Version        string                `yaml:"version,omitempty"`        // Optional version/tag for the container
+	Entrypoint     string                `yaml:"entrypoint,omitempty"`     // Optional entrypoint override for the container
+	Args           []string              `yaml:"args,omitempty"`           // Arguments for docker run
+	EntrypointArgs []string              `yaml:"entrypointArgs,omitempty"` // Arguments passed to container entrypoint
+	Env            map[string]string     `yaml:"env,omitempty"`            // Environment variables for the gateway
+	Port           int                   `yaml:"port,omitempty"`           // Port for the gateway HTTP server (default: 8080)
+	APIKey         string                `yaml:"api-key,omitempty"`        // API key for gateway authentication
+	Domain         string                `yaml:"domain,omitempty"`         // Domain for gateway URL (localhost or host.docker.internal)
+	Mounts         []string              `yaml:"mounts,omitempty"`         // Volume mounts for the gateway container (format: "source:dest:mode")
+	PayloadDir     string                `yaml:"payload-dir,omitempty"`    // Directory path for storing large payload JSON files (must be absolute path)
+	LogRotation    *MCPLogRotationConfig `yaml:"log-rotation,omitempty"`   // Size/age-based rotation policy for MCP server logs
+}
+
+// MCPLogRotationConfig represents the rotation policy applied to files in the MCP logs directory
+type MCPLogRotationConfig struct {
+	MaxSize  string `yaml:"max-size,omitempty"`  // Size above which a log file is rotated (e.g., "10m", "512k")
+	MaxAge   string `yaml:"max-age,omitempty"`   // Age after which rotated copies are removed (e.g., "30m", "2h")
+	MaxFiles int    `yaml:"max-files,omitempty"` // Number of rotated copies kept per log file (default: 3)
 }
 
 // HasTool checks if a tool is present in the configuration
@@ -2,9 +2,12 @@ package workflow
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 )
 
 var toolsValidationLog = logger.New("workflow:tools_validation")
@@ -24,6 +27,13 @@ func validateBashToolConfig(tools *Tools, workflowName string) error {
 		}
 	}
 
+	if tools.Bash != nil {
+		if err := validateBashCommandPatterns(tools.Bash.AllowedCommands); err != nil {
+			toolsValidationLog.Printf("Invalid bash command pattern in workflow: %s", workflowName)
+			return fmt.Errorf("invalid bash tool configuration: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -84,6 +94,49 @@ func validateGitHubToolConfig(tools *Tools, workflowName string) error {
 		return errors.New("invalid GitHub tool configuration: 'tools.github.app' and 'tools.github.github-token' cannot both be set. Use one authentication method: either 'app' (GitHub App) or 'github-token' (personal access token)")
 	}
 
+	if err := parser.ValidateImageDigest(tools.GitHub.Version); err != nil {
+		toolsValidationLog.Printf("Invalid GitHub MCP server digest in workflow: %s", workflowName)
+		return fmt.Errorf("invalid GitHub tool configuration: 'tools.github.version' %w. Use a tag such as 'v0.30.0' or a full digest such as 'sha256:<64 hex characters>'", err)
+	}
+
+	if err := validateGitHubReadOnlyToolsets(tools.GitHub); err != nil {
+		toolsValidationLog.Printf("Invalid GitHub read-only toolsets in workflow: %s", workflowName)
+		return err
+	}
+
+	return nil
+}
+
+// validateGitHubReadOnlyToolsets validates that read-only-toolsets only names known toolsets that
+// are enabled, and that it is combined with read-only: false
+func validateGitHubReadOnlyToolsets(github *GitHubToolConfig) error {
+	if len(github.ReadOnlyToolsets) == 0 {
+		return nil
+	}
+
+	if github.ReadOnly {
+		return errors.New("invalid GitHub tool configuration: 'tools.github.read-only-toolsets' requires 'tools.github.read-only: false'. With read-only enabled, all toolsets are already read-only")
+	}
+
+	enabled := make(map[string]bool)
+	for _, toolset := range ParseGitHubToolsets(github.GetToolsets()) {
+		enabled[toolset] = true
+	}
+
+	for _, toolset := range github.ReadOnlyToolsets.ToStringSlice() {
+		if _, exists := toolsetPermissionsMap[toolset]; !exists {
+			validToolsets := make([]string, 0, len(toolsetPermissionsMap))
+			for name := range toolsetPermissionsMap {
+				validToolsets = append(validToolsets, name)
+			}
+			sort.Strings(validToolsets)
+			return fmt.Errorf("invalid GitHub tool configuration: unknown toolset %q in 'tools.github.read-only-toolsets'. Valid toolsets: %s", toolset, strings.Join(validToolsets, ", "))
+		}
+		if !enabled[toolset] {
+			return fmt.Errorf("invalid GitHub tool configuration: toolset %q in 'tools.github.read-only-toolsets' is not enabled. Add it to 'tools.github.toolsets'", toolset)
+		}
+	}
+
 	return nil
 }
 
@@ -337,6 +337,81 @@ func TestValidateGitHubToolConfig(t *testing.T) {
 			},
 			shouldError: false,
 		},
+		{
+			name: "github tool pinned by digest is valid",
+			toolsMap: map[string]any{
+				"github": map[string]any{
+					"version": "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				},
+			},
+			shouldError: false,
+		},
+		{
+			name: "github tool with truncated digest is invalid",
+			toolsMap: map[string]any{
+				"github": map[string]any{
+					"version": "sha256:0123456789abcdef",
+				},
+			},
+			shouldError: true,
+			errorMsg:    "'tools.github.version' image digest 'sha256:0123456789abcdef' must be 'sha256:' followed by 64 lowercase hex characters",
+		},
+		{
+			name: "github tool with enabled read-only toolsets is valid",
+			toolsMap: map[string]any{
+				"github": map[string]any{
+					"read-only":          false,
+					"toolsets":           []any{"repos", "issues", "pull_requests"},
+					"read-only-toolsets": []any{"repos", "pull_requests"},
+				},
+			},
+			shouldError: false,
+		},
+		{
+			name: "github tool with read-only toolsets from default toolsets is valid",
+			toolsMap: map[string]any{
+				"github": map[string]any{
+					"read-only":          false,
+					"read-only-toolsets": []any{"repos"},
+				},
+			},
+			shouldError: false,
+		},
+		{
+			name: "github tool with unknown read-only toolset is invalid",
+			toolsMap: map[string]any{
+				"github": map[string]any{
+					"read-only":          false,
+					"toolsets":           []any{"repos", "issues"},
+					"read-only-toolsets": []any{"code"},
+				},
+			},
+			shouldError: true,
+			errorMsg:    `unknown toolset "code" in 'tools.github.read-only-toolsets'. Valid toolsets: actions, code_security,`,
+		},
+		{
+			name: "github tool with read-only toolset that is not enabled is invalid",
+			toolsMap: map[string]any{
+				"github": map[string]any{
+					"read-only":          false,
+					"toolsets":           []any{"issues"},
+					"read-only-toolsets": []any{"repos"},
+				},
+			},
+			shouldError: true,
+			errorMsg:    `toolset "repos" in 'tools.github.read-only-toolsets' is not enabled`,
+		},
+		{
+			name: "github tool with read-only toolsets in read-only mode is invalid",
+			toolsMap: map[string]any{
+				"github": map[string]any{
+					"toolsets":           []any{"repos", "issues"},
+					"read-only-toolsets": []any{"repos"},
+				},
+			},
+			shouldError: true,
+			errorMsg:    "'tools.github.read-only-toolsets' requires 'tools.github.read-only: false'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -621,6 +621,12 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.CreateAgentSessions != nil {
 		tools = append(tools, "create_agent_session")
 	}
+	if safeOutputs.CreateGists != nil {
+		tools = append(tools, "create_gist")
+	}
+	if safeOutputs.CreateReleases != nil {
+		tools = append(tools, "create_release")
+	}
 	if safeOutputs.CreatePullRequests != nil {
 		tools = append(tools, "create_pull_request")
 	}
@@ -639,6 +645,9 @@ func buildSafeOutputsSections(safeOutputs *SafeOutputsConfig) []PromptSection {
 	if safeOutputs.SubmitPullRequestReview != nil {
 		tools = append(tools, "submit_pull_request_review")
 	}
+	if safeOutputs.CreatePullRequestReviews != nil {
+		tools = append(tools, "create_pull_request_review")
+	}
 	if safeOutputs.ReplyToPullRequestReviewComment != nil {
 		tools = append(tools, "reply_to_pull_request_review_comment")
 	}
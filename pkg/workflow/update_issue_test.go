@@ -5,6 +5,7 @@ package workflow
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/github/gh-aw/pkg/testutil"
@@ -421,3 +422,76 @@ This workflow tests the update-issue title-prefix configuration.
 		t.Fatalf("Expected title-prefix to be '[bot] ', got '%s'", workflowData.SafeOutputs.UpdateIssues.TitlePrefix)
 	}
 }
+
+func TestUpdateIssueAllowedFields(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "output-update-issue-allowed-fields-test")
+
+	testContent := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+  pull-requests: read
+engine: copilot
+safe-outputs:
+  update-issue:
+    status:
+    allowed-fields: [body]
+---
+
+# Test Update Issue Allowed Fields
+`
+
+	testFile := filepath.Join(tmpDir, "test-update-issue-allowed-fields.md")
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Unexpected error compiling workflow with allowed-fields: %v", err)
+	}
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "test-update-issue-allowed-fields.lock.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(lockContent), `\"allowed_fields\":[\"body\"]`) {
+		t.Error("Expected allowed_fields in the update_issue handler config")
+	}
+	if !strings.Contains(string(lockContent), "Only these fields can be changed: body.") {
+		t.Error("Expected the update_issue tool description to list the allowed fields")
+	}
+}
+
+func TestUpdateIssueAllowedFieldsRejectsUnknownField(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "output-update-issue-allowed-fields-invalid-test")
+
+	testContent := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+  pull-requests: read
+engine: copilot
+safe-outputs:
+  update-issue:
+    allowed-fields: [body, state]
+---
+
+# Test Update Issue Allowed Fields
+`
+
+	testFile := filepath.Join(tmpDir, "test-update-issue-allowed-fields-invalid.md")
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewCompiler().CompileWorkflow(testFile); err == nil {
+		t.Fatal("Expected an error for an unknown field in allowed-fields")
+	}
+}
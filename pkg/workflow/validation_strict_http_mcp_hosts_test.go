@@ -0,0 +1,77 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateStrictHTTPMCPHosts tests that HTTP MCP server hosts must be covered by network.allowed
+func TestValidateStrictHTTPMCPHosts(t *testing.T) {
+	tests := []struct {
+		name        string
+		server      map[string]any
+		allowed     []string
+		expectError bool
+	}{
+		{
+			name:    "host listed in network.allowed",
+			server:  map[string]any{"type": "http", "url": "https://mcp.example.com/mcp"},
+			allowed: []string{"defaults", "mcp.example.com"},
+		},
+		{
+			name:    "host covered by a wildcard entry",
+			server:  map[string]any{"url": "https://mcp.example.com:8443/mcp"},
+			allowed: []string{"*.example.com"},
+		},
+		{
+			name:    "host covered by an ecosystem identifier",
+			server:  map[string]any{"type": "http", "url": "https://pypi.org/mcp"},
+			allowed: []string{"python"},
+		},
+		{
+			name: "host listed in the server network block",
+			server: map[string]any{
+				"type":    "http",
+				"url":     "https://mcp.example.com/mcp",
+				"network": map[string]any{"allowed": []any{"mcp.example.com"}},
+			},
+			allowed: []string{"defaults"},
+		},
+		{
+			name:        "host missing from network.allowed",
+			server:      map[string]any{"type": "http", "url": "https://mcp.example.com/mcp"},
+			allowed:     []string{"defaults"},
+			expectError: true,
+		},
+		{
+			name:    "URL built from an expression",
+			server:  map[string]any{"type": "http", "url": "${{ secrets.MCP_URL }}"},
+			allowed: []string{"defaults"},
+		},
+		{
+			name:    "stdio servers are not checked",
+			server:  map[string]any{"container": "example/mcp"},
+			allowed: []string{"defaults"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"mcp-servers": map[string]any{"remote": tt.server},
+			}
+			err := NewCompiler().validateStrictHTTPMCPHosts(frontmatter, &NetworkPermissions{Allowed: tt.allowed})
+			if !tt.expectError {
+				assert.NoError(t, err, "HTTP MCP host should be accepted")
+				return
+			}
+			require.Error(t, err, "HTTP MCP host should be rejected")
+			assert.Contains(t, err.Error(), "HTTP MCP server 'remote' uses host 'mcp.example.com'", "Error should name the server and host")
+			assert.Contains(t, err.Error(), "network.allowed", "Error should point to the allowlist")
+		})
+	}
+}
@@ -87,7 +87,7 @@ func TestValidateStrictMCPNetwork_ContainerWithoutNetwork(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for container without top-level network configuration, got nil")
 	}
-	expectedMsg := "strict mode: custom MCP server 'my-server' with container must have top-level network configuration for security"
+	expectedMsg := "strict mode: custom MCP server 'my-server' with container must have network configuration for security"
 	if err != nil && !strings.Contains(err.Error(), expectedMsg) {
 		t.Errorf("Expected error message to contain %q, got: %q", expectedMsg, err.Error())
 	}
@@ -389,7 +389,7 @@ func TestValidateStrictMCPNetwork_ContainerWithNoNetworkConfig(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for container without any network configuration, got nil")
 	}
-	expectedMsg := "strict mode: custom MCP server 'my-server' with container must have top-level network configuration for security"
+	expectedMsg := "strict mode: custom MCP server 'my-server' with container must have network configuration for security"
 	if err != nil && !strings.Contains(err.Error(), expectedMsg) {
 		t.Errorf("Expected error message to contain %q, got: %q", expectedMsg, err.Error())
 	}
@@ -417,7 +417,7 @@ func TestValidateStrictMCPNetwork_ContainerWithEmptyTopLevelNetwork(t *testing.T
 	if err == nil {
 		t.Error("Expected error for container with empty top-level network configuration, got nil")
 	}
-	expectedMsg := "strict mode: custom MCP server 'my-server' with container must have top-level network configuration for security"
+	expectedMsg := "strict mode: custom MCP server 'my-server' with container must have network configuration for security"
 	if err != nil && !strings.Contains(err.Error(), expectedMsg) {
 		t.Errorf("Expected error message to contain %q, got: %q", expectedMsg, err.Error())
 	}
@@ -0,0 +1,171 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractWebFetchDomains(t *testing.T) {
+	tests := []struct {
+		name     string
+		tools    map[string]any
+		expected []string
+	}{
+		{
+			name:     "web-fetch without allowlist",
+			tools:    map[string]any{"web-fetch": nil},
+			expected: []string{},
+		},
+		{
+			name:     "no web-fetch tool",
+			tools:    map[string]any{"edit": nil},
+			expected: []string{},
+		},
+		{
+			name:     "web-fetch with allowlist",
+			tools:    map[string]any{"web-fetch": map[string]any{"allowed": []any{"docs.example.com", "api.example.com"}}},
+			expected: []string{"docs.example.com", "api.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.expected, extractWebFetchDomains(tt.tools))
+		})
+	}
+
+	t.Run("ecosystem identifiers are expanded", func(t *testing.T) {
+		domains := extractWebFetchDomains(map[string]any{"web-fetch": map[string]any{"allowed": []any{"python"}}})
+		assert.Contains(t, domains, "pypi.org")
+	})
+}
+
+func TestParseWebFetchToolAllowlist(t *testing.T) {
+	tools := NewTools(map[string]any{"web-fetch": map[string]any{"allowed": []any{"docs.example.com"}}})
+	require.NotNil(t, tools.WebFetch)
+	assert.Equal(t, []string{"docs.example.com"}, tools.WebFetch.Allowed)
+
+	tools = NewTools(map[string]any{"web-fetch": nil})
+	require.NotNil(t, tools.WebFetch)
+	assert.Empty(t, tools.WebFetch.Allowed)
+}
+
+func TestValidateStrictTools_WebFetch(t *testing.T) {
+	compiler := NewCompiler()
+
+	tests := []struct {
+		name        string
+		webFetch    any
+		expectError bool
+	}{
+		{name: "web-fetch without configuration", webFetch: nil, expectError: true},
+		{name: "web-fetch with empty object", webFetch: map[string]any{}, expectError: true},
+		{name: "web-fetch with empty allowlist", webFetch: map[string]any{"allowed": []any{}}, expectError: true},
+		{name: "web-fetch with allowlist", webFetch: map[string]any{"allowed": []any{"docs.example.com"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := compiler.validateStrictTools(map[string]any{
+				"tools": map[string]any{"web-fetch": tt.webFetch},
+			})
+			if tt.expectError {
+				require.Error(t, err, "Expected web-fetch to be rejected in strict mode")
+				assert.Contains(t, err.Error(), "web-fetch tool requires a domain allowlist")
+				return
+			}
+			assert.NoError(t, err, "Expected web-fetch with allowlist to be accepted in strict mode")
+		})
+	}
+}
+
+func TestCompileWorkflowWithWebFetchAllowlist(t *testing.T) {
+	tests := []struct {
+		name     string
+		engine   string
+		expected []string
+	}{
+		{
+			name:     "copilot adds domains to the firewall",
+			engine:   "copilot",
+			expected: []string{"docs.example.com"},
+		},
+		{
+			name:     "claude restricts WebFetch to the domains",
+			engine:   "claude",
+			expected: []string{"docs.example.com", "WebFetch(domain:docs.example.com)"},
+		},
+		{
+			name:     "codex adds domains of the fetch MCP server to the firewall",
+			engine:   "codex",
+			expected: []string{"docs.example.com", "mcp/fetch"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "web-fetch-allowlist-test")
+			testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: ` + tt.engine + `
+tools:
+  web-fetch:
+    allowed:
+      - docs.example.com
+---
+
+# Documentation Research
+
+Read the documentation.
+`
+			testFile := filepath.Join(tmpDir, "web-fetch.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+			require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with web-fetch allowlist should compile in strict mode")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			require.NoError(t, err, "Should read lock file")
+			for _, expected := range tt.expected {
+				assert.Contains(t, string(lockContent), expected)
+			}
+			assert.NotContains(t, string(lockContent), "WebFetch,", "Unrestricted WebFetch should not be allowed")
+		})
+	}
+}
+
+func TestCompileWorkflowWithWebFetchWithoutAllowlist(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "web-fetch-allowlist-test")
+	testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+tools:
+  web-fetch:
+---
+
+# Research
+
+Research the web.
+`
+	testFile := filepath.Join(tmpDir, "web-fetch.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644), "Should write test file")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Strict mode should reject web-fetch without an allowlist")
+	assert.Contains(t, err.Error(), "web-fetch tool requires a domain allowlist")
+
+	nonStrictContent := "---\nstrict: false\n" + testContent[len("---\n"):]
+	require.NoError(t, os.WriteFile(testFile, []byte(nonStrictContent), 0644), "Should write test file")
+	assert.NoError(t, NewCompiler().CompileWorkflow(testFile), "Non-strict mode should accept web-fetch without an allowlist")
+}
@@ -0,0 +1,272 @@
+// This file compiles the typed top-level inputs section into workflow_dispatch inputs.
+//
+// # Workflow Inputs
+//
+// Main workflows can declare their manual-run parameters at the top level instead of nesting
+// them under on.workflow_dispatch.inputs:
+//
+//	inputs:
+//	  environment:
+//	    type: choice
+//	    options: [staging, production]
+//	    default: staging
+//	    description: Where to deploy
+//	  dry-run:
+//	    type: boolean
+//	    default: true
+//
+// preprocessWorkflowInputs validates each input (type, default and choice options) and merges
+// it into on.workflow_dispatch.inputs, adding the workflow_dispatch trigger when needed.
+// validateWorkflowInputReferences then checks that every ${{ inputs.<name> }} and
+// ${{ github.event.inputs.<name> }} placeholder in the prompt names a declared input.
+//
+// In shared workflows the same section declares import parameters and is left untouched.
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/sliceutil"
+)
+
+var workflowInputsLog = logger.New("workflow:workflow_inputs")
+
+// workflowInputTypes lists the input types supported by workflow_dispatch
+var workflowInputTypes = []string{"string", "boolean", "choice", "number", "environment"}
+
+// workflowInputFields lists the fields allowed in an input definition
+var workflowInputFields = []string{"description", "required", "default", "type", "options"}
+
+// workflowInputNamePattern matches valid workflow_dispatch input names
+var workflowInputNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// workflowInputReferencePattern matches inputs.<name> and github.event.inputs.<name> inside an
+// expression. The leading guard skips other contexts such as github.aw.inputs.<name>.
+var workflowInputReferencePattern = regexp.MustCompile(`(?:^|[^\w.-])(?:github\.event\.)?inputs\.([a-zA-Z0-9_-]+)`)
+
+// preprocessWorkflowInputs validates the top-level inputs section of a main workflow and merges
+// it into on.workflow_dispatch.inputs
+func (c *Compiler) preprocessWorkflowInputs(frontmatter map[string]any) error {
+	inputsValue, hasInputs := frontmatter["inputs"]
+	if !hasInputs {
+		return nil
+	}
+	onValue, hasOn := frontmatter["on"]
+	if !hasOn {
+		// Shared workflows use inputs for import parameters
+		return nil
+	}
+
+	inputsMap, ok := inputsValue.(map[string]any)
+	if !ok {
+		return NewValidationError("inputs", fmt.Sprintf("%v", inputsValue), "inputs must be a map of input names to definitions", "Declare each input as 'name: {type: string, description: ...}'")
+	}
+	workflowInputsLog.Printf("Compiling %d typed workflow inputs", len(inputsMap))
+
+	names := sliceutil.MapToSlice(inputsMap)
+	slices.Sort(names)
+	dispatchInputs := make(map[string]any, len(inputsMap))
+	for _, name := range names {
+		input, err := validateWorkflowInput(name, inputsMap[name])
+		if err != nil {
+			return err
+		}
+		dispatchInputs[name] = input
+	}
+
+	onMap, err := workflowInputsOnMap(onValue)
+	if err != nil {
+		return err
+	}
+
+	dispatchMap, _ := onMap["workflow_dispatch"].(map[string]any)
+	if dispatchMap == nil {
+		dispatchMap = make(map[string]any)
+	}
+	existing, _ := dispatchMap["inputs"].(map[string]any)
+	if existing == nil {
+		existing = make(map[string]any)
+	}
+	for _, name := range names {
+		if _, duplicate := existing[name]; duplicate {
+			return NewValidationError(
+				"inputs."+name,
+				name,
+				fmt.Sprintf("input '%s' is also declared under on.workflow_dispatch.inputs", name),
+				"Declare the input in only one place, preferably the top-level 'inputs' section",
+			)
+		}
+		existing[name] = dispatchInputs[name]
+	}
+	dispatchMap["inputs"] = existing
+	onMap["workflow_dispatch"] = dispatchMap
+	frontmatter["on"] = onMap
+	return nil
+}
+
+// workflowInputsOnMap returns the on section as a map, expanding the string and list forms
+// (on: push, on: [push, issues]) so that workflow_dispatch can be added to it. Expanded events
+// get an empty configuration, which GitHub Actions treats like the bare event name.
+func workflowInputsOnMap(onValue any) (map[string]any, error) {
+	switch on := onValue.(type) {
+	case map[string]any:
+		return on, nil
+	case string:
+		return map[string]any{on: map[string]any{}}, nil
+	case []any:
+		onMap := make(map[string]any, len(on))
+		for _, event := range on {
+			eventName, ok := event.(string)
+			if !ok {
+				return nil, fmt.Errorf("cannot add workflow inputs: 'on' list entries must be event names, got %v", event)
+			}
+			onMap[eventName] = map[string]any{}
+		}
+		return onMap, nil
+	default:
+		return nil, fmt.Errorf("cannot add workflow inputs: unsupported 'on' value %v", onValue)
+	}
+}
+
+// validateWorkflowInput validates a single input definition and returns it in workflow_dispatch form
+func validateWorkflowInput(name string, value any) (map[string]any, error) {
+	field := "inputs." + name
+	if !workflowInputNamePattern.MatchString(name) {
+		return nil, NewValidationError(field, name, "invalid input name", "Input names must start with a letter or '_' and contain only letters, digits, '_' or '-'")
+	}
+
+	config, ok := value.(map[string]any)
+	if !ok {
+		if value != nil {
+			return nil, NewValidationError(field, fmt.Sprintf("%v", value), "input definition must be a map", "Example: '"+name+": {type: string, description: ...}'")
+		}
+		config = map[string]any{}
+	}
+	for key := range config {
+		if !slices.Contains(workflowInputFields, key) {
+			return nil, NewValidationError(field+"."+key, key, "unknown input field", "Use one of: "+strings.Join(workflowInputFields, ", "))
+		}
+	}
+
+	input := make(map[string]any, len(config))
+	if description, hasDescription := config["description"]; hasDescription {
+		descriptionStr, ok := description.(string)
+		if !ok {
+			return nil, NewValidationError(field+".description", fmt.Sprintf("%v", description), "description must be a string", "Quote the description")
+		}
+		input["description"] = descriptionStr
+	}
+	if required, hasRequired := config["required"]; hasRequired {
+		requiredBool, ok := required.(bool)
+		if !ok {
+			return nil, NewValidationError(field+".required", fmt.Sprintf("%v", required), "required must be true or false", "Use 'required: true' or 'required: false'")
+		}
+		input["required"] = requiredBool
+	}
+
+	inputType := "string"
+	if rawType, hasType := config["type"]; hasType {
+		typeStr, ok := rawType.(string)
+		if !ok || !slices.Contains(workflowInputTypes, typeStr) {
+			return nil, NewValidationError(field+".type", fmt.Sprintf("%v", rawType), "unknown input type", "Use one of: "+strings.Join(workflowInputTypes, ", "))
+		}
+		inputType = typeStr
+	}
+	input["type"] = inputType
+
+	var options []string
+	if rawOptions, hasOptions := config["options"]; hasOptions {
+		if inputType != "choice" {
+			return nil, NewValidationError(field+".options", name, "options are only supported for choice inputs", "Set 'type: choice' or remove 'options'")
+		}
+		optionList, ok := rawOptions.([]any)
+		if !ok {
+			return nil, NewValidationError(field+".options", fmt.Sprintf("%v", rawOptions), "options must be a list of strings", "Example: 'options: [staging, production]'")
+		}
+		for _, option := range optionList {
+			optionStr, ok := option.(string)
+			if !ok || optionStr == "" {
+				return nil, NewValidationError(field+".options", fmt.Sprintf("%v", option), "options must be non-empty strings", "Quote option values such as \"1\" or \"true\"")
+			}
+			if slices.Contains(options, optionStr) {
+				return nil, NewValidationError(field+".options", optionStr, "duplicate option", "List each option once")
+			}
+			options = append(options, optionStr)
+		}
+		input["options"] = options
+	}
+	if inputType == "choice" && len(options) == 0 {
+		return nil, NewValidationError(field+".options", name, "choice inputs require at least one option", "Example: 'options: [staging, production]'")
+	}
+
+	if def, hasDefault := config["default"]; hasDefault && def != nil {
+		if err := validateWorkflowInputDefault(field, inputType, def, options); err != nil {
+			return nil, err
+		}
+		input["default"] = def
+	}
+
+	workflowInputsLog.Printf("Validated input %s: type=%s, options=%d", name, inputType, len(options))
+	return input, nil
+}
+
+// validateWorkflowInputDefault checks that a default value matches the input type
+func validateWorkflowInputDefault(field, inputType string, def any, options []string) error {
+	value := fmt.Sprintf("%v", def)
+	switch inputType {
+	case "boolean":
+		if _, ok := def.(bool); !ok {
+			return NewValidationError(field+".default", value, "default of a boolean input must be true or false", "Use 'default: true' or 'default: false'")
+		}
+	case "number":
+		switch def.(type) {
+		case int, int64, uint64, float64:
+		default:
+			return NewValidationError(field+".default", value, "default of a number input must be a number", "Remove the quotes around the number")
+		}
+	case "choice":
+		defStr, ok := def.(string)
+		if !ok || !slices.Contains(options, defStr) {
+			return NewValidationError(field+".default", value, "default of a choice input must be one of its options", "Use one of: "+strings.Join(options, ", "))
+		}
+	default:
+		if _, ok := def.(string); !ok {
+			return NewValidationError(field+".default", value, fmt.Sprintf("default of a %s input must be a string", inputType), "Quote the default value")
+		}
+	}
+	return nil
+}
+
+// validateWorkflowInputReferences checks that the input placeholders in the prompt refer to
+// inputs the workflow declares. It only runs for workflows with a typed inputs section, since
+// other workflows may receive inputs that are not visible to the compiler.
+func validateWorkflowInputReferences(markdown string, frontmatter map[string]any) error {
+	if _, hasInputs := frontmatter["inputs"]; !hasInputs {
+		return nil
+	}
+	declared := getWorkflowInputNames(frontmatter)
+
+	for _, match := range ExpressionPatternDotAll.FindAllStringSubmatch(markdown, -1) {
+		for _, ref := range workflowInputReferencePattern.FindAllStringSubmatch(match[1], -1) {
+			name := ref[1]
+			if declared[name] {
+				continue
+			}
+			workflowInputsLog.Printf("Prompt references undeclared input %s", name)
+			names := sliceutil.MapToSlice(declared)
+			slices.Sort(names)
+			return NewValidationError(
+				"inputs",
+				match[0],
+				fmt.Sprintf("the prompt references input '%s', which is not declared", name),
+				"Declare '"+name+"' under 'inputs', or use one of the declared inputs: "+strings.Join(names, ", "),
+			)
+		}
+	}
+	return nil
+}
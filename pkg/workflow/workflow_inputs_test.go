@@ -0,0 +1,158 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compileWorkflowInputsTest compiles a workflow with the given frontmatter and prompt and
+// returns the on section of the lock file
+func compileWorkflowInputsTest(t *testing.T, frontmatter, prompt string) (map[string]any, error) {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "workflow-inputs-test")
+	testFile := filepath.Join(tmpDir, "inputs.md")
+	content := "---\n" + frontmatter + "permissions:\n  contents: read\nengine: copilot\n---\n\n# Deploy\n\n" + prompt + "\n"
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	if err := NewCompiler().CompileWorkflow(testFile); err != nil {
+		return nil, err
+	}
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err)
+
+	var lock map[string]any
+	require.NoError(t, yaml.Unmarshal(lockContent, &lock))
+	on, ok := lock["on"].(map[string]any)
+	require.True(t, ok, "lock file should have an on section")
+	return on, nil
+}
+
+func TestCompileWorkflowInputs(t *testing.T) {
+	on, err := compileWorkflowInputsTest(t, `on: issues
+inputs:
+  environment:
+    type: choice
+    options: [staging, production]
+    default: staging
+    description: Where to deploy
+  dry-run:
+    type: boolean
+    default: true
+  retries:
+    type: number
+    required: true
+  reason:
+`, "Deploy to ${{ inputs.environment }} (dry run: ${{ github.event.inputs.dry-run }}).")
+	require.NoError(t, err)
+
+	assert.Contains(t, on, "issues", "existing triggers should be kept")
+	dispatch, ok := on["workflow_dispatch"].(map[string]any)
+	require.True(t, ok, "workflow_dispatch should be added")
+	inputs, ok := dispatch["inputs"].(map[string]any)
+	require.True(t, ok, "workflow_dispatch should have inputs")
+	require.Len(t, inputs, 4)
+
+	assert.Equal(t, map[string]any{
+		"type":        "choice",
+		"options":     []any{"staging", "production"},
+		"default":     "staging",
+		"description": "Where to deploy",
+	}, inputs["environment"])
+	assert.Equal(t, map[string]any{"type": "boolean", "default": true}, inputs["dry-run"])
+	assert.Equal(t, map[string]any{"type": "number", "required": true}, inputs["retries"])
+	assert.Equal(t, map[string]any{"type": "string"}, inputs["reason"], "inputs default to the string type")
+}
+
+func TestCompileWorkflowInputsMergesDispatchInputs(t *testing.T) {
+	on, err := compileWorkflowInputsTest(t, `on:
+  workflow_dispatch:
+    inputs:
+      target:
+        type: string
+inputs:
+  verbose:
+    type: boolean
+`, "Run against ${{ inputs.target }}.")
+	require.NoError(t, err)
+
+	inputs := on["workflow_dispatch"].(map[string]any)["inputs"].(map[string]any)
+	assert.Contains(t, inputs, "target")
+	assert.Contains(t, inputs, "verbose")
+}
+
+func TestCompileWorkflowInputsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		prompt      string
+		errText     string
+	}{
+		{
+			name:        "unknown input type",
+			frontmatter: "on: issues\ninputs:\n  count:\n    type: integer\n",
+			errText:     "unknown input type",
+		},
+		{
+			name:        "choice without options",
+			frontmatter: "on: issues\ninputs:\n  env:\n    type: choice\n",
+			errText:     "choice inputs require at least one option",
+		},
+		{
+			name:        "default not in options",
+			frontmatter: "on: issues\ninputs:\n  env:\n    type: choice\n    options: [staging, production]\n    default: dev\n",
+			errText:     "must be one of its options",
+		},
+		{
+			name:        "options on a string input",
+			frontmatter: "on: issues\ninputs:\n  env:\n    options: [staging]\n",
+			errText:     "options are only supported for choice inputs",
+		},
+		{
+			name:        "boolean default of the wrong type",
+			frontmatter: "on: issues\ninputs:\n  debug:\n    type: boolean\n    default: \"yes\"\n",
+			errText:     "must be true or false",
+		},
+		{
+			name:        "input declared twice",
+			frontmatter: "on:\n  workflow_dispatch:\n    inputs:\n      env:\n        type: string\ninputs:\n  env:\n    type: string\n",
+			errText:     "also declared under on.workflow_dispatch.inputs",
+		},
+		{
+			name:        "prompt references an undeclared input",
+			frontmatter: "on: issues\ninputs:\n  environment:\n    type: string\n",
+			prompt:      "Deploy to ${{ inputs.enviroment }}.",
+			errText:     "the prompt references input 'enviroment', which is not declared",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileWorkflowInputsTest(t, tt.frontmatter, tt.prompt)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errText)
+		})
+	}
+}
+
+func TestValidateWorkflowInputReferences(t *testing.T) {
+	frontmatter := map[string]any{
+		"inputs": map[string]any{"env": nil},
+		"on": map[string]any{
+			"workflow_dispatch": map[string]any{"inputs": map[string]any{"env": map[string]any{}}},
+		},
+	}
+
+	assert.NoError(t, validateWorkflowInputReferences("Use ${{ inputs.env }} and ${{ github.event.inputs.env }}", frontmatter))
+	assert.NoError(t, validateWorkflowInputReferences("Shared ${{ github.aw.inputs.count }} is not a dispatch input", frontmatter))
+	require.Error(t, validateWorkflowInputReferences("Use ${{ inputs.env || inputs.other }}", frontmatter))
+	assert.NoError(t, validateWorkflowInputReferences("Use ${{ inputs.other }}", map[string]any{}), "workflows without typed inputs are not checked")
+}